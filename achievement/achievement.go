@@ -0,0 +1,115 @@
+package achievement
+
+import (
+	"slices"
+	"time"
+)
+
+// Game is a completed booking a member organized or played.
+type Game struct {
+	Username string
+	PlayedAt time.Time
+}
+
+// Badge is an achievement awarded to a member, Title being the name the
+// club gives it.
+type Badge struct {
+	Username  string    `json:"username"`
+	Code      string    `json:"code"`
+	Title     string    `json:"title" db:"-"`
+	AwardedAt time.Time `json:"awardedAt"`
+}
+
+// Progress is how much a member played: the completed bookings, and the
+// consecutive weeks with at least one of them.
+type Progress struct {
+	Games int `json:"games"`
+	// CurrentStreak still counts the last week until the current one ends
+	CurrentStreak int `json:"currentStreak"`
+	LongestStreak int `json:"longestStreak"`
+}
+
+type milestone struct {
+	code    string
+	title   string
+	reached func(progress Progress) bool
+}
+
+// milestones are the badges awarded, in the order they are announced.
+var milestones = []milestone{
+	{"games-10", "10 parties jouées", func(p Progress) bool { return p.Games >= 10 }},
+	{"games-50", "50 parties jouées", func(p Progress) bool { return p.Games >= 50 }},
+	{"games-100", "100 parties jouées", func(p Progress) bool { return p.Games >= 100 }},
+	{"streak-4", "4 semaines de jeu d'affilée", func(p Progress) bool { return p.LongestStreak >= 4 }},
+	{"streak-12", "12 semaines de jeu d'affilée", func(p Progress) bool { return p.LongestStreak >= 12 }},
+}
+
+// Compute sums up the games played at the given times, the weeks starting
+// on Monday in loc.
+func Compute(played []time.Time, now time.Time, loc *time.Location) Progress {
+	progress := Progress{Games: len(played)}
+
+	weeks := make([]time.Time, 0, len(played))
+
+	for _, at := range played {
+		weeks = append(weeks, weekOf(at, loc))
+	}
+
+	slices.SortFunc(weeks, func(a, b time.Time) int { return a.Compare(b) })
+	weeks = slices.Compact(weeks)
+
+	streak := 0
+
+	for i, week := range weeks {
+		if i > 0 && weeks[i-1].AddDate(0, 0, 7).Equal(week) {
+			streak++
+		} else {
+			streak = 1
+		}
+
+		progress.LongestStreak = max(progress.LongestStreak, streak)
+	}
+
+	if len(weeks) != 0 {
+		current := weekOf(now, loc)
+		last := weeks[len(weeks)-1]
+
+		if last.Equal(current) || last.AddDate(0, 0, 7).Equal(current) {
+			progress.CurrentStreak = streak
+		}
+	}
+
+	return progress
+}
+
+// earned returns the badges of the milestones reached.
+func earned(username string, progress Progress) []Badge {
+	var badges []Badge
+
+	for _, milestone := range milestones {
+		if milestone.reached(progress) {
+			badges = append(badges, Badge{Username: username, Code: milestone.code, Title: milestone.title})
+		}
+	}
+
+	return badges
+}
+
+// titled fills in the title of the badge from its milestone.
+func titled(badge Badge) Badge {
+	for _, milestone := range milestones {
+		if milestone.code == badge.Code {
+			badge.Title = milestone.title
+		}
+	}
+
+	return badge
+}
+
+// weekOf returns the Monday starting the week of at, in loc.
+func weekOf(at time.Time, loc *time.Location) time.Time {
+	at = at.In(loc)
+	offset := (int(at.Weekday()) + 6) % 7
+
+	return time.Date(at.Year(), at.Month(), at.Day()-offset, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,105 @@
+package achievement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// GetGames lists the accepted bookings started before the given time, once
+// per member organizing or playing them, those of username only unless it
+// is empty.
+func (r *Repository) GetGames(ctx context.Context, username string, before time.Time) ([]Game, error) {
+	sql := `
+		SELECT member AS username, "dateTime" AS "playedAt"
+		FROM (
+			SELECT DISTINCT booking.id, lower(member) AS member, booking."dateTime"
+			FROM "game-table-booking".booking, unnest(array_append(booking.players, booking.username)) AS member
+			WHERE booking.status = 'accepted'
+			AND booking."dateTime" < $2
+			AND booking."deletedAt" IS NULL
+			AND member <> ''
+		) AS played
+		WHERE $1 = '' OR member = $1
+		ORDER BY "playedAt", username;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, username, before)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch games: %w", err)
+	}
+
+	games, err := pgx.CollectRows(rows, pgx.RowToStructByName[Game])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning game rows: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetBadges lists the badges awarded to username, oldest first.
+func (r *Repository) GetBadges(ctx context.Context, username string) ([]Badge, error) {
+	sql := `
+		SELECT username, badge AS code, "awardedAt"
+		FROM "game-table-booking".achievement
+		WHERE username = $1
+		ORDER BY "awardedAt", badge;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch badges of '%v': %w", username, err)
+	}
+
+	badges, err := pgx.CollectRows(rows, pgx.RowToStructByName[Badge])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning badge rows: %w", err)
+	}
+
+	return badges, nil
+}
+
+// AwardBadges stores the badges and returns those not awarded before.
+func (r *Repository) AwardBadges(ctx context.Context, badges []Badge) ([]Badge, error) {
+	usernames := make([]string, len(badges))
+	codes := make([]string, len(badges))
+
+	for i, badge := range badges {
+		usernames[i] = badge.Username
+		codes[i] = badge.Code
+	}
+
+	sql := `
+		INSERT INTO "game-table-booking".achievement(username, badge)
+		SELECT * FROM unnest($1::text[], $2::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING username, badge AS code, "awardedAt";
+	`
+
+	rows, err := r.conn.Query(ctx, sql, usernames, codes)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to award badges: %w", err)
+	}
+
+	awarded, err := pgx.CollectRows(rows, pgx.RowToStructByName[Badge])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning badge rows: %w", err)
+	}
+
+	return awarded, nil
+}
@@ -0,0 +1,157 @@
+package achievement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Discord accepts at most 25 fields per embed
+const maxFields = 25
+
+type AchievementRepository interface {
+	GetGames(ctx context.Context, username string, before time.Time) ([]Game, error)
+	GetBadges(ctx context.Context, username string) ([]Badge, error)
+	AwardBadges(ctx context.Context, badges []Badge) ([]Badge, error)
+}
+
+type MessageSender interface {
+	SendMessage(ctx context.Context, channelID string, message discord.Message) error
+}
+
+// Achievements is the progress of a member and the badges awarded to them.
+type Achievements struct {
+	Username string `json:"username"`
+	Progress
+	Badges []Badge `json:"badges"`
+}
+
+type Service struct {
+	repo      AchievementRepository
+	sender    MessageSender
+	channelID string
+	// channel overrides channelID when set
+	channel func(ctx context.Context) string
+	// location is the guild's time zone, the weeks of the streaks start on
+	// Monday in it
+	location *time.Location
+}
+
+type ServiceOption func(*Service)
+
+// WithChannelLookup announces the badges in the channel lookup returns,
+// rather than the one given to NewService, for the admins to change it
+// without a redeploy.
+func WithChannelLookup(lookup func(ctx context.Context) string) ServiceOption {
+	return func(s *Service) {
+		s.channel = lookup
+	}
+}
+
+func NewService(repo AchievementRepository, sender MessageSender, channelID string, loc *time.Location, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, sender: sender, channelID: channelID, location: loc}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) channelOf(ctx context.Context) string {
+	if s.channel != nil {
+		return s.channel(ctx)
+	}
+
+	return s.channelID
+}
+
+// GetAchievements returns the progress of the member with the username and
+// the badges awarded so far.
+func (s *Service) GetAchievements(ctx context.Context, username string) (Achievements, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	now := time.Now()
+
+	games, err := s.repo.GetGames(ctx, username, now)
+
+	if err != nil {
+		return Achievements{}, err
+	}
+
+	badges, err := s.repo.GetBadges(ctx, username)
+
+	if err != nil {
+		return Achievements{}, err
+	}
+
+	for i := range badges {
+		badges[i] = titled(badges[i])
+	}
+
+	return Achievements{Username: username, Progress: Compute(playedAt(games), now, s.location), Badges: badges}, nil
+}
+
+// AwardAchievements awards the badges of the milestones the members reached
+// since the last run, and announces them on the Discord channel.
+func (s *Service) AwardAchievements(ctx context.Context) error {
+	now := time.Now()
+	games, err := s.repo.GetGames(ctx, "", now)
+
+	if err != nil {
+		return err
+	}
+
+	var usernames []string
+	played := map[string][]Game{}
+
+	for _, game := range games {
+		if _, ok := played[game.Username]; !ok {
+			usernames = append(usernames, game.Username)
+		}
+
+		played[game.Username] = append(played[game.Username], game)
+	}
+
+	var badges []Badge
+
+	for _, username := range usernames {
+		badges = append(badges, earned(username, Compute(playedAt(played[username]), now, s.location))...)
+	}
+
+	if len(badges) == 0 {
+		return nil
+	}
+
+	awarded, err := s.repo.AwardBadges(ctx, badges)
+
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(awarded); i += maxFields {
+		embed := discord.Embed{Type: "rich", Title: "Nouveaux succès :medal:"}
+
+		for _, badge := range awarded[i:min(i+maxFields, len(awarded))] {
+			embed.Fields = append(embed.Fields, discord.EmbedField{Name: badge.Username, Value: titled(badge).Title})
+		}
+
+		if err := s.sender.SendMessage(ctx, s.channelOf(ctx), discord.Message{Embeds: []discord.Embed{embed}}); err != nil {
+			return fmt.Errorf("failed to announce achievements: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func playedAt(games []Game) []time.Time {
+	times := make([]time.Time, len(games))
+
+	for i, game := range games {
+		times[i] = game.PlayedAt
+	}
+
+	return times
+}
@@ -0,0 +1,159 @@
+package achievement_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/achievement"
+	achievement_mocks "github.com/hanksha/tbz-booking-system-backend/achievement/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingSender struct {
+	messages []discord.Message
+	err      error
+}
+
+func (s *recordingSender) SendMessage(ctx context.Context, channelID string, message discord.Message) error {
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+// friday is the 6th of March 2026, a Friday
+var friday = time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+// weeksBefore returns the Friday evenings of the weeks before friday.
+func weeksBefore(weeks ...int) []time.Time {
+	times := make([]time.Time, len(weeks))
+
+	for i, week := range weeks {
+		times[i] = friday.AddDate(0, 0, -7*week)
+	}
+
+	return times
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("streaks", func(t *testing.T) {
+		// two games the week of friday, then a gap, then three weeks in a row
+		played := append(weeksBefore(0, 0, 1, 2), weeksBefore(5, 6, 7, 8)...)
+
+		progress := achievement.Compute(played, friday.Add(time.Hour), time.UTC)
+
+		require.Equal(t, achievement.Progress{Games: 8, CurrentStreak: 3, LongestStreak: 4}, progress)
+	})
+
+	t.Run("current streak kept until the week ends", func(t *testing.T) {
+		progress := achievement.Compute(weeksBefore(1, 2), friday, time.UTC)
+
+		require.Equal(t, 2, progress.CurrentStreak)
+	})
+
+	t.Run("current streak broken by a week without games", func(t *testing.T) {
+		progress := achievement.Compute(weeksBefore(2, 3), friday, time.UTC)
+
+		require.Equal(t, achievement.Progress{Games: 2, CurrentStreak: 0, LongestStreak: 2}, progress)
+	})
+
+	t.Run("weeks start on Monday in the guild's time zone", func(t *testing.T) {
+		paris, _ := time.LoadLocation("Europe/Paris")
+		// Sunday the 1st at 23:30 in UTC is Monday the 2nd in Paris
+		sunday := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+
+		progress := achievement.Compute([]time.Time{sunday, friday}, friday, paris)
+
+		require.Equal(t, 1, progress.LongestStreak)
+	})
+
+	t.Run("no games", func(t *testing.T) {
+		require.Equal(t, achievement.Progress{}, achievement.Compute(nil, friday, time.UTC))
+	})
+}
+
+func TestGetAchievements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := achievement_mocks.NewMockAchievementRepository(ctrl)
+	svc := achievement.NewService(repo, &recordingSender{}, "channel", time.UTC)
+	awardedAt := friday.AddDate(0, 0, -7)
+
+	repo.EXPECT().GetGames(gomock.Any(), "alice", gomock.Any()).Return([]achievement.Game{
+		{Username: "alice", PlayedAt: friday},
+	}, nil).Times(1)
+	repo.EXPECT().GetBadges(gomock.Any(), "alice").Return([]achievement.Badge{
+		{Username: "alice", Code: "games-10", AwardedAt: awardedAt},
+	}, nil).Times(1)
+
+	achievements, err := svc.GetAchievements(context.Background(), " Alice ")
+
+	require.Nil(t, err)
+	require.Equal(t, "alice", achievements.Username)
+	require.Equal(t, 1, achievements.Games)
+	require.Equal(t, []achievement.Badge{{Username: "alice", Code: "games-10", Title: "10 parties jouées", AwardedAt: awardedAt}}, achievements.Badges)
+}
+
+func TestAwardAchievements(t *testing.T) {
+	games := func(username string, played []time.Time) []achievement.Game {
+		var games []achievement.Game
+
+		for _, at := range played {
+			games = append(games, achievement.Game{Username: username, PlayedAt: at})
+		}
+
+		return games
+	}
+
+	t.Run("announces the new badges", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := achievement_mocks.NewMockAchievementRepository(ctrl)
+		sender := &recordingSender{}
+		svc := achievement.NewService(repo, sender, "channel", time.UTC)
+
+		// alice played ten weeks in a row, bob once
+		played := append(games("alice", weeksBefore(10, 9, 8, 7, 6, 5, 4, 3, 2, 1)), games("bob", weeksBefore(1))...)
+		repo.EXPECT().GetGames(gomock.Any(), "", gomock.Any()).Return(played, nil).Times(1)
+		repo.EXPECT().AwardBadges(gomock.Any(), []achievement.Badge{
+			{Username: "alice", Code: "games-10", Title: "10 parties jouées"},
+			{Username: "alice", Code: "streak-4", Title: "4 semaines de jeu d'affilée"},
+		}).Return([]achievement.Badge{{Username: "alice", Code: "streak-4"}}, nil).Times(1)
+
+		require.Nil(t, svc.AwardAchievements(context.Background()))
+
+		require.Len(t, sender.messages, 1)
+		require.Equal(t, []discord.EmbedField{{Name: "alice", Value: "4 semaines de jeu d'affilée"}}, sender.messages[0].Embeds[0].Fields)
+	})
+
+	t.Run("no milestone reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := achievement_mocks.NewMockAchievementRepository(ctrl)
+		sender := &recordingSender{}
+		svc := achievement.NewService(repo, sender, "channel", time.UTC)
+
+		repo.EXPECT().GetGames(gomock.Any(), "", gomock.Any()).Return(games("bob", weeksBefore(1)), nil).Times(1)
+		repo.EXPECT().AwardBadges(gomock.Any(), gomock.Any()).Times(0)
+
+		require.Nil(t, svc.AwardAchievements(context.Background()))
+		require.Empty(t, sender.messages)
+	})
+
+	t.Run("repository failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := achievement_mocks.NewMockAchievementRepository(ctrl)
+		svc := achievement.NewService(repo, &recordingSender{}, "channel", time.UTC)
+
+		repo.EXPECT().GetGames(gomock.Any(), "", gomock.Any()).Return(nil, errors.New("db down")).Times(1)
+
+		require.Error(t, svc.AwardAchievements(context.Background()))
+	})
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/achievement (interfaces: AchievementRepository)
+//
+// Generated by this command:
+//
+//	mockgen . AchievementRepository
+//
+
+// Package mock_achievement is a generated GoMock package.
+package mock_achievement
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	achievement "github.com/hanksha/tbz-booking-system-backend/achievement"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAchievementRepository is a mock of AchievementRepository interface.
+type MockAchievementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAchievementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAchievementRepositoryMockRecorder is the mock recorder for MockAchievementRepository.
+type MockAchievementRepositoryMockRecorder struct {
+	mock *MockAchievementRepository
+}
+
+// NewMockAchievementRepository creates a new mock instance.
+func NewMockAchievementRepository(ctrl *gomock.Controller) *MockAchievementRepository {
+	mock := &MockAchievementRepository{ctrl: ctrl}
+	mock.recorder = &MockAchievementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAchievementRepository) EXPECT() *MockAchievementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AwardBadges mocks base method.
+func (m *MockAchievementRepository) AwardBadges(ctx context.Context, badges []achievement.Badge) ([]achievement.Badge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardBadges", ctx, badges)
+	ret0, _ := ret[0].([]achievement.Badge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardBadges indicates an expected call of AwardBadges.
+func (mr *MockAchievementRepositoryMockRecorder) AwardBadges(ctx, badges any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardBadges", reflect.TypeOf((*MockAchievementRepository)(nil).AwardBadges), ctx, badges)
+}
+
+// GetBadges mocks base method.
+func (m *MockAchievementRepository) GetBadges(ctx context.Context, username string) ([]achievement.Badge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBadges", ctx, username)
+	ret0, _ := ret[0].([]achievement.Badge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBadges indicates an expected call of GetBadges.
+func (mr *MockAchievementRepositoryMockRecorder) GetBadges(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBadges", reflect.TypeOf((*MockAchievementRepository)(nil).GetBadges), ctx, username)
+}
+
+// GetGames mocks base method.
+func (m *MockAchievementRepository) GetGames(ctx context.Context, username string, before time.Time) ([]achievement.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGames", ctx, username, before)
+	ret0, _ := ret[0].([]achievement.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGames indicates an expected call of GetGames.
+func (mr *MockAchievementRepositoryMockRecorder) GetGames(ctx, username, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGames", reflect.TypeOf((*MockAchievementRepository)(nil).GetGames), ctx, username, before)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/achievement"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type AchievementService interface {
+	GetAchievements(ctx context.Context, username string) (achievement.Achievements, error)
+}
+
+// AchievementHandler serves the streaks, milestones and badges of the
+// members.
+type AchievementHandler struct {
+	service AchievementService
+}
+
+func NewAchievementHandler(service AchievementService) *AchievementHandler {
+	return &AchievementHandler{service: service}
+}
+
+func (h *AchievementHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/:id/achievements", h.Get)
+}
+
+// Get returns the achievements of the user whose Discord username is the id,
+// or of the requesting user for me.
+func (h *AchievementHandler) Get(c *gin.Context) {
+	username := c.Param("id")
+
+	if username == "me" {
+		username = c.MustGet("user").(discord.DiscordUser).Username
+	}
+
+	achievements, err := h.service.GetAchievements(c.Request.Context(), username)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve achievements")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, achievements)
+}
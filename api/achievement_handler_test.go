@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/achievement"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAchievements(t *testing.T) {
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockAchievementService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockAchievementService(ctrl)
+		rg := router.Group("/api/v1/users")
+		rg.Use(setUserInContext(discord.DiscordUser{ID: "1", Username: "alice"}))
+		api.NewAchievementHandler(mockService).Register(rg)
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("of a user", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetAchievements(gomock.Any(), "bob").Return(achievement.Achievements{
+			Username: "bob",
+			Progress: achievement.Progress{Games: 12, CurrentStreak: 2, LongestStreak: 5},
+			Badges:   []achievement.Badge{{Username: "bob", Code: "games-10", Title: "10 parties jouées"}},
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/bob/achievements", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"username":"bob","games":12,"currentStreak":2,"longestStreak":5,"badges":[{"username":"bob","code":"games-10","title":"10 parties jouées","awardedAt":"0001-01-01T00:00:00Z"}]}`, w.Body.String())
+	})
+
+	t.Run("of the requesting user", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetAchievements(gomock.Any(), "alice").Return(achievement.Achievements{Username: "alice"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/me/achievements", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetAchievements(gomock.Any(), "bob").Return(achievement.Achievements{}, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/bob/achievements", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to retrieve achievements"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/audit"
+)
+
+type AuditService interface {
+	GetEntries(ctx context.Context, filter audit.Filter) (audit.Page, error)
+}
+
+type AuditHandler struct {
+	service AuditService
+}
+
+func NewAuditHandler(service AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/audit", AdminOnly(), h.List)
+}
+
+func (h *AuditHandler) List(c *gin.Context) {
+	filter := audit.Filter{
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("targetType"),
+		TargetID:   c.Query("targetId"),
+	}
+
+	var err error
+
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse from")})
+		return
+	}
+
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse to")})
+		return
+	}
+
+	if filter.Limit, err = parseOptionalInt(c.Query("limit")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse limit")})
+		return
+	}
+
+	if filter.Offset, err = parseOptionalInt(c.Query("offset")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse offset")})
+		return
+	}
+
+	page, err := h.service.GetEntries(c.Request.Context(), filter)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve audit entries")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, page)
+}
+
+// parseOptionalTime accepts either a date (2006-01-02) or an RFC3339 timestamp.
+func parseOptionalTime(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func parseOptionalInt(value string) (int, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
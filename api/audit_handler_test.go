@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/audit"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupAuditRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockAuditService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockAuditService(ctrl)
+	handler := api.NewAuditHandler(mockService)
+	rg := router.Group("/api/v1/admin")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestListAudit(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupAuditRouter(t, admin)
+		defer ctrl.Finish()
+
+		filter := audit.Filter{
+			Actor:  "admin",
+			Action: "booking.accept",
+			From:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Limit:  10,
+			Offset: 20,
+		}
+		mockService.EXPECT().GetEntries(gomock.Any(), filter).Return(audit.Page{Entries: []audit.Entry{}, Total: 0, Limit: 10, Offset: 20}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/audit?actor=admin&action=booking.accept&from=2026-01-01&limit=10&offset=20", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"entries":[],"total":0,"limit":10,"offset":20}`, w.Body.String())
+	})
+
+	t.Run("bad limit", func(t *testing.T) {
+		router, ctrl, _ := setupAuditRouter(t, admin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/audit?limit=ten", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse limit"}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupAuditRouter(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/audit", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/ban"
+)
+
+type BanService interface {
+	GetActiveBans(ctx context.Context) ([]ban.Ban, error)
+	BanUser(ctx context.Context, ban ban.Ban) (ban.Ban, error)
+	LiftBan(ctx context.Context, userID string) error
+}
+
+type BanHandler struct {
+	service BanService
+}
+
+func NewBanHandler(service BanService) *BanHandler {
+	return &BanHandler{service: service}
+}
+
+func (h *BanHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("/bans", adminOnly, h.List)
+	rg.POST("/bans", adminOnly, h.Create)
+	rg.DELETE("/bans/:userId", adminOnly, h.Delete)
+}
+
+func (h *BanHandler) List(c *gin.Context) {
+	bans, err := h.service.GetActiveBans(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve bans")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, bans)
+}
+
+func (h *BanHandler) Create(c *gin.Context) {
+	var b ban.Ban
+
+	if !bindJSON(c, &b) {
+		return
+	}
+
+	saved, err := h.service.BanUser(c.Request.Context(), b)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ban.ErrInvalidBan) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to ban user")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+func (h *BanHandler) Delete(c *gin.Context) {
+	userID := c.Param("userId")
+
+	err := h.service.LiftBan(c.Request.Context(), userID)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ban.ErrBanNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "ban not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to lift ban")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "ban lifted")})
+}
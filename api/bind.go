@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why a field of a request body was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	// Suggestions are the values the client may have meant
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+func init() {
+	// name the fields of validation errors as in the JSON body
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+			if name == "-" {
+				return ""
+			}
+
+			return name
+		})
+	}
+}
+
+// bindJSON decodes the request body into obj and validates its binding tags,
+// the elements of a slice one by one. On failure it writes the response and
+// returns false: 400 when the body is not valid JSON, a field has the wrong
+// type or a date is not RFC 3339 with a time zone offset, 422 when fields
+// fail validation, with the offending fields as details.
+func bindJSON(c *gin.Context, obj any) bool {
+	var body []byte
+	err := errors.New("missing request body")
+
+	if c.Request.Body != nil {
+		if body, err = io.ReadAll(c.Request.Body); err == nil {
+			err = json.NewDecoder(bytes.NewReader(body)).Decode(obj)
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+
+	if errors.As(err, &typeErr) {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": translate(c, "failed to parse JSON body"),
+			"details": []FieldError{{
+				Field:   typeErr.Field,
+				Message: fmt.Sprintf("expected %v, got %v", typeErr.Type, typeErr.Value),
+			}},
+		})
+		return false
+	}
+
+	// a date without offset would be ambiguous, time.Time only accepts the
+	// ones with an offset or Z
+	if errors.As(err, &timeErr) {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": translate(c, "failed to parse JSON body"),
+			"details": []FieldError{{
+				Field:   timeField(body, timeErr.Value),
+				Message: fmt.Sprintf("expected an RFC 3339 date with a time zone offset, e.g. 2026-03-12T19:00:00+01:00, got '%v'", timeErr.Value),
+			}},
+		})
+		return false
+	}
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse JSON body")})
+		return false
+	}
+
+	if details := validateBody(obj); len(details) > 0 {
+		c.Error(fmt.Errorf("invalid request body: %v", details))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   translate(c, "invalid request body"),
+			"details": details,
+		})
+		return false
+	}
+
+	return true
+}
+
+// timeField returns the field of the body holding the date value, empty when
+// it is not a field of the top-level object.
+func timeField(body []byte, value string) string {
+	var fields map[string]json.RawMessage
+
+	if json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+
+	for name, raw := range fields {
+		var s string
+
+		if json.Unmarshal(raw, &s) == nil && s == value {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// fieldErrorResponse reports a field rejected by the service, as bindJSON
+// reports the ones failing validation.
+func fieldErrorResponse(c *gin.Context, field string, err error) gin.H {
+	return gin.H{
+		"error":   translate(c, "invalid request body"),
+		"details": []FieldError{{Field: field, Message: err.Error()}},
+	}
+}
+
+func validateBody(obj any) []FieldError {
+	value := reflect.Indirect(reflect.ValueOf(obj))
+
+	if value.Kind() != reflect.Slice {
+		return fieldErrors("", binding.Validator.ValidateStruct(obj))
+	}
+
+	var details []FieldError
+
+	for i := range value.Len() {
+		prefix := fmt.Sprintf("[%d].", i)
+		details = append(details, fieldErrors(prefix, binding.Validator.ValidateStruct(value.Index(i).Interface()))...)
+	}
+
+	return details
+}
+
+func fieldErrors(prefix string, err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	details := make([]FieldError, 0, len(validationErrs))
+
+	for _, fe := range validationErrs {
+		// the namespace starts with the Go type name
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		details = append(details, FieldError{Field: prefix + field, Message: validationMessage(fe)})
+	}
+
+	return details
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gte", "min":
+		return "must be at least " + fe.Param()
+	case "lte", "max":
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of " + fe.Param()
+	default:
+		return fmt.Sprintf("failed on %v", fe.Tag())
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// maxUploadBody bounds the multipart body of an upload, above the largest
+// attachment size allowed by the configuration.
+const maxUploadBody = 101 << 20
+
+// AttachmentResponse is an attachment with the URL it is downloaded from, on
+// the first version of the API.
+type AttachmentResponse struct {
+	bk.Attachment
+	URL string `json:"url"`
+}
+
+func NewAttachmentResponse(attachment bk.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		Attachment: attachment,
+		URL:        fmt.Sprintf("/api/v1/bookings/booking/%v/attachments/%v", attachment.BookingID, attachment.ID),
+	}
+}
+
+func NewAttachmentResponses(attachments []bk.Attachment) []AttachmentResponse {
+	responses := make([]AttachmentResponse, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		responses = append(responses, NewAttachmentResponse(attachment))
+	}
+
+	return responses
+}
+
+func (h *BookingHandler) ListAttachments(c *gin.Context) {
+	attachments, err := h.service.GetAttachments(c.Request.Context(), c.Param("id"), requestUser(c))
+
+	if err != nil {
+		attachmentError(c, err, "failed to retrieve attachments")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewAttachmentResponses(attachments))
+}
+
+// Attach uploads the file of the "file" field of a multipart form, such as
+// an army list or a scenario.
+func (h *BookingHandler) Attach(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadBody)
+
+	header, err := c.FormFile("file")
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "expected a multipart form with a file field")})
+		return
+	}
+
+	file, err := header.Open()
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to read file")})
+		return
+	}
+
+	defer file.Close()
+
+	attachment, err := h.service.AddAttachment(c.Request.Context(), c.Param("id"), header.Filename, file, user)
+
+	if err != nil {
+		attachmentError(c, err, "failed to attach file")
+		return
+	}
+
+	c.JSON(http.StatusCreated, NewAttachmentResponse(attachment))
+}
+
+func (h *BookingHandler) DownloadAttachment(c *gin.Context) {
+	attachment, content, err := h.service.OpenAttachment(c.Request.Context(), c.Param("id"), c.Param("attachmentId"), requestUser(c))
+
+	if err != nil {
+		attachmentError(c, err, "failed to download attachment")
+		return
+	}
+
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *BookingHandler) DeleteAttachment(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	err := h.service.DeleteAttachment(c.Request.Context(), c.Param("id"), c.Param("attachmentId"), user)
+
+	if err != nil {
+		attachmentError(c, err, "failed to delete attachment")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "attachment deleted")})
+}
+
+func attachmentError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+	case errors.Is(err, bk.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "attachment not found")})
+	case errors.Is(err, bk.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to change the attachments of this booking")})
+	case errors.Is(err, bk.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, bk.ErrInvalidAttachment):
+		c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "file", err))
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
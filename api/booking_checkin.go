@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/skip2/go-qrcode"
+)
+
+// checkInCodeScale is the size of a module of the check-in QR code, in
+// pixels, large enough to be printed.
+const checkInCodeScale = 8
+
+// defaultNoShowPeriod is how far back the no-show stats go without since.
+const defaultNoShowPeriod = 90 * 24 * time.Hour
+
+// CheckInCode serves the PNG QR code the players of an accepted booking scan
+// at the venue kiosk.
+func (h *BookingHandler) CheckInCode(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	checkInURL, err := h.service.CheckInURL(c.Request.Context(), c.Param("id"), user)
+
+	if err != nil {
+		checkInError(c, err, "failed to generate check-in code")
+		return
+	}
+
+	// a negative size scales the modules rather than the whole image
+	content, err := qrcode.Encode(checkInURL, qrcode.Medium, -checkInCodeScale)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to generate check-in code")})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, "image/png", content)
+}
+
+// GetNoShowStats counts per organizer the accepted bookings since the date of
+// the since parameter, the last 90 days by default, and those never checked
+// in.
+func (h *BookingHandler) GetNoShowStats(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
+	since, err := parseOptionalTime(c.Query("since"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse since")})
+		return
+	}
+
+	if since.IsZero() {
+		since = time.Now().Add(-defaultNoShowPeriod)
+	}
+
+	stats, err := h.service.GetNoShowCounts(c.Request.Context(), since)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		return
+	}
+
+	renderStats(c, "stats-no-shows", stats, noShowStatsTable)
+}
+
+func checkInError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+	case errors.Is(err, bk.ErrCheckInDisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "check-in is disabled")})
+	case errors.Is(err, bk.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to check in this booking")})
+	case errors.Is(err, bk.ErrInvalidCheckInToken):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "invalid check-in code")})
+	case errors.Is(err, bk.ErrInvalidBookingState):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
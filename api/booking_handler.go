@@ -3,7 +3,12 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,16 +17,49 @@ import (
 )
 
 type BookingService interface {
-	GetActiveBookings(ctx context.Context) ([]bk.Booking, error)
+	FindBookings(ctx context.Context, user *discord.DiscordUser, filter bk.Filter) ([]bk.Booking, error)
+	CountBookings(ctx context.Context, user *discord.DiscordUser, filter bk.Filter) (int, error)
+	GetActiveBookingsIncludingDeleted(ctx context.Context) ([]bk.Booking, error)
 	FindBookingByID(ctx context.Context, id string) (bk.Booking, error)
-	FindBookingsPerUsername(ctx context.Context, username string) ([]bk.Booking, error)
-	CreateBooking(ctx context.Context, booking bk.Booking) (bk.Booking, error)
+	FindBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]bk.Booking, error)
+	FindBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]bk.Booking, error)
+	GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter bk.Filter, cursor string, limit int) (bk.HistoryPage, error)
+	SearchBookings(ctx context.Context, user *discord.DiscordUser, query string, limit int) ([]bk.SearchResult, error)
+	CreateBookingWithWarnings(ctx context.Context, booking bk.Booking) (bk.Booking, []bk.Warning, error)
 	ImportBookings(ctx context.Context, bookings []bk.Booking) error
 	ModifyBooking(ctx context.Context, updated bk.Booking, user discord.DiscordUser) error
 	AcceptBooking(ctx context.Context, id string) error
 	RefuseBooking(ctx context.Context, id, reason string) error
 	CancelBooking(ctx context.Context, id string, user discord.DiscordUser) error
+	ReopenBooking(ctx context.Context, id string) (bk.Booking, error)
+	JoinBooking(ctx context.Context, id string, user discord.DiscordUser) (bk.Booking, error)
+	LeaveBooking(ctx context.Context, id string, user discord.DiscordUser) (bk.Booking, error)
+	ReplacePlayer(ctx context.Context, id, oldPlayer, newPlayer string, user discord.DiscordUser) (bk.Booking, error)
+	SetPriority(ctx context.Context, id, priority string) (bk.Booking, error)
+	SetReminders(ctx context.Context, id string, reminders bk.Reminders, user discord.DiscordUser) (bk.Booking, error)
+	OptOutOfReminders(ctx context.Context, id string, optOut bool, user discord.DiscordUser) (bk.Booking, error)
+	AcknowledgeReminder(ctx context.Context, id string, user discord.DiscordUser) (bk.Booking, error)
+	SnoozeReminder(ctx context.Context, id string, snooze time.Duration, user discord.DiscordUser) (bk.Booking, error)
+	GetPendingQueue(ctx context.Context) ([]bk.QueueEntry, error)
+	FindFreeSlots(ctx context.Context, request bk.SlotRequest, user discord.DiscordUser) ([]bk.FreeSlot, error)
+	DeleteBooking(ctx context.Context, id string) error
+	RetryNotification(ctx context.Context, id string) error
+	ReportResult(ctx context.Context, id string, result bk.Result, user discord.DiscordUser) (bk.Result, error)
+	FindResult(ctx context.Context, id string) (bk.Result, error)
+	AddAttachment(ctx context.Context, bookingID, filename string, content io.Reader, user discord.DiscordUser) (bk.Attachment, error)
+	GetAttachments(ctx context.Context, bookingID string, user *discord.DiscordUser) ([]bk.Attachment, error)
+	ResolvePlayers(ctx context.Context, players []string) []bk.Player
+	OpenAttachment(ctx context.Context, bookingID, id string, user *discord.DiscordUser) (bk.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, bookingID, id string, user discord.DiscordUser) error
+	CheckInURL(ctx context.Context, id string, user discord.DiscordUser) (string, error)
+	GetPayment(ctx context.Context, id string, user discord.DiscordUser) (bk.Payment, error)
+	SetPaymentStatus(ctx context.Context, id, status string) (bk.Booking, error)
+	GetNoShowCounts(ctx context.Context, start time.Time) ([]bk.NoShowCount, error)
 	GetBookingCountPerGame(ctx context.Context) ([]bk.GameBookingCount, error)
+	GetBookingCountPerTag(ctx context.Context) ([]bk.TagBookingCount, error)
+	GetBookingCountPerPair(ctx context.Context, limit int) ([]bk.PairBookingCount, error)
+	GetHeatmap(ctx context.Context, from, to time.Time) (bk.Heatmap, error)
+	GetApprovalLatencies(ctx context.Context, from, to time.Time) ([]bk.ApprovalLatency, error)
 	GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]bk.GameBookingCount, error)
 	GetBookingCountPerWeekDay(ctx context.Context) ([]bk.WeekDayBookingCount, error)
 }
@@ -38,99 +76,516 @@ func (h *BookingHandler) Register(rg *gin.RouterGroup) {
 	adminOnly := AdminOnly()
 	rg.GET("", h.ListActive)
 	rg.GET("/booking/:id", h.GetByID)
+	rg.GET("/count", h.Count)
+	rg.GET("/history", h.History)
+	rg.GET("/search", adminOnly, h.Search)
+	rg.GET("/queue", adminOnly, h.Queue)
+	rg.GET("/suggest", h.Suggest)
 	rg.POST("", h.Create)
 	rg.POST("/import", adminOnly, h.Import)
 	rg.PUT("/:id/accept", adminOnly, h.Accept)
 	rg.PUT("/:id/refuse", adminOnly, h.Refuse)
+	rg.PUT("/:id/priority", adminOnly, h.SetPriority)
 	rg.PUT("/:id/cancel", h.Cancel)
+	rg.PUT("/:id/reopen", adminOnly, h.Reopen)
 	rg.PUT("/:id/modify", h.Modify)
+	rg.PUT("/:id/join", h.Join)
+	rg.PUT("/:id/leave", h.Leave)
+	rg.PUT("/:id/players/replace", h.ReplacePlayer)
+	rg.PUT("/:id/reminders", h.SetReminders)
+	rg.PUT("/:id/reminders/opt-out", h.OptOutOfReminders)
+	rg.DELETE("/:id/reminders/opt-out", h.OptInToReminders)
+	rg.PUT("/:id/reminders/acknowledge", h.AcknowledgeReminder)
+	rg.PUT("/:id/reminders/snooze", h.SnoozeReminder)
+	rg.DELETE("/:id", adminOnly, h.Delete)
+	rg.POST("/:id/notification/retry", adminOnly, h.RetryNotification)
+	rg.POST("/:id/result", h.Result)
+	// reads go under /booking/:id, /:username taking the other GETs
+	rg.GET("/booking/:id/attachments", h.ListAttachments)
+	rg.GET("/booking/:id/attachments/:attachmentId", h.DownloadAttachment)
+	rg.POST("/:id/attachments", h.Attach)
+	rg.DELETE("/:id/attachments/:attachmentId", h.DeleteAttachment)
+	rg.GET("/booking/:id/check-in/qr", h.CheckInCode)
+	rg.GET("/booking/:id/payment", h.GetPayment)
+	rg.PUT("/:id/payment", adminOnly, h.SetPaymentStatus)
 
 	rg.GET("/stats/game", h.GetGameStats)
+	rg.GET("/stats/tags", h.GetTagStats)
+	rg.GET("/stats/pairs", h.GetPairStats)
+	rg.GET("/stats/heatmap", h.GetHeatmap)
 	rg.GET("/stats/game/period", h.GetGameStatsPerPeriod)
 	rg.GET("/stats/day", h.GetGameStatsPerDay)
+	rg.GET("/stats/no-shows", adminOnly, h.GetNoShowStats)
+	rg.GET("/stats/approval-latency", adminOnly, h.GetApprovalLatencyStats)
 
 	rg.GET("/:username", h.GetByUsername)
 }
 
+// RegisterV2 mounts the booking routes on the versioned API group, keeping
+// users and stats out of the /bookings/:id namespace.
+func (h *BookingHandler) RegisterV2(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+
+	bookings := rg.Group("/bookings")
+	bookings.GET("", h.ListActive)
+	bookings.POST("", h.Create)
+	bookings.POST("/import", adminOnly, h.Import)
+	bookings.GET("/count", h.Count)
+	bookings.GET("/history", h.History)
+	bookings.GET("/search", adminOnly, h.Search)
+	bookings.GET("/queue", adminOnly, h.Queue)
+	bookings.GET("/suggest", h.Suggest)
+	bookings.GET("/:id", h.GetByID)
+	bookings.PUT("/:id/accept", adminOnly, h.Accept)
+	bookings.PUT("/:id/refuse", adminOnly, h.Refuse)
+	bookings.PUT("/:id/priority", adminOnly, h.SetPriority)
+	bookings.PUT("/:id/cancel", h.Cancel)
+	bookings.PUT("/:id/reopen", adminOnly, h.Reopen)
+	bookings.PUT("/:id/modify", h.Modify)
+	bookings.PUT("/:id/join", h.Join)
+	bookings.PUT("/:id/leave", h.Leave)
+	bookings.PUT("/:id/players/replace", h.ReplacePlayer)
+	bookings.PUT("/:id/reminders", h.SetReminders)
+	bookings.PUT("/:id/reminders/opt-out", h.OptOutOfReminders)
+	bookings.DELETE("/:id/reminders/opt-out", h.OptInToReminders)
+	bookings.PUT("/:id/reminders/acknowledge", h.AcknowledgeReminder)
+	bookings.PUT("/:id/reminders/snooze", h.SnoozeReminder)
+	bookings.DELETE("/:id", adminOnly, h.Delete)
+	bookings.POST("/:id/notification/retry", adminOnly, h.RetryNotification)
+	bookings.POST("/:id/result", h.Result)
+	bookings.GET("/:id/attachments", h.ListAttachments)
+	bookings.POST("/:id/attachments", h.Attach)
+	bookings.GET("/:id/attachments/:attachmentId", h.DownloadAttachment)
+	bookings.DELETE("/:id/attachments/:attachmentId", h.DeleteAttachment)
+	bookings.GET("/:id/check-in/qr", h.CheckInCode)
+	bookings.GET("/:id/payment", h.GetPayment)
+	bookings.PUT("/:id/payment", adminOnly, h.SetPaymentStatus)
+
+	rg.GET("/users/:username/bookings", h.GetByUsername)
+
+	stats := rg.Group("/stats")
+	stats.GET("/game", h.GetGameStats)
+	stats.GET("/tags", h.GetTagStats)
+	stats.GET("/pairs", h.GetPairStats)
+	stats.GET("/heatmap", h.GetHeatmap)
+	stats.GET("/game/period", h.GetGameStatsPerPeriod)
+	stats.GET("/day", h.GetGameStatsPerDay)
+	stats.GET("/no-shows", adminOnly, h.GetNoShowStats)
+	stats.GET("/approval-latency", adminOnly, h.GetApprovalLatencyStats)
+}
+
 func (h *BookingHandler) ListActive(c *gin.Context) {
-	if bookings, err := h.service.GetActiveBookings(c.Request.Context()); err != nil {
+	if _, ok := c.GetQuery("ids"); ok {
+		h.ListByIDs(c)
+		return
+	}
+
+	sortBy := c.DefaultQuery("sort", "dateTime")
+
+	if sortBy != "dateTime" && sortBy != "recent" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "sort must be one of dateTime, recent")})
+		return
+	}
+
+	includeDeleted := c.Query("includeDeleted") == "true"
+	user := requestUser(c)
+
+	if includeDeleted && (user == nil || !user.Admin) {
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed")})
+		return
+	}
+
+	filter, ok := bookingFilter(c)
+
+	if !ok {
+		return
+	}
+
+	getBookings := func(ctx context.Context) ([]bk.Booking, error) {
+		return h.service.FindBookings(ctx, user, filter)
+	}
+
+	if includeDeleted {
+		getBookings = func(ctx context.Context) ([]bk.Booking, error) {
+			bookings, err := h.service.GetActiveBookingsIncludingDeleted(ctx)
+
+			return slices.DeleteFunc(bookings, func(booking bk.Booking) bool { return !filter.Matches(booking) }), err
+		}
+	}
+
+	if bookings, err := getBookings(c.Request.Context()); err != nil {
 		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to retrieve bookings",
+			"error": translate(c, "failed to retrieve bookings"),
 		})
 	} else {
+		// each room of the club has its own calendar
+		bookings = filterBookings(c, bookings)
+
+		if sortBy == "recent" {
+			// most recently requested first, for the admin view
+			slices.SortStableFunc(bookings, func(a, b bk.Booking) int { return b.CreatedAt.Compare(a.CreatedAt) })
+		}
+
+		responses := NewBookingResponses(bookings, user, time.Now())
+
+		// not cached, the members change their names apart from the bookings
+		if expandsPlayers(c) {
+			c.IndentedJSON(http.StatusOK, h.expandPlayers(c.Request.Context(), responses))
+			return
+		}
+
+		bookingsWithETag(c, responses, user)
+	}
+}
+
+// bookingFilter reads the filter of a listing from the query parameters:
+// status, game, a name of the catalog, player, the username or Discord id of
+// a member involved, and the period from and to, dates or RFC 3339 times. On
+// an invalid filter it writes the response and returns false.
+func bookingFilter(c *gin.Context) (bk.Filter, bool) {
+	filter := bk.Filter{Status: c.Query("status"), Game: c.Query("game"), Player: c.Query("player")}
+
+	var err error
+
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse from")})
+		return filter, false
+	}
+
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse to")})
+		return filter, false
+	}
+
+	if err = filter.Normalize(); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// filterBookings keeps the bookings matching the query parameters: venue,
+// and tag, a comma separated list of tags they must all have.
+func filterBookings(c *gin.Context, bookings []bk.Booking) []bk.Booking {
+	venueID := c.Query("venue")
+	tags := bk.NormalizeTags(strings.Split(c.Query("tag"), ","))
+
+	if len(venueID) == 0 && len(tags) == 0 {
+		return bookings
+	}
+
+	filtered := []bk.Booking{}
+
+	for _, booking := range bookings {
+		if (len(venueID) == 0 || booking.VenueID == venueID) && booking.HasTags(tags) {
+			filtered = append(filtered, booking)
+		}
+	}
+
+	return filtered
+}
+
+// Count counts the active bookings listed to the user, those with the status,
+// game and player of the query parameters when given, so the frontend shows
+// its badges without fetching the bookings.
+func (h *BookingHandler) Count(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	filter, ok := bookingFilter(c)
+
+	if !ok {
+		return
+	}
+
+	count, err := h.service.CountBookings(c.Request.Context(), &user, filter)
+
+	if err != nil {
+		c.Error(err)
+
+		if errors.Is(err, bk.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to count bookings")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"count": count})
+}
+
+const maxBatchIDs = 100
+
+func (h *BookingHandler) ListByIDs(c *gin.Context) {
+	ids := []string{}
+
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		id = strings.TrimSpace(id)
+
+		if len(id) == 0 {
+			continue
+		}
+
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "ids must be a comma separated list of booking ids")})
+			return
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 || len(ids) > maxBatchIDs {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(translate(c, "ids must contain between 1 and %d booking ids"), maxBatchIDs)})
+		return
+	}
+
+	user := requestUser(c)
+	bookings, err := h.service.FindBookingsByIDs(c.Request.Context(), user, ids)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve bookings")})
+		return
+	}
+
+	bookingsWithETag(c, NewBookingResponses(bookings, user, time.Now()), user)
+}
+
+func (h *BookingHandler) History(c *gin.Context) {
+	limit, err := parseOptionalInt(c.Query("limit"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse limit")})
+		return
+	}
+
+	filter, ok := bookingFilter(c)
+
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetBookingHistory(c.Request.Context(), requestUser(c), filter, c.Query("cursor"), limit)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, bk.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid cursor")})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve booking history")})
+		return
+	}
+
+	bookings := NewBookingResponses(page.Bookings, requestUser(c), time.Now())
+
+	// the envelope carries the cursor in its metadata
+	if isEnveloped(c) {
+		setNextCursor(c, page.NextCursor)
 		c.IndentedJSON(http.StatusOK, bookings)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, BookingHistoryResponse{Bookings: bookings, NextCursor: page.NextCursor})
+}
+
+func (h *BookingHandler) Search(c *gin.Context) {
+	limit, err := parseOptionalInt(c.Query("limit"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse limit")})
+		return
+	}
+
+	results, err := h.service.SearchBookings(c.Request.Context(), requestUser(c), c.Query("q"), limit)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, bk.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "q must contain at least one word")})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to search bookings")})
+		return
 	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingSearchResults(results, requestUser(c), time.Now()))
 }
 
 func (h *BookingHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
+	user := requestUser(c)
 	booking, err := h.service.FindBookingByID(c.Request.Context(), id)
 
+	// a private booking is not found by the members it is not listed to
+	if err == nil && !booking.VisibleTo(user) {
+		err = bk.ErrBookingNotFound
+	}
+
 	if err != nil {
 		c.Error(err)
 		if errors.Is(err, bk.ErrBookingNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error": "booking not found",
+				"error": translate(c, "booking not found"),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": translate(c, "failed to fetch booking"),
+		})
+		return
+	}
+
+	now := time.Now()
+	response := NewBookingResponse(booking, user, now)
+
+	if booking.Completed(now) {
+		result, err := h.service.FindResult(c.Request.Context(), id)
+
+		if err != nil && !errors.Is(err, bk.ErrResultNotFound) {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": translate(c, "failed to fetch booking result"),
 			})
 			return
 		}
+
+		if err == nil {
+			response.Result = &result
+		}
+	}
+
+	attachments, err := h.service.GetAttachments(c.Request.Context(), id, user)
+
+	if err != nil {
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch booking",
+			"error": translate(c, "failed to fetch booking attachments"),
 		})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, booking)
+	response.Attachments = NewAttachmentResponses(attachments)
+
+	if expandsPlayers(c) {
+		c.IndentedJSON(http.StatusOK, h.expandPlayers(c.Request.Context(), []BookingResponse{response})[0])
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
 }
 
 func (h *BookingHandler) GetByUsername(c *gin.Context) {
 	username := c.Param("username")
-	bookings, err := h.service.FindBookingsPerUsername(c.Request.Context(), username)
+	user := requestUser(c)
+	bookings, err := h.service.FindBookingsPerUsername(c.Request.Context(), user, username)
 
 	if err != nil {
 		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to get bookings",
+			"error": translate(c, "failed to get bookings"),
 		})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, bookings)
+	c.IndentedJSON(http.StatusOK, NewBookingResponses(filterBookings(c, bookings), user, time.Now()))
 }
 
+// Create books a table. The dateTime is RFC 3339 with the offset of the
+// client's time zone, e.g. 2026-03-12T19:00:00+01:00, and stored in UTC, a
+// date without offset is rejected.
+// When conflicts are warnings, the conflicting booking is created with them.
+// The organizer is the authenticated user, only admins book for others.
 func (h *BookingHandler) Create(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
 	var booking bk.Booking
 
-	if err := c.BindJSON(&booking); err != nil {
-		c.Error(err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "failed to parse JSON body",
-		})
+	if !bindJSON(c, &booking) {
 		return
 	}
 
-	inserted, err := h.service.CreateBooking(c.Request.Context(), booking)
+	if !user.Admin {
+		if len(booking.UserID) != 0 && booking.UserID != user.ID {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "userId", errors.New("only admins book for other users")))
+			return
+		}
+
+		booking.UserID = user.ID
+		booking.Username = user.Username
+	}
+
+	inserted, warnings, err := h.service.CreateBookingWithWarnings(c.Request.Context(), booking)
 
 	if err != nil {
 		c.Error(err)
+		var unknown *bk.UnknownPlayersError
+		if errors.As(err, &unknown) {
+			c.JSON(http.StatusUnprocessableEntity, unknownPlayersResponse(c, unknown))
+			return
+		}
+		if errors.Is(err, bk.ErrTooManyPlayers) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "players", err))
+			return
+		}
+		if errors.Is(err, bk.ErrInvalidPoints) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "points", err))
+			return
+		}
+		if errors.Is(err, bk.ErrUnknownEquipment) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "equipment", err))
+			return
+		}
+		if errors.Is(err, bk.ErrInvalidVenue) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "venueId", err))
+			return
+		}
+		if errors.Is(err, bk.ErrInvalidTags) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "tags", err))
+			return
+		}
+		if errors.Is(err, bk.ErrInvalidCoOrganizers) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "coOrganizers", err))
+			return
+		}
+		if errors.Is(err, bk.ErrInvalidCustomFields) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "customFields", err))
+			return
+		}
+		if errors.Is(err, bk.ErrEquipmentUnavailable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, bk.ErrUserSuspended) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": translate(c, "user is suspended from booking"),
+			})
+			return
+		}
+		if errors.Is(err, bk.ErrMembershipRequired) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "failed to create booking",
+			"error": translate(c, "failed to create booking"),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, inserted)
+	c.JSON(http.StatusCreated, CreatedBookingResponse{
+		BookingResponse: NewBookingResponse(inserted, requestUser(c), time.Now()),
+		Warnings:        warnings,
+	})
 }
 
 func (h *BookingHandler) Import(c *gin.Context) {
 	var bookings []bk.Booking
 
-	if err := c.BindJSON(&bookings); err != nil {
-		c.Error(err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "failed to parse JSON body",
-		})
+	if !bindJSON(c, &bookings) {
 		return
 	}
 
@@ -139,12 +594,12 @@ func (h *BookingHandler) Import(c *gin.Context) {
 	if err != nil {
 		c.Error(err)
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "failed to import bookings",
+			"error": translate(c, "failed to import bookings"),
 		})
 		return
 	}
 
-	c.IndentedJSON(http.StatusCreated, gin.H{"message": "bookings imported"})
+	c.IndentedJSON(http.StatusCreated, gin.H{"message": translate(c, "bookings imported")})
 }
 
 func (h *BookingHandler) Accept(c *gin.Context) {
@@ -156,22 +611,26 @@ func (h *BookingHandler) Accept(c *gin.Context) {
 		c.Error(err)
 		if errors.Is(err, bk.ErrBookingNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error": "booking not found",
+				"error": translate(c, "booking not found"),
 			})
 		} else if errors.Is(err, bk.ErrInvalidBookingState) {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "invalid booking state",
+				"error": translate(c, "invalid booking state"),
+			})
+		} else if errors.Is(err, bk.ErrPriorityConflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
 			})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to accept booking",
+				"error": translate(c, "failed to accept booking"),
 			})
 		}
 
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "booking accepted"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking accepted")})
 }
 
 func (h *BookingHandler) Refuse(c *gin.Context) {
@@ -184,22 +643,22 @@ func (h *BookingHandler) Refuse(c *gin.Context) {
 		c.Error(err)
 		if errors.Is(err, bk.ErrBookingNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error": "booking not found",
+				"error": translate(c, "booking not found"),
 			})
 		} else if errors.Is(err, bk.ErrInvalidBookingState) {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "invalid booking state",
+				"error": translate(c, "invalid booking state"),
 			})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to refuse booking",
+				"error": translate(c, "failed to refuse booking"),
 			})
 		}
 
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "booking refused"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking refused")})
 }
 
 func (h *BookingHandler) Cancel(c *gin.Context) {
@@ -212,68 +671,389 @@ func (h *BookingHandler) Cancel(c *gin.Context) {
 		c.Error(err)
 		if errors.Is(err, bk.ErrBookingNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
-				"error": "booking not found",
+				"error": translate(c, "booking not found"),
+			})
+		} else if errors.Is(err, bk.ErrInvalidBookingState) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": translate(c, "invalid booking state"),
+			})
+		} else if errors.Is(err, bk.ErrBookingLocked) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": translate(c, "booking can no longer be canceled"),
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": translate(c, "failed to cancel booking"),
+			})
+		}
+
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking canceled")})
+}
+
+// Reopen puts a canceled or refused booking back in the approval queue, as
+// long as nothing took its slot meanwhile.
+func (h *BookingHandler) Reopen(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	booking, err := h.service.ReopenBooking(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+
+		switch {
+		case errors.Is(err, bk.ErrBookingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+		case errors.Is(err, bk.ErrInvalidBookingState):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, bk.ErrSlotTaken), errors.Is(err, bk.ErrInvalidVenue), errors.Is(err, bk.ErrEquipmentUnavailable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to reopen booking")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// Join claims a free seat of a booking looking for players.
+func (h *BookingHandler) Join(c *gin.Context) {
+	id := c.Param("id")
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	booking, err := h.service.JoinBooking(c.Request.Context(), id, user)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, bk.ErrBookingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": translate(c, "booking not found"),
 			})
 		} else if errors.Is(err, bk.ErrInvalidBookingState) {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "invalid booking state",
+				"error": translate(c, "invalid booking state"),
+			})
+		} else if errors.Is(err, bk.ErrNoOpenSeat) || errors.Is(err, bk.ErrAlreadyJoined) || errors.Is(err, bk.ErrTooManyPlayers) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+		} else if errors.Is(err, bk.ErrUserSuspended) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": translate(c, "user is suspended from booking"),
 			})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "failed to cancel booking",
+				"error": translate(c, "failed to join booking"),
 			})
 		}
 
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "booking canceled"})
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
 }
 
-func (h *BookingHandler) Modify(c *gin.Context) {
+func (h *BookingHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.service.DeleteBooking(c.Request.Context(), id)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, bk.ErrBookingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": translate(c, "booking not found"),
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": translate(c, "failed to delete booking"),
+			})
+		}
+
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking deleted")})
+}
+
+// Result records the winner, scores and report of a completed booking, it
+// replaces the previous report.
+func (h *BookingHandler) Result(c *gin.Context) {
+	id := c.Param("id")
 	user := c.MustGet("user").(discord.DiscordUser)
-	booking := bk.Booking{}
+
+	var result bk.Result
+
+	if !bindJSON(c, &result) {
+		return
+	}
+
+	saved, err := h.service.ReportResult(c.Request.Context(), id, result, user)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, bk.ErrBookingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": translate(c, "booking not found"),
+			})
+		} else if errors.Is(err, bk.ErrNotAllowed) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": translate(c, "not allowed to report the result of this booking"),
+			})
+		} else if errors.Is(err, bk.ErrInvalidBookingState) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": translate(c, "booking is not completed"),
+			})
+		} else if errors.Is(err, bk.ErrInvalidResult) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "result", err))
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": translate(c, "failed to save result"),
+			})
+		}
+
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, saved)
+}
+
+func unknownPlayersResponse(c *gin.Context, err *bk.UnknownPlayersError) gin.H {
+	details := make([]FieldError, 0, len(err.Players))
+
+	for _, player := range err.Players {
+		details = append(details, FieldError{
+			Field:       fmt.Sprintf("players[%d]", player.Index),
+			Message:     fmt.Sprintf("'%v' is not a member of the server", player.Username),
+			Suggestions: player.Suggestions,
+		})
+	}
+
+	return gin.H{"error": translate(c, "unknown players"), "details": details}
+}
+
+// RetryNotification sends the Discord notification of a booking whose last
+// notification failed.
+func (h *BookingHandler) RetryNotification(c *gin.Context) {
 	id := c.Param("id")
 
-	err := c.BindJSON(&booking)
+	err := h.service.RetryNotification(c.Request.Context(), id)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse JSON body"})
+		c.Error(err)
+		if errors.Is(err, bk.ErrBookingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": translate(c, "booking not found"),
+			})
+		} else if errors.Is(err, bk.ErrNotificationFailed) {
+			c.JSON(http.StatusBadGateway, gin.H{
+				"error": translate(c, "failed to send notification"),
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": translate(c, "failed to retry notification"),
+			})
+		}
+
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "notification sent")})
+}
+
+// Modify changes a booking, its dateTime given with an offset as on Create.
+func (h *BookingHandler) Modify(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	booking := bk.Booking{}
+	id := c.Param("id")
+
+	if !bindJSON(c, &booking) {
 		return
 	}
 
 	booking.ID = id
 
-	err = h.service.ModifyBooking(c.Request.Context(), booking, user)
+	err := h.service.ModifyBooking(c.Request.Context(), booking, user)
 
 	if err != nil {
 		c.Error(err)
 
-		if errors.Is(err, bk.ErrNotAllowed) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to modify this booking"})
+		var unknown *bk.UnknownPlayersError
+
+		if errors.As(err, &unknown) {
+			c.JSON(http.StatusUnprocessableEntity, unknownPlayersResponse(c, unknown))
+		} else if errors.Is(err, bk.ErrTooManyPlayers) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "players", err))
+		} else if errors.Is(err, bk.ErrInvalidPoints) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "points", err))
+		} else if errors.Is(err, bk.ErrUnknownEquipment) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "equipment", err))
+		} else if errors.Is(err, bk.ErrInvalidVenue) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "venueId", err))
+		} else if errors.Is(err, bk.ErrInvalidTags) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "tags", err))
+		} else if errors.Is(err, bk.ErrInvalidCoOrganizers) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "coOrganizers", err))
+		} else if errors.Is(err, bk.ErrInvalidCustomFields) {
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "customFields", err))
+		} else if errors.Is(err, bk.ErrEquipmentUnavailable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else if errors.Is(err, bk.ErrNotAllowed) {
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to modify this booking")})
+		} else if errors.Is(err, bk.ErrBookingLocked) {
+			c.JSON(http.StatusConflict, gin.H{"error": translate(c, "booking can no longer be modified")})
+		} else if errors.Is(err, bk.ErrMembershipRequired) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to modify booking"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to modify booking")})
 		}
 
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "booking modified"})
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking modified")})
 }
 
 func (h *BookingHandler) GetGameStats(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
 	stats, err := h.service.GetBookingCountPerGame(c.Request.Context())
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		return
+	}
+
+	renderStats(c, "stats-game", stats, gameStatsTable)
+}
+
+func (h *BookingHandler) GetTagStats(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
+	stats, err := h.service.GetBookingCountPerTag(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		return
+	}
+
+	renderStats(c, "stats-tags", stats, tagStatsTable)
+}
+
+// GetPairStats lists the pairs of members playing together the most, limit
+// pairs at most.
+func (h *BookingHandler) GetPairStats(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
+	limit, err := parseOptionalInt(c.Query("limit"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse limit")})
+		return
+	}
+
+	stats, err := h.service.GetBookingCountPerPair(c.Request.Context(), limit)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		return
+	}
+
+	renderStats(c, "stats-pairs", stats, pairStatsTable)
+}
+
+// GetHeatmap counts the bookings per weekday and hour between the from and
+// to query parameters, dates or RFC 3339, the last 12 weeks by default.
+func (h *BookingHandler) GetHeatmap(c *gin.Context) {
+	from, err := parseOptionalTime(c.Query("from"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse from")})
+		return
+	}
+
+	to, err := parseOptionalTime(c.Query("to"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse to")})
+		return
+	}
+
+	heatmap, err := h.service.GetHeatmap(c.Request.Context(), from, to)
+
+	if err != nil {
+		c.Error(err)
+
+		if errors.Is(err, bk.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		}
+		return
+	}
+
+	indentedJSONWithETag(c, heatmap, privateNoCache)
+}
+
+// GetApprovalLatencyStats sums up per month how long the bookings created
+// between the from and to query parameters waited to be accepted or refused,
+// the last year by default.
+func (h *BookingHandler) GetApprovalLatencyStats(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
+	from, err := parseOptionalTime(c.Query("from"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse from")})
+		return
+	}
+
+	to, err := parseOptionalTime(c.Query("to"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse to")})
+		return
+	}
+
+	stats, err := h.service.GetApprovalLatencies(c.Request.Context(), from, to)
+
+	if err != nil {
+		c.Error(err)
+
+		if errors.Is(err, bk.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
+		}
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, stats)
+	renderStats(c, "stats-approval-latency", stats, approvalLatencyStatsTable)
 }
 
 func (h *BookingHandler) GetGameStatsPerPeriod(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
 	startQuery := c.Query("startPeriod")
 	endQuery := c.Query("endPeriod")
 
@@ -281,7 +1061,7 @@ func (h *BookingHandler) GetGameStatsPerPeriod(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse startPeriod"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse startPeriod")})
 		return
 	}
 
@@ -289,7 +1069,7 @@ func (h *BookingHandler) GetGameStatsPerPeriod(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse endPeriod"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse endPeriod")})
 		return
 	}
 
@@ -297,23 +1077,27 @@ func (h *BookingHandler) GetGameStatsPerPeriod(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, stats)
+	renderStats(c, "stats-game-period", stats, gameStatsTable)
 }
 
 func (h *BookingHandler) GetGameStatsPerDay(c *gin.Context) {
+	if !checkStatsFormat(c) {
+		return
+	}
+
 	stats, err := h.service.GetBookingCountPerWeekDay(c.Request.Context())
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get stats"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get stats")})
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, stats)
+	renderStats(c, "stats-day", stats, weekDayStatsTable)
 }
 
 func AdminOnly() gin.HandlerFunc {
@@ -321,7 +1105,7 @@ func AdminOnly() gin.HandlerFunc {
 		user := c.MustGet("user").(discord.DiscordUser)
 
 		if !user.Admin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "not allowed"})
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed")})
 			c.Abort()
 			return
 		}
@@ -2,9 +2,15 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"image/png"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -83,8 +89,8 @@ func TestGetAllActiveBookings(t *testing.T) {
 		},
 	}
 
-	bookingsJson, _ := json.MarshalIndent(bookings, "", "    ")
-	mockService.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+	bookingsJson, _ := json.MarshalIndent(api.NewBookingResponses(bookings, nil, time.Now()), "", "    ")
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(1)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
@@ -94,150 +100,431 @@ func TestGetAllActiveBookings(t *testing.T) {
 	assert.JSONEq(t, string(bookingsJson), w.Body.String())
 }
 
-func TestGetAllActiveBookings_Error(t *testing.T) {
-	router, ctrl, mockService := setupRouter(t)
-	defer ctrl.Finish()
+func TestGetAllActiveBookings_Filter(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
 
-	mockService.EXPECT().GetActiveBookings(gomock.Any()).Return(nil, assert.AnError).Times(1)
+	t.Run("pushed down to the service", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
 
-	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
-	router.ServeHTTP(w, req)
+		bookings := []bk.Booking{{ID: "1", Game: "Kill Team", Status: "pending"}}
+		mockService.EXPECT().FindBookings(gomock.Any(), &admin, bk.Filter{Status: "pending"}).Return(bookings, nil).Times(1)
 
-	assert.Equal(t, 500, w.Code)
-	assert.JSONEq(t, `{"error":"failed to retrieve bookings"}`, w.Body.String())
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?status=Pending", nil)
+		router.ServeHTTP(w, req)
+
+		var response []map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+	})
+
+	t.Run("of a game", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookings(gomock.Any(), &admin, bk.Filter{Status: "accepted", Game: "Blood Bowl"}).Return([]bk.Booking{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?status=accepted&game=Blood%20Bowl", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("of a player", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookings(gomock.Any(), &admin, bk.Filter{Player: "jane.doe"}).Return([]bk.Booking{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v2/bookings?player=Jane.Doe", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("of a week", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, 7)
+		mockService.EXPECT().FindBookings(gomock.Any(), &admin, bk.Filter{From: from, To: to}).Return([]bk.Booking{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v2/bookings?from=2026-10-12&to=2026-10-19", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("with an invalid period", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		for _, query := range []string{"from=yesterday", "to=2026-13-01", "from=2026-10-19&to=2026-10-12"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/bookings?"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, 400, w.Code, query)
+		}
+	})
+
+	t.Run("with the deleted bookings", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		bookings := []bk.Booking{{ID: "1", Status: "pending"}, {ID: "2", Status: "accepted"}}
+		mockService.EXPECT().GetActiveBookingsIncludingDeleted(gomock.Any()).Return(bookings, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?status=accepted&includeDeleted=true", nil)
+		router.ServeHTTP(w, req)
+
+		var response []map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, "2", response[0]["id"])
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?status=lost", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
 }
 
-func TestGetByID(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+func TestExpandPlayers(t *testing.T) {
+	alice := bk.Player{ID: "1", Username: "alice", DisplayName: "Ali", AvatarURL: "https://cdn.discordapp.com/avatars/1/abc.png"}
+	bob := bk.Player{ID: "2", Username: "bob", DisplayName: "bob"}
+
+	t.Run("listing", func(t *testing.T) {
 		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
-		b := bk.Booking{ID: "123", Game: "SW"}
-		bJson, _ := json.MarshalIndent(b, "", "    ")
-		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		bookings := []bk.Booking{{ID: "1", Players: []string{"alice", "bob"}}, {ID: "2", Players: []string{"bob"}}}
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(1)
+		mockService.EXPECT().ResolvePlayers(gomock.Any(), []string{"alice", "bob"}).Return([]bk.Player{alice, bob}).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?expand=players", nil)
 		router.ServeHTTP(w, req)
 
+		var response []api.ExpandedBookingResponse
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, string(bJson), w.Body.String())
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []bk.Player{alice, bob}, response[0].Players)
+		assert.Equal(t, []bk.Player{bob}, response[1].Players)
 	})
 
-	t.Run("not found", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("detail", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, discord.DiscordUser{ID: "1", Username: "alice"})
 		defer ctrl.Finish()
 
-		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{ID: "123", Players: []string{"alice"}}, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+		mockService.EXPECT().ResolvePlayers(gomock.Any(), []string{"alice"}).Return([]bk.Player{alice}).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/123?expand=players", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 404, w.Code)
-		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+		var response struct{ Data api.ExpandedBookingResponse }
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "123", response.Data.ID)
+		assert.Equal(t, []bk.Player{alice}, response.Data.Players)
 	})
 
-	t.Run("repo error", func(t *testing.T) {
+	t.Run("usernames by default", func(t *testing.T) {
 		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{}, assert.AnError).Times(1)
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return([]bk.Booking{{ID: "1", Players: []string{"alice"}}}, nil).Times(1)
+		mockService.EXPECT().ResolvePlayers(gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to fetch booking"}`, w.Body.String())
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"players": [
+            "alice"
+        ]`)
 	})
 }
 
-func TestGetByUsername(t *testing.T) {
+func TestGetAllActiveBookings_Venue(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	bookings := []bk.Booking{{ID: "1", Game: "Star Wars Legion", VenueID: "1"}, {ID: "2", Game: "Kill Team", VenueID: "2"}}
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings?venue=2", nil)
+	router.ServeHTTP(w, req)
+
+	var response []api.BookingResponse
+	assert.Equal(t, 200, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "2", response[0].ID)
+}
+
+func TestGetAllActiveBookings_Tags(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	bookings := []bk.Booking{
+		{ID: "1", Game: "Star Wars Legion", Tags: []string{"beginner-friendly"}},
+		{ID: "2", Game: "Kill Team", Tags: []string{"beginner-friendly", "tournament-prep"}},
+		{ID: "3", Game: "Warhammer 40k"},
+	}
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings?tag=Tournament%20Prep,beginner-friendly", nil)
+	router.ServeHTTP(w, req)
+
+	var response []api.BookingResponse
+	assert.Equal(t, 200, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "2", response[0].ID)
+}
+
+func TestGetAllActiveBookings_ETag(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	bookings := []bk.Booking{{ID: "1", Game: "Star Wars Legion"}}
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(3)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
+	router.ServeHTTP(w, req)
+
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, 200, w.Code)
+	assert.Regexp(t, `^W/".+"$`, etag)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings", nil)
+	req.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 304, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings", nil)
+	req.Header.Set("If-None-Match", `W/"stale"`)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestGetAllActiveBookings_ETagChangesOnUpdate(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	updatedAt := time.Now()
+	bookings := []bk.Booking{{ID: "1", Game: "Star Wars Legion", UpdatedAt: updatedAt}}
+	modified := []bk.Booking{{ID: "1", Game: "Star Wars Legion", UpdatedAt: updatedAt.Add(time.Second)}}
+	gomock.InOrder(
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil),
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(modified, nil),
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
+	router.ServeHTTP(w, req)
+
+	etag := w.Header().Get("ETag")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings", nil)
+	req.Header.Set("If-None-Match", etag)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+func TestGetAllActiveBookings_SortRecent(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	bookings := []bk.Booking{
+		{ID: "1", DateTime: now, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "2", DateTime: now.Add(time.Hour), CreatedAt: now.Add(-time.Hour)},
+	}
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(bookings, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings?sort=recent", nil)
+	router.ServeHTTP(w, req)
+
+	var got []api.BookingResponse
+	json.Unmarshal(w.Body.Bytes(), &got)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, []string{"2", "1"}, []string{got[0].ID, got[1].ID})
+}
+
+func TestGetAllActiveBookings_InvalidSort(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Times(0)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings?sort=points", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 400, w.Code)
+}
+
+func TestGetAllActiveBookings_Error(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Return(nil, assert.AnError).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 500, w.Code)
+	assert.JSONEq(t, `{"error":"failed to retrieve bookings"}`, w.Body.String())
+}
+
+func TestListByIDs(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
-		bookings := []bk.Booking{{ID: "1"}, {ID: "2"}}
-		bookingsJson, _ := json.MarshalIndent(bookings, "", "    ")
-		mockService.EXPECT().FindBookingsPerUsername(gomock.Any(), "john").Return(bookings, nil).Times(1)
+		bookings := []bk.Booking{{ID: "1"}, {ID: "3"}}
+		bookingsJson, _ := json.MarshalIndent(api.NewBookingResponses(bookings, nil, time.Now()), "", "    ")
+		mockService.EXPECT().FindBookingsByIDs(gomock.Any(), gomock.Any(), []string{"1", "3"}).Return(bookings, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/john", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?ids=1,%203", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 200, w.Code)
 		assert.JSONEq(t, string(bookingsJson), w.Body.String())
 	})
 
-	t.Run("repo error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("invalid ids", func(t *testing.T) {
+		router, ctrl, _ := setupRouter(t)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().FindBookingsPerUsername(gomock.Any(), "john").Return(nil, assert.AnError).Times(1)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?ids=1,abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("empty ids", func(t *testing.T) {
+		router, ctrl, _ := setupRouter(t)
+		defer ctrl.Finish()
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/john", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?ids=", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to get bookings"}`, w.Body.String())
+		assert.Equal(t, 400, w.Code)
 	})
 }
 
-func TestCreate(t *testing.T) {
+func TestHistory(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
-		toCreate := bk.Booking{Game: "SW", Username: "john"}
-		inserted := bk.Booking{ID: "123", Game: "SW", Username: "john"}
-		insertedJson, _ := json.Marshal(inserted)
-		body, _ := json.Marshal(toCreate)
+		page := bk.HistoryPage{Bookings: []bk.Booking{{ID: "2"}, {ID: "1"}}, NextCursor: "next"}
+		mockService.EXPECT().GetBookingHistory(gomock.Any(), gomock.Any(), bk.Filter{}, "abc", 2).Return(page, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/history?cursor=abc&limit=2", nil)
+		router.ServeHTTP(w, req)
+
+		expected, _ := json.Marshal(api.BookingHistoryResponse{
+			Bookings:   api.NewBookingResponses(page.Bookings, nil, time.Now()),
+			NextCursor: "next",
+		})
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("of a game", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, discord.DiscordUser{ID: "1", Username: "organizer"})
+		defer ctrl.Finish()
 
-		mockService.EXPECT().CreateBooking(gomock.Any(), gomock.Any()).Return(inserted, nil).Times(1)
+		page := bk.HistoryPage{Bookings: []bk.Booking{{ID: "1", Game: "Blood Bowl"}}, NextCursor: "next"}
+		mockService.EXPECT().GetBookingHistory(gomock.Any(), gomock.Any(), bk.Filter{Game: "Blood Bowl"}, "", 0).Return(page, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/history?game=Blood+Bowl", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 201, w.Code)
-		assert.JSONEq(t, string(insertedJson), w.Body.String())
+		var response api.Envelope
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, 1, *response.Meta.Count)
+		assert.Equal(t, "next", response.Meta.NextCursor)
 	})
 
-	t.Run("bad json", func(t *testing.T) {
-		router, ctrl, _ := setupRouter(t)
+	t.Run("invalid cursor", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().GetBookingHistory(gomock.Any(), gomock.Any(), bk.Filter{}, "abc", 0).Return(bk.HistoryPage{}, bk.ErrInvalidCursor).Times(1)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString("{"))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/history?cursor=abc", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to parse JSON body"}`, w.Body.String())
+		assert.JSONEq(t, `{"error":"invalid cursor"}`, w.Body.String())
 	})
 
-	t.Run("service error", func(t *testing.T) {
+	t.Run("invalid limit", func(t *testing.T) {
 		router, ctrl, mockService := setupRouter(t)
 		defer ctrl.Finish()
 
-		body := []byte(`{"game":"SW"}`)
-		mockService.EXPECT().CreateBooking(gomock.Any(), gomock.Any()).Return(bk.Booking{}, assert.AnError).Times(1)
+		mockService.EXPECT().GetBookingHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/history?limit=ten", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to create booking"}`, w.Body.String())
 	})
 }
 
-func TestImport(t *testing.T) {
+func TestSearch(t *testing.T) {
 	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
 	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
 
@@ -245,26 +532,410 @@ func TestImport(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, admin)
 		defer ctrl.Finish()
 
-		toImport := []bk.Booking{
-			{Game: "SW", Username: "john"},
-			{Game: "Other", Username: "jane"},
-		}
-		responseJson, _ := json.Marshal(gin.H{"message": "bookings imported"})
-		body, _ := json.Marshal(toImport)
+		results := []bk.SearchResult{{Booking: bk.Booking{ID: "1", Game: "Necromunda"}, Rank: 0.5, Highlight: "<mark>Necromunda</mark>"}}
+		mockService.EXPECT().SearchBookings(gomock.Any(), gomock.Any(), "necromunda", 0).Return(results, nil).Times(1)
 
-		mockService.EXPECT().ImportBookings(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/search?q=necromunda", nil)
+		router.ServeHTTP(w, req)
+
+		expected, _ := json.Marshal(api.NewBookingSearchResults(results, &admin, time.Now()))
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SearchBookings(gomock.Any(), gomock.Any(), "", 0).Return(nil, bk.ErrInvalidSearch).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/search", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 201, w.Code)
-		assert.JSONEq(t, string(responseJson), w.Body.String())
+		assert.Equal(t, 400, w.Code)
 	})
 
-	t.Run("bad json", func(t *testing.T) {
-		router, ctrl, _ := setupRouterWithUser(t, admin)
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SearchBookings(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/search?q=necromunda", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Game: "SW"}
+		bJson, _ := json.MarshalIndent(api.NewBookingResponse(b, nil, time.Now()), "", "    ")
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(bJson), w.Body.String())
+	})
+
+	t.Run("date in guild time zone", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Game: "SW", DateTime: time.Date(2026, 3, 12, 18, 0, 0, 0, time.UTC)}
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"dateTime": "2026-03-12T19:00:00+01:00"`)
+		assert.Contains(t, w.Body.String(), `"displayDate": "jeudi 12 mars 2026 à 19:00"`)
+	})
+
+	t.Run("computed fields", func(t *testing.T) {
+		user := discord.DiscordUser{ID: "user1ID", Username: "user1"}
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		dateTime := time.Date(2099, time.March, 12, 18, 0, 0, 0, time.UTC)
+		b := bk.Booking{ID: "123", Game: "SW", UserID: "user1ID", Status: "pending", DateTime: dateTime}
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		var response api.BookingResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+
+		assert.Equal(t, 200, w.Code)
+		assert.False(t, response.IsPast)
+		assert.True(t, response.CanModify)
+		assert.True(t, response.CanCancel)
+		assert.Equal(t, "jeudi 12 mars 2099 à 19:00", response.DisplayDate)
+	})
+
+	t.Run("attachment links", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{ID: "123", Game: "SW"}, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).
+			Return([]bk.Attachment{{ID: "4", BookingID: "123", Filename: "list.pdf", ContentType: "application/pdf", Size: 1024}}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"url": "/api/v1/bookings/booking/123/attachments/4"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+	})
+
+	t.Run("not found in french", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"réservation introuvable"}`, w.Body.String())
+	})
+
+	t.Run("private booking of another member", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "user2ID", Username: "user2"})
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Game: "SW", UserID: "user1ID", Visibility: bk.VisibilityPrivate, Players: []string{"user3"}}
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{}, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to fetch booking"}`, w.Body.String())
+	})
+}
+
+func TestGetByUsername(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		bookings := []bk.Booking{{ID: "1"}, {ID: "2"}}
+		bookingsJson, _ := json.MarshalIndent(api.NewBookingResponses(bookings, nil, time.Now()), "", "    ")
+		mockService.EXPECT().FindBookingsPerUsername(gomock.Any(), gomock.Any(), "john").Return(bookings, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/john", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(bookingsJson), w.Body.String())
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingsPerUsername(gomock.Any(), gomock.Any(), "john").Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/john", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to get bookings"}`, w.Body.String())
+	})
+}
+
+func TestCreate(t *testing.T) {
+	john := discord.DiscordUser{ID: "2", Username: "john"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		toCreate := bk.Booking{Game: "SW", Username: "john"}
+		inserted := bk.Booking{ID: "123", Game: "SW", Username: "john"}
+		insertedJson, _ := json.Marshal(api.NewBookingResponse(inserted, &john, time.Now()))
+		body, _ := json.Marshal(toCreate)
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), bk.Booking{Game: "SW", UserID: "2", Username: "john"}).Return(inserted, nil, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.JSONEq(t, string(insertedJson), w.Body.String())
+	})
+
+	t.Run("with warnings", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		inserted := bk.Booking{ID: "123", Game: "SW", Username: "john", Status: "pending"}
+		warnings := []bk.Warning{
+			{Kind: bk.WarningOverlap, Message: "john already booked Necromunda at that time", BookingID: "7"},
+			{Kind: bk.WarningVenue, Message: "Cave is closed at that time"},
+		}
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Return(inserted, warnings, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW","username":"john"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var body struct {
+			ID       string       `json:"id"`
+			Warnings []bk.Warning `json:"warnings"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &body)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Equal(t, "123", body.ID)
+		assert.Equal(t, warnings, body.Warnings)
+	})
+
+	t.Run("booking for another user", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW","userId":"1","username":"alice"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"userId","message":"only admins book for other users"}]}`, w.Body.String())
+	})
+
+	t.Run("admin booking for another user", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "9", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), bk.Booking{Game: "SW", UserID: "1", Username: "alice"}).Return(bk.Booking{ID: "123"}, nil, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW","userId":"1","username":"alice"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString("{"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse JSON body"}`, w.Body.String())
+	})
+
+	t.Run("date without offset", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW","points":2,"dateTime":"2026-03-12T19:00:00"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse JSON body","details":[{"field":"dateTime","message":"expected an RFC 3339 date with a time zone offset, e.g. 2026-03-12T19:00:00+01:00, got '2026-03-12T19:00:00'"}]}`, w.Body.String())
+	})
+
+	t.Run("unknown players", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		err := &bk.UnknownPlayersError{Players: []bk.UnknownPlayer{{Index: 1, Username: "bobb", Suggestions: []string{"bob"}}}}
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Return(bk.Booking{}, nil, err).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW","players":["john","bobb"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"unknown players","details":[{"field":"players[1]","message":"'bobb' is not a member of the server","suggestions":["bob"]}]}`, w.Body.String())
+	})
+
+	t.Run("suspended", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Return(bk.Booking{}, nil, bk.ErrUserSuspended).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"user is suspended from booking"}`, w.Body.String())
+	})
+
+	t.Run("prime time reserved to members", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Return(bk.Booking{}, nil, fmt.Errorf("%w: ask an admin", bk.ErrMembershipRequired)).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBufferString(`{"game":"SW"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"prime-time slots are reserved to club members: ask an admin"}`, w.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, john)
+		defer ctrl.Finish()
+
+		body := []byte(`{"game":"SW"}`)
+		mockService.EXPECT().CreateBookingWithWarnings(gomock.Any(), gomock.Any()).Return(bk.Booking{}, nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to create booking"}`, w.Body.String())
+	})
+}
+
+func TestImport(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		toImport := []bk.Booking{
+			{Game: "SW", Username: "john"},
+			{Game: "Other", Username: "jane"},
+		}
+		responseJson, _ := json.Marshal(gin.H{"message": "bookings imported"})
+		body, _ := json.Marshal(toImport)
+
+		mockService.EXPECT().ImportBookings(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.JSONEq(t, string(responseJson), w.Body.String())
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, admin)
 		defer ctrl.Finish()
 
 		w := httptest.NewRecorder()
@@ -272,428 +943,1674 @@ func TestImport(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to parse JSON body"}`, w.Body.String())
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse JSON body"}`, w.Body.String())
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBufferString(`[{"game":"SW"},{"username":"jane"}]`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"[1].game","message":"is required"}]}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBufferString("["))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		toImport := []bk.Booking{
+			{Game: "SW", Username: "john"},
+			{Game: "Other", Username: "jane"},
+		}
+		body, _ := json.Marshal(toImport)
+		mockService.EXPECT().ImportBookings(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to import bookings"}`, w.Body.String())
+	})
+}
+
+func TestAccept(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"booking accepted"}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(bk.ErrInvalidBookingState).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to accept booking"}`, w.Body.String())
+	})
+}
+
+func TestRefuse(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"booking refused"}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(bk.ErrInvalidBookingState).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to refuse booking"}`, w.Body.String())
+	})
+}
+
+func TestJoin(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "bob"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		joined := bk.Booking{ID: "123", UserID: "1", Status: "accepted", Players: []string{"bob"}, LookingForPlayers: true}
+		mockService.EXPECT().JoinBooking(gomock.Any(), "123", user).Return(joined, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/join", nil)
+		router.ServeHTTP(w, req)
+
+		var response api.BookingResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, []string{"bob"}, response.Players)
+	})
+
+	t.Run("no open seat", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().JoinBooking(gomock.Any(), "123", user).Return(bk.Booking{}, bk.ErrNoOpenSeat).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/join", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+		assert.JSONEq(t, `{"error":"booking is not looking for players"}`, w.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().JoinBooking(gomock.Any(), "123", user).Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/join", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestCancel(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"booking canceled"}`, w.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(bk.ErrInvalidBookingState).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to cancel booking"}`, w.Body.String())
+	})
+}
+
+func TestDelete(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().DeleteBooking(gomock.Any(), "123").Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/bookings/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"booking deleted"}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/bookings/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().DeleteBooking(gomock.Any(), "123").Return(bk.ErrBookingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/bookings/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+	})
+}
+
+func TestGetByIDWithResult(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	b := bk.Booking{ID: "123", Game: "SW", Status: "accepted", Players: []string{"alice"}, DateTime: time.Now().Add(-time.Hour)}
+	result := bk.Result{BookingID: "123", Winner: "alice", Scores: map[string]int{"alice": 10}}
+	mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+	mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+	mockService.EXPECT().FindResult(gomock.Any(), "123").Return(result, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123", nil)
+	router.ServeHTTP(w, req)
+
+	var response api.BookingResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "alice", response.Result.Winner)
+	assert.Equal(t, map[string]int{"alice": 10}, response.Result.Scores)
+}
+
+func TestReportResult(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "owner"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		result := bk.Result{Winner: "alice", Scores: map[string]int{"alice": 10, "bob": 7}, Report: "close game"}
+		saved := result
+		saved.BookingID = "123"
+		saved.ReportedBy = "owner"
+		mockService.EXPECT().ReportResult(gomock.Any(), "123", result, user).Return(saved, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/result", strings.NewReader(`{"winner":"alice","scores":{"alice":10,"bob":7},"report":"close game"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"reportedBy": "owner"`)
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ReportResult(gomock.Any(), "123", gomock.Any(), user).Return(bk.Result{}, bk.ErrInvalidBookingState).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/result", strings.NewReader(`{"winner":"alice"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+		assert.JSONEq(t, `{"error":"booking is not completed"}`, w.Body.String())
+	})
+
+	t.Run("unknown player", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		err := fmt.Errorf("%w: 'carol' is not a player", bk.ErrInvalidResult)
+		mockService.EXPECT().ReportResult(gomock.Any(), "123", gomock.Any(), user).Return(bk.Result{}, err).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/result", strings.NewReader(`{"winner":"carol"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"result","message":"invalid result: 'carol' is not a player"}]}`, w.Body.String())
+	})
+
+	t.Run("report too long", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		body, _ := json.Marshal(bk.Result{Report: strings.Repeat("a", 2001)})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/result", bytes.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"report"`)
+	})
+}
+
+func TestRetryNotification(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RetryNotification(gomock.Any(), "123").Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/notification/retry", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"notification sent"}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/notification/retry", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RetryNotification(gomock.Any(), "123").Return(bk.ErrNotificationFailed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/notification/retry", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 502, w.Code)
+		assert.JSONEq(t, `{"error":"failed to send notification"}`, w.Body.String())
+	})
+}
+
+func TestListIncludingDeleted(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("admin", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		deletedAt := time.Now()
+		bookings := []bk.Booking{{ID: "1", DeletedAt: &deletedAt}}
+		mockService.EXPECT().FindBookings(gomock.Any(), gomock.Any(), bk.Filter{}).Times(0)
+		mockService.EXPECT().GetActiveBookingsIncludingDeleted(gomock.Any()).Return(bookings, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?includeDeleted=true", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"deletedAt"`)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, nonAdmin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetActiveBookingsIncludingDeleted(gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings?includeDeleted=true", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestModify(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		body := []byte(`{"game":"SW"}`)
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"message":"booking modified"}`, w.Body.String())
+	})
+
+	t.Run("too many players", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		err := fmt.Errorf("%w: 3 players, at most 2", bk.ErrTooManyPlayers)
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(err).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"game":"SW","players":["a","b","c"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"players","message":"too many players: 3 players, at most 2"}]}`, w.Body.String())
+	})
+
+	t.Run("invalid custom fields", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		err := fmt.Errorf("%w: SW needs the field 'mission'", bk.ErrInvalidCustomFields)
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(err).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"game":"SW","customFields":{"mapSize":44}}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"customFields","message":"invalid custom fields: SW needs the field 'mission'"}]}`, w.Body.String())
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(bk.ErrBookingLocked).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"game":"SW"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+		assert.JSONEq(t, `{"error":"booking can no longer be modified"}`, w.Body.String())
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		body := []byte(`{"game":"SW"}`)
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(bk.ErrNotAllowed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed to modify this booking"}`, w.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		body := []byte(`{"game":"SW"}`)
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to modify booking"}`, w.Body.String())
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString("{"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse JSON body"}`, w.Body.String())
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"game":"SW","points":"many"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse JSON body","details":[{"field":"points","message":"expected int, got string"}]}`, w.Body.String())
+	})
+
+	t.Run("date without offset", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"game":"SW","points":2,"dateTime":"2026-03-12 19:00"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"dateTime"`)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		router, ctrl, _ := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBufferString(`{"points":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"game","message":"is required"}]}`, w.Body.String())
+	})
+}
+
+func TestGetGameStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		stats := []bk.GameBookingCount{{Game: "SW", Count: 2}}
+		statsJson, _ := json.MarshalIndent(stats, "", "    ")
+		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(stats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(statsJson), w.Body.String())
+	})
+
+	t.Run("as csv", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		stats := []bk.GameBookingCount{{Game: "SW", Count: 2}, {Game: "Warhammer 40,000", Count: 5}}
+		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(stats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game?format=csv", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=stats-game.csv", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "\uFEFFgame,bookingCount\nSW,2\n\"Warhammer 40,000\",5\n", w.Body.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game?format=xlsx", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"format must be one of json, csv"}`, w.Body.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+	})
+}
+
+func TestGetTagStats(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	stats := []bk.TagBookingCount{{Tag: "beginner-friendly", Count: 3}, {Tag: "tournament-prep", Count: 1}}
+	mockService.EXPECT().GetBookingCountPerTag(gomock.Any()).Return(stats, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/tags", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `[{"tag":"beginner-friendly","bookingCount":3},{"tag":"tournament-prep","bookingCount":1}]`, w.Body.String())
+}
+
+func TestCheckInCode(t *testing.T) {
+	player := discord.DiscordUser{ID: "2", Username: "player"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, player)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CheckInURL(gomock.Any(), "123", gomock.Any()).Return("https://club.example/check-in/123?token=abc", nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123/check-in/qr", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+		_, err := png.Decode(w.Body)
+		assert.Nil(t, err)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, player)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CheckInURL(gomock.Any(), "123", gomock.Any()).Return("", bk.ErrNotAllowed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/123/check-in/qr", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestReplacePlayer(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "alice"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ReplacePlayer(gomock.Any(), "123", "alice", "carol", user).Return(bk.Booking{ID: "123", UserID: "1", Players: []string{"owner", "carol"}}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/players/replace", strings.NewReader(`{"oldPlayer":"alice","newPlayer":"carol"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []any{"owner", "carol"}, response["players"])
+	})
+
+	t.Run("unknown new player", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		unknown := &bk.UnknownPlayersError{Players: []bk.UnknownPlayer{{Username: "dave", Suggestions: []string{"davy"}}}}
+		mockService.EXPECT().ReplacePlayer(gomock.Any(), "123", "alice", "dave", user).Return(bk.Booking{}, unknown).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/players/replace", strings.NewReader(`{"oldPlayer":"alice","newPlayer":"dave"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"data":null,"meta":{},"error":{"message":"unknown players","details":[{"field":"newPlayer","message":"'dave' is not a member of the server","suggestions":["davy"]}]}}`, w.Body.String())
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ReplacePlayer(gomock.Any(), "123", "bob", "carol", user).Return(bk.Booking{}, bk.ErrNotAllowed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/players/replace", strings.NewReader(`{"oldPlayer":"bob","newPlayer":"carol"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestBookingPriority(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("set priority", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetPriority(gomock.Any(), "123", "league").Return(bk.Booking{ID: "123", Priority: "league"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/priority", strings.NewReader(`{"priority":"league"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response struct{ Data map[string]any }
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "league", response.Data["priority"])
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		queue := []bk.QueueEntry{
+			{
+				Booking:   bk.Booking{ID: "3", Priority: "tournament", Username: "alice"},
+				Conflicts: []bk.Conflict{{ID: "4", Game: "Necromunda", Status: "accepted"}},
+				Requester: bk.OrganizerHistory{Username: "alice", Bookings: 3, NoShows: 1, Cancellations: 2},
+			},
+			{Booking: bk.Booking{ID: "1", Priority: "normal"}, Conflicts: []bk.Conflict{}, TablesFull: true},
+		}
+		mockService.EXPECT().GetPendingQueue(gomock.Any()).Return(queue, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/queue", nil)
+		router.ServeHTTP(w, req)
+
+		var response []api.QueueEntryResponse
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 2)
+		assert.Equal(t, "3", response[0].ID)
+		assert.Equal(t, "4", response[0].Conflicts[0].ID)
+		assert.Equal(t, bk.OrganizerHistory{Username: "alice", Bookings: 3, NoShows: 1, Cancellations: 2}, response[0].Requester)
+		assert.True(t, response[1].TablesFull)
+	})
+
+	t.Run("queue needs an admin", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "2", Username: "alice"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetPendingQueue(gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/queue", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("accepting takes a table a higher priority needs", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(bk.ErrPriorityConflict).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+	})
+}
+
+func TestBookingPayment(t *testing.T) {
+	t.Run("get payment", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, discord.DiscordUser{ID: "1", Username: "alice"})
+		defer ctrl.Finish()
+
+		payment := bk.Payment{BookingID: "123", SessionID: "cs_1", URL: "https://checkout.example/cs_1", Amount: 500, Currency: "eur", Status: bk.PaymentUnpaid}
+		mockService.EXPECT().GetPayment(gomock.Any(), "123", gomock.Any()).Return(payment, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/123/payment", nil)
+		router.ServeHTTP(w, req)
+
+		var response struct{ Data map[string]any }
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://checkout.example/cs_1", response.Data["url"])
+		assert.Equal(t, "unpaid", response.Data["status"])
+	})
+
+	t.Run("no payment", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "1", Username: "alice"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetPayment(gomock.Any(), "123", gomock.Any()).Return(bk.Payment{}, bk.ErrPaymentNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123/payment", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("admin override", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetPaymentStatus(gomock.Any(), "123", "waived").Return(bk.Booking{ID: "123", PaymentStatus: "waived"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/payment", strings.NewReader(`{"status":"waived"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		var response map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "waived", response["paymentStatus"])
+	})
+
+	t.Run("override needs an admin", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, discord.DiscordUser{ID: "1", Username: "alice"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetPaymentStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/payment", strings.NewReader(`{"status":"paid"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetPaymentStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/payment", strings.NewReader(`{"status":"refunded"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+	})
+}
+
+func TestGetPairStats(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	stats := []bk.PairBookingCount{{PlayerA: "jane.doe", PlayerB: "john.doe", Count: 4}}
+	mockService.EXPECT().GetBookingCountPerPair(gomock.Any(), 10).Return(stats, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/pairs?limit=10", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `[{"playerA":"jane.doe","playerB":"john.doe","bookingCount":4}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings/stats/pairs?limit=ten", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 400, w.Code)
+	assert.JSONEq(t, `{"error":"failed to parse limit"}`, w.Body.String())
+}
+
+func TestGetHeatmap(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	heatmap := bk.Heatmap{From: from, To: to}
+	heatmap.Counts[time.Friday][20] = 3
+	mockService.EXPECT().GetHeatmap(gomock.Any(), from, to).Return(heatmap, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/heatmap?from=2026-03-01&to=2026-04-01", nil)
+	router.ServeHTTP(w, req)
+
+	var body bk.Heatmap
+	json.Unmarshal(w.Body.Bytes(), &body)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, 3, body.Counts[5][20])
+	assert.Len(t, body.Counts, 7)
+
+	mockService.EXPECT().GetHeatmap(gomock.Any(), to, from).Return(bk.Heatmap{}, fmt.Errorf("%w: to is before from", bk.ErrInvalidFilter)).Times(1)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings/stats/heatmap?from=2026-04-01&to=2026-03-01", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 400, w.Code)
+	assert.JSONEq(t, `{"error":"invalid filter: to is before from"}`, w.Body.String())
+}
+
+func TestGetApprovalLatencyStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		stats := []bk.ApprovalLatency{{Month: "2026-03", Decisions: 4, AverageSeconds: 14400, MedianSeconds: 9000, P95Seconds: 32220}}
+		mockService.EXPECT().GetApprovalLatencies(gomock.Any(), from, time.Time{}).Return(stats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/approval-latency?from=2026-01-01", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `[{"month":"2026-03","decisions":4,"averageSeconds":14400,"medianSeconds":9000,"p95Seconds":32220}]`, w.Body.String())
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "2", Username: "john"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetApprovalLatencies(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/approval-latency", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestGetNoShowStats(t *testing.T) {
+	router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+	defer ctrl.Finish()
+
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := []bk.NoShowCount{{UserID: "1", Username: "alice", Bookings: 4, NoShows: 1}}
+	mockService.EXPECT().GetNoShowCounts(gomock.Any(), since).Return(stats, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/no-shows?since=2026-01-01", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `[{"userId":"1","username":"alice","bookings":4,"noShows":1}]`, w.Body.String())
+
+	mockService.EXPECT().GetNoShowCounts(gomock.Any(), since).Return(stats, nil).Times(1)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/bookings/stats/no-shows?since=2026-01-01&format=csv", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "\uFEFFuserId,username,bookings,noShows\n1,alice,4,1\n", w.Body.String())
+}
+
+func TestGetGameStatsPerPeriod(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		startStr := "2026-02-01"
+		endStr := "2026-02-07"
+		startTime, _ := time.Parse(time.DateOnly, startStr)
+		endTime, _ := time.Parse(time.DateOnly, endStr)
+
+		stats := []bk.GameBookingCount{{Game: "SW", Count: 2}}
+		statsJson, _ := json.MarshalIndent(stats, "", "    ")
+		mockService.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), startTime, endTime).Return(stats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod="+startStr+"&endPeriod="+endStr, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(statsJson), w.Body.String())
+	})
+
+	t.Run("bad startPeriod", func(t *testing.T) {
+		router, ctrl, _ := setupRouter(t)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod=bad&endPeriod=2026-02-07", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse startPeriod"}`, w.Body.String())
+	})
+
+	t.Run("bad endPeriod", func(t *testing.T) {
+		router, ctrl, _ := setupRouter(t)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod=2026-02-01&endPeriod=bad", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.JSONEq(t, `{"error":"failed to parse endPeriod"}`, w.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		startStr := "2026-02-01"
+		endStr := "2026-02-07"
+		startTime, _ := time.Parse(time.DateOnly, startStr)
+		endTime, _ := time.Parse(time.DateOnly, endStr)
+
+		mockService.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), startTime, endTime).Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod="+startStr+"&endPeriod="+endStr, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+	})
+}
+
+func TestGetGameStatsPerDay(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		stats := []bk.WeekDayBookingCount{{WeekDay: "Monday", Count: 2}}
+		statsJson, _ := json.MarshalIndent(stats, "", "    ")
+		mockService.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(stats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/day", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, string(statsJson), w.Body.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		router, ctrl, mockService := setupRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/day", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+	})
+}
+
+func setupV2RouterWithUser(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockBookingService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockBookingService(ctrl)
+	handler := api.NewBookingHandler(mockService)
+	rg := router.Group("/api/v2")
+	rg.Use(api.Enveloped(), setUserInContext(user))
+	handler.RegisterV2(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestV2Routes(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("booking by id", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().FindBookingByID(gomock.Any(), "123").Return(bk.Booking{ID: "123"}, nil).Times(1)
+		mockService.EXPECT().GetAttachments(gomock.Any(), "123", gomock.Any()).Return([]bk.Attachment{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/123", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
 	})
 
-	t.Run("forbidden", func(t *testing.T) {
-		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+	t.Run("bookings per username", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().FindBookingsPerUsername(gomock.Any(), gomock.Any(), "stats").Return([]bk.Booking{}, nil).Times(1)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBufferString("["))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v2/users/stats/bookings", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 403, w.Code)
-		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+		assert.Equal(t, 200, w.Code)
 	})
 
-	t.Run("service error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("stats", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
 		defer ctrl.Finish()
 
-		toImport := []bk.Booking{
-			{Game: "SW", Username: "john"},
-			{Game: "Other", Username: "jane"},
-		}
-		body, _ := json.Marshal(toImport)
-		mockService.EXPECT().ImportBookings(gomock.Any(), gomock.Any()).Return(assert.AnError).Times(1)
+		mockService.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return([]bk.WeekDayBookingCount{}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("POST", "/api/v1/bookings/import", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("GET", "/api/v2/stats/day", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to import bookings"}`, w.Body.String())
+		assert.Equal(t, 200, w.Code)
 	})
-}
-
-func TestAccept(t *testing.T) {
-	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
-	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
 
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("accept", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
 		defer ctrl.Finish()
 
 		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/accept", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, `{"message":"booking accepted"}`, w.Body.String())
+		assert.JSONEq(t, `{"data":{"message":"booking accepted"},"meta":{}}`, w.Body.String())
 	})
+}
 
-	t.Run("forbidden", func(t *testing.T) {
-		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+func TestAttach(t *testing.T) {
+	user := discord.DiscordUser{ID: "user1ID", Username: "user1"}
+
+	upload := func(content string) *http.Request {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "list.txt")
+		part.Write([]byte(content))
+		form.Close()
+
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/attachments", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().AddAttachment(gomock.Any(), "123", "list.txt", gomock.Any(), user).
+			DoAndReturn(func(ctx context.Context, bookingID, filename string, content io.Reader, user discord.DiscordUser) (bk.Attachment, error) {
+				data, _ := io.ReadAll(content)
+				return bk.Attachment{ID: "4", BookingID: bookingID, Filename: filename, Size: int64(len(data))}, nil
+			}).Times(1)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
-		router.ServeHTTP(w, req)
+		router.ServeHTTP(w, upload("2000 points of Space Marines"))
 
-		assert.Equal(t, 403, w.Code)
-		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"size":28`)
+		assert.Contains(t, w.Body.String(), `"url":"/api/v1/bookings/booking/123/attachments/4"`)
 	})
 
-	t.Run("not found", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("type not accepted", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(bk.ErrBookingNotFound).Times(1)
+		mockService.EXPECT().AddAttachment(gomock.Any(), "123", "list.txt", gomock.Any(), user).
+			Return(bk.Attachment{}, fmt.Errorf("%w: application/zip files are not accepted", bk.ErrInvalidAttachment)).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
-		router.ServeHTTP(w, req)
+		router.ServeHTTP(w, upload("PK"))
 
-		assert.Equal(t, 404, w.Code)
-		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+		assert.Equal(t, 422, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"file"`)
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("missing file", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(bk.ErrInvalidBookingState).Times(1)
+		mockService.EXPECT().AddAttachment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/123/attachments", strings.NewReader(`{}`))
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
 	})
+}
 
-	t.Run("other error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
-		defer ctrl.Finish()
+func TestDownloadAttachment(t *testing.T) {
+	router, ctrl, mockService := setupRouter(t)
+	defer ctrl.Finish()
 
-		mockService.EXPECT().AcceptBooking(gomock.Any(), "123").Return(assert.AnError).Times(1)
+	attachment := bk.Attachment{ID: "4", BookingID: "123", Filename: "liste armée.txt", ContentType: "text/plain; charset=utf-8", Size: 5}
+	mockService.EXPECT().OpenAttachment(gomock.Any(), "123", "4", gomock.Any()).
+		Return(attachment, io.NopCloser(strings.NewReader("hello")), nil).Times(1)
 
-		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/accept", nil)
-		router.ServeHTTP(w, req)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/bookings/booking/123/attachments/4", nil)
+	router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to accept booking"}`, w.Body.String())
-	})
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "hello", w.Body.String())
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename*=utf-8''liste%20arm%C3%A9e.txt", w.Header().Get("Content-Disposition"))
 }
 
-func TestRefuse(t *testing.T) {
-	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
-	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+func TestSuggestSlots(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "john.doe"}
+	around := time.Date(2030, 3, 8, 0, 0, 0, 0, time.UTC)
 
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("suggest", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(nil).Times(1)
+		request := bk.SlotRequest{Game: "Necromunda", Duration: 3 * time.Hour, Around: around}
+		slot := bk.FreeSlot{DateTime: time.Date(2030, 3, 8, 19, 0, 0, 0, time.UTC), FreeTables: 2, OpenBookings: 1}
+		mockService.EXPECT().FindFreeSlots(gomock.Any(), request, user).Return([]bk.FreeSlot{slot}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/suggest?game=Necromunda&duration=3h&around=2030-03-08", nil)
 		router.ServeHTTP(w, req)
 
+		var response []map[string]any
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, `{"message":"booking refused"}`, w.Body.String())
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response, 1)
+		assert.Equal(t, 2.0, response[0]["freeTables"])
+		assert.Equal(t, 1.0, response[0]["openBookings"])
+		assert.NotEmpty(t, response[0]["displayDate"])
 	})
 
-	t.Run("forbidden", func(t *testing.T) {
-		router, ctrl, _ := setupRouterWithUser(t, nonAdmin)
+	t.Run("invalid duration", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().FindFreeSlots(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/suggest?duration=long", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 403, w.Code)
-		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+		assert.Equal(t, 400, w.Code)
 	})
 
-	t.Run("not found", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("duration too long", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(bk.ErrBookingNotFound).Times(1)
+		mockService.EXPECT().FindFreeSlots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, bk.ErrInvalidDuration).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/suggest?duration=48h", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 404, w.Code)
-		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+		assert.Equal(t, 400, w.Code)
 	})
+}
 
-	t.Run("invalid state", func(t *testing.T) {
+func TestReopenBooking(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("reopen", func(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, admin)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(bk.ErrInvalidBookingState).Times(1)
+		mockService.EXPECT().ReopenBooking(gomock.Any(), "123").Return(bk.Booking{ID: "123", Status: "pending"}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reopen", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
+		var response map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "pending", response["status"])
 	})
 
-	t.Run("other error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, admin)
+	t.Run("slot taken", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, admin)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().RefuseBooking(gomock.Any(), "123", "no").Return(assert.AnError).Times(1)
+		mockService.EXPECT().ReopenBooking(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrSlotTaken).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/refuse?reason=no", nil)
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/reopen", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to refuse booking"}`, w.Body.String())
+		assert.Equal(t, 409, w.Code)
 	})
-}
-
-func TestCancel(t *testing.T) {
-	user := discord.DiscordUser{ID: "1", Username: "user", Admin: false}
 
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, user)
+	t.Run("needs an admin", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, discord.DiscordUser{ID: "2", Username: "alice"})
 		defer ctrl.Finish()
 
-		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(nil).Times(1)
+		mockService.EXPECT().ReopenBooking(gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reopen", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, `{"message":"booking canceled"}`, w.Body.String())
+		assert.Equal(t, 403, w.Code)
 	})
+}
 
-	t.Run("not found", func(t *testing.T) {
+func TestLeave(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "alice"}
+
+	t.Run("success", func(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(bk.ErrBookingNotFound).Times(1)
+		booking := bk.Booking{ID: "123", UserID: "1", Status: "pending", Players: []string{"owner"}, LookingForPlayers: true, DateTime: time.Now().Add(time.Hour)}
+		mockService.EXPECT().LeaveBooking(gomock.Any(), "123", user).Return(booking, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/leave", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 404, w.Code)
-		assert.JSONEq(t, `{"error":"booking not found"}`, w.Body.String())
+		var response map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, true, response["canJoin"])
+		assert.Equal(t, false, response["canLeave"])
+		assert.Equal(t, false, response["canModify"])
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, user)
+	t.Run("not playing", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(bk.ErrInvalidBookingState).Times(1)
+		mockService.EXPECT().LeaveBooking(gomock.Any(), "123", user).Return(bk.Booking{}, bk.ErrPlayerNotFound).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/leave", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"invalid booking state"}`, w.Body.String())
+		assert.Equal(t, 409, w.Code)
 	})
 
-	t.Run("other error", func(t *testing.T) {
+	t.Run("the organizer", func(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().CancelBooking(gomock.Any(), "123", user).Return(assert.AnError).Times(1)
+		mockService.EXPECT().LeaveBooking(gomock.Any(), "123", user).Return(bk.Booking{}, bk.ErrNotAllowed).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/cancel", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/leave", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to cancel booking"}`, w.Body.String())
+		assert.Equal(t, 403, w.Code)
 	})
 }
 
-func TestModify(t *testing.T) {
-	user := discord.DiscordUser{ID: "1", Username: "user", Admin: false}
+func TestSetReminders(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "owner"}
 
 	t.Run("success", func(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		body := []byte(`{"game":"SW"}`)
-		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(nil).Times(1)
+		reminders := bk.Reminders{LeadTimes: []int{1440, 120}, Channel: "channel"}
+		booking := bk.Booking{ID: "123", UserID: "1", Status: "accepted", Reminders: &reminders}
+		mockService.EXPECT().SetReminders(gomock.Any(), "123", reminders, user).Return(booking, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders", bytes.NewBufferString(`{"leadTimes":[1440,120],"channel":"channel"}`))
 		router.ServeHTTP(w, req)
 
+		var response map[string]any
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, `{"message":"booking modified"}`, w.Body.String())
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, map[string]any{"leadTimes": []any{1440.0, 120.0}, "channel": "channel", "optedOut": nil, "responses": nil}, response["reminders"])
 	})
 
-	t.Run("not allowed", func(t *testing.T) {
-		router, ctrl, mockService := setupRouterWithUser(t, user)
+	t.Run("lead time out of range", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		body := []byte(`{"game":"SW"}`)
-		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(bk.ErrNotAllowed).Times(1)
+		mockService.EXPECT().SetReminders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/reminders", bytes.NewBufferString(`{"leadTimes":[1]}`))
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 403, w.Code)
-		assert.JSONEq(t, `{"error":"not allowed to modify this booking"}`, w.Body.String())
+		assert.Equal(t, 422, w.Code)
 	})
 
-	t.Run("service error", func(t *testing.T) {
+	t.Run("not an organizer", func(t *testing.T) {
 		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		body := []byte(`{"game":"SW"}`)
-		mockService.EXPECT().ModifyBooking(gomock.Any(), gomock.Any(), user).Return(assert.AnError).Times(1)
+		mockService.EXPECT().SetReminders(gomock.Any(), "123", gomock.Any(), user).Return(bk.Booking{}, bk.ErrNotAllowed).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/modify", bytes.NewBuffer(body))
-		req.Header.Set("Content-Type", "application/json")
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders", bytes.NewBufferString(`{"leadTimes":[120]}`))
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to modify booking"}`, w.Body.String())
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed to change the reminders"}`, w.Body.String())
 	})
 }
 
-func TestGetGameStats(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+func TestRespondToReminders(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "alice"}
+
+	t.Run("acknowledge", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		stats := []bk.GameBookingCount{{Game: "SW", Count: 2}}
-		statsJson, _ := json.MarshalIndent(stats, "", "    ")
-		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(stats, nil).Times(1)
+		mockService.EXPECT().AcknowledgeReminder(gomock.Any(), "123", user).Return(bk.Booking{ID: "123"}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders/acknowledge", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, string(statsJson), w.Body.String())
 	})
 
-	t.Run("error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("snooze for an hour by default", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(nil, assert.AnError).Times(1)
+		mockService.EXPECT().SnoozeReminder(gomock.Any(), "123", time.Hour, user).Return(bk.Booking{ID: "123"}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game", nil)
+		req, _ := http.NewRequest("PUT", "/api/v2/bookings/123/reminders/snooze", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+		assert.Equal(t, 200, w.Code)
 	})
-}
 
-func TestGetGameStatsPerPeriod(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("snooze for a while", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		startStr := "2026-02-01"
-		endStr := "2026-02-07"
-		startTime, _ := time.Parse(time.DateOnly, startStr)
-		endTime, _ := time.Parse(time.DateOnly, endStr)
-
-		stats := []bk.GameBookingCount{{Game: "SW", Count: 2}}
-		statsJson, _ := json.MarshalIndent(stats, "", "    ")
-		mockService.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), startTime, endTime).Return(stats, nil).Times(1)
+		mockService.EXPECT().SnoozeReminder(gomock.Any(), "123", 30*time.Minute, user).Return(bk.Booking{ID: "123"}, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod="+startStr+"&endPeriod="+endStr, nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders/snooze", bytes.NewBufferString(`{"minutes":30}`))
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, string(statsJson), w.Body.String())
 	})
 
-	t.Run("bad startPeriod", func(t *testing.T) {
-		router, ctrl, _ := setupRouter(t)
+	t.Run("booking over", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().AcknowledgeReminder(gomock.Any(), "123", user).Return(bk.Booking{}, bk.ErrInvalidBookingState).Times(1)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod=bad&endPeriod=2026-02-07", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders/acknowledge", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to parse startPeriod"}`, w.Body.String())
 	})
+}
 
-	t.Run("bad endPeriod", func(t *testing.T) {
-		router, ctrl, _ := setupRouter(t)
+func TestOptOutOfReminders(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "alice"}
+
+	t.Run("opt out", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
+		mockService.EXPECT().OptOutOfReminders(gomock.Any(), "123", true, user).Return(bk.Booking{ID: "123"}, nil).Times(1)
+
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod=2026-02-01&endPeriod=bad", nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders/opt-out", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 400, w.Code)
-		assert.JSONEq(t, `{"error":"failed to parse endPeriod"}`, w.Body.String())
+		assert.Equal(t, 200, w.Code)
 	})
 
-	t.Run("service error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("opt back in", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		startStr := "2026-02-01"
-		endStr := "2026-02-07"
-		startTime, _ := time.Parse(time.DateOnly, startStr)
-		endTime, _ := time.Parse(time.DateOnly, endStr)
+		mockService.EXPECT().OptOutOfReminders(gomock.Any(), "123", false, user).Return(bk.Booking{ID: "123"}, nil).Times(1)
 
-		mockService.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), startTime, endTime).Return(nil, assert.AnError).Times(1)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v2/bookings/123/reminders/opt-out", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("not playing", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().OptOutOfReminders(gomock.Any(), "123", true, user).Return(bk.Booking{}, bk.ErrPlayerNotFound).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/game/period?startPeriod="+startStr+"&endPeriod="+endStr, nil)
+		req, _ := http.NewRequest("PUT", "/api/v1/bookings/123/reminders/opt-out", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+		assert.Equal(t, 409, w.Code)
 	})
 }
 
-func TestGetGameStatsPerDay(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+func TestCount(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("count", func(t *testing.T) {
+		router, ctrl, mockService := setupRouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		stats := []bk.WeekDayBookingCount{{WeekDay: "Monday", Count: 2}}
-		statsJson, _ := json.MarshalIndent(stats, "", "    ")
-		mockService.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(stats, nil).Times(1)
+		mockService.EXPECT().CountBookings(gomock.Any(), &user, bk.Filter{Status: "pending", Player: "bob"}).Return(3, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/day", nil)
+		req, _ := http.NewRequest("GET", "/api/v1/bookings/count?status=pending&player=bob", nil)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, 200, w.Code)
-		assert.JSONEq(t, string(statsJson), w.Body.String())
+		assert.JSONEq(t, `{"count":3}`, w.Body.String())
 	})
 
-	t.Run("error", func(t *testing.T) {
-		router, ctrl, mockService := setupRouter(t)
+	t.Run("invalid status", func(t *testing.T) {
+		router, ctrl, mockService := setupV2RouterWithUser(t, user)
 		defer ctrl.Finish()
 
-		mockService.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(nil, assert.AnError).Times(1)
+		mockService.EXPECT().CountBookings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest("GET", "/api/v1/bookings/stats/day", nil)
+		req, _ := http.NewRequest("GET", "/api/v2/bookings/count?status=lost", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, 500, w.Code)
-		assert.JSONEq(t, `{"error":"failed to get stats"}`, w.Body.String())
+		assert.Equal(t, 400, w.Code)
 	})
 }
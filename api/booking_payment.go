@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/payment"
+)
+
+// maxPaymentEventBody bounds the webhook events of the payment provider,
+// Stripe sending at most a few kilobytes.
+const maxPaymentEventBody = 64 << 10
+
+type PaymentStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=unpaid paid waived"`
+}
+
+// GetPayment serves the checkout of the table fee of a booking, its link
+// included, to its participants and the admins.
+func (h *BookingHandler) GetPayment(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	p, err := h.service.GetPayment(c.Request.Context(), c.Param("id"), user)
+
+	if err != nil {
+		paymentError(c, err, "failed to get payment")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, p)
+}
+
+// SetPaymentStatus lets an admin record a payment made at the venue or waive
+// the table fee.
+func (h *BookingHandler) SetPaymentStatus(c *gin.Context) {
+	var request PaymentStatusRequest
+
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	booking, err := h.service.SetPaymentStatus(c.Request.Context(), c.Param("id"), request.Status)
+
+	if err != nil {
+		paymentError(c, err, "failed to set payment status")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, booking)
+}
+
+// PaymentWebhook confirms the payments notified by Stripe, it is registered
+// without authentication and checks the signature of the events instead.
+func (h *PublicHandler) PaymentWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPaymentEventBody))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to read event")})
+		return
+	}
+
+	err = h.service.ConfirmPayment(c.Request.Context(), payload, c.GetHeader(payment.SignatureHeader))
+
+	if err != nil {
+		paymentError(c, err, "failed to confirm payment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
+
+func paymentError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+	case errors.Is(err, bk.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "payment not found")})
+	case errors.Is(err, bk.ErrPaymentsDisabled):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "payments are disabled")})
+	case errors.Is(err, bk.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to see this payment")})
+	case errors.Is(err, bk.ErrInvalidPaymentEvent):
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid payment event")})
+	case errors.Is(err, bk.ErrInvalidPaymentStatus):
+		c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "status", err))
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type ReplacePlayerRequest struct {
+	OldPlayer string `json:"oldPlayer" binding:"required,max=64"`
+	NewPlayer string `json:"newPlayer" binding:"required,max=64"`
+}
+
+// ReplacePlayer substitutes a player of a booking, for its organizers, the
+// outgoing player or an admin.
+func (h *BookingHandler) ReplacePlayer(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var request ReplacePlayerRequest
+
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	booking, err := h.service.ReplacePlayer(c.Request.Context(), c.Param("id"), request.OldPlayer, request.NewPlayer, user)
+
+	if err != nil {
+		c.Error(err)
+
+		var unknown *bk.UnknownPlayersError
+
+		switch {
+		case errors.As(err, &unknown):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": translate(c, "unknown players"), "details": []FieldError{{
+				Field:       "newPlayer",
+				Message:     fmt.Sprintf("'%v' is not a member of the server", request.NewPlayer),
+				Suggestions: unknown.Players[0].Suggestions,
+			}}})
+		case errors.Is(err, bk.ErrBookingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+		case errors.Is(err, bk.ErrNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to replace this player")})
+		case errors.Is(err, bk.ErrUserSuspended):
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "user is suspended from booking")})
+		case errors.Is(err, bk.ErrPlayerNotFound):
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "oldPlayer", err))
+		case errors.Is(err, bk.ErrAlreadyJoined):
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "newPlayer", err))
+		case errors.Is(err, bk.ErrInvalidBookingState):
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid booking state")})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to replace player")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// Leave withdraws the user from the players of a booking.
+func (h *BookingHandler) Leave(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	booking, err := h.service.LeaveBooking(c.Request.Context(), c.Param("id"), user)
+
+	if err != nil {
+		c.Error(err)
+
+		switch {
+		case errors.Is(err, bk.ErrBookingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+		case errors.Is(err, bk.ErrInvalidBookingState):
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid booking state")})
+		case errors.Is(err, bk.ErrPlayerNotFound), errors.Is(err, bk.ErrBookingLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, bk.ErrNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "the organizer cancels the booking instead")})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to leave booking")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// expandsPlayers tells whether the expand query parameter, a comma separated
+// list, asks for the players of the bookings.
+func expandsPlayers(c *gin.Context) bool {
+	return slices.Contains(strings.Split(c.Query("expand"), ","), "players")
+}
+
+// expandPlayers resolves the players of the bookings, looking each of them up
+// once.
+func (h *BookingHandler) expandPlayers(ctx context.Context, responses []BookingResponse) []ExpandedBookingResponse {
+	usernames := []string{}
+
+	for _, response := range responses {
+		for _, player := range response.Booking.Players {
+			if !slices.Contains(usernames, player) {
+				usernames = append(usernames, player)
+			}
+		}
+	}
+
+	players := make(map[string]bk.Player, len(usernames))
+
+	for i, player := range h.service.ResolvePlayers(ctx, usernames) {
+		players[usernames[i]] = player
+	}
+
+	expanded := make([]ExpandedBookingResponse, 0, len(responses))
+
+	for _, response := range responses {
+		resolved := make([]bk.Player, 0, len(response.Booking.Players))
+
+		for _, player := range response.Booking.Players {
+			resolved = append(resolved, players[player])
+		}
+
+		expanded = append(expanded, ExpandedBookingResponse{BookingResponse: response, Players: resolved})
+	}
+
+	return expanded
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type PriorityRequest struct {
+	Priority string `json:"priority" binding:"required,oneof=normal tournament league"`
+}
+
+// SetPriority lets an admin mark a booking as a tournament or league game.
+func (h *BookingHandler) SetPriority(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var request PriorityRequest
+
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	booking, err := h.service.SetPriority(c.Request.Context(), c.Param("id"), request.Priority)
+
+	if err != nil {
+		c.Error(err)
+
+		switch {
+		case errors.Is(err, bk.ErrBookingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+		case errors.Is(err, bk.ErrInvalidPriority):
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "priority", err))
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to set priority")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// QueueEntryResponse is a booking of the approval queue, with its conflicts
+// and the history of its organizer.
+type QueueEntryResponse struct {
+	BookingResponse
+	Conflicts  []bk.Conflict       `json:"conflicts"`
+	TablesFull bool                `json:"tablesFull"`
+	Requester  bk.OrganizerHistory `json:"requester"`
+}
+
+// Queue lists the bookings awaiting approval, the highest priority first then
+// the oldest request, with what speeds up the decision on them.
+func (h *BookingHandler) Queue(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	entries, err := h.service.GetPendingQueue(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve the queue")})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]QueueEntryResponse, 0, len(entries))
+
+	for _, entry := range entries {
+		responses = append(responses, QueueEntryResponse{
+			BookingResponse: NewBookingResponse(entry.Booking, &user, now),
+			Conflicts:       entry.Conflicts,
+			TablesFull:      entry.TablesFull,
+			Requester:       entry.Requester,
+		})
+	}
+
+	c.IndentedJSON(http.StatusOK, responses)
+}
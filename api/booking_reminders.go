@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type RemindersRequest struct {
+	// LeadTimes are in minutes, from 5 minutes to a week
+	LeadTimes []int  `json:"leadTimes" binding:"max=5,dive,min=5,max=10080"`
+	Channel   string `json:"channel" binding:"omitempty,oneof=dm channel"`
+}
+
+// SetReminders changes when the reminders of a booking are sent and where,
+// for its organizers or an admin.
+func (h *BookingHandler) SetReminders(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var request RemindersRequest
+
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	reminders := bk.Reminders{LeadTimes: request.LeadTimes, Channel: request.Channel}
+	booking, err := h.service.SetReminders(c.Request.Context(), c.Param("id"), reminders, user)
+
+	if err != nil {
+		c.Error(err)
+
+		switch {
+		case errors.Is(err, bk.ErrBookingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+		case errors.Is(err, bk.ErrNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to change the reminders")})
+		case errors.Is(err, bk.ErrInvalidReminders):
+			c.JSON(http.StatusUnprocessableEntity, fieldErrorResponse(c, "leadTimes", err))
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to set reminders")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// OptOutOfReminders stops the reminders of a booking for the user.
+func (h *BookingHandler) OptOutOfReminders(c *gin.Context) {
+	h.setReminderOptOut(c, true)
+}
+
+// OptInToReminders resumes the reminders of a booking for the user.
+func (h *BookingHandler) OptInToReminders(c *gin.Context) {
+	h.setReminderOptOut(c, false)
+}
+
+func (h *BookingHandler) setReminderOptOut(c *gin.Context, optOut bool) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	booking, err := h.service.OptOutOfReminders(c.Request.Context(), c.Param("id"), optOut, user)
+
+	if err != nil {
+		reminderError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// AcknowledgeReminder confirms the user attends a booking, stopping its
+// reminders for them.
+func (h *BookingHandler) AcknowledgeReminder(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	booking, err := h.service.AcknowledgeReminder(c.Request.Context(), c.Param("id"), user)
+
+	if err != nil {
+		reminderError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+type SnoozeRequest struct {
+	// Minutes postpone the reminders, an hour by default
+	Minutes int `json:"minutes" binding:"omitempty,min=5,max=1440"`
+}
+
+// SnoozeReminder postpones the reminders of a booking for the user.
+func (h *BookingHandler) SnoozeReminder(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var request SnoozeRequest
+
+	// the body is optional, the default snooze applying without it
+	if c.Request.ContentLength != 0 && !bindJSON(c, &request) {
+		return
+	}
+
+	snooze := bk.DefaultSnooze
+
+	if request.Minutes != 0 {
+		snooze = time.Duration(request.Minutes) * time.Minute
+	}
+
+	booking, err := h.service.SnoozeReminder(c.Request.Context(), c.Param("id"), snooze, user)
+
+	if err != nil {
+		reminderError(c, err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, NewBookingResponse(booking, &user, time.Now()))
+}
+
+// reminderError answers a failed response of a participant to the reminders
+// of a booking.
+func reminderError(c *gin.Context, err error) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+	case errors.Is(err, bk.ErrInvalidBookingState):
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid booking state")})
+	case errors.Is(err, bk.ErrPlayerNotFound):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to set reminders")})
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// BookingResponse is the API representation of a booking, adding fields
+// derived for the requesting user to the stored model.
+type BookingResponse struct {
+	bk.Booking
+	IsPast      bool   `json:"isPast"`
+	CanModify   bool   `json:"canModify"`
+	CanCancel   bool   `json:"canCancel"`
+	CanJoin     bool   `json:"canJoin"`
+	CanLeave    bool   `json:"canLeave"`
+	DisplayDate string `json:"displayDate"`
+	// Result is only set on the detail of a completed booking
+	Result *bk.Result `json:"result,omitempty"`
+	// Attachments are only listed on the detail of a booking
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+}
+
+// ExpandedBookingResponse is a booking with its players resolved from the
+// member directory, for the expand=players query parameter.
+type ExpandedBookingResponse struct {
+	BookingResponse
+	Players []bk.Player `json:"players"`
+}
+
+// CreatedBookingResponse is a booking just created, with the conflicts it
+// was created despite when the conflicts are warnings.
+type CreatedBookingResponse struct {
+	BookingResponse
+	Warnings []bk.Warning `json:"warnings,omitempty"`
+}
+
+// BookingHistoryResponse is a page of the booking history, NextCursor is
+// passed back as the cursor query parameter to fetch the next page.
+type BookingHistoryResponse struct {
+	Bookings   []BookingResponse `json:"bookings"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+// BookingSearchResult is a booking matching a search, with its relevance and
+// highlighted excerpt.
+type BookingSearchResult struct {
+	BookingResponse
+	Rank      float32 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+// displayLocation is the guild's time zone, the dates of the responses are
+// rendered in it.
+var displayLocation = loadDisplayLocation()
+
+var frenchWeekDays = [...]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"}
+
+var frenchMonths = [...]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+
+func loadDisplayLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Paris")
+
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
+// SetDisplayLocation sets the time zone the booking dates are rendered in,
+// Europe/Paris by default. It must be called before serving requests.
+func SetDisplayLocation(loc *time.Location) {
+	displayLocation = loc
+}
+
+func NewBookingResponse(booking bk.Booking, user *discord.DiscordUser, now time.Time) BookingResponse {
+	// stored in UTC, sent with the offset of the guild's time zone
+	booking.DateTime = booking.DateTime.In(displayLocation)
+
+	response := BookingResponse{
+		Booking:     booking,
+		IsPast:      booking.DateTime.Before(now),
+		DisplayDate: formatDisplayDate(booking.DateTime),
+	}
+
+	if user != nil {
+		response.CanModify = bk.CanModify(booking, *user)
+		response.CanCancel = bk.CanCancel(booking, *user)
+		response.CanJoin = bk.CanJoin(booking, *user, now)
+		response.CanLeave = bk.CanLeave(booking, *user, now)
+	}
+
+	return response
+}
+
+func NewBookingResponses(bookings []bk.Booking, user *discord.DiscordUser, now time.Time) []BookingResponse {
+	responses := make([]BookingResponse, 0, len(bookings))
+
+	for _, booking := range bookings {
+		responses = append(responses, NewBookingResponse(booking, user, now))
+	}
+
+	return responses
+}
+
+func NewBookingSearchResults(results []bk.SearchResult, user *discord.DiscordUser, now time.Time) []BookingSearchResult {
+	responses := make([]BookingSearchResult, 0, len(results))
+
+	for _, result := range results {
+		responses = append(responses, BookingSearchResult{
+			BookingResponse: NewBookingResponse(result.Booking, user, now),
+			Rank:            result.Rank,
+			Highlight:       result.Highlight,
+		})
+	}
+
+	return responses
+}
+
+// formatDisplayDate renders the date the way the club writes it,
+// e.g. "jeudi 12 mars 2026 à 19:00".
+func formatDisplayDate(t time.Time) string {
+	t = t.In(displayLocation)
+
+	return fmt.Sprintf("%s %d %s %d à %s", frenchWeekDays[t.Weekday()], t.Day(), frenchMonths[t.Month()-1], t.Year(), t.Format("15:04"))
+}
+
+func requestUser(c *gin.Context) *discord.DiscordUser {
+	if value, ok := c.Get("user"); ok {
+		if user, ok := value.(discord.DiscordUser); ok {
+			return &user
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Suggest proposes the free slots nearest to the date of the around
+// parameter for a game lasting duration, e.g. 3h30m, so the user picks one
+// that will not be refused for a lack of tables.
+func (h *BookingHandler) Suggest(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	request := bk.SlotRequest{Game: c.Query("game")}
+
+	around, err := parseOptionalTime(c.Query("around"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse around")})
+		return
+	}
+
+	request.Around = around
+
+	if duration := c.Query("duration"); len(duration) != 0 {
+		if request.Duration, err = time.ParseDuration(duration); err != nil {
+			c.Error(err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse duration")})
+			return
+		}
+	}
+
+	slots, err := h.service.FindFreeSlots(c.Request.Context(), request, user)
+
+	if err != nil {
+		c.Error(err)
+
+		if errors.Is(err, bk.ErrInvalidDuration) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to suggest slots")})
+		}
+		return
+	}
+
+	response := make([]FreeSlotResponse, 0, len(slots))
+
+	for _, slot := range slots {
+		slot.DateTime = slot.DateTime.In(displayLocation)
+		response = append(response, FreeSlotResponse{FreeSlot: slot, DisplayDate: formatDisplayDate(slot.DateTime)})
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+}
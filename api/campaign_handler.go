@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/campaign"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type CampaignService interface {
+	GetCampaigns(ctx context.Context) ([]campaign.Campaign, error)
+	GetCampaign(ctx context.Context, id string, user *discord.DiscordUser) (campaign.Detail, error)
+	CreateCampaign(ctx context.Context, campaign campaign.Campaign) (campaign.Campaign, error)
+	DeleteCampaign(ctx context.Context, id string) error
+	AttachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error
+	DetachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error
+}
+
+// CampaignHandler serves the campaigns, admins create them and organizers
+// attach their bookings.
+type CampaignHandler struct {
+	service CampaignService
+}
+
+func NewCampaignHandler(service CampaignService) *CampaignHandler {
+	return &CampaignHandler{service: service}
+}
+
+func (h *CampaignHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("", h.List)
+	rg.GET("/:id", h.Get)
+	rg.POST("", adminOnly, h.Create)
+	rg.DELETE("/:id", adminOnly, h.Delete)
+	rg.PUT("/:id/bookings/:bookingId", h.Attach)
+	rg.DELETE("/:id/bookings/:bookingId", h.Detach)
+}
+
+func (h *CampaignHandler) List(c *gin.Context) {
+	campaigns, err := h.service.GetCampaigns(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve campaigns")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, campaigns)
+}
+
+func (h *CampaignHandler) Get(c *gin.Context) {
+	detail, err := h.service.GetCampaign(c.Request.Context(), c.Param("id"), requestUser(c))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "campaign not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve campaign")})
+		}
+		return
+	}
+
+	for i := range detail.Games {
+		detail.Games[i].DateTime = detail.Games[i].DateTime.In(displayLocation)
+	}
+
+	c.IndentedJSON(http.StatusOK, detail)
+}
+
+func (h *CampaignHandler) Create(c *gin.Context) {
+	var cp campaign.Campaign
+
+	if !bindJSON(c, &cp) {
+		return
+	}
+
+	created, err := h.service.CreateCampaign(c.Request.Context(), cp)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, campaign.ErrInvalidCampaign) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to create campaign")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *CampaignHandler) Delete(c *gin.Context) {
+	err := h.service.DeleteCampaign(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, campaign.ErrCampaignNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "campaign not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to delete campaign")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "campaign deleted")})
+}
+
+func (h *CampaignHandler) Attach(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	err := h.service.AttachBooking(c.Request.Context(), c.Param("id"), c.Param("bookingId"), user)
+
+	if err != nil {
+		h.bookingError(c, err, "failed to attach booking")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking attached")})
+}
+
+func (h *CampaignHandler) Detach(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	err := h.service.DetachBooking(c.Request.Context(), c.Param("id"), c.Param("bookingId"), user)
+
+	if err != nil {
+		h.bookingError(c, err, "failed to detach booking")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "booking detached")})
+}
+
+func (h *CampaignHandler) bookingError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, campaign.ErrCampaignNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "campaign not found")})
+	case errors.Is(err, bk.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "booking not found")})
+	case errors.Is(err, campaign.ErrBookingNotAttached):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, campaign.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed to change the campaign of this booking")})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
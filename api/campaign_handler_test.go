@@ -0,0 +1,95 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/campaign"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ranking"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupCampaignRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockCampaignService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockCampaignService(ctrl)
+	handler := api.NewCampaignHandler(mockService)
+	rg := router.Group("/api/v1/campaigns")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestGetCampaign(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "user"}
+	router, ctrl, mockService := setupCampaignRouter(t, user)
+	defer ctrl.Finish()
+
+	mockService.EXPECT().GetCampaign(gomock.Any(), "3", &user).Return(campaign.Detail{
+		Campaign:  campaign.Campaign{ID: "3", Name: "Underhive"},
+		Games:     []campaign.Game{{BookingID: "1", Reported: true, Winner: "alice"}},
+		Standings: []ranking.Ranking{{Rank: 1, Player: "alice", Rating: 1016, Games: 1, Wins: 1}},
+	}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/campaigns/3", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), `"winner": "alice"`)
+	assert.Contains(t, w.Body.String(), `"rating": 1016`)
+}
+
+func TestAttachBookingToCampaign(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "user"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupCampaignRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AttachBooking(gomock.Any(), "3", "1", user).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/campaigns/3/bookings/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("not the organizer", func(t *testing.T) {
+		router, ctrl, mockService := setupCampaignRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AttachBooking(gomock.Any(), "3", "1", user).Return(campaign.ErrNotAllowed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/campaigns/3/bookings/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("unknown campaign", func(t *testing.T) {
+		router, ctrl, mockService := setupCampaignRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().DetachBooking(gomock.Any(), "9", "1", user).Return(campaign.ErrCampaignNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/campaigns/9/bookings/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"campaign not found"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugHandler exposes net/http/pprof and runtime statistics to admins, to
+// profile memory and goroutine leaks in production.
+type DebugHandler struct {
+	startedAt time.Time
+}
+
+type RuntimeStats struct {
+	Goroutines   int       `json:"goroutines"`
+	HeapAlloc    uint64    `json:"heapAlloc"`
+	HeapInuse    uint64    `json:"heapInuse"`
+	HeapObjects  uint64    `json:"heapObjects"`
+	Sys          uint64    `json:"sys"`
+	NumGC        uint32    `json:"numGC"`
+	LastGC       time.Time `json:"lastGC"`
+	PauseTotalNs uint64    `json:"pauseTotalNs"`
+	GOMAXPROCS   int       `json:"gomaxprocs"`
+	Uptime       string    `json:"uptime"`
+}
+
+func NewDebugHandler() *DebugHandler {
+	return &DebugHandler{startedAt: time.Now()}
+}
+
+func (h *DebugHandler) Register(rg *gin.RouterGroup) {
+	debug := rg.Group("/debug", AdminOnly())
+
+	debug.GET("/runtime", h.Runtime)
+	debug.GET("/pprof/", gin.WrapF(pprof.Index))
+	debug.GET("/pprof/:profile", h.Profile)
+	debug.POST("/pprof/symbol", gin.WrapF(pprof.Symbol))
+}
+
+// Profile serves a single profile. pprof.Index can't, it only resolves
+// profile names under the /debug/pprof/ root path.
+func (h *DebugHandler) Profile(c *gin.Context) {
+	switch profile := c.Param("profile"); profile {
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		pprof.Handler(profile).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+func (h *DebugHandler) Runtime(c *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := RuntimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		PauseTotalNs: mem.PauseTotalNs,
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		Uptime:       time.Since(h.startedAt).Round(time.Second).String(),
+	}
+
+	if mem.LastGC != 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC))
+	}
+
+	c.IndentedJSON(http.StatusOK, stats)
+}
@@ -0,0 +1,60 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDebugRouter(user discord.DiscordUser) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	group := router.Group("/api/v1/admin")
+	group.Use(setUserInContext(user))
+	api.NewDebugHandler().Register(group)
+
+	return router
+}
+
+func TestDebug(t *testing.T) {
+	t.Run("runtime stats", func(t *testing.T) {
+		router := setupDebugRouter(discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/debug/runtime", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var stats api.RuntimeStats
+		json.Unmarshal(w.Body.Bytes(), &stats)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Positive(t, stats.Goroutines)
+	})
+
+	t.Run("named profile", func(t *testing.T) {
+		router := setupDebugRouter(discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/debug/pprof/goroutine?debug=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "goroutine profile")
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router := setupDebugRouter(discord.DiscordUser{ID: "2", Username: "user"})
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/debug/pprof/heap", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -1,19 +1,75 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/permission"
 )
 
-func DiscordAuth(discordClient discord.DiscordClient, adminRoleID string) gin.HandlerFunc {
+type authOptions struct {
+	trustedRoleID string
+	roles         func(ctx context.Context) (adminRoleID, trustedRoleID string)
+	permissions   func(ctx context.Context, roles []string) []string
+	record        func(ctx context.Context, user discord.User)
+}
+
+type AuthOption func(*authOptions)
+
+// WithTrustedRole marks the members with the roleID role as trusted.
+func WithTrustedRole(roleID string) AuthOption {
+	return func(o *authOptions) {
+		o.trustedRoleID = roleID
+	}
+}
+
+// WithRoles reads the admin and trusted roles from roles on each request,
+// rather than those given to DiscordAuth and WithTrustedRole, for the admins
+// to change them without a redeploy.
+func WithRoles(roles func(ctx context.Context) (adminRoleID, trustedRoleID string)) AuthOption {
+	return func(o *authOptions) {
+		o.roles = roles
+	}
+}
+
+// WithPermissions grants the members the permissions their roles are mapped
+// to, permissions returning those of a list of roles. The admin and trusted
+// permissions work as the admin and trusted roles.
+func WithPermissions(permissions func(ctx context.Context, roles []string) []string) AuthOption {
+	return func(o *authOptions) {
+		o.permissions = permissions
+	}
+}
+
+// WithUserRecorder hands the Discord user of each authenticated request to
+// record, which keeps their profile.
+func WithUserRecorder(record func(ctx context.Context, user discord.User)) AuthOption {
+	return func(o *authOptions) {
+		o.record = record
+	}
+}
+
+func DiscordAuth(discordClient discord.DiscordClient, adminRoleID string, opts ...AuthOption) gin.HandlerFunc {
+	var options authOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return func(c *gin.Context) {
 		accessToken := c.GetHeader("accesstoken")
 
+		// browsers cannot set headers on WebSocket handshakes
+		if len(accessToken) == 0 && websocket.IsWebSocketUpgrade(c.Request) {
+			accessToken = c.Query("accesstoken")
+		}
+
 		if len(accessToken) == 0 {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authentication"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": translate(c, "missing authentication")})
 			c.Abort()
 			return
 		}
@@ -21,16 +77,47 @@ func DiscordAuth(discordClient discord.DiscordClient, adminRoleID string) gin.Ha
 		member, err := discordClient.GetGuildMember(c.Request.Context(), accessToken)
 
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authentication"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": translate(c, "invalid authentication")})
 			c.Abort()
 			return
 		}
 
-		c.Set("user", discord.DiscordUser{
+		if options.record != nil {
+			options.record(c.Request.Context(), member.User)
+		}
+
+		admin, trusted := adminRoleID, options.trustedRoleID
+
+		if options.roles != nil {
+			admin, trusted = options.roles(c.Request.Context())
+		}
+
+		var permissions []string
+
+		if options.permissions != nil {
+			permissions = options.permissions(c.Request.Context(), member.Roles)
+		}
+
+		user := discord.DiscordUser{
 			ID:       member.User.ID,
 			Username: member.User.Username,
-			Admin:    slices.Contains(member.Roles, adminRoleID) || member.User.Username == "hanksha",
-		})
+			Admin:    slices.Contains(member.Roles, admin) || slices.Contains(permissions, permission.Admin) || member.User.Username == "hanksha",
+			Trusted:  (len(trusted) != 0 && slices.Contains(member.Roles, trusted)) || slices.Contains(permissions, permission.Trusted),
+		}
+
+		if user.Admin {
+			permissions = append(permissions, permission.Admin)
+		}
+
+		if user.Trusted {
+			permissions = append(permissions, permission.Trusted)
+		}
+
+		slices.Sort(permissions)
+		user.Permissions = slices.Compact(permissions)
+
+		c.Set("user", user)
 		c.Set("accessToken", accessToken)
+		c.Request = c.Request.WithContext(discord.ContextWithUser(c.Request.Context(), user))
 	}
 }
@@ -0,0 +1,100 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	discord_mocks "github.com/hanksha/tbz-booking-system-backend/discord/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDiscordAuthPermissions(t *testing.T) {
+	// the moderators are mapped to admin, the regulars to trusted
+	mappings := map[string][]string{"moderators": {"admin"}, "regulars": {"trusted"}}
+	permissions := func(ctx context.Context, roles []string) []string {
+		var granted []string
+
+		for _, role := range roles {
+			granted = append(granted, mappings[role]...)
+		}
+
+		return granted
+	}
+
+	tests := []struct {
+		name     string
+		roles    []string
+		expected discord.DiscordUser
+	}{
+		{"no role", []string{"players"}, discord.DiscordUser{ID: "1", Username: "alice"}},
+		{"admin role", []string{"admins"}, discord.DiscordUser{ID: "1", Username: "alice", Admin: true, Permissions: []string{"admin"}}},
+		{"mapped roles", []string{"moderators", "regulars"}, discord.DiscordUser{ID: "1", Username: "alice", Admin: true, Trusted: true, Permissions: []string{"admin", "trusted"}}},
+		{"mapped and trusted roles", []string{"trusted", "regulars"}, discord.DiscordUser{ID: "1", Username: "alice", Trusted: true, Permissions: []string{"trusted"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			client := discord_mocks.NewMockDiscordClient(ctrl)
+			client.EXPECT().GetGuildMember(gomock.Any(), "token").Return(&discord.Member{
+				User:  discord.User{ID: "1", Username: "alice"},
+				Roles: test.roles,
+			}, nil).Times(1)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(api.DiscordAuth(client, "admins", api.WithTrustedRole("trusted"), api.WithPermissions(permissions)))
+			router.GET("/me", func(c *gin.Context) {
+				c.JSON(http.StatusOK, c.MustGet("user"))
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/me", nil)
+			req.Header.Set("accesstoken", "token")
+			router.ServeHTTP(w, req)
+
+			var user discord.DiscordUser
+			json.Unmarshal(w.Body.Bytes(), &user)
+
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, test.expected, user)
+		})
+	}
+}
+
+func TestDiscordAuthRecordsUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := discord.User{ID: "1", Username: "alice", Avatar: "a1"}
+	client := discord_mocks.NewMockDiscordClient(ctrl)
+	client.EXPECT().GetGuildMember(gomock.Any(), "token").Return(&discord.Member{User: alice}, nil).Times(1)
+
+	var recorded []discord.User
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(api.DiscordAuth(client, "admins", api.WithUserRecorder(func(ctx context.Context, user discord.User) {
+		recorded = append(recorded, user)
+	})))
+	router.GET("/me", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/me", nil)
+	req.Header.Set("accesstoken", "token")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 204, w.Code)
+	assert.Equal(t, []discord.User{alice}, recorded)
+}
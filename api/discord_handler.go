@@ -11,17 +11,19 @@ import (
 type DiscordHandler struct {
 	client      discord.DiscordClient
 	adminRoleID string
+	authOptions []AuthOption
 }
 
-func NewDiscordHandler(client discord.DiscordClient, adminRoleID string) *DiscordHandler {
+func NewDiscordHandler(client discord.DiscordClient, adminRoleID string, authOptions ...AuthOption) *DiscordHandler {
 	return &DiscordHandler{
 		client:      client,
 		adminRoleID: adminRoleID,
+		authOptions: authOptions,
 	}
 }
 
 func (h *DiscordHandler) Register(rg *gin.RouterGroup) {
-	rg.GET("/user/info", DiscordAuth(h.client, h.adminRoleID), h.GetUserInfo)
+	rg.GET("/user/info", DiscordAuth(h.client, h.adminRoleID, h.authOptions...), h.GetUserInfo)
 	rg.GET("/user/search", h.SearchUsers)
 	rg.GET("/oauth/callback", h.OAuthCallback)
 	rg.GET("/events", h.GetEvents)
@@ -38,7 +40,7 @@ func (h *DiscordHandler) SearchUsers(c *gin.Context) {
 	query = strings.TrimSpace(query)
 
 	if len(query) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "query cannot be empty"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "query cannot be empty")})
 		return
 	}
 
@@ -46,7 +48,7 @@ func (h *DiscordHandler) SearchUsers(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search users"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to search users")})
 		return
 	}
 
@@ -65,7 +67,7 @@ func (h *DiscordHandler) OAuthCallback(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get oauth2 token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get oauth2 token")})
 		return
 	}
 
@@ -77,7 +79,7 @@ func (h *DiscordHandler) GetEvents(c *gin.Context) {
 
 	if err != nil {
 		c.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get events"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to get events")})
 		return
 	}
 
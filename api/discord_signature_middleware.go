@@ -0,0 +1,70 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// maxInteractionBody bounds the interactions read, Discord's are a few
+// kilobytes.
+const maxInteractionBody = 64 << 10
+
+// interactionTolerance is how old a signed interaction may be, older ones
+// being replays.
+const interactionTolerance = 5 * time.Minute
+
+// DiscordSignature verifies the interactions Discord posts to the
+// application, slash commands and button clicks, with its public key.
+// Unsigned, forged and replayed ones get a 401, Discord checking that the
+// endpoint rejects them. The interaction is set in the context under
+// "interaction" for the handlers.
+func DiscordSignature(publicKey ed25519.PublicKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxInteractionBody))
+
+		if err != nil {
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid request body")})
+			return
+		}
+
+		timestamp := c.GetHeader(discord.TimestampHeader)
+
+		if !discord.VerifyInteraction(publicKey, c.GetHeader(discord.SignatureHeader), timestamp, body) || !recent(timestamp, time.Now()) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": translate(c, "invalid request signature")})
+			return
+		}
+
+		var interaction discord.Interaction
+
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid request body")})
+			return
+		}
+
+		c.Set("interaction", interaction)
+		c.Next()
+	}
+}
+
+// recent reports whether the Unix timestamp in seconds is within the
+// tolerance of now.
+func recent(timestamp string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return false
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+
+	return age < interactionTolerance && age > -interactionTolerance
+}
@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscordSignature(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/interactions", api.DiscordSignature(publicKey), func(c *gin.Context) {
+		interaction := c.MustGet("interaction").(discord.Interaction)
+		c.JSON(http.StatusOK, gin.H{"command": interaction.Data.Name})
+	})
+
+	request := func(body string, at time.Time, key ed25519.PrivateKey) *http.Request {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		req, _ := http.NewRequest("POST", "/interactions", bytes.NewBufferString(body))
+		req.Header.Set(discord.TimestampHeader, timestamp)
+		req.Header.Set(discord.SignatureHeader, hex.EncodeToString(ed25519.Sign(key, []byte(timestamp+body))))
+		return req
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request(`{"type":2,"data":{"name":"bookings"}}`, time.Now(), privateKey))
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"command":"bookings"}`, w.Body.String())
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		_, otherKey, _ := ed25519.GenerateKey(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request(`{"type":1}`, time.Now(), otherKey))
+
+		assert.Equal(t, 401, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request signature"}`, w.Body.String())
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		req := request(`{"type":1}`, time.Now(), privateKey)
+		req.Body = http.NoBody
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request(`{"type":1}`, time.Now().Add(-time.Hour), privateKey))
+
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/interactions", bytes.NewBufferString(`{"type":1}`))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("signed but malformed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request(`{"type":`, time.Now(), privateKey))
+
+		assert.Equal(t, 400, w.Code)
+	})
+}
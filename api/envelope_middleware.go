@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope is the shape of every JSON response of the v2 API: the data of a
+// success or the error of a failure, along with metadata about the response.
+type Envelope struct {
+	Data  any            `json:"data"`
+	Meta  Meta           `json:"meta"`
+	Error *EnvelopeError `json:"error,omitempty"`
+}
+
+// Meta describes the data of an envelope.
+type Meta struct {
+	RequestID string `json:"requestId,omitempty"`
+	// Count is the number of items of the data when it is a list
+	Count *int `json:"count,omitempty"`
+	// NextCursor is passed back as the cursor query parameter to fetch the
+	// next page of a paginated list
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// EnvelopeError is the error of a failed request, with the fields rejected
+// in Details.
+type EnvelopeError struct {
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+const (
+	envelopeKey   = "envelope"
+	nextCursorKey = "nextCursor"
+)
+
+// Enveloped wraps the JSON responses of the routes in an Envelope. Handlers
+// keep writing their data or {"error", "details"} objects, the envelope is
+// built from them once they return.
+func Enveloped() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &envelopeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Set(envelopeKey, true)
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		writer.flush(c)
+	}
+}
+
+// isEnveloped tells whether the response is wrapped by Enveloped, for the
+// handlers moving their pagination to its metadata.
+func isEnveloped(c *gin.Context) bool {
+	return c.GetBool(envelopeKey)
+}
+
+// setNextCursor records the cursor of the next page in the metadata of the
+// envelope.
+func setNextCursor(c *gin.Context, cursor string) {
+	c.Set(nextCursorKey, cursor)
+}
+
+// envelopeWriter holds the body written by the handlers until Enveloped
+// wraps it.
+type envelopeWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *envelopeWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *envelopeWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *envelopeWriter) Written() bool {
+	return w.body.Len() > 0 || w.ResponseWriter.Written()
+}
+
+func (w *envelopeWriter) flush(c *gin.Context) {
+	body := w.body.Bytes()
+
+	// files, images and empty responses are left as they are
+	if len(body) == 0 || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		if len(body) > 0 {
+			w.ResponseWriter.Write(body)
+		}
+		return
+	}
+
+	envelope := Envelope{Meta: Meta{RequestID: RequestIDFrom(c), NextCursor: c.GetString(nextCursorKey)}}
+
+	if w.Status() >= http.StatusBadRequest {
+		var failure struct {
+			Error   string          `json:"error"`
+			Details json.RawMessage `json:"details"`
+		}
+
+		json.Unmarshal(body, &failure)
+		envelope.Error = &EnvelopeError{Message: failure.Error, Details: failure.Details}
+	} else {
+		envelope.Data = json.RawMessage(body)
+
+		var items []json.RawMessage
+
+		if json.Unmarshal(body, &items) == nil {
+			count := len(items)
+			envelope.Meta.Count = &count
+		}
+	}
+
+	encoded, err := json.Marshal(envelope)
+
+	if err != nil {
+		c.Error(err)
+		w.ResponseWriter.Write(body)
+		return
+	}
+
+	w.ResponseWriter.Write(encoded)
+}
@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnveloped(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(api.RequestID(), api.Enveloped())
+	router.GET("/list", func(c *gin.Context) {
+		c.IndentedJSON(http.StatusOK, []gin.H{{"id": "1"}, {"id": "2"}})
+	})
+	router.GET("/object", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"count": 3})
+	})
+	router.GET("/failure", func(c *gin.Context) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid request body", "details": []gin.H{{"field": "game"}}})
+	})
+	router.GET("/file", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte("png"))
+	})
+	router.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/list", 200, `{"data":[{"id":"1"},{"id":"2"}],"meta":{"requestId":"req-1","count":2}}`},
+		{"/object", 200, `{"data":{"count":3},"meta":{"requestId":"req-1"}}`},
+		{"/failure", 422, `{"data":null,"meta":{"requestId":"req-1"},"error":{"message":"invalid request body","details":[{"field":"game"}]}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", test.path, nil)
+			req.Header.Set(api.RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.code, w.Code)
+			assert.JSONEq(t, test.body, w.Body.String())
+		})
+	}
+
+	t.Run("leaves the files as they are", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/file", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "png", w.Body.String())
+	})
+
+	t.Run("leaves the empty responses as they are", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/empty", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 304, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
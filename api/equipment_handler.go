@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/equipment"
+)
+
+type EquipmentService interface {
+	GetItems(ctx context.Context) ([]equipment.Item, error)
+	CreateItem(ctx context.Context, item equipment.Item) (equipment.Item, error)
+	UpdateItem(ctx context.Context, item equipment.Item) (equipment.Item, error)
+	DeleteItem(ctx context.Context, id string) error
+	GetAllocations(ctx context.Context, at time.Time) ([]equipment.Allocation, error)
+}
+
+// EquipmentHandler serves the equipment inventory, managed by the admins,
+// and how much of it the bookings reserve.
+type EquipmentHandler struct {
+	service EquipmentService
+}
+
+func NewEquipmentHandler(service EquipmentService) *EquipmentHandler {
+	return &EquipmentHandler{service: service}
+}
+
+func (h *EquipmentHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("", h.List)
+	rg.GET("/allocations", h.Allocations)
+	rg.POST("", adminOnly, h.Create)
+	rg.PUT("/:id", adminOnly, h.Update)
+	rg.DELETE("/:id", adminOnly, h.Delete)
+}
+
+func (h *EquipmentHandler) List(c *gin.Context) {
+	items, err := h.service.GetItems(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve equipment")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, items)
+}
+
+// Allocations lists the quantity of each item reserved at the time given by
+// the at parameter, now when omitted.
+func (h *EquipmentHandler) Allocations(c *gin.Context) {
+	at, err := parseOptionalTime(c.Query("at"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse at")})
+		return
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	allocations, err := h.service.GetAllocations(c.Request.Context(), at)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve equipment allocations")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, allocations)
+}
+
+func (h *EquipmentHandler) Create(c *gin.Context) {
+	var item equipment.Item
+
+	if !bindJSON(c, &item) {
+		return
+	}
+
+	created, err := h.service.CreateItem(c.Request.Context(), item)
+
+	if err != nil {
+		h.itemError(c, err, "failed to create equipment item")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *EquipmentHandler) Update(c *gin.Context) {
+	var item equipment.Item
+
+	if !bindJSON(c, &item) {
+		return
+	}
+
+	item.ID = c.Param("id")
+
+	updated, err := h.service.UpdateItem(c.Request.Context(), item)
+
+	if err != nil {
+		h.itemError(c, err, "failed to update equipment item")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, updated)
+}
+
+func (h *EquipmentHandler) Delete(c *gin.Context) {
+	err := h.service.DeleteItem(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.itemError(c, err, "failed to delete equipment item")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "equipment item deleted")})
+}
+
+func (h *EquipmentHandler) itemError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, equipment.ErrItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "equipment item not found")})
+	case errors.Is(err, equipment.ErrInvalidItem):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, equipment.ErrDuplicateItem):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
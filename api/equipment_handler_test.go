@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/equipment"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupEquipmentRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockEquipmentService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockEquipmentService(ctrl)
+	handler := api.NewEquipmentHandler(mockService)
+	rg := router.Group("/api/v1/equipment")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestGetEquipmentAllocations(t *testing.T) {
+	router, ctrl, mockService := setupEquipmentRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+	defer ctrl.Finish()
+
+	at := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetAllocations(gomock.Any(), at).Return([]equipment.Allocation{
+		{Item: equipment.Item{ID: "2", Name: "Terrain ruines", Quantity: 1}, Reserved: 2, OverAllocated: true},
+	}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/equipment/allocations?at=2026-03-01T20:00:00Z", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), `"overAllocated": true`)
+}
+
+func TestCreateEquipmentItem(t *testing.T) {
+	body := `{"name":"Tapis","quantity":4}`
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupEquipmentRouter(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateItem(gomock.Any(), equipment.Item{Name: "Tapis", Quantity: 4}).
+			Return(equipment.Item{ID: "1", Name: "Tapis", Quantity: 4}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/equipment", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		router, ctrl, mockService := setupEquipmentRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/equipment", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		router, ctrl, mockService := setupEquipmentRouter(t, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(equipment.Item{}, equipment.ErrDuplicateItem).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/equipment", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+	})
+}
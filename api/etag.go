@@ -0,0 +1,83 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// privateNoCache lets clients keep the responses depending on the requesting
+// user, revalidating them with their ETag on each use.
+const privateNoCache = "private, no-cache"
+
+// indentedJSONWithETag renders data like c.IndentedJSON with a weak ETag, and
+// answers 304 Not Modified when the client already holds that representation.
+func indentedJSONWithETag(c *gin.Context, data any, cacheControl string) {
+	body, err := json.MarshalIndent(data, "", "    ")
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to encode response")})
+		return
+	}
+
+	sum := sha256.Sum256(body)
+
+	if notModified(c, fmt.Sprintf(`W/"%x"`, sum[:16]), cacheControl) {
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// bookingsWithETag renders a booking listing with a weak ETag fingerprinting
+// the bookings from their ids and update times, rather than hashing the whole
+// body. The requesting user and the past flags are part of the fingerprint as
+// they change the derived fields.
+func bookingsWithETag(c *gin.Context, bookings []BookingResponse, user *discord.DiscordUser) {
+	hash := sha256.New()
+
+	if user != nil {
+		fmt.Fprintf(hash, "%s|%t;", user.ID, user.Admin)
+	}
+
+	for _, booking := range bookings {
+		fmt.Fprintf(hash, "%s|%d|%t;", booking.ID, booking.UpdatedAt.UnixNano(), booking.IsPast)
+	}
+
+	if notModified(c, fmt.Sprintf(`W/"%x-%d"`, hash.Sum(nil)[:16], len(bookings)), privateNoCache) {
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, bookings)
+}
+
+// notModified sets the caching headers for etag and answers 304 Not Modified
+// when the client already holds that representation.
+func notModified(c *gin.Context, etag, cacheControl string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+
+	return false
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
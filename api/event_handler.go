@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/event"
+)
+
+type EventService interface {
+	GetUpcomingEvents(ctx context.Context) ([]event.Event, error)
+	GetEvent(ctx context.Context, id string) (event.Detail, error)
+	CreateEvent(ctx context.Context, event event.Event) (event.Event, error)
+	DeleteEvent(ctx context.Context, id string) error
+	SignUp(ctx context.Context, id string, user discord.DiscordUser) (event.Participant, error)
+}
+
+// EventHandler serves the multi-table events, admins organize them and
+// members sign up.
+type EventHandler struct {
+	service EventService
+}
+
+func NewEventHandler(service EventService) *EventHandler {
+	return &EventHandler{service: service}
+}
+
+func (h *EventHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("", h.List)
+	rg.GET("/:id", h.Get)
+	rg.POST("", adminOnly, h.Create)
+	rg.DELETE("/:id", adminOnly, h.Delete)
+	rg.POST("/:id/signup", h.SignUp)
+}
+
+func (h *EventHandler) List(c *gin.Context) {
+	events, err := h.service.GetUpcomingEvents(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve events")})
+		return
+	}
+
+	for i := range events {
+		events[i].DateTime = events[i].DateTime.In(displayLocation)
+	}
+
+	c.IndentedJSON(http.StatusOK, events)
+}
+
+func (h *EventHandler) Get(c *gin.Context) {
+	detail, err := h.service.GetEvent(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, event.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "event not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve event")})
+		}
+		return
+	}
+
+	detail.DateTime = detail.DateTime.In(displayLocation)
+
+	c.IndentedJSON(http.StatusOK, detail)
+}
+
+// Create organizes an event, the dateTime is RFC 3339 as for bookings.
+func (h *EventHandler) Create(c *gin.Context) {
+	var e event.Event
+
+	if !bindJSON(c, &e) {
+		return
+	}
+
+	created, err := h.service.CreateEvent(c.Request.Context(), e)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, event.ErrInvalidEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to create event")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *EventHandler) Delete(c *gin.Context) {
+	err := h.service.DeleteEvent(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, event.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "event not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to delete event")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "event deleted")})
+}
+
+// SignUp registers the user to the event, which books their table.
+func (h *EventHandler) SignUp(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	participant, err := h.service.SignUp(c.Request.Context(), c.Param("id"), user)
+
+	if err != nil {
+		c.Error(err)
+		switch {
+		case errors.Is(err, event.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "event not found")})
+		case errors.Is(err, event.ErrEventFull), errors.Is(err, event.ErrEventClosed), errors.Is(err, event.ErrAlreadySignedUp):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, bk.ErrUserSuspended):
+			c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "user is suspended from booking")})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to sign up")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, participant)
+}
@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/event"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupEventRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockEventService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockEventService(ctrl)
+	handler := api.NewEventHandler(mockService)
+	rg := router.Group("/api/v1/events")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestCreateEvent(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupEventRouter(t, admin)
+		defer ctrl.Finish()
+
+		e := event.Event{Name: "Tournoi", Game: "Star Wars", Rounds: 3, Capacity: 8, DateTime: time.Date(2099, 3, 12, 18, 0, 0, 0, time.UTC)}
+		created := e
+		created.ID = "7"
+		mockService.EXPECT().CreateEvent(gomock.Any(), e).Return(created, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(`{"name":"Tournoi","game":"Star Wars","rounds":3,"capacity":8,"dateTime":"2099-03-12T18:00:00Z"}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"7"`)
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		router, ctrl, _ := setupEventRouter(t, admin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(`{"name":"Tournoi","game":"Star Wars","rounds":3,"capacity":1}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"capacity","message":"must be at least 2"}]}`, w.Body.String())
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupEventRouter(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBufferString(`{}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestGetEvent(t *testing.T) {
+	router, ctrl, mockService := setupEventRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+	defer ctrl.Finish()
+
+	detail := event.Detail{
+		Event:        event.Event{ID: "7", Name: "Tournoi", Rounds: 1, Capacity: 4},
+		Participants: []event.Participant{{UserID: "1", Username: "alice", BookingID: "42"}, {UserID: "2", Username: "bob", BookingID: "43"}},
+		Pairings:     []event.Pairing{{Round: 1, Table: 1, Players: []string{"alice", "bob"}}},
+	}
+	mockService.EXPECT().GetEvent(gomock.Any(), "7").Return(detail, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/events/7", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), `"bookingId": "42"`)
+	assert.Contains(t, w.Body.String(), `"table": 1`)
+}
+
+func TestSignUp(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "bob"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupEventRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SignUp(gomock.Any(), "7", user).Return(event.Participant{UserID: "2", Username: "bob", BookingID: "43"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/events/7/signup", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"bookingId":"43"`)
+	})
+
+	t.Run("full", func(t *testing.T) {
+		router, ctrl, mockService := setupEventRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SignUp(gomock.Any(), "7", user).Return(event.Participant{}, event.ErrEventFull).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/events/7/signup", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+		assert.JSONEq(t, `{"error":"event is full"}`, w.Body.String())
+	})
+}
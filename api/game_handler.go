@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/game"
+)
+
+type GameService interface {
+	GetGames(ctx context.Context) ([]game.Game, error)
+	SaveGame(ctx context.Context, game game.Game) (game.Game, error)
+	DeleteGame(ctx context.Context, name string) error
+}
+
+// GameHandler serves the game catalog, readable by every member so the
+// booking form can show the points allowed.
+type GameHandler struct {
+	service GameService
+}
+
+func NewGameHandler(service GameService) *GameHandler {
+	return &GameHandler{service: service}
+}
+
+func (h *GameHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("", h.List)
+	rg.POST("", adminOnly, h.Save)
+	rg.DELETE("/:name", adminOnly, h.Delete)
+}
+
+func (h *GameHandler) List(c *gin.Context) {
+	games, err := h.service.GetGames(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve games")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, games)
+}
+
+func (h *GameHandler) Save(c *gin.Context) {
+	var g game.Game
+
+	if !bindJSON(c, &g) {
+		return
+	}
+
+	saved, err := h.service.SaveGame(c.Request.Context(), g)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, game.ErrInvalidGame) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to save game")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+func (h *GameHandler) Delete(c *gin.Context) {
+	name := c.Param("name")
+
+	err := h.service.DeleteGame(c.Request.Context(), name)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, game.ErrGameNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "game not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to delete game")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "game deleted")})
+}
@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/game"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupGameRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockGameService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockGameService(ctrl)
+	handler := api.NewGameHandler(mockService)
+	rg := router.Group("/api/v1/games")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestSaveGame(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupGameRouter(t, admin)
+		defer ctrl.Finish()
+
+		g := game.Game{Name: "Star Wars", MinPoints: 500, MaxPoints: 2000, PointsStep: 250}
+		mockService.EXPECT().SaveGame(gomock.Any(), g).Return(g, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/games", bytes.NewBufferString(`{"name":"Star Wars","minPoints":500,"maxPoints":2000,"pointsStep":250}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"name":"Star Wars","minPoints":500,"maxPoints":2000,"pointsStep":250,"createdAt":"0001-01-01T00:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("invalid game", func(t *testing.T) {
+		router, ctrl, mockService := setupGameRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SaveGame(gomock.Any(), gomock.Any()).Return(game.Game{}, game.ErrInvalidGame).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/games", bytes.NewBufferString(`{"name":"Star Wars","minPoints":2000,"maxPoints":500}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupGameRouter(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/games", bytes.NewBufferString(`{"name":"Star Wars"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
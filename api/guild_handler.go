@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/guild"
+)
+
+type GuildConfigService interface {
+	GetConfig(ctx context.Context) (guild.Config, error)
+	UpdateConfig(ctx context.Context, cfg guild.Config) (guild.Config, error)
+}
+
+// GuildConfigHandler lets the admins change the channels, roles and locale
+// of the server without a redeploy.
+type GuildConfigHandler struct {
+	service GuildConfigService
+}
+
+func NewGuildConfigHandler(service GuildConfigService) *GuildConfigHandler {
+	return &GuildConfigHandler{service: service}
+}
+
+func (h *GuildConfigHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("/config", adminOnly, h.Get)
+	rg.PUT("/config", adminOnly, h.Update)
+}
+
+func (h *GuildConfigHandler) Get(c *gin.Context) {
+	cfg, err := h.service.GetConfig(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve config")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, cfg)
+}
+
+func (h *GuildConfigHandler) Update(c *gin.Context) {
+	var cfg guild.Config
+
+	if !bindJSON(c, &cfg) {
+		return
+	}
+
+	saved, err := h.service.UpdateConfig(c.Request.Context(), cfg)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, guild.ErrInvalidConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to save config")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, saved)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/guild"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupGuildConfigRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockGuildConfigService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockGuildConfigService(ctrl)
+	rg := router.Group("/api/v1/admin")
+	rg.Use(setUserInContext(user))
+	api.NewGuildConfigHandler(mockService).Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestGuildConfig(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	body := `{"channelId":"201","adminRoleId":"301","locale":"fr"}`
+
+	t.Run("get", func(t *testing.T) {
+		router, ctrl, mockService := setupGuildConfigRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetConfig(gomock.Any()).Return(guild.Config{GuildID: "100", ChannelID: "200", AdminChannelID: "200", AdminRoleID: "300", Locale: i18n.English}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/config", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"guildId":"100","channelId":"200","adminChannelId":"200","openSeatsChannelId":"","opsChannelId":"","adminRoleId":"300","trustedRoleId":"","locale":"en"}`, w.Body.String())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		router, ctrl, mockService := setupGuildConfigRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().UpdateConfig(gomock.Any(), guild.Config{ChannelID: "201", AdminRoleID: "301", Locale: i18n.French}).Return(guild.Config{GuildID: "100", ChannelID: "201", AdminRoleID: "301", Locale: i18n.French}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/config", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		router, ctrl, mockService := setupGuildConfigRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().UpdateConfig(gomock.Any(), gomock.Any()).Return(guild.Config{}, fmt.Errorf("%w: locale 'de' is not supported", guild.ErrInvalidConfig)).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/config", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+		assert.Contains(t, w.Body.String(), "locale 'de' is not supported")
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		router, ctrl, mockService := setupGuildConfigRouter(t, discord.DiscordUser{ID: "2", Username: "bob"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetConfig(gomock.Any()).Times(0)
+		mockService.EXPECT().UpdateConfig(gomock.Any(), gomock.Any()).Times(0)
+
+		for _, method := range []string{"GET", "PUT"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(method, "/api/v1/admin/config", strings.NewReader(body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, 403, w.Code, method)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthCheck verifies a dependency the service needs to handle requests.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks  []HealthCheck
+	timeout time.Duration
+}
+
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks"`
+}
+
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks, timeout: 3 * time.Second}
+}
+
+func (h *HealthHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/livez", h.Live)
+	rg.GET("/readyz", h.Ready)
+}
+
+// Live reports the process is up, without looking at its dependencies so
+// an outage of Postgres or Discord doesn't get every replica restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready runs every check concurrently and answers 503 when one fails, so the
+// replica is taken out of the load balancer until it recovers.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.timeout)
+	defer cancel()
+
+	response := ReadinessResponse{Status: "ok", Checks: map[string]DependencyStatus{}}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, check := range h.checks {
+		wg.Go(func() {
+			status := DependencyStatus{Status: "ok"}
+
+			if err := check.Check(ctx); err != nil {
+				status = DependencyStatus{Status: "error", Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			response.Checks[check.Name] = status
+			if status.Status != "ok" {
+				response.Status = "error"
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if response.Status != "ok" {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,68 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHealthRouter(checks ...api.HealthCheck) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api.NewHealthHandler(checks...).Register(router.Group(""))
+
+	return router
+}
+
+func TestReady(t *testing.T) {
+	ok := api.HealthCheck{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	failing := api.HealthCheck{Name: "discord", Check: func(ctx context.Context) error { return errors.New("401") }}
+
+	t.Run("all dependencies up", func(t *testing.T) {
+		router := setupHealthRouter(ok)
+
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var res api.ReadinessResponse
+		json.Unmarshal(w.Body.Bytes(), &res)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", res.Status)
+		assert.Equal(t, api.DependencyStatus{Status: "ok"}, res.Checks["database"])
+	})
+
+	t.Run("dependency down", func(t *testing.T) {
+		router := setupHealthRouter(ok, failing)
+
+		req, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var res api.ReadinessResponse
+		json.Unmarshal(w.Body.Bytes(), &res)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "error", res.Status)
+		assert.Equal(t, api.DependencyStatus{Status: "ok"}, res.Checks["database"])
+		assert.Equal(t, api.DependencyStatus{Status: "error", Error: "401"}, res.Checks["discord"])
+	})
+}
+
+func TestLive(t *testing.T) {
+	router := setupHealthRouter(api.HealthCheck{Name: "database", Check: func(ctx context.Context) error { return errors.New("down") }})
+
+	req, _ := http.NewRequest("GET", "/livez", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ical"
+)
+
+type ICalService interface {
+	GetToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error)
+	CreateToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error)
+	RotateToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error)
+	RevokeToken(ctx context.Context, user discord.DiscordUser) error
+	Feed(ctx context.Context, token string) ([]byte, error)
+}
+
+// ICalHandler lets the members manage the token of their calendar feed, and
+// serves the feed to their calendar app.
+type ICalHandler struct {
+	service ICalService
+}
+
+func NewICalHandler(service ICalService) *ICalHandler {
+	return &ICalHandler{service: service}
+}
+
+func (h *ICalHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/me/calendar-token", h.GetToken)
+	rg.POST("/me/calendar-token", h.CreateToken)
+	rg.POST("/me/calendar-token/rotate", h.RotateToken)
+	rg.DELETE("/me/calendar-token", h.RevokeToken)
+}
+
+// RegisterFeed registers the feed, authenticated by its token alone as
+// calendar apps send no other credentials.
+func (h *ICalHandler) RegisterFeed(rg *gin.RouterGroup) {
+	rg.GET("/calendar/:token", h.GetFeed)
+}
+
+func (h *ICalHandler) GetToken(c *gin.Context) {
+	token, err := h.service.GetToken(c.Request.Context(), c.MustGet("user").(discord.DiscordUser))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ical.ErrTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "calendar token not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve calendar token")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, token)
+}
+
+func (h *ICalHandler) CreateToken(c *gin.Context) {
+	token, err := h.service.CreateToken(c.Request.Context(), c.MustGet("user").(discord.DiscordUser))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ical.ErrTokenExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": translate(c, "calendar token already exists")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to create calendar token")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// RotateToken replaces the token of the user, for a leaked feed URL.
+func (h *ICalHandler) RotateToken(c *gin.Context) {
+	token, err := h.service.RotateToken(c.Request.Context(), c.MustGet("user").(discord.DiscordUser))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ical.ErrTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "calendar token not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to rotate calendar token")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, token)
+}
+
+func (h *ICalHandler) RevokeToken(c *gin.Context) {
+	err := h.service.RevokeToken(c.Request.Context(), c.MustGet("user").(discord.DiscordUser))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ical.ErrTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "calendar token not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to revoke calendar token")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "calendar token revoked")})
+}
+
+func (h *ICalHandler) GetFeed(c *gin.Context) {
+	feed, err := h.service.Feed(c.Request.Context(), strings.TrimSuffix(c.Param("token"), ".ics"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, ical.ErrTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "calendar token not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve calendar")})
+		}
+		return
+	}
+
+	// calendar apps poll the feed every few hours at most
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
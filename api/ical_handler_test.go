@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ical"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarToken(t *testing.T) {
+	alice := discord.DiscordUser{ID: "1", Username: "alice"}
+	createdAt := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockICalService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockICalService(ctrl)
+		handler := api.NewICalHandler(mockService)
+		rg := router.Group("/api/v1/users")
+		rg.Use(setUserInContext(alice))
+		handler.Register(rg)
+		handler.RegisterFeed(router.Group("/api/public"))
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("create", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateToken(gomock.Any(), alice).Return(ical.Token{
+			UserID:    "1",
+			Username:  "alice",
+			Token:     "secret",
+			URL:       "https://tbz.example/api/public/calendar/secret.ics",
+			CreatedAt: createdAt,
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/users/me/calendar-token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.JSONEq(t, `{"userId":"1","username":"alice","token":"secret","url":"https://tbz.example/api/public/calendar/secret.ics","createdAt":"2026-03-06T19:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("create twice", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateToken(gomock.Any(), alice).Return(ical.Token{}, ical.ErrTokenExists).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/users/me/calendar-token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RotateToken(gomock.Any(), alice).Return(ical.Token{UserID: "1", Token: "new"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/users/me/calendar-token/rotate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("revoke without a token", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RevokeToken(gomock.Any(), alice).Return(ical.ErrTokenNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/users/me/calendar-token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"calendar token not found"}`, w.Body.String())
+	})
+
+	t.Run("feed", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Feed(gomock.Any(), "secret").Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/public/calendar/secret.ics", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", w.Body.String())
+	})
+
+	t.Run("feed of a revoked token", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Feed(gomock.Any(), "leaked").Return(nil, ical.ErrTokenNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/public/calendar/leaked.ics", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type ReminderResponder interface {
+	AcknowledgeReminder(ctx context.Context, id string, user discord.DiscordUser) (bk.Booking, error)
+	SnoozeReminder(ctx context.Context, id string, snooze time.Duration, user discord.DiscordUser) (bk.Booking, error)
+}
+
+// InteractionHandler receives the interactions of Discord with the messages
+// of the bot, the clicks on the buttons of the reminders.
+type InteractionHandler struct {
+	publicKey ed25519.PublicKey
+	service   ReminderResponder
+}
+
+func NewInteractionHandler(publicKey ed25519.PublicKey, service ReminderResponder) *InteractionHandler {
+	return &InteractionHandler{publicKey: publicKey, service: service}
+}
+
+func (h *InteractionHandler) Register(rg *gin.RouterGroup) {
+	rg.POST("/interactions", DiscordSignature(h.publicKey), h.Interact)
+}
+
+// Interact answers an interaction, verified by DiscordSignature as the route
+// is registered without authentication.
+func (h *InteractionHandler) Interact(c *gin.Context) {
+	interaction := c.MustGet("interaction").(discord.Interaction)
+
+	switch interaction.Type {
+	case discord.InteractionPing:
+		c.JSON(http.StatusOK, discord.Pong())
+	case discord.InteractionMessageComponent:
+		h.respondToReminder(c, interaction)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "unsupported interaction")})
+	}
+}
+
+// respondToReminder acknowledges or snoozes the reminder whose button was
+// clicked, telling the user only how it went.
+func (h *InteractionHandler) respondToReminder(c *gin.Context, interaction discord.Interaction) {
+	author := interaction.Author()
+	user := discord.DiscordUser{ID: author.ID, Username: author.Username}
+	customID := interaction.Data.CustomID
+
+	var err error
+	var content string
+
+	if id, ok := strings.CutPrefix(customID, bk.AcknowledgeButtonPrefix); ok {
+		_, err = h.service.AcknowledgeReminder(c.Request.Context(), id, user)
+		content = "C'est noté, à bientôt autour de la table !"
+	} else if id, ok := strings.CutPrefix(customID, bk.SnoozeButtonPrefix); ok {
+		_, err = h.service.SnoozeReminder(c.Request.Context(), id, bk.DefaultSnooze, user)
+		content = "C'est noté, nouveau rappel dans 1 h."
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "unsupported interaction")})
+		return
+	}
+
+	if err != nil {
+		slog.Warn("failed to respond to reminder", "customId", customID, "userId", user.ID, "err", err)
+
+		switch {
+		case errors.Is(err, bk.ErrBookingNotFound), errors.Is(err, bk.ErrInvalidBookingState):
+			content = "Cette réservation n'attend plus de réponse."
+		case errors.Is(err, bk.ErrPlayerNotFound):
+			content = "Vous ne participez pas à cette réservation."
+		default:
+			c.Error(err)
+			content = "Impossible d'enregistrer votre réponse, réessayez plus tard."
+		}
+	}
+
+	c.JSON(http.StatusOK, discord.EphemeralMessage(content))
+}
@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInteract(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
+
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockBookingService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockBookingService(ctrl)
+		api.NewInteractionHandler(publicKey, mockService).Register(router.Group("/api/discord"))
+
+		return router, ctrl, mockService
+	}
+
+	signed := func(body string) *http.Request {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req, _ := http.NewRequest("POST", "/api/discord/interactions", bytes.NewBufferString(body))
+		req.Header.Set(discord.TimestampHeader, timestamp)
+		req.Header.Set(discord.SignatureHeader, hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))))
+		return req
+	}
+
+	alice := discord.DiscordUser{ID: "2", Username: "alice"}
+
+	t.Run("ping", func(t *testing.T) {
+		router, ctrl, _ := setup(t)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, signed(`{"type":1}`))
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"type":1}`, w.Body.String())
+	})
+
+	t.Run("acknowledge button in a direct message", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().AcknowledgeReminder(gomock.Any(), "123", alice).Return(bk.Booking{ID: "123"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, signed(`{"type":3,"data":{"custom_id":"reminder:acknowledge:123"},"user":{"id":"2","username":"alice"}}`))
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"type":4,"data":{"content":"C'est noté, à bientôt autour de la table !","flags":64}}`, w.Body.String())
+	})
+
+	t.Run("snooze button in a channel", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SnoozeReminder(gomock.Any(), "123", time.Hour, alice).Return(bk.Booking{}, bk.ErrPlayerNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, signed(`{"type":3,"data":{"custom_id":"reminder:snooze:123"},"member":{"user":{"id":"2","username":"alice"}}}`))
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"type":4,"data":{"content":"Vous ne participez pas à cette réservation.","flags":64}}`, w.Body.String())
+	})
+
+	t.Run("unknown button", func(t *testing.T) {
+		router, ctrl, _ := setup(t)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, signed(`{"type":3,"data":{"custom_id":"poll:vote:1"},"user":{"id":"2","username":"alice"}}`))
+
+		assert.Equal(t, 400, w.Code)
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/jobs"
+)
+
+// JobsHandler reports the health of the scheduled jobs as seen by the
+// replica answering, the runs of the other replicas are counted as skipped.
+type JobsHandler struct {
+	scheduler *jobs.Scheduler
+}
+
+func NewJobsHandler(scheduler *jobs.Scheduler) *JobsHandler {
+	return &JobsHandler{scheduler: scheduler}
+}
+
+func (h *JobsHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/jobs", AdminOnly(), h.List)
+}
+
+func (h *JobsHandler) List(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, h.scheduler.Statuses())
+}
@@ -0,0 +1,62 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/jobs"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJobsRouter(scheduler *jobs.Scheduler, user discord.DiscordUser) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	admin := router.Group("/api/v1/admin")
+	admin.Use(setUserInContext(user))
+	api.NewJobsHandler(scheduler).Register(admin)
+
+	return router
+}
+
+func TestJobsHandler(t *testing.T) {
+	scheduler := jobs.NewScheduler(jobs.NewLocalLocker())
+	scheduler.Add(jobs.Job{
+		Name:     "reminders",
+		Schedule: jobs.Every(time.Hour),
+		Run:      func(ctx context.Context) error { return nil },
+	})
+
+	t.Run("lists jobs", func(t *testing.T) {
+		router := setupJobsRouter(scheduler, discord.DiscordUser{ID: "1", Username: "admin", Admin: true})
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/jobs", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var statuses []jobs.Status
+		json.Unmarshal(w.Body.Bytes(), &statuses)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, "reminders", statuses[0].Name)
+		assert.Equal(t, "@every 1h0m0s", statuses[0].Schedule)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router := setupJobsRouter(scheduler, discord.DiscordUser{ID: "2", Username: "user"})
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/jobs", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+)
+
+type languageOptions struct {
+	fallback func(ctx context.Context) i18n.Language
+}
+
+type LanguageOption func(*languageOptions)
+
+// WithDefaultLanguage answers in the language fallback returns, such as the
+// locale of the server, when the caller prefers none of the supported ones.
+func WithDefaultLanguage(fallback func(ctx context.Context) i18n.Language) LanguageOption {
+	return func(o *languageOptions) {
+		o.fallback = fallback
+	}
+}
+
+// Language picks the language of the messages of the response from the
+// Accept-Language header of the request, and tells it in Content-Language.
+func Language(opts ...LanguageOption) gin.HandlerFunc {
+	var options languageOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(c *gin.Context) {
+		fallback := i18n.Default
+
+		if options.fallback != nil {
+			fallback = options.fallback(c.Request.Context())
+		}
+
+		language := i18n.NegotiateOr(c.GetHeader("Accept-Language"), fallback)
+
+		c.Set("language", language)
+		c.Header("Content-Language", string(language))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
+
+// LanguageFrom returns the language picked by Language, negotiated from the
+// request without it.
+func LanguageFrom(c *gin.Context) i18n.Language {
+	if language, ok := c.Value("language").(i18n.Language); ok {
+		return language
+	}
+
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// translate returns a human-readable message of the response in the language
+// of the caller.
+func translate(c *gin.Context, text string) string {
+	return i18n.Translate(LanguageFrom(c), text)
+}
@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(api.Language(), api.Recovery(nil))
+	router.GET("/bookings/:id", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		language string
+		message  string
+	}{
+		{"english by default", "", "en", "internal server error"},
+		{"french when preferred", "fr-FR,fr;q=0.9,en;q=0.8", "fr", "erreur interne du serveur"},
+		{"english when preferred", "en-US,fr;q=0.5", "en", "internal server error"},
+		{"english for unsupported languages", "de-DE", "en", "internal server error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/bookings/42", nil)
+			req.Header.Set("Accept-Language", test.header)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var body map[string]string
+			json.Unmarshal(w.Body.Bytes(), &body)
+
+			assert.Equal(t, test.language, w.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+			assert.Equal(t, test.message, body["error"])
+		})
+	}
+}
+
+func TestDefaultLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(api.Language(api.WithDefaultLanguage(func(ctx context.Context) i18n.Language {
+		return i18n.French
+	})), api.Recovery(nil))
+	router.GET("/bookings/:id", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	for header, language := range map[string]string{"": "fr", "de-DE": "fr", "en-US": "en"} {
+		req, _ := http.NewRequest("GET", "/bookings/42", nil)
+		req.Header.Set("Accept-Language", header)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, language, w.Header().Get("Content-Language"), header)
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultMaintenanceMessage = "The booking system is under maintenance, please try again later."
+
+type MaintenanceState struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// Maintenance is the read-only mode of the API, used while the schema is
+// migrated. The state is kept in memory by each replica.
+type Maintenance struct {
+	mu    sync.RWMutex
+	state MaintenanceState
+}
+
+func NewMaintenance(enabled bool, message string) *Maintenance {
+	m := &Maintenance{}
+	m.Set(enabled, message)
+
+	return m
+}
+
+func (m *Maintenance) State() MaintenanceState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.state
+}
+
+func (m *Maintenance) Set(enabled bool, message string) MaintenanceState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(message) == 0 {
+		message = DefaultMaintenanceMessage
+	}
+
+	since := m.state.Since
+
+	if !enabled {
+		since = nil
+	} else if !m.state.Enabled {
+		now := time.Now()
+		since = &now
+	}
+
+	m.state = MaintenanceState{Enabled: enabled, Message: message, Since: since}
+
+	return m.state
+}
+
+// ReadOnly rejects writes with a 503 while maintenance is enabled. Reads and
+// the routes in exempt, given as "METHOD /route/:param", still work.
+func ReadOnly(m *Maintenance, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+
+		state := m.State()
+
+		if !state.Enabled {
+			return
+		}
+
+		route := c.Request.Method + " " + c.FullPath()
+
+		for _, e := range exempt {
+			if route == e {
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":       state.Message,
+			"maintenance": true,
+		})
+	}
+}
+
+type MaintenanceHandler struct {
+	maintenance *Maintenance
+}
+
+type MaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
+
+func NewMaintenanceHandler(maintenance *Maintenance) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenance: maintenance}
+}
+
+func (h *MaintenanceHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/maintenance", h.Get)
+	rg.PUT("/maintenance", AdminOnly(), h.Set)
+}
+
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, h.maintenance.State())
+}
+
+func (h *MaintenanceHandler) Set(c *gin.Context) {
+	var req MaintenanceRequest
+
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, h.maintenance.Set(req.Enabled, req.Message))
+}
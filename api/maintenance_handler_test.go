@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMaintenanceRouter(maintenance *api.Maintenance, user discord.DiscordUser) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(api.ReadOnly(maintenance, "PUT /api/v1/admin/maintenance"))
+
+	admin := router.Group("/api/v1/admin")
+	admin.Use(setUserInContext(user))
+	api.NewMaintenanceHandler(maintenance).Register(admin)
+
+	router.GET("/api/v1/bookings", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/api/v1/bookings", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	return router
+}
+
+func TestMaintenance(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("writes rejected, reads allowed", func(t *testing.T) {
+		router := setupMaintenanceRouter(api.NewMaintenance(true, "migrating"), admin)
+
+		req, _ := http.NewRequest("POST", "/api/v1/bookings", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"error": "migrating", "maintenance": true}`, w.Body.String())
+
+		req, _ = http.NewRequest("GET", "/api/v1/bookings", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("admin lifts maintenance", func(t *testing.T) {
+		maintenance := api.NewMaintenance(true, "")
+		router := setupMaintenanceRouter(maintenance, admin)
+
+		body, _ := json.Marshal(api.MaintenanceRequest{Enabled: false})
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/maintenance", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, maintenance.State().Enabled)
+
+		req, _ = http.NewRequest("POST", "/api/v1/bookings", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		maintenance := api.NewMaintenance(false, "")
+		router := setupMaintenanceRouter(maintenance, discord.DiscordUser{ID: "2", Username: "user"})
+
+		body, _ := json.Marshal(api.MaintenanceRequest{Enabled: true})
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/maintenance", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.False(t, maintenance.State().Enabled)
+	})
+}
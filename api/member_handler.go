@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/member"
+)
+
+type MemberService interface {
+	GetMembers(ctx context.Context) ([]member.Member, error)
+	SaveMember(ctx context.Context, m member.Member) (member.Member, error)
+	RemoveMember(ctx context.Context, userID string) error
+}
+
+type MemberHandler struct {
+	service MemberService
+}
+
+func NewMemberHandler(service MemberService) *MemberHandler {
+	return &MemberHandler{service: service}
+}
+
+func (h *MemberHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("/members", adminOnly, h.List)
+	rg.POST("/members", adminOnly, h.Create)
+	rg.DELETE("/members/:userId", adminOnly, h.Delete)
+}
+
+func (h *MemberHandler) List(c *gin.Context) {
+	members, err := h.service.GetMembers(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve members")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, members)
+}
+
+// Create registers a member, or whitelists a guest for the prime-time slots
+// when guest is set.
+func (h *MemberHandler) Create(c *gin.Context) {
+	var m member.Member
+
+	if !bindJSON(c, &m) {
+		return
+	}
+
+	saved, err := h.service.SaveMember(c.Request.Context(), m)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, member.ErrInvalidMember) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to save member")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+func (h *MemberHandler) Delete(c *gin.Context) {
+	userID := c.Param("userId")
+
+	err := h.service.RemoveMember(c.Request.Context(), userID)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, member.ErrMemberNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "member not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to remove member")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "member removed")})
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: AchievementService)
+//
+// Generated by this command:
+//
+//	mockgen . AchievementService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	achievement "github.com/hanksha/tbz-booking-system-backend/achievement"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAchievementService is a mock of AchievementService interface.
+type MockAchievementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAchievementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAchievementServiceMockRecorder is the mock recorder for MockAchievementService.
+type MockAchievementServiceMockRecorder struct {
+	mock *MockAchievementService
+}
+
+// NewMockAchievementService creates a new mock instance.
+func NewMockAchievementService(ctrl *gomock.Controller) *MockAchievementService {
+	mock := &MockAchievementService{ctrl: ctrl}
+	mock.recorder = &MockAchievementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAchievementService) EXPECT() *MockAchievementServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAchievements mocks base method.
+func (m *MockAchievementService) GetAchievements(ctx context.Context, username string) (achievement.Achievements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAchievements", ctx, username)
+	ret0, _ := ret[0].(achievement.Achievements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAchievements indicates an expected call of GetAchievements.
+func (mr *MockAchievementServiceMockRecorder) GetAchievements(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAchievements", reflect.TypeOf((*MockAchievementService)(nil).GetAchievements), ctx, username)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: BanService)
+//
+// Generated by this command:
+//
+//	mockgen . BanService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ban "github.com/hanksha/tbz-booking-system-backend/ban"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBanService is a mock of BanService interface.
+type MockBanService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBanServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBanServiceMockRecorder is the mock recorder for MockBanService.
+type MockBanServiceMockRecorder struct {
+	mock *MockBanService
+}
+
+// NewMockBanService creates a new mock instance.
+func NewMockBanService(ctrl *gomock.Controller) *MockBanService {
+	mock := &MockBanService{ctrl: ctrl}
+	mock.recorder = &MockBanServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBanService) EXPECT() *MockBanServiceMockRecorder {
+	return m.recorder
+}
+
+// BanUser mocks base method.
+func (m *MockBanService) BanUser(ctx context.Context, arg1 ban.Ban) (ban.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanUser", ctx, arg1)
+	ret0, _ := ret[0].(ban.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanUser indicates an expected call of BanUser.
+func (mr *MockBanServiceMockRecorder) BanUser(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanUser", reflect.TypeOf((*MockBanService)(nil).BanUser), ctx, arg1)
+}
+
+// GetActiveBans mocks base method.
+func (m *MockBanService) GetActiveBans(ctx context.Context) ([]ban.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBans", ctx)
+	ret0, _ := ret[0].([]ban.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBans indicates an expected call of GetActiveBans.
+func (mr *MockBanServiceMockRecorder) GetActiveBans(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBans", reflect.TypeOf((*MockBanService)(nil).GetActiveBans), ctx)
+}
+
+// LiftBan mocks base method.
+func (m *MockBanService) LiftBan(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftBan", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LiftBan indicates an expected call of LiftBan.
+func (mr *MockBanServiceMockRecorder) LiftBan(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftBan", reflect.TypeOf((*MockBanService)(nil).LiftBan), ctx, userID)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//      mockgen . BookingService
+//	mockgen . BookingService
 //
 
 // Package mock_api is a generated GoMock package.
@@ -11,6 +11,7 @@ package mock_api
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -57,6 +58,36 @@ func (mr *MockBookingServiceMockRecorder) AcceptBooking(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptBooking", reflect.TypeOf((*MockBookingService)(nil).AcceptBooking), ctx, id)
 }
 
+// AcknowledgeReminder mocks base method.
+func (m *MockBookingService) AcknowledgeReminder(ctx context.Context, id string, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeReminder", ctx, id, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeReminder indicates an expected call of AcknowledgeReminder.
+func (mr *MockBookingServiceMockRecorder) AcknowledgeReminder(ctx, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeReminder", reflect.TypeOf((*MockBookingService)(nil).AcknowledgeReminder), ctx, id, user)
+}
+
+// AddAttachment mocks base method.
+func (m *MockBookingService) AddAttachment(ctx context.Context, bookingID, filename string, content io.Reader, user discord.DiscordUser) (booking.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", ctx, bookingID, filename, content, user)
+	ret0, _ := ret[0].(booking.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockBookingServiceMockRecorder) AddAttachment(ctx, bookingID, filename, content, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockBookingService)(nil).AddAttachment), ctx, bookingID, filename, content, user)
+}
+
 // CancelBooking mocks base method.
 func (m *MockBookingService) CancelBooking(ctx context.Context, id string, user discord.DiscordUser) error {
 	m.ctrl.T.Helper()
@@ -71,19 +102,78 @@ func (mr *MockBookingServiceMockRecorder) CancelBooking(ctx, id, user any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockBookingService)(nil).CancelBooking), ctx, id, user)
 }
 
-// CreateBooking mocks base method.
-func (m *MockBookingService) CreateBooking(ctx context.Context, arg1 booking.Booking) (booking.Booking, error) {
+// CheckInURL mocks base method.
+func (m *MockBookingService) CheckInURL(ctx context.Context, id string, user discord.DiscordUser) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBooking", ctx, arg1)
-	ret0, _ := ret[0].(booking.Booking)
+	ret := m.ctrl.Call(m, "CheckInURL", ctx, id, user)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBooking indicates an expected call of CreateBooking.
-func (mr *MockBookingServiceMockRecorder) CreateBooking(ctx, arg1 any) *gomock.Call {
+// CheckInURL indicates an expected call of CheckInURL.
+func (mr *MockBookingServiceMockRecorder) CheckInURL(ctx, id, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockBookingService)(nil).CreateBooking), ctx, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInURL", reflect.TypeOf((*MockBookingService)(nil).CheckInURL), ctx, id, user)
+}
+
+// CountBookings mocks base method.
+func (m *MockBookingService) CountBookings(ctx context.Context, user *discord.DiscordUser, filter booking.Filter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBookings", ctx, user, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBookings indicates an expected call of CountBookings.
+func (mr *MockBookingServiceMockRecorder) CountBookings(ctx, user, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBookings", reflect.TypeOf((*MockBookingService)(nil).CountBookings), ctx, user, filter)
+}
+
+// CreateBookingWithWarnings mocks base method.
+func (m *MockBookingService) CreateBookingWithWarnings(ctx context.Context, arg1 booking.Booking) (booking.Booking, []booking.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBookingWithWarnings", ctx, arg1)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].([]booking.Warning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBookingWithWarnings indicates an expected call of CreateBookingWithWarnings.
+func (mr *MockBookingServiceMockRecorder) CreateBookingWithWarnings(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBookingWithWarnings", reflect.TypeOf((*MockBookingService)(nil).CreateBookingWithWarnings), ctx, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockBookingService) DeleteAttachment(ctx context.Context, bookingID, id string, user discord.DiscordUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, bookingID, id, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockBookingServiceMockRecorder) DeleteAttachment(ctx, bookingID, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockBookingService)(nil).DeleteAttachment), ctx, bookingID, id, user)
+}
+
+// DeleteBooking mocks base method.
+func (m *MockBookingService) DeleteBooking(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooking", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBooking indicates an expected call of DeleteBooking.
+func (mr *MockBookingServiceMockRecorder) DeleteBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooking", reflect.TypeOf((*MockBookingService)(nil).DeleteBooking), ctx, id)
 }
 
 // FindBookingByID mocks base method.
@@ -101,34 +191,124 @@ func (mr *MockBookingServiceMockRecorder) FindBookingByID(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingByID", reflect.TypeOf((*MockBookingService)(nil).FindBookingByID), ctx, id)
 }
 
+// FindBookings mocks base method.
+func (m *MockBookingService) FindBookings(ctx context.Context, user *discord.DiscordUser, filter booking.Filter) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookings", ctx, user, filter)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookings indicates an expected call of FindBookings.
+func (mr *MockBookingServiceMockRecorder) FindBookings(ctx, user, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookings", reflect.TypeOf((*MockBookingService)(nil).FindBookings), ctx, user, filter)
+}
+
+// FindBookingsByIDs mocks base method.
+func (m *MockBookingService) FindBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingsByIDs", ctx, user, ids)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingsByIDs indicates an expected call of FindBookingsByIDs.
+func (mr *MockBookingServiceMockRecorder) FindBookingsByIDs(ctx, user, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsByIDs", reflect.TypeOf((*MockBookingService)(nil).FindBookingsByIDs), ctx, user, ids)
+}
+
 // FindBookingsPerUsername mocks base method.
-func (m *MockBookingService) FindBookingsPerUsername(ctx context.Context, username string) ([]booking.Booking, error) {
+func (m *MockBookingService) FindBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]booking.Booking, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindBookingsPerUsername", ctx, username)
+	ret := m.ctrl.Call(m, "FindBookingsPerUsername", ctx, user, username)
 	ret0, _ := ret[0].([]booking.Booking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindBookingsPerUsername indicates an expected call of FindBookingsPerUsername.
-func (mr *MockBookingServiceMockRecorder) FindBookingsPerUsername(ctx, username any) *gomock.Call {
+func (mr *MockBookingServiceMockRecorder) FindBookingsPerUsername(ctx, user, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsPerUsername", reflect.TypeOf((*MockBookingService)(nil).FindBookingsPerUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsPerUsername", reflect.TypeOf((*MockBookingService)(nil).FindBookingsPerUsername), ctx, user, username)
 }
 
-// GetActiveBookings mocks base method.
-func (m *MockBookingService) GetActiveBookings(ctx context.Context) ([]booking.Booking, error) {
+// FindFreeSlots mocks base method.
+func (m *MockBookingService) FindFreeSlots(ctx context.Context, request booking.SlotRequest, user discord.DiscordUser) ([]booking.FreeSlot, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveBookings", ctx)
+	ret := m.ctrl.Call(m, "FindFreeSlots", ctx, request, user)
+	ret0, _ := ret[0].([]booking.FreeSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFreeSlots indicates an expected call of FindFreeSlots.
+func (mr *MockBookingServiceMockRecorder) FindFreeSlots(ctx, request, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFreeSlots", reflect.TypeOf((*MockBookingService)(nil).FindFreeSlots), ctx, request, user)
+}
+
+// FindResult mocks base method.
+func (m *MockBookingService) FindResult(ctx context.Context, id string) (booking.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindResult", ctx, id)
+	ret0, _ := ret[0].(booking.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindResult indicates an expected call of FindResult.
+func (mr *MockBookingServiceMockRecorder) FindResult(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindResult", reflect.TypeOf((*MockBookingService)(nil).FindResult), ctx, id)
+}
+
+// GetActiveBookingsIncludingDeleted mocks base method.
+func (m *MockBookingService) GetActiveBookingsIncludingDeleted(ctx context.Context) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBookingsIncludingDeleted", ctx)
 	ret0, _ := ret[0].([]booking.Booking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveBookings indicates an expected call of GetActiveBookings.
-func (mr *MockBookingServiceMockRecorder) GetActiveBookings(ctx any) *gomock.Call {
+// GetActiveBookingsIncludingDeleted indicates an expected call of GetActiveBookingsIncludingDeleted.
+func (mr *MockBookingServiceMockRecorder) GetActiveBookingsIncludingDeleted(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookingsIncludingDeleted", reflect.TypeOf((*MockBookingService)(nil).GetActiveBookingsIncludingDeleted), ctx)
+}
+
+// GetApprovalLatencies mocks base method.
+func (m *MockBookingService) GetApprovalLatencies(ctx context.Context, from, to time.Time) ([]booking.ApprovalLatency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalLatencies", ctx, from, to)
+	ret0, _ := ret[0].([]booking.ApprovalLatency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalLatencies indicates an expected call of GetApprovalLatencies.
+func (mr *MockBookingServiceMockRecorder) GetApprovalLatencies(ctx, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookings", reflect.TypeOf((*MockBookingService)(nil).GetActiveBookings), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalLatencies", reflect.TypeOf((*MockBookingService)(nil).GetApprovalLatencies), ctx, from, to)
+}
+
+// GetAttachments mocks base method.
+func (m *MockBookingService) GetAttachments(ctx context.Context, bookingID string, user *discord.DiscordUser) ([]booking.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, bookingID, user)
+	ret0, _ := ret[0].([]booking.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockBookingServiceMockRecorder) GetAttachments(ctx, bookingID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockBookingService)(nil).GetAttachments), ctx, bookingID, user)
 }
 
 // GetBookingCountPerGame mocks base method.
@@ -161,6 +341,36 @@ func (mr *MockBookingServiceMockRecorder) GetBookingCountPerGameInPeriod(ctx, st
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerGameInPeriod", reflect.TypeOf((*MockBookingService)(nil).GetBookingCountPerGameInPeriod), ctx, start, end)
 }
 
+// GetBookingCountPerPair mocks base method.
+func (m *MockBookingService) GetBookingCountPerPair(ctx context.Context, limit int) ([]booking.PairBookingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingCountPerPair", ctx, limit)
+	ret0, _ := ret[0].([]booking.PairBookingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingCountPerPair indicates an expected call of GetBookingCountPerPair.
+func (mr *MockBookingServiceMockRecorder) GetBookingCountPerPair(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerPair", reflect.TypeOf((*MockBookingService)(nil).GetBookingCountPerPair), ctx, limit)
+}
+
+// GetBookingCountPerTag mocks base method.
+func (m *MockBookingService) GetBookingCountPerTag(ctx context.Context) ([]booking.TagBookingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingCountPerTag", ctx)
+	ret0, _ := ret[0].([]booking.TagBookingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingCountPerTag indicates an expected call of GetBookingCountPerTag.
+func (mr *MockBookingServiceMockRecorder) GetBookingCountPerTag(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerTag", reflect.TypeOf((*MockBookingService)(nil).GetBookingCountPerTag), ctx)
+}
+
 // GetBookingCountPerWeekDay mocks base method.
 func (m *MockBookingService) GetBookingCountPerWeekDay(ctx context.Context) ([]booking.WeekDayBookingCount, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +386,81 @@ func (mr *MockBookingServiceMockRecorder) GetBookingCountPerWeekDay(ctx any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerWeekDay", reflect.TypeOf((*MockBookingService)(nil).GetBookingCountPerWeekDay), ctx)
 }
 
+// GetBookingHistory mocks base method.
+func (m *MockBookingService) GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter booking.Filter, cursor string, limit int) (booking.HistoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingHistory", ctx, user, filter, cursor, limit)
+	ret0, _ := ret[0].(booking.HistoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingHistory indicates an expected call of GetBookingHistory.
+func (mr *MockBookingServiceMockRecorder) GetBookingHistory(ctx, user, filter, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingHistory", reflect.TypeOf((*MockBookingService)(nil).GetBookingHistory), ctx, user, filter, cursor, limit)
+}
+
+// GetHeatmap mocks base method.
+func (m *MockBookingService) GetHeatmap(ctx context.Context, from, to time.Time) (booking.Heatmap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeatmap", ctx, from, to)
+	ret0, _ := ret[0].(booking.Heatmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeatmap indicates an expected call of GetHeatmap.
+func (mr *MockBookingServiceMockRecorder) GetHeatmap(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeatmap", reflect.TypeOf((*MockBookingService)(nil).GetHeatmap), ctx, from, to)
+}
+
+// GetNoShowCounts mocks base method.
+func (m *MockBookingService) GetNoShowCounts(ctx context.Context, start time.Time) ([]booking.NoShowCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoShowCounts", ctx, start)
+	ret0, _ := ret[0].([]booking.NoShowCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoShowCounts indicates an expected call of GetNoShowCounts.
+func (mr *MockBookingServiceMockRecorder) GetNoShowCounts(ctx, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoShowCounts", reflect.TypeOf((*MockBookingService)(nil).GetNoShowCounts), ctx, start)
+}
+
+// GetPayment mocks base method.
+func (m *MockBookingService) GetPayment(ctx context.Context, id string, user discord.DiscordUser) (booking.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, id, user)
+	ret0, _ := ret[0].(booking.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockBookingServiceMockRecorder) GetPayment(ctx, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockBookingService)(nil).GetPayment), ctx, id, user)
+}
+
+// GetPendingQueue mocks base method.
+func (m *MockBookingService) GetPendingQueue(ctx context.Context) ([]booking.QueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingQueue", ctx)
+	ret0, _ := ret[0].([]booking.QueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingQueue indicates an expected call of GetPendingQueue.
+func (mr *MockBookingServiceMockRecorder) GetPendingQueue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingQueue", reflect.TypeOf((*MockBookingService)(nil).GetPendingQueue), ctx)
+}
+
 // ImportBookings mocks base method.
 func (m *MockBookingService) ImportBookings(ctx context.Context, bookings []booking.Booking) error {
 	m.ctrl.T.Helper()
@@ -190,6 +475,36 @@ func (mr *MockBookingServiceMockRecorder) ImportBookings(ctx, bookings any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBookings", reflect.TypeOf((*MockBookingService)(nil).ImportBookings), ctx, bookings)
 }
 
+// JoinBooking mocks base method.
+func (m *MockBookingService) JoinBooking(ctx context.Context, id string, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinBooking", ctx, id, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinBooking indicates an expected call of JoinBooking.
+func (mr *MockBookingServiceMockRecorder) JoinBooking(ctx, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinBooking", reflect.TypeOf((*MockBookingService)(nil).JoinBooking), ctx, id, user)
+}
+
+// LeaveBooking mocks base method.
+func (m *MockBookingService) LeaveBooking(ctx context.Context, id string, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveBooking", ctx, id, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaveBooking indicates an expected call of LeaveBooking.
+func (mr *MockBookingServiceMockRecorder) LeaveBooking(ctx, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveBooking", reflect.TypeOf((*MockBookingService)(nil).LeaveBooking), ctx, id, user)
+}
+
 // ModifyBooking mocks base method.
 func (m *MockBookingService) ModifyBooking(ctx context.Context, updated booking.Booking, user discord.DiscordUser) error {
 	m.ctrl.T.Helper()
@@ -204,6 +519,37 @@ func (mr *MockBookingServiceMockRecorder) ModifyBooking(ctx, updated, user any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyBooking", reflect.TypeOf((*MockBookingService)(nil).ModifyBooking), ctx, updated, user)
 }
 
+// OpenAttachment mocks base method.
+func (m *MockBookingService) OpenAttachment(ctx context.Context, bookingID, id string, user *discord.DiscordUser) (booking.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, bookingID, id, user)
+	ret0, _ := ret[0].(booking.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockBookingServiceMockRecorder) OpenAttachment(ctx, bookingID, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockBookingService)(nil).OpenAttachment), ctx, bookingID, id, user)
+}
+
+// OptOutOfReminders mocks base method.
+func (m *MockBookingService) OptOutOfReminders(ctx context.Context, id string, optOut bool, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OptOutOfReminders", ctx, id, optOut, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OptOutOfReminders indicates an expected call of OptOutOfReminders.
+func (mr *MockBookingServiceMockRecorder) OptOutOfReminders(ctx, id, optOut, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OptOutOfReminders", reflect.TypeOf((*MockBookingService)(nil).OptOutOfReminders), ctx, id, optOut, user)
+}
+
 // RefuseBooking mocks base method.
 func (m *MockBookingService) RefuseBooking(ctx context.Context, id, reason string) error {
 	m.ctrl.T.Helper()
@@ -217,3 +563,151 @@ func (mr *MockBookingServiceMockRecorder) RefuseBooking(ctx, id, reason any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefuseBooking", reflect.TypeOf((*MockBookingService)(nil).RefuseBooking), ctx, id, reason)
 }
+
+// ReopenBooking mocks base method.
+func (m *MockBookingService) ReopenBooking(ctx context.Context, id string) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenBooking", ctx, id)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenBooking indicates an expected call of ReopenBooking.
+func (mr *MockBookingServiceMockRecorder) ReopenBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenBooking", reflect.TypeOf((*MockBookingService)(nil).ReopenBooking), ctx, id)
+}
+
+// ReplacePlayer mocks base method.
+func (m *MockBookingService) ReplacePlayer(ctx context.Context, id, oldPlayer, newPlayer string, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePlayer", ctx, id, oldPlayer, newPlayer, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplacePlayer indicates an expected call of ReplacePlayer.
+func (mr *MockBookingServiceMockRecorder) ReplacePlayer(ctx, id, oldPlayer, newPlayer, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePlayer", reflect.TypeOf((*MockBookingService)(nil).ReplacePlayer), ctx, id, oldPlayer, newPlayer, user)
+}
+
+// ReportResult mocks base method.
+func (m *MockBookingService) ReportResult(ctx context.Context, id string, result booking.Result, user discord.DiscordUser) (booking.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportResult", ctx, id, result, user)
+	ret0, _ := ret[0].(booking.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportResult indicates an expected call of ReportResult.
+func (mr *MockBookingServiceMockRecorder) ReportResult(ctx, id, result, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportResult", reflect.TypeOf((*MockBookingService)(nil).ReportResult), ctx, id, result, user)
+}
+
+// ResolvePlayers mocks base method.
+func (m *MockBookingService) ResolvePlayers(ctx context.Context, players []string) []booking.Player {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolvePlayers", ctx, players)
+	ret0, _ := ret[0].([]booking.Player)
+	return ret0
+}
+
+// ResolvePlayers indicates an expected call of ResolvePlayers.
+func (mr *MockBookingServiceMockRecorder) ResolvePlayers(ctx, players any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolvePlayers", reflect.TypeOf((*MockBookingService)(nil).ResolvePlayers), ctx, players)
+}
+
+// RetryNotification mocks base method.
+func (m *MockBookingService) RetryNotification(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryNotification", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryNotification indicates an expected call of RetryNotification.
+func (mr *MockBookingServiceMockRecorder) RetryNotification(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryNotification", reflect.TypeOf((*MockBookingService)(nil).RetryNotification), ctx, id)
+}
+
+// SearchBookings mocks base method.
+func (m *MockBookingService) SearchBookings(ctx context.Context, user *discord.DiscordUser, query string, limit int) ([]booking.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBookings", ctx, user, query, limit)
+	ret0, _ := ret[0].([]booking.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBookings indicates an expected call of SearchBookings.
+func (mr *MockBookingServiceMockRecorder) SearchBookings(ctx, user, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBookings", reflect.TypeOf((*MockBookingService)(nil).SearchBookings), ctx, user, query, limit)
+}
+
+// SetPaymentStatus mocks base method.
+func (m *MockBookingService) SetPaymentStatus(ctx context.Context, id, status string) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentStatus", ctx, id, status)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPaymentStatus indicates an expected call of SetPaymentStatus.
+func (mr *MockBookingServiceMockRecorder) SetPaymentStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentStatus", reflect.TypeOf((*MockBookingService)(nil).SetPaymentStatus), ctx, id, status)
+}
+
+// SetPriority mocks base method.
+func (m *MockBookingService) SetPriority(ctx context.Context, id, priority string) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPriority", ctx, id, priority)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPriority indicates an expected call of SetPriority.
+func (mr *MockBookingServiceMockRecorder) SetPriority(ctx, id, priority any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockBookingService)(nil).SetPriority), ctx, id, priority)
+}
+
+// SetReminders mocks base method.
+func (m *MockBookingService) SetReminders(ctx context.Context, id string, reminders booking.Reminders, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminders", ctx, id, reminders, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReminders indicates an expected call of SetReminders.
+func (mr *MockBookingServiceMockRecorder) SetReminders(ctx, id, reminders, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminders", reflect.TypeOf((*MockBookingService)(nil).SetReminders), ctx, id, reminders, user)
+}
+
+// SnoozeReminder mocks base method.
+func (m *MockBookingService) SnoozeReminder(ctx context.Context, id string, snooze time.Duration, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", ctx, id, snooze, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder.
+func (mr *MockBookingServiceMockRecorder) SnoozeReminder(ctx, id, snooze, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockBookingService)(nil).SnoozeReminder), ctx, id, snooze, user)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: CampaignService)
+//
+// Generated by this command:
+//
+//	mockgen . CampaignService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	campaign "github.com/hanksha/tbz-booking-system-backend/campaign"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCampaignService is a mock of CampaignService interface.
+type MockCampaignService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCampaignServiceMockRecorder is the mock recorder for MockCampaignService.
+type MockCampaignServiceMockRecorder struct {
+	mock *MockCampaignService
+}
+
+// NewMockCampaignService creates a new mock instance.
+func NewMockCampaignService(ctrl *gomock.Controller) *MockCampaignService {
+	mock := &MockCampaignService{ctrl: ctrl}
+	mock.recorder = &MockCampaignServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaignService) EXPECT() *MockCampaignServiceMockRecorder {
+	return m.recorder
+}
+
+// AttachBooking mocks base method.
+func (m *MockCampaignService) AttachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachBooking", ctx, id, bookingID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachBooking indicates an expected call of AttachBooking.
+func (mr *MockCampaignServiceMockRecorder) AttachBooking(ctx, id, bookingID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachBooking", reflect.TypeOf((*MockCampaignService)(nil).AttachBooking), ctx, id, bookingID, user)
+}
+
+// CreateCampaign mocks base method.
+func (m *MockCampaignService) CreateCampaign(ctx context.Context, arg1 campaign.Campaign) (campaign.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, arg1)
+	ret0, _ := ret[0].(campaign.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockCampaignServiceMockRecorder) CreateCampaign(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaignService)(nil).CreateCampaign), ctx, arg1)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockCampaignService) DeleteCampaign(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockCampaignServiceMockRecorder) DeleteCampaign(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaignService)(nil).DeleteCampaign), ctx, id)
+}
+
+// DetachBooking mocks base method.
+func (m *MockCampaignService) DetachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachBooking", ctx, id, bookingID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachBooking indicates an expected call of DetachBooking.
+func (mr *MockCampaignServiceMockRecorder) DetachBooking(ctx, id, bookingID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachBooking", reflect.TypeOf((*MockCampaignService)(nil).DetachBooking), ctx, id, bookingID, user)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaignService) GetCampaign(ctx context.Context, id string, user *discord.DiscordUser) (campaign.Detail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, id, user)
+	ret0, _ := ret[0].(campaign.Detail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignServiceMockRecorder) GetCampaign(ctx, id, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaignService)(nil).GetCampaign), ctx, id, user)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockCampaignService) GetCampaigns(ctx context.Context) ([]campaign.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx)
+	ret0, _ := ret[0].([]campaign.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockCampaignServiceMockRecorder) GetCampaigns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockCampaignService)(nil).GetCampaigns), ctx)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: EquipmentService)
+//
+// Generated by this command:
+//
+//	mockgen . EquipmentService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	equipment "github.com/hanksha/tbz-booking-system-backend/equipment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEquipmentService is a mock of EquipmentService interface.
+type MockEquipmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEquipmentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEquipmentServiceMockRecorder is the mock recorder for MockEquipmentService.
+type MockEquipmentServiceMockRecorder struct {
+	mock *MockEquipmentService
+}
+
+// NewMockEquipmentService creates a new mock instance.
+func NewMockEquipmentService(ctrl *gomock.Controller) *MockEquipmentService {
+	mock := &MockEquipmentService{ctrl: ctrl}
+	mock.recorder = &MockEquipmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEquipmentService) EXPECT() *MockEquipmentServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockEquipmentService) CreateItem(ctx context.Context, item equipment.Item) (equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, item)
+	ret0, _ := ret[0].(equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockEquipmentServiceMockRecorder) CreateItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockEquipmentService)(nil).CreateItem), ctx, item)
+}
+
+// DeleteItem mocks base method.
+func (m *MockEquipmentService) DeleteItem(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockEquipmentServiceMockRecorder) DeleteItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockEquipmentService)(nil).DeleteItem), ctx, id)
+}
+
+// GetAllocations mocks base method.
+func (m *MockEquipmentService) GetAllocations(ctx context.Context, at time.Time) ([]equipment.Allocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllocations", ctx, at)
+	ret0, _ := ret[0].([]equipment.Allocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllocations indicates an expected call of GetAllocations.
+func (mr *MockEquipmentServiceMockRecorder) GetAllocations(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocations", reflect.TypeOf((*MockEquipmentService)(nil).GetAllocations), ctx, at)
+}
+
+// GetItems mocks base method.
+func (m *MockEquipmentService) GetItems(ctx context.Context) ([]equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx)
+	ret0, _ := ret[0].([]equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockEquipmentServiceMockRecorder) GetItems(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockEquipmentService)(nil).GetItems), ctx)
+}
+
+// UpdateItem mocks base method.
+func (m *MockEquipmentService) UpdateItem(ctx context.Context, item equipment.Item) (equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret0, _ := ret[0].(equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockEquipmentServiceMockRecorder) UpdateItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockEquipmentService)(nil).UpdateItem), ctx, item)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: GameService)
+//
+// Generated by this command:
+//
+//	mockgen . GameService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	game "github.com/hanksha/tbz-booking-system-backend/game"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGameService is a mock of GameService interface.
+type MockGameService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGameServiceMockRecorder is the mock recorder for MockGameService.
+type MockGameServiceMockRecorder struct {
+	mock *MockGameService
+}
+
+// NewMockGameService creates a new mock instance.
+func NewMockGameService(ctrl *gomock.Controller) *MockGameService {
+	mock := &MockGameService{ctrl: ctrl}
+	mock.recorder = &MockGameServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameService) EXPECT() *MockGameServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteGame mocks base method.
+func (m *MockGameService) DeleteGame(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGame", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGame indicates an expected call of DeleteGame.
+func (mr *MockGameServiceMockRecorder) DeleteGame(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGame", reflect.TypeOf((*MockGameService)(nil).DeleteGame), ctx, name)
+}
+
+// GetGames mocks base method.
+func (m *MockGameService) GetGames(ctx context.Context) ([]game.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGames", ctx)
+	ret0, _ := ret[0].([]game.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGames indicates an expected call of GetGames.
+func (mr *MockGameServiceMockRecorder) GetGames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGames", reflect.TypeOf((*MockGameService)(nil).GetGames), ctx)
+}
+
+// SaveGame mocks base method.
+func (m *MockGameService) SaveGame(ctx context.Context, arg1 game.Game) (game.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGame", ctx, arg1)
+	ret0, _ := ret[0].(game.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveGame indicates an expected call of SaveGame.
+func (mr *MockGameServiceMockRecorder) SaveGame(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGame", reflect.TypeOf((*MockGameService)(nil).SaveGame), ctx, arg1)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: GuildConfigService)
+//
+// Generated by this command:
+//
+//	mockgen . GuildConfigService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	guild "github.com/hanksha/tbz-booking-system-backend/guild"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuildConfigService is a mock of GuildConfigService interface.
+type MockGuildConfigService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuildConfigServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGuildConfigServiceMockRecorder is the mock recorder for MockGuildConfigService.
+type MockGuildConfigServiceMockRecorder struct {
+	mock *MockGuildConfigService
+}
+
+// NewMockGuildConfigService creates a new mock instance.
+func NewMockGuildConfigService(ctrl *gomock.Controller) *MockGuildConfigService {
+	mock := &MockGuildConfigService{ctrl: ctrl}
+	mock.recorder = &MockGuildConfigServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuildConfigService) EXPECT() *MockGuildConfigServiceMockRecorder {
+	return m.recorder
+}
+
+// GetConfig mocks base method.
+func (m *MockGuildConfigService) GetConfig(ctx context.Context) (guild.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfig", ctx)
+	ret0, _ := ret[0].(guild.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfig indicates an expected call of GetConfig.
+func (mr *MockGuildConfigServiceMockRecorder) GetConfig(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockGuildConfigService)(nil).GetConfig), ctx)
+}
+
+// UpdateConfig mocks base method.
+func (m *MockGuildConfigService) UpdateConfig(ctx context.Context, cfg guild.Config) (guild.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConfig", ctx, cfg)
+	ret0, _ := ret[0].(guild.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConfig indicates an expected call of UpdateConfig.
+func (mr *MockGuildConfigServiceMockRecorder) UpdateConfig(ctx, cfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfig", reflect.TypeOf((*MockGuildConfigService)(nil).UpdateConfig), ctx, cfg)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: ICalService)
+//
+// Generated by this command:
+//
+//	mockgen . ICalService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	ical "github.com/hanksha/tbz-booking-system-backend/ical"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICalService is a mock of ICalService interface.
+type MockICalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICalServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockICalServiceMockRecorder is the mock recorder for MockICalService.
+type MockICalServiceMockRecorder struct {
+	mock *MockICalService
+}
+
+// NewMockICalService creates a new mock instance.
+func NewMockICalService(ctrl *gomock.Controller) *MockICalService {
+	mock := &MockICalService{ctrl: ctrl}
+	mock.recorder = &MockICalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICalService) EXPECT() *MockICalServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockICalService) CreateToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, user)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockICalServiceMockRecorder) CreateToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockICalService)(nil).CreateToken), ctx, user)
+}
+
+// Feed mocks base method.
+func (m *MockICalService) Feed(ctx context.Context, token string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Feed", ctx, token)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockICalServiceMockRecorder) Feed(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockICalService)(nil).Feed), ctx, token)
+}
+
+// GetToken mocks base method.
+func (m *MockICalService) GetToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", ctx, user)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockICalServiceMockRecorder) GetToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockICalService)(nil).GetToken), ctx, user)
+}
+
+// RevokeToken mocks base method.
+func (m *MockICalService) RevokeToken(ctx context.Context, user discord.DiscordUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockICalServiceMockRecorder) RevokeToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockICalService)(nil).RevokeToken), ctx, user)
+}
+
+// RotateToken mocks base method.
+func (m *MockICalService) RotateToken(ctx context.Context, user discord.DiscordUser) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateToken", ctx, user)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateToken indicates an expected call of RotateToken.
+func (mr *MockICalServiceMockRecorder) RotateToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateToken", reflect.TypeOf((*MockICalService)(nil).RotateToken), ctx, user)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: MemberService)
+//
+// Generated by this command:
+//
+//	mockgen . MemberService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	member "github.com/hanksha/tbz-booking-system-backend/member"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMemberService is a mock of MemberService interface.
+type MockMemberService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMemberServiceMockRecorder is the mock recorder for MockMemberService.
+type MockMemberServiceMockRecorder struct {
+	mock *MockMemberService
+}
+
+// NewMockMemberService creates a new mock instance.
+func NewMockMemberService(ctrl *gomock.Controller) *MockMemberService {
+	mock := &MockMemberService{ctrl: ctrl}
+	mock.recorder = &MockMemberServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberService) EXPECT() *MockMemberServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMembers mocks base method.
+func (m *MockMemberService) GetMembers(ctx context.Context) ([]member.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx)
+	ret0, _ := ret[0].([]member.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockMemberServiceMockRecorder) GetMembers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockMemberService)(nil).GetMembers), ctx)
+}
+
+// RemoveMember mocks base method.
+func (m *MockMemberService) RemoveMember(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockMemberServiceMockRecorder) RemoveMember(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockMemberService)(nil).RemoveMember), ctx, userID)
+}
+
+// SaveMember mocks base method.
+func (m_2 *MockMemberService) SaveMember(ctx context.Context, m member.Member) (member.Member, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SaveMember", ctx, m)
+	ret0, _ := ret[0].(member.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMember indicates an expected call of SaveMember.
+func (mr *MockMemberServiceMockRecorder) SaveMember(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockMemberService)(nil).SaveMember), ctx, m)
+}
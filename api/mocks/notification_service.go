@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: NotificationService)
+//
+// Generated by this command:
+//
+//	mockgen . NotificationService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	notification "github.com/hanksha/tbz-booking-system-backend/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// DiscardDeadLetter mocks base method.
+func (m *MockNotificationService) DiscardDeadLetter(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadLetter", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardDeadLetter indicates an expected call of DiscardDeadLetter.
+func (mr *MockNotificationServiceMockRecorder) DiscardDeadLetter(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadLetter", reflect.TypeOf((*MockNotificationService)(nil).DiscardDeadLetter), ctx, id)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockNotificationService) GetDeadLetters(ctx context.Context) ([]notification.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx)
+	ret0, _ := ret[0].([]notification.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockNotificationServiceMockRecorder) GetDeadLetters(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockNotificationService)(nil).GetDeadLetters), ctx)
+}
+
+// RetryDeadLetter mocks base method.
+func (m *MockNotificationService) RetryDeadLetter(ctx context.Context, id string) (notification.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDeadLetter", ctx, id)
+	ret0, _ := ret[0].(notification.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryDeadLetter indicates an expected call of RetryDeadLetter.
+func (mr *MockNotificationServiceMockRecorder) RetryDeadLetter(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDeadLetter", reflect.TypeOf((*MockNotificationService)(nil).RetryDeadLetter), ctx, id)
+}
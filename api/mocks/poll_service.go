@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: PollService)
+//
+// Generated by this command:
+//
+//	mockgen . PollService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	booking "github.com/hanksha/tbz-booking-system-backend/booking"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	poll "github.com/hanksha/tbz-booking-system-backend/poll"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPollService is a mock of PollService interface.
+type MockPollService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPollServiceMockRecorder is the mock recorder for MockPollService.
+type MockPollServiceMockRecorder struct {
+	mock *MockPollService
+}
+
+// NewMockPollService creates a new mock instance.
+func NewMockPollService(ctrl *gomock.Controller) *MockPollService {
+	mock := &MockPollService{ctrl: ctrl}
+	mock.recorder = &MockPollServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollService) EXPECT() *MockPollServiceMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockPollService) Book(ctx context.Context, id, slotID string, user discord.DiscordUser) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", ctx, id, slotID, user)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockPollServiceMockRecorder) Book(ctx, id, slotID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockPollService)(nil).Book), ctx, id, slotID, user)
+}
+
+// CreatePoll mocks base method.
+func (m *MockPollService) CreatePoll(ctx context.Context, arg1 poll.Poll, user discord.DiscordUser) (poll.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, arg1, user)
+	ret0, _ := ret[0].(poll.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePoll indicates an expected call of CreatePoll.
+func (mr *MockPollServiceMockRecorder) CreatePoll(ctx, arg1, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockPollService)(nil).CreatePoll), ctx, arg1, user)
+}
+
+// GetPoll mocks base method.
+func (m *MockPollService) GetPoll(ctx context.Context, id string) (poll.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoll", ctx, id)
+	ret0, _ := ret[0].(poll.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoll indicates an expected call of GetPoll.
+func (mr *MockPollServiceMockRecorder) GetPoll(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockPollService)(nil).GetPoll), ctx, id)
+}
+
+// Vote mocks base method.
+func (m *MockPollService) Vote(ctx context.Context, id string, slotIDs []string, user discord.DiscordUser) (poll.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, id, slotIDs, user)
+	ret0, _ := ret[0].(poll.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockPollServiceMockRecorder) Vote(ctx, id, slotIDs, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockPollService)(nil).Vote), ctx, id, slotIDs, user)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: PreferenceService)
+//
+// Generated by this command:
+//
+//	mockgen . PreferenceService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	preference "github.com/hanksha/tbz-booking-system-backend/preference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferenceService is a mock of PreferenceService interface.
+type MockPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPreferenceServiceMockRecorder is the mock recorder for MockPreferenceService.
+type MockPreferenceServiceMockRecorder struct {
+	mock *MockPreferenceService
+}
+
+// NewMockPreferenceService creates a new mock instance.
+func NewMockPreferenceService(ctrl *gomock.Controller) *MockPreferenceService {
+	mock := &MockPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceService) EXPECT() *MockPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceService) GetPreferences(ctx context.Context, user discord.DiscordUser) (preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, user)
+	ret0, _ := ret[0].(preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceServiceMockRecorder) GetPreferences(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceService)(nil).GetPreferences), ctx, user)
+}
+
+// SavePreferences mocks base method.
+func (m *MockPreferenceService) SavePreferences(ctx context.Context, user discord.DiscordUser, prefs preference.Preferences) (preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, user, prefs)
+	ret0, _ := ret[0].(preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockPreferenceServiceMockRecorder) SavePreferences(ctx, user, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockPreferenceService)(nil).SavePreferences), ctx, user, prefs)
+}
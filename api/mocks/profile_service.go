@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: ProfileService)
+//
+// Generated by this command:
+//
+//	mockgen . ProfileService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	profile "github.com/hanksha/tbz-booking-system-backend/profile"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileService is a mock of ProfileService interface.
+type MockProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileServiceMockRecorder is the mock recorder for MockProfileService.
+type MockProfileServiceMockRecorder struct {
+	mock *MockProfileService
+}
+
+// NewMockProfileService creates a new mock instance.
+func NewMockProfileService(ctrl *gomock.Controller) *MockProfileService {
+	mock := &MockProfileService{ctrl: ctrl}
+	mock.recorder = &MockProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileService) EXPECT() *MockProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDirectory mocks base method.
+func (m *MockProfileService) GetDirectory(ctx context.Context, query, cursor string, limit int) (profile.DirectoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectory", ctx, query, cursor, limit)
+	ret0, _ := ret[0].(profile.DirectoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectory indicates an expected call of GetDirectory.
+func (mr *MockProfileServiceMockRecorder) GetDirectory(ctx, query, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectory", reflect.TypeOf((*MockProfileService)(nil).GetDirectory), ctx, query, cursor, limit)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: PublicBookingService)
+//
+// Generated by this command:
+//
+//	mockgen . PublicBookingService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	booking "github.com/hanksha/tbz-booking-system-backend/booking"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublicBookingService is a mock of PublicBookingService interface.
+type MockPublicBookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicBookingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPublicBookingServiceMockRecorder is the mock recorder for MockPublicBookingService.
+type MockPublicBookingServiceMockRecorder struct {
+	mock *MockPublicBookingService
+}
+
+// NewMockPublicBookingService creates a new mock instance.
+func NewMockPublicBookingService(ctrl *gomock.Controller) *MockPublicBookingService {
+	mock := &MockPublicBookingService{ctrl: ctrl}
+	mock.recorder = &MockPublicBookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicBookingService) EXPECT() *MockPublicBookingServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckIn mocks base method.
+func (m *MockPublicBookingService) CheckIn(ctx context.Context, id, token string) (booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, id, token)
+	ret0, _ := ret[0].(booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockPublicBookingServiceMockRecorder) CheckIn(ctx, id, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockPublicBookingService)(nil).CheckIn), ctx, id, token)
+}
+
+// ConfirmPayment mocks base method.
+func (m *MockPublicBookingService) ConfirmPayment(ctx context.Context, payload []byte, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, payload, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPayment indicates an expected call of ConfirmPayment.
+func (mr *MockPublicBookingServiceMockRecorder) ConfirmPayment(ctx, payload, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockPublicBookingService)(nil).ConfirmPayment), ctx, payload, signature)
+}
+
+// GetVisibleBookings mocks base method.
+func (m *MockPublicBookingService) GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibleBookings", ctx, user)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibleBookings indicates an expected call of GetVisibleBookings.
+func (mr *MockPublicBookingServiceMockRecorder) GetVisibleBookings(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibleBookings", reflect.TypeOf((*MockPublicBookingService)(nil).GetVisibleBookings), ctx, user)
+}
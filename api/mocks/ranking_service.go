@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: RankingService)
+//
+// Generated by this command:
+//
+//	mockgen . RankingService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ranking "github.com/hanksha/tbz-booking-system-backend/ranking"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingService is a mock of RankingService interface.
+type MockRankingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRankingServiceMockRecorder is the mock recorder for MockRankingService.
+type MockRankingServiceMockRecorder struct {
+	mock *MockRankingService
+}
+
+// NewMockRankingService creates a new mock instance.
+func NewMockRankingService(ctrl *gomock.Controller) *MockRankingService {
+	mock := &MockRankingService{ctrl: ctrl}
+	mock.recorder = &MockRankingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingService) EXPECT() *MockRankingServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRankings mocks base method.
+func (m *MockRankingService) GetRankings(ctx context.Context, game string) ([]ranking.Ranking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankings", ctx, game)
+	ret0, _ := ret[0].([]ranking.Ranking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankings indicates an expected call of GetRankings.
+func (mr *MockRankingServiceMockRecorder) GetRankings(ctx, game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankings", reflect.TypeOf((*MockRankingService)(nil).GetRankings), ctx, game)
+}
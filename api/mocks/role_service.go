@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: RoleService)
+//
+// Generated by this command:
+//
+//	mockgen . RoleService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	permission "github.com/hanksha/tbz-booking-system-backend/permission"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleService is a mock of RoleService interface.
+type MockRoleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleServiceMockRecorder is the mock recorder for MockRoleService.
+type MockRoleServiceMockRecorder struct {
+	mock *MockRoleService
+}
+
+// NewMockRoleService creates a new mock instance.
+func NewMockRoleService(ctrl *gomock.Controller) *MockRoleService {
+	mock := &MockRoleService{ctrl: ctrl}
+	mock.recorder = &MockRoleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleService) EXPECT() *MockRoleServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteMapping mocks base method.
+func (m *MockRoleService) DeleteMapping(ctx context.Context, roleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMapping", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMapping indicates an expected call of DeleteMapping.
+func (mr *MockRoleServiceMockRecorder) DeleteMapping(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMapping", reflect.TypeOf((*MockRoleService)(nil).DeleteMapping), ctx, roleID)
+}
+
+// GetMappings mocks base method.
+func (m *MockRoleService) GetMappings(ctx context.Context) ([]permission.RoleMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMappings", ctx)
+	ret0, _ := ret[0].([]permission.RoleMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMappings indicates an expected call of GetMappings.
+func (mr *MockRoleServiceMockRecorder) GetMappings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappings", reflect.TypeOf((*MockRoleService)(nil).GetMappings), ctx)
+}
+
+// SetMapping mocks base method.
+func (m *MockRoleService) SetMapping(ctx context.Context, mapping permission.RoleMapping) (permission.RoleMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMapping", ctx, mapping)
+	ret0, _ := ret[0].(permission.RoleMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMapping indicates an expected call of SetMapping.
+func (mr *MockRoleServiceMockRecorder) SetMapping(ctx, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMapping", reflect.TypeOf((*MockRoleService)(nil).SetMapping), ctx, mapping)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: UserService)
+//
+// Generated by this command:
+//
+//	mockgen . UserService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	booking "github.com/hanksha/tbz-booking-system-backend/booking"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// SuggestSlots mocks base method.
+func (m *MockUserService) SuggestSlots(ctx context.Context, user discord.DiscordUser) (booking.Suggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestSlots", ctx, user)
+	ret0, _ := ret[0].(booking.Suggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestSlots indicates an expected call of SuggestSlots.
+func (mr *MockUserServiceMockRecorder) SuggestSlots(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestSlots", reflect.TypeOf((*MockUserService)(nil).SuggestSlots), ctx, user)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/api (interfaces: VenueService)
+//
+// Generated by this command:
+//
+//	mockgen . VenueService
+//
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	venue "github.com/hanksha/tbz-booking-system-backend/venue"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVenueService is a mock of VenueService interface.
+type MockVenueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueServiceMockRecorder is the mock recorder for MockVenueService.
+type MockVenueServiceMockRecorder struct {
+	mock *MockVenueService
+}
+
+// NewMockVenueService creates a new mock instance.
+func NewMockVenueService(ctrl *gomock.Controller) *MockVenueService {
+	mock := &MockVenueService{ctrl: ctrl}
+	mock.recorder = &MockVenueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueService) EXPECT() *MockVenueServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateVenue mocks base method.
+func (m *MockVenueService) CreateVenue(ctx context.Context, arg1 venue.Venue) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVenue", ctx, arg1)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVenue indicates an expected call of CreateVenue.
+func (mr *MockVenueServiceMockRecorder) CreateVenue(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVenue", reflect.TypeOf((*MockVenueService)(nil).CreateVenue), ctx, arg1)
+}
+
+// DeleteVenue mocks base method.
+func (m *MockVenueService) DeleteVenue(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVenue indicates an expected call of DeleteVenue.
+func (mr *MockVenueServiceMockRecorder) DeleteVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenue", reflect.TypeOf((*MockVenueService)(nil).DeleteVenue), ctx, id)
+}
+
+// GetVenue mocks base method.
+func (m *MockVenueService) GetVenue(ctx context.Context, id string) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenue", ctx, id)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenue indicates an expected call of GetVenue.
+func (mr *MockVenueServiceMockRecorder) GetVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenue", reflect.TypeOf((*MockVenueService)(nil).GetVenue), ctx, id)
+}
+
+// GetVenues mocks base method.
+func (m *MockVenueService) GetVenues(ctx context.Context) ([]venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenues", ctx)
+	ret0, _ := ret[0].([]venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenues indicates an expected call of GetVenues.
+func (mr *MockVenueServiceMockRecorder) GetVenues(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenues", reflect.TypeOf((*MockVenueService)(nil).GetVenues), ctx)
+}
+
+// UpdateVenue mocks base method.
+func (m *MockVenueService) UpdateVenue(ctx context.Context, arg1 venue.Venue) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVenue", ctx, arg1)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVenue indicates an expected call of UpdateVenue.
+func (mr *MockVenueServiceMockRecorder) UpdateVenue(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVenue", reflect.TypeOf((*MockVenueService)(nil).UpdateVenue), ctx, arg1)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/notification"
+)
+
+type NotificationService interface {
+	GetDeadLetters(ctx context.Context) ([]notification.Notification, error)
+	RetryDeadLetter(ctx context.Context, id string) (notification.Notification, error)
+	DiscardDeadLetter(ctx context.Context, id string) error
+}
+
+// NotificationHandler lets admins review the Discord messages which could
+// not be delivered, and retry or discard them.
+type NotificationHandler struct {
+	service NotificationService
+}
+
+func NewNotificationHandler(service NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+func (h *NotificationHandler) Register(rg *gin.RouterGroup) {
+	notifications := rg.Group("/notifications", AdminOnly())
+
+	notifications.GET("/dead-letters", h.ListDeadLetters)
+	notifications.POST("/dead-letters/:id/retry", h.RetryDeadLetter)
+	notifications.DELETE("/dead-letters/:id", h.DiscardDeadLetter)
+}
+
+func (h *NotificationHandler) ListDeadLetters(c *gin.Context) {
+	deadLetters, err := h.service.GetDeadLetters(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve dead letters")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, deadLetters)
+}
+
+func (h *NotificationHandler) RetryDeadLetter(c *gin.Context) {
+	n, err := h.service.RetryDeadLetter(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, notification.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "notification not found")})
+		} else {
+			c.JSON(http.StatusBadGateway, gin.H{"error": translate(c, "failed to send notification"), "notification": n})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, n)
+}
+
+func (h *NotificationHandler) DiscardDeadLetter(c *gin.Context) {
+	err := h.service.DiscardDeadLetter(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, notification.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "notification not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to discard notification")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "notification discarded")})
+}
@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/notification"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupNotificationRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockNotificationService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockNotificationService(ctrl)
+	rg := router.Group("/api/v1/admin")
+	rg.Use(setUserInContext(user))
+	api.NewNotificationHandler(mockService).Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestNotificationDeadLetters(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("list", func(t *testing.T) {
+		router, ctrl, mockService := setupNotificationRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetDeadLetters(gomock.Any()).Return([]notification.Notification{{ID: "1", ChannelID: "c"}}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/notifications/dead-letters", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"channelId": "c"`)
+	})
+
+	t.Run("retry fails again", func(t *testing.T) {
+		router, ctrl, mockService := setupNotificationRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().RetryDeadLetter(gomock.Any(), "1").Return(notification.Notification{ID: "1", Attempts: 7}, errors.New("discord is down")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/notifications/dead-letters/1/retry", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	})
+
+	t.Run("discard unknown", func(t *testing.T) {
+		router, ctrl, mockService := setupNotificationRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().DiscardDeadLetter(gomock.Any(), "42").Return(notification.ErrNotificationNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/admin/notifications/dead-letters/42", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		router, ctrl, _ := setupNotificationRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/notifications/dead-letters", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
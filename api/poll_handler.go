@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/poll"
+)
+
+type PollService interface {
+	GetPoll(ctx context.Context, id string) (poll.Poll, error)
+	CreatePoll(ctx context.Context, poll poll.Poll, user discord.DiscordUser) (poll.Poll, error)
+	Vote(ctx context.Context, id string, slotIDs []string, user discord.DiscordUser) (poll.Poll, error)
+	Book(ctx context.Context, id, slotID string, user discord.DiscordUser) (bk.Booking, error)
+}
+
+// PollHandler serves the availability polls used to find a date before
+// booking.
+type PollHandler struct {
+	service PollService
+}
+
+func NewPollHandler(service PollService) *PollHandler {
+	return &PollHandler{service: service}
+}
+
+func (h *PollHandler) Register(rg *gin.RouterGroup) {
+	rg.POST("", h.Create)
+	rg.GET("/:id", h.Get)
+	rg.PUT("/:id/votes", h.Vote)
+	rg.POST("/:id/book", h.Book)
+}
+
+type voteRequest struct {
+	Slots []string `json:"slots"`
+}
+
+func (h *PollHandler) Get(c *gin.Context) {
+	p, err := h.service.GetPoll(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.pollError(c, err, "failed to retrieve poll")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, pollInDisplayLocation(p))
+}
+
+// Create opens a poll organized by the user, the slots' dateTime is RFC 3339
+// as for bookings.
+func (h *PollHandler) Create(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var p poll.Poll
+
+	if !bindJSON(c, &p) {
+		return
+	}
+
+	created, err := h.service.CreatePoll(c.Request.Context(), p, user)
+
+	if err != nil {
+		h.pollError(c, err, "failed to create poll")
+		return
+	}
+
+	c.JSON(http.StatusCreated, pollInDisplayLocation(created))
+}
+
+// Vote replaces the slots the user is available for.
+func (h *PollHandler) Vote(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	var request voteRequest
+
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	p, err := h.service.Vote(c.Request.Context(), c.Param("id"), request.Slots, user)
+
+	if err != nil {
+		h.pollError(c, err, "failed to vote")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, pollInDisplayLocation(p))
+}
+
+// Book creates the booking of the slot given by the slot query parameter,
+// or of the winning slot.
+func (h *PollHandler) Book(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	booking, err := h.service.Book(c.Request.Context(), c.Param("id"), c.Query("slot"), user)
+
+	if err != nil {
+		var unknown *bk.UnknownPlayersError
+		if errors.As(err, &unknown) {
+			c.Error(err)
+			c.JSON(http.StatusUnprocessableEntity, unknownPlayersResponse(c, unknown))
+			return
+		}
+		h.pollError(c, err, "failed to create booking")
+		return
+	}
+
+	c.JSON(http.StatusCreated, NewBookingResponse(booking, &user, time.Now()))
+}
+
+func (h *PollHandler) pollError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, poll.ErrPollNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "poll not found")})
+	case errors.Is(err, poll.ErrInvalidPoll):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, poll.ErrNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "not allowed")})
+	case errors.Is(err, poll.ErrPollClosed), errors.Is(err, poll.ErrNoVotes):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, bk.ErrUserSuspended):
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "user is suspended from booking")})
+	case errors.Is(err, bk.ErrMembershipRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, bk.ErrInvalidPoints), errors.Is(err, bk.ErrTooManyPlayers), errors.Is(err, bk.ErrInvalidCustomFields):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
+
+func pollInDisplayLocation(p poll.Poll) poll.Poll {
+	for i := range p.Slots {
+		p.Slots[i].DateTime = p.Slots[i].DateTime.In(displayLocation)
+	}
+
+	return p
+}
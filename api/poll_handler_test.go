@@ -0,0 +1,136 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/poll"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupPollRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockPollService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockPollService(ctrl)
+	handler := api.NewPollHandler(mockService)
+	rg := router.Group("/api/v1/polls")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestCreatePoll(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "alice"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreatePoll(gomock.Any(), gomock.Any(), user).Return(poll.Poll{ID: "5", Title: "Partie"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/polls", bytes.NewBufferString(`{"title":"Partie","game":"Necromunda","slots":[{"dateTime":"2099-03-12T18:00:00Z"}]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+	})
+
+	t.Run("no slots", func(t *testing.T) {
+		router, ctrl, _ := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/polls", bytes.NewBufferString(`{"title":"Partie","game":"Necromunda","slots":[]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.JSONEq(t, `{"error":"invalid request body","details":[{"field":"slots","message":"must be at least 1"}]}`, w.Body.String())
+	})
+}
+
+func TestVotePoll(t *testing.T) {
+	user := discord.DiscordUser{ID: "2", Username: "bob"}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Vote(gomock.Any(), "5", []string{"10", "11"}, user).Return(poll.Poll{ID: "5"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/polls/5/votes", bytes.NewBufferString(`{"slots":["10","11"]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Vote(gomock.Any(), "5", []string{"10"}, user).Return(poll.Poll{}, poll.ErrPollClosed).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/polls/5/votes", bytes.NewBufferString(`{"slots":["10"]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+		assert.JSONEq(t, `{"error":"poll is closed"}`, w.Body.String())
+	})
+}
+
+func TestBookPoll(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "alice"}
+
+	t.Run("winning slot", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Book(gomock.Any(), "5", "", user).Return(bk.Booking{ID: "42", UserID: "1", Status: "pending"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/polls/5/book", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"42"`)
+	})
+
+	t.Run("nobody voted", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Book(gomock.Any(), "5", "", user).Return(bk.Booking{}, poll.ErrNoVotes).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/polls/5/book", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+	})
+
+	t.Run("given slot", func(t *testing.T) {
+		router, ctrl, mockService := setupPollRouter(t, user)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().Book(gomock.Any(), "5", "11", user).Return(bk.Booking{ID: "42"}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/polls/5/book?slot=11", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+	})
+}
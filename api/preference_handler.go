@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+)
+
+type PreferenceService interface {
+	GetPreferences(ctx context.Context, user discord.DiscordUser) (preference.Preferences, error)
+	SavePreferences(ctx context.Context, user discord.DiscordUser, prefs preference.Preferences) (preference.Preferences, error)
+}
+
+// PreferenceHandler lets the members set where the booking notifications are
+// sent to, by email or on Telegram, and opt in or out of them.
+type PreferenceHandler struct {
+	service PreferenceService
+}
+
+func NewPreferenceHandler(service PreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{service: service}
+}
+
+func (h *PreferenceHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/me/preferences", h.Get)
+	rg.PUT("/me/preferences", h.Update)
+}
+
+func (h *PreferenceHandler) Get(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	prefs, err := h.service.GetPreferences(c.Request.Context(), user)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve preferences")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, prefs)
+}
+
+func (h *PreferenceHandler) Update(c *gin.Context) {
+	var prefs preference.Preferences
+
+	if !bindJSON(c, &prefs) {
+		return
+	}
+
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	saved, err := h.service.SavePreferences(c.Request.Context(), user, prefs)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, preference.ErrInvalidPreferences) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to save preferences")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, saved)
+}
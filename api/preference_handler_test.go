@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreferences(t *testing.T) {
+	alice := discord.DiscordUser{ID: "1", Username: "alice"}
+	updatedAt := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockPreferenceService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockPreferenceService(ctrl)
+		rg := router.Group("/api/v1/users")
+		rg.Use(setUserInContext(alice))
+		api.NewPreferenceHandler(mockService).Register(rg)
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("get", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetPreferences(gomock.Any(), alice).Return(preference.Preferences{
+			UserID:             "1",
+			Username:           "alice",
+			Email:              "alice@example.com",
+			EmailNotifications: true,
+			UpdatedAt:          updatedAt,
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/me/preferences", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"userId":"1","username":"alice","email":"alice@example.com","emailNotifications":true,"telegramChatId":"","telegramNotifications":false,"updatedAt":"2026-03-06T19:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SavePreferences(gomock.Any(), alice, preference.Preferences{Email: "alice@example.com", EmailNotifications: true}).Return(preference.Preferences{
+			UserID:             "1",
+			Username:           "alice",
+			Email:              "alice@example.com",
+			EmailNotifications: true,
+			UpdatedAt:          updatedAt,
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/users/me/preferences", strings.NewReader(`{"email":"alice@example.com","emailNotifications":true}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SavePreferences(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/users/me/preferences", strings.NewReader(`{"email":"alice","emailNotifications":true}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+	})
+
+	t.Run("opt in without an email", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SavePreferences(gomock.Any(), alice, gomock.Any()).Return(preference.Preferences{}, preference.ErrInvalidPreferences).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/users/me/preferences", strings.NewReader(`{"emailNotifications":true}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetPreferences(gomock.Any(), alice).Return(preference.Preferences{}, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/me/preferences", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+		assert.JSONEq(t, `{"error":"failed to retrieve preferences"}`, w.Body.String())
+	})
+}
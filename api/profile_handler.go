@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/profile"
+)
+
+type ProfileService interface {
+	GetDirectory(ctx context.Context, query, cursor string, limit int) (profile.DirectoryPage, error)
+}
+
+// ProfileHandler serves the directory of the members who used the booking
+// system, with their current names, bookings and suspensions, to the admins.
+type ProfileHandler struct {
+	service ProfileService
+}
+
+func NewProfileHandler(service ProfileService) *ProfileHandler {
+	return &ProfileHandler{service: service}
+}
+
+func (h *ProfileHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("", AdminOnly(), h.List)
+}
+
+// List serves the member directory to the admins, filtered by q and paged by
+// cursor and limit. It has no points balance, none being tracked per user.
+func (h *ProfileHandler) List(c *gin.Context) {
+	limit, err := parseOptionalInt(c.Query("limit"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse limit")})
+		return
+	}
+
+	page, err := h.service.GetDirectory(c.Request.Context(), c.Query("q"), c.Query("cursor"), limit)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, profile.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "invalid cursor")})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve users")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, page)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/profile"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDirectory(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	setup := func(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockProfileService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockProfileService(ctrl)
+		rg := router.Group("/api/v1/users")
+		rg.Use(setUserInContext(user))
+		api.NewProfileHandler(mockService).Register(rg)
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("search", func(t *testing.T) {
+		router, ctrl, mockService := setup(t, admin)
+		defer ctrl.Finish()
+
+		seen := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+		mockService.EXPECT().GetDirectory(gomock.Any(), "ali", "abc", 10).Return(profile.DirectoryPage{
+			Users: []profile.DirectoryEntry{{
+				Profile:        profile.Profile{ID: "1", Username: "alice", GlobalName: "Alice", FirstSeenAt: seen, LastSeenAt: seen},
+				Bookings:       4,
+				Suspended:      true,
+				LastActivityAt: seen,
+			}},
+			NextCursor: "def",
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users?q=ali&cursor=abc&limit=10", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"users":[{"userId":"1","username":"alice","globalName":"Alice","avatar":"","avatarUrl":"","firstSeenAt":"2026-10-16T20:00:00Z","lastSeenAt":"2026-10-16T20:00:00Z","bookings":4,"suspended":true,"lastActivityAt":"2026-10-16T20:00:00Z"}],"nextCursor":"def"}`, w.Body.String())
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		router, ctrl, mockService := setup(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetDirectory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users?limit=many", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		router, ctrl, mockService := setup(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetDirectory(gomock.Any(), "", "bad", 0).Return(profile.DirectoryPage{}, profile.ErrInvalidCursor).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users?cursor=bad", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		router, ctrl, mockService := setup(t, discord.DiscordUser{ID: "2", Username: "bob"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetDirectory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
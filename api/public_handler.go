@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type PublicBookingService interface {
+	GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]bk.Booking, error)
+	CheckIn(ctx context.Context, id, token string) (bk.Booking, error)
+	ConfirmPayment(ctx context.Context, payload []byte, signature string) error
+}
+
+// PublicBooking is the view of a booking served without authentication, it
+// leaves out the Discord user ids and the moderation fields.
+type PublicBooking struct {
+	Game        string    `json:"game"`
+	Organizer   string    `json:"organizer"`
+	Points      int       `json:"points"`
+	Description string    `json:"description,omitempty"`
+	DateTime    time.Time `json:"dateTime"`
+	DisplayDate string    `json:"displayDate"`
+	Players     []string  `json:"players"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+// CheckInResponse is the booking the venue kiosk checked in.
+type CheckInResponse struct {
+	PublicBooking
+	CheckedInAt time.Time `json:"checkedInAt"`
+}
+
+// publicCacheControl lets the wall displays and the proxies in front of the
+// API share the feed for a minute.
+const publicCacheControl = "public, max-age=60"
+
+// PublicHandler serves the upcoming accepted bookings to the venue's wall
+// display, it is registered without authentication.
+type PublicHandler struct {
+	service PublicBookingService
+}
+
+func NewPublicHandler(service PublicBookingService) *PublicHandler {
+	return &PublicHandler{service: service}
+}
+
+func (h *PublicHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/bookings", h.GetBookings)
+	rg.POST("/check-in/:id", h.CheckIn)
+	rg.POST("/payments/stripe", h.PaymentWebhook)
+}
+
+func (h *PublicHandler) GetBookings(c *gin.Context) {
+	// anonymous visitors only get the public bookings
+	bookings, err := h.service.GetVisibleBookings(c.Request.Context(), nil)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve bookings")})
+		return
+	}
+
+	indentedJSONWithETag(c, newPublicBookings(filterBookings(c, bookings), time.Now()), publicCacheControl)
+}
+
+func newPublicBookings(bookings []bk.Booking, now time.Time) []PublicBooking {
+	feed := make([]PublicBooking, 0, len(bookings))
+
+	for _, booking := range bookings {
+		if booking.Status != "accepted" || !booking.DateTime.After(now) {
+			continue
+		}
+
+		feed = append(feed, newPublicBooking(booking))
+	}
+
+	slices.SortFunc(feed, func(a, b PublicBooking) int { return a.DateTime.Compare(b.DateTime) })
+
+	return feed
+}
+
+func newPublicBooking(booking bk.Booking) PublicBooking {
+	players := booking.Players
+
+	if players == nil {
+		players = []string{}
+	}
+
+	return PublicBooking{
+		Game:        booking.Game,
+		Organizer:   booking.Username,
+		Points:      booking.Points,
+		Description: booking.Description,
+		DateTime:    booking.DateTime.In(displayLocation),
+		DisplayDate: formatDisplayDate(booking.DateTime),
+		Players:     players,
+		Tags:        booking.Tags,
+	}
+}
+
+// CheckIn marks the attendance of a booking, the venue kiosk posting the URL
+// of the QR code it scanned, its token included.
+func (h *PublicHandler) CheckIn(c *gin.Context) {
+	booking, err := h.service.CheckIn(c.Request.Context(), c.Param("id"), c.Query("token"))
+
+	if err != nil {
+		checkInError(c, err, "failed to check in booking")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, CheckInResponse{PublicBooking: newPublicBooking(booking), CheckedInAt: booking.CheckedInAt.In(displayLocation)})
+}
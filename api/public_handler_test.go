@@ -0,0 +1,177 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupPublicRouter(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockPublicBookingService) {
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockPublicBookingService(ctrl)
+	api.NewPublicHandler(mockService).Register(router.Group("/api/public"))
+
+	return router, ctrl, mockService
+}
+
+func TestGetPublicBookings(t *testing.T) {
+	setup := setupPublicRouter
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		tomorrow := time.Now().Add(24 * time.Hour).UTC()
+
+		mockService.EXPECT().GetVisibleBookings(gomock.Any(), nil).Return([]bk.Booking{
+			{ID: "1", Game: "Star Wars", UserID: "10", Username: "alice", Status: "accepted", DateTime: tomorrow.Add(time.Hour), Players: []string{"bob"}},
+			{ID: "2", Game: "Chess", UserID: "11", Username: "carol", Status: "pending", DateTime: tomorrow},
+			{ID: "3", Game: "Go", UserID: "12", Username: "dave", Status: "accepted", DateTime: time.Now().Add(-time.Hour)},
+			{ID: "4", Game: "Catan", UserID: "13", Username: "erin", Status: "accepted", DateTime: tomorrow, Description: "Base game"},
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/public/bookings", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.NotContains(t, w.Body.String(), "userId")
+
+		var feed []api.PublicBooking
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+
+		if assert.Len(t, feed, 2) {
+			assert.Equal(t, "Catan", feed[0].Game)
+			assert.Equal(t, "Base game", feed[0].Description)
+			assert.Equal(t, []string{}, feed[0].Players)
+			assert.Equal(t, "Star Wars", feed[1].Game)
+			assert.Equal(t, "alice", feed[1].Organizer)
+			assert.Equal(t, []string{"bob"}, feed[1].Players)
+		}
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetVisibleBookings(gomock.Any(), nil).Return([]bk.Booking{}, nil).Times(2)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/public/bookings", nil)
+		router.ServeHTTP(w, req)
+
+		w2 := httptest.NewRecorder()
+		req2, _ := http.NewRequest("GET", "/api/public/bookings", nil)
+		req2.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		router.ServeHTTP(w2, req2)
+
+		assert.Equal(t, http.StatusNotModified, w2.Code)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetVisibleBookings(gomock.Any(), nil).Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/public/bookings", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
+
+func TestPublicCheckIn(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupPublicRouter(t)
+		defer ctrl.Finish()
+
+		at := time.Date(2026, 3, 14, 19, 50, 0, 0, time.UTC)
+		mockService.EXPECT().CheckIn(gomock.Any(), "1", "abc").Return(bk.Booking{ID: "1", Game: "Kill Team", UserID: "10", Username: "alice", Status: "accepted", DateTime: at.Add(10 * time.Minute), CheckedInAt: &at}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/public/check-in/1?token=abc", nil)
+		router.ServeHTTP(w, req)
+
+		var response map[string]any
+		assert.Equal(t, 200, w.Code)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Kill Team", response["game"])
+		assert.Contains(t, response, "checkedInAt")
+		assert.NotContains(t, response, "userId")
+	})
+
+	t.Run("forged code", func(t *testing.T) {
+		router, ctrl, mockService := setupPublicRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CheckIn(gomock.Any(), "1", "forged").Return(bk.Booking{}, bk.ErrInvalidCheckInToken).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/public/check-in/1?token=forged", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"invalid check-in code"}`, w.Body.String())
+	})
+
+	t.Run("outside the check-in window", func(t *testing.T) {
+		router, ctrl, mockService := setupPublicRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CheckIn(gomock.Any(), "1", "abc").Return(bk.Booking{}, fmt.Errorf("%w: too early", bk.ErrInvalidBookingState)).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/public/check-in/1?token=abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 409, w.Code)
+	})
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupPublicRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ConfirmPayment(gomock.Any(), []byte(`{"type":"checkout.session.completed"}`), "t=1,v1=abc").Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/public/payments/stripe", strings.NewReader(`{"type":"checkout.session.completed"}`))
+		req.Header.Set("Stripe-Signature", "t=1,v1=abc")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{"received":true}`, w.Body.String())
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		router, ctrl, mockService := setupPublicRouter(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().ConfirmPayment(gomock.Any(), gomock.Any(), "").Return(fmt.Errorf("%w: invalid Stripe signature", bk.ErrInvalidPaymentEvent)).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/public/payments/stripe", strings.NewReader(`{}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/ranking"
+)
+
+type RankingService interface {
+	GetRankings(ctx context.Context, game string) ([]ranking.Ranking, error)
+}
+
+// RankingHandler serves the player rankings of each game, computed from the
+// reported results.
+type RankingHandler struct {
+	service RankingService
+}
+
+func NewRankingHandler(service RankingService) *RankingHandler {
+	return &RankingHandler{service: service}
+}
+
+func (h *RankingHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/:game", h.GetByGame)
+}
+
+func (h *RankingHandler) GetByGame(c *gin.Context) {
+	rankings, err := h.service.GetRankings(c.Request.Context(), c.Param("game"))
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve rankings")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, rankings)
+}
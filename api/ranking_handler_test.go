@@ -0,0 +1,59 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ranking"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRankings(t *testing.T) {
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockRankingService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockRankingService(ctrl)
+		rg := router.Group("/api/v1/rankings")
+		rg.Use(setUserInContext(discord.DiscordUser{ID: "1", Username: "user"}))
+		api.NewRankingHandler(mockService).Register(rg)
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetRankings(gomock.Any(), "Star Wars").Return([]ranking.Ranking{
+			{Rank: 1, Player: "alice", Rating: 1016, Games: 1, Wins: 1},
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/rankings/Star%20Wars", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `[{"rank":1,"player":"alice","rating":1016,"games":1,"wins":1,"losses":0,"draws":0}]`, w.Body.String())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetRankings(gomock.Any(), "Chess").Return(nil, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/rankings/Chess", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
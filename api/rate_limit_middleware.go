@@ -0,0 +1,51 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/patrickmn/go-cache"
+)
+
+// RateLimit allows each client IP at most limit requests per window, the
+// window starting with its first request. Requests over the limit get a 429
+// with a Retry-After header. It guards the routes served without
+// authentication; the counters are kept per replica.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	counters := cache.New(window, 2*window)
+
+	return func(c *gin.Context) {
+		key := c.ClientIP()
+
+		if counters.Add(key, 1, window) == nil {
+			c.Next()
+			return
+		}
+
+		count, err := counters.IncrementInt(key, 1)
+
+		// the window expired in between, start a new one
+		if err != nil {
+			counters.Set(key, 1, window)
+			c.Next()
+			return
+		}
+
+		if count <= limit {
+			c.Next()
+			return
+		}
+
+		retryAfter := window
+
+		if _, expiration, ok := counters.GetWithExpiration(key); ok {
+			retryAfter = time.Until(expiration)
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": translate(c, "too many requests")})
+	}
+}
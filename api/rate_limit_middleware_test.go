@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(api.RateLimit(2, time.Minute))
+	router.GET("/feed", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	get := func(remoteAddr string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/feed", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, get("10.0.0.1:1235").Code)
+
+	w := get("10.0.0.1:1236")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "too many requests")
+
+	// other clients have their own window
+	assert.Equal(t, http.StatusOK, get("10.0.0.2:1234").Code)
+}
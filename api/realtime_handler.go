@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/realtime"
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = 30 * time.Second
+)
+
+type RealtimeHandler struct {
+	hub      *realtime.Hub
+	upgrader websocket.Upgrader
+}
+
+func NewRealtimeHandler(hub *realtime.Hub, allowedOrigins []string) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return len(origin) == 0 || slices.Contains(allowedOrigins, origin)
+			},
+		},
+	}
+}
+
+func (h *RealtimeHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/ws", h.Connect)
+}
+
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+
+	lastEventID, err := parseLastEventID(c)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "failed to parse lastEventId")})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	sub, backlog, resumed := h.hub.Subscribe(lastEventID, func(booking bk.Booking) bool {
+		return canSeeBooking(user, booking)
+	})
+	defer h.hub.Unsubscribe(sub)
+
+	if !resumed {
+		if err := writeJSON(conn, gin.H{"type": "resync"}); err != nil {
+			return
+		}
+	}
+
+	for _, msg := range backlog {
+		if err := writeJSON(conn, msg); err != nil {
+			return
+		}
+	}
+
+	closed := make(chan struct{})
+	go readUntilClosed(conn, closed)
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if err := writeJSON(conn, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func parseLastEventID(c *gin.Context) (int64, error) {
+	raw := c.Query("lastEventId")
+
+	if len(raw) == 0 {
+		raw = c.GetHeader("Last-Event-ID")
+	}
+
+	if len(raw) == 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+// readUntilClosed drains incoming frames so pongs and close frames are
+// processed, and signals when the client goes away.
+func readUntilClosed(conn *websocket.Conn, closed chan<- struct{}) {
+	defer close(closed)
+
+	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func writeJSON(conn *websocket.Conn, v any) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(v)
+}
+
+func canSeeBooking(user discord.DiscordUser, booking bk.Booking) bool {
+	return user.Admin || booking.UserID == user.ID || slices.Contains(booking.Players, user.Username)
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type PanicReport struct {
+	RequestID string
+	Method    string
+	Route     string
+	Value     any
+	Stack     []byte
+}
+
+// PanicReporter forwards recovered panics to the people operating the
+// service.
+type PanicReporter interface {
+	ReportPanic(ctx context.Context, report PanicReport)
+}
+
+// Recovery recovers from panics in handlers, logs them with their stack and
+// answers with the standard error envelope carrying the request ID. reporter
+// may be nil.
+func Recovery(reporter PanicReporter) gin.HandlerFunc {
+	logger := slog.Default().With("component", "recovery")
+
+	return func(c *gin.Context) {
+		defer func() {
+			value := recover()
+
+			if value == nil {
+				return
+			}
+
+			if value == http.ErrAbortHandler {
+				// deliberate abort, net/http handles it
+				panic(value)
+			}
+
+			report := PanicReport{
+				RequestID: RequestIDFrom(c),
+				Method:    c.Request.Method,
+				Route:     c.FullPath(),
+				Value:     value,
+				Stack:     debug.Stack(),
+			}
+
+			if isBrokenConnection(value) {
+				// the client is gone, there is no one to answer to
+				logger.Warn("connection lost while writing response", "requestId", report.RequestID, "err", value)
+				c.Abort()
+				return
+			}
+
+			logger.Error("panic recovered",
+				"requestId", report.RequestID,
+				"method", report.Method,
+				"route", report.Route,
+				"panic", fmt.Sprint(value),
+				"stack", string(report.Stack),
+			)
+
+			if reporter != nil {
+				reporter.ReportPanic(c.Request.Context(), report)
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":     translate(c, "internal server error"),
+				"requestId": report.RequestID,
+			})
+		}()
+
+		c.Next()
+	}
+}
+
+func isBrokenConnection(value any) bool {
+	err, ok := value.(error)
+
+	return ok && (errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET))
+}
+
+// DiscordPanicReporter posts panics to an ops Discord channel, at most once
+// per interval so a panicking hot path doesn't flood the channel.
+type DiscordPanicReporter struct {
+	client    discord.DiscordClient
+	channelID string
+	// channel overrides channelID when set
+	channel  func(ctx context.Context) string
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu         sync.Mutex
+	lastReport time.Time
+	suppressed int
+}
+
+type PanicReporterOption func(*DiscordPanicReporter)
+
+// WithPanicChannelLookup posts to the channel lookup returns rather than the
+// one given to NewDiscordPanicReporter, nothing being reported when it is
+// empty.
+func WithPanicChannelLookup(lookup func(ctx context.Context) string) PanicReporterOption {
+	return func(r *DiscordPanicReporter) {
+		r.channel = lookup
+	}
+}
+
+func NewDiscordPanicReporter(client discord.DiscordClient, channelID string, opts ...PanicReporterOption) *DiscordPanicReporter {
+	r := &DiscordPanicReporter{
+		client:    client,
+		channelID: channelID,
+		interval:  time.Minute,
+		logger:    slog.Default().With("component", "recovery"),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *DiscordPanicReporter) ReportPanic(ctx context.Context, report PanicReport) {
+	channelID := r.channelID
+
+	if r.channel != nil {
+		channelID = r.channel(ctx)
+	}
+
+	if len(channelID) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+
+	if time.Since(r.lastReport) < r.interval {
+		r.suppressed++
+		r.mu.Unlock()
+		return
+	}
+
+	suppressed := r.suppressed
+	r.lastReport = time.Now()
+	r.suppressed = 0
+	r.mu.Unlock()
+
+	// embed titles are limited to 256 characters and field values to 1024
+	title := truncate(fmt.Sprintf("Panic: %v", report.Value), 256)
+	stack := truncate(string(report.Stack), 1000)
+
+	fields := []discord.EmbedField{
+		{Name: "Route", Value: fmt.Sprintf("%v %v", report.Method, report.Route), Inline: true},
+		{Name: "Request ID", Value: report.RequestID, Inline: true},
+		{Name: "Stack", Value: "```\n" + stack + "\n```"},
+	}
+
+	if suppressed != 0 {
+		fields = append(fields, discord.EmbedField{Name: "Suppressed", Value: fmt.Sprintf("%d panics since the last report", suppressed)})
+	}
+
+	message := discord.Message{
+		Embeds: []discord.Embed{{
+			Type:   "rich",
+			Title:  title,
+			Fields: fields,
+		}},
+	}
+
+	// the request is about to end, the report must outlive it
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		if err := r.client.SendMessage(ctx, channelID, message); err != nil {
+			r.logger.Error("failed to report panic", "err", err)
+		}
+	}()
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+
+	return s
+}
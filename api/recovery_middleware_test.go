@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	discord_mocks "github.com/hanksha/tbz-booking-system-backend/discord/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingPanicReporter struct {
+	reports []api.PanicReport
+}
+
+func (r *recordingPanicReporter) ReportPanic(ctx context.Context, report api.PanicReport) {
+	r.reports = append(r.reports, report)
+}
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reporter := &recordingPanicReporter{}
+	router := gin.New()
+	router.Use(api.RequestID(), api.Recovery(reporter))
+	router.GET("/bookings/:id", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	t.Run("answers with the request id", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/bookings/42", nil)
+		req.Header.Set(api.RequestIDHeader, "req-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body map[string]string
+		json.Unmarshal(w.Body.Bytes(), &body)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "req-1", w.Header().Get(api.RequestIDHeader))
+		assert.Equal(t, map[string]string{"error": "internal server error", "requestId": "req-1"}, body)
+
+		assert.Len(t, reporter.reports, 1)
+		assert.Equal(t, "/bookings/:id", reporter.reports[0].Route)
+		assert.Equal(t, "boom", reporter.reports[0].Value)
+		assert.NotEmpty(t, reporter.reports[0].Stack)
+	})
+
+	t.Run("generates invalid request ids", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/bookings/42", nil)
+		req.Header.Set(api.RequestIDHeader, "bad id\n")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Len(t, w.Header().Get(api.RequestIDHeader), 32)
+	})
+}
+
+func TestDiscordPanicReporter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := discord_mocks.NewMockDiscordClient(ctrl)
+	reporter := api.NewDiscordPanicReporter(client, "ops")
+
+	sent := make(chan discord.Message, 2)
+	client.EXPECT().SendMessage(gomock.Any(), "ops", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+		sent <- message
+		return nil
+	}).Times(1)
+
+	report := api.PanicReport{RequestID: "req-1", Method: "GET", Route: "/bookings/:id", Value: "boom", Stack: []byte("stack")}
+
+	// the second panic within the interval is suppressed
+	reporter.ReportPanic(context.Background(), report)
+	reporter.ReportPanic(context.Background(), report)
+
+	select {
+	case message := <-sent:
+		assert.Equal(t, "Panic: boom", message.Embeds[0].Title)
+	case <-time.After(time.Second):
+		t.Fatal("panic not reported")
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern bounds the IDs accepted from callers, so they can't
+// inject arbitrary content into logs.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID tags every request with an ID, the caller's one when it sent a
+// valid X-Request-ID header, echoed back in the response so errors reported
+// by users can be matched with the logs.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set("requestId", id)
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+	}
+}
+
+// RequestIDFrom returns the ID set by RequestID, empty without it.
+func RequestIDFrom(c *gin.Context) string {
+	return c.GetString("requestId")
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
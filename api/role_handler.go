@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/permission"
+)
+
+type RoleService interface {
+	GetMappings(ctx context.Context) ([]permission.RoleMapping, error)
+	SetMapping(ctx context.Context, mapping permission.RoleMapping) (permission.RoleMapping, error)
+	DeleteMapping(ctx context.Context, roleID string) error
+}
+
+// RoleHandler lets the admins grant permissions to the members of any
+// Discord role.
+type RoleHandler struct {
+	service RoleService
+}
+
+func NewRoleHandler(service RoleService) *RoleHandler {
+	return &RoleHandler{service: service}
+}
+
+func (h *RoleHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("/roles", adminOnly, h.List)
+	rg.PUT("/roles/:roleId", adminOnly, h.Update)
+	rg.DELETE("/roles/:roleId", adminOnly, h.Delete)
+}
+
+func (h *RoleHandler) List(c *gin.Context) {
+	mappings, err := h.service.GetMappings(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve role mappings")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, mappings)
+}
+
+func (h *RoleHandler) Update(c *gin.Context) {
+	var mapping permission.RoleMapping
+
+	if !bindJSON(c, &mapping) {
+		return
+	}
+
+	mapping.RoleID = c.Param("roleId")
+
+	saved, err := h.service.SetMapping(c.Request.Context(), mapping)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, permission.ErrInvalidMapping) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to save role mapping")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, saved)
+}
+
+func (h *RoleHandler) Delete(c *gin.Context) {
+	err := h.service.DeleteMapping(c.Request.Context(), c.Param("roleId"))
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, permission.ErrMappingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "role mapping not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to delete role mapping")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "role mapping deleted")})
+}
@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/permission"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRoleRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockRoleService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockRoleService(ctrl)
+	rg := router.Group("/api/v1/admin")
+	rg.Use(setUserInContext(user))
+	api.NewRoleHandler(mockService).Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestRoles(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("update", func(t *testing.T) {
+		router, ctrl, mockService := setupRoleRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetMapping(gomock.Any(), permission.RoleMapping{RoleID: "42", Permissions: []string{"trusted"}}).Return(permission.RoleMapping{RoleID: "42", Permissions: []string{"trusted"}}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/roles/42", strings.NewReader(`{"roleId":"7","permissions":["trusted"]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		router, ctrl, mockService := setupRoleRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SetMapping(gomock.Any(), gomock.Any()).Return(permission.RoleMapping{}, fmt.Errorf("%w: unknown permission 'owner'", permission.ErrInvalidMapping)).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/roles/42", strings.NewReader(`{"permissions":["owner"]}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("delete unknown mapping", func(t *testing.T) {
+		router, ctrl, mockService := setupRoleRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().DeleteMapping(gomock.Any(), "42").Return(permission.ErrMappingNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/v1/admin/roles/42", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		router, ctrl, mockService := setupRoleRouter(t, discord.DiscordUser{ID: "2", Username: "bob"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetMappings(gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/admin/roles", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/csv"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+// utf8BOM lets spreadsheets opening the CSV files read the accented game
+// names as UTF-8.
+const utf8BOM = "\uFEFF"
+
+// statsTable lays out stats as the header and rows of a CSV file.
+type statsTable[T any] struct {
+	header []string
+	row    func(T) []string
+}
+
+var (
+	gameStatsTable = statsTable[bk.GameBookingCount]{
+		header: []string{"game", "bookingCount"},
+		row: func(count bk.GameBookingCount) []string {
+			return []string{count.Game, strconv.Itoa(count.Count)}
+		},
+	}
+	tagStatsTable = statsTable[bk.TagBookingCount]{
+		header: []string{"tag", "bookingCount"},
+		row: func(count bk.TagBookingCount) []string {
+			return []string{count.Tag, strconv.Itoa(count.Count)}
+		},
+	}
+	pairStatsTable = statsTable[bk.PairBookingCount]{
+		header: []string{"playerA", "playerB", "bookingCount"},
+		row: func(count bk.PairBookingCount) []string {
+			return []string{count.PlayerA, count.PlayerB, strconv.Itoa(count.Count)}
+		},
+	}
+	weekDayStatsTable = statsTable[bk.WeekDayBookingCount]{
+		header: []string{"dayOfWeek", "bookingCount"},
+		row: func(count bk.WeekDayBookingCount) []string {
+			return []string{count.WeekDay, strconv.Itoa(count.Count)}
+		},
+	}
+	approvalLatencyStatsTable = statsTable[bk.ApprovalLatency]{
+		header: []string{"month", "decisions", "averageSeconds", "medianSeconds", "p95Seconds"},
+		row: func(latency bk.ApprovalLatency) []string {
+			return []string{
+				latency.Month,
+				strconv.Itoa(latency.Decisions),
+				strconv.FormatInt(latency.AverageSeconds, 10),
+				strconv.FormatInt(latency.MedianSeconds, 10),
+				strconv.FormatInt(latency.P95Seconds, 10),
+			}
+		},
+	}
+	noShowStatsTable = statsTable[bk.NoShowCount]{
+		header: []string{"userId", "username", "bookings", "noShows"},
+		row: func(count bk.NoShowCount) []string {
+			return []string{count.UserID, count.Username, strconv.Itoa(count.Bookings), strconv.Itoa(count.NoShows)}
+		},
+	}
+)
+
+// checkStatsFormat rejects a format query parameter other than json and csv.
+func checkStatsFormat(c *gin.Context) bool {
+	switch c.Query("format") {
+	case "", "json", "csv":
+		return true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "format must be one of json, csv")})
+	return false
+}
+
+// renderStats renders the stats as JSON, or as a CSV file named after name
+// with format=csv.
+func renderStats[T any](c *gin.Context, name string, stats []T, table statsTable[T]) {
+	if c.Query("format") != "csv" {
+		indentedJSONWithETag(c, stats, privateNoCache)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name + ".csv"}))
+	c.Header("Cache-Control", privateNoCache)
+	c.Status(http.StatusOK)
+	c.Writer.WriteString(utf8BOM)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write(table.header)
+
+	for _, stat := range stats {
+		writer.Write(table.row(stat))
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeouts bounds the handling of each request, canceling the context given
+// to the database and Discord calls. Routes are looked up in overrides as
+// "METHOD /route/:param", others get defaultTimeout; zero disables the
+// timeout, as needed by WebSockets and profiles.
+//
+// A handler failing because of the deadline gets its 5xx response replaced
+// with a 504, so timeouts can be told apart from errors.
+func Timeouts(defaultTimeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := overrides[c.Request.Method+" "+c.FullPath()]
+
+		if !ok {
+			timeout = defaultTimeout
+		}
+
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		writer := &timeoutWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Writer = writer
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+
+		if writer.timedOut || (!writer.Written() && errors.Is(ctx.Err(), context.DeadlineExceeded)) {
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
+				"error":     translate(c, "request timed out"),
+				"requestId": RequestIDFrom(c),
+			})
+		}
+	}
+}
+
+// timeoutWriter drops the server error responses written once the deadline
+// is exceeded, they are replaced with a 504 by Timeouts.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx      context.Context
+	timedOut bool
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if code >= http.StatusInternalServerError && !w.Written() && errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		w.timedOut = true
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.timedOut {
+		return
+	}
+
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.timedOut {
+		return len(data), nil
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.timedOut {
+		return len(s), nil
+	}
+
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,61 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeouts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(api.Timeouts(10*time.Millisecond, map[string]time.Duration{"GET /slow/:id": 0}))
+
+	// behaves like a handler whose query fails on the canceled context
+	waitForDeadline := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve bookings"})
+		case <-time.After(100 * time.Millisecond):
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		}
+	}
+
+	router.GET("/hung", waitForDeadline)
+	router.GET("/slow/:id", waitForDeadline)
+	router.GET("/fast", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	t.Run("hung handler", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/hung", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), "request timed out")
+		assert.NotContains(t, w.Body.String(), "failed to retrieve bookings")
+	})
+
+	t.Run("fast handler", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/fast", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("route without timeout", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/slow/1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
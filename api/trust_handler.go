@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/trust"
+)
+
+type TrustService interface {
+	GetTrustedUsers(ctx context.Context) ([]trust.TrustedUser, error)
+	TrustUser(ctx context.Context, user trust.TrustedUser) (trust.TrustedUser, error)
+	RevokeTrust(ctx context.Context, userID string) error
+}
+
+type TrustHandler struct {
+	service TrustService
+}
+
+func NewTrustHandler(service TrustService) *TrustHandler {
+	return &TrustHandler{service: service}
+}
+
+func (h *TrustHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("/trusted-users", adminOnly, h.List)
+	rg.POST("/trusted-users", adminOnly, h.Create)
+	rg.DELETE("/trusted-users/:userId", adminOnly, h.Delete)
+}
+
+func (h *TrustHandler) List(c *gin.Context) {
+	users, err := h.service.GetTrustedUsers(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve trusted users")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, users)
+}
+
+func (h *TrustHandler) Create(c *gin.Context) {
+	var user trust.TrustedUser
+
+	if !bindJSON(c, &user) {
+		return
+	}
+
+	saved, err := h.service.TrustUser(c.Request.Context(), user)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, trust.ErrInvalidTrustedUser) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to trust user")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, saved)
+}
+
+func (h *TrustHandler) Delete(c *gin.Context) {
+	userID := c.Param("userId")
+
+	err := h.service.RevokeTrust(c.Request.Context(), userID)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, trust.ErrTrustedUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "trusted user not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to revoke trust")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "trust revoked")})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type UserService interface {
+	SuggestSlots(ctx context.Context, user discord.DiscordUser) (bk.Suggestions, error)
+}
+
+// UserHandler serves what is personal to the requesting user.
+type UserHandler struct {
+	service UserService
+}
+
+// FreeSlotResponse is a suggested slot with its date as the club writes it.
+type FreeSlotResponse struct {
+	bk.FreeSlot
+	DisplayDate string `json:"displayDate"`
+}
+
+type SuggestionsResponse struct {
+	FavoriteGames []bk.GameBookingCount `json:"favoriteGames"`
+	Slots         []FreeSlotResponse    `json:"slots"`
+}
+
+func NewUserHandler(service UserService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+func (h *UserHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/me/suggestions", h.GetSuggestions)
+}
+
+// GetSuggestions proposes the free slots of the coming week for the games
+// the user books the most.
+func (h *UserHandler) GetSuggestions(c *gin.Context) {
+	user := c.MustGet("user").(discord.DiscordUser)
+	suggestions, err := h.service.SuggestSlots(c.Request.Context(), user)
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to suggest slots")})
+		return
+	}
+
+	response := SuggestionsResponse{
+		FavoriteGames: suggestions.FavoriteGames,
+		Slots:         make([]FreeSlotResponse, 0, len(suggestions.Slots)),
+	}
+
+	for _, slot := range suggestions.Slots {
+		slot.DateTime = slot.DateTime.In(displayLocation)
+		response.Slots = append(response.Slots, FreeSlotResponse{FreeSlot: slot, DisplayDate: formatDisplayDate(slot.DateTime)})
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+}
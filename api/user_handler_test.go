@@ -0,0 +1,66 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "user"}
+
+	setup := func(t *testing.T) (*gin.Engine, *gomock.Controller, *mock_api.MockUserService) {
+		ctrl := gomock.NewController(t)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.Default()
+		mockService := mock_api.NewMockUserService(ctrl)
+		rg := router.Group("/api/v1/users")
+		rg.Use(setUserInContext(user))
+		api.NewUserHandler(mockService).Register(rg)
+
+		return router, ctrl, mockService
+	}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SuggestSlots(gomock.Any(), user).Return(bk.Suggestions{
+			FavoriteGames: []bk.GameBookingCount{{Game: "Necromunda", Count: 4}},
+			Slots:         []bk.FreeSlot{{DateTime: time.Date(2026, 3, 12, 19, 0, 0, 0, time.UTC), FreeTables: 2}},
+		}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/me/suggestions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.JSONEq(t, `{
+			"favoriteGames": [{"game": "Necromunda", "bookingCount": 4}],
+			"slots": [{"dateTime": "2026-03-12T20:00:00+01:00", "freeTables": 2, "displayDate": "jeudi 12 mars 2026 à 20:00"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		router, ctrl, mockService := setup(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().SuggestSlots(gomock.Any(), user).Return(bk.Suggestions{}, assert.AnError).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/users/me/suggestions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 500, w.Code)
+	})
+}
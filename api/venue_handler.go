@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/venue"
+)
+
+type VenueService interface {
+	GetVenues(ctx context.Context) ([]venue.Venue, error)
+	GetVenue(ctx context.Context, id string) (venue.Venue, error)
+	CreateVenue(ctx context.Context, venue venue.Venue) (venue.Venue, error)
+	UpdateVenue(ctx context.Context, venue venue.Venue) (venue.Venue, error)
+	DeleteVenue(ctx context.Context, id string) error
+}
+
+// VenueHandler serves the rooms of the club, managed by the admins.
+type VenueHandler struct {
+	service VenueService
+}
+
+func NewVenueHandler(service VenueService) *VenueHandler {
+	return &VenueHandler{service: service}
+}
+
+func (h *VenueHandler) Register(rg *gin.RouterGroup) {
+	adminOnly := AdminOnly()
+	rg.GET("", h.List)
+	rg.GET("/:id", h.Get)
+	rg.POST("", adminOnly, h.Create)
+	rg.PUT("/:id", adminOnly, h.Update)
+	rg.DELETE("/:id", adminOnly, h.Delete)
+}
+
+func (h *VenueHandler) List(c *gin.Context) {
+	venues, err := h.service.GetVenues(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve venues")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, venues)
+}
+
+func (h *VenueHandler) Get(c *gin.Context) {
+	v, err := h.service.GetVenue(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.venueError(c, err, "failed to retrieve venue")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, v)
+}
+
+func (h *VenueHandler) Create(c *gin.Context) {
+	var v venue.Venue
+
+	if !bindJSON(c, &v) {
+		return
+	}
+
+	created, err := h.service.CreateVenue(c.Request.Context(), v)
+
+	if err != nil {
+		h.venueError(c, err, "failed to create venue")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *VenueHandler) Update(c *gin.Context) {
+	var v venue.Venue
+
+	if !bindJSON(c, &v) {
+		return
+	}
+
+	v.ID = c.Param("id")
+
+	updated, err := h.service.UpdateVenue(c.Request.Context(), v)
+
+	if err != nil {
+		h.venueError(c, err, "failed to update venue")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, updated)
+}
+
+func (h *VenueHandler) Delete(c *gin.Context) {
+	err := h.service.DeleteVenue(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		h.venueError(c, err, "failed to delete venue")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "venue deleted")})
+}
+
+func (h *VenueHandler) venueError(c *gin.Context, err error, message string) {
+	c.Error(err)
+
+	switch {
+	case errors.Is(err, venue.ErrVenueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "venue not found")})
+	case errors.Is(err, venue.ErrInvalidVenue):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, venue.ErrDuplicateVenue):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, message)})
+	}
+}
@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/venue"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupVenueRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockVenueService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockVenueService(ctrl)
+	handler := api.NewVenueHandler(mockService)
+	rg := router.Group("/api/v1/venues")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestCreateVenue(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	body := `{"name":"Cave","tables":4,"openingHours":[{"weekday":5,"opens":"18:00","closes":"01:00"}]}`
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupVenueRouter(t, admin)
+		defer ctrl.Finish()
+
+		expected := venue.Venue{Name: "Cave", Tables: 4, OpeningHours: []venue.OpeningHours{{Weekday: 5, Opens: "18:00", Closes: "01:00"}}}
+		created := expected
+		created.ID = "2"
+		mockService.EXPECT().CreateVenue(gomock.Any(), expected).Return(created, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/venues", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"2"`)
+	})
+
+	t.Run("no table", func(t *testing.T) {
+		router, ctrl, mockService := setupVenueRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateVenue(gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/venues", strings.NewReader(`{"name":"Cave","tables":0}`))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 422, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"tables"`)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		router, ctrl, mockService := setupVenueRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateVenue(gomock.Any(), gomock.Any()).Times(0)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/venues", strings.NewReader(body))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+	})
+}
+
+func TestGetVenueNotFound(t *testing.T) {
+	router, ctrl, mockService := setupVenueRouter(t, discord.DiscordUser{ID: "2", Username: "user"})
+	defer ctrl.Finish()
+
+	mockService.EXPECT().GetVenue(gomock.Any(), "9").Return(venue.Venue{}, venue.ErrVenueNotFound).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/venues/9", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 404, w.Code)
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/version"
+)
+
+type VersionHandler struct {
+	info version.Info
+}
+
+func NewVersionHandler(info version.Info) *VersionHandler {
+	return &VersionHandler{info: info}
+}
+
+func (h *VersionHandler) Register(rg *gin.RouterGroup) {
+	rg.GET("/version", h.GetVersion)
+}
+
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, h.info)
+}
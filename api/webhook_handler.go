@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/webhook"
+)
+
+type WebhookService interface {
+	GetWebhooks(ctx context.Context) ([]webhook.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook webhook.Webhook) (webhook.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetDeliveries(ctx context.Context, webhookID string) ([]webhook.Delivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID string) (webhook.Delivery, error)
+}
+
+type WebhookHandler struct {
+	service WebhookService
+}
+
+func NewWebhookHandler(service WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) Register(rg *gin.RouterGroup) {
+	rg.Use(AdminOnly())
+	rg.GET("", h.List)
+	rg.POST("", h.Create)
+	rg.DELETE("/:id", h.Delete)
+	rg.GET("/:id/deliveries", h.ListDeliveries)
+	rg.POST("/:id/deliveries/:deliveryId/retry", h.Redeliver)
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	webhooks, err := h.service.GetWebhooks(c.Request.Context())
+
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve webhooks")})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, webhooks)
+}
+
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var wh webhook.Webhook
+
+	if !bindJSON(c, &wh) {
+		return
+	}
+
+	created, err := h.service.CreateWebhook(c.Request.Context(), wh)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, webhook.ErrInvalidWebhook) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to create webhook")})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.service.DeleteWebhook(c.Request.Context(), id)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "webhook not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to delete webhook")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": translate(c, "webhook deleted")})
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id := c.Param("id")
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), id)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "webhook not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retrieve deliveries")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id := c.Param("id")
+	deliveryID := c.Param("deliveryId")
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), id, deliveryID)
+
+	if err != nil {
+		c.Error(err)
+		if errors.Is(err, webhook.ErrWebhookNotFound) || errors.Is(err, webhook.ErrDeliveryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "delivery not found")})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "failed to retry delivery")})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hanksha/tbz-booking-system-backend/api"
+	mock_api "github.com/hanksha/tbz-booking-system-backend/api/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/webhook"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupWebhookRouter(t *testing.T, user discord.DiscordUser) (*gin.Engine, *gomock.Controller, *mock_api.MockWebhookService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mockService := mock_api.NewMockWebhookService(ctrl)
+	handler := api.NewWebhookHandler(mockService)
+	rg := router.Group("/api/v1/webhooks")
+	rg.Use(setUserInContext(user))
+	handler.Register(rg)
+
+	return router, ctrl, mockService
+}
+
+func TestCreateWebhook(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+	nonAdmin := discord.DiscordUser{ID: "2", Username: "user", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		router, ctrl, mockService := setupWebhookRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateWebhook(gomock.Any(), webhook.Webhook{URL: "https://example.com"}).
+			Return(webhook.Webhook{ID: "1", URL: "https://example.com", Secret: "abc", Active: true}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/webhooks", bytes.NewBufferString(`{"url":"https://example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 201, w.Code)
+		assert.JSONEq(t, `{"id":"1","url":"https://example.com","secret":"abc","events":null,"active":true,"createdAt":"0001-01-01T00:00:00Z"}`, w.Body.String())
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		router, ctrl, mockService := setupWebhookRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(webhook.Webhook{}, webhook.ErrInvalidWebhook).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/webhooks", bytes.NewBufferString(`{"url":"nope"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 400, w.Code)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		router, ctrl, _ := setupWebhookRouter(t, nonAdmin)
+		defer ctrl.Finish()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/webhooks", bytes.NewBufferString(`{"url":"https://example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"not allowed"}`, w.Body.String())
+	})
+}
+
+func TestListDeliveries(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "admin", Admin: true}
+
+	t.Run("not found", func(t *testing.T) {
+		router, ctrl, mockService := setupWebhookRouter(t, admin)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().GetDeliveries(gomock.Any(), "9").Return(nil, webhook.ErrWebhookNotFound).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/webhooks/9/deliveries", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, 404, w.Code)
+		assert.JSONEq(t, `{"error":"webhook not found"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Entry struct {
+	ID            string          `json:"id"`
+	ActorID       string          `json:"actorId"`
+	ActorUsername string          `json:"actorUsername"`
+	Action        string          `json:"action"`
+	TargetType    string          `json:"targetType"`
+	TargetID      string          `json:"targetId"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+type Filter struct {
+	Actor      string // matches the actor ID or username
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+type Page struct {
+	Entries []Entry `json:"entries"`
+	Total   int     `json:"total"`
+	Limit   int     `json:"limit"`
+	Offset  int     `json:"offset"`
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Conn is the subset of pgxpool.Pool and pgx.Tx used by the repository, so
+// an entry can be written in the transaction of the action it records.
+type Conn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct{ conn Conn }
+
+func NewRepository(conn Conn) *Repository {
+	return &Repository{conn: conn}
+}
+
+func (r *Repository) InsertEntry(ctx context.Context, entry Entry) error {
+	sql := `
+		INSERT INTO "game-table-booking".admin_audit(
+		"actorId", "actorUsername", action, "targetType", "targetId", payload)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	_, err := r.conn.Exec(ctx, sql,
+		entry.ActorID,
+		entry.ActorUsername,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		entry.Payload,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetEntries(ctx context.Context, filter Filter) ([]Entry, int, error) {
+	conditions := []string{}
+	args := []any{}
+
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filter.Actor) != 0 {
+		addCondition(`("actorId" = $%[1]d OR "actorUsername" = $%[1]d)`, filter.Actor)
+	}
+	if len(filter.Action) != 0 {
+		addCondition(`action = $%d`, filter.Action)
+	}
+	if len(filter.TargetType) != 0 {
+		addCondition(`"targetType" = $%d`, filter.TargetType)
+	}
+	if len(filter.TargetID) != 0 {
+		addCondition(`"targetId" = $%d`, filter.TargetID)
+	}
+	if !filter.From.IsZero() {
+		addCondition(`"createdAt" >= $%d`, filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition(`"createdAt" < $%d`, filter.To)
+	}
+
+	where := ""
+	if len(conditions) != 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := r.conn.QueryRow(ctx, `SELECT COUNT(*) FROM "game-table-booking".admin_audit `+where, args...).Scan(&total)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	sql := fmt.Sprintf(`
+		SELECT id, COALESCE("actorId", ''), COALESCE("actorUsername", ''), action, COALESCE("targetType", ''), COALESCE("targetId", ''), COALESCE(payload, 'null'::jsonb), "createdAt"
+		FROM "game-table-booking".admin_audit
+		%s
+		ORDER BY "createdAt" DESC, id DESC
+		LIMIT $%d OFFSET $%d;
+	`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.conn.Query(ctx, sql, append(args, filter.Limit, filter.Offset)...)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch audit entries: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := []Entry{}
+
+	for rows.Next() {
+		var entry Entry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.ActorUsername,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&entry.Payload,
+			&entry.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning audit row: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating audit rows: %w", err)
+	}
+
+	return entries, total, nil
+}
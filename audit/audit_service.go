@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// SystemActor is recorded as the actor of actions not triggered by a user,
+// such as scheduled jobs.
+const SystemActor = "system"
+
+type AuditRepository interface {
+	InsertEntry(ctx context.Context, entry Entry) error
+	GetEntries(ctx context.Context, filter Filter) ([]Entry, int, error)
+}
+
+type Service struct {
+	repo   AuditRepository
+	logger *slog.Logger
+}
+
+func NewService(repo AuditRepository) *Service {
+	return &Service{repo: repo, logger: slog.Default().With("component", "audit")}
+}
+
+// Record stores an audit entry for an admin action, attributed to the user
+// of ctx. Failures are logged rather than returned so auditing never blocks
+// the action itself.
+func (s *Service) Record(ctx context.Context, action, targetType, targetID string, payload any) {
+	entry, err := NewEntry(ctx, action, targetType, targetID, payload)
+
+	if err != nil {
+		s.logger.Error("failed to marshal audit payload", "action", action, "err", err)
+	}
+
+	if err := s.repo.InsertEntry(ctx, entry); err != nil {
+		s.logger.Error("failed to record audit entry", "action", action, "targetId", targetID, "err", err)
+	}
+}
+
+// NewEntry builds the audit entry of an action attributed to the user of
+// ctx, for the services writing it in the transaction of the action. The
+// entry is returned without payload when it cannot be marshaled.
+func NewEntry(ctx context.Context, action, targetType, targetID string, payload any) (Entry, error) {
+	entry := Entry{
+		ActorID:       SystemActor,
+		ActorUsername: SystemActor,
+		Action:        action,
+		TargetType:    targetType,
+		TargetID:      targetID,
+	}
+
+	if user, ok := discord.UserFromContext(ctx); ok {
+		entry.ActorID = user.ID
+		entry.ActorUsername = user.Username
+	}
+
+	if payload == nil {
+		return entry, nil
+	}
+
+	raw, err := json.Marshal(payload)
+
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Payload = raw
+
+	return entry, nil
+}
+
+func (s *Service) GetEntries(ctx context.Context, filter Filter) (Page, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	entries, total, err := s.repo.GetEntries(ctx, filter)
+
+	if err != nil {
+		return Page{}, err
+	}
+
+	return Page{Entries: entries, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
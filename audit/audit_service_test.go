@@ -0,0 +1,69 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/audit"
+	audit_mocks "github.com/hanksha/tbz-booking-system-backend/audit/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecord(t *testing.T) {
+	t.Run("actor from context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := audit_mocks.NewMockAuditRepository(ctrl)
+		svc := audit.NewService(repo)
+		ctx := discord.ContextWithUser(context.Background(), discord.DiscordUser{ID: "1", Username: "admin"})
+
+		repo.EXPECT().InsertEntry(ctx, audit.Entry{
+			ActorID:       "1",
+			ActorUsername: "admin",
+			Action:        "booking.refuse",
+			TargetType:    "booking",
+			TargetID:      "42",
+			Payload:       []byte(`{"reason":"closed"}`),
+		}).Return(nil).Times(1)
+
+		svc.Record(ctx, "booking.refuse", "booking", "42", map[string]string{"reason": "closed"})
+	})
+
+	t.Run("system actor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := audit_mocks.NewMockAuditRepository(ctrl)
+		svc := audit.NewService(repo)
+
+		repo.EXPECT().InsertEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry audit.Entry) error {
+			require.Equal(t, audit.SystemActor, entry.ActorID)
+			require.Nil(t, entry.Payload)
+			return nil
+		}).Times(1)
+
+		svc.Record(context.Background(), "booking.accept", "booking", "42", nil)
+	})
+}
+
+func TestGetEntries(t *testing.T) {
+	t.Run("clamps pagination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := audit_mocks.NewMockAuditRepository(ctrl)
+		svc := audit.NewService(repo)
+
+		repo.EXPECT().GetEntries(gomock.Any(), audit.Filter{Action: "booking.accept", Limit: 200}).Return([]audit.Entry{{ID: "1"}}, 1, nil).Times(1)
+
+		page, err := svc.GetEntries(context.Background(), audit.Filter{Action: "booking.accept", Limit: 1000, Offset: -3})
+
+		require.Nil(t, err)
+		require.Equal(t, 1, page.Total)
+		require.Equal(t, 200, page.Limit)
+		require.Equal(t, 0, page.Offset)
+	})
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/audit (interfaces: AuditRepository)
+//
+// Generated by this command:
+//
+//	mockgen . AuditRepository
+//
+
+// Package mock_audit is a generated GoMock package.
+package mock_audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	audit "github.com/hanksha/tbz-booking-system-backend/audit"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockAuditRepository) GetEntries(ctx context.Context, filter audit.Filter) ([]audit.Entry, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, filter)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetEntries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetEntries), ctx, filter)
+}
+
+// InsertEntry mocks base method.
+func (m *MockAuditRepository) InsertEntry(ctx context.Context, entry audit.Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEntry indicates an expected call of InsertEntry.
+func (mr *MockAuditRepositoryMockRecorder) InsertEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEntry", reflect.TypeOf((*MockAuditRepository)(nil).InsertEntry), ctx, entry)
+}
@@ -0,0 +1,16 @@
+package ban
+
+import "time"
+
+type Ban struct {
+	UserID    string     `json:"userId" binding:"required"`
+	Username  string     `json:"username"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"` // nil means permanent
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (b Ban) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(now)
+}
@@ -0,0 +1,7 @@
+package ban
+
+import "errors"
+
+var ErrBanNotFound = errors.New("ban not found")
+
+var ErrInvalidBan = errors.New("invalid ban")
@@ -0,0 +1,131 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+func (r *Repository) GetActiveBans(ctx context.Context) ([]Ban, error) {
+	sql := `
+		SELECT "userId", COALESCE(username, ''), COALESCE(reason, ''), "expiresAt", COALESCE("createdBy", ''), "createdAt"
+		FROM "game-table-booking".user_ban
+		WHERE "expiresAt" IS NULL OR "expiresAt" > now()
+		ORDER BY "createdAt" DESC;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bans: %w", err)
+	}
+
+	defer rows.Close()
+
+	bans := []Ban{}
+
+	for rows.Next() {
+		var ban Ban
+		err := rows.Scan(
+			&ban.UserID,
+			&ban.Username,
+			&ban.Reason,
+			&ban.ExpiresAt,
+			&ban.CreatedBy,
+			&ban.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning ban row: %w", err)
+		}
+
+		bans = append(bans, ban)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ban rows: %w", err)
+	}
+
+	return bans, nil
+}
+
+func (r *Repository) GetBan(ctx context.Context, userID string) (Ban, error) {
+	sql := `
+		SELECT "userId", COALESCE(username, ''), COALESCE(reason, ''), "expiresAt", COALESCE("createdBy", ''), "createdAt"
+		FROM "game-table-booking".user_ban
+		WHERE "userId"=$1;
+	`
+
+	var ban Ban
+	err := r.conn.QueryRow(ctx, sql, userID).Scan(
+		&ban.UserID,
+		&ban.Username,
+		&ban.Reason,
+		&ban.ExpiresAt,
+		&ban.CreatedBy,
+		&ban.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Ban{}, ErrBanNotFound
+	}
+
+	if err != nil {
+		return Ban{}, fmt.Errorf("failed to fetch ban of user %v: %w", userID, err)
+	}
+
+	return ban, nil
+}
+
+func (r *Repository) UpsertBan(ctx context.Context, ban Ban) (Ban, error) {
+	sql := `
+		INSERT INTO "game-table-booking".user_ban("userId", username, reason, "expiresAt", "createdBy")
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT ("userId") DO UPDATE SET
+			username=EXCLUDED.username,
+			reason=EXCLUDED.reason,
+			"expiresAt"=EXCLUDED."expiresAt",
+			"createdBy"=EXCLUDED."createdBy",
+			"createdAt"=now()
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		ban.UserID,
+		ban.Username,
+		ban.Reason,
+		ban.ExpiresAt,
+		ban.CreatedBy,
+	).Scan(&ban.CreatedAt)
+
+	if err != nil {
+		return Ban{}, fmt.Errorf("failed to save ban: %w", err)
+	}
+
+	return ban, nil
+}
+
+func (r *Repository) DeleteBan(ctx context.Context, userID string) error {
+	sql := `DELETE FROM "game-table-booking".user_ban WHERE "userId"=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete ban of user '%v': %w", userID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBanNotFound
+	}
+
+	return nil
+}
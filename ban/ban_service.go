@@ -0,0 +1,114 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type BanRepository interface {
+	GetActiveBans(ctx context.Context) ([]Ban, error)
+	GetBan(ctx context.Context, userID string) (Ban, error)
+	UpsertBan(ctx context.Context, ban Ban) (Ban, error)
+	DeleteBan(ctx context.Context, userID string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  BanRepository
+	audit AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo BanRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetActiveBans(ctx context.Context) ([]Ban, error) {
+	return s.repo.GetActiveBans(ctx)
+}
+
+func (s *Service) BanUser(ctx context.Context, ban Ban) (Ban, error) {
+	ban.UserID = strings.TrimSpace(ban.UserID)
+
+	if len(ban.UserID) == 0 {
+		return Ban{}, fmt.Errorf("%w: userId cannot be empty", ErrInvalidBan)
+	}
+
+	if ban.ExpiresAt != nil && !ban.ExpiresAt.After(time.Now()) {
+		return Ban{}, fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidBan)
+	}
+
+	if user, ok := discord.UserFromContext(ctx); ok {
+		ban.CreatedBy = user.Username
+	}
+
+	saved, err := s.repo.UpsertBan(ctx, ban)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "ban.create", "user", saved.UserID, map[string]any{"reason": saved.Reason, "expiresAt": saved.ExpiresAt})
+	}
+
+	return saved, err
+}
+
+func (s *Service) LiftBan(ctx context.Context, userID string) error {
+	err := s.repo.DeleteBan(ctx, userID)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "ban.delete", "user", userID, nil)
+	}
+
+	return err
+}
+
+// CheckSuspended returns the active ban of userID, or ErrBanNotFound when the
+// user is allowed to book.
+func (s *Service) CheckSuspended(ctx context.Context, userID string) (Ban, error) {
+	ban, err := s.repo.GetBan(ctx, userID)
+
+	if err != nil {
+		return Ban{}, err
+	}
+
+	if !ban.Active(time.Now()) {
+		return Ban{}, ErrBanNotFound
+	}
+
+	return ban, nil
+}
+
+// IsSuspended implements booking.SuspensionChecker.
+func (s *Service) IsSuspended(ctx context.Context, userID string) (bool, string, error) {
+	ban, err := s.CheckSuspended(ctx, userID)
+
+	if errors.Is(err, ErrBanNotFound) {
+		return false, "", nil
+	}
+
+	if err != nil {
+		return false, "", err
+	}
+
+	return true, ban.Reason, nil
+}
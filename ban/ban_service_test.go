@@ -0,0 +1,66 @@
+package ban_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/ban"
+	ban_mocks "github.com/hanksha/tbz-booking-system-backend/ban/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIsSuspended(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		ban       ban.Ban
+		err       error
+		suspended bool
+	}{
+		{name: "permanent", ban: ban.Ban{UserID: "1", Reason: "no-show"}, suspended: true},
+		{name: "not expired", ban: ban.Ban{UserID: "1", Reason: "no-show", ExpiresAt: &future}, suspended: true},
+		{name: "expired", ban: ban.Ban{UserID: "1", ExpiresAt: &past}, suspended: false},
+		{name: "not banned", err: ban.ErrBanNotFound, suspended: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ban_mocks.NewMockBanRepository(ctrl)
+			svc := ban.NewService(repo)
+
+			repo.EXPECT().GetBan(gomock.Any(), "1").Return(tt.ban, tt.err).Times(1)
+
+			suspended, reason, err := svc.IsSuspended(context.Background(), "1")
+
+			require.Nil(t, err)
+			require.Equal(t, tt.suspended, suspended)
+			if tt.suspended {
+				require.Equal(t, tt.ban.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestBanUser(t *testing.T) {
+	t.Run("expiry in the past", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ban_mocks.NewMockBanRepository(ctrl)
+		svc := ban.NewService(repo)
+		past := time.Now().Add(-time.Hour)
+
+		repo.EXPECT().UpsertBan(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.BanUser(context.Background(), ban.Ban{UserID: "1", ExpiresAt: &past})
+
+		require.ErrorIs(t, err, ban.ErrInvalidBan)
+	})
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/ban (interfaces: BanRepository)
+//
+// Generated by this command:
+//
+//	mockgen . BanRepository
+//
+
+// Package mock_ban is a generated GoMock package.
+package mock_ban
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ban "github.com/hanksha/tbz-booking-system-backend/ban"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBanRepository is a mock of BanRepository interface.
+type MockBanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBanRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBanRepositoryMockRecorder is the mock recorder for MockBanRepository.
+type MockBanRepositoryMockRecorder struct {
+	mock *MockBanRepository
+}
+
+// NewMockBanRepository creates a new mock instance.
+func NewMockBanRepository(ctrl *gomock.Controller) *MockBanRepository {
+	mock := &MockBanRepository{ctrl: ctrl}
+	mock.recorder = &MockBanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBanRepository) EXPECT() *MockBanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBan mocks base method.
+func (m *MockBanRepository) DeleteBan(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBan", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBan indicates an expected call of DeleteBan.
+func (mr *MockBanRepositoryMockRecorder) DeleteBan(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBan", reflect.TypeOf((*MockBanRepository)(nil).DeleteBan), ctx, userID)
+}
+
+// GetActiveBans mocks base method.
+func (m *MockBanRepository) GetActiveBans(ctx context.Context) ([]ban.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBans", ctx)
+	ret0, _ := ret[0].([]ban.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBans indicates an expected call of GetActiveBans.
+func (mr *MockBanRepositoryMockRecorder) GetActiveBans(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBans", reflect.TypeOf((*MockBanRepository)(nil).GetActiveBans), ctx)
+}
+
+// GetBan mocks base method.
+func (m *MockBanRepository) GetBan(ctx context.Context, userID string) (ban.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBan", ctx, userID)
+	ret0, _ := ret[0].(ban.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBan indicates an expected call of GetBan.
+func (mr *MockBanRepositoryMockRecorder) GetBan(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBan", reflect.TypeOf((*MockBanRepository)(nil).GetBan), ctx, userID)
+}
+
+// UpsertBan mocks base method.
+func (m *MockBanRepository) UpsertBan(ctx context.Context, arg1 ban.Ban) (ban.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBan", ctx, arg1)
+	ret0, _ := ret[0].(ban.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertBan indicates an expected call of UpsertBan.
+func (mr *MockBanRepositoryMockRecorder) UpsertBan(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBan", reflect.TypeOf((*MockBanRepository)(nil).UpsertBan), ctx, arg1)
+}
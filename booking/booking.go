@@ -1,10 +1,25 @@
 package booking
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Visibility of a booking: public ones are listed everywhere, the public
+// feed included, members ones only to authenticated members, private ones
+// only to their organizer, co-organizers, players and the admins.
+const (
+	VisibilityPublic  = "public"
+	VisibilityMembers = "members"
+	VisibilityPrivate = "private"
+)
 
 type Booking struct {
 	ID              string    `json:"id"`
-	Game            string    `json:"game"`
+	Game            string    `json:"game" binding:"required"`
 	UserID          string    `json:"userId"`
 	Username        string    `json:"username"`
 	Points          int       `json:"points"`
@@ -13,4 +28,92 @@ type Booking struct {
 	ReminderEnabled bool      `json:"reminderEnabled"`
 	DateTime        time.Time `json:"dateTime"`
 	Players         []string  `json:"players"`
-}
\ No newline at end of file
+	// CoOrganizers are the usernames of the members managing the booking
+	// with its owner, who may modify and cancel it as well.
+	CoOrganizers []string `json:"coOrganizers,omitempty" binding:"omitempty,max=3,dive,max=64"`
+	// LookingForPlayers announces the free seats of the booking, members
+	// may then join it.
+	LookingForPlayers bool   `json:"lookingForPlayers"`
+	Visibility        string `json:"visibility" binding:"omitempty,oneof=public members private"`
+	// Equipment is the gear of the club reserved for the game, left
+	// untouched by a modification without it.
+	Equipment []Reservation `json:"equipment" binding:"omitempty,dive"`
+	// VenueID is the room of the club the table is in, left unchanged by a
+	// modification without it.
+	VenueID string `json:"venueId,omitempty"`
+	// Tags describe the game, such as beginner-friendly or tournament-prep,
+	// lowercase with dashes for spaces.
+	Tags []string `json:"tags,omitempty" binding:"omitempty,max=10,dive,max=30"`
+	// CustomFields are the values of the fields the game catalog defines for
+	// the game, such as the mission or the map size.
+	CustomFields map[string]any `json:"customFields,omitempty" binding:"omitempty,max=20"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+	DeletedAt    *time.Time     `json:"deletedAt,omitempty"`
+	// CheckedInAt is when the booking's QR code was scanned at the venue,
+	// nil for a booking nobody attended yet.
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+	// DecidedAt is when the booking was first accepted or refused after its
+	// review, nil for the bookings of trusted users accepted on creation.
+	DecidedAt *time.Time `json:"decidedAt,omitempty"`
+	// Priority is normal, tournament or league, set by the admins. It orders
+	// the pending bookings and decides which one gets the last table of a
+	// slot.
+	Priority string `json:"priority,omitempty" binding:"omitempty,oneof=normal tournament league"`
+	// PaymentStatus is unpaid, paid or waived for the bookings charged a
+	// table fee, empty for the others.
+	PaymentStatus string `json:"paymentStatus,omitempty"`
+	// NotificationError is why the last Discord notification of the booking
+	// could not be sent, empty once sent.
+	NotificationError string `json:"notificationError,omitempty"`
+	// CalendarEventID is the Google Calendar event mirroring the booking
+	// while accepted, CalendarSyncStatus how its last sync went and
+	// CalendarSyncError why it failed.
+	CalendarEventID    string `json:"calendarEventId,omitempty"`
+	CalendarSyncStatus string `json:"calendarSyncStatus,omitempty"`
+	CalendarSyncError  string `json:"calendarSyncError,omitempty"`
+	// VoiceChannelID is the Discord voice channel of a remote booking while
+	// it is accepted and not over.
+	VoiceChannelID string `json:"voiceChannelId,omitempty"`
+	// Reminders are when and where the reminders of the booking are sent,
+	// nil for a booking reminded on its day by direct message.
+	Reminders *Reminders `json:"reminders,omitempty"`
+}
+
+// SearchResult is a booking matching a full-text search, with its relevance
+// and an excerpt where the matched terms are wrapped in <mark> tags.
+type SearchResult struct {
+	Booking
+	Rank      float32 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+// HasTags reports whether the booking is tagged with every tag given.
+func (b Booking) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(b.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// VisibleTo reports whether the booking is listed to user, nil being an
+// anonymous visitor. The repository queries apply the same rule.
+func (b Booking) VisibleTo(user *discord.DiscordUser) bool {
+	switch b.Visibility {
+	case VisibilityMembers:
+		return user != nil
+	case VisibilityPrivate:
+		if user == nil {
+			return false
+		}
+
+		username := strings.ToLower(user.Username)
+
+		return user.Admin || b.UserID == user.ID || slices.Contains(b.Players, username) || slices.Contains(b.CoOrganizers, username)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,253 @@
+package booking
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Attachment is a file shared with the players of a booking, such as an army
+// list or a scenario. Its content is kept in the AttachmentStorage under
+// StorageKey.
+type Attachment struct {
+	ID          string    `json:"id"`
+	BookingID   string    `json:"bookingId"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AttachmentStorage keeps the content of the attachments, on disk or in an
+// S3-compatible bucket.
+type AttachmentStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const defaultMaxAttachmentSize = 10 << 20
+
+const maxFilenameLength = 200
+
+// attachmentTypes are the media types accepted, as sniffed from the content
+// rather than trusted from the client.
+var attachmentTypes = []string{"application/pdf", "image/png", "image/jpeg", "image/gif", "image/webp", "text/plain"}
+
+// WithAttachmentStorage accepts attachments on the bookings, stored in
+// storage and of at most maxSize bytes.
+func WithAttachmentStorage(storage AttachmentStorage, maxSize int64) ServiceOption {
+	return func(s *Service) {
+		s.attachments = storage
+		s.maxAttachmentSize = maxSize
+	}
+}
+
+// AddAttachment stores a file read from content on the booking. The
+// organizer, the players and the admins may attach files.
+func (s *Service) AddAttachment(ctx context.Context, bookingID, filename string, content io.Reader, user discord.DiscordUser) (Attachment, error) {
+	if s.attachments == nil {
+		return Attachment{}, fmt.Errorf("%w: attachments are disabled", ErrInvalidAttachment)
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, bookingID)
+
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	if !booking.VisibleTo(&user) {
+		return Attachment{}, ErrBookingNotFound
+	}
+
+	if !participates(booking, user) {
+		return Attachment{}, ErrNotAllowed
+	}
+
+	maxSize := s.maxAttachmentSize
+
+	if maxSize <= 0 {
+		maxSize = defaultMaxAttachmentSize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	if int64(len(data)) > maxSize {
+		return Attachment{}, fmt.Errorf("%w: at most %d bytes", ErrAttachmentTooLarge, maxSize)
+	}
+
+	if len(data) == 0 {
+		return Attachment{}, fmt.Errorf("%w: the file is empty", ErrInvalidAttachment)
+	}
+
+	contentType := http.DetectContentType(data)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if !slices.Contains(attachmentTypes, mediaType) {
+		return Attachment{}, fmt.Errorf("%w: %v files are not accepted", ErrInvalidAttachment, mediaType)
+	}
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return Attachment{}, fmt.Errorf("failed to generate attachment key: %w", err)
+	}
+
+	attachment := Attachment{
+		BookingID:   booking.ID,
+		Filename:    sanitizeFilename(filename),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  "bookings/" + booking.ID + "/" + hex.EncodeToString(key),
+		UploadedBy:  user.Username,
+	}
+
+	if err := s.attachments.Put(ctx, attachment.StorageKey, data, contentType); err != nil {
+		return Attachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	inserted, err := s.repo.InsertAttachment(ctx, attachment)
+
+	if err != nil {
+		// not to leave an unreachable file behind
+		if err := s.attachments.Delete(ctx, attachment.StorageKey); err != nil {
+			slog.Warn("failed to delete orphan attachment", "key", attachment.StorageKey, "err", err)
+		}
+
+		return Attachment{}, err
+	}
+
+	s.record(ctx, "booking.attachment.add", booking.ID, map[string]any{"attachmentId": inserted.ID, "filename": inserted.Filename})
+
+	return inserted, nil
+}
+
+// GetAttachments lists the attachments of a booking visible to user.
+func (s *Service) GetAttachments(ctx context.Context, bookingID string, user *discord.DiscordUser) ([]Attachment, error) {
+	if _, err := s.visibleBooking(ctx, bookingID, user); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetAttachments(ctx, bookingID)
+}
+
+// OpenAttachment returns an attachment of a booking visible to user with its
+// content, which the caller must close.
+func (s *Service) OpenAttachment(ctx context.Context, bookingID, id string, user *discord.DiscordUser) (Attachment, io.ReadCloser, error) {
+	if s.attachments == nil {
+		return Attachment{}, nil, ErrAttachmentNotFound
+	}
+
+	if _, err := s.visibleBooking(ctx, bookingID, user); err != nil {
+		return Attachment{}, nil, err
+	}
+
+	attachment, err := s.repo.GetAttachment(ctx, bookingID, id)
+
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+
+	content, err := s.attachments.Get(ctx, attachment.StorageKey)
+
+	if err != nil {
+		return Attachment{}, nil, fmt.Errorf("failed to read attachment '%v': %w", id, err)
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment, which its uploader, the organizer
+// and the admins may do.
+func (s *Service) DeleteAttachment(ctx context.Context, bookingID, id string, user discord.DiscordUser) error {
+	booking, err := s.visibleBooking(ctx, bookingID, &user)
+
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.repo.GetAttachment(ctx, bookingID, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !user.Admin && booking.UserID != user.ID && attachment.UploadedBy != user.Username {
+		return ErrNotAllowed
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, bookingID, id); err != nil {
+		return err
+	}
+
+	if s.attachments != nil {
+		if err := s.attachments.Delete(ctx, attachment.StorageKey); err != nil {
+			slog.Warn("failed to delete attachment content", "key", attachment.StorageKey, "err", err)
+		}
+	}
+
+	s.record(ctx, "booking.attachment.delete", booking.ID, map[string]any{"attachmentId": id, "filename": attachment.Filename})
+
+	return nil
+}
+
+func (s *Service) visibleBooking(ctx context.Context, id string, user *discord.DiscordUser) (Booking, error) {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	if !booking.VisibleTo(user) {
+		return Booking{}, ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+// participates reports whether the user organizes or plays the booking, or
+// is an admin.
+func participates(booking Booking, user discord.DiscordUser) bool {
+	return user.Admin || booking.UserID == user.ID || slices.Contains(booking.Players, strings.ToLower(user.Username))
+}
+
+// sanitizeFilename keeps the base name of the uploaded file, without control
+// characters, to be sent back in a Content-Disposition header.
+func sanitizeFilename(filename string) string {
+	filename = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, filepath.Base(strings.ReplaceAll(filename, "\\", "/")))
+
+	filename = strings.TrimSpace(filename)
+
+	for len(filename) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(filename)
+		filename = filename[:len(filename)-size]
+	}
+
+	if filename == "" || filename == "." || filename == "/" {
+		return "attachment"
+	}
+
+	return filename
+}
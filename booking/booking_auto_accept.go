@@ -0,0 +1,114 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// trustedReason is why the bookings of trusted users are accepted without
+// review.
+const trustedReason = "membre de confiance"
+
+// The rules of the policy, recorded in the audit trail along with the
+// displayed reason so that the entries can be told apart whatever the
+// wording.
+const (
+	ruleTrusted = "trusted"
+	ruleOverdue = "overdue"
+)
+
+// AutoAcceptConfig is the policy accepting the pending bookings the admins
+// did not answer in time, and those of trusted users.
+type AutoAcceptConfig struct {
+	// After is how long a booking may stay pending, bookings are not
+	// accepted for waiting when zero.
+	After time.Duration
+	// UserIDs are the Discord users whose bookings are accepted on the next
+	// run.
+	UserIDs []string
+}
+
+func WithAutoAccept(config AutoAcceptConfig) ServiceOption {
+	return func(s *Service) {
+		s.autoAccept = &config
+	}
+}
+
+// AutoAcceptPendingBookings accepts the upcoming bookings the policy lets
+// through, the highest priority first, the reason noted in the audit trail
+// and the Discord notification. A booking answered meanwhile is left alone.
+func (s *Service) AutoAcceptPendingBookings(ctx context.Context) error {
+	if s.autoAccept == nil {
+		return fmt.Errorf("auto-acceptance is not configured")
+	}
+
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	now := time.Now()
+	failed := 0
+
+	// the bookings of the highest priority get the tables left first
+	slices.SortStableFunc(bookings, comparePriority)
+
+	for _, booking := range bookings {
+		rule, reason := s.autoAcceptReason(booking, now)
+
+		if booking.Status != "pending" || !booking.DateTime.After(now) || len(rule) == 0 {
+			continue
+		}
+
+		event := &auditEvent{action: "booking.auto-accept", payload: map[string]any{"rule": rule, "reason": reason}}
+		accepted, err := s.setStatus(ctx, booking.ID, "accept", "accepted", event, func(booking Booking) error {
+			if booking.Status != "pending" {
+				return ErrInvalidBookingState
+			}
+
+			return s.checkPriority(ctx, booking)
+		})
+
+		// a booking of a higher priority awaiting review keeps the table
+		if errors.Is(err, ErrInvalidBookingState) || errors.Is(err, ErrPriorityConflict) {
+			continue
+		}
+
+		if err != nil {
+			slog.Error("Failed to auto-accept booking", "booking", booking.ID, "error", err)
+			failed++
+			continue
+		}
+
+		s.publish(ctx, EventBookingAccepted, accepted)
+		s.notify(ctx, accepted, NotificationOptions{message: title("Booking Automatically Accepted", ":robot:"), reason: reason})
+		s.requestPayment(ctx, accepted)
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("failed to auto-accept %d bookings", failed)
+	}
+
+	return nil
+}
+
+// autoAcceptReason tells the rule of the policy accepting the booking and
+// why, both empty when none does. The organizer of a stored booking is the user who authenticated
+// to create it, an admin aside, so its id is not the client's claim.
+func (s *Service) autoAcceptReason(booking Booking, now time.Time) (string, string) {
+	if len(booking.UserID) != 0 && slices.Contains(s.autoAccept.UserIDs, booking.UserID) {
+		return ruleTrusted, trustedReason
+	}
+
+	if s.autoAccept.After > 0 && now.Sub(booking.CreatedAt) >= s.autoAccept.After {
+		return ruleOverdue, "en attente depuis plus de " + strings.TrimSuffix(s.autoAccept.After.String(), "0m0s")
+	}
+
+	return "", ""
+}
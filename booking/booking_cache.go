@@ -0,0 +1,75 @@
+package booking
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// activeBookingsCache holds the last active bookings read until a write
+// invalidates them or ttl expires, the latter bounding how stale the
+// bookings written by other replicas can get.
+type activeBookingsCache struct {
+	ttl      time.Duration
+	mu       sync.Mutex
+	bookings []Booking
+	loadedAt time.Time
+	// generation changes on every invalidation, so a read started before
+	// a write doesn't cache stale bookings
+	generation uint64
+}
+
+func (c *activeBookingsCache) get(ctx context.Context, load func(ctx context.Context) ([]Booking, error)) ([]Booking, error) {
+	c.mu.Lock()
+	if c.bookings != nil && time.Since(c.loadedAt) < c.ttl {
+		bookings := cloneBookings(c.bookings)
+		c.mu.Unlock()
+
+		return bookings, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	bookings, err := load(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if generation == c.generation {
+		c.bookings = cloneBookings(bookings)
+		c.loadedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	return bookings, nil
+}
+
+func (c *activeBookingsCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.bookings = nil
+	c.generation++
+}
+
+// Publish implements EventPublisher, to invalidate the cache on the events
+// of other replicas.
+func (c *activeBookingsCache) Publish(ctx context.Context, event Event) {
+	c.invalidate()
+}
+
+func cloneBookings(bookings []Booking) []Booking {
+	cloned := make([]Booking, len(bookings))
+
+	for i, booking := range bookings {
+		booking.Players = slices.Clone(booking.Players)
+		booking.Equipment = slices.Clone(booking.Equipment)
+		booking.Tags = slices.Clone(booking.Tags)
+		cloned[i] = booking
+	}
+
+	return cloned
+}
@@ -0,0 +1,35 @@
+package booking
+
+import (
+	"context"
+	"log/slog"
+)
+
+// States of the Google Calendar event mirroring a booking.
+const (
+	CalendarSynced  = "synced"
+	CalendarRemoved = "removed"
+	CalendarFailed  = "failed"
+)
+
+// CalendarSync is how the last sync of a booking with Google Calendar went.
+type CalendarSync struct {
+	EventID string
+	Status  string
+	Error   string
+}
+
+// RecordCalendarSync records the outcome of the last sync of the booking
+// with Google Calendar on it.
+func (s *Service) RecordCalendarSync(ctx context.Context, id string, sync CalendarSync) {
+	if sync.Status == CalendarFailed {
+		slog.Error("failed to sync booking with Google Calendar", "bookingId", id, "err", sync.Error)
+	}
+
+	if err := s.repo.SetCalendarSync(ctx, id, sync); err != nil {
+		slog.Error("failed to record booking calendar sync", "bookingId", id, "err", err)
+		return
+	}
+
+	s.invalidate()
+}
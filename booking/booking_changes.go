@@ -0,0 +1,92 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// changes lists the fields that differ between two versions of a booking, as
+// "before → after" embed fields.
+func (s *Service) changes(before, after Booking) []discord.EmbedField {
+	var fields []discord.EmbedField
+
+	add := func(name, old, new string) {
+		if old != new {
+			fields = append(fields, discord.EmbedField{Name: name, Value: old + " → " + new})
+		}
+	}
+
+	add("Jeu", before.Game, after.Game)
+	add("Date et Heure", before.DateTime.In(s.location).Format(time.DateTime), after.DateTime.In(s.location).Format(time.DateTime))
+	add("Points", strconv.Itoa(before.Points), strconv.Itoa(after.Points))
+	add("Description", orNone(before.Description), orNone(after.Description))
+	add("Joueurs", orNone(strings.Join(before.Players, ", ")), orNone(strings.Join(after.Players, ", ")))
+	add("Co-organisateurs", orNone(strings.Join(before.CoOrganizers, ", ")), orNone(strings.Join(after.CoOrganizers, ", ")))
+	add("Statut", before.Status, after.Status)
+
+	return fields
+}
+
+func orNone(value string) string {
+	if len(value) == 0 {
+		return "Aucun"
+	}
+
+	return value
+}
+
+// notifyChanges sends the changes of an accepted booking to the owner and the
+// players, removed ones included, in direct messages.
+func (s *Service) notifyChanges(ctx context.Context, before, after Booking) {
+	changes := s.changes(before, after)
+
+	if len(changes) == 0 {
+		return
+	}
+
+	s.dispatcher.Dispatch(ctx, func(ctx context.Context) {
+		recipients := map[string]struct{}{}
+
+		if len(after.UserID) != 0 {
+			recipients[after.UserID] = struct{}{}
+		}
+
+		players := slices.Compact(slices.Sorted(slices.Values(append(slices.Clone(before.Players), after.Players...))))
+		players = slices.DeleteFunc(players, func(player string) bool { return player == after.Username })
+
+		for i, user := range s.resolveMembers(ctx, players) {
+			if user.Username == players[i] {
+				recipients[user.ID] = struct{}{}
+			}
+		}
+
+		message := discord.Message{
+			Content: fmt.Sprintf("La réservation de %v a été modifiée par un admin.", after.Game),
+			Embeds: []discord.Embed{{
+				Type:   "rich",
+				Title:  title("Booking Modified", ":pencil:"),
+				Fields: changes,
+			}},
+		}
+
+		for _, recipient := range slices.Sorted(maps.Keys(recipients)) {
+			channelID, err := s.client.GetDMChannel(ctx, recipient)
+
+			if err == nil {
+				err = s.messages.SendMessage(ctx, channelID, message)
+			}
+
+			if err != nil {
+				slog.Error("failed to send booking changes", "bookingId", after.ID, "recipient", recipient, "err", err)
+			}
+		}
+	})
+}
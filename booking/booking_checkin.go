@@ -0,0 +1,115 @@
+package booking
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// checkInEarly is how long before the booking starts its players may check
+// in, they can until its table is freed.
+const checkInEarly = time.Hour
+
+// WithCheckIn signs the check-in codes of the bookings with secret, a code
+// holding checkInURL followed by the booking id and its token. Check-in is
+// disabled when secret is empty.
+func WithCheckIn(secret, checkInURL string) ServiceOption {
+	return func(s *Service) {
+		s.checkInSecret = []byte(secret)
+		s.checkInURL = checkInURL
+	}
+}
+
+func (s *Service) checkInToken(id string) string {
+	mac := hmac.New(sha256.New, s.checkInSecret)
+	mac.Write([]byte("check-in:" + id))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CheckInURL returns what the check-in QR code of an accepted booking holds,
+// for its organizer, its players and admins.
+func (s *Service) CheckInURL(ctx context.Context, id string, user discord.DiscordUser) (string, error) {
+	if len(s.checkInSecret) == 0 {
+		return "", ErrCheckInDisabled
+	}
+
+	booking, err := s.visibleBooking(ctx, id, &user)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !participates(booking, user) {
+		return "", ErrNotAllowed
+	}
+
+	if booking.Status != "accepted" {
+		return "", fmt.Errorf("%w: only accepted bookings can be checked in", ErrInvalidBookingState)
+	}
+
+	return fmt.Sprintf("%v/%v?token=%v", s.checkInURL, url.PathEscape(booking.ID), s.checkInToken(booking.ID)), nil
+}
+
+// CheckIn marks the attendance of the booking whose code was scanned at the
+// venue kiosk. Scanning it again keeps the first check-in.
+func (s *Service) CheckIn(ctx context.Context, id, token string) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking.check_in", trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	if len(s.checkInSecret) == 0 {
+		return Booking{}, ErrCheckInDisabled
+	}
+
+	if !hmac.Equal([]byte(token), []byte(s.checkInToken(id))) {
+		recordError(span, ErrInvalidCheckInToken)
+		return Booking{}, ErrInvalidCheckInToken
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		recordError(span, err)
+		return Booking{}, err
+	}
+
+	if booking.Status != "accepted" {
+		return Booking{}, fmt.Errorf("%w: the booking is %v", ErrInvalidBookingState, booking.Status)
+	}
+
+	if booking.CheckedInAt != nil {
+		return booking, nil
+	}
+
+	now := time.Now()
+
+	if now.Before(booking.DateTime.Add(-checkInEarly)) || now.After(booking.DateTime.Add(TableDuration)) {
+		return Booking{}, fmt.Errorf("%w: check-in opens %v before the booking and closes when it ends", ErrInvalidBookingState, checkInEarly)
+	}
+
+	if err := s.repo.SetCheckedIn(ctx, booking.ID, now); err != nil {
+		recordError(span, err)
+		return Booking{}, err
+	}
+
+	booking.CheckedInAt = &now
+
+	s.publish(ctx, EventBookingModified, booking)
+	s.record(ctx, "booking.check-in", booking.ID, nil)
+
+	return booking, nil
+}
+
+// GetNoShowCounts counts per organizer the accepted bookings since start
+// that are over, and those nobody checked in.
+func (s *Service) GetNoShowCounts(ctx context.Context, start time.Time) ([]NoShowCount, error) {
+	return s.repo.GetNoShowCounts(ctx, start, time.Now().Add(-TableDuration))
+}
@@ -0,0 +1,87 @@
+package booking
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// columnNames returns the output names of a select list, honoring aliases
+// and ignoring the commas inside function calls.
+func columnNames(selectList string) []string {
+	names := []string{}
+	depth, start := 0, 0
+
+	addColumn := func(expr string) {
+		expr = strings.TrimSpace(expr)
+		if i := strings.LastIndex(expr, " AS "); i >= 0 {
+			expr = expr[i+len(" AS "):]
+		}
+		names = append(names, strings.Trim(expr, `"`))
+	}
+
+	for i, r := range selectList {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				addColumn(selectList[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	addColumn(selectList[start:])
+
+	return names
+}
+
+// fieldNames mirrors how pgx.RowToStructByName maps struct fields to columns.
+func fieldNames(t reflect.Type) []string {
+	names := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			names = append(names, fieldNames(field.Type)...)
+			continue
+		}
+
+		names = append(names, strings.ToLower(field.Name))
+	}
+
+	return names
+}
+
+func normalize(names []string) []string {
+	normalized := make([]string, 0, len(names))
+
+	for _, name := range names {
+		normalized = append(normalized, strings.ToLower(strings.ReplaceAll(name, "_", "")))
+	}
+
+	return normalized
+}
+
+func TestBookingColumnsMapping(t *testing.T) {
+	t.Run("booking", func(t *testing.T) {
+		require.ElementsMatch(t, fieldNames(reflect.TypeOf(Booking{})), normalize(columnNames(bookingColumns)))
+	})
+
+	t.Run("search result", func(t *testing.T) {
+		columns := append(columnNames(bookingColumns), "rank", "highlight")
+
+		require.ElementsMatch(t, fieldNames(reflect.TypeOf(SearchResult{})), normalize(columns))
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		require.ElementsMatch(t, fieldNames(reflect.TypeOf(GameBookingCount{})), normalize(columnNames(`game, COUNT(*) AS "count"`)))
+		require.ElementsMatch(t, fieldNames(reflect.TypeOf(WeekDayBookingCount{})), normalize(columnNames(`TO_CHAR("dateTime", 'Day') AS "weekDay", COUNT(*) AS "count"`)))
+	})
+}
@@ -0,0 +1,54 @@
+package booking
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Cursor is a keyset position in the booking history, ordered by dateTime
+// then id, both descending.
+type Cursor struct {
+	DateTime time.Time
+	ID       int64
+}
+
+type encodedCursor struct {
+	DateTime time.Time `json:"d"`
+	ID       int64     `json:"i"`
+}
+
+// CursorAfter returns the cursor resuming right after booking.
+func CursorAfter(booking Booking) (Cursor, error) {
+	id, err := strconv.ParseInt(booking.ID, 10, 64)
+
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid booking id '%v': %w", booking.ID, err)
+	}
+
+	return Cursor{DateTime: booking.DateTime, ID: id}, nil
+}
+
+// Encode returns the opaque form of the cursor handed to API clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(encodedCursor{DateTime: c.DateTime, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var decoded encodedCursor
+
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.ID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{DateTime: decoded.DateTime, ID: decoded.ID}, nil
+}
@@ -0,0 +1,97 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Reservation is a quantity of an item of the club's equipment, such as
+// terrain sets and mats, reserved for a booking.
+type Reservation struct {
+	ItemID   string `json:"itemId" binding:"required"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity" binding:"gte=1"`
+}
+
+// EquipmentAvailability is an item of the equipment with the quantity
+// reserved by the other bookings holding a table at the same time.
+type EquipmentAvailability struct {
+	ItemID   string
+	Name     string
+	Quantity int
+	Reserved int
+}
+
+// reserveEquipment replaces the equipment reserved for the booking, failing
+// with ErrEquipmentUnavailable when an item would be over-allocated while the
+// booking holds its table. It must run in tx, which locks the items.
+func (s *Service) reserveEquipment(ctx context.Context, tx BookingRepository, booking *Booking) error {
+	reservations := mergeReservations(booking.Equipment)
+	ids := make([]string, 0, len(reservations))
+
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.ItemID)
+	}
+
+	availabilities, err := tx.GetEquipmentAvailability(ctx, ids, booking.DateTime, booking.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for i, reservation := range reservations {
+		index := slices.IndexFunc(availabilities, func(a EquipmentAvailability) bool { return a.ItemID == reservation.ItemID })
+
+		if index < 0 {
+			return fmt.Errorf("%w: no item with id '%v'", ErrUnknownEquipment, reservation.ItemID)
+		}
+
+		availability := availabilities[index]
+		left := max(availability.Quantity-availability.Reserved, 0)
+
+		if reservation.Quantity > left {
+			return fmt.Errorf("%w: %d %v left at that time", ErrEquipmentUnavailable, left, availability.Name)
+		}
+
+		reservations[i].Name = availability.Name
+	}
+
+	if err := tx.SetEquipment(ctx, booking.ID, reservations); err != nil {
+		return err
+	}
+
+	booking.Equipment = reservations
+
+	return nil
+}
+
+// mergeReservations sums the quantities reserved twice for the same item.
+func mergeReservations(reservations []Reservation) []Reservation {
+	merged := []Reservation{}
+
+	for _, reservation := range reservations {
+		reservation.ItemID = strings.TrimSpace(reservation.ItemID)
+		index := slices.IndexFunc(merged, func(r Reservation) bool { return r.ItemID == reservation.ItemID })
+
+		if index < 0 {
+			merged = append(merged, reservation)
+		} else {
+			merged[index].Quantity += reservation.Quantity
+		}
+	}
+
+	return merged
+}
+
+// formatEquipment lists the reserved equipment for the Discord embed.
+func formatEquipment(reservations []Reservation) string {
+	lines := make([]string, 0, len(reservations))
+
+	for _, reservation := range reservations {
+		lines = append(lines, fmt.Sprintf("%d × %v", reservation.Quantity, reservation.Name))
+	}
+
+	return strings.Join(lines, "\n")
+}
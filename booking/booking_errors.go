@@ -1,9 +1,111 @@
 package booking
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var ErrBookingNotFound = errors.New("booking not found")
 
 var ErrInvalidBookingState = errors.New("invalid booking state")
 
-var ErrNotAllowed = errors.New("not allowed to perform this operation")
\ No newline at end of file
+var ErrInvalidPriority = errors.New("invalid priority")
+
+var ErrPriorityConflict = errors.New("a booking of a higher priority awaits this slot")
+
+var ErrSlotTaken = errors.New("slot is no longer free")
+
+var ErrNotAllowed = errors.New("not allowed to perform this operation")
+
+var ErrUserSuspended = errors.New("user is suspended from booking")
+
+var ErrMembershipRequired = errors.New("prime-time slots are reserved to club members")
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+var ErrInvalidSearch = errors.New("search query must contain at least one word")
+
+var ErrBookingLocked = errors.New("booking can no longer be changed")
+
+var ErrTooManyPlayers = errors.New("too many players")
+
+var ErrInvalidPoints = errors.New("invalid points")
+
+var ErrInvalidTags = errors.New("invalid tags")
+
+var ErrInvalidCoOrganizers = errors.New("invalid co-organizers")
+
+var ErrInvalidCustomFields = errors.New("invalid custom fields")
+
+var ErrResultNotFound = errors.New("result not found")
+
+var ErrInvalidResult = errors.New("invalid result")
+
+var ErrNoOpenSeat = errors.New("booking is not looking for players")
+
+var ErrAlreadyJoined = errors.New("already playing in the booking")
+
+var ErrPlayerNotFound = errors.New("not playing in the booking")
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+var ErrInvalidAttachment = errors.New("invalid attachment")
+
+var ErrAttachmentTooLarge = errors.New("attachment too large")
+
+var ErrUnknownEquipment = errors.New("unknown equipment")
+
+var ErrEquipmentUnavailable = errors.New("equipment unavailable")
+
+var ErrInvalidVenue = errors.New("invalid venue")
+
+var ErrCheckInDisabled = errors.New("check-in is disabled")
+
+var ErrInvalidCheckInToken = errors.New("invalid check-in code")
+
+var ErrPaymentsDisabled = errors.New("payments are disabled")
+
+var ErrPaymentNotFound = errors.New("payment not found")
+
+var ErrInvalidPaymentStatus = errors.New("invalid payment status")
+
+var ErrInvalidPaymentEvent = errors.New("invalid payment event")
+
+var ErrNotificationFailed = errors.New("failed to send notification")
+
+var ErrInvalidReminders = errors.New("invalid reminders")
+
+var ErrUnknownPlayers = errors.New("unknown players")
+
+// UnknownPlayer is a player missing from the Discord server, with the
+// usernames of the members closest to it.
+type UnknownPlayer struct {
+	Index       int
+	Username    string
+	Suggestions []string
+}
+
+// UnknownPlayersError lists the players of a booking that are not members of
+// the Discord server. It matches ErrUnknownPlayers.
+type UnknownPlayersError struct {
+	Players []UnknownPlayer
+}
+
+func (e *UnknownPlayersError) Error() string {
+	usernames := make([]string, 0, len(e.Players))
+
+	for _, player := range e.Players {
+		usernames = append(usernames, "'"+player.Username+"'")
+	}
+
+	return fmt.Sprintf("%v: %v", ErrUnknownPlayers, strings.Join(usernames, ", "))
+}
+
+func (e *UnknownPlayersError) Is(target error) bool {
+	return target == ErrUnknownPlayers
+}
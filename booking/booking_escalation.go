@@ -0,0 +1,98 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// EscalationConfig tells when pending bookings are brought to the admins'
+// attention, and where.
+type EscalationConfig struct {
+	ChannelID   string
+	AdminRoleID string
+	// BookingURL is the page where admins accept or refuse a booking, its id
+	// is appended.
+	BookingURL string
+	// After is how long a booking may stay pending, Before how close to its
+	// date it may still be pending.
+	After  time.Duration
+	Before time.Duration
+}
+
+func WithEscalation(config EscalationConfig) ServiceOption {
+	return func(s *Service) {
+		s.escalation = &config
+	}
+}
+
+// EscalatePendingBookings pings the admin role for every booking pending for
+// longer than the configured delay or starting soon. A booking is escalated
+// once, even when several replicas run the job.
+func (s *Service) EscalatePendingBookings(ctx context.Context) error {
+	if s.escalation == nil {
+		return fmt.Errorf("escalation is not configured")
+	}
+
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	now := time.Now()
+	settings := s.guildSettings(ctx)
+	failed := 0
+
+	for _, booking := range bookings {
+		overdue := now.Sub(booking.CreatedAt) >= s.escalation.After
+		soon := booking.DateTime.Sub(now) < s.escalation.Before
+
+		if booking.Status != "pending" || (!overdue && !soon) {
+			continue
+		}
+
+		marked, err := s.repo.MarkBookingEscalated(ctx, booking.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to mark booking '%v' escalated: %w", booking.ID, err)
+		}
+
+		if !marked {
+			continue
+		}
+
+		if err := s.messages.SendMessage(ctx, settings.AdminChannelID, s.escalationMessage(booking, settings.AdminRoleID, now)); err != nil {
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("failed to send %d escalation messages", failed)
+	}
+
+	return nil
+}
+
+func (s *Service) escalationMessage(booking Booking, adminRoleID string, now time.Time) discord.Message {
+	url := strings.TrimSuffix(s.escalation.BookingURL, "/") + "/" + booking.ID
+	waiting := now.Sub(booking.CreatedAt).Round(time.Hour)
+
+	return discord.Message{
+		Content: fmt.Sprintf("<@&%v> une réservation attend une réponse depuis %v", adminRoleID, strings.TrimSuffix(waiting.String(), "0m0s")),
+		Embeds: []discord.Embed{{
+			Type:  "rich",
+			Title: title("Booking Pending", ":hourglass:"),
+			URL:   url,
+			Fields: []discord.EmbedField{
+				{Name: "Utilisateur", Value: booking.Username, Inline: true},
+				{Name: "Date et Heure", Value: booking.DateTime.In(s.location).Format(time.DateTime), Inline: true},
+				{Name: "Jeu", Value: booking.Game, Inline: true},
+				{Name: "Accepter ou refuser", Value: url},
+			},
+		}},
+	}
+}
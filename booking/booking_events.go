@@ -0,0 +1,33 @@
+package booking
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EventBookingCreated  = "booking.created"
+	EventBookingModified = "booking.modified"
+	EventBookingAccepted = "booking.accepted"
+	EventBookingRefused  = "booking.refused"
+	EventBookingCanceled = "booking.canceled"
+	EventBookingReopened = "booking.reopened"
+	EventBookingDeleted  = "booking.deleted"
+)
+
+type Event struct {
+	Type       string    `json:"type"`
+	Booking    Booking   `json:"booking"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// EventPublisherFunc adapts a function to EventPublisher.
+type EventPublisherFunc func(ctx context.Context, event Event)
+
+func (f EventPublisherFunc) Publish(ctx context.Context, event Event) {
+	f(ctx, event)
+}
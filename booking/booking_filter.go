@@ -0,0 +1,127 @@
+package booking
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// activePeriod is how long a booking stays listed with the active ones once
+// started.
+const activePeriod = 3 * time.Hour
+
+// Statuses are the states a booking goes through.
+var Statuses = []string{"pending", "accepted", "refused", "canceled"}
+
+// Filter narrows the bookings listed or counted, its empty fields match every
+// booking.
+type Filter struct {
+	// Status is one of Statuses
+	Status string
+	// Game is the name of a game, its key in the catalog, in any case
+	Game string
+	// Player is the username or Discord id of a member involved in the
+	// bookings: their owner, a co-organizer or a player
+	Player string
+	// From and To bound the date of the bookings, both included
+	From time.Time
+	To   time.Time
+}
+
+// IsZero reports whether the filter matches every booking.
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Normalize trims the filter and lowercases the username, failing with
+// ErrInvalidFilter on an unknown status or a period ending before it starts.
+func (f *Filter) Normalize() error {
+	f.Status = strings.ToLower(strings.TrimSpace(f.Status))
+	f.Game = strings.TrimSpace(f.Game)
+	f.Player = strings.ToLower(strings.TrimSpace(f.Player))
+
+	if len(f.Status) != 0 && !slices.Contains(Statuses, f.Status) {
+		return fmt.Errorf("%w: status must be one of %v", ErrInvalidFilter, strings.Join(Statuses, ", "))
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return fmt.Errorf("%w: to is before from", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// Matches reports whether booking passes the filter, the mirror of the
+// conditions of the repository queries.
+func (f Filter) Matches(booking Booking) bool {
+	if len(f.Status) != 0 && booking.Status != f.Status {
+		return false
+	}
+
+	if (!f.From.IsZero() && booking.DateTime.Before(f.From)) || (!f.To.IsZero() && booking.DateTime.After(f.To)) {
+		return false
+	}
+
+	if len(f.Game) != 0 && !strings.EqualFold(booking.Game, f.Game) {
+		return false
+	}
+
+	if len(f.Player) != 0 && !involves(booking, f.Player) {
+		return false
+	}
+
+	return true
+}
+
+// active bounds the filter to the active bookings, those that started less
+// than activePeriod ago, unless it starts at a date of its own.
+func (f Filter) active(now time.Time) Filter {
+	if f.From.IsZero() {
+		f.From = now.Add(-activePeriod)
+	}
+
+	return f
+}
+
+// conditions returns the SQL conditions of the filter, each preceded by AND,
+// with their arguments numbered from next.
+func (f Filter) conditions(next int) (string, []any) {
+	var sql strings.Builder
+	args := []any{}
+
+	add := func(condition string, arg any) {
+		placeholder := "$" + strconv.Itoa(next+len(args))
+		sql.WriteString(" AND " + strings.ReplaceAll(condition, "?", placeholder))
+		args = append(args, arg)
+	}
+
+	if len(f.Status) != 0 {
+		add("status = ?", f.Status)
+	}
+
+	if !f.From.IsZero() {
+		add(`"dateTime" >= ?`, f.From)
+	}
+
+	if !f.To.IsZero() {
+		add(`"dateTime" <= ?`, f.To)
+	}
+
+	if len(f.Game) != 0 {
+		add("lower(game) = lower(?)", f.Game)
+	}
+
+	if len(f.Player) != 0 {
+		add(`(username = ? OR "userId" = ? OR ? = ANY(players) OR ? = ANY("coOrganizers"))`, f.Player)
+	}
+
+	return sql.String(), args
+}
+
+// involves reports whether the member of the username or Discord id takes
+// part in the booking.
+func involves(booking Booking, player string) bool {
+	return booking.Username == player || booking.UserID == player || slices.Contains(booking.Players, player) || slices.Contains(booking.CoOrganizers, player)
+}
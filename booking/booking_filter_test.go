@@ -0,0 +1,52 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterConditions(t *testing.T) {
+	sql, args := Filter{}.conditions(6)
+
+	require.Empty(t, sql)
+	require.Empty(t, args)
+
+	sql, args = Filter{Status: "pending", Game: "Blood Bowl", Player: "john.doe"}.conditions(6)
+
+	require.Equal(t, " AND status = $6 AND lower(game) = lower($7) AND (username = $8 OR \"userId\" = $8 OR $8 = ANY(players) OR $8 = ANY(\"coOrganizers\"))", sql)
+	require.Equal(t, []any{"pending", "Blood Bowl", "john.doe"}, args)
+
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	sql, args = Filter{From: from, To: to}.conditions(5)
+
+	require.Equal(t, ` AND "dateTime" >= $5 AND "dateTime" <= $6`, sql)
+	require.Equal(t, []any{from, to}, args)
+}
+
+func TestFilterActive(t *testing.T) {
+	now := time.Date(2026, 10, 12, 20, 0, 0, 0, time.UTC)
+
+	require.Equal(t, now.Add(-3*time.Hour), Filter{}.active(now).From)
+
+	from := now.AddDate(0, 0, -7)
+	require.Equal(t, from, Filter{From: from}.active(now).From)
+}
+
+func TestFilterNormalize(t *testing.T) {
+	filter := Filter{Status: " Pending ", Game: " Blood Bowl ", Player: "John.Doe"}
+
+	require.Nil(t, filter.Normalize())
+	require.Equal(t, Filter{Status: "pending", Game: "Blood Bowl", Player: "john.doe"}, filter)
+
+	filter = Filter{Status: "lost"}
+
+	require.ErrorIs(t, filter.Normalize(), ErrInvalidFilter)
+
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	filter = Filter{From: from, To: from.Add(-time.Hour)}
+
+	require.ErrorIs(t, filter.Normalize(), ErrInvalidFilter)
+}
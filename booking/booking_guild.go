@@ -0,0 +1,36 @@
+package booking
+
+import "context"
+
+// GuildSettings are the channels the notifications are posted to and the
+// role pinged for the pending bookings.
+type GuildSettings struct {
+	ChannelID          string
+	AdminChannelID     string
+	OpenSeatsChannelID string
+	AdminRoleID        string
+}
+
+// WithGuildSettings reads the channels and the admin role from settings on
+// each message, rather than those given to NewService, WithOpenSeatsChannel
+// and WithEscalation, for the admins to change them without a redeploy.
+func WithGuildSettings(settings func(ctx context.Context) GuildSettings) ServiceOption {
+	return func(s *Service) {
+		s.settings = settings
+	}
+}
+
+func (s *Service) guildSettings(ctx context.Context) GuildSettings {
+	if s.settings != nil {
+		return s.settings(ctx)
+	}
+
+	settings := GuildSettings{ChannelID: s.channelID, OpenSeatsChannelID: s.openSeatsChannelID}
+
+	if s.escalation != nil {
+		settings.AdminChannelID = s.escalation.ChannelID
+		settings.AdminRoleID = s.escalation.AdminRoleID
+	}
+
+	return settings
+}
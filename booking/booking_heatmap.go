@@ -0,0 +1,51 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultHeatmapPeriod is how far back the heatmap goes without a start.
+const defaultHeatmapPeriod = 12 * 7 * 24 * time.Hour
+
+// Heatmap counts the bookings holding a table between From and To per
+// weekday, 0 being Sunday, and hour of the day they start at, in the guild's
+// time zone.
+type Heatmap struct {
+	From   time.Time  `json:"from"`
+	To     time.Time  `json:"to"`
+	Counts [7][24]int `json:"counts"`
+}
+
+// GetHeatmap counts the pending and accepted bookings between from and to
+// per weekday and hour. The period ends now and lasts 12 weeks by default,
+// it fails with ErrInvalidFilter when to is before from.
+func (s *Service) GetHeatmap(ctx context.Context, from, to time.Time) (Heatmap, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-defaultHeatmapPeriod)
+	}
+
+	if to.Before(from) {
+		return Heatmap{}, fmt.Errorf("%w: to is before from", ErrInvalidFilter)
+	}
+
+	slots, err := s.repo.GetBookedSlots(ctx, from, to, "")
+
+	if err != nil {
+		return Heatmap{}, err
+	}
+
+	heatmap := Heatmap{From: from, To: to}
+
+	for _, slot := range slots {
+		start := slot.DateTime.In(s.location)
+		heatmap.Counts[start.Weekday()][start.Hour()]++
+	}
+
+	return heatmap, nil
+}
@@ -0,0 +1,95 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// defaultLatencyPeriod is how far back the approval latencies go without a
+// start.
+const defaultLatencyPeriod = 365 * 24 * time.Hour
+
+// ApprovalLatency sums up how long the bookings created in a month, in the
+// guild's time zone, waited to be accepted or refused, in seconds.
+type ApprovalLatency struct {
+	Month          string `json:"month"`
+	Decisions      int    `json:"decisions"`
+	AverageSeconds int64  `json:"averageSeconds"`
+	MedianSeconds  int64  `json:"medianSeconds"`
+	P95Seconds     int64  `json:"p95Seconds"`
+}
+
+// GetApprovalLatencies returns per month the average, median and 95th
+// percentile of the time the bookings created between from and to waited
+// for a decision. The period ends now and lasts a year by default, it fails
+// with ErrInvalidFilter when to is before from. Months without decisions are
+// left out.
+func (s *Service) GetApprovalLatencies(ctx context.Context, from, to time.Time) ([]ApprovalLatency, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-defaultLatencyPeriod)
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to is before from", ErrInvalidFilter)
+	}
+
+	decisions, err := s.repo.GetDecisions(ctx, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	months := []string{}
+	latencies := map[string][]time.Duration{}
+
+	for _, decision := range decisions {
+		month := decision.CreatedAt.In(s.location).Format("2006-01")
+
+		if _, ok := latencies[month]; !ok {
+			months = append(months, month)
+		}
+
+		latencies[month] = append(latencies[month], max(decision.DecidedAt.Sub(decision.CreatedAt), 0))
+	}
+
+	slices.Sort(months)
+	stats := make([]ApprovalLatency, len(months))
+
+	for i, month := range months {
+		durations := latencies[month]
+		slices.Sort(durations)
+
+		var total time.Duration
+
+		for _, duration := range durations {
+			total += duration
+		}
+
+		stats[i] = ApprovalLatency{
+			Month:          month,
+			Decisions:      len(durations),
+			AverageSeconds: int64((total / time.Duration(len(durations))).Seconds()),
+			MedianSeconds:  int64(percentile(durations, 0.5).Seconds()),
+			P95Seconds:     int64(percentile(durations, 0.95).Seconds()),
+		}
+	}
+
+	return stats, nil
+}
+
+// percentile interpolates the p percentile of the sorted durations, like
+// Postgres' percentile_cont.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	position := p * float64(len(sorted)-1)
+	lower, upper := int(math.Floor(position)), int(math.Ceil(position))
+	weight := position - float64(lower)
+
+	return sorted[lower] + time.Duration(math.Round(weight*float64(sorted[upper]-sorted[lower])))
+}
@@ -0,0 +1,155 @@
+package booking
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/patrickmn/go-cache"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// memberLookups bounds the concurrent member searches of a booking,
+	// Discord rate limits the endpoint per guild
+	memberLookups  = 4
+	memberCacheTTL = 10 * time.Minute
+	// playerSuggestions bounds the close matches offered for an unknown
+	// player
+	playerSuggestions = 5
+)
+
+// Player is a player of a booking as found in the member directory, only
+// its username is known when it is not a member of the Discord server.
+type Player struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"displayName"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+// lookupMembers looks up the Discord member of each player concurrently,
+// members[i] being the zero Member when players[i] was not found. Found
+// members are cached across notifications.
+func (s *Service) lookupMembers(ctx context.Context, players []string) []discord.Member {
+	found := make([]discord.Member, len(players))
+
+	var g errgroup.Group
+	g.SetLimit(memberLookups)
+
+	for i, player := range players {
+		if cached, ok := s.members.Get(player); ok {
+			found[i] = cached.(discord.Member)
+			continue
+		}
+
+		g.Go(func() error {
+			members, err := s.client.SearchMembers(ctx, player, 1)
+
+			// a missing player doesn't prevent notifying the others
+			if err == nil && len(members) != 0 {
+				found[i] = members[0]
+				s.members.Set(player, members[0], cache.DefaultExpiration)
+			}
+
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	return found
+}
+
+// resolveMembers looks up the Discord user of each player, users[i] being
+// the zero User when players[i] was not found.
+func (s *Service) resolveMembers(ctx context.Context, players []string) []discord.User {
+	users := make([]discord.User, len(players))
+
+	for i, member := range s.lookupMembers(ctx, players) {
+		users[i] = member.User
+	}
+
+	return users
+}
+
+// ResolvePlayers looks up the players in the member directory, for the
+// frontend to render their names and avatars.
+func (s *Service) ResolvePlayers(ctx context.Context, players []string) []Player {
+	resolved := make([]Player, len(players))
+
+	for i, member := range s.lookupMembers(ctx, players) {
+		if len(member.User.ID) == 0 {
+			resolved[i] = Player{Username: players[i], DisplayName: players[i]}
+			continue
+		}
+
+		resolved[i] = Player{
+			ID:          member.User.ID,
+			Username:    member.User.Username,
+			DisplayName: member.DisplayName(),
+			AvatarURL:   member.User.AvatarURL(),
+		}
+	}
+
+	return resolved
+}
+
+// validatePlayers checks every player is a member of the Discord server,
+// returning an *UnknownPlayersError with close matches otherwise. Found
+// members are cached for the notification. Players that can't be looked up,
+// Discord being unavailable, are let through.
+func (s *Service) validatePlayers(ctx context.Context, players []string) error {
+	unknown := make([]*UnknownPlayer, len(players))
+
+	var g errgroup.Group
+	g.SetLimit(memberLookups)
+
+	for i, player := range players {
+		if _, ok := s.members.Get(player); ok {
+			continue
+		}
+
+		g.Go(func() error {
+			members, err := s.client.SearchMembers(ctx, player, playerSuggestions)
+
+			if err != nil {
+				slog.Warn("failed to validate player", "player", player, "err", err)
+				return nil
+			}
+
+			suggestions := make([]string, 0, len(members))
+
+			for _, member := range members {
+				if strings.EqualFold(member.User.Username, player) {
+					s.members.Set(player, member, cache.DefaultExpiration)
+					return nil
+				}
+
+				suggestions = append(suggestions, member.User.Username)
+			}
+
+			unknown[i] = &UnknownPlayer{Index: i, Username: player, Suggestions: suggestions}
+
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	var missing []UnknownPlayer
+
+	for _, player := range unknown {
+		if player != nil {
+			missing = append(missing, *player)
+		}
+	}
+
+	if len(missing) != 0 {
+		return &UnknownPlayersError{Players: missing}
+	}
+
+	return nil
+}
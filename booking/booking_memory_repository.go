@@ -0,0 +1,1016 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/audit"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// MemoryRepository is a BookingRepository kept in process memory, for local
+// development and demos without a Postgres instance. Data is lost on restart.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	txMu     sync.Mutex
+	bookings map[string]Booking
+	nextID   int
+	// escalated holds the ids of the bookings the admins were alerted about
+	escalated map[string]bool
+	results   map[string]Result
+	// attachments are keyed by booking id
+	attachments      map[string][]Attachment
+	nextAttachmentID int
+	// payments are keyed by booking id
+	payments map[string]Payment
+	// remindersSent holds the reminders sent, by booking id, lead time and
+	// date
+	remindersSent map[string]bool
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{bookings: map[string]Booking{}, nextID: 1, escalated: map[string]bool{}, results: map[string]Result{},
+		attachments: map[string][]Attachment{}, nextAttachmentID: 1, payments: map[string]Payment{}, remindersSent: map[string]bool{}}
+}
+
+func (r *MemoryRepository) GetActiveBookings(ctx context.Context) ([]Booking, error) {
+	cutoff := time.Now().Add(-3 * time.Hour)
+
+	return r.filter(func(booking Booking) bool { return !booking.DateTime.Before(cutoff) }), nil
+}
+
+func (r *MemoryRepository) GetActiveBookingsIncludingDeleted(ctx context.Context) ([]Booking, error) {
+	cutoff := time.Now().Add(-3 * time.Hour)
+
+	return r.filterAll(func(booking Booking) bool { return !booking.DateTime.Before(cutoff) }), nil
+}
+
+func (r *MemoryRepository) GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]Booking, error) {
+	cutoff := time.Now().Add(-3 * time.Hour)
+
+	return r.filter(func(booking Booking) bool { return !booking.DateTime.Before(cutoff) && booking.VisibleTo(user) }), nil
+}
+
+func (r *MemoryRepository) GetMatchingBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) ([]Booking, error) {
+	filter = filter.active(time.Now())
+
+	return r.filter(func(booking Booking) bool { return booking.VisibleTo(user) && filter.Matches(booking) }), nil
+}
+
+func (r *MemoryRepository) CountBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) (int, error) {
+	filter = filter.active(time.Now())
+
+	return len(r.filter(func(booking Booking) bool { return booking.VisibleTo(user) && filter.Matches(booking) })), nil
+}
+
+func (r *MemoryRepository) GetBookingByID(ctx context.Context, id string) (Booking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return Booking{}, ErrBookingNotFound
+	}
+
+	return cloneBooking(booking), nil
+}
+
+func (r *MemoryRepository) GetBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]Booking, error) {
+	for _, id := range ids {
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid booking id '%v': %w", id, err)
+		}
+	}
+
+	return r.filter(func(booking Booking) bool { return slices.Contains(ids, booking.ID) && booking.VisibleTo(user) }), nil
+}
+
+func (r *MemoryRepository) GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter Filter, after *Cursor, limit int) ([]Booking, error) {
+	bookings := r.filter(func(booking Booking) bool {
+		if !booking.VisibleTo(user) || !filter.Matches(booking) {
+			return false
+		}
+
+		if after == nil {
+			return true
+		}
+
+		id, _ := strconv.ParseInt(booking.ID, 10, 64)
+
+		return booking.DateTime.Before(after.DateTime) || (booking.DateTime.Equal(after.DateTime) && id < after.ID)
+	})
+
+	sort.SliceStable(bookings, func(i, j int) bool {
+		if !bookings[i].DateTime.Equal(bookings[j].DateTime) {
+			return bookings[i].DateTime.After(bookings[j].DateTime)
+		}
+
+		idI, _ := strconv.ParseInt(bookings[i].ID, 10, 64)
+		idJ, _ := strconv.ParseInt(bookings[j].ID, 10, 64)
+
+		return idI > idJ
+	})
+
+	return bookings[:min(limit, len(bookings))], nil
+}
+
+func (r *MemoryRepository) GetBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]Booking, error) {
+	return r.filter(func(booking Booking) bool {
+		return (strings.ToLower(booking.Username) == username || slices.Contains(booking.Players, username)) && booking.VisibleTo(user)
+	}), nil
+}
+
+func (r *MemoryRepository) InsertBooking(ctx context.Context, booking Booking) (Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(booking.Status) == 0 {
+		booking.Status = "pending"
+	}
+
+	booking.Priority = priorityOrNormal(booking.Priority)
+	// reminders are set afterwards, by SetReminders
+	booking.Reminders = nil
+	// and the voice channel by SetVoiceChannel
+	booking.VoiceChannelID = ""
+
+	return cloneBooking(r.insert(booking)), nil
+}
+
+func (r *MemoryRepository) InsertManyBookings(ctx context.Context, bookings []Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, booking := range bookings {
+		booking.Priority = priorityOrNormal(booking.Priority)
+		r.insert(booking)
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) UpdateBooking(ctx context.Context, booking Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.bookings[booking.ID]
+
+	if !ok || existing.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	existing.Game = booking.Game
+	existing.Points = booking.Points
+	existing.Description = booking.Description
+	existing.ReminderEnabled = booking.ReminderEnabled
+	existing.DateTime = booking.DateTime
+	existing.Players = slices.Clone(booking.Players)
+	existing.CoOrganizers = slices.Clone(booking.CoOrganizers)
+	existing.LookingForPlayers = booking.LookingForPlayers
+	existing.Visibility = booking.Visibility
+	existing.VenueID = booking.VenueID
+	existing.Tags = slices.Clone(booking.Tags)
+	existing.CustomFields = maps.Clone(booking.CustomFields)
+	existing.UpdatedAt = time.Now()
+
+	r.bookings[booking.ID] = existing
+
+	return nil
+}
+
+func (r *MemoryRepository) SetCheckedIn(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	if booking.CheckedInAt == nil {
+		booking.CheckedInAt = &at
+	}
+
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) SetPaymentStatus(ctx context.Context, id, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	booking.PaymentStatus = status
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	if payment, ok := r.payments[id]; ok {
+		payment.Status = status
+
+		if status != PaymentPaid {
+			payment.PaidAt = nil
+		} else if payment.PaidAt == nil {
+			now := time.Now()
+			payment.PaidAt = &now
+		}
+
+		r.payments[id] = payment
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) SetBookingStatus(ctx context.Context, id string, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	now := time.Now()
+	booking.Status = status
+	booking.UpdatedAt = now
+
+	if booking.DecidedAt == nil && (status == "accepted" || status == "refused") {
+		booking.DecidedAt = &now
+	}
+
+	r.bookings[id] = booking
+
+	return nil
+}
+
+// InsertAuditEntry drops the entry, the audit log being unavailable with
+// in-memory storage.
+func (r *MemoryRepository) InsertAuditEntry(ctx context.Context, entry audit.Entry) error {
+	return nil
+}
+
+func (r *MemoryRepository) SetPriority(ctx context.Context, id, priority string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	booking.Priority = priority
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	return nil
+}
+
+// SearchBookings matches terms as case-insensitive substrings, ranking
+// bookings by the number of fields matching.
+func (r *MemoryRepository) SearchBookings(ctx context.Context, user *discord.DiscordUser, terms []string, limit int) ([]SearchResult, error) {
+	results := []SearchResult{}
+
+	for _, booking := range r.filter(func(booking Booking) bool { return booking.VisibleTo(user) }) {
+		fields := append([]string{booking.Game, booking.Description, booking.Username}, booking.Players...)
+		matches := 0
+
+		for _, term := range terms {
+			matched := false
+
+			for _, field := range fields {
+				if strings.Contains(strings.ToLower(field), term) {
+					matches++
+					matched = true
+				}
+			}
+
+			if !matched {
+				matches = 0
+				break
+			}
+		}
+
+		if matches != 0 {
+			results = append(results, SearchResult{Booking: booking, Rank: float32(matches), Highlight: booking.Game})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank > results[j].Rank })
+
+	return results[:min(limit, len(results))], nil
+}
+
+func (r *MemoryRepository) DeleteBooking(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	now := time.Now()
+	booking.DeletedAt = &now
+	booking.UpdatedAt = now
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) PurgeDeletedBookings(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+
+	for id, booking := range r.bookings {
+		if booking.DeletedAt != nil && booking.DeletedAt.Before(before) {
+			delete(r.bookings, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (r *MemoryRepository) SetNotificationError(ctx context.Context, id, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	booking.NotificationError = message
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) SetCalendarSync(ctx context.Context, id string, sync CalendarSync) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok {
+		return ErrBookingNotFound
+	}
+
+	booking.CalendarEventID = sync.EventID
+	booking.CalendarSyncStatus = sync.Status
+	booking.CalendarSyncError = sync.Error
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) SetVoiceChannel(ctx context.Context, id, channelID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok {
+		return ErrBookingNotFound
+	}
+
+	booking.VoiceChannelID = channelID
+	booking.UpdatedAt = time.Now()
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) GetVoiceChannelBookings(ctx context.Context) ([]Booking, error) {
+	return r.filterAll(func(booking Booking) bool { return len(booking.VoiceChannelID) != 0 }), nil
+}
+
+func (r *MemoryRepository) SetReminders(ctx context.Context, id string, reminders Reminders) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	updated := Reminders{LeadTimes: slices.Clone(reminders.LeadTimes), Channel: reminders.Channel, OptedOut: []string{}, Responses: []ReminderResponse{}}
+
+	if booking.Reminders != nil {
+		updated.OptedOut = booking.Reminders.OptedOut
+		updated.Responses = booking.Reminders.Responses
+	}
+
+	booking.Reminders = &updated
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) SetReminderOptOut(ctx context.Context, id, username string, optOut bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	reminders := r.reminders(booking)
+	reminders.OptedOut = slices.DeleteFunc(slices.Clone(reminders.OptedOut), func(optedOut string) bool { return optedOut == username })
+
+	if optOut {
+		reminders.OptedOut = append(reminders.OptedOut, username)
+	}
+
+	booking.Reminders = &reminders
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) SetReminderResponse(ctx context.Context, id string, response ReminderResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	reminders := r.reminders(booking)
+	reminders.Responses = slices.DeleteFunc(slices.Clone(reminders.Responses), func(previous ReminderResponse) bool { return previous.Username == response.Username })
+	reminders.Responses = append(reminders.Responses, response)
+	slices.SortFunc(reminders.Responses, func(a, b ReminderResponse) int { return strings.Compare(a.Username, b.Username) })
+
+	booking.Reminders = &reminders
+	r.bookings[id] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) ClearSnooze(ctx context.Context, id, username string, snoozedUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[id]
+
+	if !ok || booking.Reminders == nil {
+		return false, nil
+	}
+
+	reminders := r.reminders(booking)
+	reminders.Responses = slices.Clone(reminders.Responses)
+
+	for i, response := range reminders.Responses {
+		if response.Username == username && response.SnoozedUntil != nil && response.SnoozedUntil.Equal(snoozedUntil) {
+			reminders.Responses[i].SnoozedUntil = nil
+			booking.Reminders = &reminders
+			r.bookings[id] = booking
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// reminders returns the reminders of the booking, those of a booking without
+// any set up yet being the defaults.
+func (r *MemoryRepository) reminders(booking Booking) Reminders {
+	if booking.Reminders == nil {
+		return Reminders{LeadTimes: []int{}, Channel: ReminderDM, OptedOut: []string{}, Responses: []ReminderResponse{}}
+	}
+
+	return *booking.Reminders
+}
+
+func (r *MemoryRepository) MarkReminderSent(ctx context.Context, id string, leadTime int, dateTime time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fmt.Sprintf("%v/%d/%v", id, leadTime, dateTime.UnixNano())
+
+	if r.remindersSent[key] {
+		return false, nil
+	}
+
+	r.remindersSent[key] = true
+
+	return true, nil
+}
+
+func (r *MemoryRepository) UpsertResult(ctx context.Context, result Result) (Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.bookings[result.BookingID]; !ok {
+		return Result{}, ErrBookingNotFound
+	}
+
+	result.Scores = maps.Clone(result.Scores)
+	result.ReportedAt = time.Now()
+	r.results[result.BookingID] = result
+
+	return result, nil
+}
+
+func (r *MemoryRepository) GetResult(ctx context.Context, bookingID string) (Result, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, ok := r.results[bookingID]
+
+	if !ok {
+		return Result{}, ErrResultNotFound
+	}
+
+	result.Scores = maps.Clone(result.Scores)
+
+	return result, nil
+}
+
+func (r *MemoryRepository) InsertPayment(ctx context.Context, payment Payment) (Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.bookings[payment.BookingID]; !ok {
+		return Payment{}, ErrBookingNotFound
+	}
+
+	payment.CreatedAt = time.Now()
+	payment.PaidAt = nil
+	r.payments[payment.BookingID] = payment
+
+	return payment, nil
+}
+
+func (r *MemoryRepository) GetPayment(ctx context.Context, bookingID string) (Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	payment, ok := r.payments[bookingID]
+
+	if !ok {
+		return Payment{}, ErrPaymentNotFound
+	}
+
+	return payment, nil
+}
+
+func (r *MemoryRepository) InsertAttachment(ctx context.Context, attachment Attachment) (Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.bookings[attachment.BookingID]; !ok {
+		return Attachment{}, ErrBookingNotFound
+	}
+
+	attachment.ID = strconv.Itoa(r.nextAttachmentID)
+	attachment.CreatedAt = time.Now()
+	r.nextAttachmentID++
+	r.attachments[attachment.BookingID] = append(r.attachments[attachment.BookingID], attachment)
+
+	return attachment, nil
+}
+
+func (r *MemoryRepository) GetAttachments(ctx context.Context, bookingID string) ([]Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Attachment{}, r.attachments[bookingID]...), nil
+}
+
+func (r *MemoryRepository) GetAttachment(ctx context.Context, bookingID, id string) (Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	index := slices.IndexFunc(r.attachments[bookingID], func(a Attachment) bool { return a.ID == id })
+
+	if index < 0 {
+		return Attachment{}, ErrAttachmentNotFound
+	}
+
+	return r.attachments[bookingID][index], nil
+}
+
+func (r *MemoryRepository) DeleteAttachment(ctx context.Context, bookingID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachments := r.attachments[bookingID]
+	index := slices.IndexFunc(attachments, func(a Attachment) bool { return a.ID == id })
+
+	if index < 0 {
+		return ErrAttachmentNotFound
+	}
+
+	r.attachments[bookingID] = slices.Delete(slices.Clone(attachments), index, index+1)
+
+	return nil
+}
+
+func (r *MemoryRepository) MarkBookingEscalated(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.escalated[id] {
+		return false, nil
+	}
+
+	r.escalated[id] = true
+
+	return true, nil
+}
+
+func (r *MemoryRepository) GetBookingCountPerGame(ctx context.Context) ([]GameBookingCount, error) {
+	return r.countPerGame(func(booking Booking) bool { return true }), nil
+}
+
+func (r *MemoryRepository) GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]GameBookingCount, error) {
+	return r.countPerGame(func(booking Booking) bool {
+		return !booking.DateTime.Before(start) && !booking.DateTime.After(end)
+	}), nil
+}
+
+// GetEquipmentAvailability finds no item, the equipment inventory is only
+// kept in Postgres.
+func (r *MemoryRepository) GetEquipmentAvailability(ctx context.Context, ids []string, at time.Time, bookingID string) ([]EquipmentAvailability, error) {
+	return []EquipmentAvailability{}, nil
+}
+
+func (r *MemoryRepository) SetEquipment(ctx context.Context, bookingID string, reservations []Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, ok := r.bookings[bookingID]
+
+	if !ok || booking.DeletedAt != nil {
+		return ErrBookingNotFound
+	}
+
+	booking.Equipment = slices.Clone(reservations)
+	r.bookings[bookingID] = booking
+
+	return nil
+}
+
+func (r *MemoryRepository) GetFavoriteGames(ctx context.Context, userID, username string, limit int) ([]GameBookingCount, error) {
+	counts := map[string]int{}
+
+	for _, booking := range r.filter(func(booking Booking) bool {
+		organizer := len(userID) != 0 && booking.UserID == userID
+		return isHoldingTable(booking) && (organizer || slices.Contains(booking.Players, username))
+	}) {
+		counts[booking.Game]++
+	}
+
+	stats := []GameBookingCount{}
+
+	for game, count := range counts {
+		stats = append(stats, GameBookingCount{Game: game, Count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Game < stats[j].Game
+	})
+
+	return stats[:min(limit, len(stats))], nil
+}
+
+func (r *MemoryRepository) GetBookedSlots(ctx context.Context, from, to time.Time, username string) ([]BookedSlot, error) {
+	slots := []BookedSlot{}
+
+	for _, booking := range r.filter(func(booking Booking) bool {
+		return isHoldingTable(booking) && !booking.DateTime.Before(from) && booking.DateTime.Before(to)
+	}) {
+		slots = append(slots, BookedSlot{
+			DateTime: booking.DateTime,
+			Mine:     booking.Username == username || slices.Contains(booking.Players, username),
+		})
+	}
+
+	return slots, nil
+}
+
+func (r *MemoryRepository) GetBookingCountPerWeekDay(ctx context.Context) ([]WeekDayBookingCount, error) {
+	counts := map[string]int{}
+
+	for _, booking := range r.filter(isCounted) {
+		// matches the padding of Postgres' TO_CHAR(..., 'Day')
+		counts[fmt.Sprintf("%-9s", booking.DateTime.Weekday())]++
+	}
+
+	stats := []WeekDayBookingCount{}
+
+	for weekDay, count := range counts {
+		stats = append(stats, WeekDayBookingCount{WeekDay: weekDay, Count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].WeekDay < stats[j].WeekDay
+	})
+
+	return stats, nil
+}
+
+// WithTx serializes fn against other transactions and restores the previous
+// state when it fails.
+func (r *MemoryRepository) WithTx(ctx context.Context, fn func(tx BookingRepository) error) error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+
+	r.mu.RLock()
+	snapshot := maps.Clone(r.bookings)
+	nextID := r.nextID
+	r.mu.RUnlock()
+
+	if err := fn(r); err != nil {
+		r.mu.Lock()
+		r.bookings = snapshot
+		r.nextID = nextID
+		r.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) insert(booking Booking) Booking {
+	booking.ID = strconv.Itoa(r.nextID)
+	booking.Players = slices.Clone(booking.Players)
+	booking.CreatedAt = time.Now()
+	booking.UpdatedAt = booking.CreatedAt
+	r.bookings[booking.ID] = booking
+	r.nextID++
+
+	return booking
+}
+
+// filter returns copies of the bookings matching keep that are not deleted,
+// sorted by date.
+func (r *MemoryRepository) filter(keep func(booking Booking) bool) []Booking {
+	return r.filterAll(func(booking Booking) bool { return booking.DeletedAt == nil && keep(booking) })
+}
+
+// filterAll is filter including the deleted bookings.
+func (r *MemoryRepository) filterAll(keep func(booking Booking) bool) []Booking {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	bookings := []Booking{}
+
+	for _, booking := range r.bookings {
+		if keep(booking) {
+			bookings = append(bookings, cloneBooking(booking))
+		}
+	}
+
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].DateTime.Before(bookings[j].DateTime) })
+
+	return bookings
+}
+
+func (r *MemoryRepository) GetBookingCountPerTag(ctx context.Context) ([]TagBookingCount, error) {
+	counts := map[string]int{}
+
+	for _, booking := range r.filter(isCounted) {
+		for _, tag := range booking.Tags {
+			counts[tag]++
+		}
+	}
+
+	stats := []TagBookingCount{}
+
+	for tag, count := range counts {
+		stats = append(stats, TagBookingCount{Tag: tag, Count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Tag < stats[j].Tag
+	})
+
+	return stats, nil
+}
+
+func (r *MemoryRepository) GetBookingCountPerPair(ctx context.Context, limit int) ([]PairBookingCount, error) {
+	counts := map[[2]string]int{}
+
+	for _, booking := range r.filter(isCounted) {
+		members := []string{}
+
+		for _, member := range append(booking.Players, booking.Username) {
+			if len(member) != 0 && !slices.Contains(members, member) {
+				members = append(members, member)
+			}
+		}
+
+		slices.Sort(members)
+
+		for i, a := range members {
+			for _, b := range members[i+1:] {
+				counts[[2]string{a, b}]++
+			}
+		}
+	}
+
+	stats := []PairBookingCount{}
+
+	for pair, count := range counts {
+		stats = append(stats, PairBookingCount{PlayerA: pair[0], PlayerB: pair[1], Count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		if stats[i].PlayerA != stats[j].PlayerA {
+			return stats[i].PlayerA < stats[j].PlayerA
+		}
+		return stats[i].PlayerB < stats[j].PlayerB
+	})
+
+	if len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	return stats, nil
+}
+
+func (r *MemoryRepository) GetDecisions(ctx context.Context, start, end time.Time) ([]Decision, error) {
+	bookings := r.filter(func(booking Booking) bool {
+		return booking.DecidedAt != nil && !booking.CreatedAt.Before(start) && !booking.CreatedAt.After(end)
+	})
+
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].CreatedAt.Before(bookings[j].CreatedAt) })
+
+	decisions := make([]Decision, len(bookings))
+
+	for i, booking := range bookings {
+		decisions[i] = Decision{CreatedAt: booking.CreatedAt, DecidedAt: *booking.DecidedAt}
+	}
+
+	return decisions, nil
+}
+
+func (r *MemoryRepository) GetNoShowCounts(ctx context.Context, start, end time.Time) ([]NoShowCount, error) {
+	counts := map[string]*NoShowCount{}
+
+	for _, booking := range r.filter(func(booking Booking) bool {
+		return booking.Status == "accepted" && !booking.DateTime.Before(start) && !booking.DateTime.After(end)
+	}) {
+		count, ok := counts[booking.UserID]
+
+		if !ok {
+			count = &NoShowCount{UserID: booking.UserID, Username: booking.Username}
+			counts[booking.UserID] = count
+		}
+
+		count.Bookings++
+
+		if booking.CheckedInAt == nil {
+			count.NoShows++
+		}
+	}
+
+	stats := []NoShowCount{}
+
+	for _, count := range counts {
+		stats = append(stats, *count)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].NoShows != stats[j].NoShows {
+			return stats[i].NoShows > stats[j].NoShows
+		}
+		if stats[i].Bookings != stats[j].Bookings {
+			return stats[i].Bookings > stats[j].Bookings
+		}
+		return stats[i].Username < stats[j].Username
+	})
+
+	return stats, nil
+}
+
+func (r *MemoryRepository) GetOrganizerHistories(ctx context.Context, userIDs []string, before time.Time) ([]OrganizerHistory, error) {
+	histories := map[string]*OrganizerHistory{}
+
+	for _, booking := range r.filter(func(booking Booking) bool {
+		return len(booking.UserID) != 0 && slices.Contains(userIDs, booking.UserID)
+	}) {
+		history, ok := histories[booking.UserID]
+
+		if !ok {
+			history = &OrganizerHistory{UserID: booking.UserID, Username: booking.Username}
+			histories[booking.UserID] = history
+		}
+
+		switch {
+		case booking.Status == "accepted" && booking.DateTime.Before(before):
+			history.Bookings++
+
+			if booking.CheckedInAt == nil {
+				history.NoShows++
+			}
+		case booking.Status == "canceled":
+			history.Cancellations++
+		}
+	}
+
+	result := []OrganizerHistory{}
+
+	for _, userID := range userIDs {
+		if history, ok := histories[userID]; ok {
+			result = append(result, *history)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *MemoryRepository) countPerGame(keep func(booking Booking) bool) []GameBookingCount {
+	counts := map[string]int{}
+
+	for _, booking := range r.filter(func(booking Booking) bool { return isCounted(booking) && keep(booking) }) {
+		counts[booking.Game]++
+	}
+
+	stats := []GameBookingCount{}
+
+	for game, count := range counts {
+		stats = append(stats, GameBookingCount{Game: game, Count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Game < stats[j].Game
+	})
+
+	return stats
+}
+
+// isCounted mirrors the statistics queries, which only count accepted bookings.
+func isCounted(booking Booking) bool {
+	return booking.Status != "pending" && booking.Status != "canceled" && booking.Status != "refused"
+}
+
+// isHoldingTable mirrors the queries on the bookings holding a table.
+func isHoldingTable(booking Booking) bool {
+	return booking.Status == "pending" || booking.Status == "accepted"
+}
+
+func cloneBooking(booking Booking) Booking {
+	booking.Players = slices.Clone(booking.Players)
+	booking.CoOrganizers = slices.Clone(booking.CoOrganizers)
+	booking.Equipment = slices.Clone(booking.Equipment)
+	booking.Tags = slices.Clone(booking.Tags)
+	booking.CustomFields = maps.Clone(booking.CustomFields)
+
+	if booking.Reminders != nil {
+		reminders := *booking.Reminders
+		reminders.LeadTimes = slices.Clone(reminders.LeadTimes)
+		reminders.OptedOut = slices.Clone(reminders.OptedOut)
+		reminders.Responses = slices.Clone(reminders.Responses)
+		booking.Reminders = &reminders
+	}
+
+	return booking
+}
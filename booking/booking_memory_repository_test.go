@@ -0,0 +1,339 @@
+package booking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("insert and fetch", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		inserted, err := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", Username: "John.Doe", DateTime: now.Add(time.Hour), Players: []string{"jane.doe"}})
+
+		require.Nil(t, err)
+		require.Equal(t, "1", inserted.ID)
+		require.Equal(t, "pending", inserted.Status)
+
+		got, err := repo.GetBookingByID(ctx, "1")
+
+		require.Nil(t, err)
+		require.Equal(t, inserted, got)
+
+		_, err = repo.GetBookingByID(ctx, "2")
+
+		require.ErrorIs(t, err, bk.ErrBookingNotFound)
+
+		perUser, err := repo.GetBookingsPerUsername(ctx, nil, "jane.doe")
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.Booking{inserted}, perUser)
+
+		perOrganizer, err := repo.GetBookingsPerUsername(ctx, nil, "john.doe")
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.Booking{inserted}, perOrganizer)
+	})
+
+	t.Run("active bookings exclude past ones", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "old", Status: "accepted", DateTime: now.Add(-24 * time.Hour)},
+			{Game: "new", Status: "accepted", DateTime: now.Add(24 * time.Hour)},
+		})
+
+		require.Nil(t, err)
+
+		active, err := repo.GetActiveBookings(ctx)
+
+		require.Nil(t, err)
+		require.Len(t, active, 1)
+		require.Equal(t, "new", active[0].Game)
+	})
+
+	t.Run("visible bookings depend on the user", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "public", UserID: "1", Username: "john.doe", Visibility: bk.VisibilityPublic, DateTime: now.Add(time.Hour)},
+			{Game: "members", UserID: "1", Username: "john.doe", Visibility: bk.VisibilityMembers, DateTime: now.Add(time.Hour)},
+			{Game: "private", UserID: "1", Username: "john.doe", Visibility: bk.VisibilityPrivate, DateTime: now.Add(time.Hour), Players: []string{"jane.doe"}},
+		})
+
+		require.Nil(t, err)
+
+		games := func(user *discord.DiscordUser) []string {
+			bookings, err := repo.GetVisibleBookings(ctx, user)
+			require.Nil(t, err)
+
+			names := []string{}
+			for _, booking := range bookings {
+				names = append(names, booking.Game)
+			}
+
+			return names
+		}
+
+		require.ElementsMatch(t, []string{"public"}, games(nil))
+		require.ElementsMatch(t, []string{"public", "members"}, games(&discord.DiscordUser{ID: "2", Username: "bob"}))
+		require.ElementsMatch(t, []string{"public", "members", "private"}, games(&discord.DiscordUser{ID: "3", Username: "Jane.Doe"}))
+		require.ElementsMatch(t, []string{"public", "members", "private"}, games(&discord.DiscordUser{ID: "1", Username: "john.doe"}))
+		require.ElementsMatch(t, []string{"public", "members", "private"}, games(&discord.DiscordUser{ID: "4", Username: "admin", Admin: true}))
+	})
+
+	t.Run("count with a filter", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "pending", Username: "john.doe", Status: "pending", DateTime: now.Add(time.Hour)},
+			{Game: "played", Username: "bob", Status: "pending", DateTime: now.Add(time.Hour), Players: []string{"john.doe"}},
+			{Game: "accepted", Username: "bob", UserID: "7", Status: "accepted", DateTime: now.Add(time.Hour), CoOrganizers: []string{"jane.doe"}},
+			{Game: "past", Username: "john.doe", Status: "pending", DateTime: now.Add(-24 * time.Hour)},
+			{Game: "private", Username: "bob", Status: "pending", Visibility: bk.VisibilityPrivate, DateTime: now.Add(time.Hour)},
+		})
+
+		require.Nil(t, err)
+
+		count := func(filter bk.Filter) int {
+			count, err := repo.CountBookings(ctx, &discord.DiscordUser{ID: "1", Username: "john.doe"}, filter)
+			require.Nil(t, err)
+
+			return count
+		}
+
+		require.Equal(t, 3, count(bk.Filter{}))
+		require.Equal(t, 2, count(bk.Filter{Status: "pending"}))
+		require.Equal(t, 2, count(bk.Filter{Player: "john.doe"}))
+		require.Equal(t, 0, count(bk.Filter{Status: "accepted", Player: "john.doe"}))
+		require.Equal(t, 1, count(bk.Filter{Game: "ACCEPTED"}))
+		require.Equal(t, 1, count(bk.Filter{Player: "7"}))
+		require.Equal(t, 1, count(bk.Filter{Player: "jane.doe"}))
+		require.Equal(t, 1, count(bk.Filter{From: now.Add(-48 * time.Hour), To: now}))
+		require.Equal(t, 3, count(bk.Filter{To: now.Add(2 * time.Hour)}))
+	})
+
+	t.Run("counts only accepted bookings", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "Warhammer", Status: "accepted", DateTime: now},
+			{Game: "Warhammer", Status: "accepted", DateTime: now},
+			{Game: "Blood Bowl", Status: "accepted", DateTime: now},
+			{Game: "Blood Bowl", Status: "refused", DateTime: now},
+		})
+
+		require.Nil(t, err)
+
+		stats, err := repo.GetBookingCountPerGame(ctx)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.GameBookingCount{{Game: "Warhammer", Count: 2}, {Game: "Blood Bowl", Count: 1}}, stats)
+	})
+
+	t.Run("failed transaction is rolled back", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		inserted, err := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", DateTime: now})
+
+		require.Nil(t, err)
+
+		err = repo.WithTx(ctx, func(tx bk.BookingRepository) error {
+			if err := tx.SetBookingStatus(ctx, inserted.ID, "accepted"); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		})
+
+		require.Error(t, err)
+
+		got, err := repo.GetBookingByID(ctx, inserted.ID)
+
+		require.Nil(t, err)
+		require.Equal(t, "pending", got.Status)
+	})
+
+	t.Run("history pages by cursor", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "a", DateTime: now.Add(-2 * time.Hour)},
+			{Game: "b", DateTime: now},
+			{Game: "c", DateTime: now},
+		})
+
+		require.Nil(t, err)
+
+		first, err := repo.GetBookingHistory(ctx, nil, bk.Filter{}, nil, 2)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"c", "b"}, []string{first[0].Game, first[1].Game})
+
+		cursor, err := bk.CursorAfter(first[1])
+
+		require.Nil(t, err)
+
+		second, err := repo.GetBookingHistory(ctx, nil, bk.Filter{}, &cursor, 2)
+
+		require.Nil(t, err)
+		require.Len(t, second, 1)
+		require.Equal(t, "a", second[0].Game)
+	})
+
+	t.Run("no-show counts", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		for _, username := range []string{"john.doe", "john.doe", "jane.doe"} {
+			inserted, _ := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", UserID: username + "ID", Username: username, DateTime: now.Add(-24 * time.Hour)})
+			require.Nil(t, repo.SetBookingStatus(ctx, inserted.ID, "accepted"))
+		}
+
+		require.Nil(t, repo.SetCheckedIn(ctx, "1", now.Add(-25*time.Hour)))
+
+		stats, err := repo.GetNoShowCounts(ctx, now.Add(-48*time.Hour), now)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.NoShowCount{
+			{UserID: "john.doeID", Username: "john.doe", Bookings: 2, NoShows: 1},
+			{UserID: "jane.doeID", Username: "jane.doe", Bookings: 1, NoShows: 1},
+		}, stats)
+	})
+
+	t.Run("pair counts", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "a", Username: "john.doe", Players: []string{"john.doe", "jane.doe"}, Status: "accepted", DateTime: now},
+			{Game: "b", Username: "jane.doe", Players: []string{"john.doe", "bob"}, Status: "accepted", DateTime: now},
+			{Game: "c", Username: "bob", Players: []string{"john.doe"}, Status: "canceled", DateTime: now},
+		})
+
+		require.Nil(t, err)
+
+		stats, err := repo.GetBookingCountPerPair(ctx, 2)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.PairBookingCount{
+			{PlayerA: "jane.doe", PlayerB: "john.doe", Count: 2},
+			{PlayerA: "bob", PlayerB: "jane.doe", Count: 1},
+		}, stats)
+	})
+
+	t.Run("decisions", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		accepted, _ := repo.InsertBooking(ctx, bk.Booking{Game: "accepted", DateTime: now})
+		pending, _ := repo.InsertBooking(ctx, bk.Booking{Game: "pending", DateTime: now})
+
+		require.Nil(t, repo.SetBookingStatus(ctx, accepted.ID, "accepted"))
+
+		decided, _ := repo.GetBookingByID(ctx, accepted.ID)
+		require.NotNil(t, decided.DecidedAt)
+
+		require.Nil(t, repo.SetBookingStatus(ctx, accepted.ID, "canceled"))
+
+		canceled, _ := repo.GetBookingByID(ctx, accepted.ID)
+		require.Equal(t, decided.DecidedAt, canceled.DecidedAt)
+
+		decisions, err := repo.GetDecisions(ctx, now.Add(-time.Hour), time.Now())
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.Decision{{CreatedAt: decided.CreatedAt, DecidedAt: *decided.DecidedAt}}, decisions)
+
+		undecided, _ := repo.GetBookingByID(ctx, pending.ID)
+		require.Nil(t, undecided.DecidedAt)
+	})
+
+	t.Run("organizer histories", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+		checkedIn := now.Add(-25 * time.Hour)
+
+		err := repo.InsertManyBookings(ctx, []bk.Booking{
+			{Game: "played", UserID: "1", Username: "john.doe", Status: "accepted", DateTime: now.Add(-24 * time.Hour), CheckedInAt: &checkedIn},
+			{Game: "no-show", UserID: "1", Username: "john.doe", Status: "accepted", DateTime: now.Add(-48 * time.Hour)},
+			{Game: "upcoming", UserID: "1", Username: "john.doe", Status: "accepted", DateTime: now.Add(24 * time.Hour)},
+			{Game: "canceled", UserID: "1", Username: "johnny", Status: "canceled", DateTime: now.Add(24 * time.Hour)},
+			{Game: "other", UserID: "2", Username: "jane.doe", Status: "canceled", DateTime: now},
+		})
+
+		require.Nil(t, err)
+
+		histories, err := repo.GetOrganizerHistories(ctx, []string{"1", "3"}, now)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.OrganizerHistory{{UserID: "1", Username: "john.doe", Bookings: 2, NoShows: 1, Cancellations: 1}}, histories)
+	})
+
+	t.Run("side records update the booking", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+		inserted, _ := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", Username: "john.doe", DateTime: now.Add(time.Hour)})
+
+		require.Nil(t, repo.SetNotificationError(ctx, inserted.ID, "discord down"))
+		require.Nil(t, repo.SetCalendarSync(ctx, inserted.ID, bk.CalendarSync{EventID: "event"}))
+		require.Nil(t, repo.SetVoiceChannel(ctx, inserted.ID, "channel"))
+
+		got, _ := repo.GetBookingByID(ctx, inserted.ID)
+
+		require.True(t, got.UpdatedAt.After(inserted.UpdatedAt))
+	})
+
+	t.Run("reminders keep the opt-outs", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+		inserted, _ := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", Username: "john.doe", DateTime: now.Add(time.Hour)})
+
+		require.Nil(t, repo.SetReminderOptOut(ctx, inserted.ID, "jane.doe", true))
+		require.Nil(t, repo.SetReminders(ctx, inserted.ID, bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderChannel}))
+
+		got, _ := repo.GetBookingByID(ctx, inserted.ID)
+
+		require.Equal(t, &bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderChannel, OptedOut: []string{"jane.doe"}, Responses: []bk.ReminderResponse{}}, got.Reminders)
+
+		require.Nil(t, repo.SetReminderOptOut(ctx, inserted.ID, "jane.doe", false))
+		got, _ = repo.GetBookingByID(ctx, inserted.ID)
+
+		require.Empty(t, got.Reminders.OptedOut)
+		require.ErrorIs(t, repo.SetReminders(ctx, "42", bk.Reminders{}), bk.ErrBookingNotFound)
+	})
+
+	t.Run("reminder responses", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+		inserted, _ := repo.InsertBooking(ctx, bk.Booking{Game: "Warhammer", Username: "john.doe", DateTime: now.Add(time.Hour)})
+		snoozedUntil := now.Add(30 * time.Minute)
+
+		require.Nil(t, repo.SetReminderResponse(ctx, inserted.ID, bk.ReminderResponse{Username: "jane.doe", SnoozedUntil: &snoozedUntil, RespondedAt: now}))
+
+		cleared, _ := repo.ClearSnooze(ctx, inserted.ID, "jane.doe", snoozedUntil)
+		require.True(t, cleared)
+
+		cleared, _ = repo.ClearSnooze(ctx, inserted.ID, "jane.doe", snoozedUntil)
+		require.False(t, cleared)
+
+		require.Nil(t, repo.SetReminderResponse(ctx, inserted.ID, bk.ReminderResponse{Username: "jane.doe", Acknowledged: true, RespondedAt: now}))
+
+		got, _ := repo.GetBookingByID(ctx, inserted.ID)
+
+		require.Equal(t, []bk.ReminderResponse{{Username: "jane.doe", Acknowledged: true, RespondedAt: now}}, got.Reminders.Responses)
+	})
+
+	t.Run("reminders are marked sent once per date", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		marked, _ := repo.MarkReminderSent(ctx, "1", 120, now)
+		require.True(t, marked)
+
+		marked, _ = repo.MarkReminderSent(ctx, "1", 120, now)
+		require.False(t, marked)
+
+		marked, _ = repo.MarkReminderSent(ctx, "1", 120, now.Add(time.Hour))
+		require.True(t, marked)
+	})
+}
@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// eventChannel is the Postgres NOTIFY channel booking events are shared on.
+const eventChannel = "booking_events"
+
+// eventPayload is the NOTIFY payload. It only carries the booking id, as
+// payloads are limited to 8000 bytes, listeners load the booking themselves.
+type eventPayload struct {
+	Origin     string    `json:"origin"`
+	Type       string    `json:"type"`
+	BookingID  string    `json:"bookingId"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// Notifier shares booking events between backend replicas through Postgres
+// LISTEN/NOTIFY. As an EventPublisher it broadcasts local events, and Listen
+// feeds the events of the other replicas to local publishers.
+type Notifier struct {
+	conn       *pgxpool.Pool
+	repo       BookingRepository
+	instanceID string
+	logger     *slog.Logger
+}
+
+func NewNotifier(conn *pgxpool.Pool, repo BookingRepository) *Notifier {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return &Notifier{
+		conn:       conn,
+		repo:       repo,
+		instanceID: hex.EncodeToString(id),
+		logger:     slog.Default().With("component", "booking-notifier"),
+	}
+}
+
+func (n *Notifier) Publish(ctx context.Context, event Event) {
+	payload, err := json.Marshal(eventPayload{
+		Origin:     n.instanceID,
+		Type:       event.Type,
+		BookingID:  event.Booking.ID,
+		OccurredAt: event.OccurredAt,
+	})
+
+	if err != nil {
+		n.logger.Error("failed to marshal notification", "err", err)
+		return
+	}
+
+	if _, err := n.conn.Exec(ctx, `SELECT pg_notify($1, $2)`, eventChannel, string(payload)); err != nil {
+		n.logger.Error("failed to notify booking event", "type", event.Type, "bookingId", event.Booking.ID, "err", err)
+	}
+}
+
+// Listen forwards the events published by other replicas to publishers until
+// ctx is done, reconnecting when the connection is lost. Only pass publishers
+// serving local clients: side effects such as webhooks already ran on the
+// replica the event originates from.
+func (n *Notifier) Listen(ctx context.Context, publishers ...EventPublisher) error {
+	for {
+		err := n.listen(ctx, publishers)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		n.logger.Error("booking event listener disconnected, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (n *Notifier) listen(ctx context.Context, publishers []EventPublisher) error {
+	pooled, err := n.conn.Acquire(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// the connection stays subscribed, so it must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, `LISTEN `+eventChannel); err != nil {
+		return fmt.Errorf("failed to listen to %v: %w", eventChannel, err)
+	}
+
+	n.logger.Info("listening to booking events", "instanceId", n.instanceID)
+
+	for {
+		received, err := conn.WaitForNotification(ctx)
+
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		var payload eventPayload
+
+		if err := json.Unmarshal([]byte(received.Payload), &payload); err != nil {
+			n.logger.Error("invalid booking notification", "payload", received.Payload, "err", err)
+			continue
+		}
+
+		if payload.Origin == n.instanceID {
+			continue
+		}
+
+		booking, err := n.repo.GetBookingByID(ctx, payload.BookingID)
+
+		if err != nil {
+			n.logger.Error("failed to load notified booking", "bookingId", payload.BookingID, "err", err)
+			continue
+		}
+
+		event := Event{Type: payload.Type, Booking: booking, OccurredAt: payload.OccurredAt}
+
+		for _, publisher := range publishers {
+			publisher.Publish(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Payment states of the bookings charged a table fee, empty for those that
+// are not.
+const (
+	PaymentUnpaid = "unpaid"
+	PaymentPaid   = "paid"
+	// PaymentWaived is set by an admin letting the organizer off the fee.
+	PaymentWaived = "waived"
+)
+
+// Payment is the checkout of the table fee of a booking, paid on the page at
+// URL.
+type Payment struct {
+	BookingID string `json:"bookingId"`
+	SessionID string `json:"sessionId"`
+	URL       string `json:"url"`
+	// Amount is in the smallest unit of Currency, cents for euros.
+	Amount    int64      `json:"amount"`
+	Currency  string     `json:"currency"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	PaidAt    *time.Time `json:"paidAt,omitempty"`
+}
+
+// CheckoutRequest is the table fee of a booking to collect.
+type CheckoutRequest struct {
+	BookingID   string
+	Description string
+	Amount      int64
+	Currency    string
+}
+
+// CheckoutSession is the payment page of a CheckoutRequest.
+type CheckoutSession struct {
+	ID  string
+	URL string
+}
+
+// PaymentConfirmation is a payment the provider notified through its
+// webhook.
+type PaymentConfirmation struct {
+	BookingID string
+	SessionID string
+}
+
+// PaymentProvider collects the table fees, Stripe Checkout in production.
+type PaymentProvider interface {
+	CreateCheckout(ctx context.Context, request CheckoutRequest) (CheckoutSession, error)
+	// ParseWebhook authenticates a webhook call, ok being false for the
+	// events other than a completed payment.
+	ParseWebhook(payload []byte, signature string) (confirmation PaymentConfirmation, ok bool, err error)
+}
+
+// PaymentConfig is the table fee charged for the accepted bookings of
+// non-members.
+type PaymentConfig struct {
+	Fee      int64
+	Currency string
+}
+
+// WithPayments charges the table fee of config through provider when a
+// booking is accepted. Without a MemberChecker, every organizer is charged.
+func WithPayments(provider PaymentProvider, config PaymentConfig) ServiceOption {
+	return func(s *Service) {
+		s.payments = provider
+		s.paymentConfig = config
+	}
+}
+
+// requestPayment charges the table fee of an accepted booking off the
+// request path, the organizer sent the payment link in a direct message.
+func (s *Service) requestPayment(ctx context.Context, booking Booking) {
+	if s.payments == nil || len(booking.PaymentStatus) != 0 || len(booking.UserID) == 0 {
+		return
+	}
+
+	s.dispatcher.Dispatch(ctx, func(ctx context.Context) {
+		if err := s.chargeTableFee(ctx, booking); err != nil {
+			slog.Error("Failed to request the table fee", "booking", booking.ID, "error", err)
+		}
+	})
+}
+
+func (s *Service) chargeTableFee(ctx context.Context, booking Booking) error {
+	ctx, span := tracer.Start(ctx, "booking.charge_table_fee", trace.WithAttributes(attribute.String("booking.id", booking.ID)))
+	defer span.End()
+
+	if s.membership != nil {
+		member, err := s.membership.IsMember(ctx, booking.UserID)
+
+		if err != nil {
+			recordError(span, err)
+			return fmt.Errorf("failed to check membership: %w", err)
+		}
+
+		if member {
+			return nil
+		}
+	}
+
+	session, err := s.payments.CreateCheckout(ctx, CheckoutRequest{
+		BookingID:   booking.ID,
+		Description: fmt.Sprintf("Table %v du %v", booking.Game, booking.DateTime.In(s.location).Format("02/01/2006 15:04")),
+		Amount:      s.paymentConfig.Fee,
+		Currency:    s.paymentConfig.Currency,
+	})
+
+	if err != nil {
+		recordError(span, err)
+		return err
+	}
+
+	payment := Payment{
+		BookingID: booking.ID,
+		SessionID: session.ID,
+		URL:       session.URL,
+		Amount:    s.paymentConfig.Fee,
+		Currency:  s.paymentConfig.Currency,
+		Status:    PaymentUnpaid,
+	}
+
+	if _, err := s.repo.InsertPayment(ctx, payment); err != nil {
+		recordError(span, err)
+		return err
+	}
+
+	booking, err = s.updatePaymentStatus(ctx, booking.ID, PaymentUnpaid)
+
+	if err != nil {
+		recordError(span, err)
+		return err
+	}
+
+	channelID, err := s.client.GetDMChannel(ctx, booking.UserID)
+
+	if err != nil {
+		recordError(span, err)
+		return fmt.Errorf("failed to open DM channel: %w", err)
+	}
+
+	return s.messages.SendMessage(ctx, channelID, discord.Message{
+		Content: fmt.Sprintf("Ta réservation de %v est acceptée ! Les frais de table de %v sont à régler ici : %v",
+			booking.Game, formatAmount(payment.Amount, payment.Currency), payment.URL),
+	})
+}
+
+// ConfirmPayment marks paid the booking of a payment notified by the
+// provider's webhook.
+func (s *Service) ConfirmPayment(ctx context.Context, payload []byte, signature string) error {
+	if s.payments == nil {
+		return ErrPaymentsDisabled
+	}
+
+	confirmation, ok, err := s.payments.ParseWebhook(payload, signature)
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPaymentEvent, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_, err = s.updatePaymentStatus(ctx, confirmation.BookingID, PaymentPaid)
+
+	// the booking may have been purged since
+	if errors.Is(err, ErrBookingNotFound) {
+		slog.Warn("Payment confirmed for a missing booking", "booking", confirmation.BookingID, "session", confirmation.SessionID)
+		return nil
+	}
+
+	return err
+}
+
+// SetPaymentStatus overrides the payment state of a booking, for the admins
+// to record a payment in cash or waive the fee.
+func (s *Service) SetPaymentStatus(ctx context.Context, id, status string) (Booking, error) {
+	if status != PaymentUnpaid && status != PaymentPaid && status != PaymentWaived {
+		return Booking{}, fmt.Errorf("%w: '%v'", ErrInvalidPaymentStatus, status)
+	}
+
+	return s.updatePaymentStatus(ctx, id, status)
+}
+
+// GetPayment returns the checkout of the table fee of a booking, for its
+// participants and the admins.
+func (s *Service) GetPayment(ctx context.Context, id string, user discord.DiscordUser) (Payment, error) {
+	booking, err := s.visibleBooking(ctx, id, &user)
+
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if !participates(booking, user) {
+		return Payment{}, ErrNotAllowed
+	}
+
+	return s.repo.GetPayment(ctx, booking.ID)
+}
+
+func (s *Service) updatePaymentStatus(ctx context.Context, id, status string) (Booking, error) {
+	if err := s.repo.SetPaymentStatus(ctx, id, status); err != nil {
+		return Booking{}, err
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.record(ctx, "booking.payment", id, map[string]any{"status": status})
+	s.publish(ctx, EventBookingModified, booking)
+
+	return booking, nil
+}
+
+// formatAmount writes an amount in cents with its currency, such as 5.00 EUR.
+func formatAmount(amount int64, currency string) string {
+	return fmt.Sprintf("%d.%02d %v", amount/100, amount%100, strings.ToUpper(currency))
+}
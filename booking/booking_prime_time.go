@@ -0,0 +1,98 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// PrimeTime is a weekly slot reserved to the members of the club in good
+// standing, Start and End being times of day in the guild's time zone.
+type PrimeTime struct {
+	Day   time.Weekday
+	Start time.Duration
+	End   time.Duration
+}
+
+// contains tells whether a booking starting at t, in the guild's time zone,
+// falls within the slot.
+func (p PrimeTime) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+
+	return t.Weekday() == p.Day && offset >= p.Start && offset < p.End
+}
+
+// MemberChecker looks the users up in the club's registry: members are not
+// charged the table fee and, with the guests the admins whitelisted, are the
+// only ones booking the prime-time slots.
+type MemberChecker interface {
+	// IsMember tells whether the user is a member in good standing.
+	IsMember(ctx context.Context, userID string) (bool, error)
+	IsGuest(ctx context.Context, userID string) (bool, error)
+}
+
+func WithMemberChecker(checker MemberChecker) ServiceOption {
+	return func(s *Service) {
+		s.membership = checker
+	}
+}
+
+// WithPrimeTime reserves the slots to members and whitelisted guests, only
+// enforced with a MemberChecker.
+func WithPrimeTime(slots []PrimeTime) ServiceOption {
+	return func(s *Service) {
+		s.primeTime = slots
+	}
+}
+
+// checkPrimeTime fails with ErrMembershipRequired when a booking in a
+// prime-time slot is organized by neither a member in good standing nor a
+// whitelisted guest, the authenticated user being checked rather than the
+// organizer the booking claims. Admins book any slot.
+func (s *Service) checkPrimeTime(ctx context.Context, booking Booking) error {
+	if s.membership == nil || !s.isPrimeTime(booking.DateTime) {
+		return nil
+	}
+
+	if user, ok := discord.UserFromContext(ctx); ok && user.Admin {
+		return nil
+	}
+
+	userID := organizerID(ctx, booking)
+	member, err := s.membership.IsMember(ctx, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	if member {
+		return nil
+	}
+
+	guest, err := s.membership.IsGuest(ctx, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+
+	if !guest {
+		return fmt.Errorf("%w: ask an admin to renew your membership or invite you", ErrMembershipRequired)
+	}
+
+	return nil
+}
+
+func (s *Service) isPrimeTime(at time.Time) bool {
+	at = at.In(s.location)
+
+	for _, slot := range s.primeTime {
+		if slot.contains(at) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,219 @@
+package booking
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Priorities of the bookings, from the lowest. Tournaments come first, their
+// date being set for every player beforehand, then league games.
+const (
+	PriorityNormal     = "normal"
+	PriorityLeague     = "league"
+	PriorityTournament = "tournament"
+)
+
+var priorityRanks = map[string]int{PriorityNormal: 0, PriorityLeague: 1, PriorityTournament: 2}
+
+func priorityOrNormal(priority string) string {
+	if len(priority) == 0 {
+		return PriorityNormal
+	}
+
+	return priority
+}
+
+// priorityRank orders the priorities, unknown ones ranking as normal.
+func priorityRank(priority string) int {
+	return priorityRanks[priority]
+}
+
+// SetPriority changes the priority of a booking, for the admins.
+func (s *Service) SetPriority(ctx context.Context, id, priority string) (Booking, error) {
+	if _, ok := priorityRanks[priority]; !ok {
+		return Booking{}, fmt.Errorf("%w: '%v'", ErrInvalidPriority, priority)
+	}
+
+	if err := s.repo.SetPriority(ctx, id, priority); err != nil {
+		return Booking{}, err
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.record(ctx, "booking.priority", id, map[string]any{"priority": priority})
+	s.publish(ctx, EventBookingModified, booking)
+
+	return booking, nil
+}
+
+// QueueEntry is a booking awaiting approval, with what the admins weigh to
+// decide on it.
+type QueueEntry struct {
+	Booking
+	// Conflicts are the other pending or accepted bookings at the same time
+	Conflicts []Conflict `json:"conflicts"`
+	// TablesFull tells the accepted bookings at the same time already take
+	// every table of the club
+	TablesFull bool `json:"tablesFull"`
+	// Requester is the history of the organizer
+	Requester OrganizerHistory `json:"requester"`
+}
+
+// Conflict is a booking held at the same time as a booking of the queue.
+type Conflict struct {
+	ID       string    `json:"id"`
+	Game     string    `json:"game"`
+	Status   string    `json:"status"`
+	DateTime time.Time `json:"dateTime"`
+	// Players are the members playing both bookings, organizers included
+	Players []string `json:"players,omitempty"`
+}
+
+// GetPendingQueue returns the upcoming bookings awaiting approval, the highest
+// priority first then the oldest request, with their conflicts and the
+// history of their organizers.
+func (s *Service) GetPendingQueue(ctx context.Context) ([]QueueEntry, error) {
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	queue := slices.DeleteFunc(slices.Clone(bookings), func(booking Booking) bool {
+		return booking.Status != "pending" || !booking.DateTime.After(now)
+	})
+
+	slices.SortStableFunc(queue, comparePriority)
+
+	userIDs := []string{}
+
+	for _, booking := range queue {
+		if !slices.Contains(userIDs, booking.UserID) {
+			userIDs = append(userIDs, booking.UserID)
+		}
+	}
+
+	histories := map[string]OrganizerHistory{}
+
+	if len(userIDs) != 0 {
+		found, err := s.repo.GetOrganizerHistories(ctx, userIDs, now)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, history := range found {
+			histories[history.UserID] = history
+		}
+	}
+
+	entries := make([]QueueEntry, 0, len(queue))
+
+	for _, booking := range queue {
+		entry := QueueEntry{Booking: booking, Conflicts: []Conflict{}, Requester: histories[booking.UserID]}
+		entry.Requester.UserID, entry.Requester.Username = booking.UserID, booking.Username
+		accepted := 0
+
+		for _, other := range bookings {
+			held := other.Status == "pending" || other.Status == "accepted"
+
+			if !held || other.ID == booking.ID || !other.DateTime.After(booking.DateTime.Add(-TableDuration)) || !other.DateTime.Before(booking.DateTime.Add(TableDuration)) {
+				continue
+			}
+
+			if other.Status == "accepted" {
+				accepted++
+			}
+
+			entry.Conflicts = append(entry.Conflicts, Conflict{
+				ID:       other.ID,
+				Game:     other.Game,
+				Status:   other.Status,
+				DateTime: other.DateTime,
+				Players:  sharedMembers(booking, other),
+			})
+		}
+
+		entry.TablesFull = s.tables != 0 && accepted >= s.tables
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// sharedMembers returns the members taking part in both bookings, as
+// organizer or player.
+func sharedMembers(a, b Booking) []string {
+	var shared []string
+
+	for _, member := range append([]string{a.Username}, a.Players...) {
+		if len(member) != 0 && (member == b.Username || slices.Contains(b.Players, member)) && !slices.Contains(shared, member) {
+			shared = append(shared, member)
+		}
+	}
+
+	return shared
+}
+
+func comparePriority(a, b Booking) int {
+	return cmp.Or(
+		cmp.Compare(priorityRank(b.Priority), priorityRank(a.Priority)),
+		a.CreatedAt.Compare(b.CreatedAt),
+	)
+}
+
+// checkPriority fails with ErrPriorityConflict when accepting the booking
+// would take a table that pending bookings of a higher priority need at the
+// same time. Without sessions the tables are not counted.
+func (s *Service) checkPriority(ctx context.Context, booking Booking) error {
+	if s.tables == 0 {
+		return nil
+	}
+
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	accepted, contenders := 0, 0
+
+	for _, other := range bookings {
+		if other.ID == booking.ID || !other.DateTime.After(booking.DateTime.Add(-TableDuration)) || !other.DateTime.Before(booking.DateTime.Add(TableDuration)) {
+			continue
+		}
+
+		switch {
+		case other.Status == "accepted":
+			accepted++
+		case other.Status == "pending" && priorityRank(other.Priority) > priorityRank(booking.Priority):
+			contenders++
+		}
+	}
+
+	if contenders != 0 && s.tables-accepted <= contenders {
+		return fmt.Errorf("%w: %d pending bookings of a higher priority need the %d tables left", ErrPriorityConflict, contenders, max(s.tables-accepted, 0))
+	}
+
+	return nil
+}
+
+// priorityFor keeps the priority requested for a new booking when an admin
+// creates it, normal otherwise.
+func priorityFor(ctx context.Context, priority string) string {
+	if user, ok := discord.UserFromContext(ctx); ok && user.Admin {
+		return priorityOrNormal(priority)
+	}
+
+	return PriorityNormal
+}
@@ -0,0 +1,449 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Where the reminders of a booking are sent: by direct message to every
+// participant, or in the booking channel mentioning them.
+const (
+	ReminderDM      = "dm"
+	ReminderChannel = "channel"
+)
+
+// Custom ids of the buttons of the reminders, followed by the booking id.
+const (
+	AcknowledgeButtonPrefix = "reminder:acknowledge:"
+	SnoozeButtonPrefix      = "reminder:snooze:"
+)
+
+// DefaultSnooze is how long the snooze button of the reminders postpones
+// them.
+const DefaultSnooze = time.Hour
+
+// Bounds of the lead times of the reminders, in minutes.
+const (
+	minLeadTime  = 5
+	maxLeadTime  = 7 * 24 * 60
+	maxLeadTimes = 5
+)
+
+// Reminders tell when the reminders of a booking are sent before it starts
+// and where. ReminderEnabled still switches them on and off.
+type Reminders struct {
+	// LeadTimes are how many minutes before the booking a reminder is sent,
+	// the longest first. The booking is reminded on its day when empty.
+	LeadTimes []int  `json:"leadTimes"`
+	Channel   string `json:"channel"`
+	// OptedOut are the usernames of the participants who do not want to be
+	// reminded of the booking.
+	OptedOut []string `json:"optedOut"`
+	// Responses are how the participants answered the reminders, by
+	// username.
+	Responses []ReminderResponse `json:"responses"`
+}
+
+// ReminderResponse is the answer of a participant to the reminders of a
+// booking: confirming they attend, which stops the reminders, or postponing
+// them until SnoozedUntil.
+type ReminderResponse struct {
+	Username     string     `json:"username"`
+	Acknowledged bool       `json:"acknowledged"`
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+	RespondedAt  time.Time  `json:"respondedAt"`
+}
+
+// RemindsOf reports whether the participant with the username is reminded
+// of the booking at now: neither opted out, nor acknowledged or snoozed.
+func (r *Reminders) RemindsOf(username string, now time.Time) bool {
+	if r == nil {
+		return true
+	}
+
+	username = strings.ToLower(username)
+
+	if slices.Contains(r.OptedOut, username) {
+		return false
+	}
+
+	for _, response := range r.Responses {
+		if response.Username == username && (response.Acknowledged || (response.SnoozedUntil != nil && response.SnoozedUntil.After(now))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dueSnoozes returns the responses of the participants whose snooze ended
+// at now.
+func (r *Reminders) dueSnoozes(now time.Time) []ReminderResponse {
+	var due []ReminderResponse
+
+	if r == nil {
+		return due
+	}
+
+	for _, response := range r.Responses {
+		if !response.Acknowledged && response.SnoozedUntil != nil && !response.SnoozedUntil.After(now) && !slices.Contains(r.OptedOut, response.Username) {
+			due = append(due, response)
+		}
+	}
+
+	return due
+}
+
+// hasLeadTimes reports whether the reminders are sent ahead of the booking
+// rather than on its day.
+func (r *Reminders) hasLeadTimes() bool {
+	return r != nil && len(r.LeadTimes) != 0
+}
+
+// dueLeadTime returns the shortest lead time reached at now, zero when none
+// is. The longer ones reached with it are skipped, the booking being set up
+// too late for them or already reminded.
+func (r *Reminders) dueLeadTime(start, now time.Time) int {
+	due := 0
+
+	if r == nil || !now.Before(start) {
+		return due
+	}
+
+	for _, lead := range r.LeadTimes {
+		if !now.Before(start.Add(-time.Duration(lead)*time.Minute)) && (due == 0 || lead < due) {
+			due = lead
+		}
+	}
+
+	return due
+}
+
+// normalize sorts the lead times, the longest first, and defaults the channel
+// to direct messages.
+func (r Reminders) normalize() (Reminders, error) {
+	leadTimes := slices.Clone(r.LeadTimes)
+	slices.Sort(leadTimes)
+	leadTimes = slices.Compact(leadTimes)
+	slices.Reverse(leadTimes)
+
+	if len(leadTimes) > maxLeadTimes {
+		return Reminders{}, fmt.Errorf("%w: at most %d lead times", ErrInvalidReminders, maxLeadTimes)
+	}
+
+	for _, lead := range leadTimes {
+		if lead < minLeadTime || lead > maxLeadTime {
+			return Reminders{}, fmt.Errorf("%w: lead times range from %d to %d minutes", ErrInvalidReminders, minLeadTime, maxLeadTime)
+		}
+	}
+
+	switch r.Channel {
+	case "":
+		r.Channel = ReminderDM
+	case ReminderDM, ReminderChannel:
+	default:
+		return Reminders{}, fmt.Errorf("%w: unknown channel '%v'", ErrInvalidReminders, r.Channel)
+	}
+
+	if leadTimes == nil {
+		leadTimes = []int{}
+	}
+
+	r.LeadTimes = leadTimes
+
+	return r, nil
+}
+
+// SetReminders changes when the reminders of a booking are sent and where,
+// for its owner, co-organizers and the admins. The participants who opted out
+// stay so.
+func (s *Service) SetReminders(ctx context.Context, id string, reminders Reminders, user discord.DiscordUser) (Booking, error) {
+	reminders, err := reminders.normalize()
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	if !user.Admin && !checkUserAllowed(booking, user) {
+		return Booking{}, ErrNotAllowed
+	}
+
+	if err := s.repo.SetReminders(ctx, id, reminders); err != nil {
+		return Booking{}, err
+	}
+
+	booking, err = s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.record(ctx, "booking.reminders", id, map[string]any{"leadTimes": reminders.LeadTimes, "channel": reminders.Channel})
+	s.publish(ctx, EventBookingModified, booking)
+
+	return booking, nil
+}
+
+// OptOutOfReminders stops, or resumes when optOut is false, the reminders of
+// a booking for the user, its owner or one of its players.
+func (s *Service) OptOutOfReminders(ctx context.Context, id string, optOut bool, user discord.DiscordUser) (Booking, error) {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	username := strings.ToLower(user.Username)
+
+	if booking.UserID != user.ID && !slices.Contains(booking.Players, username) {
+		return Booking{}, fmt.Errorf("%w: '%v'", ErrPlayerNotFound, username)
+	}
+
+	if err := s.repo.SetReminderOptOut(ctx, id, username, optOut); err != nil {
+		return Booking{}, err
+	}
+
+	s.invalidate()
+
+	return s.repo.GetBookingByID(ctx, id)
+}
+
+// WithReminderButtons adds to the reminders the buttons acknowledging and
+// snoozing them, for a server receiving the interactions of Discord.
+func WithReminderButtons() ServiceOption {
+	return func(s *Service) {
+		s.reminderButtons = true
+	}
+}
+
+// AcknowledgeReminder records that the user, the owner or a player of the
+// booking, attends it. They are not reminded of it anymore.
+func (s *Service) AcknowledgeReminder(ctx context.Context, id string, user discord.DiscordUser) (Booking, error) {
+	return s.respondToReminder(ctx, id, user, func(booking Booking, response *ReminderResponse) error {
+		response.Acknowledged = true
+		return nil
+	})
+}
+
+// SnoozeReminder postpones the reminders of the booking for the user, its
+// owner or one of its players, to shortly before it starts at the latest.
+func (s *Service) SnoozeReminder(ctx context.Context, id string, snooze time.Duration, user discord.DiscordUser) (Booking, error) {
+	return s.respondToReminder(ctx, id, user, func(booking Booking, response *ReminderResponse) error {
+		until := response.RespondedAt.Add(snooze)
+
+		if latest := booking.DateTime.Add(-minLeadTime * time.Minute); until.After(latest) {
+			until = latest
+		}
+
+		if !until.After(response.RespondedAt) {
+			return fmt.Errorf("%w: the booking starts in less than %d minutes", ErrInvalidBookingState, minLeadTime)
+		}
+
+		response.SnoozedUntil = &until
+		return nil
+	})
+}
+
+func (s *Service) respondToReminder(ctx context.Context, id string, user discord.DiscordUser, respond func(booking Booking, response *ReminderResponse) error) (Booking, error) {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	username := strings.ToLower(user.Username)
+
+	if booking.UserID != user.ID && !slices.Contains(booking.Players, username) {
+		return Booking{}, fmt.Errorf("%w: '%v'", ErrPlayerNotFound, username)
+	}
+
+	now := time.Now()
+
+	if !isOpen(booking, now) {
+		return Booking{}, ErrInvalidBookingState
+	}
+
+	response := ReminderResponse{Username: username, RespondedAt: now}
+
+	if err := respond(booking, &response); err != nil {
+		return Booking{}, err
+	}
+
+	if err := s.repo.SetReminderResponse(ctx, id, response); err != nil {
+		return Booking{}, err
+	}
+
+	s.invalidate()
+
+	return s.repo.GetBookingByID(ctx, id)
+}
+
+// SendDueReminders sends the reminders of the accepted bookings whose lead
+// time is reached, once per lead time and date even when several replicas
+// run the job, then those snoozed until now.
+func (s *Service) SendDueReminders(ctx context.Context) error {
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	now := time.Now()
+	failed := 0
+
+	for _, booking := range bookings {
+		if booking.Status != "accepted" || !booking.ReminderEnabled || !booking.DateTime.After(now) {
+			continue
+		}
+
+		content := fmt.Sprintf("Rappel : la partie de %s commence dans %s, à %s !",
+			booking.Game, formatLeadTime(int(booking.DateTime.Sub(now).Round(time.Minute).Minutes())), booking.DateTime.In(s.location).Format("15:04"))
+
+		if lead := booking.Reminders.dueLeadTime(booking.DateTime, now); lead != 0 {
+			marked, err := s.repo.MarkReminderSent(ctx, booking.ID, lead, booking.DateTime)
+
+			if err != nil {
+				return fmt.Errorf("failed to mark reminder of booking '%v' sent: %w", booking.ID, err)
+			}
+
+			if marked {
+				failed += s.remind(ctx, booking, s.reminderRecipients(ctx, booking, now), content)
+			}
+		}
+
+		for _, response := range booking.Reminders.dueSnoozes(now) {
+			cleared, err := s.repo.ClearSnooze(ctx, booking.ID, response.Username, *response.SnoozedUntil)
+
+			if err != nil {
+				return fmt.Errorf("failed to clear snooze of '%v' for booking '%v': %w", response.Username, booking.ID, err)
+			}
+
+			if cleared {
+				failed += s.remind(ctx, booking, s.participantIDs(ctx, booking, []string{response.Username}), content)
+			}
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("failed to send %d reminders", failed)
+	}
+
+	return nil
+}
+
+// remind sends the reminder to the participants with the Discord ids, where
+// the reminders of the booking go. It returns how many sends failed.
+func (s *Service) remind(ctx context.Context, booking Booking, recipients []string, content string) int {
+	if len(recipients) == 0 {
+		return 0
+	}
+
+	message := discord.Message{Content: content}
+
+	if s.reminderButtons {
+		message.Components = []discord.Component{discord.Buttons(
+			discord.Component{Style: discord.ButtonPrimary, Label: "Je serai là", CustomID: AcknowledgeButtonPrefix + booking.ID},
+			discord.Component{Style: discord.ButtonSecondary, Label: "Me le rappeler dans 1 h", CustomID: SnoozeButtonPrefix + booking.ID},
+		)}
+	}
+
+	if booking.Reminders != nil && booking.Reminders.Channel == ReminderChannel {
+		mentions := make([]string, len(recipients))
+
+		for i, recipient := range recipients {
+			mentions[i] = fmt.Sprintf("<@%v>", recipient)
+		}
+
+		message.Content = strings.Join(mentions, " ") + " " + content
+
+		if err := s.messages.SendMessage(ctx, s.guildSettings(ctx).ChannelID, message); err != nil {
+			slog.Error("failed to send booking reminder", "bookingId", booking.ID, "err", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	failed := 0
+
+	for _, recipient := range recipients {
+		channelID, err := s.client.GetDMChannel(ctx, recipient)
+
+		if err == nil {
+			err = s.messages.SendMessage(ctx, channelID, message)
+		}
+
+		if err != nil {
+			slog.Error("failed to send booking reminder", "bookingId", booking.ID, "userId", recipient, "err", err)
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// reminderRecipients returns the Discord ids of the owner and players of the
+// booking reminded of it at now.
+func (s *Service) reminderRecipients(ctx context.Context, booking Booking, now time.Time) []string {
+	participants := slices.DeleteFunc(append([]string{booking.Username}, booking.Players...), func(participant string) bool {
+		return !booking.Reminders.RemindsOf(participant, now)
+	})
+
+	return s.participantIDs(ctx, booking, participants)
+}
+
+// participantIDs returns the Discord ids of the participants of the booking
+// with the usernames, its owner being known by id and its players looked up.
+func (s *Service) participantIDs(ctx context.Context, booking Booking, usernames []string) []string {
+	var ids []string
+
+	owner := strings.ToLower(booking.Username)
+
+	if len(booking.UserID) != 0 && slices.ContainsFunc(usernames, func(username string) bool { return strings.ToLower(username) == owner }) {
+		ids = append(ids, booking.UserID)
+	}
+
+	players := slices.DeleteFunc(slices.Clone(usernames), func(username string) bool {
+		return strings.ToLower(username) == owner || !slices.Contains(booking.Players, username)
+	})
+
+	for i, user := range s.resolveMembers(ctx, players) {
+		if user.Username == players[i] && !slices.Contains(ids, user.ID) {
+			ids = append(ids, user.ID)
+		}
+	}
+
+	return ids
+}
+
+// formatLeadTime renders minutes in French, in days, hours or minutes.
+func formatLeadTime(minutes int) string {
+	switch {
+	case minutes%(24*60) == 0:
+		days := minutes / (24 * 60)
+
+		if days == 1 {
+			return "1 jour"
+		}
+
+		return fmt.Sprintf("%d jours", days)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%d h", minutes/60)
+	case minutes > 60:
+		return fmt.Sprintf("%d h %02d", minutes/60, minutes%60)
+	default:
+		return fmt.Sprintf("%d min", minutes)
+	}
+}
@@ -2,65 +2,210 @@ package booking
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hanksha/tbz-booking-system-backend/audit"
+	"github.com/hanksha/tbz-booking-system-backend/database"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-type Repository struct{ conn *pgxpool.Pool }
+// querier is the subset of pgxpool.Pool and pgx.Tx used by the repository,
+// so the same queries run either standalone or inside a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+type Repository struct {
+	conn  querier
+	pool  *pgxpool.Pool
+	inTx  bool
+	retry database.RetryPolicy
+}
+
+// bookingColumns selects every column of the booking table under the name of
+// the matching Booking field, for pgx.RowToStructByName.
+const bookingColumns = `id::text AS id, game, COALESCE("userId", '') AS "userId", COALESCE(username, '') AS username, points, ` +
+	`COALESCE(description, '') AS description, status, COALESCE("reminderEnabled", false) AS "reminderEnabled", "dateTime", players, "coOrganizers", ` +
+	`COALESCE("lookingForPlayers", false) AS "lookingForPlayers", visibility, COALESCE("venueId"::text, '') AS "venueId", tags, "customFields", ` +
+	`"createdAt", "updatedAt", "deletedAt", "checkedInAt", "decidedAt", priority, COALESCE("paymentStatus", '') AS "paymentStatus", ` +
+	`COALESCE("notificationError", '') AS "notificationError", COALESCE("calendarEventId", '') AS "calendarEventId", ` +
+	`COALESCE("calendarSyncStatus", '') AS "calendarSyncStatus", COALESCE("calendarSyncError", '') AS "calendarSyncError", ` +
+	`COALESCE("voiceChannelId", '') AS "voiceChannelId", ` +
+	`COALESCE((SELECT json_agg(json_build_object('itemId', be."equipmentId"::text, 'name', e.name, 'quantity', be.quantity) ORDER BY e.name) ` +
+	`FROM "game-table-booking".booking_equipment be JOIN "game-table-booking".equipment e ON e.id = be."equipmentId" ` +
+	`WHERE be."bookingId" = booking.id), '[]') AS equipment, ` +
+	`(SELECT json_build_object('leadTimes', br."leadTimes", 'channel', br.channel, 'optedOut', br."optedOut", 'responses', ` +
+	`COALESCE((SELECT json_agg(json_build_object('username', rr.username, 'acknowledged', rr.acknowledged, ` +
+	`'snoozedUntil', rr."snoozedUntil", 'respondedAt', rr."respondedAt") ORDER BY rr.username) ` +
+	`FROM "game-table-booking".booking_reminder_response rr WHERE rr."bookingId" = br."bookingId"), '[]')) ` +
+	`FROM "game-table-booking".booking_reminder br WHERE br."bookingId" = booking.id) AS reminders`
+
+// visibleTo is the condition on the booking visibility listing them to a
+// user, the mirror of Booking.VisibleTo. Its arguments, from visibleToArgs,
+// are numbered from $1 so the listing queries put them first.
+const visibleTo = `(visibility = 'public'
+            OR (visibility = 'members' AND $1)
+            OR $2
+            OR ($3 <> '' AND "userId" = $3)
+            OR ($4 <> '' AND ($4 = ANY(players) OR $4 = ANY("coOrganizers"))))`
+
+func visibleToArgs(user *discord.DiscordUser) []any {
+	if user == nil {
+		return []any{false, false, "", ""}
+	}
+
+	return []any{true, user.Admin, user.ID, strings.ToLower(user.Username)}
+}
+
+// arrayArg stores no tags or co-organizers as an empty array, the columns
+// being NOT NULL.
+func arrayArg(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// customFieldsArg stores no custom fields as NULL.
+func customFieldsArg(fields map[string]any) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fields
+}
+
+// optionalID parses the id of a nullable reference, nil when empty.
+func optionalID(id string) (*int64, error) {
+	if len(id) == 0 {
+		return nil, nil
+	}
+
+	intID, err := strconv.ParseInt(id, 10, 64)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &intID, nil
+}
 
 func NewRepository(conn *pgxpool.Pool) *Repository {
-	return &Repository{conn: conn}
+	return &Repository{conn: conn, pool: conn, retry: database.DefaultRetryPolicy}
+}
+
+// WithTx runs fn with a repository bound to a single transaction, committed
+// when fn returns nil and rolled back otherwise. Bookings read by id inside
+// the transaction are locked until it ends. Nested calls reuse the current
+// transaction. The whole transaction is retried when the server rolled it
+// back on a transient error, so fn may run more than once.
+func (r *Repository) WithTx(ctx context.Context, fn func(tx BookingRepository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	return database.Retry(ctx, database.TxRetryPolicy, func() error {
+		return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+			return fn(&Repository{conn: tx, pool: r.pool, inTx: true})
+		})
+	})
+}
+
+// queryRows runs a select whose columns match the fields of T, retrying on
+// transient errors unless it is part of a transaction.
+func queryRows[T any](ctx context.Context, r *Repository, sql string, args ...any) ([]T, error) {
+	var result []T
+
+	err := r.withRetry(ctx, func() error {
+		rows, err := r.conn.Query(ctx, sql, args...)
+
+		if err != nil {
+			return err
+		}
+
+		result, err = pgx.CollectRows(rows, pgx.RowToStructByName[T])
+
+		return err
+	})
+
+	return result, err
+}
+
+// execIdempotent runs a statement which can safely be applied twice,
+// retrying on transient errors unless it is part of a transaction.
+func (r *Repository) execIdempotent(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+
+	err := r.withRetry(ctx, func() error {
+		var err error
+		tag, err = r.conn.Exec(ctx, sql, args...)
+		return err
+	})
+
+	return tag, err
+}
+
+// withRetry retries fn according to the repository policy. Statements of a
+// transaction are never retried on their own, a failure aborts it.
+func (r *Repository) withRetry(ctx context.Context, fn func() error) error {
+	if r.inTx || r.retry.Attempts == 0 {
+		return fn()
+	}
+
+	return database.Retry(ctx, r.retry, fn)
 }
 
 func (r *Repository) GetActiveBookings(ctx context.Context) ([]Booking, error) {
-	sql := `SELECT id, game, COALESCE("userId", ''), COALESCE(username, ''), points, description, status, COALESCE("reminderEnabled", false), "dateTime", players
+	return r.getActiveBookings(ctx, false)
+}
+
+// GetActiveBookingsIncludingDeleted also returns the soft-deleted bookings,
+// for the admin listing.
+func (r *Repository) GetActiveBookingsIncludingDeleted(ctx context.Context) ([]Booking, error) {
+	return r.getActiveBookings(ctx, true)
+}
+
+func (r *Repository) getActiveBookings(ctx context.Context, includeDeleted bool) ([]Booking, error) {
+	sql := `SELECT ` + bookingColumns + `
             FROM "game-table-booking".booking
-            WHERE "dateTime" >= $1;
+            WHERE "dateTime" >= $1 AND ($2 OR "deletedAt" IS NULL);
         `
 
-	paris, _ := time.LoadLocation("Europe/Paris")
-	nowParis := time.Now().In(paris)
-	cutoffParis := nowParis.Add(-3 * time.Hour)
-	nowAsUTC := time.Date(cutoffParis.Year(), cutoffParis.Month(), cutoffParis.Day(), cutoffParis.Hour(), cutoffParis.Minute(), cutoffParis.Second(), cutoffParis.Nanosecond(), time.UTC)
+	cutoff := time.Now().Add(-3 * time.Hour)
 
-	rows, err := r.conn.Query(ctx, sql, nowAsUTC)
+	bookings, err := queryRows[Booking](ctx, r, sql, cutoff, includeDeleted)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch bookings: %w", err)
 	}
 
-	defer rows.Close()
-
-	var bookings []Booking
+	return bookings, nil
+}
 
-	for rows.Next() {
-		var booking Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.Game,
-			&booking.UserID,
-			&booking.Username,
-			&booking.Points,
-			&booking.Description,
-			&booking.Status,
-			&booking.ReminderEnabled,
-			&booking.DateTime,
-			&booking.Players,
-		)
+// GetVisibleBookings returns the active bookings listed to user, nil being
+// an anonymous visitor.
+func (r *Repository) GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]Booking, error) {
+	sql := `SELECT ` + bookingColumns + `
+            FROM "game-table-booking".booking
+            WHERE "dateTime" >= $5 AND "deletedAt" IS NULL AND ` + visibleTo + `;
+        `
 
-		if err != nil {
-			return nil, fmt.Errorf("error scanning booking row: %w", err)
-		}
+	cutoff := time.Now().Add(-3 * time.Hour)
 
-		bookings = append(bookings, booking)
-	}
+	bookings, err := queryRows[Booking](ctx, r, sql, append(visibleToArgs(user), cutoff)...)
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings rows: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings: %w", err)
 	}
 
 	return bookings, nil
@@ -68,88 +213,156 @@ func (r *Repository) GetActiveBookings(ctx context.Context) ([]Booking, error) {
 
 func (r *Repository) GetBookingByID(ctx context.Context, id string) (Booking, error) {
 	sql := `
-			SELECT id, game, COALESCE("userId", ''), COALESCE(username, ''), points, description, status, COALESCE("reminderEnabled", false), "dateTime", players 
+			SELECT ` + bookingColumns + `
 			FROM "game-table-booking".booking 
-			WHERE id=$1;
+			WHERE id=$1 AND "deletedAt" IS NULL
 		`
 
-	var booking Booking
-	err := r.conn.QueryRow(ctx, sql, id).Scan(
-		&booking.ID,
-		&booking.Game,
-		&booking.UserID,
-		&booking.Username,
-		&booking.Points,
-		&booking.Description,
-		&booking.Status,
-		&booking.ReminderEnabled,
-		&booking.DateTime,
-		&booking.Players,
-	)
+	if r.inTx {
+		sql += " FOR UPDATE"
+	}
+
+	bookings, err := queryRows[Booking](ctx, r, sql, id)
+
+	if err != nil {
+		return Booking{}, fmt.Errorf("failed to fetch booking with id %v: %w", id, err)
+	}
 
-	if errors.Is(err, pgx.ErrNoRows) {
+	if len(bookings) == 0 {
 		return Booking{}, ErrBookingNotFound
 	}
 
+	return bookings[0], nil
+}
+
+func (r *Repository) GetBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]Booking, error) {
+	sql := `
+            SELECT ` + bookingColumns + `
+            FROM "game-table-booking".booking
+            WHERE id = ANY($5) AND "deletedAt" IS NULL AND ` + visibleTo + `
+            ORDER BY "dateTime";
+        `
+
+	intIDs := make([]int64, 0, len(ids))
+
+	for _, id := range ids {
+		intID, err := strconv.ParseInt(id, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking id '%v': %w", id, err)
+		}
+
+		intIDs = append(intIDs, intID)
+	}
+
+	bookings, err := queryRows[Booking](ctx, r, sql, append(visibleToArgs(user), intIDs)...)
+
 	if err != nil {
-		return Booking{}, fmt.Errorf("failed to fetch booking with id %v: %w", id, err)
+		return nil, fmt.Errorf("failed to fetch bookings by ids: %w", err)
 	}
 
-	return booking, nil
+	return bookings, nil
 }
 
-func (r *Repository) GetBookingsPerUsername(ctx context.Context, username string) ([]Booking, error) {
+// GetBookingHistory returns up to limit bookings listed to user, past ones
+// included, most recent first, starting after the given cursor when not nil.
+func (r *Repository) GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter Filter, after *Cursor, limit int) ([]Booking, error) {
+	conditions, args := filter.conditions(8)
 	sql := `
-            SELECT id, game, COALESCE("userId", ''), COALESCE(username, ''), points, description, status, COALESCE("reminderEnabled", false), "dateTime", players
+            SELECT ` + bookingColumns + `
             FROM "game-table-booking".booking
-            WHERE username=$1 OR $1 = ANY(players);
+            WHERE "deletedAt" IS NULL AND ` + visibleTo + conditions + `
+            AND ($5::timestamptz IS NULL OR ("dateTime", id) < ($5, $6))
+            ORDER BY "dateTime" DESC, id DESC
+            LIMIT $7;
         `
 
-	rows, err := r.conn.Query(ctx, sql, username)
+	var afterDateTime *time.Time
+	var afterID int64
+
+	if after != nil {
+		afterDateTime = &after.DateTime
+		afterID = after.ID
+	}
+
+	bookings, err := queryRows[Booking](ctx, r, sql, append(append(visibleToArgs(user), afterDateTime, afterID, limit), args...)...)
 
 	if err != nil {
-		return []Booking{}, fmt.Errorf("failed to fetch bookings for username '%v': %w", username, err)
+		return nil, fmt.Errorf("failed to fetch booking history: %w", err)
 	}
 
-	defer rows.Close()
+	return bookings, nil
+}
 
-	var bookings []Booking
+// GetMatchingBookings returns the bookings listed to user that match the
+// filter, the active ones unless it starts at a date of its own.
+func (r *Repository) GetMatchingBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) ([]Booking, error) {
+	conditions, args := filter.active(time.Now()).conditions(5)
+	sql := `SELECT ` + bookingColumns + `
+            FROM "game-table-booking".booking
+            WHERE "deletedAt" IS NULL AND ` + visibleTo + conditions + `
+            ORDER BY "dateTime";
+        `
 
-	for rows.Next() {
-		var booking Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.Game,
-			&booking.UserID,
-			&booking.Username,
-			&booking.Points,
-			&booking.Description,
-			&booking.Status,
-			&booking.ReminderEnabled,
-			&booking.DateTime,
-			&booking.Players,
-		)
+	bookings, err := queryRows[Booking](ctx, r, sql, append(visibleToArgs(user), args...)...)
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan bookings for username '%v': %w", username, err)
-		}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// CountBookings counts the bookings listed to user that match the filter, the
+// active ones unless it starts at a date of its own.
+func (r *Repository) CountBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) (int, error) {
+	conditions, args := filter.active(time.Now()).conditions(5)
+	sql := `SELECT count(*)
+            FROM "game-table-booking".booking
+            WHERE "deletedAt" IS NULL AND ` + visibleTo + conditions + `;
+        `
+
+	var count int
 
-		bookings = append(bookings, booking)
+	err := r.withRetry(ctx, func() error {
+		return r.conn.QueryRow(ctx, sql, append(visibleToArgs(user), args...)...).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count bookings: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings rows: %w", err)
+	return count, nil
+}
+
+func (r *Repository) GetBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]Booking, error) {
+	sql := `
+            SELECT ` + bookingColumns + `
+            FROM "game-table-booking".booking
+            WHERE (lower(username)=$5 OR $5 = ANY(players)) AND "deletedAt" IS NULL AND ` + visibleTo + `;
+        `
+
+	bookings, err := queryRows[Booking](ctx, r, sql, append(visibleToArgs(user), username)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings for username '%v': %w", username, err)
 	}
 
 	return bookings, nil
 }
 
 func (r *Repository) InsertBooking(ctx context.Context, booking Booking) (Booking, error) {
+	if len(booking.Status) == 0 {
+		booking.Status = "pending"
+	}
+
+	booking.Priority = priorityOrNormal(booking.Priority)
+
 	sql := `
 			INSERT INTO "game-table-booking".booking(
-			game, "userId", username, points, description, status, "reminderEnabled", "dateTime", players)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-			RETURNING id;
+			game, "userId", username, points, description, status, "reminderEnabled", "dateTime", players, "coOrganizers", "lookingForPlayers", visibility, "venueId", tags, "customFields", priority)
+			VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, '')::integer, $14, $15, $16)
+			RETURNING id, "createdAt", "updatedAt";
 		`
 
 	err := r.conn.QueryRow(ctx, sql,
@@ -158,11 +371,18 @@ func (r *Repository) InsertBooking(ctx context.Context, booking Booking) (Bookin
 		booking.Username,
 		booking.Points,
 		booking.Description,
-		"pending",
+		booking.Status,
 		booking.ReminderEnabled,
 		booking.DateTime,
 		booking.Players,
-	).Scan(&booking.ID)
+		arrayArg(booking.CoOrganizers),
+		booking.LookingForPlayers,
+		booking.Visibility,
+		booking.VenueID,
+		arrayArg(booking.Tags),
+		customFieldsArg(booking.CustomFields),
+		booking.Priority,
+	).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
 
 	if err != nil {
 		return Booking{}, fmt.Errorf("failed to insert booking: %w", err)
@@ -175,9 +395,21 @@ func (r *Repository) InsertManyBookings(ctx context.Context, bookings []Booking)
 	rows := [][]interface{}{}
 
 	for _, booking := range bookings {
+		var userID any
+
+		if len(booking.UserID) != 0 {
+			userID = booking.UserID
+		}
+
+		venueID, err := optionalID(booking.VenueID)
+
+		if err != nil {
+			return fmt.Errorf("%w: '%v'", ErrInvalidVenue, booking.VenueID)
+		}
+
 		rows = append(rows, []interface{}{
 			booking.Game,
-			booking.UserID,
+			userID,
 			booking.Username,
 			int32(booking.Points),
 			booking.Description,
@@ -185,11 +417,18 @@ func (r *Repository) InsertManyBookings(ctx context.Context, bookings []Booking)
 			booking.ReminderEnabled,
 			booking.DateTime,
 			booking.Players,
+			arrayArg(booking.CoOrganizers),
+			booking.LookingForPlayers,
+			booking.Visibility,
+			venueID,
+			arrayArg(booking.Tags),
+			customFieldsArg(booking.CustomFields),
+			priorityOrNormal(booking.Priority),
 		})
 	}
 
 	_, err := r.conn.CopyFrom(ctx, pgx.Identifier{"game-table-booking", "booking"},
-		[]string{"game", "userId", "username", "points", "description", "status", "reminderEnabled", "dateTime", "players"}, pgx.CopyFromRows(rows))
+		[]string{"game", "userId", "username", "points", "description", "status", "reminderEnabled", "dateTime", "players", "coOrganizers", "lookingForPlayers", "visibility", "venueId", "tags", "customFields", "priority"}, pgx.CopyFromRows(rows))
 
 	if err != nil {
 		return fmt.Errorf("failed to insert many bookings: %w", err)
@@ -206,17 +445,30 @@ func (r *Repository) UpdateBooking(ctx context.Context, booking Booking) error {
 				description=$3,
 				"reminderEnabled"=$4,
 				"dateTime"=$5,
-				players=$6
-			WHERE id=$7;
+				players=$6,
+				"coOrganizers"=$7,
+				"lookingForPlayers"=$8,
+				visibility=$9,
+				"venueId"=NULLIF($10, '')::integer,
+				tags=$11,
+				"customFields"=$12,
+				"updatedAt"=now()
+			WHERE id=$13 AND "deletedAt" IS NULL;
 		`
 
-	tag, err := r.conn.Exec(ctx, sql,
+	tag, err := r.execIdempotent(ctx, sql,
 		booking.Game,
 		booking.Points,
 		booking.Description,
 		booking.ReminderEnabled,
 		booking.DateTime,
 		booking.Players,
+		arrayArg(booking.CoOrganizers),
+		booking.LookingForPlayers,
+		booking.Visibility,
+		booking.VenueID,
+		arrayArg(booking.Tags),
+		customFieldsArg(booking.CustomFields),
 		booking.ID,
 	)
 
@@ -231,14 +483,71 @@ func (r *Repository) UpdateBooking(ctx context.Context, booking Booking) error {
 	return nil
 }
 
+// SetCheckedIn records when the booking was checked in, the first check-in
+// is kept.
+func (r *Repository) SetCheckedIn(ctx context.Context, id string, at time.Time) error {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET "checkedInAt"=COALESCE("checkedInAt", $1), "updatedAt"=now()
+            WHERE id=$2 AND "deletedAt" IS NULL;
+        `
+
+	tag, err := r.execIdempotent(ctx, sql, at, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to check in booking '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
+}
+
+// SetPaymentStatus sets the payment state of the booking and of its
+// checkout, if any. A paid checkout keeps the date it was first paid.
+func (r *Repository) SetPaymentStatus(ctx context.Context, id, status string) error {
+	sql := `
+            WITH updated AS (
+                UPDATE "game-table-booking".booking
+                SET "paymentStatus"=$2, "updatedAt"=now()
+                WHERE id=$1 AND "deletedAt" IS NULL
+                RETURNING id
+            ), payment AS (
+                UPDATE "game-table-booking".booking_payment
+                SET status=$2, "paidAt"=CASE WHEN $2='paid' THEN COALESCE("paidAt", now()) END
+                WHERE "bookingId" IN (SELECT id FROM updated)
+            )
+            SELECT count(*) FROM updated;
+        `
+
+	var count int
+
+	err := r.withRetry(ctx, func() error {
+		return r.conn.QueryRow(ctx, sql, id, status).Scan(&count)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set payment status of booking '%v': %w", id, err)
+	}
+
+	if count == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
+}
+
 func (r *Repository) SetBookingStatus(ctx context.Context, id string, status string) error {
 	sql := `
             UPDATE "game-table-booking".booking
-            SET status=$1
-            WHERE id=$2;
+            SET status=$1, "updatedAt"=now(),
+                "decidedAt"=CASE WHEN $1 IN ('accepted', 'refused') THEN COALESCE("decidedAt", now()) ELSE "decidedAt" END
+            WHERE id=$2 AND "deletedAt" IS NULL;
         `
 
-	tag, err := r.conn.Exec(ctx, sql, status, id)
+	tag, err := r.execIdempotent(ctx, sql, status, id)
 
 	if err != nil {
 		return fmt.Errorf("failed to update booking '%v' status: %w", id, err)
@@ -251,130 +560,842 @@ func (r *Repository) SetBookingStatus(ctx context.Context, id string, status str
 	return err
 }
 
-type GameBookingCount struct {
-	Game  string `json:"game"`
-	Count int    `json:"bookingCount"`
+// InsertAuditEntry records an action in the audit log, committed or rolled
+// back with the change it records when the repository is bound to a
+// transaction.
+func (r *Repository) InsertAuditEntry(ctx context.Context, entry audit.Entry) error {
+	return audit.NewRepository(r.conn).InsertEntry(ctx, entry)
 }
 
-type WeekDayBookingCount struct {
-	WeekDay string `json:"dayOfWeek"`
-	Count   int    `json:"bookingCount"`
+func (r *Repository) SetPriority(ctx context.Context, id, priority string) error {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET priority=$1, "updatedAt"=now()
+            WHERE id=$2 AND "deletedAt" IS NULL;
+        `
+
+	tag, err := r.execIdempotent(ctx, sql, priority, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to update booking '%v' priority: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
 }
 
-func (r *Repository) GetBookingCountPerGame(ctx context.Context) ([]GameBookingCount, error) {
+// SetReminders sets the lead times and channel of the reminders of the
+// booking, keeping the participants who opted out.
+func (r *Repository) SetReminders(ctx context.Context, id string, reminders Reminders) error {
 	sql := `
-		SELECT booking.game, COUNT(*) as booking_count FROM "game-table-booking".booking 
-		WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
-		GROUP BY booking.game
-		ORDER BY booking_count DESC
-	`
+            INSERT INTO "game-table-booking".booking_reminder("bookingId", "leadTimes", channel)
+            SELECT id, $2, $3 FROM "game-table-booking".booking
+            WHERE id=$1 AND "deletedAt" IS NULL
+            ON CONFLICT ("bookingId") DO UPDATE
+            SET "leadTimes"=EXCLUDED."leadTimes", channel=EXCLUDED.channel;
+        `
 
-	rows, err := r.conn.Query(ctx, sql)
+	tag, err := r.conn.Exec(ctx, sql, id, reminders.LeadTimes, reminders.Channel)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
+		return fmt.Errorf("failed to set reminders of booking '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
 	}
 
-	defer rows.Close()
+	return nil
+}
 
-	stats := []GameBookingCount{}
+// SetReminderOptOut adds the username to the participants not reminded of
+// the booking, or removes it when optOut is false.
+func (r *Repository) SetReminderOptOut(ctx context.Context, id, username string, optOut bool) error {
+	sql := `
+            INSERT INTO "game-table-booking".booking_reminder AS br("bookingId", "optedOut")
+            SELECT id, CASE WHEN $3 THEN ARRAY[$2::text] ELSE '{}' END FROM "game-table-booking".booking
+            WHERE id=$1 AND "deletedAt" IS NULL
+            ON CONFLICT ("bookingId") DO UPDATE
+            SET "optedOut"=CASE WHEN $3 THEN array_append(array_remove(br."optedOut", $2::text), $2::text)
+                ELSE array_remove(br."optedOut", $2::text) END;
+        `
 
-	for rows.Next() {
-		var game string
-		var count int
-		err := rows.Scan(&game, &count)
+	tag, err := r.conn.Exec(ctx, sql, id, username, optOut)
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
+	if err != nil {
+		return fmt.Errorf("failed to set reminder opt-out of '%v' for booking '%v': %w", username, id, err)
+	}
 
-		stats = append(stats, GameBookingCount{Game: game, Count: count})
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings rows: %w", err)
+	return nil
+}
+
+// MarkReminderSent records that the reminder of the booking starting at
+// dateTime was sent leadTime minutes ahead. It reports false when it already
+// was, by another replica maybe, and records it anew once the booking moves.
+func (r *Repository) MarkReminderSent(ctx context.Context, id string, leadTime int, dateTime time.Time) (bool, error) {
+	sql := `
+            INSERT INTO "game-table-booking".booking_reminder_sent("bookingId", "leadTime", "dateTime")
+            VALUES ($1, $2, $3)
+            ON CONFLICT DO NOTHING;
+        `
+
+	tag, err := r.conn.Exec(ctx, sql, id, leadTime, dateTime)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to mark reminder of booking '%v' sent: %w", id, err)
 	}
 
-	return stats, err
+	return tag.RowsAffected() != 0, nil
 }
 
-func (r *Repository) GetBookingCountPerWeekDay(ctx context.Context) ([]WeekDayBookingCount, error) {
+// SetReminderResponse records the answer of a participant to the reminders
+// of the booking, replacing their previous one.
+func (r *Repository) SetReminderResponse(ctx context.Context, id string, response ReminderResponse) error {
 	sql := `
-		SELECT 
-			TO_CHAR("dateTime", 'Day') as day_of_week,
-			COUNT(*) as booking_count
-		FROM 
-			"game-table-booking".booking
-		WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
-		GROUP BY 
-			TO_CHAR("dateTime", 'Day')
-		ORDER BY 
-			booking_count DESC;
-	`
+            WITH reminder AS (
+                INSERT INTO "game-table-booking".booking_reminder("bookingId")
+                SELECT id FROM "game-table-booking".booking
+                WHERE id=$1 AND "deletedAt" IS NULL
+                ON CONFLICT ("bookingId") DO NOTHING
+            )
+            INSERT INTO "game-table-booking".booking_reminder_response("bookingId", username, acknowledged, "snoozedUntil", "respondedAt")
+            SELECT id, $2, $3, $4, $5 FROM "game-table-booking".booking
+            WHERE id=$1 AND "deletedAt" IS NULL
+            ON CONFLICT ("bookingId", username) DO UPDATE
+            SET acknowledged=EXCLUDED.acknowledged, "snoozedUntil"=EXCLUDED."snoozedUntil", "respondedAt"=EXCLUDED."respondedAt";
+        `
 
-	rows, err := r.conn.Query(ctx, sql)
+	tag, err := r.conn.Exec(ctx, sql, id, response.Username, response.Acknowledged, response.SnoozedUntil, response.RespondedAt)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
+		return fmt.Errorf("failed to record reminder response of '%v' for booking '%v': %w", response.Username, id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
 	}
 
-	defer rows.Close()
+	return nil
+}
 
-	stats := []WeekDayBookingCount{}
+// ClearSnooze ends the snooze of the participant once reminded. It reports
+// false when it already ended, by another replica maybe, or was changed.
+func (r *Repository) ClearSnooze(ctx context.Context, id, username string, snoozedUntil time.Time) (bool, error) {
+	sql := `
+            UPDATE "game-table-booking".booking_reminder_response
+            SET "snoozedUntil"=NULL
+            WHERE "bookingId"=$1 AND username=$2 AND "snoozedUntil"=$3;
+        `
 
-	for rows.Next() {
-		var weekDay string
-		var count int
-		err := rows.Scan(&weekDay, &count)
+	tag, err := r.conn.Exec(ctx, sql, id, username, snoozedUntil)
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
+	if err != nil {
+		return false, fmt.Errorf("failed to clear snooze of '%v' for booking '%v': %w", username, id, err)
+	}
 
-		stats = append(stats, WeekDayBookingCount{WeekDay: weekDay, Count: count})
+	return tag.RowsAffected() != 0, nil
+}
+
+// SearchBookings runs a prefix full-text search over the game, description,
+// username and players of the bookings listed to user, best matches first.
+func (r *Repository) SearchBookings(ctx context.Context, user *discord.DiscordUser, terms []string, limit int) ([]SearchResult, error) {
+	sql := `
+            SELECT ` + bookingColumns + `,
+                ts_rank("searchVector", query) AS rank,
+                ts_headline('simple', concat_ws(' · ', game, description, username, array_to_string(players, ', ')), query,
+                    'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20') AS highlight
+            FROM "game-table-booking".booking, to_tsquery('simple', $5) query
+            WHERE "deletedAt" IS NULL AND "searchVector" @@ query AND ` + visibleTo + `
+            ORDER BY rank DESC, "dateTime" DESC
+            LIMIT $6;
+        `
+
+	prefixes := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings rows: %w", err)
+	results, err := queryRows[SearchResult](ctx, r, sql, append(visibleToArgs(user), strings.Join(prefixes, " & "), limit)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to search bookings: %w", err)
 	}
 
-	return stats, err
+	return results, nil
 }
 
-func (r *Repository) GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]GameBookingCount, error) {
+// DeleteBooking soft-deletes the booking, hiding it from every query until it
+// is purged.
+func (r *Repository) DeleteBooking(ctx context.Context, id string) error {
 	sql := `
-		SELECT booking.game, COUNT(*) as booking_count FROM "game-table-booking".booking
-		WHERE booking."dateTime" BETWEEN $1 AND $2
-		AND booking.status NOT IN ('pending', 'canceled', 'refused')
-		GROUP BY booking.game
-		ORDER BY booking_count DESC
-	`
+            UPDATE "game-table-booking".booking
+            SET "deletedAt"=now(), "updatedAt"=now()
+            WHERE id=$1 AND "deletedAt" IS NULL;
+        `
 
-	rows, err := r.conn.Query(ctx, sql, start, end)
+	tag, err := r.conn.Exec(ctx, sql, id)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
+		return fmt.Errorf("failed to delete booking '%v': %w", id, err)
 	}
 
-	defer rows.Close()
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
 
-	stats := []GameBookingCount{}
+	return nil
+}
 
-	for rows.Next() {
-		var game string
-		var count int
-		err := rows.Scan(&game, &count)
+// PurgeDeletedBookings permanently removes the bookings soft-deleted before
+// the given time and returns how many were removed.
+func (r *Repository) PurgeDeletedBookings(ctx context.Context, before time.Time) (int64, error) {
+	sql := `
+            DELETE FROM "game-table-booking".booking
+            WHERE "deletedAt" < $1;
+        `
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
+	tag, err := r.execIdempotent(ctx, sql, before)
 
-		stats = append(stats, GameBookingCount{Game: game, Count: count})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted bookings: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bookings rows: %w", err)
+	return tag.RowsAffected(), nil
+}
+
+// SetNotificationError records why the notification of the booking failed,
+// an empty message clearing it.
+func (r *Repository) SetNotificationError(ctx context.Context, id, message string) error {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET "notificationError"=NULLIF($1, ''), "updatedAt"=now()
+            WHERE id=$2 AND "deletedAt" IS NULL;
+        `
+
+	tag, err := r.execIdempotent(ctx, sql, message, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to set booking '%v' notification error: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
+}
+
+// SetCalendarSync records how the last sync of the booking with Google
+// Calendar went, deleted bookings included as their event is removed.
+func (r *Repository) SetCalendarSync(ctx context.Context, id string, sync CalendarSync) error {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET "calendarEventId"=NULLIF($1, ''), "calendarSyncStatus"=NULLIF($2, ''), "calendarSyncError"=NULLIF($3, ''), "updatedAt"=now()
+            WHERE id=$4;
+        `
+
+	tag, err := r.execIdempotent(ctx, sql, sync.EventID, sync.Status, sync.Error, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to set booking '%v' calendar sync: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
+}
+
+// SetVoiceChannel records the voice channel of the booking, deleted
+// bookings included as their channel is deleted.
+func (r *Repository) SetVoiceChannel(ctx context.Context, id, channelID string) error {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET "voiceChannelId"=NULLIF($1, ''), "updatedAt"=now()
+            WHERE id=$2;
+        `
+
+	tag, err := r.execIdempotent(ctx, sql, channelID, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to set booking '%v' voice channel: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotFound
+	}
+
+	return nil
+}
+
+// GetVoiceChannelBookings returns the bookings having a voice channel,
+// deleted bookings included.
+func (r *Repository) GetVoiceChannelBookings(ctx context.Context) ([]Booking, error) {
+	sql := `SELECT ` + bookingColumns + `
+            FROM "game-table-booking".booking
+            WHERE "voiceChannelId" IS NOT NULL;
+        `
+
+	bookings, err := queryRows[Booking](ctx, r, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings with a voice channel: %w", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *Repository) UpsertResult(ctx context.Context, result Result) (Result, error) {
+	sql := `
+            INSERT INTO "game-table-booking".booking_result("bookingId", winner, scores, report, "reportedBy")
+            VALUES ($1, NULLIF($2, ''), $3, $4, $5)
+            ON CONFLICT ("bookingId") DO UPDATE SET
+                winner=EXCLUDED.winner,
+                scores=EXCLUDED.scores,
+                report=EXCLUDED.report,
+                "reportedBy"=EXCLUDED."reportedBy",
+                "reportedAt"=now()
+            RETURNING "reportedAt";
+        `
+
+	err := r.withRetry(ctx, func() error {
+		return r.conn.QueryRow(ctx, sql, result.BookingID, result.Winner, result.Scores, result.Report, result.ReportedBy).Scan(&result.ReportedAt)
+	})
+
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to save result of booking '%v': %w", result.BookingID, err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) GetResult(ctx context.Context, bookingID string) (Result, error) {
+	sql := `
+            SELECT "bookingId"::text AS "bookingId", COALESCE(winner, '') AS winner, scores,
+                COALESCE(report, '') AS report, COALESCE("reportedBy", '') AS "reportedBy", "reportedAt"
+            FROM "game-table-booking".booking_result
+            WHERE "bookingId"=$1;
+        `
+
+	results, err := queryRows[Result](ctx, r, sql, bookingID)
+
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to fetch result of booking '%v': %w", bookingID, err)
+	}
+
+	if len(results) == 0 {
+		return Result{}, ErrResultNotFound
+	}
+
+	return results[0], nil
+}
+
+const paymentColumns = `"bookingId"::text AS "bookingId", "sessionId", url, amount, currency, status, "createdAt", "paidAt"`
+
+// InsertPayment saves the checkout of a booking, replacing the previous one.
+func (r *Repository) InsertPayment(ctx context.Context, payment Payment) (Payment, error) {
+	sql := `
+            INSERT INTO "game-table-booking".booking_payment("bookingId", "sessionId", url, amount, currency, status)
+            VALUES ($1, $2, $3, $4, $5, $6)
+            ON CONFLICT ("bookingId") DO UPDATE SET
+                "sessionId"=EXCLUDED."sessionId",
+                url=EXCLUDED.url,
+                amount=EXCLUDED.amount,
+                currency=EXCLUDED.currency,
+                status=EXCLUDED.status,
+                "createdAt"=now(),
+                "paidAt"=NULL
+            RETURNING "createdAt";
+        `
+
+	err := r.conn.QueryRow(ctx, sql,
+		payment.BookingID,
+		payment.SessionID,
+		payment.URL,
+		payment.Amount,
+		payment.Currency,
+		payment.Status,
+	).Scan(&payment.CreatedAt)
+
+	if err != nil {
+		return Payment{}, fmt.Errorf("failed to insert payment of booking '%v': %w", payment.BookingID, err)
+	}
+
+	return payment, nil
+}
+
+func (r *Repository) GetPayment(ctx context.Context, bookingID string) (Payment, error) {
+	sql := `
+            SELECT ` + paymentColumns + `
+            FROM "game-table-booking".booking_payment
+            WHERE "bookingId"=$1;
+        `
+
+	payments, err := queryRows[Payment](ctx, r, sql, bookingID)
+
+	if err != nil {
+		return Payment{}, fmt.Errorf("failed to fetch payment of booking '%v': %w", bookingID, err)
+	}
+
+	if len(payments) == 0 {
+		return Payment{}, ErrPaymentNotFound
+	}
+
+	return payments[0], nil
+}
+
+const attachmentColumns = `id::text AS id, "bookingId"::text AS "bookingId", filename, "contentType", size, "storageKey", "uploadedBy", "createdAt"`
+
+func (r *Repository) InsertAttachment(ctx context.Context, attachment Attachment) (Attachment, error) {
+	sql := `
+            INSERT INTO "game-table-booking".booking_attachment("bookingId", filename, "contentType", size, "storageKey", "uploadedBy")
+            VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id::text, "createdAt";
+        `
+
+	err := r.conn.QueryRow(ctx, sql,
+		attachment.BookingID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		attachment.UploadedBy,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to insert attachment of booking '%v': %w", attachment.BookingID, err)
+	}
+
+	return attachment, nil
+}
+
+func (r *Repository) GetAttachments(ctx context.Context, bookingID string) ([]Attachment, error) {
+	sql := `
+            SELECT ` + attachmentColumns + `
+            FROM "game-table-booking".booking_attachment
+            WHERE "bookingId"=$1
+            ORDER BY "createdAt", id;
+        `
+
+	attachments, err := queryRows[Attachment](ctx, r, sql, bookingID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachments of booking '%v': %w", bookingID, err)
+	}
+
+	return attachments, nil
+}
+
+func (r *Repository) GetAttachment(ctx context.Context, bookingID, id string) (Attachment, error) {
+	sql := `
+            SELECT ` + attachmentColumns + `
+            FROM "game-table-booking".booking_attachment
+            WHERE "bookingId"=$1 AND id::text=$2;
+        `
+
+	attachments, err := queryRows[Attachment](ctx, r, sql, bookingID, id)
+
+	if err != nil {
+		return Attachment{}, fmt.Errorf("failed to fetch attachment '%v': %w", id, err)
+	}
+
+	if len(attachments) == 0 {
+		return Attachment{}, ErrAttachmentNotFound
+	}
+
+	return attachments[0], nil
+}
+
+func (r *Repository) DeleteAttachment(ctx context.Context, bookingID, id string) error {
+	sql := `DELETE FROM "game-table-booking".booking_attachment WHERE "bookingId"=$1 AND id::text=$2;`
+
+	tag, err := r.conn.Exec(ctx, sql, bookingID, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// MarkBookingEscalated records that the admins were alerted about the pending
+// booking. It reports false when it already was, by another replica maybe.
+func (r *Repository) MarkBookingEscalated(ctx context.Context, id string) (bool, error) {
+	sql := `
+            UPDATE "game-table-booking".booking
+            SET "escalatedAt"=now()
+            WHERE id=$1 AND "escalatedAt" IS NULL;
+        `
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to mark booking '%v' escalated: %w", id, err)
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+// NoShowCount is how many of the accepted bookings of an organizer nobody
+// checked in.
+type NoShowCount struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	Bookings int    `json:"bookings"`
+	NoShows  int    `json:"noShows"`
+}
+
+// GetNoShowCounts counts per organizer the accepted bookings started between
+// start and end, and those never checked in. Organizers are named by their
+// latest username, whatever the one they booked under.
+func (r *Repository) GetNoShowCounts(ctx context.Context, start, end time.Time) ([]NoShowCount, error) {
+	sql := `
+		SELECT COALESCE(booking."userId", '') AS "userId", COALESCE(MAX(users.username), MAX(booking.username), '') AS username,
+			COUNT(*) AS bookings, COUNT(*) FILTER (WHERE booking."checkedInAt" IS NULL) AS "noShows"
+		FROM "game-table-booking".booking
+		LEFT JOIN "game-table-booking".users ON users.id = booking."userId"
+		WHERE booking."dateTime" BETWEEN $1 AND $2
+		AND booking.status = 'accepted'
+		AND booking."deletedAt" IS NULL
+		GROUP BY booking."userId"
+		ORDER BY "noShows" DESC, bookings DESC, username
+	`
+
+	stats, err := queryRows[NoShowCount](ctx, r, sql, start, end)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch no-show counts: %w", err)
+	}
+
+	return stats, nil
+}
+
+// OrganizerHistory is the past of an organizer: its accepted bookings played,
+// those nobody checked in, and the bookings it canceled.
+type OrganizerHistory struct {
+	UserID        string `json:"userId"`
+	Username      string `json:"username"`
+	Bookings      int    `json:"bookings"`
+	NoShows       int    `json:"noShows"`
+	Cancellations int    `json:"cancellations"`
+}
+
+// GetOrganizerHistories sums up per organizer the accepted bookings started
+// before the given time, those never checked in, and the canceled ones.
+// Organizers without any booking are left out, the bookings being grouped by
+// user whatever the username they were made under.
+func (r *Repository) GetOrganizerHistories(ctx context.Context, userIDs []string, before time.Time) ([]OrganizerHistory, error) {
+	sql := `
+		SELECT users.id AS "userId", users.username,
+			COUNT(*) FILTER (WHERE booking.status = 'accepted' AND booking."dateTime" < $2) AS bookings,
+			COUNT(*) FILTER (WHERE booking.status = 'accepted' AND booking."dateTime" < $2 AND booking."checkedInAt" IS NULL) AS "noShows",
+			COUNT(*) FILTER (WHERE booking.status = 'canceled') AS cancellations
+		FROM "game-table-booking".booking
+		JOIN "game-table-booking".users ON users.id = booking."userId"
+		WHERE users.id = ANY($1)
+		AND booking."deletedAt" IS NULL
+		GROUP BY users.id
+	`
+
+	histories, err := queryRows[OrganizerHistory](ctx, r, sql, userIDs, before)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch organizer histories: %w", err)
+	}
+
+	return histories, nil
+}
+
+type GameBookingCount struct {
+	Game  string `json:"game"`
+	Count int    `json:"bookingCount"`
+}
+
+type TagBookingCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"bookingCount"`
+}
+
+type WeekDayBookingCount struct {
+	WeekDay string `json:"dayOfWeek"`
+	Count   int    `json:"bookingCount"`
+}
+
+// PairBookingCount is how many bookings two members played together, the
+// usernames in alphabetical order.
+type PairBookingCount struct {
+	PlayerA string `json:"playerA"`
+	PlayerB string `json:"playerB"`
+	Count   int    `json:"bookingCount"`
+}
+
+func (r *Repository) GetBookingCountPerGame(ctx context.Context) ([]GameBookingCount, error) {
+	sql := `
+		SELECT booking.game, COUNT(*) AS "count" FROM "game-table-booking".booking 
+		WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
+		AND booking."deletedAt" IS NULL
+		GROUP BY booking.game
+		ORDER BY "count" DESC
+	`
+
+	stats, err := queryRows[GameBookingCount](ctx, r, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) GetBookingCountPerTag(ctx context.Context) ([]TagBookingCount, error) {
+	sql := `
+		SELECT tag, COUNT(*) AS "count"
+		FROM "game-table-booking".booking, unnest(booking.tags) AS tag
+		WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
+		AND booking."deletedAt" IS NULL
+		GROUP BY tag
+		ORDER BY "count" DESC, tag
+	`
+
+	stats, err := queryRows[TagBookingCount](ctx, r, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings count per tag: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetBookingCountPerPair counts the bookings per pair of members, the
+// organizer and the players, most frequent first. The members known as users
+// are counted by id under their latest username, the organizer through the
+// booking's user and the players by username.
+func (r *Repository) GetBookingCountPerPair(ctx context.Context, limit int) ([]PairBookingCount, error) {
+	sql := `
+		WITH members AS (
+			SELECT DISTINCT booking.id, COALESCE(users.id, member.username) AS member, COALESCE(lower(users.username), member.username) AS username
+			FROM "game-table-booking".booking
+			CROSS JOIN LATERAL (
+				SELECT booking."userId" AS "userId", lower(booking.username) AS username
+				UNION ALL
+				SELECT NULL, player FROM unnest(booking.players) AS player
+			) AS member
+			LEFT JOIN "game-table-booking".users ON users.id = member."userId"
+				OR (member."userId" IS NULL AND lower(users.username) = member.username)
+			WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
+			AND booking."deletedAt" IS NULL
+			AND member.username <> ''
+		)
+		SELECT LEAST(MAX(a.username), MAX(b.username)) AS "playerA", GREATEST(MAX(a.username), MAX(b.username)) AS "playerB", COUNT(DISTINCT a.id) AS "count"
+		FROM members a
+		JOIN members b ON a.id = b.id AND a.member < b.member
+		GROUP BY a.member, b.member
+		ORDER BY "count" DESC, "playerA", "playerB"
+		LIMIT $1
+	`
+
+	stats, err := queryRows[PairBookingCount](ctx, r, sql, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings count per pair: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Decision is when a booking was created and first accepted or refused.
+type Decision struct {
+	CreatedAt time.Time
+	DecidedAt time.Time
+}
+
+// GetDecisions returns the decisions on the bookings created between start
+// and end.
+func (r *Repository) GetDecisions(ctx context.Context, start, end time.Time) ([]Decision, error) {
+	sql := `
+		SELECT "createdAt", "decidedAt"
+		FROM "game-table-booking".booking
+		WHERE "createdAt" BETWEEN $1 AND $2
+		AND "decidedAt" IS NOT NULL
+		AND "deletedAt" IS NULL
+		ORDER BY "createdAt"
+	`
+
+	decisions, err := queryRows[Decision](ctx, r, sql, start, end)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch decisions: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// GetFavoriteGames counts the pending and accepted bookings of a user per
+// game, as organizer, whatever the username booked under, or as player,
+// most booked first.
+func (r *Repository) GetFavoriteGames(ctx context.Context, userID, username string, limit int) ([]GameBookingCount, error) {
+	sql := `
+		SELECT game, COUNT(*) AS "count" FROM "game-table-booking".booking
+		WHERE (("userId"=$1 AND $1 <> '') OR $2 = ANY(players))
+		AND status IN ('pending', 'accepted')
+		AND "deletedAt" IS NULL
+		GROUP BY game
+		ORDER BY "count" DESC, game
+		LIMIT $3
+	`
+
+	stats, err := queryRows[GameBookingCount](ctx, r, sql, userID, username, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch favorite games of '%v': %w", username, err)
+	}
+
+	return stats, nil
+}
+
+// GetBookedSlots returns the starts of the pending and accepted bookings
+// between from and to, each holding a table, flagging the ones username
+// organizes or plays.
+func (r *Repository) GetBookedSlots(ctx context.Context, from, to time.Time, username string) ([]BookedSlot, error) {
+	sql := `
+		SELECT "dateTime", (username=$3 OR $3 = ANY(players)) AS mine
+		FROM "game-table-booking".booking
+		WHERE "dateTime" >= $1 AND "dateTime" < $2
+		AND status IN ('pending', 'accepted')
+		AND "deletedAt" IS NULL
+		ORDER BY "dateTime"
+	`
+
+	slots, err := queryRows[BookedSlot](ctx, r, sql, from, to, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booked slots: %w", err)
+	}
+
+	return slots, nil
+}
+
+// GetEquipmentAvailability returns the items among ids with the quantity
+// reserved by the other bookings holding a table at the given time. Inside a
+// transaction the items stay locked until it ends.
+func (r *Repository) GetEquipmentAvailability(ctx context.Context, ids []string, at time.Time, bookingID string) ([]EquipmentAvailability, error) {
+	sql := `
+		SELECT e.id::text AS "itemId", e.name, e.quantity,
+			COALESCE((
+				SELECT SUM(be.quantity)
+				FROM "game-table-booking".booking_equipment be
+				JOIN "game-table-booking".booking b ON b.id = be."bookingId"
+				WHERE be."equipmentId" = e.id
+				AND b.id::text <> $4
+				AND b.status IN ('pending', 'accepted')
+				AND b."deletedAt" IS NULL
+				AND b."dateTime" > $2 AND b."dateTime" < $3
+			), 0) AS reserved
+		FROM "game-table-booking".equipment e
+		WHERE e.id::text = ANY($1)
+	`
+
+	if r.inTx {
+		sql += " FOR UPDATE OF e"
+	}
+
+	availabilities, err := queryRows[EquipmentAvailability](ctx, r, sql, ids, at.Add(-TableDuration), at.Add(TableDuration), bookingID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch equipment availability: %w", err)
+	}
+
+	return availabilities, nil
+}
+
+// SetEquipment replaces the equipment reserved for the booking, it is meant
+// to run in a transaction.
+func (r *Repository) SetEquipment(ctx context.Context, bookingID string, reservations []Reservation) error {
+	itemIDs := make([]int64, 0, len(reservations))
+	quantities := make([]int32, 0, len(reservations))
+
+	for _, reservation := range reservations {
+		id, err := strconv.ParseInt(reservation.ItemID, 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("%w: invalid item id '%v'", ErrUnknownEquipment, reservation.ItemID)
+		}
+
+		itemIDs = append(itemIDs, id)
+		quantities = append(quantities, int32(reservation.Quantity))
+	}
+
+	deleteSQL := `DELETE FROM "game-table-booking".booking_equipment WHERE "bookingId"=$1;`
+
+	if _, err := r.conn.Exec(ctx, deleteSQL, bookingID); err != nil {
+		return fmt.Errorf("failed to release equipment of booking '%v': %w", bookingID, err)
+	}
+
+	insertSQL := `
+		INSERT INTO "game-table-booking".booking_equipment("bookingId", "equipmentId", quantity)
+		SELECT $1, item.id, item.quantity
+		FROM unnest($2::integer[], $3::integer[]) AS item(id, quantity);
+	`
+
+	if _, err := r.conn.Exec(ctx, insertSQL, bookingID, itemIDs, quantities); err != nil {
+		return fmt.Errorf("failed to reserve equipment for booking '%v': %w", bookingID, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetBookingCountPerWeekDay(ctx context.Context) ([]WeekDayBookingCount, error) {
+	sql := `
+		SELECT 
+			TO_CHAR("dateTime", 'Day') AS "weekDay",
+			COUNT(*) AS "count"
+		FROM 
+			"game-table-booking".booking
+		WHERE booking.status NOT IN ('pending', 'canceled', 'refused')
+		AND booking."deletedAt" IS NULL
+		GROUP BY 
+			TO_CHAR("dateTime", 'Day')
+		ORDER BY 
+			"count" DESC;
+	`
+
+	stats, err := queryRows[WeekDayBookingCount](ctx, r, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]GameBookingCount, error) {
+	sql := `
+		SELECT booking.game, COUNT(*) AS "count" FROM "game-table-booking".booking
+		WHERE booking."dateTime" BETWEEN $1 AND $2
+		AND booking.status NOT IN ('pending', 'canceled', 'refused')
+		AND booking."deletedAt" IS NULL
+		GROUP BY booking.game
+		ORDER BY "count" DESC
+	`
+
+	stats, err := queryRows[GameBookingCount](ctx, r, sql, start, end)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings count per game: %w", err)
 	}
 
-	return stats, err
+	return stats, nil
 }
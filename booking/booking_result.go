@@ -0,0 +1,84 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Result is the outcome of a completed booking, reported by its organizer.
+// Scores are keyed by player username.
+type Result struct {
+	BookingID  string         `json:"bookingId"`
+	Winner     string         `json:"winner,omitempty"`
+	Scores     map[string]int `json:"scores"`
+	Report     string         `json:"report" binding:"max=2000"`
+	ReportedBy string         `json:"reportedBy"`
+	ReportedAt time.Time      `json:"reportedAt"`
+}
+
+// Completed reports whether the booking took place, so its result can be
+// reported.
+func (b Booking) Completed(now time.Time) bool {
+	return b.Status == "accepted" && b.DateTime.Before(now)
+}
+
+// ReportResult records the result of a completed booking, replacing the
+// previous report. Only the organizer and admins report results, the winner
+// and the scored players must be players of the booking.
+func (s *Service) ReportResult(ctx context.Context, id string, result Result, user discord.DiscordUser) (Result, error) {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	if booking.UserID != user.ID && !user.Admin {
+		return Result{}, ErrNotAllowed
+	}
+
+	if !booking.Completed(time.Now()) {
+		return Result{}, fmt.Errorf("%w: the booking is not completed", ErrInvalidBookingState)
+	}
+
+	result.Winner = strings.ToLower(strings.TrimSpace(result.Winner))
+
+	if len(result.Winner) != 0 && !slices.Contains(booking.Players, result.Winner) {
+		return Result{}, fmt.Errorf("%w: winner '%v' is not a player", ErrInvalidResult, result.Winner)
+	}
+
+	scores := make(map[string]int, len(result.Scores))
+
+	for _, player := range slices.Sorted(maps.Keys(result.Scores)) {
+		normalized := strings.ToLower(strings.TrimSpace(player))
+
+		if !slices.Contains(booking.Players, normalized) {
+			return Result{}, fmt.Errorf("%w: '%v' is not a player", ErrInvalidResult, player)
+		}
+
+		scores[normalized] = result.Scores[player]
+	}
+
+	result.BookingID = booking.ID
+	result.Scores = scores
+	result.ReportedBy = user.Username
+
+	saved, err := s.repo.UpsertResult(ctx, result)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	s.record(ctx, "booking.result", booking.ID, map[string]any{"winner": saved.Winner})
+
+	return saved, nil
+}
+
+func (s *Service) FindResult(ctx context.Context, id string) (Result, error) {
+	return s.repo.GetResult(ctx, id)
+}
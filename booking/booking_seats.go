@@ -0,0 +1,260 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithOpenSeatsChannel announces in channelID the bookings looking for
+// players, none are announced when it is empty.
+func WithOpenSeatsChannel(channelID string) ServiceOption {
+	return func(s *Service) {
+		s.openSeatsChannelID = channelID
+	}
+}
+
+// JoinBooking adds the user to the players of a booking looking for players.
+// The booking stops looking for players once it reaches the maximum.
+func (s *Service) JoinBooking(ctx context.Context, id string, user discord.DiscordUser) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking.join", trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	if err := s.checkNotSuspended(ctx, user.ID); err != nil {
+		recordError(span, err)
+		return Booking{}, err
+	}
+
+	username := strings.ToLower(user.Username)
+
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		// a private booking is not listed to the user, it cannot be joined
+		if !booking.VisibleTo(&user) {
+			return ErrBookingNotFound
+		}
+
+		if booking.Status != "pending" && booking.Status != "accepted" {
+			return ErrInvalidBookingState
+		}
+
+		if !booking.LookingForPlayers || !booking.DateTime.After(time.Now()) {
+			return ErrNoOpenSeat
+		}
+
+		if booking.UserID == user.ID || slices.Contains(booking.Players, username) {
+			return ErrAlreadyJoined
+		}
+
+		if s.maxPlayers > 0 && len(booking.Players) >= s.maxPlayers {
+			return fmt.Errorf("%w: the booking already has %d players", ErrTooManyPlayers, len(booking.Players))
+		}
+
+		booking.Players = append(slices.Clone(booking.Players), username)
+		booking.LookingForPlayers = s.maxPlayers == 0 || len(booking.Players) < s.maxPlayers
+
+		return tx.UpdateBooking(ctx, booking)
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.publish(ctx, EventBookingModified, booking)
+	s.record(ctx, "booking.join", booking.ID, map[string]any{"player": username})
+
+	return booking, nil
+}
+
+// ReplacePlayer substitutes newPlayer for oldPlayer in the players of a
+// booking, for its organizers, the outgoing player or an admin. The new player
+// must be a member of the server, the booking channel is told of the change.
+func (s *Service) ReplacePlayer(ctx context.Context, id, oldPlayer, newPlayer string, user discord.DiscordUser) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking.replace_player", trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	oldPlayer = strings.ToLower(strings.TrimSpace(oldPlayer))
+	newPlayer = strings.ToLower(strings.TrimSpace(newPlayer))
+
+	// before the transaction, not to hold the booking lock on Discord
+	if err := s.validatePlayers(ctx, []string{newPlayer}); err != nil {
+		recordError(span, err)
+		return Booking{}, err
+	}
+
+	if member, ok := s.members.Get(newPlayer); ok {
+		if err := s.checkNotSuspended(ctx, member.(discord.Member).User.ID); err != nil {
+			recordError(span, err)
+			return Booking{}, err
+		}
+	}
+
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if !booking.VisibleTo(&user) {
+			return ErrBookingNotFound
+		}
+
+		if !user.Admin && !checkUserAllowed(booking, user) && strings.ToLower(user.Username) != oldPlayer {
+			return ErrNotAllowed
+		}
+
+		if booking.Status != "pending" && booking.Status != "accepted" {
+			return ErrInvalidBookingState
+		}
+
+		i := slices.Index(booking.Players, oldPlayer)
+
+		if i < 0 {
+			return fmt.Errorf("%w: '%v'", ErrPlayerNotFound, oldPlayer)
+		}
+
+		if slices.Contains(booking.Players, newPlayer) {
+			return fmt.Errorf("%w: '%v'", ErrAlreadyJoined, newPlayer)
+		}
+
+		booking.Players = slices.Clone(booking.Players)
+		booking.Players[i] = newPlayer
+
+		return tx.UpdateBooking(ctx, booking)
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.publish(ctx, EventBookingModified, booking)
+	s.record(ctx, "booking.replace-player", booking.ID, map[string]any{"oldPlayer": oldPlayer, "newPlayer": newPlayer})
+	s.notify(ctx, booking, NotificationOptions{
+		message: title("Player Replaced", ":arrows_counterclockwise:"),
+		reason:  fmt.Sprintf("%v remplace %v", newPlayer, oldPlayer),
+	})
+
+	return booking, nil
+}
+
+// LeaveBooking removes the user from the players of a booking that has not
+// started, the organizers being told in the booking channel. Its owner cancels
+// it instead.
+func (s *Service) LeaveBooking(ctx context.Context, id string, user discord.DiscordUser) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking.leave", trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	username := strings.ToLower(user.Username)
+
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if !booking.VisibleTo(&user) {
+			return ErrBookingNotFound
+		}
+
+		if !isOpen(booking, time.Now()) {
+			return ErrInvalidBookingState
+		}
+
+		if !slices.Contains(booking.Players, username) {
+			return fmt.Errorf("%w: '%v'", ErrPlayerNotFound, username)
+		}
+
+		if booking.UserID == user.ID {
+			return ErrNotAllowed
+		}
+
+		if err := s.checkNotLocked(booking, user); err != nil {
+			return err
+		}
+
+		booking.Players = slices.DeleteFunc(slices.Clone(booking.Players), func(player string) bool { return player == username })
+
+		return tx.UpdateBooking(ctx, booking)
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	s.publish(ctx, EventBookingModified, booking)
+	s.record(ctx, "booking.leave", booking.ID, map[string]any{"player": username})
+	s.notify(ctx, booking, NotificationOptions{
+		message: title("Player Left", ":wave:"),
+		reason:  fmt.Sprintf("%v quitte la partie", username),
+	})
+
+	return booking, nil
+}
+
+// announceOpenSeats posts the booking to the open seats channel, for members
+// to join it.
+func (s *Service) announceOpenSeats(ctx context.Context, booking Booking) {
+	// private bookings are not advertised to the other members
+	channelID := s.guildSettings(ctx).OpenSeatsChannelID
+
+	if len(channelID) == 0 || booking.Visibility == VisibilityPrivate {
+		return
+	}
+
+	message := s.openSeatsMessage(booking)
+
+	s.dispatcher.Dispatch(ctx, func(ctx context.Context) {
+		if err := s.messages.SendMessage(ctx, channelID, message); err != nil {
+			slog.Error("failed to announce open seats", "bookingId", booking.ID, "err", err)
+		}
+	})
+}
+
+func (s *Service) openSeatsMessage(booking Booking) discord.Message {
+	players := strconv.Itoa(len(booking.Players))
+
+	if s.maxPlayers > 0 {
+		players += "/" + strconv.Itoa(s.maxPlayers)
+	}
+
+	return discord.Message{
+		Content: fmt.Sprintf("<@%v> cherche des joueurs pour une partie de %v !", booking.UserID, booking.Game),
+		Embeds: []discord.Embed{{
+			Type:  "rich",
+			Title: "Places disponibles :chair:",
+			Fields: []discord.EmbedField{
+				{Name: "Date et Heure", Value: booking.DateTime.In(s.location).Format(time.DateTime), Inline: true},
+				{Name: "Jeu", Value: booking.Game, Inline: true},
+				{Name: "Points", Value: strconv.Itoa(booking.Points), Inline: true},
+				{Name: "Joueurs", Value: players, Inline: true},
+			},
+		}},
+	}
+}
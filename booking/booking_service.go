@@ -3,166 +3,1018 @@ package booking
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/hanksha/tbz-booking-system-backend/audit"
 	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/hanksha/tbz-booking-system-backend/notification"
+	"github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/hanksha/tbz-booking-system-backend/booking")
+
 type BookingRepository interface {
 	GetActiveBookings(ctx context.Context) ([]Booking, error)
+	GetActiveBookingsIncludingDeleted(ctx context.Context) ([]Booking, error)
 	GetBookingByID(ctx context.Context, id string) (Booking, error)
-	GetBookingsPerUsername(ctx context.Context, username string) ([]Booking, error)
+	GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]Booking, error)
+	GetMatchingBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) ([]Booking, error)
+	CountBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) (int, error)
+	GetBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]Booking, error)
+	GetBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]Booking, error)
+	GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter Filter, after *Cursor, limit int) ([]Booking, error)
+	SearchBookings(ctx context.Context, user *discord.DiscordUser, terms []string, limit int) ([]SearchResult, error)
 	InsertBooking(ctx context.Context, booking Booking) (Booking, error)
 	InsertManyBookings(ctx context.Context, bookings []Booking) error
 	UpdateBooking(ctx context.Context, booking Booking) error
 	SetBookingStatus(ctx context.Context, id string, status string) error
+	InsertAuditEntry(ctx context.Context, entry audit.Entry) error
+	SetPriority(ctx context.Context, id, priority string) error
+	SetCheckedIn(ctx context.Context, id string, at time.Time) error
+	SetPaymentStatus(ctx context.Context, id, status string) error
+	InsertPayment(ctx context.Context, payment Payment) (Payment, error)
+	GetPayment(ctx context.Context, bookingID string) (Payment, error)
+	DeleteBooking(ctx context.Context, id string) error
+	PurgeDeletedBookings(ctx context.Context, before time.Time) (int64, error)
+	MarkBookingEscalated(ctx context.Context, id string) (bool, error)
+	SetNotificationError(ctx context.Context, id, message string) error
+	SetCalendarSync(ctx context.Context, id string, sync CalendarSync) error
+	SetVoiceChannel(ctx context.Context, id, channelID string) error
+	GetVoiceChannelBookings(ctx context.Context) ([]Booking, error)
+	SetReminders(ctx context.Context, id string, reminders Reminders) error
+	SetReminderOptOut(ctx context.Context, id, username string, optOut bool) error
+	MarkReminderSent(ctx context.Context, id string, leadTime int, dateTime time.Time) (bool, error)
+	SetReminderResponse(ctx context.Context, id string, response ReminderResponse) error
+	ClearSnooze(ctx context.Context, id, username string, snoozedUntil time.Time) (bool, error)
+	UpsertResult(ctx context.Context, result Result) (Result, error)
+	GetResult(ctx context.Context, bookingID string) (Result, error)
+	InsertAttachment(ctx context.Context, attachment Attachment) (Attachment, error)
+	GetAttachments(ctx context.Context, bookingID string) ([]Attachment, error)
+	GetAttachment(ctx context.Context, bookingID, id string) (Attachment, error)
+	DeleteAttachment(ctx context.Context, bookingID, id string) error
 	GetBookingCountPerGame(ctx context.Context) ([]GameBookingCount, error)
+	GetBookingCountPerTag(ctx context.Context) ([]TagBookingCount, error)
+	GetBookingCountPerPair(ctx context.Context, limit int) ([]PairBookingCount, error)
+	GetDecisions(ctx context.Context, start, end time.Time) ([]Decision, error)
+	GetNoShowCounts(ctx context.Context, start, end time.Time) ([]NoShowCount, error)
+	GetOrganizerHistories(ctx context.Context, userIDs []string, before time.Time) ([]OrganizerHistory, error)
+	GetEquipmentAvailability(ctx context.Context, ids []string, at time.Time, bookingID string) ([]EquipmentAvailability, error)
+	SetEquipment(ctx context.Context, bookingID string, reservations []Reservation) error
+	GetFavoriteGames(ctx context.Context, userID, username string, limit int) ([]GameBookingCount, error)
+	GetBookedSlots(ctx context.Context, from, to time.Time, username string) ([]BookedSlot, error)
 	GetBookingCountPerWeekDay(ctx context.Context) ([]WeekDayBookingCount, error)
 	GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]GameBookingCount, error)
+	WithTx(ctx context.Context, fn func(tx BookingRepository) error) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+// MessageSender sends Discord messages, the Discord client itself unless
+// WithMessageSender wraps it, to retry failed sends for instance.
+type MessageSender interface {
+	SendMessage(ctx context.Context, channelID string, message discord.Message) error
+}
+
+// Dispatcher runs notification tasks, off the request path when it is a
+// notification.Dispatcher. Tasks run inline by default.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, task func(ctx context.Context))
+}
+
+type inlineDispatcher struct{}
+
+func (inlineDispatcher) Dispatch(ctx context.Context, task func(ctx context.Context)) {
+	task(ctx)
+}
+
+type SuspensionChecker interface {
+	IsSuspended(ctx context.Context, userID string) (suspended bool, reason string, err error)
+}
+
+// TrustChecker tells whether the bookings of a user are accepted without
+// review, usually from the users the admins trusted.
+type TrustChecker interface {
+	IsTrusted(ctx context.Context, userID string) (bool, error)
+}
+
+// PointsChecker tells whether a game may be booked for the given points,
+// usually from the game catalog.
+type PointsChecker interface {
+	CheckPoints(ctx context.Context, game string, points int) (valid bool, reason string, err error)
+}
+
+// FieldsChecker tells whether the custom fields of a booking match the
+// fields defined for its game, usually from the game catalog.
+type FieldsChecker interface {
+	CheckFields(ctx context.Context, game string, fields map[string]any) (valid bool, reason string, err error)
+}
+
+// VenueChecker tells whether a booking may hold a table of a venue at the
+// given time, the booking with bookingID excepted from the tables taken.
+type VenueChecker interface {
+	CheckVenue(ctx context.Context, venueID string, at time.Time, bookingID string) (valid bool, reason string, err error)
+}
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+	defaultPairLimit    = 20
+	maxPairLimit        = 200
+)
+
+// HistoryPage is a page of the booking history. NextCursor is empty on the
+// last page.
+type HistoryPage struct {
+	Bookings   []Booking `json:"bookings"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 type Service struct {
-	repo      BookingRepository
-	client    discord.DiscordClient
-	channelID string
+	repo       BookingRepository
+	client     discord.DiscordClient
+	messages   MessageSender
+	dispatcher Dispatcher
+	// members caches the Discord users of the players, by username
+	members    *cache.Cache
+	active     *activeBookingsCache
+	channelID  string
+	events     []EventPublisher
+	audit      AuditRecorder
+	bans       SuspensionChecker
+	trust      TrustChecker
+	points     PointsChecker
+	fields     FieldsChecker
+	venues     VenueChecker
+	escalation *EscalationConfig
+	autoAccept *AutoAcceptConfig
+	payments   PaymentProvider
+	membership MemberChecker
+	// primeTime are the slots reserved to members and whitelisted guests
+	primeTime []PrimeTime
+	// paymentConfig is the table fee charged when payments is set
+	paymentConfig PaymentConfig
+	// lockChanges rejects the modifications and cancellations from
+	// changeCutoff before the booking starts, admins excepted
+	lockChanges  bool
+	changeCutoff time.Duration
+	// maxPlayers bounds the players of a booking, unbounded when zero
+	maxPlayers int
+	// checkPlayers rejects bookings with players missing from the server
+	checkPlayers bool
+	// location is the guild's time zone, dates are rendered in it
+	location *time.Location
+	// openSeatsChannelID receives the bookings looking for players
+	openSeatsChannelID string
+	// settings overrides the channels and the admin role when set
+	settings func(ctx context.Context) GuildSettings
+	// tables and sessionTimes bound the free slots suggested to the users
+	tables       int
+	sessionTimes []time.Duration
+	// allowedTags is the curated list of tags, any tag goes when empty
+	allowedTags []string
+	// checkInSecret signs the check-in codes, check-in is disabled when empty
+	checkInSecret []byte
+	checkInURL    string
+	// attachments stores the files attached to the bookings, refused when nil
+	attachments       AttachmentStorage
+	maxAttachmentSize int64
+	// warnConflicts creates the conflicting bookings with warnings
+	warnConflicts bool
+	// reminderButtons adds the acknowledge and snooze buttons to the
+	// reminders
+	reminderButtons bool
+}
+
+type ServiceOption func(*Service)
+
+func WithEventPublisher(publisher EventPublisher) ServiceOption {
+	return func(s *Service) {
+		s.events = append(s.events, publisher)
+	}
+}
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func WithMessageSender(sender MessageSender) ServiceOption {
+	return func(s *Service) {
+		s.messages = sender
+	}
+}
+
+func WithDispatcher(dispatcher Dispatcher) ServiceOption {
+	return func(s *Service) {
+		s.dispatcher = dispatcher
+	}
+}
+
+// WithActiveBookingsCache caches the active bookings for ttl, writes going
+// through the service invalidate them right away.
+func WithActiveBookingsCache(ttl time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.active = &activeBookingsCache{ttl: ttl}
+	}
+}
+
+// WithLocation sets the time zone the booking dates are rendered in, in
+// notifications and reminders. Europe/Paris by default.
+func WithLocation(loc *time.Location) ServiceOption {
+	return func(s *Service) {
+		s.location = loc
+	}
+}
+
+// WithChangeCutoff rejects the modifications and cancellations of bookings
+// starting in less than cutoff, or already started when it is zero. Admins
+// can still change them.
+func WithChangeCutoff(cutoff time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.lockChanges = true
+		s.changeCutoff = cutoff
+	}
+}
+
+func WithPointsChecker(checker PointsChecker) ServiceOption {
+	return func(s *Service) {
+		s.points = checker
+	}
+}
+
+// WithVenueChecker rejects the bookings in a venue that is closed or has no
+// table left at their time.
+func WithVenueChecker(checker VenueChecker) ServiceOption {
+	return func(s *Service) {
+		s.venues = checker
+	}
+}
+
+// WithAllowedTags restricts the tags of the bookings to a curated list.
+func WithAllowedTags(tags []string) ServiceOption {
+	return func(s *Service) {
+		s.allowedTags = NormalizeTags(tags)
+	}
+}
+
+// WithMaxPlayers rejects the bookings with more than max players.
+func WithMaxPlayers(max int) ServiceOption {
+	return func(s *Service) {
+		s.maxPlayers = max
+	}
+}
+
+// WithPlayerValidation rejects the bookings created or modified with players
+// that are not members of the Discord server.
+func WithPlayerValidation() ServiceOption {
+	return func(s *Service) {
+		s.checkPlayers = true
+	}
+}
+
+func WithSuspensionChecker(checker SuspensionChecker) ServiceOption {
+	return func(s *Service) {
+		s.bans = checker
+	}
+}
+
+func WithFieldsChecker(checker FieldsChecker) ServiceOption {
+	return func(s *Service) {
+		s.fields = checker
+	}
+}
+
+func WithTrustChecker(checker TrustChecker) ServiceOption {
+	return func(s *Service) {
+		s.trust = checker
+	}
 }
 
-func NewService(repo BookingRepository, client discord.DiscordClient, channelID string) *Service {
-	return &Service{repo: repo, client: client, channelID: channelID}
+func NewService(repo BookingRepository, client discord.DiscordClient, channelID string, opts ...ServiceOption) *Service {
+	s := &Service{
+		repo:       repo,
+		client:     client,
+		messages:   client,
+		dispatcher: inlineDispatcher{},
+		channelID:  channelID,
+		members:    cache.New(memberCacheTTL, 2*memberCacheTTL),
+		location:   defaultLocation(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func defaultLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Paris")
+
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
 }
 
 func (s *Service) GetActiveBookings(ctx context.Context) ([]Booking, error) {
-	return s.repo.GetActiveBookings(ctx)
+	if s.active == nil {
+		return s.repo.GetActiveBookings(ctx)
+	}
+
+	return s.active.get(ctx, s.repo.GetActiveBookings)
+}
+
+// GetVisibleBookings returns the active bookings listed to user, nil being an
+// anonymous visitor. The cached bookings are filtered with the rule the
+// repository queries apply.
+func (s *Service) GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]Booking, error) {
+	if s.active == nil {
+		return s.repo.GetVisibleBookings(ctx, user)
+	}
+
+	bookings, err := s.active.get(ctx, s.repo.GetActiveBookings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]Booking, 0, len(bookings))
+
+	for _, booking := range bookings {
+		if booking.VisibleTo(user) {
+			visible = append(visible, booking)
+		}
+	}
+
+	return visible, nil
+}
+
+// CacheInvalidator returns the publisher to feed with the events of other
+// replicas, so their writes invalidate the active bookings cache.
+func (s *Service) CacheInvalidator() EventPublisher {
+	if s.active == nil {
+		return EventPublisherFunc(func(ctx context.Context, event Event) {})
+	}
+
+	return s.active
+}
+
+func (s *Service) GetActiveBookingsIncludingDeleted(ctx context.Context) ([]Booking, error) {
+	return s.repo.GetActiveBookingsIncludingDeleted(ctx)
 }
 
 func (s *Service) FindBookingByID(ctx context.Context, id string) (Booking, error) {
 	return s.repo.GetBookingByID(ctx, id)
 }
 
-func (s *Service) FindBookingsPerUsername(ctx context.Context, username string) ([]Booking, error) {
-	return s.repo.GetBookingsPerUsername(ctx, username)
+func (s *Service) FindBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]Booking, error) {
+	return s.repo.GetBookingsByIDs(ctx, user, ids)
+}
+
+// FindBookings returns the bookings listed to user that match the filter, the
+// active ones unless it starts at a date of its own. Only the bookings of an
+// empty filter come from the cache, the others are selected by the
+// repository.
+func (s *Service) FindBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) ([]Booking, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	if filter.IsZero() {
+		return s.GetVisibleBookings(ctx, user)
+	}
+
+	return s.repo.GetMatchingBookings(ctx, user, filter)
+}
+
+// CountBookings counts the bookings listed to user that match the filter, the
+// active ones unless it starts at a date of its own, for the badges of the
+// frontend.
+func (s *Service) CountBookings(ctx context.Context, user *discord.DiscordUser, filter Filter) (int, error) {
+	if err := filter.Normalize(); err != nil {
+		return 0, err
+	}
+
+	return s.repo.CountBookings(ctx, user, filter)
+}
+
+// FindBookingsPerUsername returns the bookings username organizes or plays,
+// whatever its case: the players are stored lowercase, the organizers as
+// Discord spells them.
+func (s *Service) FindBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]Booking, error) {
+	return s.repo.GetBookingsPerUsername(ctx, user, strings.ToLower(username))
+}
+
+// GetBookingHistory pages through every booking listed to user, most recent
+// first. cursor is the NextCursor of the previous page, or empty for the
+// first one.
+func (s *Service) GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter Filter, cursor string, limit int) (HistoryPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return HistoryPage{}, err
+	}
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	var after *Cursor
+
+	if len(cursor) != 0 {
+		decoded, err := DecodeCursor(cursor)
+
+		if err != nil {
+			return HistoryPage{}, err
+		}
+
+		after = &decoded
+	}
+
+	// one extra row tells whether there is a next page
+	bookings, err := s.repo.GetBookingHistory(ctx, user, filter, after, limit+1)
+
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	page := HistoryPage{Bookings: bookings}
+
+	if len(bookings) > limit {
+		page.Bookings = bookings[:limit]
+
+		next, err := CursorAfter(page.Bookings[limit-1])
+
+		if err != nil {
+			return HistoryPage{}, err
+		}
+
+		page.NextCursor = next.Encode()
+	}
+
+	return page, nil
+}
+
+// SearchBookings finds the bookings matching every word of query, as
+// prefixes so partial words match too.
+func (s *Service) SearchBookings(ctx context.Context, user *discord.DiscordUser, query string, limit int) ([]SearchResult, error) {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(terms) == 0 {
+		return nil, ErrInvalidSearch
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return s.repo.SearchBookings(ctx, user, terms, limit)
 }
 
 func (s *Service) CreateBooking(ctx context.Context, booking Booking) (Booking, error) {
-	booking, err := s.repo.InsertBooking(ctx, booking)
+	booking, _, err := s.CreateBookingWithWarnings(ctx, booking)
+	return booking, err
+}
+
+// CreateBookingWithWarnings creates a booking like CreateBooking. With
+// WithConflictWarnings, a booking conflicting with its venue or other
+// bookings is created anyway and the conflicts are returned.
+func (s *Service) CreateBookingWithWarnings(ctx context.Context, booking Booking) (Booking, []Warning, error) {
+	ctx, span := tracer.Start(ctx, "booking.create", trace.WithAttributes(attribute.String("booking.game", booking.Game)))
+	defer span.End()
+
+	// the stored organizer is the authenticated user, the auto-accept job
+	// trusting it later on
+	if user, ok := discord.UserFromContext(ctx); ok && !user.Admin {
+		booking.UserID, booking.Username = user.ID, user.Username
+	}
+
+	if err := s.checkNotSuspended(ctx, booking.UserID); err != nil {
+		return Booking{}, nil, err
+	}
+
+	if err := s.validate(ctx, &booking); err != nil {
+		return Booking{}, nil, err
+	}
+
+	if err := s.checkPrimeTime(ctx, booking); err != nil {
+		return Booking{}, nil, err
+	}
+
+	if s.checkPlayers {
+		if err := s.validatePlayers(ctx, booking.Players); err != nil {
+			return Booking{}, nil, err
+		}
+	}
+
+	if booking.Visibility == "" {
+		booking.Visibility = VisibilityPublic
+	}
+
+	var warnings []Warning
+
+	if s.warnConflicts {
+		found, err := s.conflictWarnings(ctx, booking)
+
+		if err != nil {
+			return Booking{}, nil, err
+		}
+
+		warnings = found
+	} else if err := s.checkVenue(ctx, booking); err != nil {
+		return Booking{}, nil, err
+	}
+
+	// the conflicting bookings of trusted users are left to the admins too
+	trusted := len(warnings) == 0 && s.isTrusted(ctx, organizerID(ctx, booking))
+	booking.Status = "pending"
+	booking.Priority = priorityFor(ctx, booking.Priority)
+
+	var event *auditEvent
+
+	if trusted {
+		booking.Status = "accepted"
+		event = &auditEvent{action: "booking.auto-accept", payload: map[string]any{"rule": ruleTrusted, "reason": trustedReason}}
+	}
+
+	var err error
+
+	if len(booking.Equipment) == 0 && event == nil {
+		booking, err = s.repo.InsertBooking(ctx, booking)
+	} else {
+		err = s.repo.WithTx(ctx, func(tx BookingRepository) error {
+			inserted, err := tx.InsertBooking(ctx, booking)
+
+			if err != nil {
+				return err
+			}
+
+			if len(booking.Equipment) != 0 {
+				inserted.Equipment = booking.Equipment
+
+				if err := s.reserveEquipment(ctx, tx, &inserted); err != nil {
+					return err
+				}
+			}
+
+			if err := s.recordTx(ctx, tx, inserted.ID, event); err != nil {
+				return err
+			}
+
+			booking = inserted
+
+			return nil
+		})
+	}
+	recordError(span, err)
 
 	if err == nil {
-		s.sendNotification(ctx, booking, NotificationOptions{message: "Nouvelle Réservation :calendar:"})
+		notification := NotificationOptions{message: title("New Booking", ":calendar:")}
+
+		if trusted {
+			notification = NotificationOptions{message: title("New Booking Automatically Accepted", ":zap:"), reason: trustedReason}
+		}
+
+		s.publish(ctx, EventBookingCreated, booking)
+		s.notify(ctx, booking, notification)
+
+		if trusted {
+			s.requestPayment(ctx, booking)
+		}
+
+		if booking.LookingForPlayers {
+			s.announceOpenSeats(ctx, booking)
+		}
 	}
 
-	return booking, err
+	if err != nil {
+		return booking, nil, err
+	}
+
+	return booking, warnings, nil
 }
 
 func (s *Service) ImportBookings(ctx context.Context, bookings []Booking) error {
+	for i := range bookings {
+		bookings[i].Players = normalizePlayers(bookings[i].Players)
+
+		if bookings[i].Visibility == "" {
+			bookings[i].Visibility = VisibilityPublic
+		}
+	}
+
 	err := s.repo.InsertManyBookings(ctx, bookings)
 
+	if err == nil {
+		s.invalidate()
+		s.record(ctx, "booking.import", "", map[string]any{"count": len(bookings)})
+	}
+
 	return err
 }
 
 func (s *Service) ModifyBooking(ctx context.Context, updated Booking, user discord.DiscordUser) error {
-	booking, err := s.repo.GetBookingByID(ctx, updated.ID)
+	ctx, span := tracer.Start(ctx, "booking.modify", trace.WithAttributes(attribute.String("booking.id", updated.ID)))
+	defer span.End()
 
-	if err != nil {
+	if err := s.validate(ctx, &updated); err != nil {
+		recordError(span, err)
 		return err
 	}
 
-	if booking.Status != "pending" {
-		return ErrInvalidBookingState
+	if s.checkPlayers {
+		// before the transaction, not to hold the booking lock on Discord
+		if err := s.validatePlayers(ctx, updated.Players); err != nil {
+			recordError(span, err)
+			return err
+		}
 	}
 
-	if !checkUserAllowed(booking, user) {
-		return ErrNotAllowed
+	var booking, before Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, updated.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if !CanModify(booking, user) {
+			if booking.Status != "pending" && booking.Status != "accepted" {
+				return ErrInvalidBookingState
+			}
+
+			return ErrNotAllowed
+		}
+
+		if err := s.checkNotLocked(booking, user); err != nil {
+			return err
+		}
+
+		before = booking
+		booking.Game = updated.Game
+		booking.Points = updated.Points
+		booking.Description = updated.Description
+		booking.ReminderEnabled = updated.ReminderEnabled
+		booking.DateTime = updated.DateTime
+		booking.Players = updated.Players
+		booking.LookingForPlayers = updated.LookingForPlayers
+
+		// the co-organizers cannot delegate further
+		if updated.CoOrganizers != nil && (user.Admin || booking.UserID == user.ID) {
+			booking.CoOrganizers = updated.CoOrganizers
+		}
+		booking.Tags = updated.Tags
+		booking.CustomFields = updated.CustomFields
+
+		if updated.Visibility != "" {
+			booking.Visibility = updated.Visibility
+		}
+
+		if updated.VenueID != "" {
+			booking.VenueID = updated.VenueID
+		}
+
+		// the venue must still have a table at the new date
+		if booking.VenueID != before.VenueID || !booking.DateTime.Equal(before.DateTime) {
+			if err := s.checkVenue(ctx, booking); err != nil {
+				return err
+			}
+		}
+
+		// moving a booking must not get around the prime-time reservation
+		if !booking.DateTime.Equal(before.DateTime) {
+			if err := s.checkPrimeTime(ctx, booking); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.UpdateBooking(ctx, booking); err != nil {
+			return err
+		}
+
+		if updated.Equipment != nil {
+			booking.Equipment = updated.Equipment
+		}
+
+		// the equipment reserved must still be available at the new date
+		if updated.Equipment != nil || (len(booking.Equipment) != 0 && !booking.DateTime.Equal(before.DateTime)) {
+			if err := s.reserveEquipment(ctx, tx, &booking); err != nil {
+				return err
+			}
+		}
+
+		// an admin sending the booking back to pending has it reviewed again
+		if booking.Status == "accepted" && updated.Status == "pending" {
+			booking.Status = "pending"
+			return tx.SetBookingStatus(ctx, booking.ID, booking.Status)
+		}
+
+		return nil
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return err
 	}
 
-	booking.Game = updated.Game
-	booking.Points = updated.Points
-	booking.Description = updated.Description
-	booking.ReminderEnabled = updated.ReminderEnabled
-	booking.DateTime = updated.DateTime
-	booking.Players = updated.Players
+	s.publish(ctx, EventBookingModified, booking)
+	s.notify(ctx, booking, NotificationOptions{message: title("Booking Modified", ":pencil:")})
 
-	err = s.repo.UpdateBooking(ctx, booking)
+	if before.Status == "accepted" {
+		s.notifyChanges(ctx, before, booking)
+	}
 
-	if err == nil {
-		s.sendNotification(ctx, booking, NotificationOptions{message: "Réservation Modifiée :pencil:"})
+	if booking.LookingForPlayers && !before.LookingForPlayers {
+		s.announceOpenSeats(ctx, booking)
 	}
 
-	return err
+	return nil
 }
 
 func (s *Service) AcceptBooking(ctx context.Context, id string) error {
-	booking, err := s.repo.GetBookingByID(ctx, id)
+	booking, err := s.setStatus(ctx, id, "accept", "accepted", &auditEvent{action: "booking.accept"}, func(booking Booking) error {
+		if booking.Status == "canceled" || booking.Status == "accepted" {
+			return ErrInvalidBookingState
+		}
+
+		return s.checkPriority(ctx, booking)
+	})
 
 	if err != nil {
 		return err
 	}
 
-	if booking.Status == "canceled" || booking.Status == "accepted" {
-		return ErrInvalidBookingState
-	}
+	s.publish(ctx, EventBookingAccepted, booking)
+	s.notify(ctx, booking, NotificationOptions{message: title("Booking Accepted", ":white_check_mark:")})
+	s.requestPayment(ctx, booking)
 
-	err = s.repo.SetBookingStatus(ctx, id, "accepted")
+	return nil
+}
 
-	if err == nil {
-		s.sendNotification(ctx, booking, NotificationOptions{message: "Réservation Acceptée :white_check_mark:"})
+func (s *Service) RefuseBooking(ctx context.Context, id, reason string) error {
+	refused := &auditEvent{action: "booking.refuse", payload: map[string]any{"reason": reason}}
+	booking, err := s.setStatus(ctx, id, "refuse", "refused", refused, func(booking Booking) error {
+		if booking.Status == "refused" || booking.Status == "canceled" {
+			return ErrInvalidBookingState
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
 	}
 
-	return err
+	s.publish(ctx, EventBookingRefused, booking)
+	s.notify(ctx, booking, NotificationOptions{message: title("Booking Refused", ":no_entry:"), reason: reason})
+
+	return nil
 }
 
-func (s *Service) RefuseBooking(ctx context.Context, id, reason string) error {
-	booking, err := s.repo.GetBookingByID(ctx, id)
+func (s *Service) CancelBooking(ctx context.Context, id string, user discord.DiscordUser) error {
+	booking, err := s.setStatus(ctx, id, "cancel", "canceled", nil, func(booking Booking) error {
+		if booking.Status == "canceled" || booking.Status == "refused" {
+			return ErrInvalidBookingState
+		}
+
+		if !user.Admin && !checkUserAllowed(booking, user) {
+			return ErrNotAllowed
+		}
+
+		return s.checkNotLocked(booking, user)
+	})
 
 	if err != nil {
 		return err
 	}
 
-	if booking.Status == "refused" || booking.Status == "canceled" {
-		return ErrInvalidBookingState
-	}
+	s.publish(ctx, EventBookingCanceled, booking)
+	s.notify(ctx, booking, NotificationOptions{message: title("Booking Canceled", ":negative_squared_cross_mark:")})
 
-	err = s.repo.SetBookingStatus(ctx, id, "refused")
+	return nil
+}
 
-	if err == nil {
-		s.sendNotification(ctx, booking, NotificationOptions{message: "Réservation Refusée :no_entry:", reason: reason})
+// ReopenBooking puts a canceled or refused booking back to pending while its
+// slot is still free: a table left, the venue open and the equipment it
+// reserved available.
+func (s *Service) ReopenBooking(ctx context.Context, id string) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking.reopen", trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if booking.Status != "canceled" && booking.Status != "refused" {
+			return fmt.Errorf("%w: only canceled and refused bookings can be reopened", ErrInvalidBookingState)
+		}
+
+		if !booking.DateTime.After(time.Now()) {
+			return fmt.Errorf("%w: the booking is over", ErrInvalidBookingState)
+		}
+
+		if err := s.checkTableLeft(ctx, tx, booking); err != nil {
+			return err
+		}
+
+		if err := s.checkVenue(ctx, booking); err != nil {
+			return err
+		}
+
+		if len(booking.Equipment) != 0 {
+			if err := s.reserveEquipment(ctx, tx, &booking); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.SetBookingStatus(ctx, id, "pending"); err != nil {
+			return fmt.Errorf("failed to reopen booking: %w", err)
+		}
+
+		return s.recordTx(ctx, tx, id, &auditEvent{action: "booking.reopen", payload: map[string]any{"from": booking.Status}})
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return Booking{}, err
 	}
 
-	return err
+	booking.Status = "pending"
+
+	s.publish(ctx, EventBookingReopened, booking)
+	s.notify(ctx, booking, NotificationOptions{message: title("Booking Reopened", ":recycle:")})
+
+	return booking, nil
 }
 
-func (s *Service) CancelBooking(ctx context.Context, id string, user discord.DiscordUser) error {
-	booking, err := s.repo.GetBookingByID(ctx, id)
+// checkTableLeft fails with ErrSlotTaken when the other bookings hold every
+// table while the booking would, read through tx. Without sessions the tables
+// are not counted.
+func (s *Service) checkTableLeft(ctx context.Context, tx BookingRepository, booking Booking) error {
+	if s.tables == 0 {
+		return nil
+	}
+
+	bookings, err := tx.GetActiveBookings(ctx)
 
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get active bookings: %w", err)
 	}
 
-	if booking.Status == "canceled" || booking.Status == "refused" {
-		return ErrInvalidBookingState
+	taken := 0
+
+	for _, other := range bookings {
+		held := other.Status == "pending" || other.Status == "accepted"
+
+		if held && other.ID != booking.ID && other.DateTime.After(booking.DateTime.Add(-TableDuration)) && other.DateTime.Before(booking.DateTime.Add(TableDuration)) {
+			taken++
+		}
 	}
 
-	if !checkUserAllowed(booking, user) {
-		return ErrNotAllowed
+	if taken >= s.tables {
+		return fmt.Errorf("%w: the %d tables are taken at that time", ErrSlotTaken, s.tables)
 	}
 
-	err = s.repo.SetBookingStatus(ctx, id, "canceled")
+	return nil
+}
+
+// DeleteBooking soft-deletes a booking, it stays in the database until
+// purged by PurgeDeletedBookings.
+func (s *Service) DeleteBooking(ctx context.Context, id string) error {
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err := tx.DeleteBooking(ctx, id); err != nil {
+			return err
+		}
+
+		return s.recordTx(ctx, tx, id, &auditEvent{action: "booking.delete"})
+	})
 
 	if err != nil {
-		return fmt.Errorf("failed to cancel booking: %w", err)
+		return err
 	}
 
-	s.sendNotification(ctx, booking, NotificationOptions{message: "Réservation Annulée :negative_squared_cross_mark:"})
+	s.publish(ctx, EventBookingDeleted, booking)
 
 	return nil
 }
 
+// PurgeDeletedBookings permanently removes the bookings deleted for longer
+// than retention.
+func (s *Service) PurgeDeletedBookings(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.repo.PurgeDeletedBookings(ctx, time.Now().Add(-retention))
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.record(ctx, "booking.purge", "", map[string]any{"count": purged, "retention": retention.String()})
+
+	return purged, nil
+}
+
+// setStatus loads the booking, validates the transition with check and
+// updates its status in a single transaction, so two concurrent transitions
+// can't both succeed. The audit event, when set, is written in the same
+// transaction. Other side effects are left to the caller, once committed.
+func (s *Service) setStatus(ctx context.Context, id, action, status string, event *auditEvent, check func(booking Booking) error) (Booking, error) {
+	ctx, span := tracer.Start(ctx, "booking."+action, trace.WithAttributes(attribute.String("booking.id", id)))
+	defer span.End()
+
+	var booking Booking
+
+	err := s.repo.WithTx(ctx, func(tx BookingRepository) error {
+		var err error
+		booking, err = tx.GetBookingByID(ctx, id)
+
+		if err != nil {
+			return err
+		}
+
+		if err := check(booking); err != nil {
+			return err
+		}
+
+		if err := tx.SetBookingStatus(ctx, id, status); err != nil {
+			return fmt.Errorf("failed to %v booking: %w", action, err)
+		}
+
+		return s.recordTx(ctx, tx, id, event)
+	})
+	recordError(span, err)
+
+	if err != nil {
+		return Booking{}, err
+	}
+
+	booking.Status = status
+
+	return booking, nil
+}
+
 func (s *Service) GetBookingCountPerGame(ctx context.Context) ([]GameBookingCount, error) {
 	return s.repo.GetBookingCountPerGame(ctx)
 }
 
+func (s *Service) GetBookingCountPerTag(ctx context.Context) ([]TagBookingCount, error) {
+	return s.repo.GetBookingCountPerTag(ctx)
+}
+
+// GetBookingCountPerPair returns the pairs of members playing together the
+// most, limit pairs at most.
+func (s *Service) GetBookingCountPerPair(ctx context.Context, limit int) ([]PairBookingCount, error) {
+	if limit <= 0 {
+		limit = defaultPairLimit
+	}
+
+	if limit > maxPairLimit {
+		limit = maxPairLimit
+	}
+
+	return s.repo.GetBookingCountPerPair(ctx, limit)
+}
+
 func (s *Service) GetBookingCountPerGameInPeriod(ctx context.Context, start, end time.Time) ([]GameBookingCount, error) {
 	return s.repo.GetBookingCountPerGameInPeriod(ctx, start, end)
 }
@@ -171,6 +1023,8 @@ func (s *Service) GetBookingCountPerWeekDay(ctx context.Context) ([]WeekDayBooki
 	return s.repo.GetBookingCountPerWeekDay(ctx)
 }
 
+// SendBookingReminders reminds the participants of the bookings of the day,
+// those reminded ahead by SendDueReminders excepted.
 func (s *Service) SendBookingReminders(ctx context.Context) error {
 	activeBookings, err := s.repo.GetActiveBookings(ctx)
 
@@ -178,48 +1032,188 @@ func (s *Service) SendBookingReminders(ctx context.Context) error {
 		return fmt.Errorf("failed to get active bookings: %w", err)
 	}
 
-	nowDate := time.Now()
+	nowDate := time.Now().In(s.location)
 	for _, booking := range activeBookings {
-		if booking.ReminderEnabled && booking.DateTime.Day() == nowDate.Day() && booking.DateTime.Month() == nowDate.Month() && booking.DateTime.Year() == nowDate.Year() {
-			recipients := map[string]struct{}{}
+		dateTime := booking.DateTime.In(s.location)
 
-			if len(booking.UserID) != 0 {
-				recipients[booking.UserID] = struct{}{}
-			}
+		if booking.ReminderEnabled && !booking.Reminders.hasLeadTimes() && dateTime.Day() == nowDate.Day() && dateTime.Month() == nowDate.Month() && dateTime.Year() == nowDate.Year() {
+			s.remind(ctx, booking, s.reminderRecipients(ctx, booking, nowDate), fmt.Sprintf("Rappel pour la réservation de %s aujourd'hui at %s !", booking.Game, dateTime.Format("15:04")))
+		}
+	}
 
-			for _, player := range booking.Players {
-				if player == booking.Username {
-					continue
-				}
+	return nil
+}
 
-				members, err := s.client.SearchMembers(ctx, player, 1)
+// CanModify reports whether user is allowed to modify the booking in its
+// current state: its owner, co-organizers and the admins while it is pending.
+// Accepted bookings are only fixed by admins.
+func CanModify(booking Booking, user discord.DiscordUser) bool {
+	if booking.Status == "accepted" {
+		return user.Admin
+	}
 
-				if err == nil && len(members) != 0 && members[0].User.Username == player {
-					recipients[members[0].User.ID] = struct{}{}
-				}
-			}
+	return booking.Status == "pending" && (user.Admin || checkUserAllowed(booking, user))
+}
 
-			for recipient := range recipients {
-				channelId, err := s.client.GetDMChannel(ctx, recipient)
-				if err != nil {
-					continue
-				}
-				err = s.client.SendMessage(ctx, channelId, discord.Message{
-					Content: fmt.Sprintf("Rappel pour la réservation de %s aujourd'hui at %s !", booking.Game, booking.DateTime.Format("15:04")),
-				})
-			}
-		}
+// CanCancel reports whether user is allowed to cancel the booking in its
+// current state, as its owner, a co-organizer or an admin.
+func CanCancel(booking Booking, user discord.DiscordUser) bool {
+	return booking.Status != "canceled" && booking.Status != "refused" && (user.Admin || checkUserAllowed(booking, user))
+}
+
+// CanJoin reports whether user may take a seat of the booking, one looking
+// for players that has not started and that user is not part of yet.
+func CanJoin(booking Booking, user discord.DiscordUser, now time.Time) bool {
+	return isOpen(booking, now) && booking.LookingForPlayers && booking.UserID != user.ID && !slices.Contains(booking.Players, strings.ToLower(user.Username))
+}
+
+// CanLeave reports whether user may withdraw from the players of the
+// booking. Its owner cancels it instead.
+func CanLeave(booking Booking, user discord.DiscordUser, now time.Time) bool {
+	return isOpen(booking, now) && booking.UserID != user.ID && slices.Contains(booking.Players, strings.ToLower(user.Username))
+}
+
+// isOpen reports whether the players of the booking may still change.
+func isOpen(booking Booking, now time.Time) bool {
+	return (booking.Status == "pending" || booking.Status == "accepted") && booking.DateTime.After(now)
+}
+
+// checkNotLocked fails with ErrBookingLocked when the booking starts within
+// the change cutoff and user is not an admin.
+func (s *Service) checkNotLocked(booking Booking, user discord.DiscordUser) error {
+	if !s.lockChanges || user.Admin {
+		return nil
+	}
+
+	if time.Until(booking.DateTime) < s.changeCutoff {
+		return fmt.Errorf("%w: it starts at %v", ErrBookingLocked, booking.DateTime.In(s.location).Format("02/01 15:04"))
 	}
 
 	return nil
 }
 
+// checkUserAllowed reports whether user manages the booking, as its owner or
+// one of the co-organizers it delegated to. Being listed as a player is not
+// enough.
 func checkUserAllowed(booking Booking, user discord.DiscordUser) bool {
-	if booking.UserID != user.ID && !slices.Contains(booking.Players, user.Username) {
+	return (len(booking.UserID) != 0 && booking.UserID == user.ID) || slices.Contains(booking.CoOrganizers, strings.ToLower(user.Username))
+}
+
+func (s *Service) publish(ctx context.Context, eventType string, booking Booking) {
+	s.invalidate()
+
+	event := Event{Type: eventType, Booking: booking, OccurredAt: time.Now()}
+
+	for _, publisher := range s.events {
+		publisher.Publish(ctx, event)
+	}
+}
+
+func (s *Service) invalidate() {
+	if s.active != nil {
+		s.active.invalidate()
+	}
+}
+
+// checkNotSuspended fails with ErrUserSuspended when the booking owner or the
+// authenticated user is on the ban list.
+func (s *Service) checkNotSuspended(ctx context.Context, userID string) error {
+	if s.bans == nil {
+		return nil
+	}
+
+	userIDs := []string{userID}
+
+	if user, ok := discord.UserFromContext(ctx); ok && user.ID != userID {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	for _, id := range userIDs {
+		if len(id) == 0 {
+			continue
+		}
+
+		suspended, reason, err := s.bans.IsSuspended(ctx, id)
+
+		if err != nil {
+			return fmt.Errorf("failed to check suspension: %w", err)
+		}
+
+		if suspended {
+			return fmt.Errorf("%w: %s", ErrUserSuspended, reason)
+		}
+	}
+
+	return nil
+}
+
+// organizerID is the id of the user organizing the booking: the authenticated
+// user, whatever the booking claims, unless an admin books for someone else.
+func organizerID(ctx context.Context, booking Booking) string {
+	if user, ok := discord.UserFromContext(ctx); ok && !user.Admin {
+		return user.ID
+	}
+
+	return booking.UserID
+}
+
+// isTrusted tells whether the bookings of the organizer skip the admins'
+// review: they hold the trusted role, or the admins trusted them. A failing
+// trust check leaves the booking to review.
+func (s *Service) isTrusted(ctx context.Context, userID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+
+	if user, ok := discord.UserFromContext(ctx); ok && user.ID == userID && user.Trusted {
+		return true
+	}
+
+	if s.trust == nil {
+		return false
+	}
+
+	trusted, err := s.trust.IsTrusted(ctx, userID)
+
+	if err != nil {
+		slog.Warn("Failed to check trust, the booking awaits review", "user", userID, "error", err)
 		return false
 	}
 
-	return true
+	return trusted
+}
+
+func (s *Service) record(ctx context.Context, action, bookingID string, payload any) {
+	if s.audit != nil {
+		s.audit.Record(ctx, action, "booking", bookingID, payload)
+	}
+}
+
+// auditEvent is an action recorded in the transaction of the change it
+// records, so the change is never committed without its entry.
+type auditEvent struct {
+	action  string
+	payload any
+}
+
+// recordTx writes the audit entry of event through tx, when auditing is
+// enabled by WithAuditRecorder. A failure rolls the change back.
+func (s *Service) recordTx(ctx context.Context, tx BookingRepository, bookingID string, event *auditEvent) error {
+	if s.audit == nil || event == nil {
+		return nil
+	}
+
+	entry, err := audit.NewEntry(ctx, event.action, "booking", bookingID, event.payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit payload: %w", err)
+	}
+
+	if err := tx.InsertAuditEntry(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record %v: %w", event.action, err)
+	}
+
+	return nil
 }
 
 type NotificationOptions struct {
@@ -227,14 +1221,91 @@ type NotificationOptions struct {
 	reason  string
 }
 
-func (s *Service) sendNotification(ctx context.Context, booking Booking, options NotificationOptions) error {
-	playerTags := []string{}
+// notificationLanguage is the language of the Discord server the
+// notifications are sent to.
+const notificationLanguage = i18n.French
+
+// title returns the title of a notification from the i18n catalog, followed
+// by its emoji.
+func title(text, emoji string) string {
+	return i18n.Translate(notificationLanguage, text) + " " + emoji
+}
 
-	for _, player := range booking.Players {
-		_members, err := s.client.SearchMembers(ctx, player, 1)
+// notify builds and sends the notification through the dispatcher. The
+// booking is already committed, failures are recorded on it for admins to
+// retry the notification.
+func (s *Service) notify(ctx context.Context, booking Booking, options NotificationOptions) {
+	ctx = notification.WithSubject(ctx, NotificationSubject(booking.ID))
 
-		if err == nil && len(_members) != 0 {
-			playerTags = append(playerTags, fmt.Sprintf("<@%v>", _members[0].User.ID))
+	s.dispatcher.Dispatch(ctx, func(ctx context.Context) {
+		if err := s.sendNotification(ctx, s.messages, booking, options); err != nil {
+			s.RecordNotificationFailure(ctx, booking.ID, err.Error())
+		}
+	})
+}
+
+// NotificationSubject tags the notifications of a booking, see
+// notification.WithSubject.
+func NotificationSubject(bookingID string) string {
+	return "booking:" + bookingID
+}
+
+// RecordNotificationFailure logs the failed notification of a booking and
+// records it on the booking.
+func (s *Service) RecordNotificationFailure(ctx context.Context, id, message string) {
+	slog.Error("failed to send booking notification", "bookingId", id, "err", message)
+
+	if err := s.repo.SetNotificationError(ctx, id, message); err != nil {
+		slog.Error("failed to record booking notification failure", "bookingId", id, "err", err)
+		return
+	}
+
+	s.invalidate()
+}
+
+// statusNotifications titles the notification sent for each status.
+var statusNotifications = map[string]string{
+	"pending":  title("New Booking", ":calendar:"),
+	"accepted": title("Booking Accepted", ":white_check_mark:"),
+	"refused":  title("Booking Refused", ":no_entry:"),
+	"canceled": title("Booking Canceled", ":negative_squared_cross_mark:"),
+}
+
+// RetryNotification sends the notification of the booking's current status
+// again, right away, and clears its notification error once sent.
+func (s *Service) RetryNotification(ctx context.Context, id string) error {
+	booking, err := s.repo.GetBookingByID(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	// the queue would accept the message even with Discord down
+	if err := s.sendNotification(ctx, s.client, booking, NotificationOptions{message: statusNotifications[booking.Status]}); err != nil {
+		s.RecordNotificationFailure(ctx, id, err.Error())
+		return fmt.Errorf("%w: %v", ErrNotificationFailed, err)
+	}
+
+	if err := s.repo.SetNotificationError(ctx, id, ""); err != nil {
+		return err
+	}
+
+	s.invalidate()
+
+	return nil
+}
+
+func (s *Service) sendNotification(ctx context.Context, sender MessageSender, booking Booking, options NotificationOptions) error {
+	ctx, span := tracer.Start(ctx, "booking.notify", trace.WithAttributes(attribute.Int("booking.players", len(booking.Players))))
+	defer span.End()
+
+	channelID := s.guildSettings(ctx).ChannelID
+
+	playerTags := []string{}
+
+	for _, user := range s.resolveMembers(ctx, booking.Players) {
+		if len(user.ID) != 0 {
+			playerTags = append(playerTags, fmt.Sprintf("<@%v>", user.ID))
 		}
 	}
 
@@ -246,12 +1317,6 @@ func (s *Service) sendNotification(ctx context.Context, booking Booking, options
 		userTag = fmt.Sprintf("<@%v>", booking.Username)
 	}
 
-	loc, err := time.LoadLocation("Europe/Paris")
-
-	if err != nil {
-		loc = time.Now().Location()
-	}
-
 	description := "Aucune"
 
 	if len(booking.Description) != 0 {
@@ -260,7 +1325,7 @@ func (s *Service) sendNotification(ctx context.Context, booking Booking, options
 
 	embed := discord.Embed{
 		Type:      "rich",
-		ChannelID: s.channelID,
+		ChannelID: channelID,
 		Title:     options.message,
 		Fields: []discord.EmbedField{
 			{
@@ -270,7 +1335,7 @@ func (s *Service) sendNotification(ctx context.Context, booking Booking, options
 			},
 			{
 				Name:   "Date et Heure",
-				Value:  booking.DateTime.In(loc).Format(time.DateTime),
+				Value:  booking.DateTime.In(s.location).Format(time.DateTime),
 				Inline: true,
 			},
 			{
@@ -296,6 +1361,30 @@ func (s *Service) sendNotification(ctx context.Context, booking Booking, options
 		},
 	}
 
+	if len(booking.CoOrganizers) != 0 {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:   "Co-organisateurs",
+			Value:  strings.Join(booking.CoOrganizers, ", "),
+			Inline: true,
+		})
+	}
+
+	if len(booking.Equipment) != 0 {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:   "Matériel",
+			Value:  formatEquipment(booking.Equipment),
+			Inline: true,
+		})
+	}
+
+	if len(booking.CustomFields) != 0 {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:   "Détails",
+			Value:  formatCustomFields(booking.CustomFields),
+			Inline: true,
+		})
+	}
+
 	if len(options.reason) != 0 {
 		embed.Fields = append(embed.Fields, discord.EmbedField{
 			Name:   "Raison",
@@ -304,9 +1393,18 @@ func (s *Service) sendNotification(ctx context.Context, booking Booking, options
 		})
 	}
 
-	s.client.SendMessage(ctx, s.channelID, discord.Message{
+	err := sender.SendMessage(ctx, channelID, discord.Message{
 		Embeds: []discord.Embed{embed},
 	})
+	recordError(span, err)
 
-	return nil
+	return err
+}
+
+// recordError marks span as failed when err is not nil.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 }
@@ -1,12 +1,18 @@
 package booking_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hanksha/tbz-booking-system-backend/audit"
 	bk "github.com/hanksha/tbz-booking-system-backend/booking"
 	bk_mocks "github.com/hanksha/tbz-booking-system-backend/booking/mocks"
 	"github.com/hanksha/tbz-booking-system-backend/discord"
@@ -28,9 +34,97 @@ var activeBookings = []bk.Booking{{
 	Players:         []string{"user1", "player2"},
 }}
 
+type recordedAction struct {
+	action   string
+	targetID string
+	payload  any
+}
+
+type recordingAuditRecorder struct {
+	actions []recordedAction
+}
+
+func (r *recordingAuditRecorder) Record(ctx context.Context, action, targetType, targetID string, payload any) {
+	r.actions = append(r.actions, recordedAction{action: action, targetID: targetID, payload: payload})
+}
+
+// recordTx collects the audit entries the service writes through the
+// transactions of repo along with those it records afterwards.
+func (r *recordingAuditRecorder) recordTx(repo *bk_mocks.MockBookingRepository) {
+	repo.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry audit.Entry) error {
+		var payload any
+
+		if entry.Payload != nil {
+			decoded := map[string]any{}
+
+			if err := json.Unmarshal(entry.Payload, &decoded); err != nil {
+				return err
+			}
+
+			payload = decoded
+		}
+
+		r.actions = append(r.actions, recordedAction{action: entry.Action, targetID: entry.TargetID, payload: payload})
+
+		return nil
+	}).AnyTimes()
+}
+
+type suspensionChecker map[string]string
+
+func (c suspensionChecker) IsSuspended(ctx context.Context, userID string) (bool, string, error) {
+	reason, ok := c[userID]
+	return ok, reason, nil
+}
+
+// fieldsChecker rejects the custom fields of the games it lists, with the
+// reason.
+type fieldsChecker map[string]string
+
+func (c fieldsChecker) CheckFields(ctx context.Context, game string, fields map[string]any) (bool, string, error) {
+	reason, ok := c[game]
+	return !ok, reason, nil
+}
+
+// trustChecker trusts the users it lists.
+type trustChecker []string
+
+func (c trustChecker) IsTrusted(ctx context.Context, userID string) (bool, error) {
+	return slices.Contains(c, userID), nil
+}
+
+// pointsChecker rejects the points of the games it lists, with the reason.
+type pointsChecker map[string]string
+
+func (c pointsChecker) CheckPoints(ctx context.Context, game string, points int) (bool, string, error) {
+	reason, ok := c[game]
+	return !ok, reason, nil
+}
+
+// venueChecker rejects the bookings in the venues it lists, with the reason.
+type venueChecker map[string]string
+
+func (c venueChecker) CheckVenue(ctx context.Context, venueID string, at time.Time, bookingID string) (bool, string, error) {
+	reason, ok := c[venueID]
+	return !ok, reason, nil
+}
+
+// memberChecker registers the users it lists, as guests when true.
+type memberChecker map[string]bool
+
+func (c memberChecker) IsMember(ctx context.Context, userID string) (bool, error) {
+	guest, ok := c[userID]
+	return ok && !guest, nil
+}
+
+func (c memberChecker) IsGuest(ctx context.Context, userID string) (bool, error) {
+	return c[userID], nil
+}
+
 type testDeps struct {
 	repo    *bk_mocks.MockBookingRepository
 	client  *dc_mocks.MockDiscordClient
+	audit   *recordingAuditRecorder
 	service *bk.Service
 	ctx     context.Context
 }
@@ -41,20 +135,33 @@ func newTestDeps(t *testing.T) (*gomock.Controller, testDeps) {
 
 	repo := bk_mocks.NewMockBookingRepository(ctrl)
 	client := dc_mocks.NewMockDiscordClient(ctrl)
-	svc := bk.NewService(repo, client, "test-channel-d")
+	audit := &recordingAuditRecorder{}
+	audit.recordTx(repo)
+	repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+		return fn(repo)
+	}).AnyTimes()
+	svc := bk.NewService(repo, client, "test-channel-d", bk.WithAuditRecorder(audit))
 
 	return ctrl, testDeps{
-		repo: repo, client: client, service: svc, ctx: context.Background(),
+		repo: repo, client: client, audit: audit, service: svc, ctx: context.Background(),
 	}
 }
 
+type recordingPublisher struct {
+	events []bk.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event bk.Event) {
+	p.events = append(p.events, event)
+}
+
 func TestGetAllActiveBookings(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetActiveBookings(testDeps.ctx).Return(activeBookings, nil).Times(1)
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(activeBookings, nil).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		bookings, err := testDeps.service.GetActiveBookings(testDeps.ctx)
@@ -71,7 +178,7 @@ func TestGetAllActiveBookings(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetActiveBookings(testDeps.ctx).Return(nil, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		bookings, err := testDeps.service.GetActiveBookings(testDeps.ctx)
@@ -79,6 +186,128 @@ func TestGetAllActiveBookings(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, 0, len(bookings))
 	})
+
+	t.Run("cached until a write", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithActiveBookingsCache(time.Minute))
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return(activeBookings, nil).Times(1)
+
+		for range 3 {
+			bookings, err := svc.GetActiveBookings(context.Background())
+			require.Nil(t, err)
+			require.Equal(t, activeBookings, bookings)
+		}
+
+		// a write on another replica
+		svc.CacheInvalidator().Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted})
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return(nil, nil).Times(1)
+
+		bookings, err := svc.GetActiveBookings(context.Background())
+		require.Nil(t, err)
+		require.Empty(t, bookings)
+
+		// a local write
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		})
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(activeBookings[0], nil)
+		repo.EXPECT().DeleteBooking(gomock.Any(), "1").Return(nil)
+		require.Nil(t, svc.DeleteBooking(context.Background(), "1"))
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return(activeBookings, nil).Times(1)
+
+		bookings, err = svc.GetActiveBookings(context.Background())
+		require.Nil(t, err)
+		require.Len(t, bookings, 1)
+	})
+}
+
+func TestGetVisibleBookings(t *testing.T) {
+	bookings := []bk.Booking{
+		{ID: "1", Game: "public", UserID: "1", Visibility: bk.VisibilityPublic},
+		{ID: "2", Game: "private", UserID: "1", Visibility: bk.VisibilityPrivate},
+	}
+	member := &discord.DiscordUser{ID: "2", Username: "member"}
+
+	t.Run("queries the repository", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetVisibleBookings(gomock.Any(), member).Return(bookings[:1], nil).Times(1)
+
+		got, err := testDeps.service.GetVisibleBookings(testDeps.ctx, member)
+
+		require.Nil(t, err)
+		require.Equal(t, bookings[:1], got)
+	})
+
+	t.Run("filters the cached bookings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithActiveBookingsCache(time.Minute))
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+
+		got, err := svc.GetVisibleBookings(context.Background(), member)
+		require.Nil(t, err)
+		require.Equal(t, bookings[:1], got)
+
+		got, err = svc.GetVisibleBookings(context.Background(), &discord.DiscordUser{ID: "1", Username: "owner"})
+		require.Nil(t, err)
+		require.Equal(t, bookings, got)
+	})
+}
+
+func TestFindBookings(t *testing.T) {
+	member := &discord.DiscordUser{ID: "2", Username: "member"}
+	pending := []bk.Booking{{ID: "1", Game: "Kill Team", Status: "pending"}}
+
+	t.Run("filtered by the repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithActiveBookingsCache(time.Minute))
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Times(0)
+		repo.EXPECT().GetMatchingBookings(gomock.Any(), member, bk.Filter{Status: "pending"}).Return(pending, nil).Times(1)
+
+		got, err := svc.FindBookings(context.Background(), member, bk.Filter{Status: " Pending"})
+
+		require.Nil(t, err)
+		require.Equal(t, pending, got)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetVisibleBookings(gomock.Any(), member).Return(pending, nil).Times(1)
+		testDeps.repo.EXPECT().GetMatchingBookings(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.FindBookings(testDeps.ctx, member, bk.Filter{})
+
+		require.Nil(t, err)
+		require.Equal(t, pending, got)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		_, err := testDeps.service.FindBookings(testDeps.ctx, member, bk.Filter{Status: "lost"})
+
+		require.ErrorIs(t, err, bk.ErrInvalidFilter)
+	})
 }
 
 func TestGetBookingById(t *testing.T) {
@@ -87,7 +316,7 @@ func TestGetBookingById(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(activeBookings[0], nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(activeBookings[0], nil).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		booking, err := testDeps.service.FindBookingByID(testDeps.ctx, "123")
@@ -103,7 +332,7 @@ func TestGetBookingById(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		got, err := testDeps.service.FindBookingByID(testDeps.ctx, "123")
@@ -119,10 +348,10 @@ func TestGetBookingsPerUsername(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingsPerUsername(testDeps.ctx, "john.doe").Return(activeBookings, nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingsPerUsername(gomock.Any(), nil, "john.doe").Return(activeBookings, nil).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		bookings, err := testDeps.service.FindBookingsPerUsername(testDeps.ctx, "john.doe")
+		bookings, err := testDeps.service.FindBookingsPerUsername(testDeps.ctx, nil, "John.Doe")
 
 		require.Nil(t, err)
 		require.NotEqual(t, 0, len(bookings))
@@ -136,16 +365,110 @@ func TestGetBookingsPerUsername(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingsPerUsername(testDeps.ctx, "john.doe").Return(nil, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().GetBookingsPerUsername(gomock.Any(), nil, "john.doe").Return(nil, errors.New("repo error")).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		bookings, err := testDeps.service.FindBookingsPerUsername(testDeps.ctx, "john.doe")
+		bookings, err := testDeps.service.FindBookingsPerUsername(testDeps.ctx, nil, "john.doe")
 
 		require.Error(t, err)
 		require.Equal(t, 0, len(bookings))
 	})
 }
 
+func TestGetBookingHistory(t *testing.T) {
+	now := time.Now().UTC()
+	history := []bk.Booking{
+		{ID: "3", DateTime: now},
+		{ID: "2", DateTime: now.Add(-time.Hour)},
+		{ID: "1", DateTime: now.Add(-2 * time.Hour)},
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingHistory(gomock.Any(), nil, bk.Filter{}, nil, 3).Return(history, nil).Times(1)
+
+		page, err := testDeps.service.GetBookingHistory(testDeps.ctx, nil, bk.Filter{}, "", 2)
+
+		require.Nil(t, err)
+		require.Equal(t, history[:2], page.Bookings)
+
+		cursor, err := bk.DecodeCursor(page.NextCursor)
+
+		require.Nil(t, err)
+		require.Equal(t, int64(2), cursor.ID)
+		require.True(t, history[1].DateTime.Equal(cursor.DateTime))
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		cursor := bk.Cursor{DateTime: now.Add(-time.Hour), ID: 2}
+		testDeps.repo.EXPECT().GetBookingHistory(gomock.Any(), nil, bk.Filter{}, gomock.Any(), 3).DoAndReturn(func(ctx context.Context, user *discord.DiscordUser, filter bk.Filter, after *bk.Cursor, limit int) ([]bk.Booking, error) {
+			require.Equal(t, cursor.ID, after.ID)
+			require.True(t, cursor.DateTime.Equal(after.DateTime))
+			return history[2:], nil
+		}).Times(1)
+
+		page, err := testDeps.service.GetBookingHistory(testDeps.ctx, nil, bk.Filter{}, cursor.Encode(), 2)
+
+		require.Nil(t, err)
+		require.Equal(t, history[2:], page.Bookings)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.GetBookingHistory(testDeps.ctx, nil, bk.Filter{}, "not-a-cursor", 2)
+
+		require.ErrorIs(t, err, bk.ErrInvalidCursor)
+	})
+}
+
+func TestSearchBookings(t *testing.T) {
+
+	t.Run("splits query into terms", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		results := []bk.SearchResult{{Booking: bk.Booking{ID: "1", Game: "Necromunda"}, Rank: 0.5}}
+		testDeps.repo.EXPECT().SearchBookings(gomock.Any(), nil, []string{"necro", "john", "doe"}, 20).Return(results, nil).Times(1)
+
+		got, err := testDeps.service.SearchBookings(testDeps.ctx, nil, "  Necro john.doe!", 0)
+
+		require.Nil(t, err)
+		require.Equal(t, results, got)
+	})
+
+	t.Run("clamps limit", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().SearchBookings(gomock.Any(), nil, []string{"necromunda"}, 100).Return([]bk.SearchResult{}, nil).Times(1)
+
+		_, err := testDeps.service.SearchBookings(testDeps.ctx, nil, "necromunda", 1000)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().SearchBookings(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.SearchBookings(testDeps.ctx, nil, " & ! ", 0)
+
+		require.ErrorIs(t, err, bk.ErrInvalidSearch)
+	})
+}
+
 func TestCreateBooking(t *testing.T) {
 	dateTime := time.Now()
 
@@ -155,9 +478,12 @@ func TestCreateBooking(t *testing.T) {
 		Username:        "user1",
 		Points:          10,
 		Description:     "test description1",
+		Status:          "pending",
 		ReminderEnabled: true,
 		DateTime:        dateTime,
 		Players:         []string{"user1", "player2"},
+		Visibility:      bk.VisibilityPublic,
+		Priority:        bk.PriorityNormal,
 	}
 	inserted := bk.Booking{
 		ID:              "1",
@@ -189,10 +515,10 @@ func TestCreateBooking(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().InsertBooking(testDeps.ctx, toInsert).Return(inserted, nil).Times(1)
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
 
 		booking, err := testDeps.service.CreateBooking(testDeps.ctx, toInsert)
 
@@ -207,7 +533,7 @@ func TestCreateBooking(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().InsertBooking(testDeps.ctx, toInsert).Return(bk.Booking{}, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(bk.Booking{}, errors.New("repo error")).Times(1)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 		booking, err := testDeps.service.CreateBooking(testDeps.ctx, toInsert)
@@ -215,716 +541,3199 @@ func TestCreateBooking(t *testing.T) {
 		require.Error(t, err)
 		require.NotNil(t, booking)
 	})
-}
-
-func TestInsertManyBookings(t *testing.T) {
-	dateTime := time.Now()
-
-	toInsert := []bk.Booking{
-		{
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		},
-		{
-			Game:            "test2",
-			UserID:          "user2ID",
-			Username:        "user2",
-			Points:          11,
-			Description:     "test description2",
-			ReminderEnabled: false,
-			DateTime:        dateTime,
-			Players:         []string{"user2", "player3"},
-		},
-	}
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("reserves equipment", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().InsertManyBookings(testDeps.ctx, toInsert).Return(nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
+		withGear := toInsert
+		withGear.Equipment = []bk.Reservation{{ItemID: "7", Quantity: 1}, {ItemID: "7", Quantity: 1}}
+		reserved := []bk.Reservation{{ItemID: "7", Name: "Terrain ruines", Quantity: 2}}
 
-		err := testDeps.service.ImportBookings(testDeps.ctx, toInsert)
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), withGear).Return(inserted, nil).Times(1)
+		testDeps.repo.EXPECT().GetEquipmentAvailability(gomock.Any(), []string{"7"}, dateTime, "1").
+			Return([]bk.EquipmentAvailability{{ItemID: "7", Name: "Terrain ruines", Quantity: 3, Reserved: 1}}, nil).Times(1)
+		testDeps.repo.EXPECT().SetEquipment(gomock.Any(), "1", reserved).Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+
+		booking, err := testDeps.service.CreateBooking(testDeps.ctx, withGear)
 
 		require.Nil(t, err)
+		require.Equal(t, reserved, booking.Equipment)
 	})
 
-	t.Run("repo error", func(t *testing.T) {
+	t.Run("equipment over-allocated", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().InsertManyBookings(testDeps.ctx, toInsert).Return(errors.New("repo error")).Times(1)
+		withGear := toInsert
+		withGear.Equipment = []bk.Reservation{{ItemID: "7", Quantity: 2}}
+
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), withGear).Return(inserted, nil).Times(1)
+		testDeps.repo.EXPECT().GetEquipmentAvailability(gomock.Any(), []string{"7"}, dateTime, "1").
+			Return([]bk.EquipmentAvailability{{ItemID: "7", Name: "Terrain ruines", Quantity: 3, Reserved: 2}}, nil).Times(1)
+		testDeps.repo.EXPECT().SetEquipment(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
 
-		err := testDeps.service.ImportBookings(testDeps.ctx, toInsert)
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, withGear)
 
-		require.Error(t, err)
+		require.ErrorIs(t, err, bk.ErrEquipmentUnavailable)
+		require.ErrorContains(t, err, "1 Terrain ruines left")
 	})
-}
 
-func TestModifyBooking(t *testing.T) {
-	user := discord.DiscordUser{
-		ID:       "user1ID",
-		Username: "user1",
-		Admin:    false,
-	}
+	t.Run("suspended user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-	member1 := discord.Member{
-		User: discord.User{
-			ID:       "user1ID",
-			Username: "user1",
-		},
-	}
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		bans := suspensionChecker{"user1ID": "no-show"}
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithSuspensionChecker(bans))
 
-	member2 := discord.Member{
-		User: discord.User{
-			ID:       "abcdef",
-			Username: "player2",
-		},
-	}
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-	t.Run("success", func(t *testing.T) {
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.ErrorIs(t, err, bk.ErrUserSuspended)
+		require.ErrorContains(t, err, "no-show")
+	})
+
+	t.Run("accepts the bookings of the trusted role", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		dateTime := time.Now()
+		ctx := discord.ContextWithUser(testDeps.ctx, discord.DiscordUser{ID: "user1ID", Username: "user1", Trusted: true})
+		expected := toInsert
+		expected.Status = "accepted"
+		accepted := inserted
+		accepted.Status = "accepted"
 
-		booking := bk.Booking{
-			ID:              "123",
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), expected).Return(accepted, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			embed := message.Embeds[0]
+			require.Contains(t, embed.Title, "automatiquement")
+			require.Equal(t, "membre de confiance", embed.Fields[len(embed.Fields)-1].Value)
+			return nil
+		}).Times(1)
 
-		updated := bk.Booking{
-			ID:              "123",
-			Game:            "modified",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		booking, err := testDeps.service.CreateBooking(ctx, toInsert)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(booking, nil).Times(1)
-		testDeps.repo.EXPECT().UpdateBooking(testDeps.ctx, updated).Return(nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		require.Nil(t, err)
+		require.Equal(t, "accepted", booking.Status)
+		require.Len(t, testDeps.audit.actions, 1)
+		require.Equal(t, "booking.auto-accept", testDeps.audit.actions[0].action)
+		require.Equal(t, map[string]any{"rule": "trusted", "reason": "membre de confiance"}, testDeps.audit.actions[0].payload)
+	})
 
-		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+	t.Run("fails the auto-acceptance without its audit entry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		require.Nil(t, err)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithTrustChecker(trustChecker{"user1ID"}), bk.WithAuditRecorder(&recordingAuditRecorder{}))
+		expected := toInsert
+		expected.Status = "accepted"
+
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).Times(1)
+		repo.EXPECT().InsertBooking(gomock.Any(), expected).Return(inserted, nil).Times(1)
+		repo.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).Return(errors.New("db down")).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.ErrorContains(t, err, "booking.auto-accept")
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("accepts the bookings of users trusted by admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		dateTime := time.Now()
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithTrustChecker(trustChecker{"user1ID"}))
+		expected := toInsert
+		expected.Status = "accepted"
 
-		booking := bk.Booking{
-			ID:              "123",
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "approved",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).Times(1)
+		repo.EXPECT().InsertBooking(gomock.Any(), expected).Return(inserted, nil).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-		updated := bk.Booking{
-			ID:              "123",
-			Game:            "modified",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "approved",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		_, err := svc.CreateBooking(context.Background(), toInsert)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(booking, nil).Times(1)
-		testDeps.repo.EXPECT().UpdateBooking(testDeps.ctx, updated).Return(nil).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(0)
+		require.Nil(t, err)
+	})
 
-		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+	t.Run("ignores the status sent and the trust of others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		audit := &recordingAuditRecorder{}
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithTrustChecker(trustChecker{"user1ID"}), bk.WithAuditRecorder(audit))
+
+		// a member claiming the booking of a trusted one
+		ctx := discord.ContextWithUser(context.Background(), discord.DiscordUser{ID: "otherID", Username: "other"})
+		booking := toInsert
+		booking.Status = "accepted"
+		expected := toInsert
+		expected.UserID, expected.Username = "otherID", "other"
+
+		repo.EXPECT().InsertBooking(gomock.Any(), expected).Return(inserted, nil).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := svc.CreateBooking(ctx, booking)
+
+		require.Nil(t, err)
+		require.Empty(t, audit.actions)
 	})
 
-	t.Run("not allowed", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("publishes event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		user := discord.DiscordUser{
-			ID:       "user2ID",
-			Username: "user2",
-			Admin:    false,
-		}
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		publisher := &recordingPublisher{}
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithEventPublisher(publisher))
 
-		dateTime := time.Now()
+		repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
 
-		booking := bk.Booking{
-			ID:              "123",
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		_, err := svc.CreateBooking(context.Background(), toInsert)
 
-		updated := bk.Booking{
-			ID:              "123",
-			Game:            "modified",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		require.Nil(t, err)
+		require.Len(t, publisher.events, 1)
+		require.Equal(t, bk.EventBookingCreated, publisher.events[0].Type)
+		require.Equal(t, inserted, publisher.events[0].Booking)
+	})
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(booking, nil).Times(1)
-		testDeps.repo.EXPECT().UpdateBooking(testDeps.ctx, updated).Return(nil).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(0)
+	t.Run("notifies through dispatcher", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		dispatcher := &queuedDispatcher{}
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithDispatcher(dispatcher))
 
-		require.ErrorIs(t, err, bk.ErrNotAllowed)
+		repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.Nil(t, err)
+		require.Len(t, dispatcher.tasks, 1)
+
+		// Discord is only called once the task runs
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Return(nil).Times(1)
+
+		dispatcher.tasks[0](context.Background())
 	})
 
-	t.Run("repo error GetBookingById", func(t *testing.T) {
+	t.Run("normalizes players", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		dateTime := time.Now()
+		booking := toInsert
+		booking.Players = []string{"user1", " Player2", "player2 ", "", "USER1"}
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		updated := bk.Booking{
-			ID:              "123",
-			Game:            "modified",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, booking)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
-		testDeps.repo.EXPECT().UpdateBooking(testDeps.ctx, updated).Return(nil).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+		require.Nil(t, err)
+	})
 
-		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+	t.Run("too many players", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		require.Error(t, err)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithMaxPlayers(1))
+
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.ErrorIs(t, err, bk.ErrTooManyPlayers)
 	})
 
-	t.Run("repo error UpdateBooking", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("points out of the game bounds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		dateTime := time.Now()
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithPointsChecker(pointsChecker{"test1": "test1 allows at most 5 points"}))
 
-		booking := bk.Booking{
-			ID:              "123",
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
 
-		updated := bk.Booking{
-			ID:              "123",
-			Game:            "modified",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        dateTime,
-			Players:         []string{"user1", "player2"},
-		}
+		_, err := svc.CreateBooking(context.Background(), toInsert)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(booking, nil).Times(1)
-		testDeps.repo.EXPECT().UpdateBooking(testDeps.ctx, updated).Return(errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+		require.ErrorIs(t, err, bk.ErrInvalidPoints)
+		require.ErrorContains(t, err, "test1 allows at most 5 points")
+	})
 
-		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+	t.Run("custom fields outside the game schema", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
 
-		require.Error(t, err)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithFieldsChecker(fieldsChecker{"test1": "test1 has no field 'weather'"}))
+
+		booking := toInsert
+		booking.CustomFields = map[string]any{"weather": "rain"}
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateBooking(context.Background(), booking)
+
+		require.ErrorIs(t, err, bk.ErrInvalidCustomFields)
+		require.ErrorContains(t, err, "no field 'weather'")
 	})
 
-}
+	t.Run("renders custom fields", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
 
-func TestAcceptBooking(t *testing.T) {
-	member1 := discord.Member{
-		User: discord.User{
-			ID:       "12345",
-			Username: "user1",
-		},
-	}
+		booking := toInsert
+		booking.CustomFields = map[string]any{"mission": "Take and Hold", "mapSize": float64(44), "terrain": true}
+		withFields := inserted
+		withFields.CustomFields = booking.CustomFields
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), booking).Return(withFields, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			fields := message.Embeds[0].Fields
+			require.Equal(t, "Détails", fields[len(fields)-1].Name)
+			require.Equal(t, "mapSize : 44\nmission : Take and Hold\nterrain : oui", fields[len(fields)-1].Value)
+			return nil
+		}).Times(1)
+
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, booking)
 
-	member2 := discord.Member{
-		User: discord.User{
-			ID:       "abcdef",
-			Username: "player2",
-		},
-	}
+		require.Nil(t, err)
+	})
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("venue without a table left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithVenueChecker(venueChecker{"2": "no table left in Cave at that time"}))
+
+		booking := toInsert
+		booking.VenueID = "2"
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateBooking(context.Background(), booking)
+
+		require.ErrorIs(t, err, bk.ErrInvalidVenue)
+		require.ErrorContains(t, err, "no table left in Cave")
+	})
+
+	t.Run("normalizes tags", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		b := bk.Booking{
-			ID:              "123",
-			Game:            "test1",
-			UserID:          "user1ID",
-			Username:        "user1",
-			Status:          "pending",
-			Points:          10,
-			Description:     "test description1",
-			ReminderEnabled: true,
-			DateTime:        time.Now(),
-			Players:         []string{"user1", "player2"},
-		}
+		booking := toInsert
+		booking.Tags = []string{"Beginner Friendly", " tournament-prep", "beginner-friendly", ""}
+		expected := toInsert
+		expected.Tags = []string{"beginner-friendly", "tournament-prep"}
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), expected).Return(inserted, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "accepted").Return(nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, booking)
 
-		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
 		require.Nil(t, err)
 	})
 
-	t.Run("repo error GetBookingById", func(t *testing.T) {
+	t.Run("tag outside the curated list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithAllowedTags([]string{"Beginner Friendly", "tournament-prep"}))
+
+		booking := toInsert
+		booking.Tags = []string{"beginner-friendly", "casual"}
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateBooking(context.Background(), booking)
+
+		require.ErrorIs(t, err, bk.ErrInvalidTags)
+		require.ErrorContains(t, err, "'casual'")
+	})
+
+	t.Run("negative points", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		booking := toInsert
+		booking.Points = -10
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
 
-		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
-		require.Error(t, err)
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, booking)
+
+		require.ErrorIs(t, err, bk.ErrInvalidPoints)
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
+	t.Run("validates players", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithPlayerValidation())
+
+		repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+		// found members are not searched again for the notification
+		client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 5).Return([]discord.Member{member1}, nil).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 5).Return([]discord.Member{member2}, nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("unknown players", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithPlayerValidation())
+
+		booking := toInsert
+		booking.Players = []string{"user1", "player"}
+		client.EXPECT().SearchMembers(gomock.Any(), "user1", 5).Return([]discord.Member{member1}, nil).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), "player", 5).Return([]discord.Member{member2}, nil).Times(1)
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateBooking(context.Background(), booking)
+
+		var unknown *bk.UnknownPlayersError
+		require.ErrorIs(t, err, bk.ErrUnknownPlayers)
+		require.ErrorAs(t, err, &unknown)
+		require.Equal(t, []bk.UnknownPlayer{{Index: 1, Username: "player", Suggestions: []string{"player2"}}}, unknown.Players)
+	})
+
+	t.Run("lets players through when Discord fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithPlayerValidation())
+
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("discord down")).AnyTimes()
+		repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("renders date in guild time zone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		montreal, _ := time.LoadLocation("America/Montreal")
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithLocation(montreal))
+
+		utc := inserted
+		utc.DateTime = time.Date(2026, 3, 12, 18, 0, 0, 0, time.UTC)
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Return(utc, nil).Times(1)
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Equal(t, "2026-03-12 14:00:00", message.Embeds[0].Fields[1].Value)
+			return nil
+		}).Times(1)
+
+		_, err := svc.CreateBooking(context.Background(), toInsert)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("records notification failure", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		b := bk.Booking{ID: "123", Status: "accepted"}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).Times(2)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("discord down")).Times(1)
+		testDeps.repo.EXPECT().SetNotificationError(gomock.Any(), inserted.ID, "discord down").Return(nil).Times(1)
 
-		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
-		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+		_, err := testDeps.service.CreateBooking(testDeps.ctx, toInsert)
+
+		require.Nil(t, err)
 	})
 
-	t.Run("repo error SetBookingStatus", func(t *testing.T) {
+	t.Run("caches players across notifications", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		b := bk.Booking{ID: "123", Status: "pending", Players: []string{"user1", "player2"}}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "accepted").Return(errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.repo.EXPECT().InsertBooking(gomock.Any(), toInsert).Return(inserted, nil).Times(2)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Equal(t, "<@12345>, <@abcdef>", message.Embeds[0].Fields[4].Value)
+			return nil
+		}).Times(2)
+
+		for range 2 {
+			_, err := testDeps.service.CreateBooking(testDeps.ctx, toInsert)
+			require.Nil(t, err)
+		}
+	})
+}
+
+func TestCreateBookingWithWarnings(t *testing.T) {
+	slot := time.Now().Add(48 * time.Hour)
+	toInsert := bk.Booking{Game: "Kill Team", UserID: "aliceID", Username: "alice", Players: []string{"bob"}, DateTime: slot, VenueID: "annex"}
+	overlapping := bk.Booking{ID: "2", Game: "Necromunda", Username: "bob", Status: "accepted", DateTime: slot.Add(time.Hour)}
+	other := bk.Booking{ID: "3", Game: "Blood Bowl", Username: "carol", Status: "pending", DateTime: slot.Add(-time.Hour)}
+	later := bk.Booking{ID: "4", Game: "Warhammer", Username: "alice", Status: "accepted", DateTime: slot.Add(6 * time.Hour)}
+
+	newService := func(t *testing.T, options ...bk.ServiceOption) (*bk_mocks.MockBookingRepository, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).AnyTimes()
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+		options = append(options, bk.WithVenueChecker(venueChecker{"annex": "Annex is closed at that time"}), bk.WithSessions(2, []time.Duration{20 * time.Hour}))
+
+		return repo, bk.NewService(repo, client, "test-channel-d", options...)
+	}
+
+	t.Run("creates the conflicting booking", func(t *testing.T) {
+		repo, svc := newService(t, bk.WithConflictWarnings(), bk.WithTrustChecker(trustChecker{"aliceID"}))
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{overlapping, other, later}, nil).Times(1)
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, booking bk.Booking) (bk.Booking, error) {
+			booking.ID = "5"
+			return booking, nil
+		}).Times(1)
+
+		booking, warnings, err := svc.CreateBookingWithWarnings(context.Background(), toInsert)
+
+		require.Nil(t, err)
+		require.Equal(t, "5", booking.ID)
+		require.Equal(t, "pending", booking.Status, "the conflicts are left to the admins")
+		require.Equal(t, []bk.Warning{
+			{Kind: bk.WarningVenue, Message: "Annex is closed at that time"},
+			{Kind: bk.WarningOverlap, Message: "bob already booked Necromunda at that time", BookingID: "2"},
+			{Kind: bk.WarningTablesFull, Message: "the 2 tables are taken at that time"},
+		}, warnings)
+	})
+
+	t.Run("without conflicts", func(t *testing.T) {
+		repo, svc := newService(t, bk.WithConflictWarnings())
+		booking := toInsert
+		booking.VenueID = ""
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{later}, nil).Times(1)
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Return(bk.Booking{ID: "5"}, nil).Times(1)
+
+		_, warnings, err := svc.CreateBookingWithWarnings(context.Background(), booking)
+
+		require.Nil(t, err)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("rejected without warnings", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, warnings, err := svc.CreateBookingWithWarnings(context.Background(), toInsert)
+
+		require.ErrorIs(t, err, bk.ErrInvalidVenue)
+		require.Nil(t, warnings)
+	})
+}
+
+// queuedDispatcher holds the tasks until the test runs them.
+type queuedDispatcher struct {
+	tasks []func(ctx context.Context)
+}
+
+func (d *queuedDispatcher) Dispatch(ctx context.Context, task func(ctx context.Context)) {
+	d.tasks = append(d.tasks, task)
+}
+
+func TestInsertManyBookings(t *testing.T) {
+	dateTime := time.Now()
+
+	toInsert := []bk.Booking{
+		{
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		},
+		{
+			Game:            "test2",
+			UserID:          "user2ID",
+			Username:        "user2",
+			Points:          11,
+			Description:     "test description2",
+			ReminderEnabled: false,
+			DateTime:        dateTime,
+			Players:         []string{"user2", "player3"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().InsertManyBookings(gomock.Any(), toInsert).Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.ImportBookings(testDeps.ctx, toInsert)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().InsertManyBookings(gomock.Any(), toInsert).Return(errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.ImportBookings(testDeps.ctx, toInsert)
+
+		require.Error(t, err)
+	})
+}
+
+func TestModifyBooking(t *testing.T) {
+	user := discord.DiscordUser{
+		ID:       "user1ID",
+		Username: "user1",
+		Admin:    false,
+	}
+
+	member1 := discord.Member{
+		User: discord.User{
+			ID:       "user1ID",
+			Username: "user1",
+		},
+	}
+
+	member2 := discord.Member{
+		User: discord.User{
+			ID:       "abcdef",
+			Username: "player2",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		dateTime := time.Now()
+
+		booking := bk.Booking{
+			ID:              "123",
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		updated := bk.Booking{
+			ID:              "123",
+			Game:            "modified",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), updated).Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+
+		require.Nil(t, err)
+
+	})
+
+	t.Run("co-organizers", func(t *testing.T) {
+		booking := bk.Booking{ID: "123", Game: "test1", UserID: "ownerID", Username: "owner", Status: "pending", CoOrganizers: []string{"user1"}}
+
+		for name, test := range map[string]struct {
+			user     discord.DiscordUser
+			expected []string
+		}{
+			"set by the owner":                  {discord.DiscordUser{ID: "ownerID", Username: "owner"}, []string{"user1", "player2"}},
+			"kept when a co-organizer modifies": {user, []string{"user1"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctrl, testDeps := newTestDeps(t)
+				defer ctrl.Finish()
+
+				updated := booking
+				updated.Game = "modified"
+				updated.CoOrganizers = []string{" User1", "player2"}
+
+				testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+				testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, saved bk.Booking) error {
+					require.Equal(t, "modified", saved.Game)
+					require.Equal(t, test.expected, saved.CoOrganizers)
+					return nil
+				}).Times(1)
+				testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+				require.Nil(t, testDeps.service.ModifyBooking(testDeps.ctx, updated, test.user))
+			})
+		}
+	})
+
+	t.Run("too many co-organizers", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, bk.Booking{ID: "123", Game: "test1", CoOrganizers: []string{"a", "b", "c", "d"}}, user)
+
+		require.ErrorIs(t, err, bk.ErrInvalidCoOrganizers)
+	})
+
+	t.Run("admin fixes accepted booking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		admin := discord.DiscordUser{ID: "adminID", Username: "admin", Admin: true}
+		dateTime := time.Date(2026, 3, 12, 18, 0, 0, 0, time.UTC)
+		booking := bk.Booking{ID: "123", Game: "SW", UserID: "user1ID", Username: "user1", Status: "accepted", DateTime: dateTime, Players: []string{"user1", "player2"}}
+		updated := bk.Booking{ID: "123", Game: "Star Wars", Status: "accepted", DateTime: dateTime, Players: []string{"user1", "player2"}}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		// the channel notification, then the changes to the owner and player2
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "user1ID").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "abcdef").Return("dm-player2", nil).Times(1)
+		for _, channel := range []string{"dm-owner", "dm-player2"} {
+			testDeps.client.EXPECT().SendMessage(gomock.Any(), channel, gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+				require.Equal(t, []discord.EmbedField{{Name: "Jeu", Value: "SW → Star Wars"}}, message.Embeds[0].Fields)
+				return nil
+			}).Times(1)
+		}
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, admin)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("admin sends accepted booking back to pending", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		admin := discord.DiscordUser{ID: "adminID", Username: "admin", Admin: true}
+		booking := bk.Booking{ID: "123", Game: "SW", UserID: "user1ID", Username: "user1", Status: "accepted"}
+		updated := bk.Booking{ID: "123", Game: "SW", Status: "pending"}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "pending").Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "user1ID").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-owner", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Equal(t, []discord.EmbedField{{Name: "Statut", Value: "accepted → pending"}}, message.Embeds[0].Fields)
+			return nil
+		}).Times(1)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, admin)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("accepted booking needs an admin", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "accepted"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, bk.Booking{ID: "123"}, user)
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("already started", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithChangeCutoff(0))
+
+		booking := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "pending", DateTime: time.Now().Add(-time.Minute)}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		err := svc.ModifyBooking(context.Background(), bk.Booking{ID: "123", Game: "modified"}, user)
+
+		require.ErrorIs(t, err, bk.ErrBookingLocked)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		dateTime := time.Now()
+
+		booking := bk.Booking{
+			ID:              "123",
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "approved",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		updated := bk.Booking{
+			ID:              "123",
+			Game:            "modified",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "approved",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), updated).Return(nil).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		user := discord.DiscordUser{
+			ID:       "user2ID",
+			Username: "user2",
+			Admin:    false,
+		}
+
+		dateTime := time.Now()
+
+		booking := bk.Booking{
+			ID:              "123",
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		updated := bk.Booking{
+			ID:              "123",
+			Game:            "modified",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), updated).Return(nil).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("repo error GetBookingById", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		dateTime := time.Now()
+
+		updated := bk.Booking{
+			ID:              "123",
+			Game:            "modified",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), updated).Return(nil).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+
+		require.Error(t, err)
+	})
+
+	t.Run("repo error UpdateBooking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		dateTime := time.Now()
+
+		booking := bk.Booking{
+			ID:              "123",
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		updated := bk.Booking{
+			ID:              "123",
+			Game:            "modified",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        dateTime,
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().UpdateBooking(gomock.Any(), updated).Return(errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(0)
+
+		err := testDeps.service.ModifyBooking(testDeps.ctx, updated, user)
+
+		require.Error(t, err)
+	})
+
+}
+
+func TestAcceptBooking(t *testing.T) {
+	member1 := discord.Member{
+		User: discord.User{
+			ID:       "12345",
+			Username: "user1",
+		},
+	}
+
+	member2 := discord.Member{
+		User: discord.User{
+			ID:       "abcdef",
+			Username: "player2",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{
+			ID:              "123",
+			Game:            "test1",
+			UserID:          "user1ID",
+			Username:        "user1",
+			Status:          "pending",
+			Points:          10,
+			Description:     "test description1",
+			ReminderEnabled: true,
+			DateTime:        time.Now(),
+			Players:         []string{"user1", "player2"},
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "accepted").Return(nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
+		require.Nil(t, err)
+		require.Equal(t, []recordedAction{{action: "booking.accept", targetID: "123"}}, testDeps.audit.actions)
+	})
+
+	t.Run("repo error GetBookingById", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
+		require.Error(t, err)
+	})
+
+	t.Run("audit entry failing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithAuditRecorder(&recordingAuditRecorder{}))
+
+		// the transaction is rolled back with the error of fn
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).Times(1)
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{ID: "123", Status: "pending"}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "accepted").Return(nil).Times(1)
+		repo.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).Return(errors.New("connection reset")).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := svc.AcceptBooking(context.Background(), "123")
+		require.ErrorContains(t, err, "failed to record booking.accept")
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "accepted"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("repo error SetBookingStatus", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "pending", Players: []string{"user1", "player2"}}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "accepted").Return(errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
+		require.Error(t, err)
+	})
+}
+
+func TestRefuseBooking(t *testing.T) {
+	member1 := discord.Member{
+		User: discord.User{
+			ID:       "12345",
+			Username: "user1",
+		},
+	}
+
+	member2 := discord.Member{
+		User: discord.User{
+			ID:       "abcdef",
+			Username: "player2",
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{
+			ID:       "123",
+			Status:   "pending",
+			Players:  []string{"user1", "player2"},
+			DateTime: time.Now(),
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "refused").Return(nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
+		require.Nil(t, err)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "canceled"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("repo error GetBookingById", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
+		require.Error(t, err)
+	})
+
+	t.Run("repo error SetBookingStatus", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "pending"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "refused").Return(errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
+		require.Error(t, err)
+	})
+}
+
+func TestDeleteBooking(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "accepted"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().DeleteBooking(gomock.Any(), "123").Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.DeleteBooking(testDeps.ctx, "123")
+
+		require.Nil(t, err)
+		require.Equal(t, []recordedAction{{action: "booking.delete", targetID: "123"}}, testDeps.audit.actions)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+		testDeps.repo.EXPECT().DeleteBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.DeleteBooking(testDeps.ctx, "123")
+
+		require.ErrorIs(t, err, bk.ErrBookingNotFound)
+		require.Empty(t, testDeps.audit.actions)
+	})
+}
+
+func TestRetryNotification(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "accepted", NotificationError: "discord down"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Equal(t, "Réservation Acceptée :white_check_mark:", message.Embeds[0].Title)
+			return nil
+		}).Times(1)
+		testDeps.repo.EXPECT().SetNotificationError(gomock.Any(), "123", "").Return(nil).Times(1)
+
+		err := testDeps.service.RetryNotification(testDeps.ctx, "123")
+
+		require.Nil(t, err)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "pending"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("discord down")).Times(1)
+		testDeps.repo.EXPECT().SetNotificationError(gomock.Any(), "123", "discord down").Return(nil).Times(1)
+
+		err := testDeps.service.RetryNotification(testDeps.ctx, "123")
+
+		require.ErrorIs(t, err, bk.ErrNotificationFailed)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, bk.ErrBookingNotFound).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.RetryNotification(testDeps.ctx, "123")
+
+		require.ErrorIs(t, err, bk.ErrBookingNotFound)
+	})
+}
+
+func TestPurgeDeletedBookings(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		before := time.Now().Add(-30 * 24 * time.Hour)
+		testDeps.repo.EXPECT().PurgeDeletedBookings(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, cutoff time.Time) (int64, error) {
+			require.WithinDuration(t, before, cutoff, time.Minute)
+			return 3, nil
+		}).Times(1)
+
+		purged, err := testDeps.service.PurgeDeletedBookings(testDeps.ctx, 30*24*time.Hour)
+
+		require.Nil(t, err)
+		require.Equal(t, int64(3), purged)
+		require.Len(t, testDeps.audit.actions, 1)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().PurgeDeletedBookings(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("repo error")).Times(1)
+
+		_, err := testDeps.service.PurgeDeletedBookings(testDeps.ctx, time.Hour)
+
+		require.Error(t, err)
+		require.Empty(t, testDeps.audit.actions)
+	})
+}
+
+func TestCancelBooking(t *testing.T) {
+	member1 := discord.Member{
+		User: discord.User{
+			ID:       "12345",
+			Username: "user1",
+		},
+	}
+
+	member2 := discord.Member{
+		User: discord.User{
+			ID:       "abcdef",
+			Username: "player2",
+		},
+	}
+
+	user := discord.DiscordUser{ID: "user1ID", Username: "user1", Admin: false}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{
+			ID:       "123",
+			UserID:   "user1ID",
+			Username: "user1",
+			Status:   "pending",
+			Players:  []string{"user1", "player2"},
+			DateTime: time.Now(),
+		}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "canceled").Return(nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
+		require.Nil(t, err)
+	})
+
+	t.Run("within change cutoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithChangeCutoff(2*time.Hour))
+
+		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "accepted", DateTime: time.Now().Add(time.Hour)}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := svc.CancelBooking(context.Background(), "123", user)
+
+		require.ErrorIs(t, err, bk.ErrBookingLocked)
+	})
+
+	t.Run("admin overrides change cutoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithChangeCutoff(0))
+
+		admin := discord.DiscordUser{ID: "user1ID", Username: "user1", Admin: true}
+		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "accepted", DateTime: time.Now().Add(-time.Hour)}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "canceled").Return(nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		err := svc.CancelBooking(context.Background(), "123", admin)
+
+		require.Nil(t, err)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", Status: "refused"}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		notAllowedUser := discord.DiscordUser{ID: "someone", Username: "someone", Admin: false}
+		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "pending", Players: []string{"user1", "player2"}}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", notAllowedUser)
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("repo error GetBookingById", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
+		require.Error(t, err)
+	})
+
+	t.Run("repo error SetBookingStatus", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "pending", Players: []string{"user1", "player2"}}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "canceled").Return(errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "failed to cancel booking")
+	})
+
+	t.Run("by a co-organizer", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", UserID: "ownerID", Username: "owner", Status: "pending", CoOrganizers: []string{"user1"}, DateTime: time.Now()}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "123", "canceled").Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		require.Nil(t, testDeps.service.CancelBooking(testDeps.ctx, "123", user))
+	})
+
+	t.Run("a player does not manage the booking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		b := bk.Booking{ID: "123", UserID: "ownerID", Username: "owner", Status: "pending", Players: []string{"owner", "user1"}, DateTime: time.Now()}
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(b, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+}
+
+func TestReopenBooking(t *testing.T) {
+	slot := time.Now().Add(48 * time.Hour)
+	canceled := bk.Booking{ID: "1", Game: "Kill Team", Status: "canceled", DateTime: slot}
+	taken := bk.Booking{ID: "2", Game: "Necromunda", Status: "accepted", DateTime: slot.Add(time.Hour)}
+	freed := bk.Booking{ID: "3", Game: "Blood Bowl", Status: "refused", DateTime: slot}
+
+	newService := func(t *testing.T, options ...bk.ServiceOption) (*bk_mocks.MockBookingRepository, *dc_mocks.MockDiscordClient, *recordingAuditRecorder, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		audit := &recordingAuditRecorder{}
+		audit.recordTx(repo)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+
+		options = append(options, bk.WithAuditRecorder(audit), bk.WithSessions(2, []time.Duration{20 * time.Hour}))
+
+		return repo, client, audit, bk.NewService(repo, client, "test-channel-d", options...)
+	}
+
+	t.Run("reopen", func(t *testing.T) {
+		repo, client, audit, svc := newService(t)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(canceled, nil).Times(1)
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{canceled, taken, freed}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "1", "pending").Return(nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		booking, err := svc.ReopenBooking(context.Background(), "1")
+
+		require.Nil(t, err)
+		require.Equal(t, "pending", booking.Status)
+		require.Equal(t, []recordedAction{{action: "booking.reopen", targetID: "1", payload: map[string]any{"from": "canceled"}}}, audit.actions)
+	})
+
+	t.Run("every table taken", func(t *testing.T) {
+		repo, _, audit, svc := newService(t)
+		other := bk.Booking{ID: "4", Game: "Warhammer", Status: "pending", DateTime: slot.Add(-time.Hour)}
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(canceled, nil).Times(1)
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{canceled, taken, other}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReopenBooking(context.Background(), "1")
+
+		require.ErrorIs(t, err, bk.ErrSlotTaken)
+		require.Empty(t, audit.actions)
+	})
+
+	t.Run("venue closed", func(t *testing.T) {
+		repo, _, _, svc := newService(t, bk.WithVenueChecker(venueChecker{"annex": "closed"}))
+		inAnnex := canceled
+		inAnnex.VenueID = "annex"
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(inAnnex, nil).Times(1)
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{inAnnex}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReopenBooking(context.Background(), "1")
+
+		require.ErrorIs(t, err, bk.ErrInvalidVenue)
+	})
+
+	t.Run("only canceled and refused bookings", func(t *testing.T) {
+		repo, _, _, svc := newService(t)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "2").Return(taken, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReopenBooking(context.Background(), "2")
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("over", func(t *testing.T) {
+		repo, _, _, svc := newService(t)
+		past := canceled
+		past.DateTime = time.Now().Add(-time.Hour)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(past, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReopenBooking(context.Background(), "1")
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+}
+
+func TestGetBookingCountPerGame(t *testing.T) {
+	stats := []bk.GameBookingCount{{Game: "test1", Count: 2}}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(stats, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerGame(testDeps.ctx)
+		require.Nil(t, err)
+		require.True(t, reflect.DeepEqual(got, stats))
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerGame(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerGame(testDeps.ctx)
+		require.Error(t, err)
+		require.Equal(t, 0, len(got))
+	})
+}
+
+func TestGetBookingCountPerWeekDay(t *testing.T) {
+	stats := []bk.WeekDayBookingCount{{WeekDay: "Monday", Count: 2}}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(stats, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerWeekDay(testDeps.ctx)
+		require.Nil(t, err)
+		require.True(t, reflect.DeepEqual(got, stats))
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerWeekDay(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerWeekDay(testDeps.ctx)
+		require.Error(t, err)
+		require.Equal(t, 0, len(got))
+	})
+}
+
+func TestGetBookingCountPerGameInPeriod(t *testing.T) {
+	stats := []bk.GameBookingCount{{Game: "test1", Count: 2}}
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), start, end).Return(stats, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerGameInPeriod(testDeps.ctx, start, end)
+		require.Nil(t, err)
+		require.True(t, reflect.DeepEqual(got, stats))
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingCountPerGameInPeriod(gomock.Any(), start, end).Return(nil, errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		got, err := testDeps.service.GetBookingCountPerGameInPeriod(testDeps.ctx, start, end)
+		require.Error(t, err)
+		require.Equal(t, 0, len(got))
+	})
+}
+
+func TestGetHeatmap(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("in the guild's time zone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithLocation(paris))
+
+		// friday the 6th at 20:00 and 21:00 in Paris, saturday the 7th at 00:30
+		repo.EXPECT().GetBookedSlots(gomock.Any(), from, to, "").Return([]bk.BookedSlot{
+			{DateTime: time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)},
+			{DateTime: time.Date(2026, 3, 6, 19, 30, 0, 0, time.UTC)},
+			{DateTime: time.Date(2026, 3, 6, 20, 0, 0, 0, time.UTC)},
+			{DateTime: time.Date(2026, 3, 6, 23, 30, 0, 0, time.UTC)},
+		}, nil).Times(1)
+
+		heatmap, err := svc.GetHeatmap(context.Background(), from, to)
+
+		require.Nil(t, err)
+		require.Equal(t, 2, heatmap.Counts[time.Friday][20])
+		require.Equal(t, 1, heatmap.Counts[time.Friday][21])
+		require.Equal(t, 1, heatmap.Counts[time.Saturday][0])
+		require.Equal(t, from, heatmap.From)
+		require.Equal(t, to, heatmap.To)
+	})
+
+	t.Run("the last 12 weeks by default", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookedSlots(gomock.Any(), gomock.Any(), gomock.Any(), "").Return(nil, nil).Times(1)
+
+		heatmap, err := testDeps.service.GetHeatmap(testDeps.ctx, time.Time{}, time.Time{})
+
+		require.Nil(t, err)
+		require.Equal(t, 84*24*time.Hour, heatmap.To.Sub(heatmap.From))
+	})
+
+	t.Run("period ending before it starts", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		_, err := testDeps.service.GetHeatmap(testDeps.ctx, to, from)
+
+		require.ErrorIs(t, err, bk.ErrInvalidFilter)
+	})
+}
+
+func TestGetApprovalLatencies(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	decision := func(created time.Time, waited time.Duration) bk.Decision {
+		return bk.Decision{CreatedAt: created, DecidedAt: created.Add(waited)}
+	}
+
+	t.Run("per month", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithLocation(paris))
+
+		march := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		repo.EXPECT().GetDecisions(gomock.Any(), from, to).Return([]bk.Decision{
+			// the 31st of January at 23:30 in UTC is in February in Paris
+			decision(time.Date(2026, 1, 31, 23, 30, 0, 0, time.UTC), 2*time.Hour),
+			decision(march, time.Hour),
+			decision(march, 3*time.Hour),
+			decision(march, 2*time.Hour),
+			decision(march, 10*time.Hour),
+		}, nil).Times(1)
+
+		stats, err := svc.GetApprovalLatencies(context.Background(), from, to)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.ApprovalLatency{
+			{Month: "2026-02", Decisions: 1, AverageSeconds: 7200, MedianSeconds: 7200, P95Seconds: 7200},
+			{Month: "2026-03", Decisions: 4, AverageSeconds: 14400, MedianSeconds: 9000, P95Seconds: 32220},
+		}, stats)
+	})
+
+	t.Run("period ending before it starts", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		_, err := testDeps.service.GetApprovalLatencies(testDeps.ctx, to, from)
+
+		require.ErrorIs(t, err, bk.ErrInvalidFilter)
+	})
+}
+
+func TestSendBookingReminders(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		now := time.Now()
+		bookings := []bk.Booking{
+			{
+				ID:              "123",
+				Game:            "test1",
+				UserID:          "owner-id",
+				Username:        "user1",
+				ReminderEnabled: true,
+				DateTime:        now.Add(2 * time.Hour),
+				Players:         []string{"user1", "player2"},
+			},
+		}
+
+		member2 := discord.Member{
+			User: discord.User{
+				ID:       "player2-id",
+				Username: "player2",
+			},
+		}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "owner-id").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "player2-id").Return("dm-player2", nil).Times(1)
+		// rendered in the guild's time zone
+		paris, _ := time.LoadLocation("Europe/Paris")
+		at := bookings[0].DateTime.In(paris).Format("15:04")
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-owner", discord.Message{
+			Content: "Rappel pour la réservation de test1 aujourd'hui at " + at + " !",
+		}).Return(nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-player2", discord.Message{
+			Content: "Rappel pour la réservation de test1 aujourd'hui at " + at + " !",
+		}).Return(nil).Times(1)
+
+		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("skip bookings without due reminders", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		now := time.Now()
+		bookings := []bk.Booking{
+			{
+				ID:              "123",
+				Game:            "test1",
+				UserID:          "owner-id",
+				Username:        "user1",
+				ReminderEnabled: false,
+				DateTime:        now,
+				Players:         []string{"user1", "player2"},
+			},
+			{
+				ID:              "456",
+				Game:            "test2",
+				UserID:          "owner-id-2",
+				Username:        "user2",
+				ReminderEnabled: true,
+				DateTime:        now.Add(24 * time.Hour),
+				Players:         []string{"user2", "player3"},
+			},
+		}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+
+		require.Error(t, err)
+		require.ErrorContains(t, err, "failed to get active bookings")
+	})
+
+	t.Run("skip opted out participants and bookings reminded ahead", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		now := time.Now()
+		bookings := []bk.Booking{
+			{
+				ID:              "123",
+				Game:            "test1",
+				UserID:          "owner-id",
+				Username:        "user1",
+				ReminderEnabled: true,
+				DateTime:        now,
+				Players:         []string{"user1", "player2"},
+				Reminders:       &bk.Reminders{Channel: bk.ReminderDM, OptedOut: []string{"player2"}},
+			},
+			{
+				ID:              "456",
+				Game:            "test2",
+				UserID:          "owner-id-2",
+				Username:        "user2",
+				ReminderEnabled: true,
+				DateTime:        now,
+				Reminders:       &bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderDM},
+			},
+		}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "owner-id").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-owner", gomock.Any()).Return(nil).Times(1)
+
+		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestSetReminders(t *testing.T) {
+	booking := bk.Booking{ID: "123", UserID: "owner-id", Username: "owner", Status: "accepted"}
+
+	t.Run("sorts the lead times", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		updated := booking
+		updated.Reminders = &bk.Reminders{LeadTimes: []int{1440, 120}, Channel: bk.ReminderDM}
+
+		gomock.InOrder(
+			testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil),
+			testDeps.repo.EXPECT().SetReminders(gomock.Any(), "123", bk.Reminders{LeadTimes: []int{1440, 120}, Channel: bk.ReminderDM}).Return(nil),
+			testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(updated, nil),
+		)
+
+		result, err := testDeps.service.SetReminders(testDeps.ctx, "123", bk.Reminders{LeadTimes: []int{120, 1440, 120}}, discord.DiscordUser{ID: "owner-id"})
+
+		require.NoError(t, err)
+		require.Equal(t, updated, result)
+		require.Len(t, testDeps.audit.actions, 1)
+		require.Equal(t, "booking.reminders", testDeps.audit.actions[0].action)
+	})
+
+	t.Run("invalid lead time", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		_, err := testDeps.service.SetReminders(testDeps.ctx, "123", bk.Reminders{LeadTimes: []int{1}}, discord.DiscordUser{ID: "owner-id"})
+
+		require.ErrorIs(t, err, bk.ErrInvalidReminders)
+	})
+
+	t.Run("not an organizer", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().SetReminders(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.SetReminders(testDeps.ctx, "123", bk.Reminders{LeadTimes: []int{120}}, discord.DiscordUser{ID: "player-id", Username: "player"})
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+}
+
+func TestOptOutOfReminders(t *testing.T) {
+	booking := bk.Booking{ID: "123", UserID: "owner-id", Username: "owner", Players: []string{"owner", "player"}}
+
+	t.Run("player", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(2)
+		testDeps.repo.EXPECT().SetReminderOptOut(gomock.Any(), "123", "player", true).Return(nil).Times(1)
+
+		_, err := testDeps.service.OptOutOfReminders(testDeps.ctx, "123", true, discord.DiscordUser{ID: "player-id", Username: "Player"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().SetReminderOptOut(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.OptOutOfReminders(testDeps.ctx, "123", true, discord.DiscordUser{ID: "other-id", Username: "other"})
+
+		require.ErrorIs(t, err, bk.ErrPlayerNotFound)
+	})
+}
+
+func TestRespondToReminders(t *testing.T) {
+	player := discord.DiscordUser{ID: "player-id", Username: "Player"}
+
+	t.Run("acknowledge", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "123", UserID: "owner-id", Status: "accepted", Players: []string{"player"}, DateTime: time.Now().Add(time.Hour)}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(2)
+		testDeps.repo.EXPECT().SetReminderResponse(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, response bk.ReminderResponse) error {
+			require.Equal(t, "player", response.Username)
+			require.True(t, response.Acknowledged)
+			require.Nil(t, response.SnoozedUntil)
+			return nil
+		}).Times(1)
+
+		_, err := testDeps.service.AcknowledgeReminder(testDeps.ctx, "123", player)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("snooze until shortly before the booking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "123", UserID: "owner-id", Status: "accepted", Players: []string{"player"}, DateTime: time.Now().Add(30 * time.Minute)}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(2)
+		testDeps.repo.EXPECT().SetReminderResponse(gomock.Any(), "123", gomock.Any()).DoAndReturn(func(ctx context.Context, id string, response bk.ReminderResponse) error {
+			require.False(t, response.Acknowledged)
+			require.Equal(t, booking.DateTime.Add(-5*time.Minute), *response.SnoozedUntil)
+			return nil
+		}).Times(1)
+
+		_, err := testDeps.service.SnoozeReminder(testDeps.ctx, "123", time.Hour, player)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("snooze too close to the booking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "123", UserID: "owner-id", Status: "accepted", Players: []string{"player"}, DateTime: time.Now().Add(2 * time.Minute)}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().SetReminderResponse(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.SnoozeReminder(testDeps.ctx, "123", time.Hour, player)
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("booking over", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "123", UserID: "owner-id", Status: "accepted", Players: []string{"player"}, DateTime: time.Now().Add(-time.Hour)}
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		testDeps.repo.EXPECT().SetReminderResponse(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.AcknowledgeReminder(testDeps.ctx, "123", player)
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+}
+
+func TestSendDueReminders(t *testing.T) {
+	now := time.Now()
+	reminders := &bk.Reminders{LeadTimes: []int{1440, 120}, Channel: bk.ReminderDM}
+
+	t.Run("sends the shortest lead time reached once", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		bookings := []bk.Booking{
+			// both lead times reached, only the 2 hours one is sent
+			{ID: "1", Game: "soon", UserID: "owner-id", Username: "owner", Status: "accepted", ReminderEnabled: true, DateTime: now.Add(time.Hour), Reminders: reminders},
+			{ID: "2", Game: "tomorrow", UserID: "owner-id", Username: "owner", Status: "accepted", ReminderEnabled: true, DateTime: now.Add(23 * time.Hour), Reminders: reminders},
+			{ID: "3", Game: "later", UserID: "owner-id", Username: "owner", Status: "accepted", ReminderEnabled: true, DateTime: now.Add(48 * time.Hour), Reminders: reminders},
+			{ID: "4", Game: "pending", UserID: "owner-id", Username: "owner", Status: "pending", ReminderEnabled: true, DateTime: now.Add(time.Hour), Reminders: reminders},
+			{ID: "5", Game: "disabled", UserID: "owner-id", Username: "owner", Status: "accepted", DateTime: now.Add(time.Hour), Reminders: reminders},
+		}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.repo.EXPECT().MarkReminderSent(gomock.Any(), "1", 120, bookings[0].DateTime).Return(true, nil).Times(1)
+		testDeps.repo.EXPECT().MarkReminderSent(gomock.Any(), "2", 1440, bookings[1].DateTime).Return(false, nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "owner-id").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-owner", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Contains(t, message.Content, "la partie de soon commence dans 1 h")
+			return nil
+		}).Times(1)
+
+		require.NoError(t, testDeps.service.SendDueReminders(testDeps.ctx))
+	})
+
+	t.Run("posts in the channel mentioning the participants", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "1", Game: "soon", UserID: "owner-id", Username: "owner", Players: []string{"owner", "player2", "player3"},
+			Status: "accepted", ReminderEnabled: true, DateTime: now.Add(time.Hour),
+			Reminders: &bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderChannel, OptedOut: []string{"player3"}}}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{booking}, nil).Times(1)
+		testDeps.repo.EXPECT().MarkReminderSent(gomock.Any(), "1", 120, booking.DateTime).Return(true, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), "player2", 1).Return([]discord.Member{{User: discord.User{ID: "player2-id", Username: "player2"}}}, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.True(t, strings.HasPrefix(message.Content, "<@owner-id> <@player2-id> Rappel"))
+			return nil
+		}).Times(1)
+
+		require.NoError(t, testDeps.service.SendDueReminders(testDeps.ctx))
+	})
+
+	t.Run("skips the participants who acknowledged or snoozed", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		later := now.Add(30 * time.Minute)
+		booking := bk.Booking{ID: "1", Game: "soon", UserID: "owner-id", Username: "owner", Players: []string{"player2", "player3"},
+			Status: "accepted", ReminderEnabled: true, DateTime: now.Add(time.Hour),
+			Reminders: &bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderDM, Responses: []bk.ReminderResponse{
+				{Username: "owner", Acknowledged: true},
+				{Username: "player3", SnoozedUntil: &later},
+			}}}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{booking}, nil).Times(1)
+		testDeps.repo.EXPECT().MarkReminderSent(gomock.Any(), "1", 120, booking.DateTime).Return(true, nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), "player2", 1).Return([]discord.Member{{User: discord.User{ID: "player2-id", Username: "player2"}}}, nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "player2-id").Return("dm-player2", nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-player2", gomock.Any()).Return(nil).Times(1)
+
+		require.NoError(t, testDeps.service.SendDueReminders(testDeps.ctx))
+	})
+
+	t.Run("reminds again once the snooze ends, with buttons", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithReminderButtons())
+		ended := now.Add(-time.Minute)
+		booking := bk.Booking{ID: "1", Game: "soon", UserID: "owner-id", Username: "owner", Status: "accepted", ReminderEnabled: true,
+			DateTime: now.Add(3 * time.Hour), Reminders: &bk.Reminders{LeadTimes: []int{120}, Channel: bk.ReminderDM, Responses: []bk.ReminderResponse{
+				{Username: "owner", SnoozedUntil: &ended},
+			}}}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{booking}, nil).Times(1)
+		testDeps.repo.EXPECT().ClearSnooze(gomock.Any(), "1", "owner", ended).Return(true, nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "owner-id").Return("dm-owner", nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "dm-owner", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			buttons := message.Components[0].Components
+			require.Equal(t, "reminder:acknowledge:1", buttons[0].CustomID)
+			require.Equal(t, "reminder:snooze:1", buttons[1].CustomID)
+			return nil
+		}).Times(1)
+
+		require.NoError(t, svc.SendDueReminders(testDeps.ctx))
+	})
+
+	t.Run("reports failed messages", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		booking := bk.Booking{ID: "1", Game: "soon", UserID: "owner-id", Username: "owner", Status: "accepted", ReminderEnabled: true,
+			DateTime: now.Add(time.Hour), Reminders: reminders}
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{booking}, nil).Times(1)
+		testDeps.repo.EXPECT().MarkReminderSent(gomock.Any(), "1", 120, booking.DateTime).Return(true, nil).Times(1)
+		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), "owner-id").Return("", errors.New("discord is down")).Times(1)
+
+		require.Error(t, testDeps.service.SendDueReminders(testDeps.ctx))
+	})
+}
+
+func TestAutoAcceptPendingBookings(t *testing.T) {
+	policy := bk.AutoAcceptConfig{After: 48 * time.Hour, UserIDs: []string{"trustedID"}}
+
+	now := time.Now()
+	bookings := []bk.Booking{
+		{ID: "1", Game: "overdue", Status: "pending", CreatedAt: now.Add(-72 * time.Hour), DateTime: now.Add(7 * 24 * time.Hour)},
+		{ID: "2", Game: "trusted", UserID: "trustedID", Status: "pending", CreatedAt: now.Add(-time.Hour), DateTime: now.Add(7 * 24 * time.Hour)},
+		{ID: "3", Game: "recent", Status: "pending", CreatedAt: now.Add(-time.Hour), DateTime: now.Add(7 * 24 * time.Hour)},
+		{ID: "4", Game: "past", Status: "pending", CreatedAt: now.Add(-72 * time.Hour), DateTime: now.Add(-time.Hour)},
+		{ID: "5", Game: "refused", Status: "refused", CreatedAt: now.Add(-72 * time.Hour), DateTime: now.Add(12 * time.Hour)},
+	}
+
+	t.Run("accepts overdue and trusted bookings", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithAuditRecorder(testDeps.audit), bk.WithAutoAccept(policy))
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(bookings[0], nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "2").Return(bookings[1], nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "1", "accepted").Return(nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), "2", "accepted").Return(nil).Times(1)
+		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), 1).Return(nil, nil).AnyTimes()
+
+		var reasons []string
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			embed := message.Embeds[0]
+			require.Contains(t, embed.Title, "automatiquement")
+			reasons = append(reasons, embed.Fields[len(embed.Fields)-1].Value)
+			return nil
+		}).Times(2)
+
+		err := svc.AutoAcceptPendingBookings(testDeps.ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"en attente depuis plus de 48h", "membre de confiance"}, reasons)
+		require.Len(t, testDeps.audit.actions, 2)
+		require.Equal(t, "booking.auto-accept", testDeps.audit.actions[0].action)
+		require.Equal(t, map[string]any{"rule": "overdue", "reason": "en attente depuis plus de 48h"}, testDeps.audit.actions[0].payload)
+		require.Equal(t, map[string]any{"rule": "trusted", "reason": "membre de confiance"}, testDeps.audit.actions[1].payload)
+	})
+
+	t.Run("skips bookings answered meanwhile", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithAutoAccept(policy))
+
+		refused := bookings[0]
+		refused.Status = "refused"
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings[:1], nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(refused, nil).Times(1)
+		testDeps.repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := svc.AutoAcceptPendingBookings(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		err := testDeps.service.AutoAcceptPendingBookings(testDeps.ctx)
+
+		require.Error(t, err)
+	})
+}
+
+func TestEscalatePendingBookings(t *testing.T) {
+	escalation := bk.EscalationConfig{
+		ChannelID:   "admin-channel",
+		AdminRoleID: "admin-role",
+		BookingURL:  "https://booking.example/bookings",
+		After:       48 * time.Hour,
+		Before:      24 * time.Hour,
+	}
+
+	now := time.Now()
+	bookings := []bk.Booking{
+		{ID: "1", Game: "overdue", Status: "pending", CreatedAt: now.Add(-72 * time.Hour), DateTime: now.Add(7 * 24 * time.Hour)},
+		{ID: "2", Game: "soon", Status: "pending", CreatedAt: now.Add(-time.Hour), DateTime: now.Add(12 * time.Hour)},
+		{ID: "3", Game: "recent", Status: "pending", CreatedAt: now.Add(-time.Hour), DateTime: now.Add(7 * 24 * time.Hour)},
+		{ID: "4", Game: "accepted", Status: "accepted", CreatedAt: now.Add(-72 * time.Hour), DateTime: now.Add(12 * time.Hour)},
+	}
+
+	t.Run("pings admins once per booking", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithEscalation(escalation))
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings, nil).Times(1)
+		testDeps.repo.EXPECT().MarkBookingEscalated(gomock.Any(), "1").Return(true, nil).Times(1)
+		testDeps.repo.EXPECT().MarkBookingEscalated(gomock.Any(), "2").Return(false, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "admin-channel", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Contains(t, message.Content, "<@&admin-role>")
+			require.Equal(t, "https://booking.example/bookings/1", message.Embeds[0].URL)
+			return nil
+		}).Times(1)
+
+		err := svc.EscalatePendingBookings(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("channel and role changed by the admins", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithEscalation(escalation),
+			bk.WithGuildSettings(func(ctx context.Context) bk.GuildSettings {
+				return bk.GuildSettings{ChannelID: "test-channel-d", AdminChannelID: "new-admin-channel", AdminRoleID: "new-admin-role"}
+			}),
+		)
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings[:1], nil).Times(1)
+		testDeps.repo.EXPECT().MarkBookingEscalated(gomock.Any(), "1").Return(true, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "new-admin-channel", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Contains(t, message.Content, "<@&new-admin-role>")
+			return nil
+		}).Times(1)
+
+		err := svc.EscalatePendingBookings(testDeps.ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("reports failed messages", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		svc := bk.NewService(testDeps.repo, testDeps.client, "test-channel-d", bk.WithEscalation(escalation))
+
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return(bookings[:1], nil).Times(1)
+		testDeps.repo.EXPECT().MarkBookingEscalated(gomock.Any(), "1").Return(true, nil).Times(1)
+		testDeps.client.EXPECT().SendMessage(gomock.Any(), "admin-channel", gomock.Any()).Return(errors.New("discord is down")).Times(1)
+
+		err := svc.EscalatePendingBookings(testDeps.ctx)
+
+		require.Error(t, err)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		err := testDeps.service.EscalatePendingBookings(testDeps.ctx)
+
+		require.Error(t, err)
+	})
+}
+
+func TestReportResult(t *testing.T) {
+	owner := discord.DiscordUser{ID: "1", Username: "owner"}
+	played := bk.Booking{ID: "123", UserID: "1", Status: "accepted", Players: []string{"alice", "bob"}, DateTime: time.Now().Add(-time.Hour)}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(played, nil).Times(1)
+		expected := bk.Result{BookingID: "123", Winner: "alice", Scores: map[string]int{"alice": 10, "bob": 7}, Report: "close game", ReportedBy: "owner"}
+		testDeps.repo.EXPECT().UpsertResult(gomock.Any(), expected).Return(expected, nil).Times(1)
+
+		result, err := testDeps.service.ReportResult(testDeps.ctx, "123", bk.Result{Winner: " Alice", Scores: map[string]int{"ALICE": 10, "bob": 7}, Report: "close game"}, owner)
+
+		require.Nil(t, err)
+		require.Equal(t, expected, result)
+		require.Equal(t, []recordedAction{{action: "booking.result", targetID: "123", payload: map[string]any{"winner": "alice"}}}, testDeps.audit.actions)
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		upcoming := played
+		upcoming.DateTime = time.Now().Add(time.Hour)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(upcoming, nil).Times(1)
+		testDeps.repo.EXPECT().UpsertResult(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.ReportResult(testDeps.ctx, "123", bk.Result{Winner: "alice"}, owner)
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+	})
+
+	t.Run("not the organizer", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(played, nil).Times(1)
+		testDeps.repo.EXPECT().UpsertResult(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.ReportResult(testDeps.ctx, "123", bk.Result{Winner: "alice"}, discord.DiscordUser{ID: "2", Username: "bob"})
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("unknown player", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(played, nil).Times(1)
+		testDeps.repo.EXPECT().UpsertResult(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := testDeps.service.ReportResult(testDeps.ctx, "123", bk.Result{Scores: map[string]int{"carol": 3}}, discord.DiscordUser{ID: "9", Admin: true})
+
+		require.ErrorIs(t, err, bk.ErrInvalidResult)
+	})
+}
+
+func TestJoinBooking(t *testing.T) {
+	open := bk.Booking{ID: "123", UserID: "1", Username: "owner", Game: "Necromunda", Status: "accepted", Players: []string{"alice"}, LookingForPlayers: true, DateTime: time.Now().Add(24 * time.Hour)}
+	bob := discord.DiscordUser{ID: "2", Username: "Bob"}
+
+	newService := func(t *testing.T, opts ...bk.ServiceOption) (*bk_mocks.MockBookingRepository, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+
+		return repo, bk.NewService(repo, client, "test-channel-d", opts...)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		joined := open
+		joined.Players = []string{"alice", "bob"}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(open, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), joined).Return(nil).Times(1)
+
+		booking, err := svc.JoinBooking(context.Background(), "123", bob)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"alice", "bob"}, booking.Players)
+		require.True(t, booking.LookingForPlayers)
+	})
+
+	t.Run("last seat", func(t *testing.T) {
+		repo, svc := newService(t, bk.WithMaxPlayers(2))
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(open, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		booking, err := svc.JoinBooking(context.Background(), "123", bob)
+
+		require.Nil(t, err)
+		require.False(t, booking.LookingForPlayers)
+	})
+
+	t.Run("not looking for players", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		closed := open
+		closed.LookingForPlayers = false
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(closed, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.JoinBooking(context.Background(), "123", bob)
+
+		require.ErrorIs(t, err, bk.ErrNoOpenSeat)
+	})
+
+	t.Run("already playing", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(open, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.JoinBooking(context.Background(), "123", discord.DiscordUser{ID: "3", Username: "Alice"})
+
+		require.ErrorIs(t, err, bk.ErrAlreadyJoined)
+	})
+}
+
+func TestLeaveBooking(t *testing.T) {
+	booking := bk.Booking{ID: "123", UserID: "1", Username: "owner", Game: "Necromunda", Status: "accepted", Players: []string{"owner", "alice", "bob"}, DateTime: time.Now().Add(24 * time.Hour)}
+	bob := discord.DiscordUser{ID: "2", Username: "Bob"}
+
+	newService := func(t *testing.T) (*bk_mocks.MockBookingRepository, *dc_mocks.MockDiscordClient, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+		return repo, client, bk.NewService(repo, client, "test-channel-d")
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo, client, svc := newService(t)
+
+		left := booking
+		left.Players = []string{"owner", "alice"}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), left).Return(nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		updated, err := svc.LeaveBooking(context.Background(), "123", bob)
+
+		require.Nil(t, err)
+		require.Equal(t, []string{"owner", "alice"}, updated.Players)
+	})
+
+	t.Run("not playing", func(t *testing.T) {
+		repo, _, svc := newService(t)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.LeaveBooking(context.Background(), "123", discord.DiscordUser{ID: "3", Username: "carol"})
+
+		require.ErrorIs(t, err, bk.ErrPlayerNotFound)
+	})
+
+	t.Run("the owner cancels instead", func(t *testing.T) {
+		repo, _, svc := newService(t)
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.LeaveBooking(context.Background(), "123", discord.DiscordUser{ID: "1", Username: "owner"})
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+}
+
+func TestPermissions(t *testing.T) {
+	now := time.Now()
+	booking := bk.Booking{ID: "123", UserID: "1", Status: "pending", Players: []string{"owner", "alice"}, CoOrganizers: []string{"carol"}, LookingForPlayers: true, DateTime: now.Add(time.Hour)}
+	owner := discord.DiscordUser{ID: "1", Username: "owner"}
+	player := discord.DiscordUser{ID: "2", Username: "Alice"}
+	coOrganizer := discord.DiscordUser{ID: "3", Username: "carol"}
+	admin := discord.DiscordUser{ID: "4", Username: "admin", Admin: true}
+	member := discord.DiscordUser{ID: "5", Username: "dave"}
+
+	for name, test := range map[string]struct {
+		user                        discord.DiscordUser
+		modify, cancel, join, leave bool
+	}{
+		"owner":        {owner, true, true, false, false},
+		"co-organizer": {coOrganizer, true, true, true, false},
+		"player":       {player, false, false, false, true},
+		"admin":        {admin, true, true, true, false},
+		"member":       {member, false, false, true, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.modify, bk.CanModify(booking, test.user), "modify")
+			require.Equal(t, test.cancel, bk.CanCancel(booking, test.user), "cancel")
+			require.Equal(t, test.join, bk.CanJoin(booking, test.user, now), "join")
+			require.Equal(t, test.leave, bk.CanLeave(booking, test.user, now), "leave")
+		})
+	}
+
+	t.Run("nobody joins or leaves a booking over", func(t *testing.T) {
+		require.False(t, bk.CanJoin(booking, member, now.Add(2*time.Hour)))
+		require.False(t, bk.CanLeave(booking, player, now.Add(2*time.Hour)))
+	})
+}
+
+func TestReplacePlayer(t *testing.T) {
+	booking := bk.Booking{ID: "123", UserID: "1", Username: "owner", Game: "Necromunda", Status: "accepted", Players: []string{"owner", "alice"}, DateTime: time.Now().Add(24 * time.Hour)}
+	carol := discord.Member{User: discord.User{ID: "3", Username: "carol"}}
+
+	newService := func(t *testing.T) (*bk_mocks.MockBookingRepository, *dc_mocks.MockDiscordClient, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		repo.EXPECT().GetBookingByID(gomock.Any(), "123").Return(booking, nil).AnyTimes()
+		client.EXPECT().SearchMembers(gomock.Any(), "carol", gomock.Any()).Return([]discord.Member{carol}, nil).AnyTimes()
+
+		return repo, client, bk.NewService(repo, client, "test-channel-d")
+	}
+
+	for name, user := range map[string]discord.DiscordUser{
+		"by the organizer":       {ID: "1", Username: "owner"},
+		"by the outgoing player": {ID: "2", Username: "Alice"},
+		"by an admin":            {ID: "9", Username: "admin", Admin: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo, client, svc := newService(t)
+
+			replaced := booking
+			replaced.Players = []string{"owner", "carol"}
+			repo.EXPECT().UpdateBooking(gomock.Any(), replaced).Return(nil).Times(1)
+			client.EXPECT().SearchMembers(gomock.Any(), "owner", 1).Return(nil, nil).Times(1)
+			client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+				embed := message.Embeds[0]
+				require.Contains(t, embed.Title, "Joueur Remplacé")
+				require.Equal(t, "carol remplace alice", embed.Fields[len(embed.Fields)-1].Value)
+				return nil
+			}).Times(1)
+
+			got, err := svc.ReplacePlayer(context.Background(), "123", "alice", " Carol ", user)
+
+			require.Nil(t, err)
+			require.Equal(t, []string{"owner", "carol"}, got.Players)
+		})
+	}
+
+	t.Run("another player", func(t *testing.T) {
+		repo, _, svc := newService(t)
+
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReplacePlayer(context.Background(), "123", "alice", "carol", discord.DiscordUser{ID: "4", Username: "owner2"})
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("not playing", func(t *testing.T) {
+		repo, _, svc := newService(t)
+
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReplacePlayer(context.Background(), "123", "bob", "carol", discord.DiscordUser{ID: "1", Username: "owner"})
+
+		require.ErrorIs(t, err, bk.ErrPlayerNotFound)
+	})
+
+	t.Run("unknown new player", func(t *testing.T) {
+		repo, client, svc := newService(t)
+
+		client.EXPECT().SearchMembers(gomock.Any(), "dave", gomock.Any()).Return([]discord.Member{{User: discord.User{ID: "5", Username: "davy"}}}, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ReplacePlayer(context.Background(), "123", "alice", "dave", discord.DiscordUser{ID: "1", Username: "owner"})
+
+		var unknown *bk.UnknownPlayersError
+		require.ErrorAs(t, err, &unknown)
+		require.Equal(t, []string{"davy"}, unknown.Players[0].Suggestions)
+	})
+}
+
+func TestAnnounceOpenSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := bk_mocks.NewMockBookingRepository(ctrl)
+	client := dc_mocks.NewMockDiscordClient(ctrl)
+	svc := bk.NewService(repo, client, "test-channel-d", bk.WithOpenSeatsChannel("lfg-channel"), bk.WithMaxPlayers(4))
+
+	toInsert := bk.Booking{UserID: "1", Username: "owner", Game: "Necromunda", Points: 1000, LookingForPlayers: true, DateTime: time.Now().Add(24 * time.Hour)}
+	inserted := toInsert
+	inserted.ID = "123"
+	repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).Return(inserted, nil).Times(1)
+	client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Return(nil).Times(1)
+	client.EXPECT().SendMessage(gomock.Any(), "lfg-channel", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+		require.Equal(t, "<@1> cherche des joueurs pour une partie de Necromunda !", message.Content)
+		require.Equal(t, discord.EmbedField{Name: "Joueurs", Value: "0/4", Inline: true}, message.Embeds[0].Fields[3])
+		return nil
+	}).Times(1)
+
+	_, err := svc.CreateBooking(context.Background(), toInsert)
+
+	require.Nil(t, err)
+}
+
+func TestSuggestSlots(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "John.Doe"}
+	favorites := []bk.GameBookingCount{{Game: "Necromunda", Count: 3}}
+
+	t.Run("skips the full sessions and the ones the user plays", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithLocation(time.UTC), bk.WithSessions(2, []time.Duration{20 * time.Hour}))
+
+		now := time.Now().UTC()
+		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 20, 0, 0, 0, time.UTC)
+		afterTomorrow := tomorrow.AddDate(0, 0, 1)
+		inThreeDays := tomorrow.AddDate(0, 0, 2)
+
+		repo.EXPECT().GetFavoriteGames(gomock.Any(), "1", "john.doe", 3).Return(favorites, nil).Times(1)
+		repo.EXPECT().GetBookedSlots(gomock.Any(), gomock.Any(), gomock.Any(), "john.doe").Return([]bk.BookedSlot{
+			{DateTime: tomorrow, Mine: true},
+			{DateTime: afterTomorrow},
+			{DateTime: afterTomorrow.Add(time.Hour)},
+			{DateTime: inThreeDays.Add(-time.Hour)},
+		}, nil).Times(1)
+
+		suggestions, err := svc.SuggestSlots(context.Background(), user)
+
+		require.Nil(t, err)
+		require.Equal(t, favorites, suggestions.FavoriteGames)
+		require.GreaterOrEqual(t, len(suggestions.Slots), 5)
+
+		for _, slot := range suggestions.Slots {
+			require.NotEqual(t, tomorrow, slot.DateTime)
+			require.NotEqual(t, afterTomorrow, slot.DateTime)
+			require.True(t, slot.DateTime.After(now))
+
+			if slot.DateTime.Equal(inThreeDays) {
+				require.Equal(t, 1, slot.FreeTables)
+			} else {
+				require.Equal(t, 2, slot.FreeTables)
+			}
+		}
+	})
+
+	t.Run("nothing to suggest without favorite games", func(t *testing.T) {
+		ctrl, testDeps := newTestDeps(t)
+		defer ctrl.Finish()
+
+		testDeps.repo.EXPECT().GetFavoriteGames(gomock.Any(), "1", "john.doe", 3).Return([]bk.GameBookingCount{}, nil).Times(1)
+		testDeps.repo.EXPECT().GetBookedSlots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		suggestions, err := testDeps.service.SuggestSlots(testDeps.ctx, user)
+
+		require.Nil(t, err)
+		require.Empty(t, suggestions.Slots)
+	})
+}
+
+func TestFindFreeSlots(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "John.Doe"}
+	now := time.Now().UTC()
+	inTenDays := time.Date(now.Year(), now.Month(), now.Day()+10, 20, 0, 0, 0, time.UTC)
+
+	newService := func(t *testing.T) (*bk_mocks.MockBookingRepository, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+
+		return repo, bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithLocation(time.UTC), bk.WithSessions(2, []time.Duration{20 * time.Hour}))
+	}
+
+	t.Run("proposes the nearest sessions with a table free", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{
+			{Game: "Necromunda", DateTime: inTenDays, Status: "accepted", LookingForPlayers: true},
+			{Game: "Blood Bowl", DateTime: inTenDays.Add(time.Hour), Status: "pending"},
+			{Game: "Necromunda", DateTime: inTenDays.AddDate(0, 0, 1), Status: "refused"},
+			{Game: "Necromunda", DateTime: inTenDays.AddDate(0, 0, -1).Add(time.Hour), Status: "accepted", LookingForPlayers: true},
+			{Game: "Necromunda", DateTime: inTenDays.AddDate(0, 0, 1), Status: "accepted", Players: []string{"john.doe"}},
+		}, nil).Times(1)
+
+		slots, err := svc.FindFreeSlots(context.Background(), bk.SlotRequest{Game: "necromunda", Duration: 2 * time.Hour, Around: inTenDays}, user)
+
+		require.Nil(t, err)
+		require.Equal(t, []bk.FreeSlot{
+			{DateTime: inTenDays.AddDate(0, 0, -1), FreeTables: 1, OpenBookings: 1},
+			{DateTime: inTenDays.AddDate(0, 0, -2), FreeTables: 2},
+			{DateTime: inTenDays.AddDate(0, 0, 2), FreeTables: 2},
+			{DateTime: inTenDays.AddDate(0, 0, -3), FreeTables: 2},
+			{DateTime: inTenDays.AddDate(0, 0, 3), FreeTables: 2},
+		}, slots)
+	})
+
+	t.Run("never proposes a past session", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{}, nil).Times(1)
+
+		slots, err := svc.FindFreeSlots(context.Background(), bk.SlotRequest{Around: now.AddDate(0, 0, -30)}, user)
+
+		require.Nil(t, err)
+		require.Len(t, slots, 5)
+
+		for _, slot := range slots {
+			require.True(t, slot.DateTime.After(now))
+		}
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		repo, svc := newService(t)
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Times(0)
+
+		_, err := svc.FindFreeSlots(context.Background(), bk.SlotRequest{Duration: 24 * time.Hour}, user)
+
+		require.ErrorIs(t, err, bk.ErrInvalidDuration)
+	})
+}
+
+// memoryStorage is an AttachmentStorage keeping the files in a map.
+type memoryStorage map[string][]byte
+
+func (s memoryStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	s[key] = content
+	return nil
+}
+
+func (s memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s[key])), nil
+}
+
+func (s memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestAddAttachment(t *testing.T) {
+	booking := bk.Booking{ID: "1", UserID: "user1ID", Username: "user1", Players: []string{"user1", "player2"}}
+	player := discord.DiscordUser{ID: "player2ID", Username: "player2"}
+
+	newService := func(t *testing.T) (*bk_mocks.MockBookingRepository, memoryStorage, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		files := memoryStorage{}
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithAttachmentStorage(files, 16))
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil).AnyTimes()
+
+		return repo, files, svc
+	}
+
+	t.Run("player attaches a list", func(t *testing.T) {
+		repo, files, svc := newService(t)
+
+		repo.EXPECT().InsertAttachment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, a bk.Attachment) (bk.Attachment, error) {
+			a.ID = "4"
+			return a, nil
+		}).Times(1)
+
+		attachment, err := svc.AddAttachment(context.Background(), "1", "../lists/army.txt", strings.NewReader("1000 pts"), player)
+
+		require.Nil(t, err)
+		require.Equal(t, "army.txt", attachment.Filename)
+		require.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+		require.Equal(t, int64(8), attachment.Size)
+		require.Equal(t, []byte("1000 pts"), files[attachment.StorageKey])
+	})
+
+	t.Run("not a player", func(t *testing.T) {
+		repo, _, svc := newService(t)
+
+		repo.EXPECT().InsertAttachment(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.AddAttachment(context.Background(), "1", "army.txt", strings.NewReader("1000 pts"), discord.DiscordUser{ID: "3", Username: "other"})
+
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		repo, files, svc := newService(t)
+
+		repo.EXPECT().InsertAttachment(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.AddAttachment(context.Background(), "1", "army.txt", strings.NewReader("2000 points of Space Marines"), player)
+
+		require.ErrorIs(t, err, bk.ErrAttachmentTooLarge)
+		require.Empty(t, files)
+	})
+
+	t.Run("type not accepted", func(t *testing.T) {
+		repo, files, svc := newService(t)
+
+		repo.EXPECT().InsertAttachment(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.AddAttachment(context.Background(), "1", "army.zip", strings.NewReader("PK\x03\x04 zipped"), player)
+
+		require.ErrorIs(t, err, bk.ErrInvalidAttachment)
+		require.Empty(t, files)
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	booking := bk.Booking{ID: "1", UserID: "user1ID", Username: "user1", Players: []string{"user1", "player2"}, Status: "accepted", DateTime: time.Now().Add(30 * time.Minute)}
+	player := discord.DiscordUser{ID: "player2ID", Username: "player2"}
+
+	newService := func(t *testing.T, booking bk.Booking) (*bk_mocks.MockBookingRepository, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithCheckIn("secret", "https://club.example/check-in"))
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil).AnyTimes()
+
+		return repo, svc
+	}
+
+	token := func(t *testing.T, svc *bk.Service) string {
+		checkInURL, err := svc.CheckInURL(context.Background(), "1", player)
+		require.Nil(t, err)
+
+		rest, ok := strings.CutPrefix(checkInURL, "https://club.example/check-in/1?token=")
+		require.True(t, ok, checkInURL)
+
+		return rest
+	}
+
+	t.Run("scanned before the booking", func(t *testing.T) {
+		repo, svc := newService(t, booking)
+
+		repo.EXPECT().SetCheckedIn(gomock.Any(), "1", gomock.Any()).Return(nil).Times(1)
+
+		checkedIn, err := svc.CheckIn(context.Background(), "1", token(t, svc))
+
+		require.Nil(t, err)
+		require.NotNil(t, checkedIn.CheckedInAt)
+	})
+
+	t.Run("scanned again", func(t *testing.T) {
+		at := time.Now().Add(-time.Minute)
+		checkedIn := booking
+		checkedIn.CheckedInAt = &at
+		repo, svc := newService(t, checkedIn)
+
+		repo.EXPECT().SetCheckedIn(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		err := testDeps.service.AcceptBooking(testDeps.ctx, "123")
-		require.Error(t, err)
+		got, err := svc.CheckIn(context.Background(), "1", token(t, svc))
+
+		require.Nil(t, err)
+		require.Equal(t, &at, got.CheckedInAt)
 	})
-}
 
-func TestRefuseBooking(t *testing.T) {
-	member1 := discord.Member{
-		User: discord.User{
-			ID:       "12345",
-			Username: "user1",
-		},
-	}
+	t.Run("forged token", func(t *testing.T) {
+		repo, svc := newService(t, booking)
 
-	member2 := discord.Member{
-		User: discord.User{
-			ID:       "abcdef",
-			Username: "player2",
-		},
-	}
+		repo.EXPECT().SetCheckedIn(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-	t.Run("success", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+		_, err := svc.CheckIn(context.Background(), "1", "forged")
 
-		b := bk.Booking{
-			ID:       "123",
-			Status:   "pending",
-			Players:  []string{"user1", "player2"},
-			DateTime: time.Now(),
-		}
+		require.ErrorIs(t, err, bk.ErrInvalidCheckInToken)
+	})
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "refused").Return(nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	t.Run("too early", func(t *testing.T) {
+		later := booking
+		later.DateTime = time.Now().Add(24 * time.Hour)
+		repo, svc := newService(t, later)
 
-		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
-		require.Nil(t, err)
+		repo.EXPECT().SetCheckedIn(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CheckIn(context.Background(), "1", token(t, svc))
+
+		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("code of a pending booking", func(t *testing.T) {
+		pending := booking
+		pending.Status = "pending"
+		_, svc := newService(t, pending)
 
-		b := bk.Booking{ID: "123", Status: "canceled"}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		_, err := svc.CheckInURL(context.Background(), "1", player)
 
-		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
 		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
 	})
 
-	t.Run("repo error GetBookingById", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("code for another member", func(t *testing.T) {
+		_, svc := newService(t, booking)
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		_, err := svc.CheckInURL(context.Background(), "1", discord.DiscordUser{ID: "3", Username: "other"})
 
-		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
-		require.Error(t, err)
+		require.ErrorIs(t, err, bk.ErrNotAllowed)
 	})
 
-	t.Run("repo error SetBookingStatus", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		b := bk.Booking{ID: "123", Status: "pending"}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "refused").Return(errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		svc := bk.NewService(bk_mocks.NewMockBookingRepository(ctrl), dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d")
 
-		err := testDeps.service.RefuseBooking(testDeps.ctx, "123", "because")
-		require.Error(t, err)
+		_, err := svc.CheckIn(context.Background(), "1", "token")
+
+		require.ErrorIs(t, err, bk.ErrCheckInDisabled)
 	})
 }
 
-func TestCancelBooking(t *testing.T) {
-	member1 := discord.Member{
-		User: discord.User{
-			ID:       "12345",
-			Username: "user1",
-		},
+// paymentProvider creates checkouts and confirms the payment of booking "1"
+// for the signature "valid".
+type paymentProvider struct {
+	requests []bk.CheckoutRequest
+}
+
+func (p *paymentProvider) CreateCheckout(ctx context.Context, request bk.CheckoutRequest) (bk.CheckoutSession, error) {
+	p.requests = append(p.requests, request)
+	return bk.CheckoutSession{ID: "cs_1", URL: "https://checkout.example/cs_1"}, nil
+}
+
+func (p *paymentProvider) ParseWebhook(payload []byte, signature string) (bk.PaymentConfirmation, bool, error) {
+	if signature != "valid" {
+		return bk.PaymentConfirmation{}, false, errors.New("bad signature")
 	}
 
-	member2 := discord.Member{
-		User: discord.User{
-			ID:       "abcdef",
-			Username: "player2",
-		},
+	return bk.PaymentConfirmation{BookingID: "1", SessionID: "cs_1"}, string(payload) == "paid", nil
+}
+
+func TestPayments(t *testing.T) {
+	booking := bk.Booking{ID: "1", Game: "Warhammer", UserID: "user1ID", Username: "user1", Status: "pending", DateTime: time.Date(2026, 11, 7, 14, 0, 0, 0, time.UTC)}
+
+	newService := func(t *testing.T, options ...bk.ServiceOption) (*bk_mocks.MockBookingRepository, *dc_mocks.MockDiscordClient, *recordingAuditRecorder, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		audit := &recordingAuditRecorder{}
+		audit.recordTx(repo)
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+		options = append(options, bk.WithAuditRecorder(audit), bk.WithLocation(time.UTC))
+
+		return repo, client, audit, bk.NewService(repo, client, "test-channel-d", options...)
 	}
 
-	user := discord.DiscordUser{ID: "user1ID", Username: "user1", Admin: false}
+	t.Run("charges the organizer on acceptance", func(t *testing.T) {
+		provider := &paymentProvider{}
+		repo, client, audit, svc := newService(t, bk.WithPayments(provider, bk.PaymentConfig{Fee: 500, Currency: "eur"}))
+
+		accepted := booking
+		accepted.Status = "accepted"
+		unpaid := accepted
+		unpaid.PaymentStatus = bk.PaymentUnpaid
+
+		gomock.InOrder(
+			repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil),
+			repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(unpaid, nil),
+		)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "1", "accepted").Return(nil).Times(1)
+		repo.EXPECT().InsertPayment(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, payment bk.Payment) (bk.Payment, error) {
+			require.Equal(t, "https://checkout.example/cs_1", payment.URL)
+			require.Equal(t, bk.PaymentUnpaid, payment.Status)
+			return payment, nil
+		}).Times(1)
+		repo.EXPECT().SetPaymentStatus(gomock.Any(), "1", bk.PaymentUnpaid).Return(nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Times(1)
+		client.EXPECT().GetDMChannel(gomock.Any(), "user1ID").Return("dm-user1", nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), "dm-user1", gomock.Any()).DoAndReturn(func(ctx context.Context, channelID string, message discord.Message) error {
+			require.Contains(t, message.Content, "5.00 EUR")
+			require.Contains(t, message.Content, "https://checkout.example/cs_1")
+			return nil
+		}).Times(1)
+
+		err := svc.AcceptBooking(context.Background(), "1")
 
-	t.Run("success", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+		require.Nil(t, err)
+		require.Equal(t, []bk.CheckoutRequest{{BookingID: "1", Description: "Table Warhammer du 07/11/2026 14:00", Amount: 500, Currency: "eur"}}, provider.requests)
+		require.Equal(t, []recordedAction{
+			{action: "booking.accept", targetID: "1"},
+			{action: "booking.payment", targetID: "1", payload: map[string]any{"status": bk.PaymentUnpaid}},
+		}, audit.actions)
+	})
 
-		b := bk.Booking{
-			ID:       "123",
-			UserID:   "user1ID",
-			Username: "user1",
-			Status:   "pending",
-			Players:  []string{"user1", "player2"},
-			DateTime: time.Now(),
-		}
+	t.Run("members are not charged", func(t *testing.T) {
+		provider := &paymentProvider{}
+		repo, client, _, svc := newService(t, bk.WithPayments(provider, bk.PaymentConfig{Fee: 500, Currency: "eur"}), bk.WithMemberChecker(memberChecker{"user1ID": false}))
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "canceled").Return(nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member1.User.Username, 1).Return([]discord.Member{member1}, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "1", "accepted").Return(nil).Times(1)
+		repo.EXPECT().InsertPayment(gomock.Any(), gomock.Any()).Times(0)
+		client.EXPECT().SendMessage(gomock.Any(), "test-channel-d", gomock.Any()).Times(1)
+
+		err := svc.AcceptBooking(context.Background(), "1")
 
-		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
 		require.Nil(t, err)
+		require.Empty(t, provider.requests)
 	})
 
-	t.Run("invalid state", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("confirms a paid checkout", func(t *testing.T) {
+		repo, _, audit, svc := newService(t, bk.WithPayments(&paymentProvider{}, bk.PaymentConfig{Fee: 500, Currency: "eur"}))
 
-		b := bk.Booking{ID: "123", Status: "refused"}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		repo.EXPECT().SetPaymentStatus(gomock.Any(), "1", bk.PaymentPaid).Return(nil).Times(1)
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil).Times(1)
 
-		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
-		require.ErrorIs(t, err, bk.ErrInvalidBookingState)
+		err := svc.ConfirmPayment(context.Background(), []byte("paid"), "valid")
+
+		require.Nil(t, err)
+		require.Equal(t, []recordedAction{{action: "booking.payment", targetID: "1", payload: map[string]any{"status": bk.PaymentPaid}}}, audit.actions)
 	})
 
-	t.Run("not allowed", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("ignores the other events", func(t *testing.T) {
+		repo, _, _, svc := newService(t, bk.WithPayments(&paymentProvider{}, bk.PaymentConfig{Fee: 500, Currency: "eur"}))
 
-		notAllowedUser := discord.DiscordUser{ID: "someone", Username: "someone", Admin: false}
-		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "pending", Players: []string{"user1", "player2"}}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		repo.EXPECT().SetPaymentStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		err := testDeps.service.CancelBooking(testDeps.ctx, "123", notAllowedUser)
-		require.ErrorIs(t, err, bk.ErrNotAllowed)
+		require.Nil(t, svc.ConfirmPayment(context.Background(), []byte("expired"), "valid"))
 	})
 
-	t.Run("repo error GetBookingById", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("invalid signature", func(t *testing.T) {
+		_, _, _, svc := newService(t, bk.WithPayments(&paymentProvider{}, bk.PaymentConfig{Fee: 500, Currency: "eur"}))
 
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(bk.Booking{}, errors.New("repo error")).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		err := svc.ConfirmPayment(context.Background(), []byte("paid"), "forged")
 
-		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
-		require.Error(t, err)
+		require.ErrorIs(t, err, bk.ErrInvalidPaymentEvent)
 	})
 
-	t.Run("repo error SetBookingStatus", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("payments disabled", func(t *testing.T) {
+		_, _, _, svc := newService(t)
 
-		b := bk.Booking{ID: "123", UserID: "user1ID", Username: "user1", Status: "pending", Players: []string{"user1", "player2"}}
-		testDeps.repo.EXPECT().GetBookingByID(testDeps.ctx, "123").Return(b, nil).Times(1)
-		testDeps.repo.EXPECT().SetBookingStatus(testDeps.ctx, "123", "canceled").Return(errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		err := svc.ConfirmPayment(context.Background(), []byte("paid"), "valid")
 
-		err := testDeps.service.CancelBooking(testDeps.ctx, "123", user)
-		require.Error(t, err)
-		require.ErrorContains(t, err, "failed to cancel booking")
+		require.ErrorIs(t, err, bk.ErrPaymentsDisabled)
 	})
-}
 
-func TestGetBookingCountPerGame(t *testing.T) {
-	stats := []bk.GameBookingCount{{Game: "test1", Count: 2}}
+	t.Run("admin waives the fee", func(t *testing.T) {
+		repo, _, _, svc := newService(t)
+		waived := booking
+		waived.PaymentStatus = bk.PaymentWaived
 
-	t.Run("success", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+		repo.EXPECT().SetPaymentStatus(gomock.Any(), "1", bk.PaymentWaived).Return(nil).Times(1)
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(waived, nil).Times(1)
 
-		testDeps.repo.EXPECT().GetBookingCountPerGame(testDeps.ctx).Return(stats, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		got, err := svc.SetPaymentStatus(context.Background(), "1", bk.PaymentWaived)
 
-		got, err := testDeps.service.GetBookingCountPerGame(testDeps.ctx)
 		require.Nil(t, err)
-		require.True(t, reflect.DeepEqual(got, stats))
+		require.Equal(t, bk.PaymentWaived, got.PaymentStatus)
 	})
 
-	t.Run("repo error", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	t.Run("invalid status", func(t *testing.T) {
+		repo, _, _, svc := newService(t)
 
-		testDeps.repo.EXPECT().GetBookingCountPerGame(testDeps.ctx).Return(nil, errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		repo.EXPECT().SetPaymentStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		got, err := testDeps.service.GetBookingCountPerGame(testDeps.ctx)
-		require.Error(t, err)
-		require.Equal(t, 0, len(got))
+		_, err := svc.SetPaymentStatus(context.Background(), "1", "refunded")
+
+		require.ErrorIs(t, err, bk.ErrInvalidPaymentStatus)
 	})
 }
 
-func TestGetBookingCountPerWeekDay(t *testing.T) {
-	stats := []bk.WeekDayBookingCount{{WeekDay: "Monday", Count: 2}}
+func TestPrimeTime(t *testing.T) {
+	// a Friday evening, within the slot
+	friday := time.Date(2026, 11, 6, 20, 0, 0, 0, time.UTC)
+	slots := []bk.PrimeTime{{Day: time.Friday, Start: 18 * time.Hour, End: 24 * time.Hour}}
+	registry := memberChecker{"memberID": false, "guestID": true}
 
-	t.Run("success", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	newService := func(t *testing.T) (*bk_mocks.MockBookingRepository, *dc_mocks.MockDiscordClient, *bk.Service) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
 
-		testDeps.repo.EXPECT().GetBookingCountPerWeekDay(testDeps.ctx).Return(stats, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
 
-		got, err := testDeps.service.GetBookingCountPerWeekDay(testDeps.ctx)
-		require.Nil(t, err)
-		require.True(t, reflect.DeepEqual(got, stats))
-	})
+		return repo, client, bk.NewService(repo, client, "test-channel-d", bk.WithLocation(time.UTC), bk.WithPrimeTime(slots), bk.WithMemberChecker(registry))
+	}
 
-	t.Run("repo error", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
-		defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		user    discord.DiscordUser
+		at      time.Time
+		allowed bool
+	}{
+		{name: "member", user: discord.DiscordUser{ID: "memberID", Username: "member"}, at: friday, allowed: true},
+		{name: "whitelisted guest", user: discord.DiscordUser{ID: "guestID", Username: "guest"}, at: friday, allowed: true},
+		{name: "admin", user: discord.DiscordUser{ID: "adminID", Username: "admin", Admin: true}, at: friday, allowed: true},
+		{name: "non-member outside prime time", user: discord.DiscordUser{ID: "otherID", Username: "other"}, at: friday.Add(-4 * time.Hour), allowed: true},
+		{name: "non-member", user: discord.DiscordUser{ID: "otherID", Username: "other"}, at: friday},
+	}
 
-		testDeps.repo.EXPECT().GetBookingCountPerWeekDay(testDeps.ctx).Return(nil, errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, client, svc := newService(t)
+			booking := bk.Booking{Game: "test1", UserID: tt.user.ID, Username: tt.user.Username, DateTime: tt.at}
+
+			if tt.allowed {
+				repo.EXPECT().InsertBooking(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, booking bk.Booking) (bk.Booking, error) {
+					booking.ID = "1"
+					return booking, nil
+				}).Times(1)
+				client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			}
+
+			_, err := svc.CreateBooking(discord.ContextWithUser(context.Background(), tt.user), booking)
+
+			if tt.allowed {
+				require.Nil(t, err)
+			} else {
+				require.ErrorIs(t, err, bk.ErrMembershipRequired)
+			}
+		})
+	}
 
-		got, err := testDeps.service.GetBookingCountPerWeekDay(testDeps.ctx)
-		require.Error(t, err)
-		require.Equal(t, 0, len(got))
+	t.Run("non-member claiming the id of a member", func(t *testing.T) {
+		_, _, svc := newService(t)
+		user := discord.DiscordUser{ID: "otherID", Username: "other"}
+		booking := bk.Booking{Game: "test1", UserID: "memberID", Username: "member", DateTime: friday}
+
+		_, err := svc.CreateBooking(discord.ContextWithUser(context.Background(), user), booking)
+
+		require.ErrorIs(t, err, bk.ErrMembershipRequired)
+	})
+
+	t.Run("moving a booking into prime time", func(t *testing.T) {
+		repo, _, svc := newService(t)
+		user := discord.DiscordUser{ID: "otherID", Username: "other"}
+		booking := bk.Booking{ID: "1", Game: "test1", UserID: user.ID, Username: user.Username, Status: "pending", DateTime: friday.Add(-4 * time.Hour)}
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).Times(1)
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(booking, nil).Times(1)
+		repo.EXPECT().UpdateBooking(gomock.Any(), gomock.Any()).Times(0)
+
+		moved := booking
+		moved.DateTime = friday
+		err := svc.ModifyBooking(discord.ContextWithUser(context.Background(), user), moved, user)
+
+		require.ErrorIs(t, err, bk.ErrMembershipRequired)
 	})
 }
 
-func TestGetBookingCountPerGameInPeriod(t *testing.T) {
-	stats := []bk.GameBookingCount{{Game: "test1", Count: 2}}
-	start := time.Now().Add(-24 * time.Hour)
-	end := time.Now()
+func TestPriority(t *testing.T) {
+	slot := time.Now().Add(48 * time.Hour)
+	normal := bk.Booking{ID: "1", Game: "Kill Team", Status: "pending", Priority: bk.PriorityNormal, DateTime: slot, CreatedAt: time.Now().Add(-3 * time.Hour)}
+	league := bk.Booking{ID: "2", Game: "Blood Bowl", Status: "pending", Priority: bk.PriorityLeague, DateTime: slot.Add(time.Hour), CreatedAt: time.Now().Add(-time.Hour)}
+	tournament := bk.Booking{ID: "3", Game: "Warhammer", Status: "pending", Priority: bk.PriorityTournament, DateTime: slot.Add(48 * time.Hour), CreatedAt: time.Now()}
+	taken := bk.Booking{ID: "4", Game: "Necromunda", Status: "accepted", Priority: bk.PriorityNormal, DateTime: slot}
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("queue", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingCountPerGameInPeriod(testDeps.ctx, start, end).Return(stats, nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{normal, taken, tournament, league}, nil).Times(1)
+		testDeps.repo.EXPECT().GetOrganizerHistories(gomock.Any(), []string{""}, gomock.Any()).Return(nil, nil).Times(1)
+
+		queue, err := testDeps.service.GetPendingQueue(testDeps.ctx)
 
-		got, err := testDeps.service.GetBookingCountPerGameInPeriod(testDeps.ctx, start, end)
 		require.Nil(t, err)
-		require.True(t, reflect.DeepEqual(got, stats))
+		require.Len(t, queue, 3)
+		require.Equal(t, []bk.Booking{tournament, league, normal}, []bk.Booking{queue[0].Booking, queue[1].Booking, queue[2].Booking})
 	})
 
-	t.Run("repo error", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("queue annotations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetBookingCountPerGameInPeriod(testDeps.ctx, start, end).Return(nil, errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithSessions(1, []time.Duration{20 * time.Hour}))
 
-		got, err := testDeps.service.GetBookingCountPerGameInPeriod(testDeps.ctx, start, end)
-		require.Error(t, err)
-		require.Equal(t, 0, len(got))
+		pending := bk.Booking{ID: "1", UserID: "aliceID", Username: "alice", Game: "Kill Team", Status: "pending", DateTime: slot, Players: []string{"bob"}}
+		accepted := bk.Booking{ID: "4", Username: "bob", Game: "Necromunda", Status: "accepted", DateTime: slot.Add(time.Hour)}
+		refused := bk.Booking{ID: "5", Username: "carol", Game: "Blood Bowl", Status: "refused", DateTime: slot}
+		later := bk.Booking{ID: "6", UserID: "aliceID", Username: "alice", Game: "Warhammer", Status: "pending", DateTime: slot.Add(72 * time.Hour)}
+		history := bk.OrganizerHistory{UserID: "aliceID", Username: "alice", Bookings: 4, NoShows: 1, Cancellations: 2}
+
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{pending, accepted, refused, later}, nil).Times(1)
+		repo.EXPECT().GetOrganizerHistories(gomock.Any(), []string{"aliceID"}, gomock.Any()).Return([]bk.OrganizerHistory{history}, nil).Times(1)
+
+		queue, err := svc.GetPendingQueue(context.Background())
+
+		require.Nil(t, err)
+		require.Len(t, queue, 2)
+		require.Equal(t, []bk.Conflict{{ID: "4", Game: "Necromunda", Status: "accepted", DateTime: accepted.DateTime, Players: []string{"bob"}}}, queue[0].Conflicts)
+		require.True(t, queue[0].TablesFull)
+		require.Equal(t, history, queue[0].Requester)
+		require.Equal(t, []bk.Conflict{}, queue[1].Conflicts)
+		require.False(t, queue[1].TablesFull)
+		require.Equal(t, history, queue[1].Requester)
 	})
-}
 
-func TestSendBookingReminders(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		ctrl, testDeps := newTestDeps(t)
+	t.Run("the last table goes to the higher priority", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		now := time.Now()
-		bookings := []bk.Booking{
-			{
-				ID:              "123",
-				Game:            "test1",
-				UserID:          "owner-id",
-				Username:        "user1",
-				ReminderEnabled: true,
-				DateTime:        now.Add(2 * time.Hour),
-				Players:         []string{"user1", "player2"},
-			},
-		}
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		svc := bk.NewService(repo, dc_mocks.NewMockDiscordClient(ctrl), "test-channel-d", bk.WithSessions(2, []time.Duration{20 * time.Hour}))
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
 
-		member2 := discord.Member{
-			User: discord.User{
-				ID:       "player2-id",
-				Username: "player2",
-			},
-		}
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(normal, nil).Times(1)
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{normal, taken, league}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		testDeps.repo.EXPECT().GetActiveBookings(testDeps.ctx).Return(bookings, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(testDeps.ctx, member2.User.Username, 1).Return([]discord.Member{member2}, nil).Times(1)
-		testDeps.client.EXPECT().GetDMChannel(testDeps.ctx, "owner-id").Return("dm-owner", nil).Times(1)
-		testDeps.client.EXPECT().GetDMChannel(testDeps.ctx, "player2-id").Return("dm-player2", nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(testDeps.ctx, "dm-owner", discord.Message{
-			Content: "Rappel pour la réservation de test1 aujourd'hui at " + bookings[0].DateTime.Format("15:04") + " !",
-		}).Return(nil).Times(1)
-		testDeps.client.EXPECT().SendMessage(testDeps.ctx, "dm-player2", discord.Message{
-			Content: "Rappel pour la réservation de test1 aujourd'hui at " + bookings[0].DateTime.Format("15:04") + " !",
-		}).Return(nil).Times(1)
+		err := svc.AcceptBooking(context.Background(), "1")
 
-		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+		require.ErrorIs(t, err, bk.ErrPriorityConflict)
+	})
 
-		require.NoError(t, err)
+	t.Run("tables left for every contender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := bk_mocks.NewMockBookingRepository(ctrl)
+		client := dc_mocks.NewMockDiscordClient(ctrl)
+		svc := bk.NewService(repo, client, "test-channel-d", bk.WithSessions(3, []time.Duration{20 * time.Hour}))
+		repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(tx bk.BookingRepository) error) error {
+			return fn(repo)
+		}).AnyTimes()
+
+		repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(normal, nil).Times(1)
+		repo.EXPECT().GetActiveBookings(gomock.Any()).Return([]bk.Booking{normal, taken, league}, nil).Times(1)
+		repo.EXPECT().SetBookingStatus(gomock.Any(), "1", "accepted").Return(nil).Times(1)
+		client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+		require.Nil(t, svc.AcceptBooking(context.Background(), "1"))
 	})
 
-	t.Run("skip bookings without due reminders", func(t *testing.T) {
+	t.Run("set by an admin", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		now := time.Now()
-		bookings := []bk.Booking{
-			{
-				ID:              "123",
-				Game:            "test1",
-				UserID:          "owner-id",
-				Username:        "user1",
-				ReminderEnabled: false,
-				DateTime:        now,
-				Players:         []string{"user1", "player2"},
-			},
-			{
-				ID:              "456",
-				Game:            "test2",
-				UserID:          "owner-id-2",
-				Username:        "user2",
-				ReminderEnabled: true,
-				DateTime:        now.Add(24 * time.Hour),
-				Players:         []string{"user2", "player3"},
-			},
-		}
-
-		testDeps.repo.EXPECT().GetActiveBookings(testDeps.ctx).Return(bookings, nil).Times(1)
-		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		promoted := normal
+		promoted.Priority = bk.PriorityTournament
+		testDeps.repo.EXPECT().SetPriority(gomock.Any(), "1", bk.PriorityTournament).Return(nil).Times(1)
+		testDeps.repo.EXPECT().GetBookingByID(gomock.Any(), "1").Return(promoted, nil).Times(1)
 
-		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+		booking, err := testDeps.service.SetPriority(testDeps.ctx, "1", bk.PriorityTournament)
 
-		require.NoError(t, err)
+		require.Nil(t, err)
+		require.Equal(t, bk.PriorityTournament, booking.Priority)
+		require.Equal(t, []recordedAction{{action: "booking.priority", targetID: "1", payload: map[string]any{"priority": "tournament"}}}, testDeps.audit.actions)
 	})
 
-	t.Run("repo error", func(t *testing.T) {
+	t.Run("invalid priority", func(t *testing.T) {
 		ctrl, testDeps := newTestDeps(t)
 		defer ctrl.Finish()
 
-		testDeps.repo.EXPECT().GetActiveBookings(testDeps.ctx).Return(nil, errors.New("repo error")).Times(1)
-		testDeps.client.EXPECT().SearchMembers(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().GetDMChannel(gomock.Any(), gomock.Any()).Times(0)
-		testDeps.client.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		testDeps.repo.EXPECT().SetPriority(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
-		err := testDeps.service.SendBookingReminders(testDeps.ctx)
+		_, err := testDeps.service.SetPriority(testDeps.ctx, "1", "urgent")
 
-		require.Error(t, err)
-		require.ErrorContains(t, err, "failed to get active bookings")
+		require.ErrorIs(t, err, bk.ErrInvalidPriority)
 	})
 }
+
+func TestResolvePlayers(t *testing.T) {
+	ctrl, testDeps := newTestDeps(t)
+	defer ctrl.Finish()
+
+	alice := discord.Member{User: discord.User{ID: "1", Username: "alice", GlobalName: "Alice", Avatar: "abc"}, Nick: "Ali"}
+	bob := discord.Member{User: discord.User{ID: "2", Username: "bob"}}
+
+	testDeps.client.EXPECT().SearchMembers(gomock.Any(), "alice", 1).Return([]discord.Member{alice}, nil).Times(1)
+	testDeps.client.EXPECT().SearchMembers(gomock.Any(), "bob", 1).Return([]discord.Member{bob}, nil).Times(1)
+	testDeps.client.EXPECT().SearchMembers(gomock.Any(), "guest", 1).Return(nil, nil).Times(2)
+
+	expected := []bk.Player{
+		{ID: "1", Username: "alice", DisplayName: "Ali", AvatarURL: "https://cdn.discordapp.com/avatars/1/abc.png"},
+		{ID: "2", Username: "bob", DisplayName: "bob"},
+		{Username: "guest", DisplayName: "guest"},
+	}
+
+	require.Equal(t, expected, testDeps.service.ResolvePlayers(testDeps.ctx, []string{"alice", "bob", "guest"}))
+	// found members are cached
+	require.Equal(t, expected, testDeps.service.ResolvePlayers(testDeps.ctx, []string{"alice", "bob", "guest"}))
+}
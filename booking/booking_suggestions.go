@@ -0,0 +1,206 @@
+package booking
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// TableDuration is how long a booking holds its table, as long as it is kept
+// among the active bookings.
+const TableDuration = 3 * time.Hour
+
+const (
+	favoriteGamesLimit = 3
+	suggestionPeriod   = 7 * 24 * time.Hour
+	// freeSlotsLimit bounds the slots proposed around a date
+	freeSlotsLimit = 5
+	// maxGameDuration is the longest game a slot is looked for
+	maxGameDuration = 12 * time.Hour
+)
+
+// BookedSlot is the start of a booking holding a table, Mine telling whether
+// the user the slots were queried for plays it.
+type BookedSlot struct {
+	DateTime time.Time
+	Mine     bool
+}
+
+// FreeSlot is a session with tables left. OpenBookings counts the bookings
+// of the requested game looking for players then, which could be joined
+// instead.
+type FreeSlot struct {
+	DateTime     time.Time `json:"dateTime"`
+	FreeTables   int       `json:"freeTables"`
+	OpenBookings int       `json:"openBookings,omitempty"`
+}
+
+// SlotRequest is what a user wants to book: a game lasting Duration, a table
+// slot by default, as close as possible to Around, now by default.
+type SlotRequest struct {
+	Game     string
+	Duration time.Duration
+	Around   time.Time
+}
+
+// Suggestions proposes the sessions of the coming week with a free table,
+// the user not playing then, for the games the user books the most.
+type Suggestions struct {
+	FavoriteGames []GameBookingCount `json:"favoriteGames"`
+	Slots         []FreeSlot         `json:"slots"`
+}
+
+// WithSessions sets the tables of the club and the times of day, as the
+// duration since midnight, games start at. Without sessions no slot is
+// suggested.
+func WithSessions(tables int, times []time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.tables = tables
+		s.sessionTimes = times
+	}
+}
+
+// SuggestSlots proposes to user the free slots of the coming week for their
+// favorite games.
+func (s *Service) SuggestSlots(ctx context.Context, user discord.DiscordUser) (Suggestions, error) {
+	username := strings.ToLower(user.Username)
+	favorites, err := s.repo.GetFavoriteGames(ctx, user.ID, username, favoriteGamesLimit)
+
+	if err != nil {
+		return Suggestions{}, err
+	}
+
+	suggestions := Suggestions{FavoriteGames: favorites, Slots: []FreeSlot{}}
+
+	if len(favorites) == 0 || len(s.sessionTimes) == 0 {
+		return suggestions, nil
+	}
+
+	now := time.Now()
+	// the bookings started before now may still hold their table
+	booked, err := s.repo.GetBookedSlots(ctx, now.Add(-TableDuration), now.Add(suggestionPeriod+TableDuration), username)
+
+	if err != nil {
+		return Suggestions{}, fmt.Errorf("failed to get booked slots: %w", err)
+	}
+
+	for _, session := range s.sessions(now) {
+		taken, mine := 0, false
+
+		for _, slot := range booked {
+			if slot.DateTime.After(session.Add(-TableDuration)) && slot.DateTime.Before(session.Add(TableDuration)) {
+				taken++
+				mine = mine || slot.Mine
+			}
+		}
+
+		if !mine && taken < s.tables {
+			suggestions.Slots = append(suggestions.Slots, FreeSlot{DateTime: session, FreeTables: s.tables - taken})
+		}
+	}
+
+	return suggestions, nil
+}
+
+// FindFreeSlots proposes the sessions nearest to the requested date where a
+// table is free for the whole game and the user plays no other game, the
+// nearest first. The sessions are the club's opening times, none are
+// proposed without them.
+func (s *Service) FindFreeSlots(ctx context.Context, request SlotRequest, user discord.DiscordUser) ([]FreeSlot, error) {
+	slots := []FreeSlot{}
+
+	if request.Duration == 0 {
+		request.Duration = TableDuration
+	}
+
+	if request.Duration < 0 || request.Duration > maxGameDuration {
+		return nil, fmt.Errorf("%w: a game lasts at most %v", ErrInvalidDuration, maxGameDuration)
+	}
+
+	if len(s.sessionTimes) == 0 {
+		return slots, nil
+	}
+
+	now := time.Now()
+	around := request.Around
+
+	if around.Before(now) {
+		around = now
+	}
+
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	username := strings.ToLower(user.Username)
+
+	from := around.Add(-suggestionPeriod)
+
+	if from.Before(now) {
+		from = now
+	}
+
+	for _, session := range s.sessionsBetween(from, around.Add(suggestionPeriod)) {
+		end := session.Add(request.Duration)
+		taken, open, mine := 0, 0, false
+
+		for _, booking := range bookings {
+			held := booking.Status == "pending" || booking.Status == "accepted"
+
+			// the bookings hold their table for TableDuration
+			if !held || !booking.DateTime.Before(end) || !booking.DateTime.Add(TableDuration).After(session) {
+				continue
+			}
+
+			taken++
+			mine = mine || booking.UserID == user.ID || slices.Contains(booking.Players, username)
+
+			if booking.LookingForPlayers && len(request.Game) != 0 && strings.EqualFold(booking.Game, request.Game) {
+				open++
+			}
+		}
+
+		if !mine && taken < s.tables {
+			slots = append(slots, FreeSlot{DateTime: session, FreeTables: s.tables - taken, OpenBookings: open})
+		}
+	}
+
+	distance := func(slot FreeSlot) time.Duration { return max(slot.DateTime.Sub(around), around.Sub(slot.DateTime)) }
+
+	slices.SortStableFunc(slots, func(a, b FreeSlot) int { return cmp.Compare(distance(a), distance(b)) })
+
+	return slots[:min(len(slots), freeSlotsLimit)], nil
+}
+
+// sessions lists the starts of the sessions within the suggestion period
+// after now, in the guild's time zone.
+func (s *Service) sessions(now time.Time) []time.Time {
+	return s.sessionsBetween(now, now.Add(suggestionPeriod))
+}
+
+// sessionsBetween lists the starts of the sessions after from until to, in
+// the guild's time zone.
+func (s *Service) sessionsBetween(from, to time.Time) []time.Time {
+	sessions := []time.Time{}
+	first := from.In(s.location)
+	days := int(to.Sub(from).Hours()/24) + 1
+
+	for day := 0; day <= days; day++ {
+		for _, t := range s.sessionTimes {
+			start := time.Date(first.Year(), first.Month(), first.Day()+day, int(t.Hours()), int(t.Minutes())%60, 0, 0, s.location)
+
+			if start.After(from) && !start.After(to) {
+				sessions = append(sessions, start)
+			}
+		}
+	}
+
+	return sessions
+}
@@ -0,0 +1,180 @@
+package booking
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// normalizePlayers trims and lowercases the usernames, Discord usernames
+// being lowercase, and drops the blank and duplicated ones.
+func normalizePlayers(players []string) []string {
+	if len(players) == 0 {
+		return players
+	}
+
+	normalized := make([]string, 0, len(players))
+	seen := make(map[string]bool, len(players))
+
+	for _, player := range players {
+		player = strings.ToLower(strings.TrimSpace(player))
+
+		if len(player) == 0 || seen[player] {
+			continue
+		}
+
+		seen[player] = true
+		normalized = append(normalized, player)
+	}
+
+	return normalized
+}
+
+const maxTags = 10
+
+const maxCoOrganizers = 3
+
+// NormalizeTags trims and lowercases the tags, replacing their spaces by
+// dashes, and drops the blank and duplicated ones.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+
+		if len(tag) == 0 || slices.Contains(normalized, tag) {
+			continue
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// validate normalizes the booking and checks it against the service limits,
+// and the points and custom fields allowed for its game.
+func (s *Service) validate(ctx context.Context, booking *Booking) error {
+	booking.Players = normalizePlayers(booking.Players)
+
+	if s.maxPlayers > 0 && len(booking.Players) > s.maxPlayers {
+		return fmt.Errorf("%w: %d players, at most %d", ErrTooManyPlayers, len(booking.Players), s.maxPlayers)
+	}
+
+	booking.CoOrganizers = normalizePlayers(booking.CoOrganizers)
+
+	if len(booking.CoOrganizers) > maxCoOrganizers {
+		return fmt.Errorf("%w: at most %d co-organizers", ErrInvalidCoOrganizers, maxCoOrganizers)
+	}
+
+	if booking.Points < 0 {
+		return fmt.Errorf("%w: points cannot be negative", ErrInvalidPoints)
+	}
+
+	booking.Tags = NormalizeTags(booking.Tags)
+
+	if len(booking.Tags) > maxTags {
+		return fmt.Errorf("%w: at most %d tags", ErrInvalidTags, maxTags)
+	}
+
+	// the admins may restrict the tags to a curated list
+	if len(s.allowedTags) > 0 {
+		for _, tag := range booking.Tags {
+			if !slices.Contains(s.allowedTags, tag) {
+				return fmt.Errorf("%w: '%v' is not one of %v", ErrInvalidTags, tag, strings.Join(s.allowedTags, ", "))
+			}
+		}
+	}
+
+	if s.points != nil {
+		valid, reason, err := s.points.CheckPoints(ctx, booking.Game, booking.Points)
+
+		if err != nil {
+			return fmt.Errorf("failed to check points: %w", err)
+		}
+
+		if !valid {
+			return fmt.Errorf("%w: %v", ErrInvalidPoints, reason)
+		}
+	}
+
+	if s.fields != nil {
+		valid, reason, err := s.fields.CheckFields(ctx, booking.Game, booking.CustomFields)
+
+		if err != nil {
+			return fmt.Errorf("failed to check custom fields: %w", err)
+		}
+
+		if !valid {
+			return fmt.Errorf("%w: %v", ErrInvalidCustomFields, reason)
+		}
+	}
+
+	return nil
+}
+
+// formatCustomFields lists the custom fields of a booking by name, one per
+// line.
+func formatCustomFields(fields map[string]any) string {
+	lines := make([]string, 0, len(fields))
+
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		value := fmt.Sprint(fields[name])
+
+		if checked, ok := fields[name].(bool); ok {
+			value = "non"
+
+			if checked {
+				value = "oui"
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%v : %v", name, value))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// checkVenue checks the venue of the booking is open and has a table left at
+// its time, any venue being accepted without a VenueChecker.
+func (s *Service) checkVenue(ctx context.Context, booking Booking) error {
+	reason, err := s.venueUnavailable(ctx, booking)
+
+	if err != nil {
+		return err
+	}
+
+	if len(reason) != 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidVenue, reason)
+	}
+
+	return nil
+}
+
+// venueUnavailable returns why the venue of the booking cannot hold it at its
+// time, empty when it can. An unknown venue fails with ErrInvalidVenue.
+func (s *Service) venueUnavailable(ctx context.Context, booking Booking) (string, error) {
+	if len(booking.VenueID) == 0 || s.venues == nil {
+		return "", nil
+	}
+
+	valid, reason, err := s.venues.CheckVenue(ctx, booking.VenueID, booking.DateTime, booking.ID)
+
+	if errors.Is(err, ErrInvalidVenue) {
+		return "", err
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to check venue: %w", err)
+	}
+
+	if !valid {
+		return cmp.Or(reason, "the venue is unavailable at that time"), nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,34 @@
+package booking
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// RemoteTag marks the bookings played online, such as on Tabletop
+// Simulator, which get a voice channel while they are accepted.
+const RemoteTag = "remote"
+
+// IsRemote reports whether the booking is played online.
+func (b Booking) IsRemote() bool {
+	return slices.Contains(b.Tags, RemoteTag)
+}
+
+// WantsVoiceChannel reports whether the booking should have a voice channel
+// at now: remote, accepted and not over.
+func (b Booking) WantsVoiceChannel(now time.Time) bool {
+	return b.IsRemote() && b.Status == "accepted" && b.DeletedAt == nil && now.Before(b.DateTime.Add(TableDuration))
+}
+
+// RecordVoiceChannel records the voice channel of the booking, empty once
+// it is deleted.
+func (s *Service) RecordVoiceChannel(ctx context.Context, id, channelID string) {
+	if err := s.repo.SetVoiceChannel(ctx, id, channelID); err != nil {
+		slog.Error("failed to record booking voice channel", "bookingId", id, "channelId", channelID, "err", err)
+		return
+	}
+
+	s.invalidate()
+}
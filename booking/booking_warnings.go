@@ -0,0 +1,86 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// WarningKind is what a booking created despite a conflict conflicts with.
+type WarningKind string
+
+const (
+	// WarningOverlap is a held booking of some of the same members at the
+	// same time
+	WarningOverlap WarningKind = "overlap"
+	// WarningTablesFull is the tables of the club all held at that time
+	WarningTablesFull WarningKind = "tables-full"
+	// WarningVenue is the venue closed or without a table left at that time
+	WarningVenue WarningKind = "venue"
+)
+
+// Warning is a conflict of a booking created with WithConflictWarnings,
+// left to the admins to settle. BookingID is the conflicting booking, if any.
+type Warning struct {
+	Kind      WarningKind `json:"kind"`
+	Message   string      `json:"message"`
+	BookingID string      `json:"bookingId,omitempty"`
+}
+
+// WithConflictWarnings creates the bookings conflicting with other bookings
+// or the opening hours of their venue, and returns the conflicts as warnings
+// from CreateBookingWithWarnings instead of rejecting them.
+func WithConflictWarnings() ServiceOption {
+	return func(s *Service) {
+		s.warnConflicts = true
+	}
+}
+
+// conflictWarnings returns the conflicts of a new booking: its venue closed
+// or full, the held bookings sharing some of its members and the tables all
+// held at its time.
+func (s *Service) conflictWarnings(ctx context.Context, booking Booking) ([]Warning, error) {
+	warnings := []Warning{}
+
+	reason, err := s.venueUnavailable(ctx, booking)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reason) != 0 {
+		warnings = append(warnings, Warning{Kind: WarningVenue, Message: reason})
+	}
+
+	bookings, err := s.repo.GetActiveBookings(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	taken := 0
+
+	for _, other := range bookings {
+		held := other.Status == "pending" || other.Status == "accepted"
+
+		if !held || other.ID == booking.ID || !other.DateTime.After(booking.DateTime.Add(-TableDuration)) || !other.DateTime.Before(booking.DateTime.Add(TableDuration)) {
+			continue
+		}
+
+		taken++
+
+		if shared := sharedMembers(booking, other); len(shared) != 0 {
+			warnings = append(warnings, Warning{
+				Kind:      WarningOverlap,
+				Message:   fmt.Sprintf("%v already booked %v at that time", strings.Join(shared, ", "), other.Game),
+				BookingID: other.ID,
+			})
+		}
+	}
+
+	if s.tables != 0 && taken >= s.tables {
+		warnings = append(warnings, Warning{Kind: WarningTablesFull, Message: fmt.Sprintf("the %d tables are taken at that time", s.tables)})
+	}
+
+	return warnings, nil
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//      mockgen . BookingRepository
+//	mockgen . BookingRepository
 //
 
 // Package mock_booking is a generated GoMock package.
@@ -14,7 +14,9 @@ import (
 	reflect "reflect"
 	time "time"
 
+	audit "github.com/hanksha/tbz-booking-system-backend/audit"
 	booking "github.com/hanksha/tbz-booking-system-backend/booking"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +44,64 @@ func (m *MockBookingRepository) EXPECT() *MockBookingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClearSnooze mocks base method.
+func (m *MockBookingRepository) ClearSnooze(ctx context.Context, id, username string, snoozedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSnooze", ctx, id, username, snoozedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearSnooze indicates an expected call of ClearSnooze.
+func (mr *MockBookingRepositoryMockRecorder) ClearSnooze(ctx, id, username, snoozedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSnooze", reflect.TypeOf((*MockBookingRepository)(nil).ClearSnooze), ctx, id, username, snoozedUntil)
+}
+
+// CountBookings mocks base method.
+func (m *MockBookingRepository) CountBookings(ctx context.Context, user *discord.DiscordUser, filter booking.Filter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBookings", ctx, user, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBookings indicates an expected call of CountBookings.
+func (mr *MockBookingRepositoryMockRecorder) CountBookings(ctx, user, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBookings", reflect.TypeOf((*MockBookingRepository)(nil).CountBookings), ctx, user, filter)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockBookingRepository) DeleteAttachment(ctx context.Context, bookingID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, bookingID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockBookingRepositoryMockRecorder) DeleteAttachment(ctx, bookingID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockBookingRepository)(nil).DeleteAttachment), ctx, bookingID, id)
+}
+
+// DeleteBooking mocks base method.
+func (m *MockBookingRepository) DeleteBooking(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooking", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBooking indicates an expected call of DeleteBooking.
+func (mr *MockBookingRepositoryMockRecorder) DeleteBooking(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooking", reflect.TypeOf((*MockBookingRepository)(nil).DeleteBooking), ctx, id)
+}
+
 // GetActiveBookings mocks base method.
 func (m *MockBookingRepository) GetActiveBookings(ctx context.Context) ([]booking.Booking, error) {
 	m.ctrl.T.Helper()
@@ -54,8 +114,67 @@ func (m *MockBookingRepository) GetActiveBookings(ctx context.Context) ([]bookin
 // GetActiveBookings indicates an expected call of GetActiveBookings.
 func (mr *MockBookingRepositoryMockRecorder) GetActiveBookings(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookings", reflect.TypeOf((*MockBookingRepository)(nil).GetActiveBookings),
-		ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookings", reflect.TypeOf((*MockBookingRepository)(nil).GetActiveBookings), ctx)
+}
+
+// GetActiveBookingsIncludingDeleted mocks base method.
+func (m *MockBookingRepository) GetActiveBookingsIncludingDeleted(ctx context.Context) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBookingsIncludingDeleted", ctx)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBookingsIncludingDeleted indicates an expected call of GetActiveBookingsIncludingDeleted.
+func (mr *MockBookingRepositoryMockRecorder) GetActiveBookingsIncludingDeleted(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookingsIncludingDeleted", reflect.TypeOf((*MockBookingRepository)(nil).GetActiveBookingsIncludingDeleted), ctx)
+}
+
+// GetAttachment mocks base method.
+func (m *MockBookingRepository) GetAttachment(ctx context.Context, bookingID, id string) (booking.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, bookingID, id)
+	ret0, _ := ret[0].(booking.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockBookingRepositoryMockRecorder) GetAttachment(ctx, bookingID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockBookingRepository)(nil).GetAttachment), ctx, bookingID, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockBookingRepository) GetAttachments(ctx context.Context, bookingID string) ([]booking.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, bookingID)
+	ret0, _ := ret[0].([]booking.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockBookingRepositoryMockRecorder) GetAttachments(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockBookingRepository)(nil).GetAttachments), ctx, bookingID)
+}
+
+// GetBookedSlots mocks base method.
+func (m *MockBookingRepository) GetBookedSlots(ctx context.Context, from, to time.Time, username string) ([]booking.BookedSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookedSlots", ctx, from, to, username)
+	ret0, _ := ret[0].([]booking.BookedSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookedSlots indicates an expected call of GetBookedSlots.
+func (mr *MockBookingRepositoryMockRecorder) GetBookedSlots(ctx, from, to, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookedSlots", reflect.TypeOf((*MockBookingRepository)(nil).GetBookedSlots), ctx, from, to, username)
 }
 
 // GetBookingByID mocks base method.
@@ -103,6 +222,36 @@ func (mr *MockBookingRepositoryMockRecorder) GetBookingCountPerGameInPeriod(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerGameInPeriod", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingCountPerGameInPeriod), ctx, start, end)
 }
 
+// GetBookingCountPerPair mocks base method.
+func (m *MockBookingRepository) GetBookingCountPerPair(ctx context.Context, limit int) ([]booking.PairBookingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingCountPerPair", ctx, limit)
+	ret0, _ := ret[0].([]booking.PairBookingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingCountPerPair indicates an expected call of GetBookingCountPerPair.
+func (mr *MockBookingRepositoryMockRecorder) GetBookingCountPerPair(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerPair", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingCountPerPair), ctx, limit)
+}
+
+// GetBookingCountPerTag mocks base method.
+func (m *MockBookingRepository) GetBookingCountPerTag(ctx context.Context) ([]booking.TagBookingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingCountPerTag", ctx)
+	ret0, _ := ret[0].([]booking.TagBookingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingCountPerTag indicates an expected call of GetBookingCountPerTag.
+func (mr *MockBookingRepositoryMockRecorder) GetBookingCountPerTag(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerTag", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingCountPerTag), ctx)
+}
+
 // GetBookingCountPerWeekDay mocks base method.
 func (m *MockBookingRepository) GetBookingCountPerWeekDay(ctx context.Context) ([]booking.WeekDayBookingCount, error) {
 	m.ctrl.T.Helper()
@@ -118,19 +267,228 @@ func (mr *MockBookingRepositoryMockRecorder) GetBookingCountPerWeekDay(ctx any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingCountPerWeekDay", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingCountPerWeekDay), ctx)
 }
 
+// GetBookingHistory mocks base method.
+func (m *MockBookingRepository) GetBookingHistory(ctx context.Context, user *discord.DiscordUser, filter booking.Filter, after *booking.Cursor, limit int) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingHistory", ctx, user, filter, after, limit)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingHistory indicates an expected call of GetBookingHistory.
+func (mr *MockBookingRepositoryMockRecorder) GetBookingHistory(ctx, user, filter, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingHistory", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingHistory), ctx, user, filter, after, limit)
+}
+
+// GetBookingsByIDs mocks base method.
+func (m *MockBookingRepository) GetBookingsByIDs(ctx context.Context, user *discord.DiscordUser, ids []string) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByIDs", ctx, user, ids)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByIDs indicates an expected call of GetBookingsByIDs.
+func (mr *MockBookingRepositoryMockRecorder) GetBookingsByIDs(ctx, user, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByIDs", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingsByIDs), ctx, user, ids)
+}
+
 // GetBookingsPerUsername mocks base method.
-func (m *MockBookingRepository) GetBookingsPerUsername(ctx context.Context, username string) ([]booking.Booking, error) {
+func (m *MockBookingRepository) GetBookingsPerUsername(ctx context.Context, user *discord.DiscordUser, username string) ([]booking.Booking, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBookingsPerUsername", ctx, username)
+	ret := m.ctrl.Call(m, "GetBookingsPerUsername", ctx, user, username)
 	ret0, _ := ret[0].([]booking.Booking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBookingsPerUsername indicates an expected call of GetBookingsPerUsername.
-func (mr *MockBookingRepositoryMockRecorder) GetBookingsPerUsername(ctx, username any) *gomock.Call {
+func (mr *MockBookingRepositoryMockRecorder) GetBookingsPerUsername(ctx, user, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsPerUsername", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingsPerUsername), ctx, user, username)
+}
+
+// GetDecisions mocks base method.
+func (m *MockBookingRepository) GetDecisions(ctx context.Context, start, end time.Time) ([]booking.Decision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecisions", ctx, start, end)
+	ret0, _ := ret[0].([]booking.Decision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecisions indicates an expected call of GetDecisions.
+func (mr *MockBookingRepositoryMockRecorder) GetDecisions(ctx, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecisions", reflect.TypeOf((*MockBookingRepository)(nil).GetDecisions), ctx, start, end)
+}
+
+// GetEquipmentAvailability mocks base method.
+func (m *MockBookingRepository) GetEquipmentAvailability(ctx context.Context, ids []string, at time.Time, bookingID string) ([]booking.EquipmentAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEquipmentAvailability", ctx, ids, at, bookingID)
+	ret0, _ := ret[0].([]booking.EquipmentAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEquipmentAvailability indicates an expected call of GetEquipmentAvailability.
+func (mr *MockBookingRepositoryMockRecorder) GetEquipmentAvailability(ctx, ids, at, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquipmentAvailability", reflect.TypeOf((*MockBookingRepository)(nil).GetEquipmentAvailability), ctx, ids, at, bookingID)
+}
+
+// GetFavoriteGames mocks base method.
+func (m *MockBookingRepository) GetFavoriteGames(ctx context.Context, userID, username string, limit int) ([]booking.GameBookingCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavoriteGames", ctx, userID, username, limit)
+	ret0, _ := ret[0].([]booking.GameBookingCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavoriteGames indicates an expected call of GetFavoriteGames.
+func (mr *MockBookingRepositoryMockRecorder) GetFavoriteGames(ctx, userID, username, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavoriteGames", reflect.TypeOf((*MockBookingRepository)(nil).GetFavoriteGames), ctx, userID, username, limit)
+}
+
+// GetMatchingBookings mocks base method.
+func (m *MockBookingRepository) GetMatchingBookings(ctx context.Context, user *discord.DiscordUser, filter booking.Filter) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchingBookings", ctx, user, filter)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchingBookings indicates an expected call of GetMatchingBookings.
+func (mr *MockBookingRepositoryMockRecorder) GetMatchingBookings(ctx, user, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchingBookings", reflect.TypeOf((*MockBookingRepository)(nil).GetMatchingBookings), ctx, user, filter)
+}
+
+// GetNoShowCounts mocks base method.
+func (m *MockBookingRepository) GetNoShowCounts(ctx context.Context, start, end time.Time) ([]booking.NoShowCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoShowCounts", ctx, start, end)
+	ret0, _ := ret[0].([]booking.NoShowCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoShowCounts indicates an expected call of GetNoShowCounts.
+func (mr *MockBookingRepositoryMockRecorder) GetNoShowCounts(ctx, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoShowCounts", reflect.TypeOf((*MockBookingRepository)(nil).GetNoShowCounts), ctx, start, end)
+}
+
+// GetOrganizerHistories mocks base method.
+func (m *MockBookingRepository) GetOrganizerHistories(ctx context.Context, userIDs []string, before time.Time) ([]booking.OrganizerHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizerHistories", ctx, userIDs, before)
+	ret0, _ := ret[0].([]booking.OrganizerHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizerHistories indicates an expected call of GetOrganizerHistories.
+func (mr *MockBookingRepositoryMockRecorder) GetOrganizerHistories(ctx, userIDs, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsPerUsername", reflect.TypeOf((*MockBookingRepository)(nil).GetBookingsPerUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizerHistories", reflect.TypeOf((*MockBookingRepository)(nil).GetOrganizerHistories), ctx, userIDs, before)
+}
+
+// GetPayment mocks base method.
+func (m *MockBookingRepository) GetPayment(ctx context.Context, bookingID string) (booking.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, bookingID)
+	ret0, _ := ret[0].(booking.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockBookingRepositoryMockRecorder) GetPayment(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockBookingRepository)(nil).GetPayment), ctx, bookingID)
+}
+
+// GetResult mocks base method.
+func (m *MockBookingRepository) GetResult(ctx context.Context, bookingID string) (booking.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResult", ctx, bookingID)
+	ret0, _ := ret[0].(booking.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResult indicates an expected call of GetResult.
+func (mr *MockBookingRepositoryMockRecorder) GetResult(ctx, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockBookingRepository)(nil).GetResult), ctx, bookingID)
+}
+
+// GetVisibleBookings mocks base method.
+func (m *MockBookingRepository) GetVisibleBookings(ctx context.Context, user *discord.DiscordUser) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibleBookings", ctx, user)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibleBookings indicates an expected call of GetVisibleBookings.
+func (mr *MockBookingRepositoryMockRecorder) GetVisibleBookings(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibleBookings", reflect.TypeOf((*MockBookingRepository)(nil).GetVisibleBookings), ctx, user)
+}
+
+// GetVoiceChannelBookings mocks base method.
+func (m *MockBookingRepository) GetVoiceChannelBookings(ctx context.Context) ([]booking.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoiceChannelBookings", ctx)
+	ret0, _ := ret[0].([]booking.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoiceChannelBookings indicates an expected call of GetVoiceChannelBookings.
+func (mr *MockBookingRepositoryMockRecorder) GetVoiceChannelBookings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoiceChannelBookings", reflect.TypeOf((*MockBookingRepository)(nil).GetVoiceChannelBookings), ctx)
+}
+
+// InsertAttachment mocks base method.
+func (m *MockBookingRepository) InsertAttachment(ctx context.Context, attachment booking.Attachment) (booking.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAttachment", ctx, attachment)
+	ret0, _ := ret[0].(booking.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAttachment indicates an expected call of InsertAttachment.
+func (mr *MockBookingRepositoryMockRecorder) InsertAttachment(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAttachment", reflect.TypeOf((*MockBookingRepository)(nil).InsertAttachment), ctx, attachment)
+}
+
+// InsertAuditEntry mocks base method.
+func (m *MockBookingRepository) InsertAuditEntry(ctx context.Context, entry audit.Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditEntry indicates an expected call of InsertAuditEntry.
+func (mr *MockBookingRepositoryMockRecorder) InsertAuditEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEntry", reflect.TypeOf((*MockBookingRepository)(nil).InsertAuditEntry), ctx, entry)
 }
 
 // InsertBooking mocks base method.
@@ -162,6 +520,81 @@ func (mr *MockBookingRepositoryMockRecorder) InsertManyBookings(ctx, bookings an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertManyBookings", reflect.TypeOf((*MockBookingRepository)(nil).InsertManyBookings), ctx, bookings)
 }
 
+// InsertPayment mocks base method.
+func (m *MockBookingRepository) InsertPayment(ctx context.Context, payment booking.Payment) (booking.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPayment", ctx, payment)
+	ret0, _ := ret[0].(booking.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPayment indicates an expected call of InsertPayment.
+func (mr *MockBookingRepositoryMockRecorder) InsertPayment(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPayment", reflect.TypeOf((*MockBookingRepository)(nil).InsertPayment), ctx, payment)
+}
+
+// MarkBookingEscalated mocks base method.
+func (m *MockBookingRepository) MarkBookingEscalated(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBookingEscalated", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBookingEscalated indicates an expected call of MarkBookingEscalated.
+func (mr *MockBookingRepositoryMockRecorder) MarkBookingEscalated(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBookingEscalated", reflect.TypeOf((*MockBookingRepository)(nil).MarkBookingEscalated), ctx, id)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockBookingRepository) MarkReminderSent(ctx context.Context, id string, leadTime int, dateTime time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", ctx, id, leadTime, dateTime)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockBookingRepositoryMockRecorder) MarkReminderSent(ctx, id, leadTime, dateTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockBookingRepository)(nil).MarkReminderSent), ctx, id, leadTime, dateTime)
+}
+
+// PurgeDeletedBookings mocks base method.
+func (m *MockBookingRepository) PurgeDeletedBookings(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBookings", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBookings indicates an expected call of PurgeDeletedBookings.
+func (mr *MockBookingRepositoryMockRecorder) PurgeDeletedBookings(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBookings", reflect.TypeOf((*MockBookingRepository)(nil).PurgeDeletedBookings), ctx, before)
+}
+
+// SearchBookings mocks base method.
+func (m *MockBookingRepository) SearchBookings(ctx context.Context, user *discord.DiscordUser, terms []string, limit int) ([]booking.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBookings", ctx, user, terms, limit)
+	ret0, _ := ret[0].([]booking.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBookings indicates an expected call of SearchBookings.
+func (mr *MockBookingRepositoryMockRecorder) SearchBookings(ctx, user, terms, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBookings", reflect.TypeOf((*MockBookingRepository)(nil).SearchBookings), ctx, user, terms, limit)
+}
+
 // SetBookingStatus mocks base method.
 func (m *MockBookingRepository) SetBookingStatus(ctx context.Context, id, status string) error {
 	m.ctrl.T.Helper()
@@ -176,6 +609,146 @@ func (mr *MockBookingRepositoryMockRecorder) SetBookingStatus(ctx, id, status an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookingStatus", reflect.TypeOf((*MockBookingRepository)(nil).SetBookingStatus), ctx, id, status)
 }
 
+// SetCalendarSync mocks base method.
+func (m *MockBookingRepository) SetCalendarSync(ctx context.Context, id string, sync booking.CalendarSync) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarSync", ctx, id, sync)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarSync indicates an expected call of SetCalendarSync.
+func (mr *MockBookingRepositoryMockRecorder) SetCalendarSync(ctx, id, sync any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarSync", reflect.TypeOf((*MockBookingRepository)(nil).SetCalendarSync), ctx, id, sync)
+}
+
+// SetCheckedIn mocks base method.
+func (m *MockBookingRepository) SetCheckedIn(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCheckedIn", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCheckedIn indicates an expected call of SetCheckedIn.
+func (mr *MockBookingRepositoryMockRecorder) SetCheckedIn(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckedIn", reflect.TypeOf((*MockBookingRepository)(nil).SetCheckedIn), ctx, id, at)
+}
+
+// SetEquipment mocks base method.
+func (m *MockBookingRepository) SetEquipment(ctx context.Context, bookingID string, reservations []booking.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEquipment", ctx, bookingID, reservations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEquipment indicates an expected call of SetEquipment.
+func (mr *MockBookingRepositoryMockRecorder) SetEquipment(ctx, bookingID, reservations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEquipment", reflect.TypeOf((*MockBookingRepository)(nil).SetEquipment), ctx, bookingID, reservations)
+}
+
+// SetNotificationError mocks base method.
+func (m *MockBookingRepository) SetNotificationError(ctx context.Context, id, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationError", ctx, id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationError indicates an expected call of SetNotificationError.
+func (mr *MockBookingRepositoryMockRecorder) SetNotificationError(ctx, id, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationError", reflect.TypeOf((*MockBookingRepository)(nil).SetNotificationError), ctx, id, message)
+}
+
+// SetPaymentStatus mocks base method.
+func (m *MockBookingRepository) SetPaymentStatus(ctx context.Context, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentStatus indicates an expected call of SetPaymentStatus.
+func (mr *MockBookingRepositoryMockRecorder) SetPaymentStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentStatus", reflect.TypeOf((*MockBookingRepository)(nil).SetPaymentStatus), ctx, id, status)
+}
+
+// SetPriority mocks base method.
+func (m *MockBookingRepository) SetPriority(ctx context.Context, id, priority string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPriority", ctx, id, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPriority indicates an expected call of SetPriority.
+func (mr *MockBookingRepositoryMockRecorder) SetPriority(ctx, id, priority any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockBookingRepository)(nil).SetPriority), ctx, id, priority)
+}
+
+// SetReminderOptOut mocks base method.
+func (m *MockBookingRepository) SetReminderOptOut(ctx context.Context, id, username string, optOut bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminderOptOut", ctx, id, username, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminderOptOut indicates an expected call of SetReminderOptOut.
+func (mr *MockBookingRepositoryMockRecorder) SetReminderOptOut(ctx, id, username, optOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminderOptOut", reflect.TypeOf((*MockBookingRepository)(nil).SetReminderOptOut), ctx, id, username, optOut)
+}
+
+// SetReminderResponse mocks base method.
+func (m *MockBookingRepository) SetReminderResponse(ctx context.Context, id string, response booking.ReminderResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminderResponse", ctx, id, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminderResponse indicates an expected call of SetReminderResponse.
+func (mr *MockBookingRepositoryMockRecorder) SetReminderResponse(ctx, id, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminderResponse", reflect.TypeOf((*MockBookingRepository)(nil).SetReminderResponse), ctx, id, response)
+}
+
+// SetReminders mocks base method.
+func (m *MockBookingRepository) SetReminders(ctx context.Context, id string, reminders booking.Reminders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminders", ctx, id, reminders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminders indicates an expected call of SetReminders.
+func (mr *MockBookingRepositoryMockRecorder) SetReminders(ctx, id, reminders any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminders", reflect.TypeOf((*MockBookingRepository)(nil).SetReminders), ctx, id, reminders)
+}
+
+// SetVoiceChannel mocks base method.
+func (m *MockBookingRepository) SetVoiceChannel(ctx context.Context, id, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVoiceChannel", ctx, id, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVoiceChannel indicates an expected call of SetVoiceChannel.
+func (mr *MockBookingRepositoryMockRecorder) SetVoiceChannel(ctx, id, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVoiceChannel", reflect.TypeOf((*MockBookingRepository)(nil).SetVoiceChannel), ctx, id, channelID)
+}
+
 // UpdateBooking mocks base method.
 func (m *MockBookingRepository) UpdateBooking(ctx context.Context, arg1 booking.Booking) error {
 	m.ctrl.T.Helper()
@@ -189,3 +762,32 @@ func (mr *MockBookingRepositoryMockRecorder) UpdateBooking(ctx, arg1 any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBooking", reflect.TypeOf((*MockBookingRepository)(nil).UpdateBooking), ctx, arg1)
 }
+
+// UpsertResult mocks base method.
+func (m *MockBookingRepository) UpsertResult(ctx context.Context, result booking.Result) (booking.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertResult", ctx, result)
+	ret0, _ := ret[0].(booking.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertResult indicates an expected call of UpsertResult.
+func (mr *MockBookingRepositoryMockRecorder) UpsertResult(ctx, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertResult", reflect.TypeOf((*MockBookingRepository)(nil).UpsertResult), ctx, result)
+}
+
+// WithTx mocks base method.
+func (m *MockBookingRepository) WithTx(ctx context.Context, fn func(booking.BookingRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockBookingRepositoryMockRecorder) WithTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockBookingRepository)(nil).WithTx), ctx, fn)
+}
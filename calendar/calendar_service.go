@@ -0,0 +1,179 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+type CalendarClient interface {
+	PutEvent(ctx context.Context, calendarID string, event Event) error
+	DeleteEvent(ctx context.Context, calendarID, eventID string) error
+}
+
+type BookingSource interface {
+	GetBookingByID(ctx context.Context, id string) (bk.Booking, error)
+}
+
+// SyncHandler records the outcome of the sync of a booking.
+type SyncHandler func(ctx context.Context, bookingID string, sync bk.CalendarSync)
+
+// Service keeps an event of the Google Calendar for each accepted booking,
+// removed once the booking is no longer accepted.
+type Service struct {
+	client     CalendarClient
+	bookings   BookingSource
+	calendarID string
+	// bookingURL is the frontend page of the bookings, their id appended
+	bookingURL string
+	location   *time.Location
+	onSync     SyncHandler
+	logger     *slog.Logger
+	wg         sync.WaitGroup
+	// mu serializes the syncs, each one mirroring the booking as it is then
+	mu sync.Mutex
+}
+
+type ServiceOption func(*Service)
+
+// WithSyncHandler sets what records the outcome of the syncs, the bookings
+// showing it.
+func WithSyncHandler(handler SyncHandler) ServiceOption {
+	return func(s *Service) {
+		s.onSync = handler
+	}
+}
+
+func NewService(client CalendarClient, bookings BookingSource, calendarID, bookingURL string, loc *time.Location, opts ...ServiceOption) *Service {
+	s := &Service{
+		client:     client,
+		bookings:   bookings,
+		calendarID: calendarID,
+		bookingURL: strings.TrimSuffix(bookingURL, "/"),
+		location:   loc,
+		onSync:     func(ctx context.Context, bookingID string, sync bk.CalendarSync) {},
+		logger:     slog.Default().With("component", "calendar"),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// EventID is the id of the event of the booking, made of the base32hex
+// characters Google Calendar accepts.
+func EventID(bookingID string) string {
+	id := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'v') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(bookingID))
+
+	return "booking" + id
+}
+
+// Publish implements booking.EventPublisher. Syncs happen in the background
+// so booking operations never wait on Google.
+func (s *Service) Publish(ctx context.Context, event bk.Event) {
+	// pending bookings have no event yet
+	if event.Type == bk.EventBookingCreated && event.Booking.Status != "accepted" {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.Sync(ctx, event.Booking.ID)
+	}()
+}
+
+// Sync mirrors the booking as it is now: its event is put while it is
+// accepted and deleted otherwise.
+func (s *Service) Sync(ctx context.Context, bookingID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eventID := EventID(bookingID)
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+		// deleted bookings are found no more
+		s.remove(ctx, bookingID, eventID)
+	case err != nil:
+		s.logger.Error("failed to get booking to sync", "bookingId", bookingID, "err", err)
+	case booking.Status == "accepted":
+		if err := s.client.PutEvent(ctx, s.calendarID, s.event(booking, eventID)); err != nil {
+			s.onSync(ctx, bookingID, bk.CalendarSync{EventID: booking.CalendarEventID, Status: bk.CalendarFailed, Error: err.Error()})
+			return
+		}
+
+		s.onSync(ctx, bookingID, bk.CalendarSync{EventID: eventID, Status: bk.CalendarSynced})
+	case len(booking.CalendarEventID) != 0:
+		s.remove(ctx, bookingID, booking.CalendarEventID)
+	}
+}
+
+// Wait blocks until all in-flight syncs are done.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown waits for the in-flight syncs until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for calendar syncs: %w", ctx.Err())
+	}
+}
+
+func (s *Service) remove(ctx context.Context, bookingID, eventID string) {
+	if err := s.client.DeleteEvent(ctx, s.calendarID, eventID); err != nil {
+		s.onSync(ctx, bookingID, bk.CalendarSync{EventID: eventID, Status: bk.CalendarFailed, Error: err.Error()})
+		return
+	}
+
+	s.onSync(ctx, bookingID, bk.CalendarSync{Status: bk.CalendarRemoved})
+}
+
+func (s *Service) event(booking bk.Booking, eventID string) Event {
+	url := s.bookingURL + "/" + booking.ID
+	description := []string{}
+
+	if len(booking.Players) != 0 {
+		description = append(description, "Joueurs : "+strings.Join(booking.Players, ", "))
+	}
+
+	if len(booking.Description) != 0 {
+		description = append(description, booking.Description)
+	}
+
+	return Event{
+		ID:          eventID,
+		Summary:     fmt.Sprintf("%s (%s)", booking.Game, booking.Username),
+		Description: strings.Join(append(description, url), "\n\n"),
+		Start:       EventTime{DateTime: booking.DateTime.In(s.location), TimeZone: s.location.String()},
+		End:         EventTime{DateTime: booking.DateTime.Add(bk.TableDuration).In(s.location), TimeZone: s.location.String()},
+		Status:      "confirmed",
+		Source:      EventSource{Title: "Réservation", URL: url},
+	}
+}
@@ -0,0 +1,120 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/calendar"
+	calendar_mocks "github.com/hanksha/tbz-booking-system-backend/calendar/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type bookingSource map[string]bk.Booking
+
+func (s bookingSource) GetBookingByID(ctx context.Context, id string) (bk.Booking, error) {
+	booking, ok := s[id]
+
+	if !ok {
+		return bk.Booking{}, bk.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+type syncRecorder map[string]bk.CalendarSync
+
+func (r syncRecorder) record(ctx context.Context, bookingID string, sync bk.CalendarSync) {
+	r[bookingID] = sync
+}
+
+func TestEventID(t *testing.T) {
+	require.Equal(t, "booking12", calendar.EventID("12"))
+	require.Equal(t, "booking0f8fad5bd9cb469fa16570867728950e", calendar.EventID("0F8FAD5B-D9CB-469F-A165-70867728950E"))
+}
+
+func TestSync(t *testing.T) {
+	dateTime := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, bookings bookingSource) (*calendar.Service, *calendar_mocks.MockCalendarClient, syncRecorder) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		client := calendar_mocks.NewMockCalendarClient(ctrl)
+		recorder := syncRecorder{}
+		svc := calendar.NewService(client, bookings, "club", "https://tbz.example/bookings", time.UTC, calendar.WithSyncHandler(recorder.record))
+
+		return svc, client, recorder
+	}
+
+	t.Run("puts the event of an accepted booking", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Game: "Kill Team", Username: "alice", Players: []string{"bob"}, Status: "accepted", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().PutEvent(gomock.Any(), "club", calendar.Event{
+			ID:          "booking12",
+			Summary:     "Kill Team (alice)",
+			Description: "Joueurs : bob\n\nhttps://tbz.example/bookings/12",
+			Start:       calendar.EventTime{DateTime: dateTime, TimeZone: "UTC"},
+			End:         calendar.EventTime{DateTime: dateTime.Add(bk.TableDuration), TimeZone: "UTC"},
+			Status:      "confirmed",
+			Source:      calendar.EventSource{Title: "Réservation", URL: "https://tbz.example/bookings/12"},
+		}).Return(nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted, Booking: booking})
+		svc.Wait()
+
+		require.Equal(t, bk.CalendarSync{EventID: "booking12", Status: bk.CalendarSynced}, recorder["12"])
+	})
+
+	t.Run("deletes the event of a canceled booking", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Status: "canceled", CalendarEventID: "booking12", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().DeleteEvent(gomock.Any(), "club", "booking12").Return(nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingCanceled, Booking: booking})
+		svc.Wait()
+
+		require.Equal(t, bk.CalendarSync{Status: bk.CalendarRemoved}, recorder["12"])
+	})
+
+	t.Run("deletes the event of a deleted booking", func(t *testing.T) {
+		svc, client, recorder := setup(t, bookingSource{})
+
+		client.EXPECT().DeleteEvent(gomock.Any(), "club", "booking12").Return(nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingDeleted, Booking: bk.Booking{ID: "12"}})
+		svc.Wait()
+
+		require.Equal(t, bk.CalendarRemoved, recorder["12"].Status)
+	})
+
+	t.Run("leaves the pending bookings alone", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Status: "pending", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().PutEvent(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		client.EXPECT().DeleteEvent(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated, Booking: booking})
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingModified, Booking: booking})
+		svc.Wait()
+
+		require.Empty(t, recorder)
+	})
+
+	t.Run("records the failures", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Status: "accepted", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().PutEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("403 Forbidden")).Times(1)
+
+		svc.Sync(context.Background(), "12")
+
+		require.Equal(t, bk.CalendarSync{Status: bk.CalendarFailed, Error: "403 Forbidden"}, recorder["12"])
+	})
+}
@@ -0,0 +1,307 @@
+// Package calendar mirrors the accepted bookings as events of a Google
+// Calendar, through its HTTP API and a service account.
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// scope lets the service account manage the events of the calendars shared
+// with it, and nothing else.
+const scope = "https://www.googleapis.com/auth/calendar.events"
+
+// ServiceAccount is the key of a Google service account, as downloaded from
+// the Google Cloud console.
+type ServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// LoadServiceAccount reads the JSON key of a service account.
+func LoadServiceAccount(path string) (ServiceAccount, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return ServiceAccount{}, fmt.Errorf("failed to read service account key: %w", err)
+	}
+
+	var account ServiceAccount
+
+	if err := json.Unmarshal(content, &account); err != nil {
+		return ServiceAccount{}, fmt.Errorf("failed to decode service account key: %w", err)
+	}
+
+	if len(account.ClientEmail) == 0 || len(account.PrivateKey) == 0 {
+		return ServiceAccount{}, errors.New("service account key without client_email or private_key")
+	}
+
+	if len(account.TokenURI) == 0 {
+		account.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	return account, nil
+}
+
+type GoogleConfig struct {
+	APIURL  string
+	Account ServiceAccount
+}
+
+// GoogleClient manages the events of Google calendars as a service account,
+// whose access token is cached until it expires.
+type GoogleClient struct {
+	cfg    GoogleConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func NewGoogleClient(cfg GoogleConfig, client *http.Client) *GoogleClient {
+	cfg.APIURL = strings.TrimSuffix(cfg.APIURL, "/")
+
+	return &GoogleClient{cfg: cfg, client: client, now: time.Now}
+}
+
+// Event is a Google Calendar event.
+type Event struct {
+	ID          string      `json:"id"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description,omitempty"`
+	Start       EventTime   `json:"start"`
+	End         EventTime   `json:"end"`
+	Status      string      `json:"status"`
+	Source      EventSource `json:"source"`
+}
+
+type EventTime struct {
+	DateTime time.Time `json:"dateTime"`
+	TimeZone string    `json:"timeZone,omitempty"`
+}
+
+type EventSource struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// PutEvent creates the event with its id, or updates it when it exists,
+// deleted events included which are restored.
+func (c *GoogleClient) PutEvent(ctx context.Context, calendarID string, event Event) error {
+	events := c.cfg.APIURL + "/calendars/" + url.PathEscape(calendarID) + "/events"
+
+	status, err := c.do(ctx, http.MethodPost, events, event)
+
+	if status == http.StatusConflict {
+		status, err = c.do(ctx, http.MethodPut, events+"/"+url.PathEscape(event.ID), event)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to put event '%v': %w", event.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteEvent deletes the event, an event already gone being no error.
+func (c *GoogleClient) DeleteEvent(ctx context.Context, calendarID, eventID string) error {
+	status, err := c.do(ctx, http.MethodDelete, c.cfg.APIURL+"/calendars/"+url.PathEscape(calendarID)+"/events/"+url.PathEscape(eventID), nil)
+
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete event '%v': %w", eventID, err)
+	}
+
+	return nil
+}
+
+// do sends the request with the access token of the service account, and
+// returns the status of the response with an error unless it succeeded.
+func (c *GoogleClient) do(ctx context.Context, method, target string, body any) (int, error) {
+	token, err := c.accessToken(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return res.StatusCode, responseError(res)
+	}
+
+	return res.StatusCode, nil
+}
+
+// accessToken returns the cached access token, exchanging a signed JWT for
+// a new one shortly before it expires.
+func (c *GoogleClient) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if len(c.token) != 0 && now.Before(c.expires.Add(-time.Minute)) {
+		return c.token, nil
+	}
+
+	assertion, err := c.assertion(now)
+
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Account.TokenURI, strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get access token: %w", responseError(res))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode access token: %w", err)
+	}
+
+	c.token = token.AccessToken
+	c.expires = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return c.token, nil
+}
+
+// assertion is the JWT of the service account asking for the scope, signed
+// with its private key.
+func (c *GoogleClient) assertion(now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(c.cfg.Account.PrivateKey))
+
+	if block == nil {
+		return "", errors.New("service account private key is not PEM encoded")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to parse service account private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+
+	if !ok {
+		return "", errors.New("service account private key is not an RSA key")
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   c.cfg.Account.ClientEmail,
+		"scope": scope,
+		"aud":   c.cfg.Account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		return "", fmt.Errorf("failed to sign service account assertion: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// responseError reads the message of a Google API error response.
+func responseError(res *http.Response) error {
+	var body struct {
+		Error any `json:"error"`
+	}
+
+	content, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+
+	if json.Unmarshal(content, &body) == nil {
+		switch e := body.Error.(type) {
+		case map[string]any:
+			if message, ok := e["message"].(string); ok {
+				return fmt.Errorf("%v %v", res.StatusCode, message)
+			}
+		case string:
+			return fmt.Errorf("%v %v", res.StatusCode, e)
+		}
+	}
+
+	return fmt.Errorf("%v %v", res.StatusCode, strings.TrimSpace(string(content)))
+}
@@ -0,0 +1,124 @@
+package calendar_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/calendar"
+	"github.com/stretchr/testify/require"
+)
+
+func privateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.Nil(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestLoadServiceAccount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"type":"service_account","client_email":"tbz@project.iam.gserviceaccount.com","private_key":"key"}`), 0o600))
+
+	account, err := calendar.LoadServiceAccount(path)
+
+	require.Nil(t, err)
+	require.Equal(t, calendar.ServiceAccount{
+		ClientEmail: "tbz@project.iam.gserviceaccount.com",
+		PrivateKey:  "key",
+		TokenURI:    "https://oauth2.googleapis.com/token",
+	}, account)
+
+	t.Run("missing key", func(t *testing.T) {
+		require.Nil(t, os.WriteFile(path, []byte(`{"client_email":"tbz@project.iam.gserviceaccount.com"}`), 0o600))
+
+		_, err := calendar.LoadServiceAccount(path)
+
+		require.Error(t, err)
+	})
+}
+
+func TestGoogleClient(t *testing.T) {
+	var requests []string
+	tokens := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokens++
+			require.Nil(t, r.ParseForm())
+			require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+			require.NotEmpty(t, r.PostForm.Get("assertion"))
+			fmt.Fprint(w, `{"access_token":"ya29.token","expires_in":3600}`)
+			return
+		}
+
+		require.Equal(t, "Bearer ya29.token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		switch r.Method {
+		case http.MethodPost:
+			var event calendar.Event
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&event))
+			require.Equal(t, "booking12", event.ID)
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"error":{"code":409,"message":"The requested identifier already exists."}}`)
+		case http.MethodPut:
+			fmt.Fprint(w, `{}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error":{"code":410,"message":"Resource has been deleted"}}`)
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGoogleClient(calendar.GoogleConfig{
+		APIURL: server.URL + "/",
+		Account: calendar.ServiceAccount{
+			ClientEmail: "tbz@project.iam.gserviceaccount.com",
+			PrivateKey:  privateKey(t),
+			TokenURI:    server.URL + "/token",
+		},
+	}, server.Client())
+
+	event := calendar.Event{ID: "booking12", Summary: "Kill Team (alice)", Start: calendar.EventTime{DateTime: time.Now()}, End: calendar.EventTime{DateTime: time.Now()}}
+
+	require.Nil(t, client.PutEvent(context.Background(), "club@group.calendar.google.com", event))
+	require.Nil(t, client.DeleteEvent(context.Background(), "club@group.calendar.google.com", "booking12"))
+
+	require.Equal(t, 1, tokens)
+	require.Equal(t, []string{
+		"POST /calendars/club@group.calendar.google.com/events",
+		"PUT /calendars/club@group.calendar.google.com/events/booking12",
+		"DELETE /calendars/club@group.calendar.google.com/events/booking12",
+	}, requests)
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`)
+		}))
+		defer server.Close()
+
+		client := calendar.NewGoogleClient(calendar.GoogleConfig{
+			APIURL:  server.URL,
+			Account: calendar.ServiceAccount{PrivateKey: privateKey(t), TokenURI: server.URL + "/token"},
+		}, server.Client())
+
+		err := client.PutEvent(context.Background(), "club", event)
+
+		require.ErrorContains(t, err, "invalid_grant")
+	})
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/calendar (interfaces: CalendarClient)
+//
+// Generated by this command:
+//
+//	mockgen . CalendarClient
+//
+
+// Package mock_calendar is a generated GoMock package.
+package mock_calendar
+
+import (
+	context "context"
+	reflect "reflect"
+
+	calendar "github.com/hanksha/tbz-booking-system-backend/calendar"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCalendarClient is a mock of CalendarClient interface.
+type MockCalendarClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarClientMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarClientMockRecorder is the mock recorder for MockCalendarClient.
+type MockCalendarClientMockRecorder struct {
+	mock *MockCalendarClient
+}
+
+// NewMockCalendarClient creates a new mock instance.
+func NewMockCalendarClient(ctrl *gomock.Controller) *MockCalendarClient {
+	mock := &MockCalendarClient{ctrl: ctrl}
+	mock.recorder = &MockCalendarClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarClient) EXPECT() *MockCalendarClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteEvent mocks base method.
+func (m *MockCalendarClient) DeleteEvent(ctx context.Context, calendarID, eventID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", ctx, calendarID, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockCalendarClientMockRecorder) DeleteEvent(ctx, calendarID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockCalendarClient)(nil).DeleteEvent), ctx, calendarID, eventID)
+}
+
+// PutEvent mocks base method.
+func (m *MockCalendarClient) PutEvent(ctx context.Context, calendarID string, event calendar.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutEvent", ctx, calendarID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutEvent indicates an expected call of PutEvent.
+func (mr *MockCalendarClientMockRecorder) PutEvent(ctx, calendarID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEvent", reflect.TypeOf((*MockCalendarClient)(nil).PutEvent), ctx, calendarID, event)
+}
@@ -0,0 +1,57 @@
+package campaign
+
+import (
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/ranking"
+)
+
+// Campaign links the bookings of a narrative campaign or a season.
+type Campaign struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name" binding:"required"`
+	Game        string    `json:"game"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Game is a booking of the campaign, with its result once reported.
+type Game struct {
+	BookingID string         `json:"bookingId"`
+	Game      string         `json:"game"`
+	Organizer string         `json:"organizer"`
+	Status    string         `json:"status"`
+	DateTime  time.Time      `json:"dateTime"`
+	Players   []string       `json:"players"`
+	Reported  bool           `json:"reported"`
+	Winner    string         `json:"winner,omitempty"`
+	Scores    map[string]int `json:"scores,omitempty"`
+	Report    string         `json:"report,omitempty"`
+}
+
+// Detail is a campaign with its games in chronological order, and the
+// standings of its players over the reported results.
+type Detail struct {
+	Campaign
+	Games     []Game            `json:"games"`
+	Standings []ranking.Ranking `json:"standings"`
+}
+
+// Standings ranks the players of the reported games.
+func Standings(games []Game) []ranking.Ranking {
+	matches := make([]ranking.Match, 0, len(games))
+
+	for _, game := range games {
+		if game.Reported {
+			matches = append(matches, ranking.Match{
+				Game:     game.Game,
+				Players:  game.Players,
+				Winner:   game.Winner,
+				Scores:   game.Scores,
+				PlayedAt: game.DateTime,
+			})
+		}
+	}
+
+	return ranking.Compute(matches)
+}
@@ -0,0 +1,11 @@
+package campaign
+
+import "errors"
+
+var ErrCampaignNotFound = errors.New("campaign not found")
+
+var ErrInvalidCampaign = errors.New("invalid campaign")
+
+var ErrBookingNotAttached = errors.New("booking is not part of the campaign")
+
+var ErrNotAllowed = errors.New("not allowed to perform this operation")
@@ -0,0 +1,177 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const campaignColumns = `id::text, name, COALESCE(game, ''), COALESCE(description, ''), "createdAt"`
+
+// visibleTo is the condition on the visibility of the campaign bookings
+// listing them to a user, the same as for the booking listings. Its
+// arguments, from visibleToArgs, are numbered from $1.
+const visibleTo = `(b.visibility = 'public'
+			OR (b.visibility = 'members' AND $1)
+			OR $2
+			OR ($3 <> '' AND b."userId" = $3)
+			OR ($4 <> '' AND ($4 = ANY(b.players) OR $4 = ANY(b."coOrganizers"))))`
+
+func visibleToArgs(user *discord.DiscordUser) []any {
+	if user == nil {
+		return []any{false, false, "", ""}
+	}
+
+	return []any{true, user.Admin, user.ID, strings.ToLower(user.Username)}
+}
+
+func (r *Repository) GetCampaigns(ctx context.Context) ([]Campaign, error) {
+	sql := `
+		SELECT ` + campaignColumns + `
+		FROM "game-table-booking".campaign
+		ORDER BY "createdAt" DESC;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch campaigns: %w", err)
+	}
+
+	campaigns, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Campaign])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning campaign rows: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+func (r *Repository) GetCampaign(ctx context.Context, id string) (Campaign, error) {
+	sql := `
+		SELECT ` + campaignColumns + `
+		FROM "game-table-booking".campaign
+		WHERE id=$1;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, id)
+
+	if err != nil {
+		return Campaign{}, fmt.Errorf("failed to fetch campaign '%v': %w", id, err)
+	}
+
+	campaign, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[Campaign])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Campaign{}, ErrCampaignNotFound
+	}
+
+	if err != nil {
+		return Campaign{}, fmt.Errorf("error scanning campaign row: %w", err)
+	}
+
+	return campaign, nil
+}
+
+func (r *Repository) InsertCampaign(ctx context.Context, campaign Campaign) (Campaign, error) {
+	sql := `
+		INSERT INTO "game-table-booking".campaign(name, game, description)
+		VALUES ($1, $2, $3)
+		RETURNING id::text, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, campaign.Name, campaign.Game, campaign.Description).Scan(&campaign.ID, &campaign.CreatedAt)
+
+	if err != nil {
+		return Campaign{}, fmt.Errorf("failed to insert campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+func (r *Repository) DeleteCampaign(ctx context.Context, id string) error {
+	sql := `DELETE FROM "game-table-booking".campaign WHERE id=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete campaign '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrCampaignNotFound
+	}
+
+	return nil
+}
+
+// GetGames lists the bookings of a campaign the user may see chronologically,
+// with their result when reported.
+func (r *Repository) GetGames(ctx context.Context, campaignID string, user *discord.DiscordUser) ([]Game, error) {
+	sql := `
+		SELECT b.id::text, b.game, b.username, b.status, b."dateTime", COALESCE(b.players, '{}'),
+			r."bookingId" IS NOT NULL, COALESCE(r.winner, ''), COALESCE(r.scores, '{}'), COALESCE(r.report, '')
+		FROM "game-table-booking".campaign_booking cb
+		JOIN "game-table-booking".booking b ON b.id = cb."bookingId"
+		LEFT JOIN "game-table-booking".booking_result r ON r."bookingId" = b.id
+		WHERE cb."campaignId"=$5 AND b."deletedAt" IS NULL AND ` + visibleTo + `
+		ORDER BY b."dateTime", b.id;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, append(visibleToArgs(user), campaignID)...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch games of campaign '%v': %w", campaignID, err)
+	}
+
+	games, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Game])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning campaign game rows: %w", err)
+	}
+
+	return games, nil
+}
+
+// AttachBooking adds a booking to a campaign, moving it out of its previous
+// campaign.
+func (r *Repository) AttachBooking(ctx context.Context, campaignID, bookingID string) error {
+	sql := `
+		INSERT INTO "game-table-booking".campaign_booking("campaignId", "bookingId")
+		VALUES ($1, $2)
+		ON CONFLICT ("bookingId") DO UPDATE SET "campaignId"=EXCLUDED."campaignId";
+	`
+
+	if _, err := r.conn.Exec(ctx, sql, campaignID, bookingID); err != nil {
+		return fmt.Errorf("failed to attach booking '%v' to campaign '%v': %w", bookingID, campaignID, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DetachBooking(ctx context.Context, campaignID, bookingID string) error {
+	sql := `DELETE FROM "game-table-booking".campaign_booking WHERE "campaignId"=$1 AND "bookingId"=$2;`
+
+	tag, err := r.conn.Exec(ctx, sql, campaignID, bookingID)
+
+	if err != nil {
+		return fmt.Errorf("failed to detach booking '%v' from campaign '%v': %w", bookingID, campaignID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrBookingNotAttached
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type CampaignRepository interface {
+	GetCampaigns(ctx context.Context) ([]Campaign, error)
+	GetCampaign(ctx context.Context, id string) (Campaign, error)
+	InsertCampaign(ctx context.Context, campaign Campaign) (Campaign, error)
+	DeleteCampaign(ctx context.Context, id string) error
+	GetGames(ctx context.Context, campaignID string, user *discord.DiscordUser) ([]Game, error)
+	AttachBooking(ctx context.Context, campaignID, bookingID string) error
+	DetachBooking(ctx context.Context, campaignID, bookingID string) error
+}
+
+// BookingFinder looks up the bookings attached to campaigns, it is the
+// booking service.
+type BookingFinder interface {
+	FindBookingByID(ctx context.Context, id string) (bk.Booking, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo     CampaignRepository
+	bookings BookingFinder
+	audit    AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo CampaignRepository, bookings BookingFinder, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, bookings: bookings}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetCampaigns(ctx context.Context) ([]Campaign, error) {
+	return s.repo.GetCampaigns(ctx)
+}
+
+// GetCampaign returns the campaign with its games and standings, leaving out
+// the private bookings the user may not see.
+func (s *Service) GetCampaign(ctx context.Context, id string, user *discord.DiscordUser) (Detail, error) {
+	campaign, err := s.repo.GetCampaign(ctx, id)
+
+	if err != nil {
+		return Detail{}, err
+	}
+
+	games, err := s.repo.GetGames(ctx, id, user)
+
+	if err != nil {
+		return Detail{}, err
+	}
+
+	return Detail{Campaign: campaign, Games: games, Standings: Standings(games)}, nil
+}
+
+func (s *Service) CreateCampaign(ctx context.Context, campaign Campaign) (Campaign, error) {
+	campaign.Name = strings.TrimSpace(campaign.Name)
+	campaign.Game = strings.TrimSpace(campaign.Game)
+
+	if len(campaign.Name) == 0 {
+		return Campaign{}, fmt.Errorf("%w: name cannot be empty", ErrInvalidCampaign)
+	}
+
+	inserted, err := s.repo.InsertCampaign(ctx, campaign)
+
+	s.record(ctx, err, "campaign.create", inserted.ID, inserted)
+
+	return inserted, err
+}
+
+func (s *Service) DeleteCampaign(ctx context.Context, id string) error {
+	err := s.repo.DeleteCampaign(ctx, id)
+
+	s.record(ctx, err, "campaign.delete", id, nil)
+
+	return err
+}
+
+// AttachBooking adds a booking to the campaign, its organizer and the
+// admins may do it.
+func (s *Service) AttachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error {
+	if err := s.checkBooking(ctx, id, bookingID, user); err != nil {
+		return err
+	}
+
+	err := s.repo.AttachBooking(ctx, id, bookingID)
+
+	s.record(ctx, err, "campaign.attach", id, map[string]any{"bookingId": bookingID})
+
+	return err
+}
+
+func (s *Service) DetachBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error {
+	if err := s.checkBooking(ctx, id, bookingID, user); err != nil {
+		return err
+	}
+
+	err := s.repo.DetachBooking(ctx, id, bookingID)
+
+	s.record(ctx, err, "campaign.detach", id, map[string]any{"bookingId": bookingID})
+
+	return err
+}
+
+func (s *Service) checkBooking(ctx context.Context, id, bookingID string, user discord.DiscordUser) error {
+	if _, err := s.repo.GetCampaign(ctx, id); err != nil {
+		return err
+	}
+
+	booking, err := s.bookings.FindBookingByID(ctx, bookingID)
+
+	if err != nil {
+		return err
+	}
+
+	if booking.UserID != user.ID && !user.Admin {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, err error, action, campaignID string, payload any) {
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, action, "campaign", campaignID, payload)
+	}
+}
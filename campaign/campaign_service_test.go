@@ -0,0 +1,119 @@
+package campaign_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/campaign"
+	campaign_mocks "github.com/hanksha/tbz-booking-system-backend/campaign/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type bookingFinder map[string]bk.Booking
+
+func (f bookingFinder) FindBookingByID(ctx context.Context, id string) (bk.Booking, error) {
+	booking, ok := f[id]
+
+	if !ok {
+		return bk.Booking{}, bk.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+func TestGetCampaign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := campaign_mocks.NewMockCampaignRepository(ctrl)
+	svc := campaign.NewService(repo, bookingFinder{})
+
+	games := []campaign.Game{
+		{BookingID: "1", Game: "Necromunda", DateTime: time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC), Players: []string{"alice", "bob"}, Reported: true, Winner: "alice"},
+		{BookingID: "2", Game: "Necromunda", DateTime: time.Date(2026, 3, 8, 18, 0, 0, 0, time.UTC), Players: []string{"alice", "bob"}},
+	}
+	repo.EXPECT().GetCampaign(gomock.Any(), "3").Return(campaign.Campaign{ID: "3", Name: "Underhive"}, nil).Times(1)
+	repo.EXPECT().GetGames(gomock.Any(), "3", nil).Return(games, nil).Times(1)
+
+	detail, err := svc.GetCampaign(context.Background(), "3", nil)
+
+	require.Nil(t, err)
+	require.Equal(t, games, detail.Games)
+	require.Len(t, detail.Standings, 2)
+	require.Equal(t, "alice", detail.Standings[0].Player)
+	require.Equal(t, 1, detail.Standings[0].Wins)
+	require.Equal(t, 1, detail.Standings[1].Games)
+}
+
+func TestGetCampaignPrivateGames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := campaign_mocks.NewMockCampaignRepository(ctrl)
+	svc := campaign.NewService(repo, bookingFinder{})
+
+	member := &discord.DiscordUser{ID: "4", Username: "carol"}
+	public := campaign.Game{BookingID: "1", Game: "Necromunda", Players: []string{"alice", "bob"}, Reported: true, Winner: "alice"}
+
+	// the private game of alice and bob is filtered out by the query for carol
+	repo.EXPECT().GetCampaign(gomock.Any(), "3").Return(campaign.Campaign{ID: "3", Name: "Underhive"}, nil).Times(1)
+	repo.EXPECT().GetGames(gomock.Any(), "3", member).Return([]campaign.Game{public}, nil).Times(1)
+
+	detail, err := svc.GetCampaign(context.Background(), "3", member)
+
+	require.Nil(t, err)
+	require.Equal(t, []campaign.Game{public}, detail.Games)
+	require.Equal(t, 1, detail.Standings[0].Games)
+}
+
+func TestAttachBooking(t *testing.T) {
+	bookings := bookingFinder{"1": {ID: "1", UserID: "owner"}}
+
+	t.Run("organizer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := campaign_mocks.NewMockCampaignRepository(ctrl)
+		svc := campaign.NewService(repo, bookings)
+
+		repo.EXPECT().GetCampaign(gomock.Any(), "3").Return(campaign.Campaign{ID: "3"}, nil).Times(1)
+		repo.EXPECT().AttachBooking(gomock.Any(), "3", "1").Return(nil).Times(1)
+
+		err := svc.AttachBooking(context.Background(), "3", "1", discord.DiscordUser{ID: "owner"})
+
+		require.Nil(t, err)
+	})
+
+	t.Run("someone else", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := campaign_mocks.NewMockCampaignRepository(ctrl)
+		svc := campaign.NewService(repo, bookings)
+
+		repo.EXPECT().GetCampaign(gomock.Any(), "3").Return(campaign.Campaign{ID: "3"}, nil).Times(1)
+		repo.EXPECT().AttachBooking(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := svc.AttachBooking(context.Background(), "3", "1", discord.DiscordUser{ID: "other"})
+
+		require.ErrorIs(t, err, campaign.ErrNotAllowed)
+	})
+
+	t.Run("unknown booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := campaign_mocks.NewMockCampaignRepository(ctrl)
+		svc := campaign.NewService(repo, bookings)
+
+		repo.EXPECT().GetCampaign(gomock.Any(), "3").Return(campaign.Campaign{ID: "3"}, nil).Times(1)
+
+		err := svc.AttachBooking(context.Background(), "3", "9", discord.DiscordUser{ID: "owner", Admin: true})
+
+		require.ErrorIs(t, err, bk.ErrBookingNotFound)
+	})
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/campaign (interfaces: CampaignRepository)
+//
+// Generated by this command:
+//
+//	mockgen . CampaignRepository
+//
+
+// Package mock_campaign is a generated GoMock package.
+package mock_campaign
+
+import (
+	context "context"
+	reflect "reflect"
+
+	campaign "github.com/hanksha/tbz-booking-system-backend/campaign"
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCampaignRepository is a mock of CampaignRepository interface.
+type MockCampaignRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCampaignRepositoryMockRecorder is the mock recorder for MockCampaignRepository.
+type MockCampaignRepositoryMockRecorder struct {
+	mock *MockCampaignRepository
+}
+
+// NewMockCampaignRepository creates a new mock instance.
+func NewMockCampaignRepository(ctrl *gomock.Controller) *MockCampaignRepository {
+	mock := &MockCampaignRepository{ctrl: ctrl}
+	mock.recorder = &MockCampaignRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaignRepository) EXPECT() *MockCampaignRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AttachBooking mocks base method.
+func (m *MockCampaignRepository) AttachBooking(ctx context.Context, campaignID, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachBooking", ctx, campaignID, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachBooking indicates an expected call of AttachBooking.
+func (mr *MockCampaignRepositoryMockRecorder) AttachBooking(ctx, campaignID, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachBooking", reflect.TypeOf((*MockCampaignRepository)(nil).AttachBooking), ctx, campaignID, bookingID)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockCampaignRepository) DeleteCampaign(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockCampaignRepositoryMockRecorder) DeleteCampaign(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaignRepository)(nil).DeleteCampaign), ctx, id)
+}
+
+// DetachBooking mocks base method.
+func (m *MockCampaignRepository) DetachBooking(ctx context.Context, campaignID, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachBooking", ctx, campaignID, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachBooking indicates an expected call of DetachBooking.
+func (mr *MockCampaignRepositoryMockRecorder) DetachBooking(ctx, campaignID, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachBooking", reflect.TypeOf((*MockCampaignRepository)(nil).DetachBooking), ctx, campaignID, bookingID)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaignRepository) GetCampaign(ctx context.Context, id string) (campaign.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, id)
+	ret0, _ := ret[0].(campaign.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignRepositoryMockRecorder) GetCampaign(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaignRepository)(nil).GetCampaign), ctx, id)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockCampaignRepository) GetCampaigns(ctx context.Context) ([]campaign.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx)
+	ret0, _ := ret[0].([]campaign.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockCampaignRepositoryMockRecorder) GetCampaigns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockCampaignRepository)(nil).GetCampaigns), ctx)
+}
+
+// GetGames mocks base method.
+func (m *MockCampaignRepository) GetGames(ctx context.Context, campaignID string, user *discord.DiscordUser) ([]campaign.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGames", ctx, campaignID, user)
+	ret0, _ := ret[0].([]campaign.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGames indicates an expected call of GetGames.
+func (mr *MockCampaignRepositoryMockRecorder) GetGames(ctx, campaignID, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGames", reflect.TypeOf((*MockCampaignRepository)(nil).GetGames), ctx, campaignID, user)
+}
+
+// InsertCampaign mocks base method.
+func (m *MockCampaignRepository) InsertCampaign(ctx context.Context, arg1 campaign.Campaign) (campaign.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCampaign", ctx, arg1)
+	ret0, _ := ret[0].(campaign.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCampaign indicates an expected call of InsertCampaign.
+func (mr *MockCampaignRepositoryMockRecorder) InsertCampaign(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCampaign", reflect.TypeOf((*MockCampaignRepository)(nil).InsertCampaign), ctx, arg1)
+}
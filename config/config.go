@@ -0,0 +1,839 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/jobs"
+	"github.com/hanksha/tbz-booking-system-backend/storage"
+)
+
+// Config holds every setting of the server, loaded once at startup.
+type Config struct {
+	// AppEnv is the deployment environment, seeding is refused in production.
+	AppEnv string
+	// Host is the interface to listen on, all of them when empty.
+	Host string
+	Port int
+	// Storage is either "postgres" or "memory".
+	Storage          string
+	DatabaseURL      string
+	MigrateOnStartup bool
+	// SeedCount is the number of bookings generated into memory storage at
+	// startup, none when zero.
+	SeedCount      int
+	AllowedOrigins []string
+	// FrontendURL is the base URL of the web app, linked from Discord
+	// messages.
+	FrontendURL string
+	// CalendarFeedURL is where the personal calendar feeds are served, the
+	// token and .ics appended.
+	CalendarFeedURL string
+	// ActiveBookingsCacheTTL bounds how long the active bookings, polled by
+	// the booking board, are served from memory.
+	ActiveBookingsCacheTTL time.Duration
+	// BookingChangeCutoff is how long before its start a booking can still
+	// be modified or canceled by its players, admins excepted.
+	BookingChangeCutoff time.Duration
+	// MaxPlayers bounds the players of a booking, unbounded when zero.
+	MaxPlayers int
+	// BookingTags are the tags bookings may be given, any tag when empty.
+	BookingTags []string
+	// ConflictWarnings creates the bookings overlapping other bookings or
+	// the closed hours of their venue, with warnings for the admins.
+	ConflictWarnings bool
+	// Tables is the number of games the club hosts at once, SessionTimes
+	// the times of day games start at, both used to suggest free slots.
+	Tables       int
+	SessionTimes []time.Duration
+	// PrimeTime are the weekly slots reserved to the club members and the
+	// guests the admins whitelisted, none when empty.
+	PrimeTime []PrimeTimeSlot
+	// Timezone is the guild's time zone, booking dates are shown in it.
+	Timezone *time.Location
+	// Maintenance starts the API in read-only mode, admins can then lift it.
+	Maintenance        bool
+	MaintenanceMessage string
+
+	Discord       DiscordConfig
+	HTTP          HTTPConfig
+	TLS           TLSConfig
+	Jobs          JobsConfig
+	Notifications NotificationsConfig
+	Telemetry     TelemetryConfig
+	Attachments   AttachmentsConfig
+	CheckIn       CheckInConfig
+	Payments      PaymentsConfig
+	Email         EmailConfig
+	Telegram      TelegramConfig
+	Calendar      CalendarConfig
+}
+
+// DiscordConfig holds the Discord application settings.
+type DiscordConfig struct {
+	// Dev selects discord.DevClient, which accepts any access token.
+	Dev          bool
+	BotToken     string
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	ServerID     string
+	ChannelID    string
+	AdminRoleID  string
+	// TrustedRoleID marks the members whose bookings skip the admins' review,
+	// none are when empty.
+	TrustedRoleID string
+	// OpsChannelID receives panic reports, none are sent when empty.
+	OpsChannelID string
+	// AdminChannelID receives the alerts for admins, ChannelID by default.
+	AdminChannelID string
+	// OpenSeatsChannelID receives the bookings looking for players, none are
+	// announced when empty.
+	OpenSeatsChannelID string
+	// VoiceCategoryID is the category the voice channels of the remote
+	// bookings are created in, none are when empty.
+	VoiceCategoryID string
+	// APIURL is the root of the Discord API, overridden to test against a
+	// fake server.
+	APIURL          string
+	HTTPTimeout     time.Duration
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	KeepAlive       time.Duration
+	// BreakerThreshold consecutive failures stop the calls to Discord for
+	// BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// PublicKey verifies the interactions of Discord with the buttons of the
+	// bot's messages, the reminders have none when it is empty.
+	PublicKey ed25519.PublicKey
+	// Presence connects the bot to the gateway to show the next game on its
+	// status line.
+	Presence bool
+}
+
+// HTTPConfig holds the timeouts of the HTTP server and outgoing calls.
+type HTTPConfig struct {
+	ReadHeaderTimeout time.Duration
+	IdleTimeout       time.Duration
+	WebhookTimeout    time.Duration
+	// TrustedProxies are the IPs or CIDRs of the reverse proxies whose
+	// X-Forwarded-For header is trusted for the client IP.
+	TrustedProxies []string
+	// RequestTimeout bounds the handling of a request, RouteTimeouts
+	// overrides it per "METHOD /route/:param", zero meaning no timeout.
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+	// ShutdownTimeout bounds how long in-flight requests and background
+	// work are drained on SIGTERM.
+	ShutdownTimeout time.Duration
+	// PublicRateLimit is the number of requests per minute a client IP may
+	// make to the unauthenticated routes.
+	PublicRateLimit int
+}
+
+// TLSConfig enables HTTPS, either with certificate files or with
+// certificates obtained from Let's Encrypt for AutocertDomains.
+type TLSConfig struct {
+	CertFile         string
+	KeyFile          string
+	AutocertDomains  []string
+	AutocertCacheDir string
+}
+
+// Enabled reports whether the server listens with TLS.
+func (c TLSConfig) Enabled() bool {
+	return len(c.CertFile) != 0 || len(c.AutocertDomains) != 0
+}
+
+// JobsConfig selects the one-shot job to run instead of the server, or the
+// schedule of the jobs run by the server itself.
+type JobsConfig struct {
+	SendReminders            bool
+	PurgeDeletedBookings     bool
+	DeletedBookingsRetention time.Duration
+	// Scheduled runs the jobs below in the server, replicas take turns
+	// through a Postgres advisory lock.
+	Scheduled bool
+	// Location evaluates the schedules, Timezone by default.
+	Location          *time.Location
+	RemindersSchedule jobs.Schedule
+	// DueRemindersSchedule sends the reminders of the bookings set up with
+	// lead times, it should run more often than the shortest of them
+	DueRemindersSchedule jobs.Schedule
+	PurgeSchedule        jobs.Schedule
+	// PresenceSchedule refreshes the next game shown by the bot.
+	PresenceSchedule jobs.Schedule
+	// VoiceChannelsSchedule deletes the voice channels of the remote
+	// bookings once they are over.
+	VoiceChannelsSchedule jobs.Schedule
+	// Bookings still pending EscalateAfter their creation, or EscalateBefore
+	// their date, are escalated to the admin role.
+	EscalationSchedule jobs.Schedule
+	EscalateAfter      time.Duration
+	EscalateBefore     time.Duration
+	// Bookings still pending AutoAcceptAfter their creation, or booked by
+	// one of AutoAcceptUserIDs, are accepted by the auto-accept job, which
+	// only runs when either is set.
+	AutoAcceptSchedule jobs.Schedule
+	AutoAcceptAfter    time.Duration
+	AutoAcceptUserIDs  []string
+	// LeaderboardSchedule posts the player rankings to the Discord channel
+	LeaderboardSchedule jobs.Schedule
+	// AchievementsSchedule awards the badges of the milestones reached and
+	// announces them on the Discord channel
+	AchievementsSchedule jobs.Schedule
+}
+
+// NotificationsConfig sizes the worker pool sending the Discord
+// notifications, and their retries.
+type NotificationsConfig struct {
+	Workers    int
+	QueueSize  int
+	Attempts   int
+	RetryDelay time.Duration
+}
+
+// TelemetryConfig holds the OpenTelemetry tracing settings.
+type TelemetryConfig struct {
+	Enabled     bool
+	ServiceName string
+	// OTLPEndpoint is the OTLP/HTTP collector URL, the exporter defaults
+	// apply when empty.
+	OTLPEndpoint string
+	// SampleRatio is the fraction of traces recorded, between 0 and 1.
+	SampleRatio float64
+}
+
+// AttachmentsConfig selects where the files attached to the bookings are
+// stored, either "disk" under Dir or "s3" in the S3 bucket.
+type AttachmentsConfig struct {
+	Storage string
+	Dir     string
+	S3      storage.S3Config
+	// MaxSize is the size in bytes of the largest file accepted.
+	MaxSize int64
+}
+
+// CheckInConfig signs the QR codes scanned at the venue kiosk, check-in is
+// disabled without a secret. URL is what the codes hold, the booking id and
+// its token appended.
+type CheckInConfig struct {
+	Secret string
+	URL    string
+}
+
+// PaymentsConfig holds the Stripe account charging the table fee of the
+// non-members, payments are disabled without a secret key. Fee is in the
+// smallest unit of Currency, cents for euros.
+type PaymentsConfig struct {
+	StripeAPIURL        string
+	StripeSecretKey     string
+	StripeWebhookSecret string
+	SuccessURL          string
+	CancelURL           string
+	Fee                 int64
+	Currency            string
+}
+
+// Enabled tells whether the table fees are charged.
+func (c PaymentsConfig) Enabled() bool {
+	return len(c.StripeSecretKey) != 0
+}
+
+// EmailConfig holds the SMTP server sending the booking emails to the
+// members who opted in, emails are disabled without a host.
+type EmailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+}
+
+// Enabled tells whether the booking emails are sent.
+func (c EmailConfig) Enabled() bool {
+	return len(c.SMTPHost) != 0
+}
+
+// TelegramConfig holds the bot messaging the members who opted in on
+// Telegram, messages are disabled without a token.
+type TelegramConfig struct {
+	APIURL   string
+	BotToken string
+}
+
+// Enabled tells whether the booking messages are sent on Telegram.
+func (c TelegramConfig) Enabled() bool {
+	return len(c.BotToken) != 0
+}
+
+// CalendarConfig holds the Google Calendar the accepted bookings are
+// mirrored in, with the key of the service account it is shared with. The
+// sync is disabled without a calendar.
+type CalendarConfig struct {
+	APIURL             string
+	CalendarID         string
+	ServiceAccountFile string
+}
+
+// Enabled tells whether the accepted bookings are mirrored in Google
+// Calendar.
+func (c CalendarConfig) Enabled() bool {
+	return len(c.CalendarID) != 0
+}
+
+// PrimeTimeSlot is a slot of the week, Start and End being durations since
+// midnight in Timezone.
+type PrimeTimeSlot struct {
+	Day   time.Weekday
+	Start time.Duration
+	End   time.Duration
+}
+
+// Error reports every missing or invalid setting at once.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+var defaultAllowedOrigins = []string{
+	"http://localhost:5173",
+	"http://localhost:5174",
+	"https://tbz-booking-frontend.onrender.com",
+	"https://tableraze-montpellier-app.fr",
+}
+
+// Load reads the configuration through getenv, usually os.Getenv, and
+// validates it. The returned error is an *Error listing every problem.
+func Load(getenv func(string) string) (Config, error) {
+	l := loader{getenv: getenv}
+
+	cfg := Config{
+		AppEnv:                 l.string("APP_ENV", "development"),
+		Host:                   l.string("HOST", ""),
+		Port:                   l.int("PORT", 9090, 1, 65535),
+		Storage:                l.oneOf("STORAGE", "postgres", "postgres", "memory"),
+		MigrateOnStartup:       l.bool("MIGRATE_ON_STARTUP", true),
+		SeedCount:              l.int("SEED_COUNT", 0, 0, 100000),
+		AllowedOrigins:         l.list("ALLOWED_ORIGINS", defaultAllowedOrigins),
+		FrontendURL:            l.string("FRONTEND_URL", "https://tableraze-montpellier-app.fr"),
+		ActiveBookingsCacheTTL: l.duration("ACTIVE_BOOKINGS_CACHE_TTL", 5*time.Second),
+		Timezone:               l.location("TIMEZONE", "Europe/Paris"),
+		BookingChangeCutoff:    l.duration("BOOKING_CHANGE_CUTOFF", 0),
+		MaxPlayers:             l.int("MAX_PLAYERS", 0, 0, 1000),
+		BookingTags:            l.list("BOOKING_TAGS", nil),
+		ConflictWarnings:       l.bool("CONFLICT_WARNINGS", false),
+		Tables:                 l.int("TABLES", 6, 1, 1000),
+		SessionTimes:           l.clockTimes("SESSION_TIMES", "14:00,20:00"),
+		PrimeTime:              l.primeTime("PRIME_TIME"),
+		Maintenance:            l.bool("MAINTENANCE_MODE", false),
+		MaintenanceMessage:     l.string("MAINTENANCE_MESSAGE", ""),
+		Discord: DiscordConfig{
+			Dev:              l.oneOf("DISCORD_CLIENT", "discord", "discord", "dev") == "dev",
+			HTTPTimeout:      l.duration("DISCORD_HTTP_TIMEOUT", 10*time.Second),
+			MaxIdleConns:     l.int("DISCORD_MAX_IDLE_CONNS", 10, 1, 1000),
+			IdleConnTimeout:  l.duration("DISCORD_IDLE_CONN_TIMEOUT", 90*time.Second),
+			KeepAlive:        l.duration("DISCORD_KEEP_ALIVE", 30*time.Second),
+			BreakerThreshold: l.int("DISCORD_BREAKER_THRESHOLD", 5, 1, 100),
+			BreakerCooldown:  l.duration("DISCORD_BREAKER_COOLDOWN", 30*time.Second),
+		},
+		HTTP: HTTPConfig{
+			ReadHeaderTimeout: l.duration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+			IdleTimeout:       l.duration("HTTP_IDLE_TIMEOUT", 2*time.Minute),
+			WebhookTimeout:    l.duration("WEBHOOK_TIMEOUT", 10*time.Second),
+			ShutdownTimeout:   l.duration("SHUTDOWN_TIMEOUT", 20*time.Second),
+			RequestTimeout:    l.duration("HTTP_REQUEST_TIMEOUT", 15*time.Second),
+			RouteTimeouts:     l.routeDurations("HTTP_ROUTE_TIMEOUTS"),
+			TrustedProxies:    l.ipRanges("TRUSTED_PROXIES"),
+			PublicRateLimit:   l.int("PUBLIC_RATE_LIMIT", 30, 1, 10000),
+		},
+		Jobs: JobsConfig{
+			SendReminders:            l.bool("SEND_REMINDERS", false),
+			PurgeDeletedBookings:     l.bool("PURGE_DELETED_BOOKINGS", false),
+			DeletedBookingsRetention: time.Duration(l.int("DELETED_BOOKINGS_RETENTION_DAYS", 30, 1, 3650)) * 24 * time.Hour,
+			Scheduled:                l.bool("JOBS_SCHEDULED", false),
+			EscalateAfter:            l.duration("ESCALATE_PENDING_AFTER", 48*time.Hour),
+			EscalateBefore:           l.duration("ESCALATE_PENDING_BEFORE", 24*time.Hour),
+			AutoAcceptAfter:          l.duration("AUTO_ACCEPT_PENDING_AFTER", 0),
+			AutoAcceptUserIDs:        l.list("AUTO_ACCEPT_USER_IDS", nil),
+		},
+		Notifications: NotificationsConfig{
+			Workers:    l.int("NOTIFICATION_WORKERS", 4, 1, 64),
+			QueueSize:  l.int("NOTIFICATION_QUEUE_SIZE", 1000, 1, 100000),
+			Attempts:   l.int("NOTIFICATION_ATTEMPTS", 6, 1, 20),
+			RetryDelay: l.duration("NOTIFICATION_RETRY_DELAY", 5*time.Second),
+		},
+		Telemetry: TelemetryConfig{
+			Enabled:     l.bool("TRACING_ENABLED", false),
+			ServiceName: l.string("OTEL_SERVICE_NAME", "tbz-booking-backend"),
+			SampleRatio: l.float("TRACING_SAMPLE_RATIO", 1, 0, 1),
+		},
+	}
+
+	if len(l.string("OTEL_EXPORTER_OTLP_ENDPOINT", "")) != 0 {
+		cfg.Telemetry.OTLPEndpoint = l.url("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+
+	cfg.Jobs.Location = cfg.Timezone
+
+	if len(l.string("JOBS_TIMEZONE", "")) != 0 {
+		cfg.Jobs.Location = l.location("JOBS_TIMEZONE", "")
+	}
+
+	cfg.Jobs.RemindersSchedule = l.schedule("JOBS_REMINDERS_SCHEDULE", "0 9 * * *", cfg.Jobs.Location)
+	cfg.Jobs.DueRemindersSchedule = l.schedule("JOBS_DUE_REMINDERS_SCHEDULE", "*/5 * * * *", cfg.Jobs.Location)
+	cfg.Jobs.PresenceSchedule = l.schedule("JOBS_PRESENCE_SCHEDULE", "*/5 * * * *", cfg.Jobs.Location)
+	cfg.Jobs.VoiceChannelsSchedule = l.schedule("JOBS_VOICE_CHANNELS_SCHEDULE", "*/15 * * * *", cfg.Jobs.Location)
+	cfg.Jobs.PurgeSchedule = l.schedule("JOBS_PURGE_SCHEDULE", "30 3 * * *", cfg.Jobs.Location)
+	cfg.Jobs.EscalationSchedule = l.schedule("JOBS_ESCALATION_SCHEDULE", "*/15 8-22 * * *", cfg.Jobs.Location)
+	cfg.Jobs.AutoAcceptSchedule = l.schedule("JOBS_AUTO_ACCEPT_SCHEDULE", "*/15 * * * *", cfg.Jobs.Location)
+	cfg.Jobs.LeaderboardSchedule = l.schedule("JOBS_LEADERBOARD_SCHEDULE", "0 18 1 * *", cfg.Jobs.Location)
+	cfg.Jobs.AchievementsSchedule = l.schedule("JOBS_ACHIEVEMENTS_SCHEDULE", "0 10 * * *", cfg.Jobs.Location)
+
+	cfg.TLS = TLSConfig{
+		CertFile:         l.string("TLS_CERT_FILE", ""),
+		KeyFile:          l.string("TLS_KEY_FILE", ""),
+		AutocertDomains:  l.list("TLS_AUTOCERT_DOMAINS", nil),
+		AutocertCacheDir: l.string("TLS_AUTOCERT_CACHE_DIR", "certs"),
+	}
+
+	if (len(cfg.TLS.CertFile) == 0) != (len(cfg.TLS.KeyFile) == 0) {
+		l.invalid("TLS_CERT_FILE", "must be set together with TLS_KEY_FILE")
+	}
+
+	if len(cfg.TLS.CertFile) != 0 && len(cfg.TLS.AutocertDomains) != 0 {
+		l.invalid("TLS_AUTOCERT_DOMAINS", "can't be used with TLS_CERT_FILE")
+	}
+
+	cfg.Attachments = AttachmentsConfig{
+		Storage: l.oneOf("ATTACHMENT_STORAGE", "disk", "disk", "s3"),
+		Dir:     l.string("ATTACHMENT_DIR", "attachments"),
+		MaxSize: int64(l.int("ATTACHMENT_MAX_SIZE_MB", 10, 1, 100)) << 20,
+	}
+
+	if cfg.Attachments.Storage == "s3" {
+		cfg.Attachments.S3 = storage.S3Config{
+			Endpoint:        l.url("S3_ENDPOINT"),
+			Bucket:          l.required("S3_BUCKET"),
+			Region:          l.string("S3_REGION", "us-east-1"),
+			AccessKeyID:     l.required("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: l.required("S3_SECRET_ACCESS_KEY"),
+		}
+	}
+
+	cfg.CalendarFeedURL = strings.TrimSuffix(l.string("CALENDAR_FEED_URL", cfg.FrontendURL+"/api/public/calendar"), "/")
+
+	cfg.CheckIn = CheckInConfig{
+		Secret: l.string("CHECK_IN_SECRET", ""),
+		URL:    strings.TrimSuffix(l.string("CHECK_IN_URL", cfg.FrontendURL+"/check-in"), "/"),
+	}
+
+	if len(l.string("STRIPE_SECRET_KEY", "")) != 0 {
+		cfg.Payments = PaymentsConfig{
+			StripeAPIURL:        "https://api.stripe.com",
+			StripeSecretKey:     l.string("STRIPE_SECRET_KEY", ""),
+			StripeWebhookSecret: l.required("STRIPE_WEBHOOK_SECRET"),
+			SuccessURL:          l.string("PAYMENT_SUCCESS_URL", cfg.FrontendURL+"/payments/success"),
+			CancelURL:           l.string("PAYMENT_CANCEL_URL", cfg.FrontendURL+"/payments/canceled"),
+			Fee:                 int64(l.int("TABLE_FEE_CENTS", 500, 1, 100000)),
+			Currency:            strings.ToLower(l.string("PAYMENTS_CURRENCY", "eur")),
+		}
+
+		if len(l.string("STRIPE_API_URL", "")) != 0 {
+			cfg.Payments.StripeAPIURL = l.url("STRIPE_API_URL")
+		}
+	}
+
+	if len(l.string("SMTP_HOST", "")) != 0 {
+		cfg.Email = EmailConfig{
+			SMTPHost:     l.string("SMTP_HOST", ""),
+			SMTPPort:     l.int("SMTP_PORT", 587, 1, 65535),
+			SMTPUsername: l.string("SMTP_USERNAME", ""),
+			SMTPPassword: l.string("SMTP_PASSWORD", ""),
+			From:         l.required("SMTP_FROM"),
+		}
+	}
+
+	cfg.Telegram = TelegramConfig{
+		APIURL:   "https://api.telegram.org",
+		BotToken: l.string("TELEGRAM_BOT_TOKEN", ""),
+	}
+
+	if len(l.string("TELEGRAM_API_URL", "")) != 0 {
+		cfg.Telegram.APIURL = l.url("TELEGRAM_API_URL")
+	}
+
+	if len(l.string("GOOGLE_CALENDAR_ID", "")) != 0 {
+		cfg.Calendar = CalendarConfig{
+			APIURL:             "https://www.googleapis.com/calendar/v3",
+			CalendarID:         l.string("GOOGLE_CALENDAR_ID", ""),
+			ServiceAccountFile: l.required("GOOGLE_SERVICE_ACCOUNT_FILE"),
+		}
+
+		if len(l.string("GOOGLE_CALENDAR_API_URL", "")) != 0 {
+			cfg.Calendar.APIURL = l.url("GOOGLE_CALENDAR_API_URL")
+		}
+	}
+
+	if cfg.Storage == "postgres" {
+		cfg.DatabaseURL = l.required("DATABASE_URL")
+	}
+
+	if cfg.Discord.Dev {
+		// the development client needs no credentials, any role ID will do
+		cfg.Discord.AdminRoleID = l.string("DISCORD_ADMIN_ROLE_ID", "dev-admin")
+		cfg.Discord.TrustedRoleID = l.string("DISCORD_TRUSTED_ROLE_ID", "")
+		cfg.Discord.ChannelID = l.string("DISCORD_CHANNEL_ID", "")
+		cfg.Discord.AdminChannelID = l.string("DISCORD_ADMIN_CHANNEL_ID", cfg.Discord.ChannelID)
+		cfg.Discord.OpenSeatsChannelID = l.string("DISCORD_OPEN_SEATS_CHANNEL_ID", "")
+	} else {
+		cfg.Discord.APIURL = "https://discord.com/api/v10"
+
+		if len(l.string("DISCORD_API_URL", "")) != 0 {
+			cfg.Discord.APIURL = l.url("DISCORD_API_URL")
+		}
+
+		cfg.Discord.BotToken = l.required("DISCORD_BOT_TOKEN")
+		cfg.Discord.ClientSecret = l.required("DISCORD_CLIENT_SECRET")
+		cfg.Discord.RedirectURI = l.url("DISCORD_REDIRECT_URI")
+		cfg.Discord.ClientID = l.snowflake("DISCORD_CLIENT_ID")
+		cfg.Discord.ServerID = l.snowflake("DISCORD_SERVER_ID")
+		cfg.Discord.ChannelID = l.snowflake("DISCORD_CHANNEL_ID")
+		cfg.Discord.AdminRoleID = l.snowflake("DISCORD_ADMIN_ROLE_ID")
+
+		if len(l.string("DISCORD_TRUSTED_ROLE_ID", "")) != 0 {
+			cfg.Discord.TrustedRoleID = l.snowflake("DISCORD_TRUSTED_ROLE_ID")
+		}
+
+		if len(l.string("DISCORD_OPS_CHANNEL_ID", "")) != 0 {
+			cfg.Discord.OpsChannelID = l.snowflake("DISCORD_OPS_CHANNEL_ID")
+		}
+
+		cfg.Discord.AdminChannelID = cfg.Discord.ChannelID
+
+		if len(l.string("DISCORD_ADMIN_CHANNEL_ID", "")) != 0 {
+			cfg.Discord.AdminChannelID = l.snowflake("DISCORD_ADMIN_CHANNEL_ID")
+		}
+
+		if len(l.string("DISCORD_OPEN_SEATS_CHANNEL_ID", "")) != 0 {
+			cfg.Discord.OpenSeatsChannelID = l.snowflake("DISCORD_OPEN_SEATS_CHANNEL_ID")
+		}
+
+		if len(l.string("DISCORD_VOICE_CATEGORY_ID", "")) != 0 {
+			cfg.Discord.VoiceCategoryID = l.snowflake("DISCORD_VOICE_CATEGORY_ID")
+		}
+	}
+
+	cfg.Discord.Presence = l.bool("DISCORD_PRESENCE", false)
+
+	if publicKey := l.string("DISCORD_PUBLIC_KEY", ""); len(publicKey) != 0 {
+		key, err := hex.DecodeString(publicKey)
+
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			l.invalid("DISCORD_PUBLIC_KEY", "'%v' is not the public key of a Discord application", publicKey)
+		}
+
+		cfg.Discord.PublicKey = key
+	}
+
+	if len(l.problems) != 0 {
+		return Config{}, &Error{Problems: l.problems}
+	}
+
+	return cfg, nil
+}
+
+// loader reads environment variables, collecting problems instead of
+// stopping at the first one.
+type loader struct {
+	getenv   func(string) string
+	problems []string
+}
+
+func (l *loader) invalid(key, format string, args ...any) {
+	l.problems = append(l.problems, fmt.Sprintf("%v: %v", key, fmt.Sprintf(format, args...)))
+}
+
+func (l *loader) string(key, fallback string) string {
+	if value := strings.TrimSpace(l.getenv(key)); len(value) != 0 {
+		return value
+	}
+
+	return fallback
+}
+
+func (l *loader) required(key string) string {
+	value := l.string(key, "")
+
+	if len(value) == 0 {
+		l.invalid(key, "is required")
+	}
+
+	return value
+}
+
+func (l *loader) oneOf(key, fallback string, allowed ...string) string {
+	value := l.string(key, fallback)
+
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+
+	l.invalid(key, "'%v' is not one of %v", value, strings.Join(allowed, ", "))
+	return fallback
+}
+
+func (l *loader) int(key string, fallback, lo, hi int) int {
+	raw := l.string(key, "")
+
+	if len(raw) == 0 {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+
+	if err != nil || value < lo || value > hi {
+		l.invalid(key, "'%v' is not an integer between %d and %d", raw, lo, hi)
+		return fallback
+	}
+
+	return value
+}
+
+func (l *loader) float(key string, fallback, lo, hi float64) float64 {
+	raw := l.string(key, "")
+
+	if len(raw) == 0 {
+		return fallback
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+
+	if err != nil || value < lo || value > hi {
+		l.invalid(key, "'%v' is not a number between %v and %v", raw, lo, hi)
+		return fallback
+	}
+
+	return value
+}
+
+func (l *loader) bool(key string, fallback bool) bool {
+	raw := l.string(key, "")
+
+	if len(raw) == 0 {
+		return fallback
+	}
+
+	value, err := strconv.ParseBool(raw)
+
+	if err != nil {
+		l.invalid(key, "'%v' is not a boolean", raw)
+		return fallback
+	}
+
+	return value
+}
+
+func (l *loader) duration(key string, fallback time.Duration) time.Duration {
+	raw := l.string(key, "")
+
+	if len(raw) == 0 {
+		return fallback
+	}
+
+	value, err := time.ParseDuration(raw)
+
+	if err != nil || value <= 0 {
+		l.invalid(key, "'%v' is not a positive duration such as 30s", raw)
+		return fallback
+	}
+
+	return value
+}
+
+func (l *loader) location(key, fallback string) *time.Location {
+	raw := l.string(key, fallback)
+	loc, err := time.LoadLocation(raw)
+
+	if err != nil {
+		l.invalid(key, "'%v' is not a time zone such as Europe/Paris", raw)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// schedule reads a cron expression such as "0 9 * * *", "@daily" or
+// "@every 1h", see jobs.Parse.
+func (l *loader) schedule(key, fallback string, loc *time.Location) jobs.Schedule {
+	raw := l.string(key, fallback)
+	schedule, err := jobs.Parse(raw, loc)
+
+	if err != nil {
+		l.invalid(key, "'%v' is not a cron expression such as '0 9 * * *'", raw)
+		schedule, _ = jobs.Parse(fallback, loc)
+	}
+
+	return schedule
+}
+
+// ipRanges reads a comma separated list of IPs and CIDRs.
+func (l *loader) ipRanges(key string) []string {
+	ranges := l.list(key, nil)
+
+	for _, r := range ranges {
+		if _, _, err := net.ParseCIDR(r); err != nil && net.ParseIP(r) == nil {
+			l.invalid(key, "'%v' is not an IP or a CIDR", r)
+		}
+	}
+
+	return ranges
+}
+
+// routeDurations reads a comma separated list of "METHOD /route=duration".
+func (l *loader) routeDurations(key string) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+
+	for _, entry := range l.list(key, nil) {
+		route, raw, found := strings.Cut(entry, "=")
+		method, path, isRoute := strings.Cut(strings.TrimSpace(route), " ")
+
+		if !found || !isRoute || !strings.HasPrefix(path, "/") || strings.ToUpper(method) != method {
+			l.invalid(key, "'%v' is not of the form 'GET /route=10s'", entry)
+			continue
+		}
+
+		value, err := time.ParseDuration(strings.TrimSpace(raw))
+
+		if err != nil || value < 0 {
+			l.invalid(key, "'%v' has an invalid duration", entry)
+			continue
+		}
+
+		durations[method+" "+path] = value
+	}
+
+	return durations
+}
+
+// clockTimes reads a comma separated list of times of day as "15:04",
+// returned as the duration since midnight.
+func (l *loader) clockTimes(key, fallback string) []time.Duration {
+	times := []time.Duration{}
+
+	for _, entry := range l.list(key, strings.Split(fallback, ",")) {
+		t, err := time.Parse("15:04", entry)
+
+		if err != nil {
+			l.invalid(key, "'%v' is not a time of day such as 20:00", entry)
+			continue
+		}
+
+		times = append(times, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute)
+	}
+
+	return times
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// primeTime reads a comma separated list of weekly slots as
+// "fri 19:00-24:00".
+func (l *loader) primeTime(key string) []PrimeTimeSlot {
+	slots := []PrimeTimeSlot{}
+
+	for _, entry := range l.list(key, nil) {
+		day, hours, _ := strings.Cut(entry, " ")
+		start, end, _ := strings.Cut(strings.TrimSpace(hours), "-")
+		weekday, isDay := weekdays[strings.ToLower(day)]
+		from, startOK := clockTime(start)
+		to, endOK := clockTime(end)
+
+		if !isDay || !startOK || !endOK || from >= to {
+			l.invalid(key, "'%v' is not a weekly slot such as 'fri 19:00-24:00'", entry)
+			continue
+		}
+
+		slots = append(slots, PrimeTimeSlot{Day: weekday, Start: from, End: to})
+	}
+
+	return slots
+}
+
+// clockTime parses a time of day as "15:04" into the duration since
+// midnight, "24:00" being the end of the day.
+func clockTime(value string) (time.Duration, bool) {
+	if value == "24:00" {
+		return 24 * time.Hour, true
+	}
+
+	t, err := time.Parse("15:04", value)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+func (l *loader) list(key string, fallback []string) []string {
+	raw := l.string(key, "")
+
+	if len(raw) == 0 {
+		return fallback
+	}
+
+	values := []string{}
+
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); len(value) != 0 {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (l *loader) url(key string) string {
+	value := l.required(key)
+
+	if len(value) == 0 {
+		return value
+	}
+
+	if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+		l.invalid(key, "'%v' is not an absolute URL", value)
+	}
+
+	return value
+}
+
+// snowflake reads a required Discord ID, a decimal number.
+func (l *loader) snowflake(key string) string {
+	value := l.required(key)
+
+	if len(value) == 0 {
+		return value
+	}
+
+	if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+		l.invalid(key, "'%v' is not a Discord ID", value)
+	}
+
+	return value
+}
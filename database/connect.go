@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect opens a connection pool to url and waits for the database to be
+// reachable, so the service survives booting before Postgres. Broken
+// connections are health checked out of the pool and replaced on demand.
+// Sessions use timezone, so dates are grouped by day as the club sees them.
+func Connect(ctx context.Context, url string, timezone *time.Location) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(url)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid database url: %w", err)
+	}
+
+	config.HealthCheckPeriod = 15 * time.Second
+	config.ConnConfig.ConnectTimeout = 5 * time.Second
+	config.ConnConfig.Tracer = NewTracer()
+	config.ConnConfig.RuntimeParams["timezone"] = timezone.String()
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	logger := slog.Default().With("component", "database")
+	policy := RetryPolicy{Attempts: 6, Backoff: time.Second}
+
+	err = Retry(ctx, policy, func() error {
+		err := pool.Ping(ctx)
+
+		if err != nil {
+			logger.Warn("database not reachable yet", "err", err)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to reach database: %w", err)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID identifies the advisory lock held while migrating so that
+// replicas booting together don't run migrations concurrently.
+const migrationLockID = 7312004850
+
+var ErrDirty = errors.New("database is in a dirty migration state, fix it manually then force the version")
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	conn       *pgxpool.Pool
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(conn *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFiles, "migrations")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{conn: conn, migrations: migrations, logger: slog.Default().With("component", "migrate")}, nil
+}
+
+// LoadMigrations reads the "<version>_<name>.(up|down).sql" files of dir,
+// requiring both directions and contiguous versions starting at 1.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name '%v'", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration '%v': %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names '%v' and '%v'", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+
+	for _, migration := range byVersion {
+		if len(migration.Up) == 0 || len(migration.Down) == 0 {
+			return nil, fmt.Errorf("migration %d_%v must have both up and down files", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be contiguous, expected %d got %d", i+1, migration.Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Version returns the current schema version and whether the last migration
+// failed half way.
+func (m *Migrator) Version(ctx context.Context) (int, bool, error) {
+	conn, err := m.acquire(ctx)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer m.release(conn)
+
+	return m.version(ctx, conn)
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	conn, err := m.acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer m.release(conn)
+
+	version, dirty, err := m.version(ctx, conn)
+
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+
+	for _, migration := range m.migrations[version:] {
+		if err := m.apply(ctx, conn, migration.Version, migration.Up, migration.Version); err != nil {
+			return fmt.Errorf("failed to apply migration %d_%v: %w", migration.Version, migration.Name, err)
+		}
+
+		m.logger.Info("applied migration", "version", migration.Version, "name", migration.Name)
+	}
+
+	return nil
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	conn, err := m.acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer m.release(conn)
+
+	version, dirty, err := m.version(ctx, conn)
+
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+
+	for ; steps > 0 && version > 0; steps-- {
+		migration := m.migrations[version-1]
+
+		if err := m.apply(ctx, conn, migration.Version, migration.Down, migration.Version-1); err != nil {
+			return fmt.Errorf("failed to revert migration %d_%v: %w", migration.Version, migration.Name, err)
+		}
+
+		m.logger.Info("reverted migration", "version", migration.Version, "name", migration.Name)
+		version--
+	}
+
+	return nil
+}
+
+// Force sets the schema version and clears the dirty flag without running
+// anything, once a failed migration has been fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version < 0 || version > len(m.migrations) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	conn, err := m.acquire(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer m.release(conn)
+
+	return m.setVersion(ctx, conn, version, false)
+}
+
+// apply marks the database dirty at dirtyVersion, runs sql in a transaction
+// and records the resulting version once it succeeded.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, dirtyVersion int, sql string, version int) error {
+	if err := m.setVersion(ctx, conn, dirtyVersion, true); err != nil {
+		return err
+	}
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, sql)
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, conn, version, false)
+}
+
+func (m *Migrator) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := m.conn.Acquire(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	sql := `
+		CREATE SCHEMA IF NOT EXISTS "game-table-booking";
+
+		CREATE TABLE IF NOT EXISTS "game-table-booking".schema_migrations
+		(
+			version integer NOT NULL,
+			dirty boolean NOT NULL
+		);
+	`
+
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		m.release(conn)
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (m *Migrator) release(conn *pgxpool.Conn) {
+	conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+	conn.Release()
+}
+
+func (m *Migrator) version(ctx context.Context, conn *pgxpool.Conn) (int, bool, error) {
+	var version int
+	var dirty bool
+
+	err := conn.QueryRow(ctx, `SELECT version, dirty FROM "game-table-booking".schema_migrations LIMIT 1`).Scan(&version, &dirty)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version > len(m.migrations) {
+		return 0, false, fmt.Errorf("schema version %d is newer than the known migrations", version)
+	}
+
+	return version, dirty, nil
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *pgxpool.Conn, version int, dirty bool) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM "game-table-booking".schema_migrations`); err != nil {
+			return fmt.Errorf("failed to clear schema version: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `INSERT INTO "game-table-booking".schema_migrations(version, dirty) VALUES ($1, $2)`, version, dirty); err != nil {
+			return fmt.Errorf("failed to set schema version: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package database_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hanksha/tbz-booking-system-backend/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		migrations, err := database.LoadMigrations(os.DirFS("."), "migrations")
+
+		require.Nil(t, err)
+		require.NotEmpty(t, migrations)
+		require.Equal(t, "create_booking", migrations[0].Name)
+	})
+
+	t.Run("sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0002_second.up.sql":   {Data: []byte("up2")},
+			"m/0002_second.down.sql": {Data: []byte("down2")},
+			"m/0001_first.up.sql":    {Data: []byte("up1")},
+			"m/0001_first.down.sql":  {Data: []byte("down1")},
+		}
+
+		migrations, err := database.LoadMigrations(fsys, "m")
+
+		require.Nil(t, err)
+		require.Equal(t, []database.Migration{
+			{Version: 1, Name: "first", Up: "up1", Down: "down1"},
+			{Version: 2, Name: "second", Up: "up2", Down: "down2"},
+		}, migrations)
+	})
+
+	t.Run("missing down", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0001_first.up.sql": {Data: []byte("up1")},
+		}
+
+		_, err := database.LoadMigrations(fsys, "m")
+
+		require.ErrorContains(t, err, "both up and down")
+	})
+
+	t.Run("gap in versions", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0001_first.up.sql":   {Data: []byte("up1")},
+			"m/0001_first.down.sql": {Data: []byte("down1")},
+			"m/0003_third.up.sql":   {Data: []byte("up3")},
+			"m/0003_third.down.sql": {Data: []byte("down3")},
+		}
+
+		_, err := database.LoadMigrations(fsys, "m")
+
+		require.ErrorContains(t, err, "contiguous")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/setup.sql": {Data: []byte("up1")},
+		}
+
+		_, err := database.LoadMigrations(fsys, "m")
+
+		require.ErrorContains(t, err, "invalid migration file name")
+	})
+}
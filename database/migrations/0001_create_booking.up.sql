@@ -2,8 +2,6 @@ CREATE SCHEMA IF NOT EXISTS "game-table-booking";
 
 -- Table: game-table-booking.booking
 
--- DROP TABLE IF EXISTS "game-table-booking".booking;
-
 CREATE TABLE IF NOT EXISTS "game-table-booking".booking
 (
     id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
@@ -16,4 +14,4 @@ CREATE TABLE IF NOT EXISTS "game-table-booking".booking
     "reminderEnabled" boolean,
     "dateTime" timestamp without time zone,
     players character varying[] COLLATE pg_catalog."default"
-);
\ No newline at end of file
+);
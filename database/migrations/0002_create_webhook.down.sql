@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "game-table-booking".webhook_delivery;
+
+DROP TABLE IF EXISTS "game-table-booking".webhook;
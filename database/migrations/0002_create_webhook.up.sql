@@ -0,0 +1,26 @@
+-- Table: game-table-booking.webhook
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".webhook
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    url character varying COLLATE pg_catalog."default" NOT NULL,
+    secret character varying COLLATE pg_catalog."default" NOT NULL,
+    events character varying[] COLLATE pg_catalog."default",
+    active boolean DEFAULT true,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+-- Table: game-table-booking.webhook_delivery
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".webhook_delivery
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "webhookId" integer REFERENCES "game-table-booking".webhook (id) ON DELETE CASCADE,
+    event character varying COLLATE pg_catalog."default",
+    payload character varying COLLATE pg_catalog."default",
+    attempt integer,
+    "statusCode" integer,
+    error character varying COLLATE pg_catalog."default",
+    success boolean,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
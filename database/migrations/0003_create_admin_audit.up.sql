@@ -0,0 +1,15 @@
+-- Table: game-table-booking.admin_audit
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".admin_audit
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "actorId" character varying COLLATE pg_catalog."default",
+    "actorUsername" character varying COLLATE pg_catalog."default",
+    action character varying COLLATE pg_catalog."default" NOT NULL,
+    "targetType" character varying COLLATE pg_catalog."default",
+    "targetId" character varying COLLATE pg_catalog."default",
+    payload jsonb,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_created_at_idx ON "game-table-booking".admin_audit ("createdAt" DESC);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "game-table-booking".user_ban;
@@ -0,0 +1,11 @@
+-- Table: game-table-booking.user_ban
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".user_ban
+(
+    "userId" character varying COLLATE pg_catalog."default" PRIMARY KEY,
+    username character varying COLLATE pg_catalog."default",
+    reason character varying COLLATE pg_catalog."default",
+    "expiresAt" timestamp with time zone,
+    "createdBy" character varying COLLATE pg_catalog."default",
+    "createdAt" timestamp with time zone DEFAULT now()
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "game-table-booking".booking_created_at_idx;
+
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "updatedAt",
+    DROP COLUMN IF EXISTS "createdAt";
@@ -0,0 +1,6 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS "updatedAt" timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS booking_created_at_idx
+    ON "game-table-booking".booking ("createdAt" DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "game-table-booking".booking_deleted_at_idx;
+
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "deletedAt";
@@ -0,0 +1,6 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "deletedAt" timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS booking_deleted_at_idx
+    ON "game-table-booking".booking ("deletedAt")
+    WHERE "deletedAt" IS NOT NULL;
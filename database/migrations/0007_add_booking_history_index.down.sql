@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "game-table-booking".booking_history_idx;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS booking_history_idx
+    ON "game-table-booking".booking ("dateTime" DESC, id DESC)
+    WHERE "deletedAt" IS NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "game-table-booking".booking_search_idx;
+
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "searchVector";
+
+DROP FUNCTION IF EXISTS "game-table-booking".booking_search_vector(character varying, character varying, character varying, character varying[]);
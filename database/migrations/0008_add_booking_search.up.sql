@@ -0,0 +1,20 @@
+-- array_to_string is only stable, generated columns need an immutable expression
+CREATE OR REPLACE FUNCTION "game-table-booking".booking_search_vector(
+    game character varying,
+    description character varying,
+    username character varying,
+    players character varying[])
+RETURNS tsvector
+LANGUAGE sql IMMUTABLE PARALLEL SAFE
+AS $$
+    SELECT setweight(to_tsvector('simple', coalesce(game, '')), 'A')
+        || setweight(to_tsvector('simple', coalesce(username, '') || ' ' || coalesce(array_to_string(players, ' '), '')), 'B')
+        || setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+$$;
+
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "searchVector" tsvector
+    GENERATED ALWAYS AS ("game-table-booking".booking_search_vector(game, description, username, players)) STORED;
+
+CREATE INDEX IF NOT EXISTS booking_search_idx
+    ON "game-table-booking".booking USING GIN ("searchVector");
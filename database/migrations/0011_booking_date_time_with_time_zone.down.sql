@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking
+    ALTER COLUMN "dateTime" TYPE timestamp without time zone USING "dateTime" AT TIME ZONE 'Europe/Paris';
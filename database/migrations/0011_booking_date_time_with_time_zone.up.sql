@@ -0,0 +1,3 @@
+-- dates were stored as Europe/Paris wall clock time
+ALTER TABLE "game-table-booking".booking
+    ALTER COLUMN "dateTime" TYPE timestamp with time zone USING "dateTime" AT TIME ZONE 'Europe/Paris';
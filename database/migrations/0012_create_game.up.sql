@@ -0,0 +1,13 @@
+-- Table: game-table-booking.game
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".game
+(
+    name character varying COLLATE pg_catalog."default" NOT NULL,
+    "minPoints" integer NOT NULL DEFAULT 0,
+    "maxPoints" integer NOT NULL DEFAULT 0,
+    "pointsStep" integer NOT NULL DEFAULT 0,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS game_name_idx
+    ON "game-table-booking".game (lower(name));
@@ -0,0 +1,11 @@
+-- Table: game-table-booking.booking_result
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_result
+(
+    "bookingId" integer PRIMARY KEY REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    winner character varying COLLATE pg_catalog."default",
+    scores jsonb NOT NULL DEFAULT '{}',
+    report character varying COLLATE pg_catalog."default",
+    "reportedBy" character varying COLLATE pg_catalog."default",
+    "reportedAt" timestamp with time zone DEFAULT now()
+);
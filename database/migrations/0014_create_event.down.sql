@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "game-table-booking".event_participant;
+DROP TABLE IF EXISTS "game-table-booking".event;
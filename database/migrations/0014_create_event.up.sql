@@ -0,0 +1,25 @@
+-- Table: game-table-booking.event
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".event
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name character varying COLLATE pg_catalog."default" NOT NULL,
+    game character varying COLLATE pg_catalog."default" NOT NULL,
+    points integer NOT NULL DEFAULT 0,
+    "dateTime" timestamp with time zone NOT NULL,
+    rounds integer NOT NULL,
+    capacity integer NOT NULL,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+-- Table: game-table-booking.event_participant
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".event_participant
+(
+    "eventId" integer NOT NULL REFERENCES "game-table-booking".event (id) ON DELETE CASCADE,
+    "userId" character varying COLLATE pg_catalog."default" NOT NULL,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    "bookingId" integer REFERENCES "game-table-booking".booking (id) ON DELETE SET NULL,
+    "signedUpAt" timestamp with time zone DEFAULT now(),
+    PRIMARY KEY ("eventId", "userId")
+);
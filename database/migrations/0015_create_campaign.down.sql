@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "game-table-booking".campaign_booking;
+DROP TABLE IF EXISTS "game-table-booking".campaign;
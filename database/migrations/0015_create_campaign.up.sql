@@ -0,0 +1,21 @@
+-- Table: game-table-booking.campaign
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".campaign
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name character varying COLLATE pg_catalog."default" NOT NULL,
+    game character varying COLLATE pg_catalog."default",
+    description character varying COLLATE pg_catalog."default",
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+-- Table: game-table-booking.campaign_booking
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".campaign_booking
+(
+    "campaignId" integer NOT NULL REFERENCES "game-table-booking".campaign (id) ON DELETE CASCADE,
+    "bookingId" integer PRIMARY KEY REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS campaign_booking_campaign_idx
+    ON "game-table-booking".campaign_booking ("campaignId");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "game-table-booking".poll_vote;
+DROP TABLE IF EXISTS "game-table-booking".poll_slot;
+DROP TABLE IF EXISTS "game-table-booking".poll;
@@ -0,0 +1,34 @@
+-- Table: game-table-booking.poll
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".poll
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    title character varying COLLATE pg_catalog."default" NOT NULL,
+    game character varying COLLATE pg_catalog."default" NOT NULL,
+    points integer NOT NULL DEFAULT 0,
+    description character varying COLLATE pg_catalog."default",
+    "organizerId" character varying COLLATE pg_catalog."default" NOT NULL,
+    organizer character varying COLLATE pg_catalog."default" NOT NULL,
+    invitees character varying[] COLLATE pg_catalog."default",
+    status character varying COLLATE pg_catalog."default" NOT NULL,
+    "bookingId" integer REFERENCES "game-table-booking".booking (id) ON DELETE SET NULL,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+-- Table: game-table-booking.poll_slot
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".poll_slot
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "pollId" integer NOT NULL REFERENCES "game-table-booking".poll (id) ON DELETE CASCADE,
+    "dateTime" timestamp with time zone NOT NULL
+);
+
+-- Table: game-table-booking.poll_vote
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".poll_vote
+(
+    "slotId" integer NOT NULL REFERENCES "game-table-booking".poll_slot (id) ON DELETE CASCADE,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    PRIMARY KEY ("slotId", username)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS visibility;
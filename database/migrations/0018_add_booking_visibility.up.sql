@@ -0,0 +1,3 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS visibility text NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'members', 'private'));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "game-table-booking".booking_equipment;
+DROP TABLE IF EXISTS "game-table-booking".equipment;
@@ -0,0 +1,25 @@
+-- Table: game-table-booking.equipment
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".equipment
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name character varying COLLATE pg_catalog."default" NOT NULL,
+    quantity integer NOT NULL CHECK (quantity >= 0),
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS equipment_name_idx
+    ON "game-table-booking".equipment (lower(name));
+
+-- Table: game-table-booking.booking_equipment
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_equipment
+(
+    "bookingId" integer NOT NULL REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    "equipmentId" integer NOT NULL REFERENCES "game-table-booking".equipment (id) ON DELETE CASCADE,
+    quantity integer NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY ("bookingId", "equipmentId")
+);
+
+CREATE INDEX IF NOT EXISTS booking_equipment_equipment_idx
+    ON "game-table-booking".booking_equipment ("equipmentId");
@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking DROP COLUMN IF EXISTS "venueId";
+DROP TABLE IF EXISTS "game-table-booking".venue;
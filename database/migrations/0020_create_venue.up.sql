@@ -0,0 +1,19 @@
+-- Table: game-table-booking.venue
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".venue
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name character varying COLLATE pg_catalog."default" NOT NULL,
+    tables integer NOT NULL CHECK (tables > 0),
+    "openingHours" jsonb NOT NULL DEFAULT '[]',
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS venue_name_idx
+    ON "game-table-booking".venue (lower(name));
+
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "venueId" integer REFERENCES "game-table-booking".venue (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS booking_venue_idx
+    ON "game-table-booking".booking ("venueId", "dateTime");
@@ -0,0 +1,16 @@
+-- Table: game-table-booking.booking_attachment
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_attachment
+(
+    id integer GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "bookingId" integer NOT NULL REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    filename character varying COLLATE pg_catalog."default" NOT NULL,
+    "contentType" character varying COLLATE pg_catalog."default" NOT NULL,
+    size bigint NOT NULL CHECK (size > 0),
+    "storageKey" character varying COLLATE pg_catalog."default" NOT NULL UNIQUE,
+    "uploadedBy" character varying COLLATE pg_catalog."default" NOT NULL,
+    "createdAt" timestamp with time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS booking_attachment_booking_idx
+    ON "game-table-booking".booking_attachment ("bookingId");
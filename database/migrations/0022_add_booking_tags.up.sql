@@ -0,0 +1,5 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS booking_tags_idx
+    ON "game-table-booking".booking USING gin (tags);
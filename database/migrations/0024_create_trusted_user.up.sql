@@ -0,0 +1,10 @@
+-- Table: game-table-booking.trusted_user
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".trusted_user
+(
+    "userId" character varying COLLATE pg_catalog."default" PRIMARY KEY,
+    username character varying COLLATE pg_catalog."default",
+    note character varying COLLATE pg_catalog."default",
+    "createdBy" character varying COLLATE pg_catalog."default",
+    "createdAt" timestamp with time zone DEFAULT now()
+);
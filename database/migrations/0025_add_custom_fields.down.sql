@@ -0,0 +1,5 @@
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "customFields";
+
+ALTER TABLE "game-table-booking".game
+    DROP COLUMN IF EXISTS fields;
@@ -0,0 +1,5 @@
+ALTER TABLE "game-table-booking".game
+    ADD COLUMN IF NOT EXISTS fields jsonb NOT NULL DEFAULT '[]';
+
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "customFields" jsonb;
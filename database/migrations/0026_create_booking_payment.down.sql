@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "game-table-booking".booking_payment;
+
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "paymentStatus";
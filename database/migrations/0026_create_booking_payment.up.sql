@@ -0,0 +1,16 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "paymentStatus" character varying COLLATE pg_catalog."default";
+
+-- Table: game-table-booking.booking_payment
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_payment
+(
+    "bookingId" integer PRIMARY KEY REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    "sessionId" character varying COLLATE pg_catalog."default" NOT NULL UNIQUE,
+    url character varying COLLATE pg_catalog."default" NOT NULL,
+    amount bigint NOT NULL CHECK (amount > 0),
+    currency character varying COLLATE pg_catalog."default" NOT NULL,
+    status character varying COLLATE pg_catalog."default" NOT NULL,
+    "createdAt" timestamp with time zone DEFAULT now(),
+    "paidAt" timestamp with time zone
+);
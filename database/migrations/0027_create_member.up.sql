@@ -0,0 +1,12 @@
+-- Table: game-table-booking.member
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".member
+(
+    "userId" character varying COLLATE pg_catalog."default" PRIMARY KEY,
+    username character varying COLLATE pg_catalog."default",
+    guest boolean NOT NULL DEFAULT false,
+    "expiresAt" timestamp with time zone,
+    note character varying COLLATE pg_catalog."default",
+    "createdBy" character varying COLLATE pg_catalog."default",
+    "createdAt" timestamp with time zone DEFAULT now()
+);
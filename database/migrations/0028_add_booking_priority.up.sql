@@ -0,0 +1,3 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS priority character varying NOT NULL DEFAULT 'normal'
+    CHECK (priority IN ('normal', 'tournament', 'league'));
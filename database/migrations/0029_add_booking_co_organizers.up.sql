@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "coOrganizers" text[] NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE "game-table-booking".booking DROP COLUMN IF EXISTS "decidedAt";
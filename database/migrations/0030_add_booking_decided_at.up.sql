@@ -0,0 +1,19 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "decidedAt" timestamp with time zone;
+
+-- the bookings decided before are dated from the audit log, the bookings of
+-- trusted users accepted on creation being left undecided, told by the rule
+-- recorded or by the reason of the entries written before it
+UPDATE "game-table-booking".booking
+SET "decidedAt" = decision."createdAt"
+FROM (
+    SELECT "targetId", MIN("createdAt") AS "createdAt"
+    FROM "game-table-booking".admin_audit
+    WHERE action IN ('booking.accept', 'booking.refuse')
+    OR (action = 'booking.auto-accept'
+        AND payload->>'rule' IS DISTINCT FROM 'trusted'
+        AND (payload->>'rule' IS NOT NULL OR payload->>'reason' IS DISTINCT FROM 'membre de confiance'))
+    GROUP BY "targetId"
+) AS decision
+WHERE decision."targetId" = booking.id::text
+AND booking."decidedAt" IS NULL;
@@ -0,0 +1,9 @@
+-- Table: game-table-booking.achievement
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".achievement
+(
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    badge character varying COLLATE pg_catalog."default" NOT NULL,
+    "awardedAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (username, badge)
+);
@@ -0,0 +1,14 @@
+-- Table: game-table-booking.user_preference
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".user_preference
+(
+    "userId" character varying COLLATE pg_catalog."default" NOT NULL,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    email character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    "emailNotifications" boolean NOT NULL DEFAULT false,
+    "updatedAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY ("userId")
+);
+
+CREATE INDEX IF NOT EXISTS user_preference_username_idx
+    ON "game-table-booking".user_preference (lower(username));
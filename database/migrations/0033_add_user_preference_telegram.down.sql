@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "game-table-booking".user_preference
+    DROP COLUMN IF EXISTS "telegramNotifications",
+    DROP COLUMN IF EXISTS "telegramChatId";
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "game-table-booking".user_preference
+    ADD COLUMN IF NOT EXISTS "telegramChatId" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "telegramNotifications" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE "game-table-booking".booking
+    DROP COLUMN IF EXISTS "calendarSyncError",
+    DROP COLUMN IF EXISTS "calendarSyncStatus",
+    DROP COLUMN IF EXISTS "calendarEventId";
@@ -0,0 +1,4 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "calendarEventId" character varying COLLATE pg_catalog."default",
+    ADD COLUMN IF NOT EXISTS "calendarSyncStatus" character varying COLLATE pg_catalog."default",
+    ADD COLUMN IF NOT EXISTS "calendarSyncError" text COLLATE pg_catalog."default";
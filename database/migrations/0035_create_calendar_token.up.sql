@@ -0,0 +1,12 @@
+-- Table: game-table-booking.calendar_token
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".calendar_token
+(
+    "userId" character varying COLLATE pg_catalog."default" NOT NULL,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    "tokenHash" character varying COLLATE pg_catalog."default" NOT NULL,
+    "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+    "lastUsedAt" timestamp with time zone,
+    PRIMARY KEY ("userId"),
+    UNIQUE ("tokenHash")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "game-table-booking".booking_reminder_sent;
+DROP TABLE IF EXISTS "game-table-booking".booking_reminder;
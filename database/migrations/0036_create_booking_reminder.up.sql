@@ -0,0 +1,21 @@
+-- Table: game-table-booking.booking_reminder
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_reminder
+(
+    "bookingId" integer NOT NULL REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    "leadTimes" integer[] NOT NULL DEFAULT '{}',
+    channel character varying COLLATE pg_catalog."default" NOT NULL DEFAULT 'dm',
+    "optedOut" text[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY ("bookingId")
+);
+
+-- Table: game-table-booking.booking_reminder_sent
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_reminder_sent
+(
+    "bookingId" integer NOT NULL REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    "leadTime" integer NOT NULL,
+    "dateTime" timestamp with time zone NOT NULL,
+    "sentAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY ("bookingId", "leadTime", "dateTime")
+);
@@ -0,0 +1,11 @@
+-- Table: game-table-booking.booking_reminder_response
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".booking_reminder_response
+(
+    "bookingId" integer NOT NULL REFERENCES "game-table-booking".booking (id) ON DELETE CASCADE,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    acknowledged boolean NOT NULL DEFAULT false,
+    "snoozedUntil" timestamp with time zone,
+    "respondedAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY ("bookingId", username)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking
+    ADD COLUMN IF NOT EXISTS "voiceChannelId" character varying COLLATE pg_catalog."default";
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "game-table-booking".guild_config;
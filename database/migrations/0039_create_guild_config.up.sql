@@ -0,0 +1,16 @@
+-- Table: game-table-booking.guild_config
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".guild_config
+(
+    "guildId" character varying COLLATE pg_catalog."default" NOT NULL,
+    "channelId" character varying COLLATE pg_catalog."default" NOT NULL,
+    "adminChannelId" character varying COLLATE pg_catalog."default" NOT NULL,
+    "openSeatsChannelId" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    "opsChannelId" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    "adminRoleId" character varying COLLATE pg_catalog."default" NOT NULL,
+    "trustedRoleId" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    locale character varying COLLATE pg_catalog."default" NOT NULL DEFAULT 'en',
+    "updatedAt" timestamp with time zone NOT NULL DEFAULT now(),
+    "updatedBy" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    PRIMARY KEY ("guildId")
+);
@@ -0,0 +1,10 @@
+-- Table: game-table-booking.role_permission
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".role_permission
+(
+    "roleId" character varying COLLATE pg_catalog."default" NOT NULL,
+    permissions text[] NOT NULL DEFAULT '{}',
+    "updatedBy" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    "updatedAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY ("roleId")
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "game-table-booking".booking DROP CONSTRAINT IF EXISTS booking_user_fkey;
+DROP TABLE IF EXISTS "game-table-booking".users;
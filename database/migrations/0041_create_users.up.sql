@@ -0,0 +1,29 @@
+-- Table: game-table-booking.users
+
+CREATE TABLE IF NOT EXISTS "game-table-booking".users
+(
+    id character varying COLLATE pg_catalog."default" NOT NULL,
+    username character varying COLLATE pg_catalog."default" NOT NULL,
+    "globalName" character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    avatar character varying COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    "firstSeenAt" timestamp with time zone NOT NULL DEFAULT now(),
+    "lastSeenAt" timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS users_username_idx
+    ON "game-table-booking".users (lower(username));
+
+-- the organizers of the existing bookings, under their latest username
+UPDATE "game-table-booking".booking SET "userId" = NULL WHERE "userId" = '';
+
+INSERT INTO "game-table-booking".users (id, username, "firstSeenAt", "lastSeenAt")
+SELECT DISTINCT ON ("userId") "userId", COALESCE(username, ''),
+    MIN("createdAt") OVER (PARTITION BY "userId"), MAX("createdAt") OVER (PARTITION BY "userId")
+FROM "game-table-booking".booking
+WHERE "userId" IS NOT NULL
+ORDER BY "userId", "createdAt" DESC
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE "game-table-booking".booking
+    ADD CONSTRAINT booking_user_fkey FOREIGN KEY ("userId") REFERENCES "game-table-booking".users (id);
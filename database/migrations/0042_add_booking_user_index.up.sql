@@ -0,0 +1,3 @@
+-- the directory counts the bookings of each user
+CREATE INDEX IF NOT EXISTS booking_user_idx
+    ON "game-table-booking".booking ("userId");
@@ -0,0 +1,2 @@
+UPDATE "game-table-booking".booking
+    SET "dateTime" = ("dateTime" AT TIME ZONE 'UTC') AT TIME ZONE 'Europe/Paris';
@@ -0,0 +1,3 @@
+-- the dates converted from Europe/Paris wall clock time were UTC instants
+UPDATE "game-table-booking".booking
+    SET "dateTime" = ("dateTime" AT TIME ZONE 'Europe/Paris') AT TIME ZONE 'UTC';
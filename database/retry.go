@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RetryPolicy bounds the retries of a database operation.
+type RetryPolicy struct {
+	Attempts int
+	// Backoff is the delay before the first retry, doubled on each attempt.
+	Backoff time.Duration
+	// Retryable decides which errors are retried, IsTransient when nil.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy is meant for idempotent queries, which can run twice.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, Backoff: 100 * time.Millisecond}
+
+// TxRetryPolicy is meant for whole transactions, only retried when the server
+// is known to have rolled them back.
+var TxRetryPolicy = RetryPolicy{Attempts: 3, Backoff: 50 * time.Millisecond, Retryable: IsRolledBack}
+
+// Retry runs fn until it succeeds, fails with an error the policy doesn't
+// retry, runs out of attempts or ctx is done.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	retryable := policy.Retryable
+
+	if retryable == nil {
+		retryable = IsTransient
+	}
+
+	backoff := policy.Backoff
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= policy.Attempts || !retryable(err) {
+			return err
+		}
+
+		// jitter spreads the retries of concurrent requests
+		delay := backoff/2 + rand.N(backoff/2+1)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		backoff *= 2
+	}
+}
+
+// IsTransient reports whether err is a failure likely to go away on its own,
+// such as a lost connection or a serialization conflict. The operation may
+// have been applied when the connection broke mid-flight, so only idempotent
+// operations should be retried on it.
+func IsTransient(err error) bool {
+	if IsRolledBack(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// admin_shutdown, crash_shutdown and connection_exception classes
+		return pgErr.Code == "57P01" || pgErr.Code == "57P02" || strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	var netErr net.Error
+	var connectErr *pgconn.ConnectError
+
+	return errors.As(err, &netErr) ||
+		errors.As(err, &connectErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// IsRolledBack reports whether err guarantees nothing was applied: the
+// request never reached the server, or the server aborted the transaction
+// because of a serialization failure or a deadlock.
+func IsRolledBack(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// serialization_failure, deadlock_detected, cannot_connect_now
+		return pgErr.Code == "40001" || pgErr.Code == "40P01" || pgErr.Code == "57P03"
+	}
+
+	return pgconn.SafeToRetry(err)
+}
@@ -0,0 +1,97 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	policy := database.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		calls := 0
+
+		err := database.Retry(ctx, policy, func() error {
+			calls++
+			if calls < 3 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+			return nil
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		calls := 0
+
+		err := database.Retry(ctx, policy, func() error {
+			calls++
+			return &pgconn.PgError{Code: "23505"}
+		})
+
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		calls := 0
+
+		err := database.Retry(ctx, policy, func() error {
+			calls++
+			return io.EOF
+		})
+
+		require.ErrorIs(t, err, io.EOF)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		calls := 0
+
+		err := database.Retry(ctx, database.RetryPolicy{Attempts: 5, Backoff: time.Hour}, func() error {
+			calls++
+			return io.EOF
+		})
+
+		require.ErrorIs(t, err, io.EOF)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err        error
+		transient  bool
+		rolledBack bool
+	}{
+		{err: &pgconn.PgError{Code: "40001"}, transient: true, rolledBack: true},
+		{err: &pgconn.PgError{Code: "40P01"}, transient: true, rolledBack: true},
+		{err: &pgconn.PgError{Code: "57P01"}, transient: true},
+		{err: &pgconn.PgError{Code: "08006"}, transient: true},
+		{err: &pgconn.PgError{Code: "23505"}},
+		{err: fmt.Errorf("failed to query: %w", io.EOF), transient: true},
+		{err: syscall.ECONNRESET, transient: true},
+		{err: errors.New("boom")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			require.Equal(t, test.transient, database.IsTransient(test.err))
+			require.Equal(t, test.rolledBack, database.IsRolledBack(test.err))
+		})
+	}
+}
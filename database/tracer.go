@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/hanksha/tbz-booking-system-backend/database"
+
+// Tracer is a pgx.QueryTracer recording a span per query, so slow
+// statements show up in the trace of the request which ran them.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer using the global tracer provider.
+func NewTracer() *Tracer {
+	return &Tracer{tracer: otel.Tracer(tracerName)}
+}
+
+func (t *Tracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		// queries outside of a traced operation, such as health checks,
+		// would only produce orphan spans
+		return ctx
+	}
+
+	ctx, _ = t.tracer.Start(ctx, spanName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.query.text", data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t *Tracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+
+	if !span.IsRecording() {
+		return
+	}
+
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.response.affected_rows", data.CommandTag.RowsAffected()))
+}
+
+// spanName is the operation of sql, such as SELECT or UPDATE.
+func spanName(sql string) string {
+	fields := strings.Fields(sql)
+
+	if len(fields) == 0 {
+		return "postgresql"
+	}
+
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	tracer := database.NewTracer()
+
+	t.Run("records queries of traced operations", func(t *testing.T) {
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+
+		ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t\tselect id from booking"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+		parent.End()
+
+		spans := recorder.Ended()
+
+		require.Len(t, spans, 2)
+		require.Equal(t, "SELECT", spans[0].Name())
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+		require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+
+	t.Run("ignores untraced queries", func(t *testing.T) {
+		before := len(recorder.Ended())
+
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select 1"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+		require.Len(t, recorder.Ended(), before)
+	})
+}
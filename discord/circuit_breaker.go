@@ -0,0 +1,143 @@
+package discord
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("discord API unavailable, circuit open")
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker fails Discord calls fast once threshold calls in a row
+// failed, so requests don't each wait out the timeout while Discord is down
+// or rate limiting the bot. After cooldown, or the Retry-After of a 429, a
+// single call probes Discord again.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openUntil time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now, state: CircuitClosed}
+}
+
+// WithCircuitBreaker guards every call of the client with breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.breaker = breaker
+	}
+}
+
+// Allow returns ErrCircuitOpen when the call must not be attempted.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Before(b.openUntil) {
+			return ErrCircuitOpen
+		}
+
+		b.state = CircuitHalfOpen
+
+		return nil
+	case CircuitHalfOpen:
+		// the probe is in flight
+		return ErrCircuitOpen
+	}
+
+	return nil
+}
+
+// Record reports the outcome of an allowed call, retryAfter being how long
+// Discord asked to wait, if it did.
+func (b *CircuitBreaker) Record(success bool, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = CircuitClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold || retryAfter > 0 {
+		b.state = CircuitOpen
+		b.openUntil = b.now().Add(max(b.cooldown, retryAfter))
+	}
+}
+
+// abandon reports a call without outcome, letting the next one probe when
+// it was the probe.
+func (b *CircuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.state = CircuitOpen
+		b.openUntil = b.now()
+	}
+}
+
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.client.Do(req)
+	}
+
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// the caller gave up, Discord is not to blame
+		c.breaker.abandon()
+	case err != nil:
+		c.breaker.Record(false, 0)
+	case res.StatusCode == http.StatusTooManyRequests:
+		c.breaker.Record(false, retryAfter(res))
+	default:
+		c.breaker.Record(res.StatusCode < http.StatusInternalServerError, 0)
+	}
+
+	return res, err
+}
+
+// retryAfter reads the delay of a rate limited response, which Discord gives
+// in seconds, possibly fractional.
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.ParseFloat(res.Header.Get("Retry-After"), 64)
+
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
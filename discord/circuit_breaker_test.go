@@ -0,0 +1,78 @@
+package discord_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		var calls atomic.Int32
+		var down atomic.Bool
+		down.Store(true)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if down.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer server.Close()
+
+		breaker := discord.NewCircuitBreaker(3, 20*time.Millisecond)
+		client := discord.NewClient("bot-token", "client", "secret", "http://localhost/auth", "server",
+			discord.WithBaseURL(server.URL),
+			discord.WithCircuitBreaker(breaker),
+		)
+
+		for range 3 {
+			require.Error(t, client.SendMessage(context.Background(), "bookings", discord.Message{}))
+		}
+
+		require.Equal(t, discord.CircuitOpen, breaker.State())
+
+		err := client.SendMessage(context.Background(), "bookings", discord.Message{})
+
+		require.ErrorIs(t, err, discord.ErrCircuitOpen)
+		require.Equal(t, int32(3), calls.Load())
+
+		// Discord recovers, the probe closes the circuit
+		down.Store(false)
+		time.Sleep(30 * time.Millisecond)
+
+		require.Nil(t, client.SendMessage(context.Background(), "bookings", discord.Message{}))
+		require.Equal(t, discord.CircuitClosed, breaker.State())
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		breaker := discord.NewCircuitBreaker(5, time.Millisecond)
+
+		require.Nil(t, breaker.Allow())
+		breaker.Record(false, time.Hour)
+
+		require.ErrorIs(t, breaker.Allow(), discord.ErrCircuitOpen)
+	})
+
+	t.Run("client errors don't count", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		breaker := discord.NewCircuitBreaker(1, time.Hour)
+		client := discord.NewClient("bot-token", "client", "secret", "http://localhost/auth", "server",
+			discord.WithBaseURL(server.URL),
+			discord.WithCircuitBreaker(breaker),
+		)
+
+		require.Error(t, client.SendMessage(context.Background(), "bookings", discord.Message{}))
+		require.Equal(t, discord.CircuitClosed, breaker.State())
+	})
+}
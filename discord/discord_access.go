@@ -0,0 +1,263 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Permissions of the bot on the channels it posts to.
+const (
+	PermissionAdministrator uint64 = 1 << 3
+	PermissionViewChannel   uint64 = 1 << 10
+	PermissionSendMessages  uint64 = 1 << 11
+	PermissionEmbedLinks    uint64 = 1 << 14
+)
+
+// channelPermissions are those the notifications need, by their name in the
+// Discord settings.
+var channelPermissions = []struct {
+	permission uint64
+	name       string
+}{
+	{PermissionViewChannel, "View Channel"},
+	{PermissionSendMessages, "Send Messages"},
+	{PermissionEmbedLinks, "Embed Links"},
+}
+
+// AccessError lists why the bot cannot post where it is configured to, each
+// problem telling how to fix it.
+type AccessError struct {
+	Problems []string
+}
+
+func (e *AccessError) Error() string {
+	return "the Discord bot is not set up: " + strings.Join(e.Problems, "; ")
+}
+
+type role struct {
+	ID          string `json:"id"`
+	Permissions string `json:"permissions"`
+}
+
+type overwrite struct {
+	ID    string `json:"id"`
+	Allow string `json:"allow"`
+	Deny  string `json:"deny"`
+}
+
+type channel struct {
+	ID         string      `json:"id"`
+	GuildID    string      `json:"guild_id"`
+	Overwrites []overwrite `json:"permission_overwrites"`
+}
+
+// CheckAccess verifies the bot token is valid, the bot is a member of the
+// server and it may post messages with embeds in the channels, keyed by the
+// setting naming them. Problems of the setup are reported together as an
+// *AccessError, Discord being unreachable as another error. The result is
+// cached for a minute, like CheckBotToken's.
+func (c *Client) CheckAccess(ctx context.Context, channels map[string]string) error {
+	c.accessCheckMu.Lock()
+	defer c.accessCheckMu.Unlock()
+
+	if !c.accessCheck.checkedAt.IsZero() && c.now().Sub(c.accessCheck.checkedAt) < botCheckTTL {
+		return c.accessCheck.err
+	}
+
+	err := c.checkAccess(ctx, channels)
+
+	if ctx.Err() == nil {
+		c.accessCheck = botCheckResult{err: err, checkedAt: c.now()}
+	}
+
+	return err
+}
+
+func (c *Client) checkAccess(ctx context.Context, channels map[string]string) error {
+	var me User
+
+	switch status, err := c.getJSON(ctx, &me, "users", "@me"); {
+	case err != nil:
+		return err
+	case status == http.StatusUnauthorized:
+		return &AccessError{Problems: []string{"the bot token is rejected by Discord, reset it on the Bot page of the application"}}
+	case status != http.StatusOK:
+		return fmt.Errorf("failed to fetch the bot user: status '%v'", status)
+	}
+
+	var member Member
+
+	switch status, err := c.getJSON(ctx, &member, "guilds", c.serverID, "members", me.ID); {
+	case err != nil:
+		return err
+	case status == http.StatusNotFound, status == http.StatusForbidden:
+		return &AccessError{Problems: []string{fmt.Sprintf("the bot %v is not a member of the server '%v', invite it with the OAuth2 URL generator of the application", me.Username, c.serverID)}}
+	case status != http.StatusOK:
+		return fmt.Errorf("failed to fetch the bot member: status '%v'", status)
+	}
+
+	var roles []role
+
+	if status, err := c.getJSON(ctx, &roles, "guilds", c.serverID, "roles"); err != nil {
+		return err
+	} else if status != http.StatusOK {
+		return fmt.Errorf("failed to fetch the server roles: status '%v'", status)
+	}
+
+	base := basePermissions(c.serverID, member.Roles, roles)
+
+	settings := make([]string, 0, len(channels))
+
+	for setting := range channels {
+		settings = append(settings, setting)
+	}
+
+	slices.Sort(settings)
+
+	var problems []string
+
+	for _, setting := range settings {
+		id := channels[setting]
+
+		var ch channel
+
+		status, err := c.getJSON(ctx, &ch, "channels", id)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case status == http.StatusNotFound:
+			problems = append(problems, fmt.Sprintf("the channel '%v' of %v does not exist", id, setting))
+			continue
+		case status == http.StatusForbidden:
+			problems = append(problems, fmt.Sprintf("the bot cannot see the channel '%v' of %v, grant it View Channel", id, setting))
+			continue
+		case status != http.StatusOK:
+			return fmt.Errorf("failed to fetch channel '%v': status '%v'", id, status)
+		case ch.GuildID != c.serverID:
+			problems = append(problems, fmt.Sprintf("the channel '%v' of %v is not in the server '%v'", id, setting, c.serverID))
+			continue
+		}
+
+		permissions := channelPermissionsOf(base, c.serverID, me.ID, member.Roles, ch.Overwrites)
+
+		var missing []string
+
+		for _, required := range channelPermissions {
+			if permissions&required.permission == 0 {
+				missing = append(missing, required.name)
+			}
+		}
+
+		if len(missing) != 0 {
+			problems = append(problems, fmt.Sprintf("the bot lacks %v in the channel '%v' of %v", strings.Join(missing, ", "), id, setting))
+		}
+	}
+
+	if len(problems) != 0 {
+		return &AccessError{Problems: problems}
+	}
+
+	return nil
+}
+
+// basePermissions are those the roles of the member grant server-wide, the
+// @everyone role having the id of the server.
+func basePermissions(serverID string, memberRoles []string, roles []role) uint64 {
+	var permissions uint64
+
+	for _, r := range roles {
+		if r.ID == serverID || slices.Contains(memberRoles, r.ID) {
+			permissions |= parsePermissions(r.Permissions)
+		}
+	}
+
+	return permissions
+}
+
+// channelPermissionsOf applies the overwrites of a channel to the base
+// permissions of the member: those of @everyone, then of their roles, then
+// their own.
+func channelPermissionsOf(base uint64, serverID, userID string, memberRoles []string, overwrites []overwrite) uint64 {
+	if base&PermissionAdministrator != 0 {
+		return ^uint64(0)
+	}
+
+	permissions := base
+
+	for _, o := range overwrites {
+		if o.ID == serverID {
+			permissions = permissions&^parsePermissions(o.Deny) | parsePermissions(o.Allow)
+		}
+	}
+
+	var allow, deny uint64
+
+	for _, o := range overwrites {
+		if slices.Contains(memberRoles, o.ID) {
+			allow |= parsePermissions(o.Allow)
+			deny |= parsePermissions(o.Deny)
+		}
+	}
+
+	permissions = permissions&^deny | allow
+
+	for _, o := range overwrites {
+		if o.ID == userID {
+			permissions = permissions&^parsePermissions(o.Deny) | parsePermissions(o.Allow)
+		}
+	}
+
+	return permissions
+}
+
+// parsePermissions reads a permission bit set, serialized as a string by
+// Discord.
+func parsePermissions(value string) uint64 {
+	permissions, _ := strconv.ParseUint(value, 10, 64)
+	return permissions
+}
+
+// getJSON decodes the resource at the path into v when Discord answers 200,
+// and returns the status code.
+func (c *Client) getJSON(ctx context.Context, v any, elem ...string) (int, error) {
+	resourceURL, err := c.getURL(elem...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", resourceURL, http.NoBody)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed create new request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	res, err := c.do(req)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return res.StatusCode, nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return 0, errors.Join(fmt.Errorf("failed to decode '%v'", resourceURL), err)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,119 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAccess(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/@me", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot bot-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(discord.User{ID: "bot", Username: "tbz-bot"})
+	})
+	mux.HandleFunc("GET /guilds/server/members/bot", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(discord.Member{User: discord.User{ID: "bot"}, Roles: []string{"bots"}})
+	})
+	mux.HandleFunc("GET /guilds/other/members/bot", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /guilds/server/roles", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]string{
+			// @everyone may view, post and embed links
+			{"id": "server", "permissions": "19456"},
+			{"id": "bots", "permissions": "0"},
+		})
+	})
+	channels := map[string]map[string]any{
+		"bookings": {"id": "bookings", "guild_id": "server"},
+		// posting is denied to @everyone but allowed to the bots
+		"admin": {"id": "admin", "guild_id": "server", "permission_overwrites": []map[string]any{
+			{"id": "server", "type": 0, "allow": "0", "deny": "2048"},
+			{"id": "bots", "type": 0, "allow": "2048", "deny": "0"},
+		}},
+		// the bot itself may not embed links
+		"ops": {"id": "ops", "guild_id": "server", "permission_overwrites": []map[string]any{
+			{"id": "bot", "type": 1, "allow": "0", "deny": "16384"},
+		}},
+		"elsewhere": {"id": "elsewhere", "guild_id": "another"},
+	}
+	mux.HandleFunc("GET /channels/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		switch id := r.PathValue("channel"); id {
+		case "hidden":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			if channel, ok := channels[id]; ok {
+				json.NewEncoder(w).Encode(channel)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	newClient := func(token, serverID string) *discord.Client {
+		return discord.NewClient(token, "client", "secret", "http://localhost/auth", serverID,
+			discord.WithBaseURL(server.URL),
+			discord.WithHTTPClient(discord.NewHTTPClient(discord.HTTPConfig{Timeout: time.Second, MaxIdleConns: 1})),
+		)
+	}
+
+	problemsOf := func(t *testing.T, err error) []string {
+		var accessErr *discord.AccessError
+		require.ErrorAs(t, err, &accessErr)
+		return accessErr.Problems
+	}
+
+	t.Run("can post", func(t *testing.T) {
+		err := newClient("bot-token", "server").CheckAccess(context.Background(), map[string]string{
+			"DISCORD_CHANNEL_ID":       "bookings",
+			"DISCORD_ADMIN_CHANNEL_ID": "admin",
+		})
+
+		require.Nil(t, err)
+	})
+
+	t.Run("cannot post", func(t *testing.T) {
+		err := newClient("bot-token", "server").CheckAccess(context.Background(), map[string]string{
+			"DISCORD_CHANNEL_ID":            "bookings",
+			"DISCORD_OPS_CHANNEL_ID":        "ops",
+			"DISCORD_ADMIN_CHANNEL_ID":      "hidden",
+			"DISCORD_OPEN_SEATS_CHANNEL_ID": "missing",
+			"DISCORD_OTHER_CHANNEL_ID":      "elsewhere",
+		})
+
+		require.Equal(t, []string{
+			"the bot cannot see the channel 'hidden' of DISCORD_ADMIN_CHANNEL_ID, grant it View Channel",
+			"the channel 'missing' of DISCORD_OPEN_SEATS_CHANNEL_ID does not exist",
+			"the bot lacks Embed Links in the channel 'ops' of DISCORD_OPS_CHANNEL_ID",
+			"the channel 'elsewhere' of DISCORD_OTHER_CHANNEL_ID is not in the server 'server'",
+		}, problemsOf(t, err))
+	})
+
+	t.Run("not a member of the server", func(t *testing.T) {
+		err := newClient("bot-token", "other").CheckAccess(context.Background(), map[string]string{"DISCORD_CHANNEL_ID": "bookings"})
+
+		require.Equal(t, []string{
+			"the bot tbz-bot is not a member of the server 'other', invite it with the OAuth2 URL generator of the application",
+		}, problemsOf(t, err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		err := newClient("wrong", "server").CheckAccess(context.Background(), map[string]string{"DISCORD_CHANNEL_ID": "bookings"})
+
+		require.Len(t, problemsOf(t, err), 1)
+	})
+}
@@ -0,0 +1,117 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Types of the channels.
+const (
+	ChannelGuildText     = 0
+	ChannelGuildVoice    = 2
+	ChannelGuildCategory = 4
+)
+
+// Channel is a channel of the server, ParentID being its category.
+type Channel struct {
+	ID       string `json:"id,omitempty"`
+	Type     int    `json:"type"`
+	GuildID  string `json:"guild_id,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+	Name     string `json:"name"`
+}
+
+// CreateChannel creates the channel in the server, the bot needing the
+// Manage Channels permission, and returns it with its id.
+func (c *Client) CreateChannel(ctx context.Context, channel Channel) (Channel, error) {
+	if len(strings.TrimSpace(channel.Name)) == 0 {
+		return Channel{}, errors.New("channel name cannot be empty")
+	}
+
+	channelsURL, err := c.getURL("guilds", c.serverID, "channels")
+
+	if err != nil {
+		return Channel{}, err
+	}
+
+	body, err := json.Marshal(channel)
+
+	if err != nil {
+		return Channel{}, fmt.Errorf("failed to marshal body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", channelsURL, bytes.NewReader(body))
+
+	if err != nil {
+		return Channel{}, fmt.Errorf("failed create new request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	res, err := c.do(req)
+
+	if err != nil {
+		return Channel{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return Channel{}, fmt.Errorf("request failed with status '%v' and body:\n%v", res.StatusCode, string(bodyBytes))
+	}
+
+	var created Channel
+
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return Channel{}, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteChannel deletes the channel, a channel already gone being no error.
+func (c *Client) DeleteChannel(ctx context.Context, channelID string) error {
+	if len(strings.TrimSpace(channelID)) == 0 {
+		return errors.New("channelID cannot be empty")
+	}
+
+	channelURL, err := c.getURL("channels", channelID)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", channelURL, http.NoBody)
+
+	if err != nil {
+		return fmt.Errorf("failed create new request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	res, err := c.do(req)
+
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("request failed with status '%v' and body:\n%v", res.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
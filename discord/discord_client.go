@@ -7,23 +7,34 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/hanksha/tbz-booking-system-backend/telemetry"
 	"github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/hanksha/tbz-booking-system-backend/discord")
+
 type Message struct {
 	Content string  `json:"content"`
 	Embeds  []Embed `json:"embeds"`
+	// Components are the rows of buttons under the message.
+	Components []Component `json:"components,omitempty"`
 }
 
 type Embed struct {
 	Type      string       `json:"type"`
 	Title     string       `json:"title"`
+	URL       string       `json:"url,omitempty"`
 	Author    Author       `json:"author"`
 	Fields    []EmbedField `json:"fields"`
 	ChannelID string       `json:"channelId"`
@@ -57,11 +68,42 @@ type OAuthToken struct {
 type Member struct {
 	User  User     `json:"user"`
 	Roles []string `json:"roles"`
+	// Nick is the nickname of the member on the server, if any.
+	Nick string `json:"nick"`
 }
 
 type User struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
+	// GlobalName is the display name of the user across servers, if any.
+	GlobalName string `json:"global_name"`
+	// Avatar is the hash of the user's avatar, empty for the default one.
+	Avatar string `json:"avatar"`
+}
+
+const avatarBaseURL = "https://cdn.discordapp.com/avatars"
+
+// DisplayName is the name the member goes by on the server: its nickname,
+// else its global name, else its username.
+func (m Member) DisplayName() string {
+	switch {
+	case len(m.Nick) != 0:
+		return m.Nick
+	case len(m.User.GlobalName) != 0:
+		return m.User.GlobalName
+	default:
+		return m.User.Username
+	}
+}
+
+// AvatarURL is the URL of the user's avatar, empty when it has the default
+// one.
+func (u User) AvatarURL() string {
+	if len(u.Avatar) == 0 {
+		return ""
+	}
+
+	return avatarBaseURL + "/" + u.ID + "/" + u.Avatar + ".png"
 }
 
 type Event struct {
@@ -73,19 +115,36 @@ type Event struct {
 	Status      int    `json:"status"`
 }
 
-const baseURL = "https://discord.com/api/v10"
+const DefaultBaseURL = "https://discord.com/api/v10"
 
 type Client struct {
+	baseURL      string
 	token        string
 	clientID     string
 	clientSecret string
 	redirectURI  string
 	serverID     string
 	client       *http.Client
+	breaker      *CircuitBreaker
 	membersCache *cache.Cache
 	eventsCache  *cache.Cache
+	now          func() time.Time
+	// botCheck holds the last bot token check, so readiness probes don't
+	// hit Discord's rate limits
+	botCheckMu sync.Mutex
+	botCheck   botCheckResult
+	// accessCheck holds the last check of the channels, see CheckAccess
+	accessCheckMu sync.Mutex
+	accessCheck   botCheckResult
 }
 
+type botCheckResult struct {
+	err       error
+	checkedAt time.Time
+}
+
+const botCheckTTL = time.Minute
+
 type DiscordClient interface {
 	SendMessage(ctx context.Context, channelID string, message Message) error
 	GetOAuth2Token(ctx context.Context, code string) (*OAuthToken, error)
@@ -95,20 +154,86 @@ type DiscordClient interface {
 	GetDMChannel(ctx context.Context, userID string) (string, error)
 }
 
-func NewClient(token, clientID, clientSecret, redirectURI, serverID string) *Client {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// HTTPConfig tunes the HTTP client calling the Discord API.
+type HTTPConfig struct {
+	// Timeout bounds a whole request, response body included.
+	Timeout time.Duration
+	// MaxIdleConns is the number of connections kept open to Discord.
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of the TCP keep-alive probes.
+	KeepAlive time.Duration
+}
+
+var DefaultHTTPConfig = HTTPConfig{
+	Timeout:         10 * time.Second,
+	MaxIdleConns:    10,
+	IdleConnTimeout: 90 * time.Second,
+	KeepAlive:       30 * time.Second,
+}
+
+// NewHTTPClient returns a traced HTTP client tuned by config.
+func NewHTTPClient(config HTTPConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConns
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: config.KeepAlive}).DialContext
+
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: telemetry.Transport(transport),
+	}
+}
+
+type ClientOption func(*Client)
+
+// WithHTTPClient replaces the HTTP client built from DefaultHTTPConfig.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = client
 	}
-	return &Client{
+}
+
+// WithBaseURL sends the requests to another API root than DefaultBaseURL,
+// such as an httptest server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithClock replaces time.Now for the client and its circuit breaker, to
+// test the cached checks and the rate limits without waiting.
+func WithClock(now func() time.Time) ClientOption {
+	return func(c *Client) {
+		c.now = now
+	}
+}
+
+func NewClient(token, clientID, clientSecret, redirectURI, serverID string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:      DefaultBaseURL,
 		token:        token,
-		client:       client,
+		client:       NewHTTPClient(DefaultHTTPConfig),
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		redirectURI:  redirectURI,
 		serverID:     serverID,
 		membersCache: cache.New(1*time.Minute, 5*time.Minute),
 		eventsCache:  cache.New(1*time.Minute, 5*time.Minute),
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.breaker != nil {
+		c.breaker.now = c.now
 	}
+
+	return c
 }
 
 func (c *Client) SendMessage(ctx context.Context, channelID string, message Message) error {
@@ -135,7 +260,7 @@ func (c *Client) SendMessage(ctx context.Context, channelID string, message Mess
 
 	c.setHeaders(req)
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
@@ -180,7 +305,7 @@ func (c *Client) GetDMChannel(ctx context.Context, userID string) (string, error
 
 	c.setHeaders(req)
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return "", fmt.Errorf("failed to send request: %w", err)
@@ -230,7 +355,7 @@ func (c *Client) GetOAuth2Token(ctx context.Context, code string) (*OAuthToken,
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -283,7 +408,7 @@ func (c *Client) GetGuildMember(ctx context.Context, accessToken string) (*Membe
 	c.setHeaders(req)
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -320,6 +445,10 @@ func (c *Client) SearchMembers(ctx context.Context, query string, limit int) ([]
 	query = strings.TrimSpace(query)
 	cachedMembers, found := c.membersCache.Get(query)
 
+	// member lookups dominate booking notifications, cache hits included
+	ctx, span := tracer.Start(ctx, "discord.SearchMembers", trace.WithAttributes(attribute.Bool("discord.cache_hit", found)))
+	defer span.End()
+
 	if found {
 		return cachedMembers.([]Member), nil
 	}
@@ -343,7 +472,7 @@ func (c *Client) SearchMembers(ctx context.Context, query string, limit int) ([]
 
 	c.setHeaders(req)
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -397,7 +526,7 @@ func (c *Client) GetEvents(ctx context.Context) ([]Event, error) {
 
 	c.setHeaders(req)
 
-	res, err := c.client.Do(req)
+	res, err := c.do(req)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -430,6 +559,56 @@ func (c *Client) GetEvents(ctx context.Context) ([]Event, error) {
 	return events, nil
 }
 
+// CheckBotToken verifies the bot token is accepted by Discord. The result is
+// cached for a minute.
+func (c *Client) CheckBotToken(ctx context.Context) error {
+	c.botCheckMu.Lock()
+	defer c.botCheckMu.Unlock()
+
+	if !c.botCheck.checkedAt.IsZero() && c.now().Sub(c.botCheck.checkedAt) < botCheckTTL {
+		return c.botCheck.err
+	}
+
+	err := c.checkBotToken(ctx)
+
+	// a canceled probe says nothing about the token
+	if ctx.Err() == nil {
+		c.botCheck = botCheckResult{err: err, checkedAt: c.now()}
+	}
+
+	return err
+}
+
+func (c *Client) checkBotToken(ctx context.Context) error {
+	meURL, err := c.getURL("users", "@me")
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", meURL, http.NoBody)
+
+	if err != nil {
+		return fmt.Errorf("failed create new request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	res, err := c.do(req)
+
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("bot token rejected with status '%v'", res.StatusCode)
+	}
+
+	return nil
+}
+
 func (c *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
@@ -437,7 +616,7 @@ func (c *Client) setHeaders(req *http.Request) {
 }
 
 func (c *Client) getURL(elem ...string) (string, error) {
-	clientURL, err := url.JoinPath(baseURL, elem...)
+	clientURL, err := url.JoinPath(c.baseURL, elem...)
 	if err != nil {
 		return "", fmt.Errorf("failed to create URL: %w", err)
 	}
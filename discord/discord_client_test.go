@@ -0,0 +1,180 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var searches int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /channels/{channel}/messages", func(w http.ResponseWriter, r *http.Request) {
+		var message discord.Message
+		json.NewDecoder(r.Body).Decode(&message)
+
+		if r.PathValue("channel") != "bookings" || r.Header.Get("Authorization") != "Bot bot-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"id": "1", "content": message.Content})
+	})
+	mux.HandleFunc("GET /guilds/server/members/search", func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		json.NewEncoder(w).Encode([]discord.Member{{User: discord.User{ID: "42", Username: r.URL.Query().Get("query")}}})
+	})
+
+	mux.HandleFunc("POST /guilds/server/channels", func(w http.ResponseWriter, r *http.Request) {
+		var channel discord.Channel
+		json.NewDecoder(r.Body).Decode(&channel)
+
+		channel.ID = "voice-1"
+		channel.GuildID = "server"
+		json.NewEncoder(w).Encode(channel)
+	})
+	mux.HandleFunc("DELETE /channels/{channel}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("channel") != "voice-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(discord.Channel{ID: "voice-1"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := discord.NewClient("bot-token", "client", "secret", "http://localhost/auth", "server",
+		discord.WithBaseURL(server.URL),
+		discord.WithHTTPClient(discord.NewHTTPClient(discord.HTTPConfig{Timeout: time.Second, MaxIdleConns: 1})),
+	)
+
+	t.Run("send message", func(t *testing.T) {
+		require.Nil(t, client.SendMessage(context.Background(), "bookings", discord.Message{Content: "hello"}))
+		require.Error(t, client.SendMessage(context.Background(), "other", discord.Message{Content: "hello"}))
+	})
+
+	t.Run("create and delete channel", func(t *testing.T) {
+		channel, err := client.CreateChannel(context.Background(), discord.Channel{Type: discord.ChannelGuildVoice, ParentID: "category", Name: "Kill Team (alice)"})
+
+		require.Nil(t, err)
+		require.Equal(t, discord.Channel{ID: "voice-1", Type: discord.ChannelGuildVoice, GuildID: "server", ParentID: "category", Name: "Kill Team (alice)"}, channel)
+		require.Nil(t, client.DeleteChannel(context.Background(), "voice-1"))
+		// already gone
+		require.Nil(t, client.DeleteChannel(context.Background(), "voice-2"))
+	})
+
+	t.Run("search members is cached", func(t *testing.T) {
+		for range 2 {
+			members, err := client.SearchMembers(context.Background(), "player2", 1)
+
+			require.Nil(t, err)
+			require.Equal(t, "42", members[0].User.ID)
+		}
+
+		require.Equal(t, 1, searches)
+	})
+}
+
+func TestClientRateLimit(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30.5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"message":"You are being rate limited.","retry_after":30.5,"global":false}`)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"id": "1"})
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	breaker := discord.NewCircuitBreaker(5, time.Second)
+	client := discord.NewClient("bot-token", "client", "secret", "http://localhost/auth", "server",
+		discord.WithBaseURL(server.URL),
+		discord.WithCircuitBreaker(breaker),
+		discord.WithClock(func() time.Time { return now }),
+	)
+
+	err := client.SendMessage(context.Background(), "bookings", discord.Message{Content: "hello"})
+
+	require.ErrorContains(t, err, "429")
+	require.Equal(t, discord.CircuitOpen, breaker.State())
+
+	// the call waits out the Retry-After rather than the cooldown
+	now = now.Add(30 * time.Second)
+
+	require.ErrorIs(t, client.SendMessage(context.Background(), "bookings", discord.Message{}), discord.ErrCircuitOpen)
+
+	now = now.Add(time.Second)
+
+	require.Nil(t, client.SendMessage(context.Background(), "bookings", discord.Message{}))
+	require.Equal(t, discord.CircuitClosed, breaker.State())
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestClientErrors(t *testing.T) {
+	var checks int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /channels/{channel}/messages", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"Missing Permissions","code":50013}`)
+	})
+	mux.HandleFunc("GET /guilds/server/members/search", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"members":`)
+	})
+	mux.HandleFunc("GET /users/@me", func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	client := discord.NewClient("bot-token", "client", "secret", "http://localhost/auth", "server",
+		discord.WithBaseURL(server.URL),
+		discord.WithClock(func() time.Time { return now }),
+	)
+
+	t.Run("rejected message", func(t *testing.T) {
+		err := client.SendMessage(context.Background(), "bookings", discord.Message{Content: "hello"})
+
+		require.ErrorContains(t, err, "'403'")
+		require.ErrorContains(t, err, "Missing Permissions")
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		_, err := client.SearchMembers(context.Background(), "player2", 1)
+
+		require.ErrorContains(t, err, "failed reading body")
+	})
+
+	t.Run("bot token check is cached", func(t *testing.T) {
+		for range 2 {
+			require.ErrorContains(t, client.CheckBotToken(context.Background()), "'401'")
+		}
+
+		require.Equal(t, 1, checks)
+
+		// a minute later Discord is asked again
+		now = now.Add(time.Minute)
+
+		require.ErrorContains(t, client.CheckBotToken(context.Background()), "'401'")
+		require.Equal(t, 2, checks)
+	})
+}
@@ -0,0 +1,66 @@
+package discord
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// DevClient is a DiscordClient that never reaches Discord, for local
+// development and demos. Any access token is accepted and names the user it
+// authenticates; tokens starting with "admin" get the admin role.
+type DevClient struct {
+	adminRoleID string
+	logger      *slog.Logger
+}
+
+func NewDevClient(adminRoleID string) *DevClient {
+	return &DevClient{adminRoleID: adminRoleID, logger: slog.Default().With("component", "discord-dev")}
+}
+
+func (c *DevClient) SendMessage(ctx context.Context, channelID string, message Message) error {
+	titles := []string{}
+
+	for _, embed := range message.Embeds {
+		titles = append(titles, embed.Title)
+	}
+
+	c.logger.Info("message not sent", "channelId", channelID, "content", message.Content, "embeds", titles)
+
+	return nil
+}
+
+func (c *DevClient) GetOAuth2Token(ctx context.Context, code string) (*OAuthToken, error) {
+	return &OAuthToken{AccessToken: code, TokenType: "Bearer", ExpiresIn: 604800, Scope: "identify guilds.members.read"}, nil
+}
+
+func (c *DevClient) GetGuildMember(ctx context.Context, accessToken string) (*Member, error) {
+	member := c.member(accessToken)
+	return &member, nil
+}
+
+func (c *DevClient) SearchMembers(ctx context.Context, query string, limit int) ([]Member, error) {
+	if len(query) == 0 || limit <= 0 {
+		return []Member{}, nil
+	}
+
+	return []Member{c.member(query)}, nil
+}
+
+func (c *DevClient) GetEvents(ctx context.Context) ([]Event, error) {
+	return []Event{}, nil
+}
+
+func (c *DevClient) GetDMChannel(ctx context.Context, userID string) (string, error) {
+	return "dm-" + userID, nil
+}
+
+func (c *DevClient) member(username string) Member {
+	member := Member{User: User{ID: "dev-" + username, Username: username}, Roles: []string{}}
+
+	if strings.HasPrefix(username, "admin") {
+		member.Roles = append(member.Roles, c.adminRoleID)
+	}
+
+	return member
+}
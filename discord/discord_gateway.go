@@ -0,0 +1,308 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const DefaultGatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+
+// Opcodes of the gateway payloads.
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opPresenceUpdate = 3
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+	opHeartbeatACK   = 11
+)
+
+// Types of the activities.
+const (
+	ActivityPlaying = 0
+	ActivityCustom  = 4
+)
+
+// Activity is what the bot shows it is doing, State being the text of a
+// custom status.
+type Activity struct {
+	Name  string `json:"name"`
+	Type  int    `json:"type"`
+	State string `json:"state,omitempty"`
+}
+
+// Presence is the status of the bot, online and doing the activities.
+type Presence struct {
+	Since      *int64     `json:"since"`
+	Activities []Activity `json:"activities"`
+	Status     string     `json:"status"`
+	AFK        bool       `json:"afk"`
+}
+
+// CustomStatus returns the presence showing text as the status line of the
+// bot, none when text is empty.
+func CustomStatus(text string) Presence {
+	presence := Presence{Activities: []Activity{}, Status: "online"}
+
+	if len(text) != 0 {
+		presence.Activities = append(presence.Activities, Activity{Name: "Custom Status", Type: ActivityCustom, State: text})
+	}
+
+	return presence
+}
+
+type gatewayPayload struct {
+	Op       int             `json:"op"`
+	Data     json.RawMessage `json:"d,omitempty"`
+	Sequence *int64          `json:"s,omitempty"`
+	Type     string          `json:"t,omitempty"`
+}
+
+// Gateway keeps the bot connected to the Discord gateway, which the REST API
+// cannot replace to show its presence. It receives no events, identifying
+// without intents.
+type Gateway struct {
+	url    string
+	token  string
+	dialer *websocket.Dialer
+	logger *slog.Logger
+
+	// mu guards the connection and the presence, sent again on each
+	// connection
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	presence Presence
+}
+
+type GatewayOption func(*Gateway)
+
+// WithGatewayURL connects to another gateway than DefaultGatewayURL, such
+// as an httptest server.
+func WithGatewayURL(url string) GatewayOption {
+	return func(g *Gateway) {
+		g.url = url
+	}
+}
+
+func NewGateway(token string, opts ...GatewayOption) *Gateway {
+	g := &Gateway{
+		url:      DefaultGatewayURL,
+		token:    token,
+		dialer:   websocket.DefaultDialer,
+		logger:   slog.Default().With("component", "discord-gateway"),
+		presence: CustomStatus(""),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// UpdatePresence sets the presence of the bot, sent right away when the
+// gateway is connected and on the next connection otherwise.
+func (g *Gateway) UpdatePresence(ctx context.Context, presence Presence) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.presence = presence
+
+	if g.conn == nil {
+		return nil
+	}
+
+	return g.send(g.conn, opPresenceUpdate, presence)
+}
+
+// Run connects to the gateway until ctx is done, reconnecting with a
+// growing delay when the connection is lost.
+func (g *Gateway) Run(ctx context.Context) {
+	delay := time.Second
+
+	for {
+		started := time.Now()
+		err := g.connect(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		// a connection that lasted was fine, Discord closes them now and then
+		if time.Since(started) > time.Minute {
+			delay = time.Second
+		}
+
+		g.logger.Warn("disconnected from the Discord gateway", "err", err, "retryIn", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = min(2*delay, 5*time.Minute)
+	}
+}
+
+// connect identifies on a new connection and heartbeats until it is lost,
+// or Discord asks to reconnect.
+func (g *Gateway) connect(ctx context.Context) error {
+	conn, _, err := g.dialer.DialContext(ctx, g.url, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to dial gateway: %w", err)
+	}
+
+	defer conn.Close()
+
+	// reading stops with the connection, closed when ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	var hello struct {
+		HeartbeatInterval int64 `json:"heartbeat_interval"`
+	}
+
+	if err := g.read(conn, opHello, &hello); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	err = g.send(conn, opIdentify, map[string]any{
+		"token":   g.token,
+		"intents": 0,
+		"properties": map[string]string{
+			"os":      "linux",
+			"browser": "tbz-booking",
+			"device":  "tbz-booking",
+		},
+		"presence": g.presence,
+	})
+
+	if err == nil {
+		g.conn = conn
+	}
+	g.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		g.mu.Lock()
+		g.conn = nil
+		g.mu.Unlock()
+	}()
+
+	var sequence sync.Mutex
+	var last *int64
+
+	// done stops the heartbeats of this connection
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		interval := time.Duration(hello.HeartbeatInterval) * time.Millisecond
+		// the first heartbeat is jittered, as Discord asks
+		timer := time.NewTimer(time.Duration(rand.Int64N(int64(interval) + 1)))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-timer.C:
+			}
+
+			sequence.Lock()
+			s := last
+			sequence.Unlock()
+
+			g.mu.Lock()
+			err := g.send(conn, opHeartbeat, s)
+			g.mu.Unlock()
+
+			if err != nil {
+				conn.Close()
+				return
+			}
+
+			timer.Reset(interval)
+		}
+	}()
+
+	for {
+		var payload gatewayPayload
+
+		if err := conn.ReadJSON(&payload); err != nil {
+			return fmt.Errorf("failed to read gateway payload: %w", err)
+		}
+
+		if payload.Sequence != nil {
+			sequence.Lock()
+			last = payload.Sequence
+			sequence.Unlock()
+		}
+
+		switch payload.Op {
+		case opDispatch:
+			if payload.Type == "READY" {
+				g.logger.Info("connected to the Discord gateway")
+			}
+		case opHeartbeat:
+			g.mu.Lock()
+			err := g.send(conn, opHeartbeat, payload.Sequence)
+			g.mu.Unlock()
+
+			if err != nil {
+				return err
+			}
+		case opReconnect:
+			return errors.New("gateway asked to reconnect")
+		case opInvalidSession:
+			return errors.New("gateway session invalidated")
+		}
+	}
+}
+
+// read reads the next payload, expecting it to have the opcode.
+func (g *Gateway) read(conn *websocket.Conn, op int, v any) error {
+	var payload gatewayPayload
+
+	if err := conn.ReadJSON(&payload); err != nil {
+		return fmt.Errorf("failed to read gateway payload: %w", err)
+	}
+
+	if payload.Op != op {
+		return fmt.Errorf("expected gateway opcode %v, got %v", op, payload.Op)
+	}
+
+	return json.Unmarshal(payload.Data, v)
+}
+
+// send writes a payload, g.mu being held as gorilla/websocket connections
+// take one writer at a time.
+func (g *Gateway) send(conn *websocket.Conn, op int, data any) error {
+	body, err := json.Marshal(data)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal gateway payload: %w", err)
+	}
+
+	if err := conn.WriteJSON(gatewayPayload{Op: op, Data: body}); err != nil {
+		return fmt.Errorf("failed to write gateway payload: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package discord_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Op   int             `json:"op"`
+	Data json.RawMessage `json:"d"`
+}
+
+func TestGateway(t *testing.T) {
+	received := make(chan payload, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]any{"op": 10, "d": map[string]any{"heartbeat_interval": 60000}})
+
+		for {
+			var p payload
+
+			if err := conn.ReadJSON(&p); err != nil {
+				return
+			}
+
+			// the heartbeats are jittered
+			if p.Op != 1 {
+				received <- p
+			}
+		}
+	}))
+	defer server.Close()
+
+	gateway := discord.NewGateway("bot-token", discord.WithGatewayURL("ws"+strings.TrimPrefix(server.URL, "http")))
+
+	// set before connecting, sent when identifying
+	require.Nil(t, gateway.UpdatePresence(context.Background(), discord.CustomStatus("Next game: Blood Bowl, Thu 19:00")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go gateway.Run(ctx)
+
+	next := func() payload {
+		select {
+		case p := <-received:
+			return p
+		case <-time.After(time.Second):
+			t.Fatal("no payload received")
+			return payload{}
+		}
+	}
+
+	identify := next()
+
+	var identifyData struct {
+		Token    string           `json:"token"`
+		Presence discord.Presence `json:"presence"`
+	}
+
+	require.Equal(t, 2, identify.Op)
+	require.Nil(t, json.Unmarshal(identify.Data, &identifyData))
+	require.Equal(t, "bot-token", identifyData.Token)
+	require.Equal(t, discord.CustomStatus("Next game: Blood Bowl, Thu 19:00"), identifyData.Presence)
+
+	// connected, the presence is sent right away
+	require.Eventually(t, func() bool {
+		return gateway.UpdatePresence(context.Background(), discord.CustomStatus("")) == nil && len(received) != 0
+	}, time.Second, 10*time.Millisecond)
+
+	update := next()
+
+	var presence discord.Presence
+
+	require.Equal(t, 3, update.Op)
+	require.Nil(t, json.Unmarshal(update.Data, &presence))
+	require.Equal(t, discord.CustomStatus(""), presence)
+}
@@ -0,0 +1,177 @@
+package discord
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Headers signing the interactions Discord posts to the interactions
+// endpoint of the application.
+const (
+	SignatureHeader = "X-Signature-Ed25519"
+	TimestampHeader = "X-Signature-Timestamp"
+)
+
+// Types of the interactions.
+const (
+	InteractionPing               = 1
+	InteractionApplicationCommand = 2
+	InteractionMessageComponent   = 3
+	InteractionAutocomplete       = 4
+	InteractionModalSubmit        = 5
+)
+
+// Types of the responses to the interactions. The deferred ones acknowledge
+// the interaction within the 3 seconds Discord waits, the answer following
+// through the interaction token.
+const (
+	ResponsePong                   = 1
+	ResponseChannelMessage         = 4
+	ResponseDeferredChannelMessage = 5
+	ResponseDeferredUpdateMessage  = 6
+	ResponseUpdateMessage          = 7
+)
+
+// MessageFlagEphemeral shows a message to the user of the interaction only.
+const MessageFlagEphemeral = 64
+
+// Types of the message components and styles of the buttons.
+const (
+	ComponentActionRow = 1
+	ComponentButton    = 2
+
+	ButtonPrimary   = 1
+	ButtonSecondary = 2
+	ButtonSuccess   = 3
+	ButtonDanger    = 4
+)
+
+// Component is a row of a message, or a button in it whose clicks Discord
+// posts to the interactions endpoint with its custom id.
+type Component struct {
+	Type       int         `json:"type"`
+	Style      int         `json:"style,omitempty"`
+	Label      string      `json:"label,omitempty"`
+	CustomID   string      `json:"custom_id,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// Buttons returns a row of buttons.
+func Buttons(buttons ...Component) Component {
+	for i := range buttons {
+		buttons[i].Type = ComponentButton
+	}
+
+	return Component{Type: ComponentActionRow, Components: buttons}
+}
+
+// CommandOption is an option of a slash command as the user filled it in,
+// Options holding those of a subcommand.
+type CommandOption struct {
+	Name    string          `json:"name"`
+	Type    int             `json:"type"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Options []CommandOption `json:"options,omitempty"`
+}
+
+// String returns the value of a string option, empty for the others.
+func (o CommandOption) String() string {
+	var value string
+
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// InteractionData is the slash command run, Name and Options, or the
+// component clicked, CustomID.
+type InteractionData struct {
+	ID            string          `json:"id,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	Options       []CommandOption `json:"options,omitempty"`
+	CustomID      string          `json:"custom_id,omitempty"`
+	ComponentType int             `json:"component_type,omitempty"`
+}
+
+// Option returns the option of the slash command with the name.
+func (d InteractionData) Option(name string) (CommandOption, bool) {
+	for _, option := range d.Options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+
+	return CommandOption{}, false
+}
+
+// Interaction is a call of Discord to the interactions endpoint, for a slash
+// command or a click on a button. Member is who interacted in a server
+// channel, User who did in a direct message. Token answers it later, for 15
+// minutes.
+type Interaction struct {
+	ID            string          `json:"id"`
+	ApplicationID string          `json:"application_id"`
+	Type          int             `json:"type"`
+	Data          InteractionData `json:"data"`
+	GuildID       string          `json:"guild_id,omitempty"`
+	ChannelID     string          `json:"channel_id,omitempty"`
+	Member        *Member         `json:"member,omitempty"`
+	User          *User           `json:"user,omitempty"`
+	Token         string          `json:"token"`
+	// Locale is the language of the user, such as fr or en-US.
+	Locale string `json:"locale,omitempty"`
+}
+
+// Author returns who interacted.
+func (i Interaction) Author() User {
+	if i.Member != nil {
+		return i.Member.User
+	}
+
+	if i.User != nil {
+		return *i.User
+	}
+
+	return User{}
+}
+
+type InteractionResponseData struct {
+	Content    string      `json:"content,omitempty"`
+	Embeds     []Embed     `json:"embeds,omitempty"`
+	Components []Component `json:"components,omitempty"`
+	Flags      int         `json:"flags,omitempty"`
+}
+
+type InteractionResponse struct {
+	Type int                      `json:"type"`
+	Data *InteractionResponseData `json:"data,omitempty"`
+}
+
+// Pong answers the pings Discord sends to check the endpoint.
+func Pong() InteractionResponse {
+	return InteractionResponse{Type: ResponsePong}
+}
+
+// EphemeralMessage answers an interaction with a message shown to its user
+// only.
+func EphemeralMessage(content string) InteractionResponse {
+	return InteractionResponse{
+		Type: ResponseChannelMessage,
+		Data: &InteractionResponseData{Content: content, Flags: MessageFlagEphemeral},
+	}
+}
+
+// VerifyInteraction checks the signature of an interaction, of the timestamp
+// followed by the body, with the public key of the application.
+func VerifyInteraction(publicKey ed25519.PublicKey, signature, timestamp string, body []byte) bool {
+	sig, err := hex.DecodeString(signature)
+
+	if err != nil || len(sig) != ed25519.SignatureSize || len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, append([]byte(timestamp), body...), sig)
+}
@@ -0,0 +1,52 @@
+package discord_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteraction(t *testing.T) {
+	t.Run("slash command in a server", func(t *testing.T) {
+		var interaction discord.Interaction
+
+		err := json.Unmarshal([]byte(`{"id":"1","type":2,"token":"tok","guild_id":"9","data":{"name":"book","options":[{"name":"game","type":3,"value":"Warhammer"},{"name":"players","type":4,"value":4}]},"member":{"user":{"id":"2","username":"alice"}}}`), &interaction)
+
+		require.Nil(t, err)
+		require.Equal(t, discord.InteractionApplicationCommand, interaction.Type)
+		require.Equal(t, "alice", interaction.Author().Username)
+
+		game, ok := interaction.Data.Option("game")
+
+		require.True(t, ok)
+		require.Equal(t, "Warhammer", game.String())
+
+		players, _ := interaction.Data.Option("players")
+
+		require.Equal(t, "", players.String())
+
+		_, ok = interaction.Data.Option("date")
+
+		require.False(t, ok)
+	})
+
+	t.Run("button in a direct message", func(t *testing.T) {
+		var interaction discord.Interaction
+
+		err := json.Unmarshal([]byte(`{"type":3,"data":{"custom_id":"reminder:snooze:1","component_type":2},"user":{"id":"2","username":"alice"}}`), &interaction)
+
+		require.Nil(t, err)
+		require.Equal(t, "reminder:snooze:1", interaction.Data.CustomID)
+		require.Equal(t, "2", interaction.Author().ID)
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		pong, _ := json.Marshal(discord.Pong())
+		message, _ := json.Marshal(discord.EphemeralMessage("ok"))
+
+		require.JSONEq(t, `{"type":1}`, string(pong))
+		require.JSONEq(t, `{"type":4,"data":{"content":"ok","flags":64}}`, string(message))
+	})
+}
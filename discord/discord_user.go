@@ -1,7 +1,27 @@
 package discord
 
+import "context"
+
 type DiscordUser struct {
-	ID   string `json:"userId"`
+	ID       string `json:"userId"`
 	Username string `json:"username"`
 	Admin    bool   `json:"admin"`
-}
\ No newline at end of file
+	// Trusted members have their bookings accepted without review.
+	Trusted bool `json:"trusted"`
+	// Permissions are those granted to the roles of the member, admin and
+	// trusted included.
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type userContextKey struct{}
+
+func ContextWithUser(ctx context.Context, user DiscordUser) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user of the request ctx belongs
+// to, if any.
+func UserFromContext(ctx context.Context) (DiscordUser, bool) {
+	user, ok := ctx.Value(userContextKey{}).(DiscordUser)
+	return user, ok
+}
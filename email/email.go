@@ -0,0 +1,8 @@
+package email
+
+// Message is an HTML email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
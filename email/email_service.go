@@ -0,0 +1,173 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+)
+
+type SubscriberRepository interface {
+	GetSubscribers(ctx context.Context, usernames []string) ([]preference.Preferences, error)
+}
+
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+type BookingSource interface {
+	GetActiveBookings(ctx context.Context) ([]bk.Booking, error)
+}
+
+// Service emails the organizer and the players of the bookings who opted in
+// when their booking is accepted or refused, and on the day of the game.
+type Service struct {
+	subscribers SubscriberRepository
+	sender      Sender
+	bookings    BookingSource
+	// bookingURL is the frontend page of the bookings, their id appended
+	bookingURL string
+	location   *time.Location
+	logger     *slog.Logger
+	wg         sync.WaitGroup
+}
+
+func NewService(subscribers SubscriberRepository, sender Sender, bookings BookingSource, bookingURL string, loc *time.Location) *Service {
+	return &Service{
+		subscribers: subscribers,
+		sender:      sender,
+		bookings:    bookings,
+		bookingURL:  strings.TrimSuffix(bookingURL, "/"),
+		location:    loc,
+		logger:      slog.Default().With("component", "email"),
+	}
+}
+
+// Publish implements booking.EventPublisher. Emails are sent in the
+// background so booking operations never wait on the SMTP server.
+func (s *Service) Publish(ctx context.Context, event bk.Event) {
+	var kind emailKind
+
+	switch event.Type {
+	case bk.EventBookingAccepted:
+		kind = emailKind{subject: "Réservation acceptée", template: confirmationTemplate}
+	case bk.EventBookingRefused:
+		kind = emailKind{subject: "Réservation refusée", template: refusalTemplate}
+	default:
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.send(ctx, event.Booking, kind); err != nil {
+			s.logger.Error("failed to send booking emails", "bookingId", event.Booking.ID, "event", event.Type, "err", err)
+		}
+	}()
+}
+
+// SendReminders emails the subscribers taking part in the bookings of the
+// day with reminders enabled.
+func (s *Service) SendReminders(ctx context.Context) error {
+	bookings, err := s.bookings.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	today := time.Now().In(s.location).Format(time.DateOnly)
+	failed := 0
+
+	for _, booking := range bookings {
+		if !booking.ReminderEnabled || booking.DateTime.In(s.location).Format(time.DateOnly) != today {
+			continue
+		}
+
+		if err := s.send(ctx, booking, emailKind{subject: "Rappel", template: reminderTemplate}); err != nil {
+			s.logger.Error("failed to send reminder emails", "bookingId", booking.ID, "err", err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("failed to send the reminder emails of %d bookings", failed)
+	}
+
+	return nil
+}
+
+// Wait blocks until all the emails being sent are.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown waits for the emails being sent until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for emails: %w", ctx.Err())
+	}
+}
+
+type emailKind struct {
+	subject  string
+	template *template.Template
+}
+
+// send emails the organizer and the players of the booking who opted in.
+func (s *Service) send(ctx context.Context, booking bk.Booking, kind emailKind) error {
+	subscribers, err := s.subscribers.GetSubscribers(ctx, preference.Participants(booking))
+
+	if err != nil {
+		return err
+	}
+
+	dateTime := booking.DateTime.In(s.location)
+	data := templateData{
+		Subject: fmt.Sprintf("%s : %s", kind.subject, booking.Game),
+		Game:    booking.Game,
+		Date:    dateTime.Format("02/01/2006 à 15:04"),
+		Time:    dateTime.Format("15:04"),
+		Players: strings.Join(booking.Players, ", "),
+		URL:     s.bookingURL + "/" + booking.ID,
+	}
+
+	var errs []error
+
+	for _, subscriber := range subscribers {
+		if !subscriber.EmailSubscribed() {
+			continue
+		}
+
+		data.Username = subscriber.Username
+		html, err := render(kind.template, data)
+
+		if err != nil {
+			return err
+		}
+
+		if err := s.sender.Send(ctx, Message{To: subscriber.Email, Subject: data.Subject, HTML: html}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,148 @@
+package email_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/email"
+	email_mocks "github.com/hanksha/tbz-booking-system-backend/email/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []email.Message
+	err      error
+}
+
+func (s *recordingSender) Send(ctx context.Context, message email.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+type bookingSource []bk.Booking
+
+func (s bookingSource) GetActiveBookings(ctx context.Context) ([]bk.Booking, error) {
+	return s, nil
+}
+
+func TestPublish(t *testing.T) {
+	booking := bk.Booking{
+		ID:       "42",
+		Username: "Alice",
+		Game:     "Kill Team",
+		Players:  []string{"bob", "carol"},
+		DateTime: time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("emails the subscribers of an accepted booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := email_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{}
+		svc := email.NewService(repo, sender, bookingSource{}, "https://tbz.example/bookings/", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), []string{"alice", "bob", "carol"}).Return([]preference.Preferences{
+			{Username: "alice", Email: "alice@example.com", EmailNotifications: true},
+			{Username: "carol", Email: "carol@example.com", EmailNotifications: true},
+		}, nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted, Booking: booking})
+		svc.Wait()
+
+		require.Len(t, sender.messages, 2)
+		require.Equal(t, "alice@example.com", sender.messages[0].To)
+		require.Equal(t, "Réservation acceptée : Kill Team", sender.messages[0].Subject)
+		require.Contains(t, sender.messages[0].HTML, "Bonjour alice,")
+		require.Contains(t, sender.messages[0].HTML, "06/03/2026 à 19:00")
+		require.Contains(t, sender.messages[0].HTML, `href="https://tbz.example/bookings/42"`)
+		require.Contains(t, sender.messages[1].HTML, "Bonjour carol,")
+	})
+
+	t.Run("emails a refusal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := email_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{}
+		svc := email.NewService(repo, sender, bookingSource{}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), gomock.Any()).Return([]preference.Preferences{
+			{Username: "alice", Email: "alice@example.com", EmailNotifications: true},
+		}, nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingRefused, Booking: booking})
+		svc.Wait()
+
+		require.Len(t, sender.messages, 1)
+		require.Equal(t, "Réservation refusée : Kill Team", sender.messages[0].Subject)
+		require.Contains(t, sender.messages[0].HTML, "a été refusée")
+	})
+
+	t.Run("ignores the other events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := email_mocks.NewMockSubscriberRepository(ctrl)
+		svc := email.NewService(repo, &recordingSender{}, bookingSource{}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingCanceled, Booking: booking})
+		svc.Wait()
+	})
+}
+
+func TestSendReminders(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 20, 0, 0, 0, time.UTC)
+
+	t.Run("emails the subscribers of the bookings of the day", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := email_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{}
+		svc := email.NewService(repo, sender, bookingSource{
+			{ID: "1", Username: "alice", Game: "Kill Team", DateTime: today, ReminderEnabled: true},
+			{ID: "2", Username: "alice", Game: "Necromunda", DateTime: today, ReminderEnabled: false},
+			{ID: "3", Username: "alice", Game: "Blood Bowl", DateTime: today.AddDate(0, 0, 1), ReminderEnabled: true},
+		}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), []string{"alice"}).Return([]preference.Preferences{
+			{Username: "alice", Email: "alice@example.com", EmailNotifications: true},
+		}, nil).Times(1)
+
+		require.Nil(t, svc.SendReminders(context.Background()))
+
+		require.Len(t, sender.messages, 1)
+		require.Equal(t, "Rappel : Kill Team", sender.messages[0].Subject)
+		require.Contains(t, sender.messages[0].HTML, "aujourd'hui à 20:00")
+	})
+
+	t.Run("sending failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := email_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{err: errors.New("connection refused")}
+		svc := email.NewService(repo, sender, bookingSource{
+			{ID: "1", Username: "alice", Game: "Kill Team", DateTime: today, ReminderEnabled: true},
+		}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), gomock.Any()).Return([]preference.Preferences{
+			{Username: "alice", Email: "alice@example.com", EmailNotifications: true},
+		}, nil).Times(1)
+
+		require.Error(t, svc.SendReminders(context.Background()))
+	})
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/email (interfaces: SubscriberRepository)
+//
+// Generated by this command:
+//
+//	mockgen . SubscriberRepository
+//
+
+// Package mock_email is a generated GoMock package.
+package mock_email
+
+import (
+	context "context"
+	reflect "reflect"
+
+	preference "github.com/hanksha/tbz-booking-system-backend/preference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriberRepository is a mock of SubscriberRepository interface.
+type MockSubscriberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriberRepositoryMockRecorder is the mock recorder for MockSubscriberRepository.
+type MockSubscriberRepositoryMockRecorder struct {
+	mock *MockSubscriberRepository
+}
+
+// NewMockSubscriberRepository creates a new mock instance.
+func NewMockSubscriberRepository(ctrl *gomock.Controller) *MockSubscriberRepository {
+	mock := &MockSubscriberRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriberRepository) EXPECT() *MockSubscriberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSubscribers mocks base method.
+func (m *MockSubscriberRepository) GetSubscribers(ctx context.Context, usernames []string) ([]preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribers", ctx, usernames)
+	ret0, _ := ret[0].([]preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribers indicates an expected call of GetSubscribers.
+func (mr *MockSubscriberRepositoryMockRecorder) GetSubscribers(ctx, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribers", reflect.TypeOf((*MockSubscriberRepository)(nil).GetSubscribers), ctx, usernames)
+}
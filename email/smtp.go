@@ -0,0 +1,86 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender of the emails, such as 'TBZ <noreply@example.com>'
+	From string
+}
+
+// SMTPSender sends the emails through an SMTP server, upgrading the
+// connection with STARTTLS when the server offers it.
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPSender(cfg SMTPConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message Message) error {
+	from, err := mail.ParseAddress(s.cfg.From)
+
+	if err != nil {
+		return fmt.Errorf("invalid sender address '%v': %w", s.cfg.From, err)
+	}
+
+	body, err := encode(from, message)
+
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+
+	if len(s.cfg.Username) != 0 {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+
+	if err := smtp.SendMail(addr, auth, from.Address, []string{message.To}, body); err != nil {
+		return fmt.Errorf("failed to send email to '%v': %w", message.To, err)
+	}
+
+	return nil
+}
+
+// encode writes the message as a quoted-printable HTML email.
+func encode(from *mail.Address, message Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+
+	if _, err := w.Write([]byte(message.HTML)); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,42 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+var (
+	confirmationTemplate = parse("confirmation.html")
+	refusalTemplate      = parse("refusal.html")
+	reminderTemplate     = parse("reminder.html")
+)
+
+// templateData is what the templates of the booking emails show.
+type templateData struct {
+	Subject  string
+	Username string
+	Game     string
+	Date     string
+	Time     string
+	Players  string
+	URL      string
+}
+
+func parse(name string) *template.Template {
+	return template.Must(template.ParseFS(templateFiles, "templates/layout.html", "templates/"+name))
+}
+
+func render(tmpl *template.Template, data templateData) (string, error) {
+	var buf bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&buf, "layout.html", data); err != nil {
+		return "", fmt.Errorf("failed to render email: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,20 @@
+package equipment
+
+import "time"
+
+// Item is a kind of gear the club lends, such as a terrain set or a mat,
+// Quantity being how many the club owns.
+type Item struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" binding:"required,max=100"`
+	Quantity  int       `json:"quantity" binding:"gte=0"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Allocation is an item with the quantity reserved by the bookings holding a
+// table at a given time, over-allocated when more than the club owns.
+type Allocation struct {
+	Item
+	Reserved      int  `json:"reserved"`
+	OverAllocated bool `json:"overAllocated"`
+}
@@ -0,0 +1,9 @@
+package equipment
+
+import "errors"
+
+var ErrItemNotFound = errors.New("equipment item not found")
+
+var ErrInvalidItem = errors.New("invalid equipment item")
+
+var ErrDuplicateItem = errors.New("equipment item already exists")
@@ -0,0 +1,143 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const itemColumns = `id::text, name, quantity, "createdAt"`
+
+func (r *Repository) GetItems(ctx context.Context) ([]Item, error) {
+	sql := `
+		SELECT ` + itemColumns + `
+		FROM "game-table-booking".equipment
+		ORDER BY name;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch equipment: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Item])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning equipment rows: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *Repository) InsertItem(ctx context.Context, item Item) (Item, error) {
+	sql := `
+		INSERT INTO "game-table-booking".equipment(name, quantity)
+		VALUES ($1, $2)
+		RETURNING id::text, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, item.Name, item.Quantity).Scan(&item.ID, &item.CreatedAt)
+
+	if isUniqueViolation(err) {
+		return Item{}, fmt.Errorf("%w: '%v'", ErrDuplicateItem, item.Name)
+	}
+
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to insert equipment item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *Repository) UpdateItem(ctx context.Context, item Item) (Item, error) {
+	sql := `
+		UPDATE "game-table-booking".equipment
+		SET name=$1, quantity=$2
+		WHERE id=$3
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, item.Name, item.Quantity, item.ID).Scan(&item.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Item{}, ErrItemNotFound
+	}
+
+	if isUniqueViolation(err) {
+		return Item{}, fmt.Errorf("%w: '%v'", ErrDuplicateItem, item.Name)
+	}
+
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to update equipment item '%v': %w", item.ID, err)
+	}
+
+	return item, nil
+}
+
+// DeleteItem removes the item along with its reservations.
+func (r *Repository) DeleteItem(ctx context.Context, id string) error {
+	sql := `DELETE FROM "game-table-booking".equipment WHERE id=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete equipment item '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrItemNotFound
+	}
+
+	return nil
+}
+
+// GetAllocations returns every item with the quantity reserved by the
+// pending and accepted bookings holding a table at the given time.
+func (r *Repository) GetAllocations(ctx context.Context, at time.Time) ([]Allocation, error) {
+	sql := `
+		SELECT e.id::text, e.name, e.quantity, e."createdAt",
+			COALESCE(SUM(be.quantity) FILTER (WHERE b.id IS NOT NULL), 0) AS reserved,
+			false
+		FROM "game-table-booking".equipment e
+		LEFT JOIN "game-table-booking".booking_equipment be ON be."equipmentId" = e.id
+		LEFT JOIN "game-table-booking".booking b ON b.id = be."bookingId"
+			AND b.status IN ('pending', 'accepted')
+			AND b."deletedAt" IS NULL
+			AND b."dateTime" > $1 AND b."dateTime" <= $2
+		GROUP BY e.id
+		ORDER BY e.name;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, at.Add(-bk.TableDuration), at)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch equipment allocations: %w", err)
+	}
+
+	allocations, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Allocation])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning equipment allocation rows: %w", err)
+	}
+
+	return allocations, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
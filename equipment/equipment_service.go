@@ -0,0 +1,117 @@
+package equipment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type EquipmentRepository interface {
+	GetItems(ctx context.Context) ([]Item, error)
+	InsertItem(ctx context.Context, item Item) (Item, error)
+	UpdateItem(ctx context.Context, item Item) (Item, error)
+	DeleteItem(ctx context.Context, id string) error
+	GetAllocations(ctx context.Context, at time.Time) ([]Allocation, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  EquipmentRepository
+	audit AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo EquipmentRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetItems(ctx context.Context) ([]Item, error) {
+	return s.repo.GetItems(ctx)
+}
+
+func (s *Service) CreateItem(ctx context.Context, item Item) (Item, error) {
+	if err := normalize(&item); err != nil {
+		return Item{}, err
+	}
+
+	inserted, err := s.repo.InsertItem(ctx, item)
+
+	s.record(ctx, err, "equipment.create", inserted.ID, inserted)
+
+	return inserted, err
+}
+
+// UpdateItem renames the item or changes the quantity owned, lowering it
+// may leave reservations over-allocated.
+func (s *Service) UpdateItem(ctx context.Context, item Item) (Item, error) {
+	if err := normalize(&item); err != nil {
+		return Item{}, err
+	}
+
+	updated, err := s.repo.UpdateItem(ctx, item)
+
+	s.record(ctx, err, "equipment.update", item.ID, updated)
+
+	return updated, err
+}
+
+func (s *Service) DeleteItem(ctx context.Context, id string) error {
+	err := s.repo.DeleteItem(ctx, id)
+
+	s.record(ctx, err, "equipment.delete", id, nil)
+
+	return err
+}
+
+// GetAllocations returns the quantity of each item reserved at the given
+// time, flagging the items reserved beyond the quantity owned.
+func (s *Service) GetAllocations(ctx context.Context, at time.Time) ([]Allocation, error) {
+	allocations, err := s.repo.GetAllocations(ctx, at)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allocations {
+		allocations[i].OverAllocated = allocations[i].Reserved > allocations[i].Quantity
+	}
+
+	return allocations, nil
+}
+
+func normalize(item *Item) error {
+	item.Name = strings.TrimSpace(item.Name)
+
+	if len(item.Name) == 0 {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidItem)
+	}
+
+	if item.Quantity < 0 {
+		return fmt.Errorf("%w: quantity cannot be negative", ErrInvalidItem)
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, err error, action, itemID string, payload any) {
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, action, "equipment", itemID, payload)
+	}
+}
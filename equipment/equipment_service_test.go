@@ -0,0 +1,64 @@
+package equipment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/equipment"
+	equipment_mocks "github.com/hanksha/tbz-booking-system-backend/equipment/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateItem(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := equipment_mocks.NewMockEquipmentRepository(ctrl)
+		svc := equipment.NewService(repo)
+
+		repo.EXPECT().InsertItem(gomock.Any(), equipment.Item{Name: "Tapis", Quantity: 4}).
+			Return(equipment.Item{ID: "1", Name: "Tapis", Quantity: 4}, nil).Times(1)
+
+		item, err := svc.CreateItem(context.Background(), equipment.Item{Name: "  Tapis ", Quantity: 4})
+
+		require.Nil(t, err)
+		require.Equal(t, "1", item.ID)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := equipment_mocks.NewMockEquipmentRepository(ctrl)
+		svc := equipment.NewService(repo)
+
+		repo.EXPECT().InsertItem(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateItem(context.Background(), equipment.Item{Name: "  ", Quantity: 4})
+
+		require.ErrorIs(t, err, equipment.ErrInvalidItem)
+	})
+}
+
+func TestGetAllocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := equipment_mocks.NewMockEquipmentRepository(ctrl)
+	svc := equipment.NewService(repo)
+	at := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().GetAllocations(gomock.Any(), at).Return([]equipment.Allocation{
+		{Item: equipment.Item{ID: "1", Name: "Tapis", Quantity: 4}, Reserved: 2},
+		{Item: equipment.Item{ID: "2", Name: "Terrain ruines", Quantity: 1}, Reserved: 2},
+	}, nil).Times(1)
+
+	allocations, err := svc.GetAllocations(context.Background(), at)
+
+	require.Nil(t, err)
+	require.False(t, allocations[0].OverAllocated)
+	require.True(t, allocations[1].OverAllocated)
+}
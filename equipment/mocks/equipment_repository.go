@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/equipment (interfaces: EquipmentRepository)
+//
+// Generated by this command:
+//
+//	mockgen . EquipmentRepository
+//
+
+// Package mock_equipment is a generated GoMock package.
+package mock_equipment
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	equipment "github.com/hanksha/tbz-booking-system-backend/equipment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEquipmentRepository is a mock of EquipmentRepository interface.
+type MockEquipmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEquipmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEquipmentRepositoryMockRecorder is the mock recorder for MockEquipmentRepository.
+type MockEquipmentRepositoryMockRecorder struct {
+	mock *MockEquipmentRepository
+}
+
+// NewMockEquipmentRepository creates a new mock instance.
+func NewMockEquipmentRepository(ctrl *gomock.Controller) *MockEquipmentRepository {
+	mock := &MockEquipmentRepository{ctrl: ctrl}
+	mock.recorder = &MockEquipmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEquipmentRepository) EXPECT() *MockEquipmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteItem mocks base method.
+func (m *MockEquipmentRepository) DeleteItem(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockEquipmentRepositoryMockRecorder) DeleteItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockEquipmentRepository)(nil).DeleteItem), ctx, id)
+}
+
+// GetAllocations mocks base method.
+func (m *MockEquipmentRepository) GetAllocations(ctx context.Context, at time.Time) ([]equipment.Allocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllocations", ctx, at)
+	ret0, _ := ret[0].([]equipment.Allocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllocations indicates an expected call of GetAllocations.
+func (mr *MockEquipmentRepositoryMockRecorder) GetAllocations(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocations", reflect.TypeOf((*MockEquipmentRepository)(nil).GetAllocations), ctx, at)
+}
+
+// GetItems mocks base method.
+func (m *MockEquipmentRepository) GetItems(ctx context.Context) ([]equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx)
+	ret0, _ := ret[0].([]equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockEquipmentRepositoryMockRecorder) GetItems(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockEquipmentRepository)(nil).GetItems), ctx)
+}
+
+// InsertItem mocks base method.
+func (m *MockEquipmentRepository) InsertItem(ctx context.Context, item equipment.Item) (equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertItem", ctx, item)
+	ret0, _ := ret[0].(equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertItem indicates an expected call of InsertItem.
+func (mr *MockEquipmentRepositoryMockRecorder) InsertItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertItem", reflect.TypeOf((*MockEquipmentRepository)(nil).InsertItem), ctx, item)
+}
+
+// UpdateItem mocks base method.
+func (m *MockEquipmentRepository) UpdateItem(ctx context.Context, item equipment.Item) (equipment.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret0, _ := ret[0].(equipment.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockEquipmentRepositoryMockRecorder) UpdateItem(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockEquipmentRepository)(nil).UpdateItem), ctx, item)
+}
@@ -0,0 +1,83 @@
+package event
+
+import "time"
+
+// Event is a multi-table game day: its participants play Rounds rounds, two
+// per table, with the points of the event.
+type Event struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name" binding:"required"`
+	Game      string    `json:"game" binding:"required"`
+	Points    int       `json:"points"`
+	DateTime  time.Time `json:"dateTime"`
+	Rounds    int       `json:"rounds" binding:"gte=1"`
+	Capacity  int       `json:"capacity" binding:"gte=2"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Participant is a member signed up to an event, BookingID is the booking
+// created for them.
+type Participant struct {
+	UserID     string    `json:"userId"`
+	Username   string    `json:"username"`
+	BookingID  string    `json:"bookingId,omitempty"`
+	SignedUpAt time.Time `json:"signedUpAt"`
+}
+
+// Pairing seats players at a table for a round, a player alone has a bye.
+type Pairing struct {
+	Round   int      `json:"round"`
+	Table   int      `json:"table,omitempty"`
+	Players []string `json:"players"`
+	Bye     bool     `json:"bye,omitempty"`
+}
+
+// Detail is an event with its participants, in sign-up order, and the
+// pairings of its rounds.
+type Detail struct {
+	Event
+	Participants []Participant `json:"participants"`
+	Pairings     []Pairing     `json:"pairings"`
+}
+
+// Pair seats the players round by round so that they meet a new opponent
+// every round, with the circle method. Past len(players)-1 rounds the
+// pairings start over.
+func Pair(players []string, rounds int) []Pairing {
+	if len(players) < 2 {
+		return []Pairing{}
+	}
+
+	seats := append([]string{}, players...)
+
+	if len(seats)%2 == 1 {
+		// the player facing the empty seat has a bye
+		seats = append(seats, "")
+	}
+
+	n := len(seats)
+	pairings := make([]Pairing, 0, rounds*n/2)
+
+	for round := 1; round <= rounds; round++ {
+		table := 1
+
+		for i := range n / 2 {
+			a, b := seats[i], seats[n-1-i]
+
+			switch {
+			case len(a) == 0:
+				pairings = append(pairings, Pairing{Round: round, Players: []string{b}, Bye: true})
+			case len(b) == 0:
+				pairings = append(pairings, Pairing{Round: round, Players: []string{a}, Bye: true})
+			default:
+				pairings = append(pairings, Pairing{Round: round, Table: table, Players: []string{a, b}})
+				table++
+			}
+		}
+
+		// the first seat stays, the others rotate
+		seats = append([]string{seats[0], seats[n-1]}, seats[1:n-1]...)
+	}
+
+	return pairings
+}
@@ -0,0 +1,13 @@
+package event
+
+import "errors"
+
+var ErrEventNotFound = errors.New("event not found")
+
+var ErrInvalidEvent = errors.New("invalid event")
+
+var ErrEventFull = errors.New("event is full")
+
+var ErrEventClosed = errors.New("event sign-ups are closed")
+
+var ErrAlreadySignedUp = errors.New("already signed up to the event")
@@ -0,0 +1,203 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const eventColumns = `id::text, name, game, points, "dateTime", rounds, capacity, "createdAt"`
+
+func (r *Repository) GetUpcomingEvents(ctx context.Context, from time.Time) ([]Event, error) {
+	sql := `
+		SELECT ` + eventColumns + `
+		FROM "game-table-booking".event
+		WHERE "dateTime" >= $1
+		ORDER BY "dateTime";
+	`
+
+	rows, err := r.conn.Query(ctx, sql, from)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Event])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *Repository) GetEvent(ctx context.Context, id string) (Event, error) {
+	sql := `
+		SELECT ` + eventColumns + `
+		FROM "game-table-booking".event
+		WHERE id=$1;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, id)
+
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to fetch event '%v': %w", id, err)
+	}
+
+	event, err := pgx.CollectOneRow(rows, pgx.RowToStructByPos[Event])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Event{}, ErrEventNotFound
+	}
+
+	if err != nil {
+		return Event{}, fmt.Errorf("error scanning event row: %w", err)
+	}
+
+	return event, nil
+}
+
+func (r *Repository) InsertEvent(ctx context.Context, event Event) (Event, error) {
+	sql := `
+		INSERT INTO "game-table-booking".event(name, game, points, "dateTime", rounds, capacity)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id::text, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		event.Name,
+		event.Game,
+		event.Points,
+		event.DateTime,
+		event.Rounds,
+		event.Capacity,
+	).Scan(&event.ID, &event.CreatedAt)
+
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to insert event: %w", err)
+	}
+
+	return event, nil
+}
+
+func (r *Repository) DeleteEvent(ctx context.Context, id string) error {
+	sql := `DELETE FROM "game-table-booking".event WHERE id=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete event '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrEventNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) GetParticipants(ctx context.Context, eventID string) ([]Participant, error) {
+	sql := `
+		SELECT "userId", username, COALESCE("bookingId"::text, ''), "signedUpAt"
+		FROM "game-table-booking".event_participant
+		WHERE "eventId"=$1
+		ORDER BY "signedUpAt", "userId";
+	`
+
+	rows, err := r.conn.Query(ctx, sql, eventID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch participants of event '%v': %w", eventID, err)
+	}
+
+	participants, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Participant])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning participant rows: %w", err)
+	}
+
+	return participants, nil
+}
+
+// AddParticipant signs a member up, the event row is locked so concurrent
+// sign-ups cannot exceed its capacity.
+func (r *Repository) AddParticipant(ctx context.Context, eventID string, participant Participant) (Participant, error) {
+	err := pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		var capacity, count int
+		var signedUp bool
+
+		err := tx.QueryRow(ctx, `
+			SELECT e.capacity,
+				(SELECT count(*) FROM "game-table-booking".event_participant p WHERE p."eventId"=e.id),
+				EXISTS (SELECT 1 FROM "game-table-booking".event_participant p WHERE p."eventId"=e.id AND p."userId"=$2)
+			FROM "game-table-booking".event e
+			WHERE e.id=$1
+			FOR UPDATE;
+		`, eventID, participant.UserID).Scan(&capacity, &count, &signedUp)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEventNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if signedUp {
+			return ErrAlreadySignedUp
+		}
+
+		if count >= capacity {
+			return ErrEventFull
+		}
+
+		return tx.QueryRow(ctx, `
+			INSERT INTO "game-table-booking".event_participant("eventId", "userId", username)
+			VALUES ($1, $2, $3)
+			RETURNING "signedUpAt";
+		`, eventID, participant.UserID, participant.Username).Scan(&participant.SignedUpAt)
+	})
+
+	if errors.Is(err, ErrEventNotFound) || errors.Is(err, ErrAlreadySignedUp) || errors.Is(err, ErrEventFull) {
+		return Participant{}, err
+	}
+
+	if err != nil {
+		return Participant{}, fmt.Errorf("failed to sign up to event '%v': %w", eventID, err)
+	}
+
+	return participant, nil
+}
+
+func (r *Repository) SetParticipantBooking(ctx context.Context, eventID, userID, bookingID string) error {
+	sql := `
+		UPDATE "game-table-booking".event_participant SET "bookingId"=$3
+		WHERE "eventId"=$1 AND "userId"=$2;
+	`
+
+	if _, err := r.conn.Exec(ctx, sql, eventID, userID, bookingID); err != nil {
+		return fmt.Errorf("failed to link the booking of '%v' to event '%v': %w", userID, eventID, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveParticipant(ctx context.Context, eventID, userID string) error {
+	sql := `DELETE FROM "game-table-booking".event_participant WHERE "eventId"=$1 AND "userId"=$2;`
+
+	if _, err := r.conn.Exec(ctx, sql, eventID, userID); err != nil {
+		return fmt.Errorf("failed to remove '%v' from event '%v': %w", userID, eventID, err)
+	}
+
+	return nil
+}
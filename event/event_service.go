@@ -0,0 +1,166 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type EventRepository interface {
+	GetUpcomingEvents(ctx context.Context, from time.Time) ([]Event, error)
+	GetEvent(ctx context.Context, id string) (Event, error)
+	InsertEvent(ctx context.Context, event Event) (Event, error)
+	DeleteEvent(ctx context.Context, id string) error
+	GetParticipants(ctx context.Context, eventID string) ([]Participant, error)
+	AddParticipant(ctx context.Context, eventID string, participant Participant) (Participant, error)
+	SetParticipantBooking(ctx context.Context, eventID, userID, bookingID string) error
+	RemoveParticipant(ctx context.Context, eventID, userID string) error
+}
+
+// BookingCreator books the table of a participant, it is the booking
+// service.
+type BookingCreator interface {
+	CreateBooking(ctx context.Context, booking bk.Booking) (bk.Booking, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo     EventRepository
+	bookings BookingCreator
+	audit    AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo EventRepository, bookings BookingCreator, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, bookings: bookings}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetUpcomingEvents(ctx context.Context) ([]Event, error) {
+	return s.repo.GetUpcomingEvents(ctx, time.Now())
+}
+
+// GetEvent returns the event with its participants and their pairings.
+func (s *Service) GetEvent(ctx context.Context, id string) (Detail, error) {
+	event, err := s.repo.GetEvent(ctx, id)
+
+	if err != nil {
+		return Detail{}, err
+	}
+
+	participants, err := s.repo.GetParticipants(ctx, id)
+
+	if err != nil {
+		return Detail{}, err
+	}
+
+	players := make([]string, 0, len(participants))
+
+	for _, participant := range participants {
+		players = append(players, participant.Username)
+	}
+
+	return Detail{Event: event, Participants: participants, Pairings: Pair(players, event.Rounds)}, nil
+}
+
+func (s *Service) CreateEvent(ctx context.Context, event Event) (Event, error) {
+	event.Name = strings.TrimSpace(event.Name)
+	event.Game = strings.TrimSpace(event.Game)
+
+	if len(event.Name) == 0 || len(event.Game) == 0 {
+		return Event{}, fmt.Errorf("%w: name and game cannot be empty", ErrInvalidEvent)
+	}
+
+	if event.Rounds < 1 || event.Capacity < 2 {
+		return Event{}, fmt.Errorf("%w: an event has at least 1 round and 2 participants", ErrInvalidEvent)
+	}
+
+	if !event.DateTime.After(time.Now()) {
+		return Event{}, fmt.Errorf("%w: the event must be in the future", ErrInvalidEvent)
+	}
+
+	inserted, err := s.repo.InsertEvent(ctx, event)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "event.create", "event", inserted.ID, inserted)
+	}
+
+	return inserted, err
+}
+
+func (s *Service) DeleteEvent(ctx context.Context, id string) error {
+	err := s.repo.DeleteEvent(ctx, id)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "event.delete", "event", id, nil)
+	}
+
+	return err
+}
+
+// SignUp registers the user to the event and books their table. The seat is
+// taken first so a full event creates no booking, and given back when the
+// booking fails.
+func (s *Service) SignUp(ctx context.Context, id string, user discord.DiscordUser) (Participant, error) {
+	event, err := s.repo.GetEvent(ctx, id)
+
+	if err != nil {
+		return Participant{}, err
+	}
+
+	if !event.DateTime.After(time.Now()) {
+		return Participant{}, ErrEventClosed
+	}
+
+	participant, err := s.repo.AddParticipant(ctx, id, Participant{UserID: user.ID, Username: user.Username})
+
+	if err != nil {
+		return Participant{}, err
+	}
+
+	booking, err := s.bookings.CreateBooking(ctx, bk.Booking{
+		Game:            event.Game,
+		UserID:          user.ID,
+		Username:        user.Username,
+		Points:          event.Points,
+		Description:     "Événement : " + event.Name,
+		ReminderEnabled: true,
+		DateTime:        event.DateTime,
+		Players:         []string{user.Username},
+	})
+
+	if err != nil {
+		if removeErr := s.repo.RemoveParticipant(ctx, id, user.ID); removeErr != nil {
+			slog.Error("failed to give back the seat of a failed sign-up", "eventId", id, "userId", user.ID, "err", removeErr)
+		}
+		return Participant{}, err
+	}
+
+	if err := s.repo.SetParticipantBooking(ctx, id, user.ID, booking.ID); err != nil {
+		return Participant{}, err
+	}
+
+	participant.BookingID = booking.ID
+
+	return participant, nil
+}
@@ -0,0 +1,153 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/event"
+	event_mocks "github.com/hanksha/tbz-booking-system-backend/event/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeBookings struct {
+	created []bk.Booking
+	err     error
+}
+
+func (f *fakeBookings) CreateBooking(ctx context.Context, booking bk.Booking) (bk.Booking, error) {
+	if f.err != nil {
+		return bk.Booking{}, f.err
+	}
+
+	booking.ID = "42"
+	f.created = append(f.created, booking)
+
+	return booking, nil
+}
+
+func TestPair(t *testing.T) {
+	t.Run("everyone meets a new opponent", func(t *testing.T) {
+		pairings := event.Pair([]string{"a", "b", "c", "d"}, 3)
+
+		require.Equal(t, []event.Pairing{
+			{Round: 1, Table: 1, Players: []string{"a", "d"}},
+			{Round: 1, Table: 2, Players: []string{"b", "c"}},
+			{Round: 2, Table: 1, Players: []string{"a", "c"}},
+			{Round: 2, Table: 2, Players: []string{"d", "b"}},
+			{Round: 3, Table: 1, Players: []string{"a", "b"}},
+			{Round: 3, Table: 2, Players: []string{"c", "d"}},
+		}, pairings)
+	})
+
+	t.Run("odd count gives a bye", func(t *testing.T) {
+		pairings := event.Pair([]string{"a", "b", "c"}, 1)
+
+		require.Equal(t, []event.Pairing{
+			{Round: 1, Players: []string{"a"}, Bye: true},
+			{Round: 1, Table: 1, Players: []string{"b", "c"}},
+		}, pairings)
+	})
+
+	t.Run("not enough players", func(t *testing.T) {
+		require.Empty(t, event.Pair([]string{"a"}, 2))
+	})
+}
+
+func TestSignUp(t *testing.T) {
+	user := discord.DiscordUser{ID: "1", Username: "alice"}
+	upcoming := event.Event{ID: "7", Name: "Tournoi", Game: "Star Wars", Points: 500, DateTime: time.Now().Add(48 * time.Hour), Rounds: 3, Capacity: 8}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := event_mocks.NewMockEventRepository(ctrl)
+		bookings := &fakeBookings{}
+		svc := event.NewService(repo, bookings)
+
+		repo.EXPECT().GetEvent(gomock.Any(), "7").Return(upcoming, nil).Times(1)
+		repo.EXPECT().AddParticipant(gomock.Any(), "7", event.Participant{UserID: "1", Username: "alice"}).Return(event.Participant{UserID: "1", Username: "alice"}, nil).Times(1)
+		repo.EXPECT().SetParticipantBooking(gomock.Any(), "7", "1", "42").Return(nil).Times(1)
+
+		participant, err := svc.SignUp(context.Background(), "7", user)
+
+		require.Nil(t, err)
+		require.Equal(t, "42", participant.BookingID)
+		require.Len(t, bookings.created, 1)
+		assert.Equal(t, "Star Wars", bookings.created[0].Game)
+		assert.Equal(t, 500, bookings.created[0].Points)
+		assert.Equal(t, upcoming.DateTime, bookings.created[0].DateTime)
+		assert.Equal(t, []string{"alice"}, bookings.created[0].Players)
+	})
+
+	t.Run("full", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := event_mocks.NewMockEventRepository(ctrl)
+		bookings := &fakeBookings{}
+		svc := event.NewService(repo, bookings)
+
+		repo.EXPECT().GetEvent(gomock.Any(), "7").Return(upcoming, nil).Times(1)
+		repo.EXPECT().AddParticipant(gomock.Any(), "7", gomock.Any()).Return(event.Participant{}, event.ErrEventFull).Times(1)
+
+		_, err := svc.SignUp(context.Background(), "7", user)
+
+		require.ErrorIs(t, err, event.ErrEventFull)
+		require.Empty(t, bookings.created)
+	})
+
+	t.Run("booking failed gives the seat back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := event_mocks.NewMockEventRepository(ctrl)
+		svc := event.NewService(repo, &fakeBookings{err: bk.ErrUserSuspended})
+
+		repo.EXPECT().GetEvent(gomock.Any(), "7").Return(upcoming, nil).Times(1)
+		repo.EXPECT().AddParticipant(gomock.Any(), "7", gomock.Any()).Return(event.Participant{UserID: "1", Username: "alice"}, nil).Times(1)
+		repo.EXPECT().RemoveParticipant(gomock.Any(), "7", "1").Return(nil).Times(1)
+
+		_, err := svc.SignUp(context.Background(), "7", user)
+
+		require.ErrorIs(t, err, bk.ErrUserSuspended)
+	})
+
+	t.Run("past event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := event_mocks.NewMockEventRepository(ctrl)
+		svc := event.NewService(repo, &fakeBookings{})
+
+		past := upcoming
+		past.DateTime = time.Now().Add(-time.Hour)
+		repo.EXPECT().GetEvent(gomock.Any(), "7").Return(past, nil).Times(1)
+
+		_, err := svc.SignUp(context.Background(), "7", user)
+
+		require.ErrorIs(t, err, event.ErrEventClosed)
+	})
+}
+
+func TestGetEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := event_mocks.NewMockEventRepository(ctrl)
+	svc := event.NewService(repo, &fakeBookings{})
+
+	repo.EXPECT().GetEvent(gomock.Any(), "7").Return(event.Event{ID: "7", Rounds: 1, Capacity: 4}, nil).Times(1)
+	repo.EXPECT().GetParticipants(gomock.Any(), "7").Return([]event.Participant{{UserID: "1", Username: "alice"}, {UserID: "2", Username: "bob"}}, nil).Times(1)
+
+	detail, err := svc.GetEvent(context.Background(), "7")
+
+	require.Nil(t, err)
+	require.Len(t, detail.Participants, 2)
+	require.Equal(t, []event.Pairing{{Round: 1, Table: 1, Players: []string{"alice", "bob"}}}, detail.Pairings)
+}
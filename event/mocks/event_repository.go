@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/event (interfaces: EventRepository)
+//
+// Generated by this command:
+//
+//	mockgen . EventRepository
+//
+
+// Package mock_event is a generated GoMock package.
+package mock_event
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	event "github.com/hanksha/tbz-booking-system-backend/event"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddParticipant mocks base method.
+func (m *MockEventRepository) AddParticipant(ctx context.Context, eventID string, participant event.Participant) (event.Participant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddParticipant", ctx, eventID, participant)
+	ret0, _ := ret[0].(event.Participant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddParticipant indicates an expected call of AddParticipant.
+func (mr *MockEventRepositoryMockRecorder) AddParticipant(ctx, eventID, participant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddParticipant", reflect.TypeOf((*MockEventRepository)(nil).AddParticipant), ctx, eventID, participant)
+}
+
+// DeleteEvent mocks base method.
+func (m *MockEventRepository) DeleteEvent(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockEventRepositoryMockRecorder) DeleteEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEventRepository)(nil).DeleteEvent), ctx, id)
+}
+
+// GetEvent mocks base method.
+func (m *MockEventRepository) GetEvent(ctx context.Context, id string) (event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", ctx, id)
+	ret0, _ := ret[0].(event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockEventRepositoryMockRecorder) GetEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockEventRepository)(nil).GetEvent), ctx, id)
+}
+
+// GetParticipants mocks base method.
+func (m *MockEventRepository) GetParticipants(ctx context.Context, eventID string) ([]event.Participant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParticipants", ctx, eventID)
+	ret0, _ := ret[0].([]event.Participant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParticipants indicates an expected call of GetParticipants.
+func (mr *MockEventRepositoryMockRecorder) GetParticipants(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipants", reflect.TypeOf((*MockEventRepository)(nil).GetParticipants), ctx, eventID)
+}
+
+// GetUpcomingEvents mocks base method.
+func (m *MockEventRepository) GetUpcomingEvents(ctx context.Context, from time.Time) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingEvents", ctx, from)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingEvents indicates an expected call of GetUpcomingEvents.
+func (mr *MockEventRepositoryMockRecorder) GetUpcomingEvents(ctx, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingEvents", reflect.TypeOf((*MockEventRepository)(nil).GetUpcomingEvents), ctx, from)
+}
+
+// InsertEvent mocks base method.
+func (m *MockEventRepository) InsertEvent(ctx context.Context, arg1 event.Event) (event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEvent", ctx, arg1)
+	ret0, _ := ret[0].(event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertEvent indicates an expected call of InsertEvent.
+func (mr *MockEventRepositoryMockRecorder) InsertEvent(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvent", reflect.TypeOf((*MockEventRepository)(nil).InsertEvent), ctx, arg1)
+}
+
+// RemoveParticipant mocks base method.
+func (m *MockEventRepository) RemoveParticipant(ctx context.Context, eventID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveParticipant", ctx, eventID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveParticipant indicates an expected call of RemoveParticipant.
+func (mr *MockEventRepositoryMockRecorder) RemoveParticipant(ctx, eventID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveParticipant", reflect.TypeOf((*MockEventRepository)(nil).RemoveParticipant), ctx, eventID, userID)
+}
+
+// SetParticipantBooking mocks base method.
+func (m *MockEventRepository) SetParticipantBooking(ctx context.Context, eventID, userID, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParticipantBooking", ctx, eventID, userID, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParticipantBooking indicates an expected call of SetParticipantBooking.
+func (mr *MockEventRepositoryMockRecorder) SetParticipantBooking(ctx, eventID, userID, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParticipantBooking", reflect.TypeOf((*MockEventRepository)(nil).SetParticipantBooking), ctx, eventID, userID, bookingID)
+}
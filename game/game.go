@@ -0,0 +1,118 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Types of the custom fields.
+const (
+	FieldText    = "text"
+	FieldNumber  = "number"
+	FieldBoolean = "boolean"
+	FieldChoice  = "choice"
+)
+
+// maxTextLength bounds the value of a text field.
+const maxTextLength = 200
+
+// Field is a custom field the bookings of a game fill in, such as the
+// mission or the map size.
+type Field struct {
+	Name     string `json:"name" binding:"required"`
+	Label    string `json:"label"`
+	Type     string `json:"type" binding:"required,oneof=text number boolean choice"`
+	Required bool   `json:"required"`
+	// Options are the values a choice field allows.
+	Options []string `json:"options,omitempty"`
+}
+
+// Game is an entry of the game catalog, with the points a booking of the game
+// may cost. Zero bounds leave the points unchecked.
+type Game struct {
+	Name       string `json:"name" binding:"required"`
+	MinPoints  int    `json:"minPoints"`
+	MaxPoints  int    `json:"maxPoints"`
+	PointsStep int    `json:"pointsStep"`
+	// Fields are the custom fields of the bookings of the game, none are
+	// allowed when empty.
+	Fields    []Field   `json:"fields,omitempty" binding:"omitempty,dive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CheckPoints describes why points are not allowed for the game, empty when
+// they are.
+func (g Game) CheckPoints(points int) string {
+	if g.MinPoints != 0 && points < g.MinPoints {
+		return fmt.Sprintf("%v needs at least %d points", g.Name, g.MinPoints)
+	}
+
+	if g.MaxPoints != 0 && points > g.MaxPoints {
+		return fmt.Sprintf("%v allows at most %d points", g.Name, g.MaxPoints)
+	}
+
+	if g.PointsStep > 1 && (points-g.MinPoints)%g.PointsStep != 0 {
+		return fmt.Sprintf("%v points go by steps of %d from %d", g.Name, g.PointsStep, g.MinPoints)
+	}
+
+	return ""
+}
+
+// CheckFields describes why the custom fields of a booking do not match the
+// fields of the game, empty when they do.
+func (g Game) CheckFields(values map[string]any) string {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		i := slices.IndexFunc(g.Fields, func(field Field) bool { return field.Name == name })
+
+		if i < 0 {
+			return fmt.Sprintf("%v has no field '%v'", g.Name, name)
+		}
+
+		if reason := g.Fields[i].check(values[name]); len(reason) != 0 {
+			return reason
+		}
+	}
+
+	for _, field := range g.Fields {
+		if _, ok := values[field.Name]; field.Required && !ok {
+			return fmt.Sprintf("%v needs the field '%v'", g.Name, field.Name)
+		}
+	}
+
+	return ""
+}
+
+func (f Field) check(value any) string {
+	switch f.Type {
+	case FieldText:
+		text, ok := value.(string)
+
+		if !ok {
+			return fmt.Sprintf("'%v' must be a text", f.Name)
+		}
+
+		if len(text) > maxTextLength {
+			return fmt.Sprintf("'%v' is longer than %d characters", f.Name, maxTextLength)
+		}
+	case FieldNumber:
+		switch value.(type) {
+		case float64, int:
+		default:
+			return fmt.Sprintf("'%v' must be a number", f.Name)
+		}
+	case FieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("'%v' must be true or false", f.Name)
+		}
+	case FieldChoice:
+		choice, ok := value.(string)
+
+		if !ok || !slices.Contains(f.Options, choice) {
+			return fmt.Sprintf("'%v' must be one of %v", f.Name, f.Options)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,7 @@
+package game
+
+import "errors"
+
+var ErrGameNotFound = errors.New("game not found")
+
+var ErrInvalidGame = errors.New("invalid game")
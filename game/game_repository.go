@@ -0,0 +1,120 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// fieldsArg stores no fields as an empty array, the column being NOT NULL.
+func fieldsArg(fields []Field) []Field {
+	if fields == nil {
+		return []Field{}
+	}
+
+	return fields
+}
+
+func (r *Repository) GetGames(ctx context.Context) ([]Game, error) {
+	sql := `
+		SELECT name, "minPoints", "maxPoints", "pointsStep", fields, "createdAt"
+		FROM "game-table-booking".game
+		ORDER BY name;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch games: %w", err)
+	}
+
+	games, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Game])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning game rows: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetGame finds a game by name, ignoring case.
+func (r *Repository) GetGame(ctx context.Context, name string) (Game, error) {
+	sql := `
+		SELECT name, "minPoints", "maxPoints", "pointsStep", fields, "createdAt"
+		FROM "game-table-booking".game
+		WHERE lower(name)=lower($1);
+	`
+
+	var game Game
+	err := r.conn.QueryRow(ctx, sql, name).Scan(
+		&game.Name,
+		&game.MinPoints,
+		&game.MaxPoints,
+		&game.PointsStep,
+		&game.Fields,
+		&game.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Game{}, ErrGameNotFound
+	}
+
+	if err != nil {
+		return Game{}, fmt.Errorf("failed to fetch game '%v': %w", name, err)
+	}
+
+	return game, nil
+}
+
+func (r *Repository) UpsertGame(ctx context.Context, game Game) (Game, error) {
+	sql := `
+		INSERT INTO "game-table-booking".game(name, "minPoints", "maxPoints", "pointsStep", fields)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (lower(name)) DO UPDATE SET
+			name=EXCLUDED.name,
+			"minPoints"=EXCLUDED."minPoints",
+			"maxPoints"=EXCLUDED."maxPoints",
+			"pointsStep"=EXCLUDED."pointsStep",
+			fields=EXCLUDED.fields
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		game.Name,
+		game.MinPoints,
+		game.MaxPoints,
+		game.PointsStep,
+		fieldsArg(game.Fields),
+	).Scan(&game.CreatedAt)
+
+	if err != nil {
+		return Game{}, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return game, nil
+}
+
+func (r *Repository) DeleteGame(ctx context.Context, name string) error {
+	sql := `DELETE FROM "game-table-booking".game WHERE lower(name)=lower($1);`
+
+	tag, err := r.conn.Exec(ctx, sql, name)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete game '%v': %w", name, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrGameNotFound
+	}
+
+	return nil
+}
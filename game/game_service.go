@@ -0,0 +1,148 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type GameRepository interface {
+	GetGames(ctx context.Context) ([]Game, error)
+	GetGame(ctx context.Context, name string) (Game, error)
+	UpsertGame(ctx context.Context, game Game) (Game, error)
+	DeleteGame(ctx context.Context, name string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  GameRepository
+	audit AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo GameRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetGames(ctx context.Context) ([]Game, error) {
+	return s.repo.GetGames(ctx)
+}
+
+func (s *Service) SaveGame(ctx context.Context, game Game) (Game, error) {
+	game.Name = strings.TrimSpace(game.Name)
+
+	if len(game.Name) == 0 {
+		return Game{}, fmt.Errorf("%w: name cannot be empty", ErrInvalidGame)
+	}
+
+	if game.MinPoints < 0 || game.MaxPoints < 0 || game.PointsStep < 0 {
+		return Game{}, fmt.Errorf("%w: points settings cannot be negative", ErrInvalidGame)
+	}
+
+	if game.MaxPoints != 0 && game.MinPoints > game.MaxPoints {
+		return Game{}, fmt.Errorf("%w: minPoints is above maxPoints", ErrInvalidGame)
+	}
+
+	if err := validateFields(game.Fields); err != nil {
+		return Game{}, err
+	}
+
+	saved, err := s.repo.UpsertGame(ctx, game)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "game.save", "game", saved.Name, saved)
+	}
+
+	return saved, err
+}
+
+func (s *Service) DeleteGame(ctx context.Context, name string) error {
+	err := s.repo.DeleteGame(ctx, name)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "game.delete", "game", name, nil)
+	}
+
+	return err
+}
+
+// CheckPoints implements booking.PointsChecker. Games missing from the
+// catalog accept any points.
+func (s *Service) CheckPoints(ctx context.Context, name string, points int) (bool, string, error) {
+	game, err := s.repo.GetGame(ctx, name)
+
+	if errors.Is(err, ErrGameNotFound) {
+		return true, "", nil
+	}
+
+	if err != nil {
+		return false, "", err
+	}
+
+	reason := game.CheckPoints(points)
+
+	return len(reason) == 0, reason, nil
+}
+
+// CheckFields implements booking.FieldsChecker. Games missing from the
+// catalog accept any custom fields.
+func (s *Service) CheckFields(ctx context.Context, name string, fields map[string]any) (bool, string, error) {
+	game, err := s.repo.GetGame(ctx, name)
+
+	if errors.Is(err, ErrGameNotFound) {
+		return true, "", nil
+	}
+
+	if err != nil {
+		return false, "", err
+	}
+
+	reason := game.CheckFields(fields)
+
+	return len(reason) == 0, reason, nil
+}
+
+func validateFields(fields []Field) error {
+	names := map[string]bool{}
+
+	for _, field := range fields {
+		if len(strings.TrimSpace(field.Name)) == 0 {
+			return fmt.Errorf("%w: field name cannot be empty", ErrInvalidGame)
+		}
+
+		if names[field.Name] {
+			return fmt.Errorf("%w: field '%v' is defined twice", ErrInvalidGame, field.Name)
+		}
+
+		names[field.Name] = true
+
+		switch field.Type {
+		case FieldText, FieldNumber, FieldBoolean:
+		case FieldChoice:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("%w: choice field '%v' needs options", ErrInvalidGame, field.Name)
+			}
+		default:
+			return fmt.Errorf("%w: field '%v' has an unknown type '%v'", ErrInvalidGame, field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package game_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/game"
+	game_mocks "github.com/hanksha/tbz-booking-system-backend/game/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckPoints(t *testing.T) {
+	sw := game.Game{Name: "Star Wars", MinPoints: 500, MaxPoints: 2000, PointsStep: 250}
+
+	tests := []struct {
+		name   string
+		game   game.Game
+		err    error
+		points int
+		valid  bool
+		reason string
+	}{
+		{name: "within bounds", game: sw, points: 1000, valid: true},
+		{name: "below min", game: sw, points: 250, reason: "Star Wars needs at least 500 points"},
+		{name: "above max", game: sw, points: 2250, reason: "Star Wars allows at most 2000 points"},
+		{name: "off step", game: sw, points: 600, reason: "Star Wars points go by steps of 250 from 500"},
+		{name: "unbounded", game: game.Game{Name: "Chess"}, points: 12345, valid: true},
+		{name: "not in catalog", err: game.ErrGameNotFound, points: -1, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := game_mocks.NewMockGameRepository(ctrl)
+			svc := game.NewService(repo)
+
+			repo.EXPECT().GetGame(gomock.Any(), "star wars").Return(tt.game, tt.err).Times(1)
+
+			valid, reason, err := svc.CheckPoints(context.Background(), "star wars", tt.points)
+
+			require.Nil(t, err)
+			require.Equal(t, tt.valid, valid)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestSaveGame(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := game_mocks.NewMockGameRepository(ctrl)
+		svc := game.NewService(repo)
+
+		g := game.Game{Name: "Star Wars", MinPoints: 500, MaxPoints: 2000}
+		repo.EXPECT().UpsertGame(gomock.Any(), g).Return(g, nil).Times(1)
+
+		saved, err := svc.SaveGame(context.Background(), game.Game{Name: " Star Wars ", MinPoints: 500, MaxPoints: 2000})
+
+		require.Nil(t, err)
+		require.Equal(t, g, saved)
+	})
+
+	t.Run("min above max", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := game_mocks.NewMockGameRepository(ctrl)
+		svc := game.NewService(repo)
+
+		repo.EXPECT().UpsertGame(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.SaveGame(context.Background(), game.Game{Name: "Star Wars", MinPoints: 2000, MaxPoints: 500})
+
+		require.ErrorIs(t, err, game.ErrInvalidGame)
+	})
+
+	t.Run("choice without options", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := game_mocks.NewMockGameRepository(ctrl)
+		svc := game.NewService(repo)
+
+		repo.EXPECT().UpsertGame(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.SaveGame(context.Background(), game.Game{Name: "Star Wars", Fields: []game.Field{{Name: "mission", Type: game.FieldChoice}}})
+
+		require.ErrorIs(t, err, game.ErrInvalidGame)
+	})
+}
+
+func TestCheckFields(t *testing.T) {
+	sw := game.Game{Name: "Star Wars", Fields: []game.Field{
+		{Name: "mission", Type: game.FieldChoice, Required: true, Options: []string{"Take and Hold", "Sabotage"}},
+		{Name: "mapSize", Type: game.FieldNumber},
+		{Name: "terrain", Type: game.FieldBoolean},
+		{Name: "notes", Type: game.FieldText},
+	}}
+
+	tests := []struct {
+		name   string
+		game   game.Game
+		err    error
+		fields map[string]any
+		valid  bool
+		reason string
+	}{
+		{name: "matching", game: sw, fields: map[string]any{"mission": "Sabotage", "mapSize": float64(44), "terrain": true, "notes": "night"}, valid: true},
+		{name: "unknown field", game: sw, fields: map[string]any{"mission": "Sabotage", "weather": "rain"}, reason: "Star Wars has no field 'weather'"},
+		{name: "missing required", game: sw, fields: map[string]any{"mapSize": float64(44)}, reason: "Star Wars needs the field 'mission'"},
+		{name: "wrong type", game: sw, fields: map[string]any{"mission": "Sabotage", "mapSize": "large"}, reason: "'mapSize' must be a number"},
+		{name: "not an option", game: sw, fields: map[string]any{"mission": "Extermination"}, reason: "'mission' must be one of [Take and Hold Sabotage]"},
+		{name: "no schema", game: game.Game{Name: "Chess"}, fields: map[string]any{"opening": "sicilian"}, reason: "Chess has no field 'opening'"},
+		{name: "not in catalog", err: game.ErrGameNotFound, fields: map[string]any{"opening": "sicilian"}, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := game_mocks.NewMockGameRepository(ctrl)
+			svc := game.NewService(repo)
+
+			repo.EXPECT().GetGame(gomock.Any(), "star wars").Return(tt.game, tt.err).Times(1)
+
+			valid, reason, err := svc.CheckFields(context.Background(), "star wars", tt.fields)
+
+			require.Nil(t, err)
+			require.Equal(t, tt.valid, valid)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/game (interfaces: GameRepository)
+//
+// Generated by this command:
+//
+//	mockgen . GameRepository
+//
+
+// Package mock_game is a generated GoMock package.
+package mock_game
+
+import (
+	context "context"
+	reflect "reflect"
+
+	game "github.com/hanksha/tbz-booking-system-backend/game"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGameRepository is a mock of GameRepository interface.
+type MockGameRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGameRepositoryMockRecorder is the mock recorder for MockGameRepository.
+type MockGameRepositoryMockRecorder struct {
+	mock *MockGameRepository
+}
+
+// NewMockGameRepository creates a new mock instance.
+func NewMockGameRepository(ctrl *gomock.Controller) *MockGameRepository {
+	mock := &MockGameRepository{ctrl: ctrl}
+	mock.recorder = &MockGameRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameRepository) EXPECT() *MockGameRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteGame mocks base method.
+func (m *MockGameRepository) DeleteGame(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGame", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGame indicates an expected call of DeleteGame.
+func (mr *MockGameRepositoryMockRecorder) DeleteGame(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGame", reflect.TypeOf((*MockGameRepository)(nil).DeleteGame), ctx, name)
+}
+
+// GetGame mocks base method.
+func (m *MockGameRepository) GetGame(ctx context.Context, name string) (game.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGame", ctx, name)
+	ret0, _ := ret[0].(game.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGame indicates an expected call of GetGame.
+func (mr *MockGameRepositoryMockRecorder) GetGame(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGame", reflect.TypeOf((*MockGameRepository)(nil).GetGame), ctx, name)
+}
+
+// GetGames mocks base method.
+func (m *MockGameRepository) GetGames(ctx context.Context) ([]game.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGames", ctx)
+	ret0, _ := ret[0].([]game.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGames indicates an expected call of GetGames.
+func (mr *MockGameRepositoryMockRecorder) GetGames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGames", reflect.TypeOf((*MockGameRepository)(nil).GetGames), ctx)
+}
+
+// UpsertGame mocks base method.
+func (m *MockGameRepository) UpsertGame(ctx context.Context, arg1 game.Game) (game.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGame", ctx, arg1)
+	ret0, _ := ret[0].(game.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertGame indicates an expected call of UpsertGame.
+func (mr *MockGameRepositoryMockRecorder) UpsertGame(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGame", reflect.TypeOf((*MockGameRepository)(nil).UpsertGame), ctx, arg1)
+}
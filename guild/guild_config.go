@@ -0,0 +1,77 @@
+package guild
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+)
+
+// Config are the settings of the Discord server the admins may change
+// without a redeploy. The environment provides them until they are first
+// saved.
+type Config struct {
+	GuildID   string `json:"guildId"`
+	ChannelID string `json:"channelId"`
+	// AdminChannelID receives the alerts for admins, ChannelID when empty
+	AdminChannelID string `json:"adminChannelId"`
+	// OpenSeatsChannelID and OpsChannelID are optional, nothing is posted
+	// there when empty
+	OpenSeatsChannelID string `json:"openSeatsChannelId"`
+	OpsChannelID       string `json:"opsChannelId"`
+	AdminRoleID        string `json:"adminRoleId"`
+	TrustedRoleID      string `json:"trustedRoleId"`
+	// Locale is the language of the API messages for the callers preferring
+	// none of the supported ones
+	Locale    i18n.Language `json:"locale"`
+	UpdatedAt *time.Time    `json:"updatedAt,omitempty"`
+	UpdatedBy string        `json:"updatedBy,omitempty"`
+}
+
+func normalize(cfg *Config) error {
+	ids := []struct {
+		value    *string
+		name     string
+		required bool
+	}{
+		{&cfg.ChannelID, "channelId", true},
+		{&cfg.AdminChannelID, "adminChannelId", false},
+		{&cfg.OpenSeatsChannelID, "openSeatsChannelId", false},
+		{&cfg.OpsChannelID, "opsChannelId", false},
+		{&cfg.AdminRoleID, "adminRoleId", true},
+		{&cfg.TrustedRoleID, "trustedRoleId", false},
+	}
+
+	for _, id := range ids {
+		*id.value = strings.TrimSpace(*id.value)
+
+		if len(*id.value) == 0 {
+			if id.required {
+				return fmt.Errorf("%w: %v cannot be empty", ErrInvalidConfig, id.name)
+			}
+			continue
+		}
+
+		if _, err := strconv.ParseUint(*id.value, 10, 64); err != nil {
+			return fmt.Errorf("%w: %v '%v' is not a Discord ID", ErrInvalidConfig, id.name, *id.value)
+		}
+	}
+
+	if len(cfg.AdminChannelID) == 0 {
+		cfg.AdminChannelID = cfg.ChannelID
+	}
+
+	cfg.Locale = i18n.Language(strings.ToLower(strings.TrimSpace(string(cfg.Locale))))
+
+	if len(cfg.Locale) == 0 {
+		cfg.Locale = i18n.Default
+	}
+
+	if !i18n.Supported(cfg.Locale) {
+		return fmt.Errorf("%w: locale '%v' is not supported", ErrInvalidConfig, cfg.Locale)
+	}
+
+	return nil
+}
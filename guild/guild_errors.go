@@ -0,0 +1,7 @@
+package guild
+
+import "errors"
+
+var ErrConfigNotFound = errors.New("guild config not found")
+
+var ErrInvalidConfig = errors.New("invalid guild config")
@@ -0,0 +1,71 @@
+package guild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// GetConfig returns the saved settings of the server, ErrConfigNotFound
+// when the admins never saved any.
+func (r *Repository) GetConfig(ctx context.Context, guildID string) (Config, error) {
+	sql := `
+		SELECT "guildId", "channelId", "adminChannelId", "openSeatsChannelId", "opsChannelId", "adminRoleId", "trustedRoleId", locale, "updatedAt", "updatedBy"
+		FROM "game-table-booking".guild_config
+		WHERE "guildId" = $1;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, guildID)
+
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to fetch config of guild '%v': %w", guildID, err)
+	}
+
+	cfg, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Config])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Config{}, ErrConfigNotFound
+	}
+
+	if err != nil {
+		return Config{}, fmt.Errorf("error scanning guild config row: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func (r *Repository) SaveConfig(ctx context.Context, cfg Config) (Config, error) {
+	sql := `
+		INSERT INTO "game-table-booking".guild_config("guildId", "channelId", "adminChannelId", "openSeatsChannelId", "opsChannelId", "adminRoleId", "trustedRoleId", locale, "updatedBy")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT ("guildId") DO UPDATE
+		SET "channelId" = EXCLUDED."channelId",
+			"adminChannelId" = EXCLUDED."adminChannelId",
+			"openSeatsChannelId" = EXCLUDED."openSeatsChannelId",
+			"opsChannelId" = EXCLUDED."opsChannelId",
+			"adminRoleId" = EXCLUDED."adminRoleId",
+			"trustedRoleId" = EXCLUDED."trustedRoleId",
+			locale = EXCLUDED.locale,
+			"updatedBy" = EXCLUDED."updatedBy",
+			"updatedAt" = now()
+		RETURNING "updatedAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, cfg.GuildID, cfg.ChannelID, cfg.AdminChannelID, cfg.OpenSeatsChannelID, cfg.OpsChannelID,
+		cfg.AdminRoleID, cfg.TrustedRoleID, cfg.Locale, cfg.UpdatedBy).Scan(&cfg.UpdatedAt)
+
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to save config of guild '%v': %w", cfg.GuildID, err)
+	}
+
+	return cfg, nil
+}
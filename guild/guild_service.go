@@ -0,0 +1,212 @@
+package guild
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+)
+
+// DefaultReloadInterval is how long the settings are cached before being
+// read again, for the changes saved through another replica to apply.
+const DefaultReloadInterval = time.Minute
+
+type ConfigRepository interface {
+	GetConfig(ctx context.Context, guildID string) (Config, error)
+	SaveConfig(ctx context.Context, cfg Config) (Config, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  ConfigRepository
+	audit AuditRecorder
+	// defaults are the settings of the environment, used until the admins
+	// save theirs
+	defaults Config
+	interval time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+
+	mu       sync.Mutex
+	current  Config
+	loadedAt time.Time
+	// reloading lets the other callers use the cached settings while they
+	// are read again
+	reloading bool
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+// WithReloadInterval caches the settings for interval rather than
+// DefaultReloadInterval, they are read on each call when it is zero.
+func WithReloadInterval(interval time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.interval = interval
+	}
+}
+
+func NewService(repo ConfigRepository, defaults Config, opts ...ServiceOption) *Service {
+	if len(defaults.AdminChannelID) == 0 {
+		defaults.AdminChannelID = defaults.ChannelID
+	}
+
+	if len(defaults.Locale) == 0 {
+		defaults.Locale = i18n.Default
+	}
+
+	s := &Service{
+		repo:     repo,
+		defaults: defaults,
+		current:  defaults,
+		interval: DefaultReloadInterval,
+		now:      time.Now,
+		logger:   slog.Default().With("component", "guild"),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Config returns the cached settings of the server, read again once older
+// than the reload interval. The last known settings are kept while the
+// database is unreachable.
+func (s *Service) Config(ctx context.Context) Config {
+	s.mu.Lock()
+	current := s.current
+	stale := !s.reloading && s.now().Sub(s.loadedAt) >= s.interval
+
+	if stale {
+		s.reloading = true
+	}
+	s.mu.Unlock()
+
+	if !stale {
+		return current
+	}
+
+	cfg, err := s.Reload(ctx)
+
+	if err != nil {
+		s.logger.Warn("failed to reload guild config, keeping the cached one", "err", err)
+		return current
+	}
+
+	return cfg
+}
+
+// Reload reads the settings from the database and caches them, those of the
+// environment when the admins never saved any.
+func (s *Service) Reload(ctx context.Context) (Config, error) {
+	cfg, err := s.repo.GetConfig(ctx, s.defaults.GuildID)
+
+	if errors.Is(err, ErrConfigNotFound) {
+		cfg, err = s.defaults, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reloading = false
+	// a failed reload is retried after the interval too, rather than on
+	// every call
+	s.loadedAt = s.now()
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	s.current = cfg
+
+	return cfg, nil
+}
+
+// GetConfig returns the settings as saved in the database, for the admins
+// to edit them.
+func (s *Service) GetConfig(ctx context.Context) (Config, error) {
+	return s.Reload(ctx)
+}
+
+// UpdateConfig replaces the settings of the server, applied right away by
+// this replica and within the reload interval by the others.
+func (s *Service) UpdateConfig(ctx context.Context, cfg Config) (Config, error) {
+	if err := normalize(&cfg); err != nil {
+		return Config{}, err
+	}
+
+	cfg.GuildID = s.defaults.GuildID
+	cfg.UpdatedAt = nil
+	cfg.UpdatedBy = ""
+
+	if user, ok := discord.UserFromContext(ctx); ok {
+		cfg.UpdatedBy = user.Username
+	}
+
+	saved, err := s.repo.SaveConfig(ctx, cfg)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	s.mu.Lock()
+	s.current = saved
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+
+	if s.audit != nil {
+		s.audit.Record(ctx, "guild.config.update", "guild", saved.GuildID, saved)
+	}
+
+	return saved, nil
+}
+
+// BookingSettings are the channels and the admin role of the booking
+// notifications, see booking.WithGuildSettings.
+func (s *Service) BookingSettings(ctx context.Context) bk.GuildSettings {
+	cfg := s.Config(ctx)
+
+	return bk.GuildSettings{
+		ChannelID:          cfg.ChannelID,
+		AdminChannelID:     cfg.AdminChannelID,
+		OpenSeatsChannelID: cfg.OpenSeatsChannelID,
+		AdminRoleID:        cfg.AdminRoleID,
+	}
+}
+
+// Roles are the admin and trusted roles of the members, see api.WithRoles.
+func (s *Service) Roles(ctx context.Context) (string, string) {
+	cfg := s.Config(ctx)
+
+	return cfg.AdminRoleID, cfg.TrustedRoleID
+}
+
+// Language is the locale of the server, see api.WithDefaultLanguage.
+func (s *Service) Language(ctx context.Context) i18n.Language {
+	return s.Config(ctx).Locale
+}
+
+// ChannelID is the channel of the booking notifications.
+func (s *Service) ChannelID(ctx context.Context) string {
+	return s.Config(ctx).ChannelID
+}
+
+// OpsChannelID is the channel of the panic reports.
+func (s *Service) OpsChannelID(ctx context.Context) string {
+	return s.Config(ctx).OpsChannelID
+}
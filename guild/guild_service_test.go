@@ -0,0 +1,147 @@
+package guild_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/guild"
+	guild_mocks "github.com/hanksha/tbz-booking-system-backend/guild/mocks"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var defaults = guild.Config{
+	GuildID:     "100",
+	ChannelID:   "200",
+	AdminRoleID: "300",
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("environment until saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := guild_mocks.NewMockConfigRepository(ctrl)
+		svc := guild.NewService(repo, defaults)
+
+		repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{}, guild.ErrConfigNotFound).Times(1)
+
+		cfg := svc.Config(context.Background())
+
+		require.Equal(t, "200", cfg.AdminChannelID)
+		require.Equal(t, i18n.English, cfg.Locale)
+		require.Nil(t, cfg.UpdatedAt)
+	})
+
+	t.Run("cached until the reload interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := guild_mocks.NewMockConfigRepository(ctrl)
+		svc := guild.NewService(repo, defaults, guild.WithReloadInterval(time.Hour))
+
+		repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{GuildID: "100", ChannelID: "201", AdminRoleID: "301"}, nil).Times(1)
+
+		for range 3 {
+			admin, _ := svc.Roles(context.Background())
+			require.Equal(t, "301", admin)
+		}
+	})
+
+	t.Run("changes of other replicas", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := guild_mocks.NewMockConfigRepository(ctrl)
+		svc := guild.NewService(repo, defaults, guild.WithReloadInterval(0))
+
+		gomock.InOrder(
+			repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{ChannelID: "201"}, nil),
+			repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{ChannelID: "202"}, nil),
+		)
+
+		require.Equal(t, "201", svc.ChannelID(context.Background()))
+		require.Equal(t, "202", svc.ChannelID(context.Background()))
+	})
+
+	t.Run("kept while the database is down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := guild_mocks.NewMockConfigRepository(ctrl)
+		svc := guild.NewService(repo, defaults, guild.WithReloadInterval(0))
+
+		gomock.InOrder(
+			repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{ChannelID: "201"}, nil),
+			repo.EXPECT().GetConfig(gomock.Any(), "100").Return(guild.Config{}, errors.New("connection refused")),
+		)
+
+		require.Equal(t, "201", svc.ChannelID(context.Background()))
+		require.Equal(t, "201", svc.ChannelID(context.Background()))
+	})
+}
+
+func TestUpdateConfig(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "alice", Admin: true}
+
+	t.Run("applied right away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := guild_mocks.NewMockConfigRepository(ctrl)
+		svc := guild.NewService(repo, defaults, guild.WithReloadInterval(time.Hour))
+		updatedAt := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+
+		repo.EXPECT().SaveConfig(gomock.Any(), guild.Config{
+			GuildID:            "100",
+			ChannelID:          "201",
+			AdminChannelID:     "201",
+			OpenSeatsChannelID: "401",
+			AdminRoleID:        "301",
+			Locale:             i18n.French,
+			UpdatedBy:          "alice",
+		}).DoAndReturn(func(ctx context.Context, cfg guild.Config) (guild.Config, error) {
+			cfg.UpdatedAt = &updatedAt
+			return cfg, nil
+		}).Times(1)
+
+		saved, err := svc.UpdateConfig(discord.ContextWithUser(context.Background(), admin), guild.Config{
+			GuildID:            "999",
+			ChannelID:          " 201 ",
+			OpenSeatsChannelID: "401",
+			AdminRoleID:        "301",
+			Locale:             "FR",
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, &updatedAt, saved.UpdatedAt)
+		require.Equal(t, i18n.French, svc.Language(context.Background()))
+		require.Equal(t, "401", svc.BookingSettings(context.Background()).OpenSeatsChannelID)
+	})
+
+	invalid := map[string]guild.Config{
+		"no channel":         {AdminRoleID: "301"},
+		"no admin role":      {ChannelID: "201"},
+		"invalid channel id": {ChannelID: "#bookings", AdminRoleID: "301"},
+		"invalid role id":    {ChannelID: "201", AdminRoleID: "301", TrustedRoleID: "@trusted"},
+		"unsupported locale": {ChannelID: "201", AdminRoleID: "301", Locale: "de"},
+	}
+
+	for name, cfg := range invalid {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := guild_mocks.NewMockConfigRepository(ctrl)
+			svc := guild.NewService(repo, defaults)
+
+			_, err := svc.UpdateConfig(context.Background(), cfg)
+
+			require.ErrorIs(t, err, guild.ErrInvalidConfig)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/guild (interfaces: ConfigRepository)
+//
+// Generated by this command:
+//
+//	mockgen . ConfigRepository
+//
+
+// Package mock_guild is a generated GoMock package.
+package mock_guild
+
+import (
+	context "context"
+	reflect "reflect"
+
+	guild "github.com/hanksha/tbz-booking-system-backend/guild"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConfigRepository is a mock of ConfigRepository interface.
+type MockConfigRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConfigRepositoryMockRecorder is the mock recorder for MockConfigRepository.
+type MockConfigRepositoryMockRecorder struct {
+	mock *MockConfigRepository
+}
+
+// NewMockConfigRepository creates a new mock instance.
+func NewMockConfigRepository(ctrl *gomock.Controller) *MockConfigRepository {
+	mock := &MockConfigRepository{ctrl: ctrl}
+	mock.recorder = &MockConfigRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigRepository) EXPECT() *MockConfigRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetConfig mocks base method.
+func (m *MockConfigRepository) GetConfig(ctx context.Context, guildID string) (guild.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfig", ctx, guildID)
+	ret0, _ := ret[0].(guild.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfig indicates an expected call of GetConfig.
+func (mr *MockConfigRepositoryMockRecorder) GetConfig(ctx, guildID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockConfigRepository)(nil).GetConfig), ctx, guildID)
+}
+
+// SaveConfig mocks base method.
+func (m *MockConfigRepository) SaveConfig(ctx context.Context, cfg guild.Config) (guild.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveConfig", ctx, cfg)
+	ret0, _ := ret[0].(guild.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveConfig indicates an expected call of SaveConfig.
+func (mr *MockConfigRepositoryMockRecorder) SaveConfig(ctx, cfg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveConfig", reflect.TypeOf((*MockConfigRepository)(nil).SaveConfig), ctx, cfg)
+}
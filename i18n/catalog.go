@@ -0,0 +1,191 @@
+package i18n
+
+// catalog translates the texts of the Default language, the messages of the
+// API and the titles of the Discord notifications.
+var catalog = map[Language]map[string]string{
+	French: {
+		// Discord notifications
+		"New Booking":                        "Nouvelle Réservation",
+		"New Booking Automatically Accepted": "Nouvelle Réservation Acceptée automatiquement",
+		"Booking Accepted":                   "Réservation Acceptée",
+		"Booking Automatically Accepted":     "Réservation Acceptée automatiquement",
+		"Booking Refused":                    "Réservation Refusée",
+		"Booking Canceled":                   "Réservation Annulée",
+		"Booking Modified":                   "Réservation Modifiée",
+		"Booking Reopened":                   "Réservation Rouverte",
+		"Booking Pending":                    "Réservation en attente",
+		"Player Replaced":                    "Joueur Remplacé",
+		"Player Left":                        "Joueur Parti",
+
+		// API
+		"attachment deleted":                                    "pièce jointe supprimée",
+		"attachment not found":                                  "pièce jointe introuvable",
+		"ban lifted":                                            "bannissement levé",
+		"ban not found":                                         "bannissement introuvable",
+		"booking accepted":                                      "réservation acceptée",
+		"booking attached":                                      "réservation rattachée",
+		"booking can no longer be canceled":                     "la réservation ne peut plus être annulée",
+		"booking can no longer be modified":                     "la réservation ne peut plus être modifiée",
+		"booking canceled":                                      "réservation annulée",
+		"booking deleted":                                       "réservation supprimée",
+		"booking detached":                                      "réservation détachée",
+		"booking is not completed":                              "la réservation n'est pas terminée",
+		"booking modified":                                      "réservation modifiée",
+		"booking not found":                                     "réservation introuvable",
+		"booking refused":                                       "réservation refusée",
+		"bookings imported":                                     "réservations importées",
+		"calendar token already exists":                         "le jeton de calendrier existe déjà",
+		"calendar token not found":                              "jeton de calendrier introuvable",
+		"calendar token revoked":                                "jeton de calendrier révoqué",
+		"campaign deleted":                                      "campagne supprimée",
+		"campaign not found":                                    "campagne introuvable",
+		"check-in is disabled":                                  "l'enregistrement est désactivé",
+		"delivery not found":                                    "envoi introuvable",
+		"equipment item deleted":                                "matériel supprimé",
+		"equipment item not found":                              "matériel introuvable",
+		"event deleted":                                         "événement supprimé",
+		"event not found":                                       "événement introuvable",
+		"expected a multipart form with a file field":           "un formulaire multipart avec un champ file est attendu",
+		"failed to accept booking":                              "impossible d'accepter la réservation",
+		"failed to ban user":                                    "impossible de bannir l'utilisateur",
+		"failed to cancel booking":                              "impossible d'annuler la réservation",
+		"failed to count bookings":                              "impossible de compter les réservations",
+		"failed to create booking":                              "impossible de créer la réservation",
+		"failed to create calendar token":                       "impossible de créer le jeton de calendrier",
+		"failed to create campaign":                             "impossible de créer la campagne",
+		"failed to create event":                                "impossible de créer l'événement",
+		"failed to create webhook":                              "impossible de créer le webhook",
+		"failed to delete booking":                              "impossible de supprimer la réservation",
+		"failed to delete campaign":                             "impossible de supprimer la campagne",
+		"failed to delete event":                                "impossible de supprimer l'événement",
+		"failed to delete game":                                 "impossible de supprimer le jeu",
+		"failed to delete role mapping":                         "impossible de supprimer l'attribution du rôle",
+		"failed to delete webhook":                              "impossible de supprimer le webhook",
+		"failed to discard notification":                        "impossible d'abandonner la notification",
+		"failed to encode response":                             "impossible d'encoder la réponse",
+		"failed to fetch booking":                               "impossible de récupérer la réservation",
+		"failed to fetch booking attachments":                   "impossible de récupérer les pièces jointes de la réservation",
+		"failed to fetch booking result":                        "impossible de récupérer le résultat de la réservation",
+		"failed to generate check-in code":                      "impossible de générer le code d'enregistrement",
+		"failed to get bookings":                                "impossible de récupérer les réservations",
+		"failed to get events":                                  "impossible de récupérer les événements",
+		"failed to get oauth2 token":                            "impossible d'obtenir le jeton oauth2",
+		"failed to get stats":                                   "impossible de récupérer les statistiques",
+		"failed to import bookings":                             "impossible d'importer les réservations",
+		"failed to join booking":                                "impossible de rejoindre la réservation",
+		"failed to leave booking":                               "impossible de quitter la réservation",
+		"failed to lift ban":                                    "impossible de lever le bannissement",
+		"failed to modify booking":                              "impossible de modifier la réservation",
+		"failed to parse JSON body":                             "corps JSON invalide",
+		"failed to parse around":                                "paramètre around invalide",
+		"failed to parse at":                                    "paramètre at invalide",
+		"failed to parse duration":                              "paramètre duration invalide",
+		"failed to parse endPeriod":                             "paramètre endPeriod invalide",
+		"failed to parse from":                                  "paramètre from invalide",
+		"failed to parse lastEventId":                           "paramètre lastEventId invalide",
+		"failed to parse limit":                                 "paramètre limit invalide",
+		"failed to parse offset":                                "paramètre offset invalide",
+		"failed to parse since":                                 "paramètre since invalide",
+		"failed to parse startPeriod":                           "paramètre startPeriod invalide",
+		"failed to parse to":                                    "paramètre to invalide",
+		"failed to read event":                                  "impossible de lire l'événement",
+		"failed to read file":                                   "impossible de lire le fichier",
+		"failed to refuse booking":                              "impossible de refuser la réservation",
+		"failed to remove member":                               "impossible de retirer le membre",
+		"failed to reopen booking":                              "impossible de rouvrir la réservation",
+		"failed to replace player":                              "impossible de remplacer le joueur",
+		"failed to retrieve achievements":                       "impossible de récupérer les succès",
+		"failed to retrieve audit entries":                      "impossible de récupérer le journal d'audit",
+		"failed to retrieve bans":                               "impossible de récupérer les bannissements",
+		"failed to retrieve booking history":                    "impossible de récupérer l'historique des réservations",
+		"failed to retrieve bookings":                           "impossible de récupérer les réservations",
+		"failed to retrieve calendar":                           "impossible de récupérer le calendrier",
+		"failed to retrieve calendar token":                     "impossible de récupérer le jeton de calendrier",
+		"failed to retrieve campaign":                           "impossible de récupérer la campagne",
+		"failed to retrieve campaigns":                          "impossible de récupérer les campagnes",
+		"failed to retrieve config":                             "impossible de récupérer la configuration",
+		"failed to retrieve dead letters":                       "impossible de récupérer les notifications en échec",
+		"failed to retrieve deliveries":                         "impossible de récupérer les envois",
+		"failed to retrieve equipment":                          "impossible de récupérer le matériel",
+		"failed to retrieve equipment allocations":              "impossible de récupérer les attributions de matériel",
+		"failed to retrieve event":                              "impossible de récupérer l'événement",
+		"failed to retrieve events":                             "impossible de récupérer les événements",
+		"failed to retrieve games":                              "impossible de récupérer les jeux",
+		"failed to retrieve members":                            "impossible de récupérer les membres",
+		"failed to retrieve preferences":                        "impossible de récupérer les préférences",
+		"failed to retrieve rankings":                           "impossible de récupérer les classements",
+		"failed to retrieve role mappings":                      "impossible de récupérer les attributions des rôles",
+		"failed to retrieve the queue":                          "impossible de récupérer la file d'attente",
+		"failed to retrieve trusted users":                      "impossible de récupérer les utilisateurs de confiance",
+		"failed to retrieve users":                              "impossible de récupérer les utilisateurs",
+		"failed to retrieve venues":                             "impossible de récupérer les lieux",
+		"failed to retrieve webhooks":                           "impossible de récupérer les webhooks",
+		"failed to retry delivery":                              "impossible de relancer l'envoi",
+		"failed to retry notification":                          "impossible de relancer la notification",
+		"failed to revoke calendar token":                       "impossible de révoquer le jeton de calendrier",
+		"failed to revoke trust":                                "impossible de retirer la confiance",
+		"failed to rotate calendar token":                       "impossible de renouveler le jeton de calendrier",
+		"failed to save config":                                 "impossible d'enregistrer la configuration",
+		"failed to save game":                                   "impossible d'enregistrer le jeu",
+		"failed to save member":                                 "impossible d'enregistrer le membre",
+		"failed to save preferences":                            "impossible d'enregistrer les préférences",
+		"failed to save result":                                 "impossible d'enregistrer le résultat",
+		"failed to save role mapping":                           "impossible d'enregistrer l'attribution du rôle",
+		"failed to search bookings":                             "impossible de rechercher les réservations",
+		"failed to search users":                                "impossible de rechercher les utilisateurs",
+		"failed to send notification":                           "impossible d'envoyer la notification",
+		"failed to set priority":                                "impossible de définir la priorité",
+		"failed to set reminders":                               "impossible de définir les rappels",
+		"failed to sign up":                                     "impossible de s'inscrire",
+		"failed to suggest slots":                               "impossible de suggérer des créneaux",
+		"failed to trust user":                                  "impossible d'accorder la confiance à l'utilisateur",
+		"format must be one of json, csv":                       "format doit valoir json ou csv",
+		"game deleted":                                          "jeu supprimé",
+		"game not found":                                        "jeu introuvable",
+		"ids must be a comma separated list of booking ids":     "ids doit être une liste d'identifiants de réservation séparés par des virgules",
+		"ids must contain between 1 and %d booking ids":         "ids doit contenir entre 1 et %d identifiants de réservation",
+		"internal server error":                                 "erreur interne du serveur",
+		"invalid authentication":                                "authentification invalide",
+		"invalid booking state":                                 "état de la réservation invalide",
+		"invalid check-in code":                                 "code d'enregistrement invalide",
+		"invalid cursor":                                        "curseur invalide",
+		"invalid payment event":                                 "événement de paiement invalide",
+		"invalid request body":                                  "corps de la requête invalide",
+		"invalid request signature":                             "signature de la requête invalide",
+		"member not found":                                      "membre introuvable",
+		"member removed":                                        "membre retiré",
+		"missing authentication":                                "authentification manquante",
+		"not allowed":                                           "non autorisé",
+		"not allowed to change the attachments of this booking": "non autorisé à modifier les pièces jointes de cette réservation",
+		"not allowed to change the campaign of this booking":    "non autorisé à modifier la campagne de cette réservation",
+		"not allowed to change the reminders":                   "non autorisé à modifier les rappels de cette réservation",
+		"not allowed to check in this booking":                  "non autorisé à enregistrer cette réservation",
+		"not allowed to modify this booking":                    "non autorisé à modifier cette réservation",
+		"not allowed to replace this player":                    "non autorisé à remplacer ce joueur",
+		"not allowed to report the result of this booking":      "non autorisé à déclarer le résultat de cette réservation",
+		"not allowed to see this payment":                       "non autorisé à voir ce paiement",
+		"notification discarded":                                "notification abandonnée",
+		"notification not found":                                "notification introuvable",
+		"notification sent":                                     "notification envoyée",
+		"payment not found":                                     "paiement introuvable",
+		"payments are disabled":                                 "les paiements sont désactivés",
+		"poll not found":                                        "sondage introuvable",
+		"q must contain at least one word":                      "q doit contenir au moins un mot",
+		"query cannot be empty":                                 "la recherche ne peut pas être vide",
+		"request timed out":                                     "la requête a expiré",
+		"role mapping deleted":                                  "attribution du rôle supprimée",
+		"role mapping not found":                                "attribution du rôle introuvable",
+		"sort must be one of dateTime, recent":                  "sort doit valoir dateTime ou recent",
+		"the organizer cancels the booking instead":             "l'organisateur annule la réservation à la place",
+		"too many requests":                                     "trop de requêtes",
+		"trust revoked":                                         "confiance retirée",
+		"trusted user not found":                                "utilisateur de confiance introuvable",
+		"unknown players":                                       "joueurs inconnus",
+		"unsupported interaction":                               "interaction non prise en charge",
+		"user is suspended from booking":                        "l'utilisateur est suspendu de réservation",
+		"venue deleted":                                         "lieu supprimé",
+		"venue not found":                                       "lieu introuvable",
+		"webhook deleted":                                       "webhook supprimé",
+		"webhook not found":                                     "webhook introuvable",
+	},
+}
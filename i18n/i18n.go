@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Language is the base tag of a supported language, such as "fr".
+type Language string
+
+const (
+	English Language = "en"
+	French  Language = "fr"
+)
+
+// Default is the language of the messages when the caller prefers none of
+// the supported ones. The catalog is keyed by its texts.
+const Default = English
+
+// Negotiate picks the supported language the Accept-Language header prefers,
+// Default when it names none of them.
+func Negotiate(header string) Language {
+	return NegotiateOr(header, Default)
+}
+
+// NegotiateOr is Negotiate falling back to fallback, such as the locale of the
+// server, rather than Default.
+func NegotiateOr(header string, fallback Language) Language {
+	best, bestQuality := fallback, 0.0
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		language := Language(base)
+
+		if base == "*" {
+			language = fallback
+		} else if !Supported(language) {
+			continue
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = language, quality
+		}
+	}
+
+	return best
+}
+
+// Supported tells whether messages can be translated in language.
+func Supported(language Language) bool {
+	_, ok := catalog[language]
+	return ok || language == Default
+}
+
+// Translate returns text in language, text itself when the catalog has no
+// translation for it.
+func Translate(language Language, text string) string {
+	if translated, ok := catalog[language][text]; ok {
+		return translated
+	}
+
+	return text
+}
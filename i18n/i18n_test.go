@@ -0,0 +1,42 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected i18n.Language
+	}{
+		{"", i18n.English},
+		{"fr", i18n.French},
+		{"fr-FR,fr;q=0.9,en;q=0.8", i18n.French},
+		{"en-US,en;q=0.9,fr;q=0.8", i18n.English},
+		{"de-DE,de;q=0.9,fr;q=0.5", i18n.French},
+		{"de-DE", i18n.English},
+		{"fr;q=0, en", i18n.English},
+		{"*;q=0.5, FR-be;q=0.8", i18n.French},
+		{"fr;q=invalid", i18n.English},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.expected, i18n.Negotiate(test.header), test.header)
+	}
+}
+
+func TestNegotiateOr(t *testing.T) {
+	require.Equal(t, i18n.French, i18n.NegotiateOr("", i18n.French))
+	require.Equal(t, i18n.French, i18n.NegotiateOr("de-DE", i18n.French))
+	require.Equal(t, i18n.English, i18n.NegotiateOr("en-US", i18n.French))
+	require.Equal(t, i18n.French, i18n.NegotiateOr("*", i18n.French))
+}
+
+func TestTranslate(t *testing.T) {
+	require.Equal(t, "réservation introuvable", i18n.Translate(i18n.French, "booking not found"))
+	require.Equal(t, "booking not found", i18n.Translate(i18n.English, "booking not found"))
+	require.Equal(t, "no translation", i18n.Translate(i18n.French, "no translation"))
+}
@@ -0,0 +1,16 @@
+// Package ical serves the bookings of each member as a calendar feed their
+// calendar app subscribes to, behind a personal token.
+package ical
+
+import "time"
+
+// Token grants access to the calendar feed of a member. Only its hash is
+// stored, the token and its feed URL being returned once, when issued.
+type Token struct {
+	UserID     string     `json:"userId"`
+	Username   string     `json:"username"`
+	Token      string     `json:"token,omitempty" db:"-"`
+	URL        string     `json:"url,omitempty" db:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
@@ -0,0 +1,7 @@
+package ical
+
+import "errors"
+
+var ErrTokenNotFound = errors.New("calendar token not found")
+
+var ErrTokenExists = errors.New("calendar token already exists")
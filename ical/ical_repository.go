@@ -0,0 +1,112 @@
+package ical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const tokenColumns = `"userId", username, "createdAt", "lastUsedAt"`
+
+func (r *Repository) GetToken(ctx context.Context, userID string) (Token, error) {
+	sql := `
+		SELECT ` + tokenColumns + `
+		FROM "game-table-booking".calendar_token
+		WHERE "userId" = $1;
+	`
+
+	return r.queryToken(ctx, sql, userID)
+}
+
+// InsertToken stores the hash of the first token of the user, failing with
+// ErrTokenExists when they already have one.
+func (r *Repository) InsertToken(ctx context.Context, token Token, hash string) (Token, error) {
+	sql := `
+		INSERT INTO "game-table-booking".calendar_token("userId", username, "tokenHash")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("userId") DO NOTHING
+		RETURNING ` + tokenColumns + `;
+	`
+
+	inserted, err := r.queryToken(ctx, sql, token.UserID, token.Username, hash)
+
+	if errors.Is(err, ErrTokenNotFound) {
+		return Token{}, ErrTokenExists
+	}
+
+	return inserted, err
+}
+
+// ReplaceToken stores the hash of the new token of the user, the previous
+// one no longer granting access.
+func (r *Repository) ReplaceToken(ctx context.Context, userID, hash string) (Token, error) {
+	sql := `
+		UPDATE "game-table-booking".calendar_token
+		SET "tokenHash" = $2, "createdAt" = now(), "lastUsedAt" = NULL
+		WHERE "userId" = $1
+		RETURNING ` + tokenColumns + `;
+	`
+
+	return r.queryToken(ctx, sql, userID, hash)
+}
+
+func (r *Repository) DeleteToken(ctx context.Context, userID string) error {
+	sql := `
+		DELETE FROM "game-table-booking".calendar_token
+		WHERE "userId" = $1;
+	`
+
+	tag, err := r.conn.Exec(ctx, sql, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar token of %v: %w", userID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// UseToken returns the owner of the token with the hash, recording when it
+// was used.
+func (r *Repository) UseToken(ctx context.Context, hash string) (Token, error) {
+	sql := `
+		UPDATE "game-table-booking".calendar_token
+		SET "lastUsedAt" = now()
+		WHERE "tokenHash" = $1
+		RETURNING ` + tokenColumns + `;
+	`
+
+	return r.queryToken(ctx, sql, hash)
+}
+
+func (r *Repository) queryToken(ctx context.Context, sql string, args ...any) (Token, error) {
+	rows, err := r.conn.Query(ctx, sql, args...)
+
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to query calendar token: %w", err)
+	}
+
+	token, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Token])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Token{}, ErrTokenNotFound
+	}
+
+	if err != nil {
+		return Token{}, fmt.Errorf("error scanning calendar token row: %w", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,151 @@
+package ical
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type TokenRepository interface {
+	GetToken(ctx context.Context, userID string) (Token, error)
+	InsertToken(ctx context.Context, token Token, hash string) (Token, error)
+	ReplaceToken(ctx context.Context, userID, hash string) (Token, error)
+	DeleteToken(ctx context.Context, userID string) error
+	UseToken(ctx context.Context, hash string) (Token, error)
+}
+
+type BookingSource interface {
+	GetActiveBookings(ctx context.Context) ([]bk.Booking, error)
+}
+
+type Service struct {
+	repo     TokenRepository
+	bookings BookingSource
+	// feedURL is where the feeds are served, the token and .ics appended
+	feedURL string
+	// bookingURL is the frontend page of the bookings, their id appended
+	bookingURL string
+}
+
+func NewService(repo TokenRepository, bookings BookingSource, feedURL, bookingURL string) *Service {
+	return &Service{
+		repo:       repo,
+		bookings:   bookings,
+		feedURL:    strings.TrimSuffix(feedURL, "/"),
+		bookingURL: strings.TrimSuffix(bookingURL, "/"),
+	}
+}
+
+// GetToken returns when the token of the user was issued and last used,
+// not the token itself.
+func (s *Service) GetToken(ctx context.Context, user discord.DiscordUser) (Token, error) {
+	return s.repo.GetToken(ctx, user.ID)
+}
+
+// CreateToken issues the first token of the user, failing with
+// ErrTokenExists when they already have one to rotate.
+func (s *Service) CreateToken(ctx context.Context, user discord.DiscordUser) (Token, error) {
+	token, hash, err := newToken()
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	created, err := s.repo.InsertToken(ctx, Token{UserID: user.ID, Username: user.Username}, hash)
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	return s.withToken(created, token), nil
+}
+
+// RotateToken issues a new token to the user, the URLs with the previous
+// one no longer serving the feed.
+func (s *Service) RotateToken(ctx context.Context, user discord.DiscordUser) (Token, error) {
+	token, hash, err := newToken()
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	rotated, err := s.repo.ReplaceToken(ctx, user.ID, hash)
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	return s.withToken(rotated, token), nil
+}
+
+func (s *Service) RevokeToken(ctx context.Context, user discord.DiscordUser) error {
+	return s.repo.DeleteToken(ctx, user.ID)
+}
+
+// Feed renders the upcoming bookings the owner of the token organizes or
+// plays, failing with ErrTokenNotFound for an unknown or revoked token.
+func (s *Service) Feed(ctx context.Context, token string) ([]byte, error) {
+	owner, err := s.repo.UseToken(ctx, hash(token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookings.GetActiveBookings(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	username := strings.ToLower(owner.Username)
+	feed := []bk.Booking{}
+
+	for _, booking := range bookings {
+		if booking.Status != "accepted" && booking.Status != "pending" {
+			continue
+		}
+
+		if booking.UserID == owner.UserID || slices.Contains(booking.CoOrganizers, username) || slices.ContainsFunc(booking.Players, func(player string) bool {
+			return strings.EqualFold(player, username)
+		}) {
+			feed = append(feed, booking)
+		}
+	}
+
+	slices.SortFunc(feed, func(a, b bk.Booking) int { return a.DateTime.Compare(b.DateTime) })
+
+	return Render("Réservations de "+owner.Username, feed, s.bookingURL), nil
+}
+
+func (s *Service) withToken(issued Token, token string) Token {
+	issued.Token = token
+	issued.URL = s.feedURL + "/" + token + ".ics"
+
+	return issued
+}
+
+// newToken returns a random token and its hash.
+func newToken() (string, string, error) {
+	random := make([]byte, 32)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(random)
+
+	return token, hash(token), nil
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,152 @@
+package ical_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ical"
+	ical_mocks "github.com/hanksha/tbz-booking-system-backend/ical/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type bookingSource []bk.Booking
+
+func (s bookingSource) GetActiveBookings(ctx context.Context) ([]bk.Booking, error) {
+	return s, nil
+}
+
+var alice = discord.DiscordUser{ID: "1", Username: "alice"}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCreateToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ical_mocks.NewMockTokenRepository(ctrl)
+		svc := ical.NewService(repo, bookingSource{}, "https://tbz.example/api/public/calendar/", "https://tbz.example/bookings")
+
+		var storedHash string
+		repo.EXPECT().InsertToken(gomock.Any(), ical.Token{UserID: "1", Username: "alice"}, gomock.Any()).DoAndReturn(func(ctx context.Context, token ical.Token, hash string) (ical.Token, error) {
+			storedHash = hash
+			return token, nil
+		}).Times(1)
+
+		token, err := svc.CreateToken(context.Background(), alice)
+
+		require.Nil(t, err)
+		require.Len(t, token.Token, 43)
+		require.Equal(t, hash(token.Token), storedHash)
+		require.Equal(t, "https://tbz.example/api/public/calendar/"+token.Token+".ics", token.URL)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ical_mocks.NewMockTokenRepository(ctrl)
+		svc := ical.NewService(repo, bookingSource{}, "https://tbz.example/api/public/calendar", "https://tbz.example/bookings")
+
+		repo.EXPECT().InsertToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(ical.Token{}, ical.ErrTokenExists).Times(1)
+
+		_, err := svc.CreateToken(context.Background(), alice)
+
+		require.ErrorIs(t, err, ical.ErrTokenExists)
+	})
+}
+
+func TestRotateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := ical_mocks.NewMockTokenRepository(ctrl)
+	svc := ical.NewService(repo, bookingSource{}, "https://tbz.example/api/public/calendar", "https://tbz.example/bookings")
+
+	var hashes []string
+	repo.EXPECT().ReplaceToken(gomock.Any(), "1", gomock.Any()).DoAndReturn(func(ctx context.Context, userID, hash string) (ical.Token, error) {
+		hashes = append(hashes, hash)
+		return ical.Token{UserID: userID, Username: "alice"}, nil
+	}).Times(2)
+
+	first, err := svc.RotateToken(context.Background(), alice)
+	require.Nil(t, err)
+
+	second, err := svc.RotateToken(context.Background(), alice)
+	require.Nil(t, err)
+
+	require.NotEqual(t, first.Token, second.Token)
+	require.NotEqual(t, hashes[0], hashes[1])
+}
+
+func TestFeed(t *testing.T) {
+	dateTime := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	t.Run("the bookings of the owner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ical_mocks.NewMockTokenRepository(ctrl)
+		svc := ical.NewService(repo, bookingSource{
+			{ID: "3", Game: "Necromunda", UserID: "2", Username: "bob", Players: []string{"Alice"}, Status: "pending", DateTime: dateTime.Add(24 * time.Hour)},
+			{ID: "1", Game: "Kill Team", UserID: "1", Username: "alice", Status: "accepted", DateTime: dateTime},
+			{ID: "2", Game: "Blood Bowl", UserID: "2", Username: "bob", Status: "accepted", DateTime: dateTime},
+			{ID: "4", Game: "Kill Team", UserID: "1", Username: "alice", Status: "canceled", DateTime: dateTime},
+		}, "https://tbz.example/api/public/calendar", "https://tbz.example/bookings")
+
+		repo.EXPECT().UseToken(gomock.Any(), hash("secret")).Return(ical.Token{UserID: "1", Username: "alice"}, nil).Times(1)
+
+		feed, err := svc.Feed(context.Background(), "secret")
+
+		require.Nil(t, err)
+		require.Equal(t, 2, strings.Count(string(feed), "BEGIN:VEVENT"))
+		require.Less(t, strings.Index(string(feed), "UID:booking-1@tbz"), strings.Index(string(feed), "UID:booking-3@tbz"))
+		require.Contains(t, string(feed), "STATUS:TENTATIVE")
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ical_mocks.NewMockTokenRepository(ctrl)
+		svc := ical.NewService(repo, bookingSource{}, "https://tbz.example/api/public/calendar", "https://tbz.example/bookings")
+
+		repo.EXPECT().UseToken(gomock.Any(), gomock.Any()).Return(ical.Token{}, ical.ErrTokenNotFound).Times(1)
+
+		_, err := svc.Feed(context.Background(), "leaked")
+
+		require.ErrorIs(t, err, ical.ErrTokenNotFound)
+	})
+}
+
+func TestRender(t *testing.T) {
+	dateTime := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	feed := string(ical.Render("Réservations", []bk.Booking{{
+		ID:          "1",
+		Game:        "Kill Team",
+		Username:    "alice",
+		Description: "Mission; table 2, " + strings.Repeat("é", 40),
+		Status:      "accepted",
+		DateTime:    dateTime,
+		UpdatedAt:   dateTime,
+	}}, "https://tbz.example/bookings"))
+
+	require.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.Contains(t, feed, "DTSTART:20260306T190000Z\r\nDTEND:20260306T220000Z\r\n")
+	require.Contains(t, feed, `DESCRIPTION:Mission\; table 2\, `)
+
+	for _, line := range strings.Split(feed, "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+}
@@ -0,0 +1,93 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+// icsTime is the UTC date-time format of RFC 5545.
+const icsTime = "20060102T150405Z"
+
+// maxLineLength is the length in octets after which RFC 5545 lines fold.
+const maxLineLength = 75
+
+// Render writes the bookings as an iCalendar feed, the pending ones being
+// tentative events.
+func Render(name string, bookings []bk.Booking, bookingURL string) []byte {
+	var buf bytes.Buffer
+
+	line := func(name, value string) {
+		fold(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//TBZ//Reservations//FR")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escape(name))
+
+	for _, booking := range bookings {
+		status := "CONFIRMED"
+
+		if booking.Status == "pending" {
+			status = "TENTATIVE"
+		}
+
+		url := bookingURL + "/" + booking.ID
+		description := []string{}
+
+		if len(booking.Players) != 0 {
+			description = append(description, "Joueurs : "+strings.Join(booking.Players, ", "))
+		}
+
+		if len(booking.Description) != 0 {
+			description = append(description, booking.Description)
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("booking-%s@tbz", booking.ID))
+		line("DTSTAMP", booking.UpdatedAt.UTC().Format(icsTime))
+		line("DTSTART", booking.DateTime.UTC().Format(icsTime))
+		line("DTEND", booking.DateTime.Add(bk.TableDuration).UTC().Format(icsTime))
+		line("SUMMARY", escape(fmt.Sprintf("%s (%s)", booking.Game, booking.Username)))
+		line("DESCRIPTION", escape(strings.Join(append(description, url), "\n\n")))
+		line("URL", url)
+		line("STATUS", status)
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// escape escapes the text values of RFC 5545.
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// fold writes the content line, folded every 75 octets without splitting
+// the UTF-8 characters.
+func fold(buf *bytes.Buffer, line string) {
+	length := 0
+
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+
+		if length+size > maxLineLength {
+			buf.WriteString("\r\n ")
+			// the leading space counts in the folded line
+			length = 1
+		}
+
+		buf.WriteRune(r)
+		length += size
+	}
+
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/ical (interfaces: TokenRepository)
+//
+// Generated by this command:
+//
+//	mockgen . TokenRepository
+//
+
+// Package mock_ical is a generated GoMock package.
+package mock_ical
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ical "github.com/hanksha/tbz-booking-system-backend/ical"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteToken mocks base method.
+func (m *MockTokenRepository) DeleteToken(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockTokenRepositoryMockRecorder) DeleteToken(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockTokenRepository)(nil).DeleteToken), ctx, userID)
+}
+
+// GetToken mocks base method.
+func (m *MockTokenRepository) GetToken(ctx context.Context, userID string) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", ctx, userID)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockTokenRepositoryMockRecorder) GetToken(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockTokenRepository)(nil).GetToken), ctx, userID)
+}
+
+// InsertToken mocks base method.
+func (m *MockTokenRepository) InsertToken(ctx context.Context, token ical.Token, hash string) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertToken", ctx, token, hash)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertToken indicates an expected call of InsertToken.
+func (mr *MockTokenRepositoryMockRecorder) InsertToken(ctx, token, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertToken", reflect.TypeOf((*MockTokenRepository)(nil).InsertToken), ctx, token, hash)
+}
+
+// ReplaceToken mocks base method.
+func (m *MockTokenRepository) ReplaceToken(ctx context.Context, userID, hash string) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceToken", ctx, userID, hash)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceToken indicates an expected call of ReplaceToken.
+func (mr *MockTokenRepositoryMockRecorder) ReplaceToken(ctx, userID, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceToken", reflect.TypeOf((*MockTokenRepository)(nil).ReplaceToken), ctx, userID, hash)
+}
+
+// UseToken mocks base method.
+func (m *MockTokenRepository) UseToken(ctx context.Context, hash string) (ical.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseToken", ctx, hash)
+	ret0, _ := ret[0].(ical.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseToken indicates an expected call of UseToken.
+func (mr *MockTokenRepositoryMockRecorder) UseToken(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseToken", reflect.TypeOf((*MockTokenRepository)(nil).UseToken), ctx, hash)
+}
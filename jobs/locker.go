@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Locker makes sure a job runs on a single replica at a time. TryLock returns
+// false when another runner holds the lock, the run is then skipped.
+type Locker interface {
+	TryLock(ctx context.Context, name string) (unlock func(), ok bool, err error)
+}
+
+// LocalLocker only guards the jobs of this process, for the memory storage
+// and single replica deployments.
+type LocalLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{held: map[string]bool{}}
+}
+
+func (l *LocalLocker) TryLock(_ context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] {
+		return nil, false, nil
+	}
+
+	l.held[name] = true
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		delete(l.held, name)
+	}, true, nil
+}
+
+// PostgresLocker takes a session level advisory lock named after the job, on
+// a connection held for the duration of the run.
+type PostgresLocker struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresLocker(pool *pgxpool.Pool) *PostgresLocker {
+	return &PostgresLocker{pool: pool}
+}
+
+func (l *PostgresLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var ok bool
+
+	err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", "job:"+name).Scan(&ok)
+
+	if err != nil || !ok {
+		conn.Release()
+		return nil, false, err
+	}
+
+	return func() {
+		// the run context may be done already
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", "job:"+name); err != nil {
+			// closing the connection releases its locks
+			conn.Conn().Close(context.Background())
+		}
+
+		conn.Release()
+	}, true, nil
+}
@@ -0,0 +1,204 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule returns the next activation time strictly after t.
+type Schedule interface {
+	Next(t time.Time) time.Time
+	String() string
+}
+
+type every struct {
+	expr     string
+	interval time.Duration
+}
+
+// Every activates a job at a fixed interval.
+func Every(interval time.Duration) Schedule {
+	return every{expr: "@every " + interval.String(), interval: interval}
+}
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(e.interval)
+}
+
+func (e every) String() string {
+	return e.expr
+}
+
+// cron is a classic 5-field schedule, each field a bit set of the values it
+// matches.
+type cron struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64
+	// restricted day fields are ORed, as crontab(5) does
+	domStar, dowStar bool
+	loc              *time.Location
+}
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// Parse reads a schedule, either a cron expression "minute hour day-of-month
+// month day-of-week" supporting *, lists, ranges and steps, a descriptor such
+// as @daily or "@every 10m". Cron schedules are evaluated in loc.
+func Parse(expr string, loc *time.Location) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	if interval, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w '%v': bad interval", ErrInvalidSchedule, expr)
+		}
+
+		return every{expr: expr, interval: d}, nil
+	}
+
+	spec := expr
+
+	if d, ok := descriptors[expr]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w '%v': expected 5 fields", ErrInvalidSchedule, expr)
+	}
+
+	c := cron{expr: expr, loc: loc, domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+
+	bounds := []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&c.minute, 0, 59},
+		{&c.hour, 0, 23},
+		{&c.dom, 1, 31},
+		{&c.month, 1, 12},
+		{&c.dow, 0, 7},
+	}
+
+	for i, b := range bounds {
+		bits, err := parseField(fields[i], b.min, b.max)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w '%v': %v", ErrInvalidSchedule, expr, err)
+		}
+
+		*b.bits = bits
+	}
+
+	// 7 is an alias of sunday
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("bad step in '%v'", part)
+			}
+		}
+
+		lo, hi := min, max
+
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("bad value in '%v'", part)
+			}
+
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("bad value in '%v'", part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("'%v' out of range %d-%d", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func (c cron) Next(t time.Time) time.Time {
+	t = t.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	// no schedule is that sparse, the limit only guards against looping
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func (c cron) String() string {
+	return c.expr
+}
@@ -0,0 +1,51 @@
+package jobs_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/jobs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	// a Wednesday
+	now := time.Date(2025, time.January, 15, 10, 30, 0, 0, paris)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"0 9 * * *", time.Date(2025, time.January, 16, 9, 0, 0, 0, paris)},
+		{"*/15 * * * *", time.Date(2025, time.January, 15, 10, 45, 0, 0, paris)},
+		{"0 18 * * 1-5", time.Date(2025, time.January, 15, 18, 0, 0, 0, paris)},
+		{"0 10 * * 7", time.Date(2025, time.January, 19, 10, 0, 0, 0, paris)},
+		{"0 0 1,15 * *", time.Date(2025, time.February, 1, 0, 0, 0, 0, paris)},
+		{"0 0 31 * *", time.Date(2025, time.January, 31, 0, 0, 0, 0, paris)},
+		{"30 3 1 6 *", time.Date(2025, time.June, 1, 3, 30, 0, 0, paris)},
+		// restricted day of month and day of week are ORed
+		{"0 12 1 * 5", time.Date(2025, time.January, 17, 12, 0, 0, 0, paris)},
+		{"@daily", time.Date(2025, time.January, 16, 0, 0, 0, 0, paris)},
+		{"@every 10m", now.Add(10 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := jobs.Parse(tt.expr, paris)
+
+			require.Nil(t, err)
+			require.True(t, tt.next.Equal(schedule.Next(now)), "got %v", schedule.Next(now))
+			require.Equal(t, tt.expr, schedule.String())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, expr := range []string{"", "0 9 * *", "60 * * * *", "0 9 * * mon", "*/0 * * * *", "5-1 * * * *", "@every -1m"} {
+			_, err := jobs.Parse(expr, paris)
+
+			require.True(t, errors.Is(err, jobs.ErrInvalidSchedule), expr)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Schedule Schedule
+	// Timeout bounds a run, zero leaves it unbounded
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// Status is the health of a job as seen by this replica, runs skipped
+// because another replica held the lock are only counted.
+type Status struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	Running      bool       `json:"running"`
+	NextRun      *time.Time `json:"nextRun,omitempty"`
+	LastRun      *time.Time `json:"lastRun,omitempty"`
+	LastDuration string     `json:"lastDuration,omitempty"`
+	LastError    string     `json:"lastError,omitempty"`
+	LastSuccess  *time.Time `json:"lastSuccess,omitempty"`
+	Runs         int        `json:"runs"`
+	Failures     int        `json:"failures"`
+	Skipped      int        `json:"skipped"`
+}
+
+type Scheduler struct {
+	locker Locker
+	mu     sync.RWMutex
+	jobs   []Job
+	status map[string]*Status
+}
+
+func NewScheduler(locker Locker) *Scheduler {
+	return &Scheduler{locker: locker, status: map[string]*Status{}}
+}
+
+// Add registers a job, it must be called before Start.
+func (s *Scheduler) Add(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job)
+	s.status[job.Name] = &Status{Name: job.Name, Schedule: job.Schedule.String()}
+}
+
+// Start runs the jobs on their schedule until ctx is done, then waits for the
+// runs in progress.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	s.mu.RLock()
+	for _, job := range s.jobs {
+		wg.Go(func() { s.loop(ctx, job) })
+	}
+	s.mu.RUnlock()
+
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		next := job.Schedule.Next(time.Now())
+
+		if next.IsZero() {
+			slog.Warn("Job has no next run", "job", job.Name)
+			return
+		}
+
+		s.update(job.Name, func(st *Status) { st.NextRun = &next })
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.run(ctx, job)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	unlock, ok, err := s.locker.TryLock(ctx, job.Name)
+
+	if err != nil {
+		slog.Error("Failed to lock job", "job", job.Name, "error", err)
+		s.update(job.Name, func(st *Status) { st.Skipped++ })
+		return
+	}
+
+	if !ok {
+		slog.Debug("Job is running elsewhere, skipped", "job", job.Name)
+		s.update(job.Name, func(st *Status) { st.Skipped++ })
+		return
+	}
+
+	defer unlock()
+
+	start := time.Now()
+	s.update(job.Name, func(st *Status) {
+		st.Running = true
+		st.LastRun = &start
+	})
+
+	slog.Info("Job started", "job", job.Name)
+
+	err = s.call(ctx, job)
+	duration := time.Since(start)
+
+	s.update(job.Name, func(st *Status) {
+		st.Running = false
+		st.Runs++
+		st.LastDuration = duration.Round(time.Millisecond).String()
+		st.LastError = ""
+
+		if err != nil {
+			st.Failures++
+			st.LastError = err.Error()
+		} else {
+			end := start.Add(duration)
+			st.LastSuccess = &end
+		}
+	})
+
+	if err != nil {
+		slog.Error("Job failed", "job", job.Name, "duration", duration, "error", err)
+	} else {
+		slog.Info("Job completed", "job", job.Name, "duration", duration)
+	}
+}
+
+func (s *Scheduler) call(ctx context.Context, job Job) (err error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+func (s *Scheduler) update(name string, fn func(*Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.status[name])
+}
+
+// Statuses lists the jobs by name.
+func (s *Scheduler) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(s.status))
+
+	for _, st := range s.status {
+		statuses = append(statuses, *st)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	return statuses
+}
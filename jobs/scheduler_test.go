@@ -0,0 +1,79 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/jobs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Run("records runs and failures", func(t *testing.T) {
+		var calls atomic.Int32
+
+		scheduler := jobs.NewScheduler(jobs.NewLocalLocker())
+		scheduler.Add(jobs.Job{
+			Name:     "flaky",
+			Schedule: jobs.Every(5 * time.Millisecond),
+			Run: func(ctx context.Context) error {
+				if calls.Add(1)%2 == 0 {
+					return errors.New("discord is down")
+				}
+				return nil
+			},
+		})
+		scheduler.Add(jobs.Job{
+			Name:     "panicking",
+			Schedule: jobs.Every(5 * time.Millisecond),
+			Run:      func(ctx context.Context) error { panic("nil booking") },
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		scheduler.Start(ctx)
+
+		statuses := scheduler.Statuses()
+
+		require.Len(t, statuses, 2)
+
+		flaky := statuses[0]
+		require.Equal(t, "flaky", flaky.Name)
+		require.False(t, flaky.Running)
+		require.GreaterOrEqual(t, flaky.Runs, 2)
+		require.GreaterOrEqual(t, flaky.Failures, 1)
+		require.NotNil(t, flaky.LastSuccess)
+		require.NotNil(t, flaky.NextRun)
+
+		panicking := statuses[1]
+		require.Equal(t, panicking.Runs, panicking.Failures)
+		require.Equal(t, "panic: nil booking", panicking.LastError)
+	})
+
+	t.Run("skips runs while locked elsewhere", func(t *testing.T) {
+		locker := jobs.NewLocalLocker()
+		unlock, ok, _ := locker.TryLock(context.Background(), "reminders")
+		require.True(t, ok)
+		defer unlock()
+
+		scheduler := jobs.NewScheduler(locker)
+		scheduler.Add(jobs.Job{
+			Name:     "reminders",
+			Schedule: jobs.Every(5 * time.Millisecond),
+			Run:      func(ctx context.Context) error { return nil },
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		scheduler.Start(ctx)
+
+		status := scheduler.Statuses()[0]
+		require.Zero(t, status.Runs)
+		require.Greater(t, status.Skipped, 0)
+	})
+}
@@ -0,0 +1,22 @@
+package member
+
+import "time"
+
+// Member is a user of the club registry, either a member paying their fee or
+// a guest the admins let book the prime-time slots.
+type Member struct {
+	UserID   string `json:"userId" binding:"required"`
+	Username string `json:"username"`
+	Guest    bool   `json:"guest"`
+	// ExpiresAt ends the membership, or the guest's invitation, it never
+	// does when nil.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Note      string     `json:"note"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// InGoodStanding tells whether the membership is still valid at now.
+func (m Member) InGoodStanding(now time.Time) bool {
+	return m.ExpiresAt == nil || now.Before(*m.ExpiresAt)
+}
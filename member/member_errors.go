@@ -0,0 +1,7 @@
+package member
+
+import "errors"
+
+var ErrMemberNotFound = errors.New("member not found")
+
+var ErrInvalidMember = errors.New("invalid member")
@@ -0,0 +1,133 @@
+package member
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const memberColumns = `"userId", COALESCE(username, ''), guest, "expiresAt", COALESCE(note, ''), COALESCE("createdBy", ''), "createdAt"`
+
+func scanMember(row pgx.Row) (Member, error) {
+	var member Member
+	err := row.Scan(
+		&member.UserID,
+		&member.Username,
+		&member.Guest,
+		&member.ExpiresAt,
+		&member.Note,
+		&member.CreatedBy,
+		&member.CreatedAt,
+	)
+
+	return member, err
+}
+
+func (r *Repository) GetMembers(ctx context.Context) ([]Member, error) {
+	sql := `
+		SELECT ` + memberColumns + `
+		FROM "game-table-booking".member
+		ORDER BY guest, username;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch members: %w", err)
+	}
+
+	defer rows.Close()
+
+	members := []Member{}
+
+	for rows.Next() {
+		member, err := scanMember(rows)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning member row: %w", err)
+		}
+
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating member rows: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *Repository) GetMember(ctx context.Context, userID string) (Member, error) {
+	sql := `
+		SELECT ` + memberColumns + `
+		FROM "game-table-booking".member
+		WHERE "userId"=$1;
+	`
+
+	member, err := scanMember(r.conn.QueryRow(ctx, sql, userID))
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Member{}, ErrMemberNotFound
+	}
+
+	if err != nil {
+		return Member{}, fmt.Errorf("failed to fetch member %v: %w", userID, err)
+	}
+
+	return member, nil
+}
+
+func (r *Repository) UpsertMember(ctx context.Context, member Member) (Member, error) {
+	sql := `
+		INSERT INTO "game-table-booking".member("userId", username, guest, "expiresAt", note, "createdBy")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("userId") DO UPDATE SET
+			username=EXCLUDED.username,
+			guest=EXCLUDED.guest,
+			"expiresAt"=EXCLUDED."expiresAt",
+			note=EXCLUDED.note,
+			"createdBy"=EXCLUDED."createdBy",
+			"createdAt"=now()
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		member.UserID,
+		member.Username,
+		member.Guest,
+		member.ExpiresAt,
+		member.Note,
+		member.CreatedBy,
+	).Scan(&member.CreatedAt)
+
+	if err != nil {
+		return Member{}, fmt.Errorf("failed to save member: %w", err)
+	}
+
+	return member, nil
+}
+
+func (r *Repository) DeleteMember(ctx context.Context, userID string) error {
+	sql := `DELETE FROM "game-table-booking".member WHERE "userId"=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete member '%v': %w", userID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
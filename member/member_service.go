@@ -0,0 +1,111 @@
+package member
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type MemberRepository interface {
+	GetMembers(ctx context.Context) ([]Member, error)
+	GetMember(ctx context.Context, userID string) (Member, error)
+	UpsertMember(ctx context.Context, member Member) (Member, error)
+	DeleteMember(ctx context.Context, userID string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  MemberRepository
+	audit AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo MemberRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetMembers(ctx context.Context) ([]Member, error) {
+	return s.repo.GetMembers(ctx)
+}
+
+// SaveMember registers a member, or whitelists a guest, replacing the
+// previous registration of the user.
+func (s *Service) SaveMember(ctx context.Context, member Member) (Member, error) {
+	member.UserID = strings.TrimSpace(member.UserID)
+
+	if len(member.UserID) == 0 {
+		return Member{}, fmt.Errorf("%w: userId cannot be empty", ErrInvalidMember)
+	}
+
+	if admin, ok := discord.UserFromContext(ctx); ok {
+		member.CreatedBy = admin.Username
+	}
+
+	saved, err := s.repo.UpsertMember(ctx, member)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "member.create", "user", saved.UserID, map[string]any{"guest": saved.Guest, "expiresAt": saved.ExpiresAt})
+	}
+
+	return saved, err
+}
+
+func (s *Service) RemoveMember(ctx context.Context, userID string) error {
+	err := s.repo.DeleteMember(ctx, userID)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "member.delete", "user", userID, nil)
+	}
+
+	return err
+}
+
+// IsMember implements booking.MemberChecker, guests are not members.
+func (s *Service) IsMember(ctx context.Context, userID string) (bool, error) {
+	member, ok, err := s.current(ctx, userID)
+
+	return ok && !member.Guest, err
+}
+
+// IsGuest implements booking.MemberChecker.
+func (s *Service) IsGuest(ctx context.Context, userID string) (bool, error) {
+	member, ok, err := s.current(ctx, userID)
+
+	return ok && member.Guest, err
+}
+
+// current returns the registration of the user, ok being false when there is
+// none or it expired.
+func (s *Service) current(ctx context.Context, userID string) (Member, bool, error) {
+	member, err := s.repo.GetMember(ctx, userID)
+
+	if errors.Is(err, ErrMemberNotFound) {
+		return Member{}, false, nil
+	}
+
+	if err != nil {
+		return Member{}, false, err
+	}
+
+	return member, member.InGoodStanding(time.Now()), nil
+}
@@ -0,0 +1,102 @@
+package member_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/member"
+	member_mocks "github.com/hanksha/tbz-booking-system-backend/member/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMembership(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	valid := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		member   member.Member
+		err      error
+		isMember bool
+		isGuest  bool
+	}{
+		{name: "member", member: member.Member{UserID: "1"}, isMember: true},
+		{name: "member until next day", member: member.Member{UserID: "1", ExpiresAt: &valid}, isMember: true},
+		{name: "expired membership", member: member.Member{UserID: "1", ExpiresAt: &expired}},
+		{name: "guest", member: member.Member{UserID: "1", Guest: true}, isGuest: true},
+		{name: "expired invitation", member: member.Member{UserID: "1", Guest: true, ExpiresAt: &expired}},
+		{name: "unknown", err: member.ErrMemberNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := member_mocks.NewMockMemberRepository(ctrl)
+			svc := member.NewService(repo)
+
+			repo.EXPECT().GetMember(gomock.Any(), "1").Return(tt.member, tt.err).Times(2)
+
+			isMember, err := svc.IsMember(context.Background(), "1")
+			require.Nil(t, err)
+			require.Equal(t, tt.isMember, isMember)
+
+			isGuest, err := svc.IsGuest(context.Background(), "1")
+			require.Nil(t, err)
+			require.Equal(t, tt.isGuest, isGuest)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := member_mocks.NewMockMemberRepository(ctrl)
+		svc := member.NewService(repo)
+		failure := errors.New("connection refused")
+
+		repo.EXPECT().GetMember(gomock.Any(), "1").Return(member.Member{}, failure).Times(1)
+
+		_, err := svc.IsMember(context.Background(), "1")
+
+		require.ErrorIs(t, err, failure)
+	})
+}
+
+func TestSaveMember(t *testing.T) {
+	t.Run("records the admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := member_mocks.NewMockMemberRepository(ctrl)
+		svc := member.NewService(repo)
+		ctx := discord.ContextWithUser(context.Background(), discord.DiscordUser{ID: "9", Username: "admin", Admin: true})
+		expected := member.Member{UserID: "1", Username: "john", Guest: true, CreatedBy: "admin"}
+
+		repo.EXPECT().UpsertMember(gomock.Any(), expected).Return(expected, nil).Times(1)
+
+		saved, err := svc.SaveMember(ctx, member.Member{UserID: " 1 ", Username: "john", Guest: true})
+
+		require.Nil(t, err)
+		require.Equal(t, expected, saved)
+	})
+
+	t.Run("empty user id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := member_mocks.NewMockMemberRepository(ctrl)
+		svc := member.NewService(repo)
+
+		repo.EXPECT().UpsertMember(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.SaveMember(context.Background(), member.Member{UserID: "  "})
+
+		require.ErrorIs(t, err, member.ErrInvalidMember)
+	})
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/member (interfaces: MemberRepository)
+//
+// Generated by this command:
+//
+//	mockgen . MemberRepository
+//
+
+// Package mock_member is a generated GoMock package.
+package mock_member
+
+import (
+	context "context"
+	reflect "reflect"
+
+	member "github.com/hanksha/tbz-booking-system-backend/member"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMemberRepository is a mock of MemberRepository interface.
+type MockMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMemberRepositoryMockRecorder is the mock recorder for MockMemberRepository.
+type MockMemberRepositoryMockRecorder struct {
+	mock *MockMemberRepository
+}
+
+// NewMockMemberRepository creates a new mock instance.
+func NewMockMemberRepository(ctrl *gomock.Controller) *MockMemberRepository {
+	mock := &MockMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberRepository) EXPECT() *MockMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteMember mocks base method.
+func (m *MockMemberRepository) DeleteMember(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockMemberRepositoryMockRecorder) DeleteMember(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockMemberRepository)(nil).DeleteMember), ctx, userID)
+}
+
+// GetMember mocks base method.
+func (m *MockMemberRepository) GetMember(ctx context.Context, userID string) (member.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, userID)
+	ret0, _ := ret[0].(member.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockMemberRepositoryMockRecorder) GetMember(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockMemberRepository)(nil).GetMember), ctx, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockMemberRepository) GetMembers(ctx context.Context) ([]member.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx)
+	ret0, _ := ret[0].([]member.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockMemberRepositoryMockRecorder) GetMembers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockMemberRepository)(nil).GetMembers), ctx)
+}
+
+// UpsertMember mocks base method.
+func (m *MockMemberRepository) UpsertMember(ctx context.Context, arg1 member.Member) (member.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMember", ctx, arg1)
+	ret0, _ := ret[0].(member.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMember indicates an expected call of UpsertMember.
+func (mr *MockMemberRepositoryMockRecorder) UpsertMember(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMember", reflect.TypeOf((*MockMemberRepository)(nil).UpsertMember), ctx, arg1)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/hanksha/tbz-booking-system-backend/database"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | version | force <version>"
+
+// runMigrateCommand handles `server migrate ...` so the schema can be managed
+// without starting the API.
+func runMigrateCommand(ctx context.Context, migrator *database.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps '%v': %s", args[1], migrateUsage)
+			}
+		}
+
+		return migrator.Down(ctx, steps)
+	case "version":
+		version, dirty, err := migrator.Version(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		slog.Info("schema version", "version", version, "dirty", dirty)
+		return nil
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.Atoi(args[1])
+
+		if err != nil {
+			return fmt.Errorf("invalid version '%v': %s", args[1], migrateUsage)
+		}
+
+		return migrator.Force(ctx, version)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Notification is a Discord message which could not be delivered after
+// every attempt, kept so admins can retry or discard it.
+type Notification struct {
+	ID        string          `json:"id"`
+	ChannelID string          `json:"channelId"`
+	Message   discord.Message `json:"message"`
+	// Subject is what the message is about, such as "booking:12"
+	Subject   string    `json:"subject,omitempty"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError"`
+	CreatedAt time.Time `json:"createdAt"`
+	FailedAt  time.Time `json:"failedAt"`
+}
+
+type subjectKey struct{}
+
+// WithSubject tags the messages sent with ctx with what they are about, so
+// failure handlers can tell, e.g. WithSubject(ctx, "booking:12").
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+func subjectFrom(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectKey{}).(string)
+	return subject
+}
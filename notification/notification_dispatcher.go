@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Dispatcher runs tasks, such as building and sending a notification, on a
+// bounded pool of workers so they stay off the request path. Tasks may run
+// in any order.
+type Dispatcher struct {
+	tasks  chan func()
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+	logger *slog.Logger
+}
+
+// NewDispatcher starts workers goroutines sharing a queue of queueSize tasks.
+func NewDispatcher(workers, queueSize int) *Dispatcher {
+	d := &Dispatcher{
+		tasks:  make(chan func(), queueSize),
+		logger: slog.Default().With("component", "notification"),
+	}
+
+	for range workers {
+		d.wg.Go(func() {
+			for task := range d.tasks {
+				task()
+			}
+		})
+	}
+
+	return d
+}
+
+// Dispatch queues task, given a context which outlives the request. When
+// the queue is full the task is dropped, Discord being too slow to keep up.
+// Once shut down, tasks run on the caller's goroutine.
+func (d *Dispatcher) Dispatch(ctx context.Context, task func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		task(ctx)
+		return
+	}
+
+	select {
+	case d.tasks <- func() { d.run(ctx, task) }:
+	default:
+		d.logger.Error("notification queue is full, dropping notification", "queueSize", cap(d.tasks))
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context, task func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.logger.Error("notification task panicked", "panic", r)
+		}
+	}()
+
+	task(ctx)
+}
+
+// Shutdown stops accepting tasks and waits for the queued ones until ctx is
+// done.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.tasks)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for queued notifications: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,75 @@
+package notification_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/notification"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("runs tasks off the caller", func(t *testing.T) {
+		d := notification.NewDispatcher(2, 10)
+		release := make(chan struct{})
+		var done atomic.Int32
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "user"))
+
+		for range 3 {
+			d.Dispatch(ctx, func(ctx context.Context) {
+				<-release
+				// the request is over, its values are kept
+				if ctx.Err() == nil && ctx.Value(ctxKey{}) == "user" {
+					done.Add(1)
+				}
+			})
+		}
+
+		cancel()
+		require.Zero(t, done.Load())
+
+		close(release)
+		require.Nil(t, d.Shutdown(context.Background()))
+		require.Equal(t, int32(3), done.Load())
+	})
+
+	t.Run("drops tasks when full", func(t *testing.T) {
+		d := notification.NewDispatcher(1, 1)
+		release := make(chan struct{})
+		started := make(chan struct{})
+		var done atomic.Int32
+
+		d.Dispatch(context.Background(), func(ctx context.Context) {
+			close(started)
+			<-release
+			done.Add(1)
+		})
+		<-started
+
+		for range 3 {
+			d.Dispatch(context.Background(), func(ctx context.Context) { done.Add(1) })
+		}
+
+		close(release)
+		require.Nil(t, d.Shutdown(context.Background()))
+		require.Equal(t, int32(2), done.Load())
+	})
+
+	t.Run("shutdown times out", func(t *testing.T) {
+		d := notification.NewDispatcher(1, 1)
+		release := make(chan struct{})
+		defer close(release)
+
+		d.Dispatch(context.Background(), func(ctx context.Context) { <-release })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		require.Error(t, d.Shutdown(ctx))
+	})
+}
@@ -0,0 +1,256 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type Sender interface {
+	SendMessage(ctx context.Context, channelID string, message discord.Message) error
+}
+
+// Service sends Discord messages, retrying failed sends in the background
+// with an exponential backoff. Messages still failing are moved to a
+// dead-letter list kept in memory by each replica.
+type Service struct {
+	sender          Sender
+	maxAttempts     int
+	retryDelay      time.Duration
+	deadLetterLimit int
+	onFailure       func(ctx context.Context, notification Notification)
+	logger          *slog.Logger
+
+	mu          sync.Mutex
+	deadLetters []Notification
+	nextID      int
+
+	wg sync.WaitGroup
+	// stopping is closed on shutdown to abandon pending retries
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+type ServiceOption func(*Service)
+
+// WithRetry sets how many times a message is sent and the delay before the
+// first retry, doubled on each following attempt.
+func WithRetry(maxAttempts int, retryDelay time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxAttempts = maxAttempts
+		s.retryDelay = retryDelay
+	}
+}
+
+// WithDeadLetterLimit bounds the dead-letter list, the oldest messages are
+// dropped first.
+func WithDeadLetterLimit(limit int) ServiceOption {
+	return func(s *Service) {
+		s.deadLetterLimit = limit
+	}
+}
+
+// WithFailureHandler calls fn with each message moved to the dead letters.
+func WithFailureHandler(fn func(ctx context.Context, notification Notification)) ServiceOption {
+	return func(s *Service) {
+		s.onFailure = fn
+	}
+}
+
+func NewService(sender Sender, opts ...ServiceOption) *Service {
+	s := &Service{
+		sender:          sender,
+		maxAttempts:     6,
+		retryDelay:      5 * time.Second,
+		deadLetterLimit: 100,
+		logger:          slog.Default().With("component", "notification"),
+		nextID:          1,
+		stopping:        make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SendMessage sends the message once and, when it fails, schedules retries
+// and returns nil: the message is then owned by the service.
+func (s *Service) SendMessage(ctx context.Context, channelID string, message discord.Message) error {
+	err := s.sender.SendMessage(ctx, channelID, message)
+
+	if err == nil {
+		return nil
+	}
+
+	s.logger.Warn("failed to send Discord message, retrying", "channelId", channelID, "err", err)
+
+	notification := Notification{
+		ChannelID: channelID,
+		Message:   message,
+		Subject:   subjectFrom(ctx),
+		Attempts:  1,
+		LastError: err.Error(),
+		CreatedAt: time.Now(),
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.retry(ctx, notification)
+	}()
+
+	return nil
+}
+
+func (s *Service) retry(ctx context.Context, notification Notification) {
+	delay := s.retryDelay
+
+	for notification.Attempts < s.maxAttempts {
+		select {
+		case <-time.After(delay):
+		case <-s.stopping:
+			s.logger.Warn("Discord message abandoned on shutdown", "channelId", notification.ChannelID, "attempts", notification.Attempts)
+			return
+		}
+
+		notification.Attempts++
+
+		err := s.sender.SendMessage(ctx, notification.ChannelID, notification.Message)
+
+		if err == nil {
+			return
+		}
+
+		notification.LastError = err.Error()
+		delay *= 2
+	}
+
+	s.logger.Error("Discord message failed, moved to dead letters", "channelId", notification.ChannelID, "subject", notification.Subject, "attempts", notification.Attempts, "err", notification.LastError)
+	s.deadLetter(notification)
+
+	if s.onFailure != nil {
+		s.onFailure(ctx, notification)
+	}
+}
+
+func (s *Service) deadLetter(notification Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notification.ID = strconv.Itoa(s.nextID)
+	notification.FailedAt = time.Now()
+	s.nextID++
+
+	s.deadLetters = append(s.deadLetters, notification)
+
+	if len(s.deadLetters) > s.deadLetterLimit {
+		s.deadLetters = slices.Delete(s.deadLetters, 0, len(s.deadLetters)-s.deadLetterLimit)
+	}
+}
+
+// GetDeadLetters returns the messages which could not be delivered, most
+// recent first.
+func (s *Service) GetDeadLetters(ctx context.Context) ([]Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadLetters := slices.Clone(s.deadLetters)
+	slices.Reverse(deadLetters)
+
+	return deadLetters, nil
+}
+
+// RetryDeadLetter sends a dead letter once more, it is removed when the send
+// succeeds.
+func (s *Service) RetryDeadLetter(ctx context.Context, id string) (Notification, error) {
+	notification, err := s.find(id)
+
+	if err != nil {
+		return Notification{}, err
+	}
+
+	notification.Attempts++
+
+	if err := s.sender.SendMessage(ctx, notification.ChannelID, notification.Message); err != nil {
+		notification.LastError = err.Error()
+		notification.FailedAt = time.Now()
+		s.update(notification)
+
+		return notification, fmt.Errorf("failed to send notification '%v': %w", id, err)
+	}
+
+	return notification, s.DiscardDeadLetter(ctx, id)
+}
+
+func (s *Service) DiscardDeadLetter(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.deadLetters, func(n Notification) bool { return n.ID == id })
+
+	if i < 0 {
+		return ErrNotificationNotFound
+	}
+
+	s.deadLetters = slices.Delete(s.deadLetters, i, i+1)
+
+	return nil
+}
+
+func (s *Service) find(id string) (Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, n := range s.deadLetters {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+
+	return Notification{}, ErrNotificationNotFound
+}
+
+func (s *Service) update(notification Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, n := range s.deadLetters {
+		if n.ID == notification.ID {
+			s.deadLetters[i] = notification
+		}
+	}
+}
+
+// Wait blocks until the pending retries are done.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown abandons pending retries and waits for the sends in progress
+// until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stopping) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for notifications: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,89 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/notification"
+	"github.com/stretchr/testify/require"
+)
+
+// flakySender fails the first failures sends.
+type flakySender struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (s *flakySender) SendMessage(ctx context.Context, channelID string, message discord.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	if s.calls <= s.failures {
+		return errors.New("discord is down")
+	}
+
+	return nil
+}
+
+func TestSendMessage(t *testing.T) {
+	message := discord.Message{Content: "Nouvelle Réservation"}
+
+	t.Run("retries until sent", func(t *testing.T) {
+		sender := &flakySender{failures: 2}
+		svc := notification.NewService(sender, notification.WithRetry(3, time.Millisecond))
+
+		err := svc.SendMessage(context.Background(), "channel", message)
+
+		require.Nil(t, err)
+		svc.Wait()
+
+		deadLetters, _ := svc.GetDeadLetters(context.Background())
+		require.Equal(t, 3, sender.calls)
+		require.Empty(t, deadLetters)
+	})
+
+	t.Run("dead letter after every attempt", func(t *testing.T) {
+		sender := &flakySender{failures: 3}
+		var failed []notification.Notification
+		svc := notification.NewService(sender, notification.WithRetry(3, time.Millisecond),
+			notification.WithFailureHandler(func(ctx context.Context, n notification.Notification) {
+				failed = append(failed, n)
+			}),
+		)
+
+		svc.SendMessage(notification.WithSubject(context.Background(), "booking:12"), "channel", message)
+		svc.Wait()
+
+		require.Len(t, failed, 1)
+		require.Equal(t, "booking:12", failed[0].Subject)
+
+		deadLetters, _ := svc.GetDeadLetters(context.Background())
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, "channel", deadLetters[0].ChannelID)
+		require.Equal(t, 3, deadLetters[0].Attempts)
+		require.Equal(t, "discord is down", deadLetters[0].LastError)
+
+		// Discord is back
+		_, err := svc.RetryDeadLetter(context.Background(), deadLetters[0].ID)
+
+		require.Nil(t, err)
+
+		deadLetters, _ = svc.GetDeadLetters(context.Background())
+		require.Empty(t, deadLetters)
+	})
+
+	t.Run("unknown dead letter", func(t *testing.T) {
+		svc := notification.NewService(&flakySender{})
+
+		_, err := svc.RetryDeadLetter(context.Background(), "42")
+		require.ErrorIs(t, err, notification.ErrNotificationNotFound)
+		require.ErrorIs(t, svc.DiscardDeadLetter(context.Background(), "42"), notification.ErrNotificationNotFound)
+	})
+}
@@ -0,0 +1,197 @@
+// Package payment collects the table fees of the bookings with Stripe
+// Checkout, through its HTTP API.
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+// SignatureHeader authenticates the webhook calls of Stripe.
+const SignatureHeader = "Stripe-Signature"
+
+// signatureTolerance is how old a signed webhook call may be, older ones
+// being replays.
+const signatureTolerance = 5 * time.Minute
+
+var ErrInvalidSignature = errors.New("invalid Stripe signature")
+
+// StripeConfig holds the keys of the Stripe account and where its checkout
+// pages send the organizers back.
+type StripeConfig struct {
+	APIURL        string
+	SecretKey     string
+	WebhookSecret string
+	SuccessURL    string
+	CancelURL     string
+}
+
+// StripeClient implements booking.PaymentProvider with Stripe Checkout.
+type StripeClient struct {
+	cfg    StripeConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewStripeClient(cfg StripeConfig, client *http.Client) *StripeClient {
+	cfg.APIURL = strings.TrimSuffix(cfg.APIURL, "/")
+
+	return &StripeClient{cfg: cfg, client: client, now: time.Now}
+}
+
+// CreateCheckout creates the checkout session of the table fee of a
+// booking. Creating it again for the same booking within a day returns the
+// same session.
+func (c *StripeClient) CreateCheckout(ctx context.Context, request bk.CheckoutRequest) (bk.CheckoutSession, error) {
+	form := url.Values{}
+	form.Set("mode", "payment")
+	form.Set("client_reference_id", request.BookingID)
+	form.Set("metadata[bookingId]", request.BookingID)
+	form.Set("success_url", c.cfg.SuccessURL)
+	form.Set("cancel_url", c.cfg.CancelURL)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("line_items[0][price_data][currency]", request.Currency)
+	form.Set("line_items[0][price_data][unit_amount]", strconv.FormatInt(request.Amount, 10))
+	form.Set("line_items[0][price_data][product_data][name]", request.Description)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.APIURL+"/v1/checkout/sessions", strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return bk.CheckoutSession{}, fmt.Errorf("failed to create checkout request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.cfg.SecretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// retries after a lost response must not charge twice
+	req.Header.Set("Idempotency-Key", "checkout-"+request.BookingID)
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		return bk.CheckoutSession{}, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return bk.CheckoutSession{}, fmt.Errorf("failed to create checkout session: %w", responseError(res))
+	}
+
+	var session struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&session); err != nil {
+		return bk.CheckoutSession{}, fmt.Errorf("failed to decode checkout session: %w", err)
+	}
+
+	return bk.CheckoutSession{ID: session.ID, URL: session.URL}, nil
+}
+
+// ParseWebhook authenticates a webhook call of Stripe and returns the booking
+// whose checkout was paid. The other events are acknowledged and ignored.
+func (c *StripeClient) ParseWebhook(payload []byte, signature string) (bk.PaymentConfirmation, bool, error) {
+	if err := verifySignature(payload, signature, c.cfg.WebhookSecret, c.now()); err != nil {
+		return bk.PaymentConfirmation{}, false, err
+	}
+
+	var event struct {
+		Type string `json:"type"`
+		Data struct {
+			Object struct {
+				ID                string `json:"id"`
+				ClientReferenceID string `json:"client_reference_id"`
+				PaymentStatus     string `json:"payment_status"`
+			} `json:"object"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return bk.PaymentConfirmation{}, false, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	// delayed payment methods complete the session before paying it
+	if event.Type != "checkout.session.completed" && event.Type != "checkout.session.async_payment_succeeded" {
+		return bk.PaymentConfirmation{}, false, nil
+	}
+
+	session := event.Data.Object
+
+	if session.PaymentStatus != "paid" || len(session.ClientReferenceID) == 0 {
+		return bk.PaymentConfirmation{}, false, nil
+	}
+
+	return bk.PaymentConfirmation{BookingID: session.ClientReferenceID, SessionID: session.ID}, true, nil
+}
+
+// verifySignature checks the Stripe-Signature header, a timestamp t and the
+// v1 HMAC-SHA256 signatures of the timestamp and payload.
+func verifySignature(payload []byte, header, secret string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+
+	if now.Sub(time.Unix(seconds, 0)).Abs() > signatureTolerance {
+		return fmt.Errorf("%w: timestamp outside the tolerance", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no matching signature", ErrInvalidSignature)
+}
+
+// responseError reads the message of a Stripe error response.
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+
+	var failure struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(body, &failure) == nil && len(failure.Error.Message) != 0 {
+		return fmt.Errorf("status %d: %v", res.StatusCode, failure.Error.Message)
+	}
+
+	return fmt.Errorf("status %d", res.StatusCode)
+}
@@ -0,0 +1,112 @@
+package payment_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/payment"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCheckout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/checkout/sessions", r.URL.Path)
+		require.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		require.Equal(t, "checkout-12", r.Header.Get("Idempotency-Key"))
+		require.Nil(t, r.ParseForm())
+		require.Equal(t, "12", r.PostForm.Get("client_reference_id"))
+		require.Equal(t, "500", r.PostForm.Get("line_items[0][price_data][unit_amount]"))
+		require.Equal(t, "eur", r.PostForm.Get("line_items[0][price_data][currency]"))
+		require.Equal(t, "https://app.example/paid", r.PostForm.Get("success_url"))
+
+		fmt.Fprint(w, `{"id":"cs_1","url":"https://checkout.stripe.com/c/cs_1"}`)
+	}))
+	defer server.Close()
+
+	client := payment.NewStripeClient(payment.StripeConfig{APIURL: server.URL + "/", SecretKey: "sk_test", SuccessURL: "https://app.example/paid"}, server.Client())
+
+	session, err := client.CreateCheckout(context.Background(), bk.CheckoutRequest{BookingID: "12", Description: "Table", Amount: 500, Currency: "eur"})
+
+	require.Nil(t, err)
+	require.Equal(t, bk.CheckoutSession{ID: "cs_1", URL: "https://checkout.stripe.com/c/cs_1"}, session)
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"Invalid currency: zzz"}}`)
+		}))
+		defer server.Close()
+
+		client := payment.NewStripeClient(payment.StripeConfig{APIURL: server.URL}, server.Client())
+
+		_, err := client.CreateCheckout(context.Background(), bk.CheckoutRequest{BookingID: "12", Amount: 500, Currency: "zzz"})
+
+		require.ErrorContains(t, err, "Invalid currency: zzz")
+	})
+}
+
+func TestParseWebhook(t *testing.T) {
+	client := payment.NewStripeClient(payment.StripeConfig{WebhookSecret: "whsec_test"}, http.DefaultClient)
+	completed := []byte(`{"type":"checkout.session.completed","data":{"object":{"id":"cs_1","client_reference_id":"12","payment_status":"paid"}}}`)
+
+	t.Run("completed checkout", func(t *testing.T) {
+		confirmation, ok, err := client.ParseWebhook(completed, sign(completed, "whsec_test", time.Now()))
+
+		require.Nil(t, err)
+		require.True(t, ok)
+		require.Equal(t, bk.PaymentConfirmation{BookingID: "12", SessionID: "cs_1"}, confirmation)
+	})
+
+	t.Run("unpaid checkout", func(t *testing.T) {
+		payload := []byte(`{"type":"checkout.session.completed","data":{"object":{"id":"cs_1","client_reference_id":"12","payment_status":"unpaid"}}}`)
+
+		_, ok, err := client.ParseWebhook(payload, sign(payload, "whsec_test", time.Now()))
+
+		require.Nil(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("other event", func(t *testing.T) {
+		payload := []byte(`{"type":"customer.created","data":{"object":{"id":"cus_1"}}}`)
+
+		_, ok, err := client.ParseWebhook(payload, sign(payload, "whsec_test", time.Now()))
+
+		require.Nil(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, _, err := client.ParseWebhook(completed, sign(completed, "whsec_other", time.Now()))
+
+		require.ErrorIs(t, err, payment.ErrInvalidSignature)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		_, _, err := client.ParseWebhook(completed, sign(completed, "whsec_test", time.Now().Add(-time.Hour)))
+
+		require.ErrorIs(t, err, payment.ErrInvalidSignature)
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		_, _, err := client.ParseWebhook(completed, "v1=abc")
+
+		require.ErrorIs(t, err, payment.ErrInvalidSignature)
+	})
+}
+
+func sign(payload []byte, secret string, at time.Time) string {
+	timestamp := fmt.Sprint(at.Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%v,v1=%v", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/permission (interfaces: MappingRepository)
+//
+// Generated by this command:
+//
+//	mockgen . MappingRepository
+//
+
+// Package mock_permission is a generated GoMock package.
+package mock_permission
+
+import (
+	context "context"
+	reflect "reflect"
+
+	permission "github.com/hanksha/tbz-booking-system-backend/permission"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMappingRepository is a mock of MappingRepository interface.
+type MockMappingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMappingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMappingRepositoryMockRecorder is the mock recorder for MockMappingRepository.
+type MockMappingRepositoryMockRecorder struct {
+	mock *MockMappingRepository
+}
+
+// NewMockMappingRepository creates a new mock instance.
+func NewMockMappingRepository(ctrl *gomock.Controller) *MockMappingRepository {
+	mock := &MockMappingRepository{ctrl: ctrl}
+	mock.recorder = &MockMappingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMappingRepository) EXPECT() *MockMappingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteMapping mocks base method.
+func (m *MockMappingRepository) DeleteMapping(ctx context.Context, roleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMapping", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMapping indicates an expected call of DeleteMapping.
+func (mr *MockMappingRepositoryMockRecorder) DeleteMapping(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMapping", reflect.TypeOf((*MockMappingRepository)(nil).DeleteMapping), ctx, roleID)
+}
+
+// GetMappings mocks base method.
+func (m *MockMappingRepository) GetMappings(ctx context.Context) ([]permission.RoleMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMappings", ctx)
+	ret0, _ := ret[0].([]permission.RoleMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMappings indicates an expected call of GetMappings.
+func (mr *MockMappingRepositoryMockRecorder) GetMappings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappings", reflect.TypeOf((*MockMappingRepository)(nil).GetMappings), ctx)
+}
+
+// SaveMapping mocks base method.
+func (m *MockMappingRepository) SaveMapping(ctx context.Context, mapping permission.RoleMapping) (permission.RoleMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMapping", ctx, mapping)
+	ret0, _ := ret[0].(permission.RoleMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMapping indicates an expected call of SaveMapping.
+func (mr *MockMappingRepositoryMockRecorder) SaveMapping(ctx, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMapping", reflect.TypeOf((*MockMappingRepository)(nil).SaveMapping), ctx, mapping)
+}
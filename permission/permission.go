@@ -0,0 +1,23 @@
+package permission
+
+import "time"
+
+// Permissions the admins may grant to the members of a Discord role.
+const (
+	// Admin gives access to the admin endpoints and lifts the booking rules
+	Admin = "admin"
+	// Trusted members have their bookings accepted without review
+	Trusted = "trusted"
+)
+
+// All lists the permissions that may be granted.
+var All = []string{Admin, Trusted}
+
+// RoleMapping grants permissions to the members with the Discord role, on top
+// of the admin and trusted roles of the environment.
+type RoleMapping struct {
+	RoleID      string    `json:"roleId"`
+	Permissions []string  `json:"permissions"`
+	UpdatedBy   string    `json:"updatedBy"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
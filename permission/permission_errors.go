@@ -0,0 +1,7 @@
+package permission
+
+import "errors"
+
+var ErrMappingNotFound = errors.New("role mapping not found")
+
+var ErrInvalidMapping = errors.New("invalid role mapping")
@@ -0,0 +1,73 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+func (r *Repository) GetMappings(ctx context.Context) ([]RoleMapping, error) {
+	sql := `
+		SELECT "roleId", permissions, "updatedBy", "updatedAt"
+		FROM "game-table-booking".role_permission
+		ORDER BY "roleId";
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role mappings: %w", err)
+	}
+
+	mappings, err := pgx.CollectRows(rows, pgx.RowToStructByName[RoleMapping])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning role mapping rows: %w", err)
+	}
+
+	return mappings, nil
+}
+
+func (r *Repository) SaveMapping(ctx context.Context, mapping RoleMapping) (RoleMapping, error) {
+	sql := `
+		INSERT INTO "game-table-booking".role_permission("roleId", permissions, "updatedBy")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("roleId") DO UPDATE
+		SET permissions = EXCLUDED.permissions,
+			"updatedBy" = EXCLUDED."updatedBy",
+			"updatedAt" = now()
+		RETURNING "updatedAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, mapping.RoleID, mapping.Permissions, mapping.UpdatedBy).Scan(&mapping.UpdatedAt)
+
+	if err != nil {
+		return RoleMapping{}, fmt.Errorf("failed to save mapping of role '%v': %w", mapping.RoleID, err)
+	}
+
+	return mapping, nil
+}
+
+func (r *Repository) DeleteMapping(ctx context.Context, roleID string) error {
+	sql := `DELETE FROM "game-table-booking".role_permission WHERE "roleId" = $1;`
+
+	tag, err := r.conn.Exec(ctx, sql, roleID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete mapping of role '%v': %w", roleID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrMappingNotFound
+	}
+
+	return nil
+}
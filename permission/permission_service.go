@@ -0,0 +1,204 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// DefaultReloadInterval is how long the mappings are cached before being
+// read again, for the changes saved through another replica to apply.
+const DefaultReloadInterval = time.Minute
+
+type MappingRepository interface {
+	GetMappings(ctx context.Context) ([]RoleMapping, error)
+	SaveMapping(ctx context.Context, mapping RoleMapping) (RoleMapping, error)
+	DeleteMapping(ctx context.Context, roleID string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo     MappingRepository
+	audit    AuditRecorder
+	interval time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+
+	mu sync.Mutex
+	// roles are the permissions granted to each role
+	roles    map[string][]string
+	loadedAt time.Time
+	// reloading lets the other callers use the cached mappings while they
+	// are read again
+	reloading bool
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+// WithReloadInterval caches the mappings for interval rather than
+// DefaultReloadInterval, they are read on each call when it is zero.
+func WithReloadInterval(interval time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.interval = interval
+	}
+}
+
+func NewService(repo MappingRepository, opts ...ServiceOption) *Service {
+	s := &Service{
+		repo:     repo,
+		interval: DefaultReloadInterval,
+		now:      time.Now,
+		logger:   slog.Default().With("component", "permission"),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetMappings(ctx context.Context) ([]RoleMapping, error) {
+	return s.repo.GetMappings(ctx)
+}
+
+// SetMapping replaces the permissions granted to the role, applied right
+// away by this replica and within the reload interval by the others.
+func (s *Service) SetMapping(ctx context.Context, mapping RoleMapping) (RoleMapping, error) {
+	mapping.RoleID = strings.TrimSpace(mapping.RoleID)
+
+	if _, err := strconv.ParseUint(mapping.RoleID, 10, 64); err != nil {
+		return RoleMapping{}, fmt.Errorf("%w: '%v' is not a Discord role ID", ErrInvalidMapping, mapping.RoleID)
+	}
+
+	permissions := []string{}
+
+	for _, p := range mapping.Permissions {
+		p = strings.ToLower(strings.TrimSpace(p))
+
+		if !slices.Contains(All, p) {
+			return RoleMapping{}, fmt.Errorf("%w: unknown permission '%v', expected one of %v", ErrInvalidMapping, p, strings.Join(All, ", "))
+		}
+
+		permissions = append(permissions, p)
+	}
+
+	slices.Sort(permissions)
+	mapping.Permissions = slices.Compact(permissions)
+
+	if len(mapping.Permissions) == 0 {
+		return RoleMapping{}, fmt.Errorf("%w: at least one permission is required, delete the mapping to revoke them all", ErrInvalidMapping)
+	}
+
+	mapping.UpdatedBy = ""
+
+	if admin, ok := discord.UserFromContext(ctx); ok {
+		mapping.UpdatedBy = admin.Username
+	}
+
+	saved, err := s.repo.SaveMapping(ctx, mapping)
+
+	if err != nil {
+		return RoleMapping{}, err
+	}
+
+	s.invalidate()
+
+	if s.audit != nil {
+		s.audit.Record(ctx, "role.update", "role", saved.RoleID, map[string]any{"permissions": saved.Permissions})
+	}
+
+	return saved, nil
+}
+
+// DeleteMapping revokes the permissions granted to the role.
+func (s *Service) DeleteMapping(ctx context.Context, roleID string) error {
+	if err := s.repo.DeleteMapping(ctx, roleID); err != nil {
+		return err
+	}
+
+	s.invalidate()
+
+	if s.audit != nil {
+		s.audit.Record(ctx, "role.delete", "role", roleID, nil)
+	}
+
+	return nil
+}
+
+// Permissions returns the permissions the roles of a member grant, sorted,
+// from the cached mappings. The last known mappings are kept while the
+// database is unreachable.
+func (s *Service) Permissions(ctx context.Context, roles []string) []string {
+	s.mu.Lock()
+	stale := !s.reloading && s.now().Sub(s.loadedAt) >= s.interval
+
+	if stale {
+		s.reloading = true
+	}
+	s.mu.Unlock()
+
+	if stale {
+		s.reload(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var permissions []string
+
+	for _, role := range roles {
+		permissions = append(permissions, s.roles[role]...)
+	}
+
+	slices.Sort(permissions)
+
+	return slices.Compact(permissions)
+}
+
+func (s *Service) reload(ctx context.Context) {
+	mappings, err := s.repo.GetMappings(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reloading = false
+	// a failed reload is retried after the interval too, rather than on
+	// every request
+	s.loadedAt = s.now()
+
+	if err != nil {
+		s.logger.Warn("failed to reload role mappings, keeping the cached ones", "err", err)
+		return
+	}
+
+	s.roles = make(map[string][]string, len(mappings))
+
+	for _, mapping := range mappings {
+		s.roles[mapping.RoleID] = mapping.Permissions
+	}
+}
+
+// invalidate makes the next call to Permissions read the mappings again.
+func (s *Service) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loadedAt = time.Time{}
+}
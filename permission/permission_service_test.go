@@ -0,0 +1,115 @@
+package permission_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/permission"
+	permission_mocks "github.com/hanksha/tbz-booking-system-backend/permission/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPermissions(t *testing.T) {
+	mappings := []permission.RoleMapping{
+		{RoleID: "10", Permissions: []string{"admin"}},
+		{RoleID: "20", Permissions: []string{"admin", "trusted"}},
+	}
+
+	t.Run("union of the roles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := permission_mocks.NewMockMappingRepository(ctrl)
+		svc := permission.NewService(repo, permission.WithReloadInterval(time.Hour))
+
+		repo.EXPECT().GetMappings(gomock.Any()).Return(mappings, nil).Times(1)
+
+		require.Equal(t, []string{"admin", "trusted"}, svc.Permissions(context.Background(), []string{"10", "20", "30"}))
+		require.Empty(t, svc.Permissions(context.Background(), []string{"30"}))
+	})
+
+	t.Run("kept while the database is down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := permission_mocks.NewMockMappingRepository(ctrl)
+		svc := permission.NewService(repo, permission.WithReloadInterval(0))
+
+		gomock.InOrder(
+			repo.EXPECT().GetMappings(gomock.Any()).Return(mappings, nil),
+			repo.EXPECT().GetMappings(gomock.Any()).Return(nil, errors.New("connection refused")),
+		)
+
+		require.Equal(t, []string{"admin"}, svc.Permissions(context.Background(), []string{"10"}))
+		require.Equal(t, []string{"admin"}, svc.Permissions(context.Background(), []string{"10"}))
+	})
+
+	t.Run("reloaded after a change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := permission_mocks.NewMockMappingRepository(ctrl)
+		svc := permission.NewService(repo, permission.WithReloadInterval(time.Hour))
+
+		gomock.InOrder(
+			repo.EXPECT().GetMappings(gomock.Any()).Return(mappings, nil),
+			repo.EXPECT().DeleteMapping(gomock.Any(), "10").Return(nil),
+			repo.EXPECT().GetMappings(gomock.Any()).Return(mappings[1:], nil),
+		)
+
+		require.Equal(t, []string{"admin"}, svc.Permissions(context.Background(), []string{"10"}))
+		require.Nil(t, svc.DeleteMapping(context.Background(), "10"))
+		require.Empty(t, svc.Permissions(context.Background(), []string{"10"}))
+	})
+}
+
+func TestSetMapping(t *testing.T) {
+	admin := discord.DiscordUser{ID: "1", Username: "alice", Admin: true}
+
+	t.Run("normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := permission_mocks.NewMockMappingRepository(ctrl)
+		svc := permission.NewService(repo)
+
+		repo.EXPECT().SaveMapping(gomock.Any(), permission.RoleMapping{
+			RoleID:      "42",
+			Permissions: []string{"admin", "trusted"},
+			UpdatedBy:   "alice",
+		}).DoAndReturn(func(ctx context.Context, mapping permission.RoleMapping) (permission.RoleMapping, error) {
+			return mapping, nil
+		}).Times(1)
+
+		_, err := svc.SetMapping(discord.ContextWithUser(context.Background(), admin), permission.RoleMapping{
+			RoleID:      " 42 ",
+			Permissions: []string{"Trusted", "admin", "trusted"},
+		})
+
+		require.Nil(t, err)
+	})
+
+	invalid := map[string]permission.RoleMapping{
+		"invalid role id":    {RoleID: "@moderators", Permissions: []string{"admin"}},
+		"unknown permission": {RoleID: "42", Permissions: []string{"owner"}},
+		"no permission":      {RoleID: "42"},
+	}
+
+	for name, mapping := range invalid {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := permission_mocks.NewMockMappingRepository(ctrl)
+			svc := permission.NewService(repo)
+
+			_, err := svc.SetMapping(context.Background(), mapping)
+
+			require.ErrorIs(t, err, permission.ErrInvalidMapping)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/poll (interfaces: PollRepository)
+//
+// Generated by this command:
+//
+//	mockgen . PollRepository
+//
+
+// Package mock_poll is a generated GoMock package.
+package mock_poll
+
+import (
+	context "context"
+	reflect "reflect"
+
+	poll "github.com/hanksha/tbz-booking-system-backend/poll"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPollRepository is a mock of PollRepository interface.
+type MockPollRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPollRepositoryMockRecorder is the mock recorder for MockPollRepository.
+type MockPollRepositoryMockRecorder struct {
+	mock *MockPollRepository
+}
+
+// NewMockPollRepository creates a new mock instance.
+func NewMockPollRepository(ctrl *gomock.Controller) *MockPollRepository {
+	mock := &MockPollRepository{ctrl: ctrl}
+	mock.recorder = &MockPollRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollRepository) EXPECT() *MockPollRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPoll mocks base method.
+func (m *MockPollRepository) GetPoll(ctx context.Context, id string) (poll.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoll", ctx, id)
+	ret0, _ := ret[0].(poll.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoll indicates an expected call of GetPoll.
+func (mr *MockPollRepositoryMockRecorder) GetPoll(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockPollRepository)(nil).GetPoll), ctx, id)
+}
+
+// InsertPoll mocks base method.
+func (m *MockPollRepository) InsertPoll(ctx context.Context, arg1 poll.Poll) (poll.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPoll", ctx, arg1)
+	ret0, _ := ret[0].(poll.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPoll indicates an expected call of InsertPoll.
+func (mr *MockPollRepositoryMockRecorder) InsertPoll(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPoll", reflect.TypeOf((*MockPollRepository)(nil).InsertPoll), ctx, arg1)
+}
+
+// SetPollStatus mocks base method.
+func (m *MockPollRepository) SetPollStatus(ctx context.Context, id, from, to, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPollStatus", ctx, id, from, to, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPollStatus indicates an expected call of SetPollStatus.
+func (mr *MockPollRepositoryMockRecorder) SetPollStatus(ctx, id, from, to, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPollStatus", reflect.TypeOf((*MockPollRepository)(nil).SetPollStatus), ctx, id, from, to, bookingID)
+}
+
+// SetVotes mocks base method.
+func (m *MockPollRepository) SetVotes(ctx context.Context, pollID, username string, slotIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVotes", ctx, pollID, username, slotIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVotes indicates an expected call of SetVotes.
+func (mr *MockPollRepositoryMockRecorder) SetVotes(ctx, pollID, username, slotIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVotes", reflect.TypeOf((*MockPollRepository)(nil).SetVotes), ctx, pollID, username, slotIDs)
+}
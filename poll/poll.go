@@ -0,0 +1,56 @@
+package poll
+
+import (
+	"slices"
+	"time"
+)
+
+// Poll finds a date for a game: the organizer proposes slots, the invited
+// players vote for the ones they can make, and the winner becomes a booking.
+type Poll struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title" binding:"required"`
+	Game        string    `json:"game" binding:"required"`
+	Points      int       `json:"points"`
+	Description string    `json:"description"`
+	OrganizerID string    `json:"organizerId"`
+	Organizer   string    `json:"organizer"`
+	Invitees    []string  `json:"invitees"`
+	Slots       []Slot    `json:"slots" binding:"required,min=1,dive"`
+	Status      string    `json:"status"` // open, booked
+	BookingID   string    `json:"bookingId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Slot is a proposed date, Votes are the usernames of the players available.
+type Slot struct {
+	ID       string    `json:"id"`
+	DateTime time.Time `json:"dateTime" binding:"required"`
+	Votes    []string  `json:"votes"`
+}
+
+// Winner is the slot with the most votes, the earliest on a tie. It returns
+// false when nobody voted.
+func (p Poll) Winner() (Slot, bool) {
+	var winner Slot
+
+	for _, slot := range p.Slots {
+		if len(slot.Votes) > len(winner.Votes) ||
+			(len(slot.Votes) == len(winner.Votes) && len(slot.Votes) > 0 && slot.DateTime.Before(winner.DateTime)) {
+			winner = slot
+		}
+	}
+
+	return winner, len(winner.Votes) > 0
+}
+
+// Slot finds a slot of the poll by id.
+func (p Poll) Slot(id string) (Slot, bool) {
+	i := slices.IndexFunc(p.Slots, func(slot Slot) bool { return slot.ID == id })
+
+	if i < 0 {
+		return Slot{}, false
+	}
+
+	return p.Slots[i], true
+}
@@ -0,0 +1,13 @@
+package poll
+
+import "errors"
+
+var ErrPollNotFound = errors.New("poll not found")
+
+var ErrInvalidPoll = errors.New("invalid poll")
+
+var ErrPollClosed = errors.New("poll is closed")
+
+var ErrNoVotes = errors.New("no slot has votes")
+
+var ErrNotAllowed = errors.New("not allowed to perform this operation")
@@ -0,0 +1,158 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// InsertPoll saves the poll with its slots.
+func (r *Repository) InsertPoll(ctx context.Context, poll Poll) (Poll, error) {
+	err := pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO "game-table-booking".poll(title, game, points, description, "organizerId", organizer, invitees, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id::text, "createdAt";
+		`, poll.Title, poll.Game, poll.Points, poll.Description, poll.OrganizerID, poll.Organizer, poll.Invitees, poll.Status,
+		).Scan(&poll.ID, &poll.CreatedAt)
+
+		if err != nil {
+			return err
+		}
+
+		for i := range poll.Slots {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO "game-table-booking".poll_slot("pollId", "dateTime")
+				VALUES ($1, $2)
+				RETURNING id::text;
+			`, poll.ID, poll.Slots[i].DateTime).Scan(&poll.Slots[i].ID)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to insert poll: %w", err)
+	}
+
+	return poll, nil
+}
+
+// GetPoll returns the poll with its slots in chronological order, and their
+// votes.
+func (r *Repository) GetPoll(ctx context.Context, id string) (Poll, error) {
+	var poll Poll
+
+	err := r.conn.QueryRow(ctx, `
+		SELECT id::text, title, game, points, COALESCE(description, ''), "organizerId", organizer,
+			COALESCE(invitees, '{}'), status, COALESCE("bookingId"::text, ''), "createdAt"
+		FROM "game-table-booking".poll
+		WHERE id=$1;
+	`, id).Scan(
+		&poll.ID,
+		&poll.Title,
+		&poll.Game,
+		&poll.Points,
+		&poll.Description,
+		&poll.OrganizerID,
+		&poll.Organizer,
+		&poll.Invitees,
+		&poll.Status,
+		&poll.BookingID,
+		&poll.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Poll{}, ErrPollNotFound
+	}
+
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to fetch poll '%v': %w", id, err)
+	}
+
+	rows, err := r.conn.Query(ctx, `
+		SELECT s.id::text, s."dateTime",
+			COALESCE(array_agg(v.username ORDER BY v.username) FILTER (WHERE v.username IS NOT NULL), '{}')
+		FROM "game-table-booking".poll_slot s
+		LEFT JOIN "game-table-booking".poll_vote v ON v."slotId" = s.id
+		WHERE s."pollId"=$1
+		GROUP BY s.id
+		ORDER BY s."dateTime";
+	`, id)
+
+	if err != nil {
+		return Poll{}, fmt.Errorf("failed to fetch slots of poll '%v': %w", id, err)
+	}
+
+	poll.Slots, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Slot])
+
+	if err != nil {
+		return Poll{}, fmt.Errorf("error scanning slot rows: %w", err)
+	}
+
+	return poll, nil
+}
+
+// SetVotes replaces the votes of a player on the slots of a poll.
+func (r *Repository) SetVotes(ctx context.Context, pollID, username string, slotIDs []string) error {
+	err := pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			DELETE FROM "game-table-booking".poll_vote v
+			USING "game-table-booking".poll_slot s
+			WHERE v."slotId" = s.id AND s."pollId"=$1 AND v.username=$2;
+		`, pollID, username)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO "game-table-booking".poll_vote("slotId", username)
+			SELECT s.id, $2 FROM "game-table-booking".poll_slot s
+			WHERE s."pollId"=$1 AND s.id::text = ANY($3);
+		`, pollID, username, slotIDs)
+
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to save the votes of '%v' on poll '%v': %w", username, pollID, err)
+	}
+
+	return nil
+}
+
+// SetPollStatus moves the poll from a status to another, it returns
+// ErrPollClosed when the poll is no longer in the expected status so that
+// only one caller books it.
+func (r *Repository) SetPollStatus(ctx context.Context, id, from, to, bookingID string) error {
+	sql := `
+		UPDATE "game-table-booking".poll SET status=$3, "bookingId"=NULLIF($4, '')::integer
+		WHERE id=$1 AND status=$2;
+	`
+
+	tag, err := r.conn.Exec(ctx, sql, id, from, to, bookingID)
+
+	if err != nil {
+		return fmt.Errorf("failed to update poll '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrPollClosed
+	}
+
+	return nil
+}
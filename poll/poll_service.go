@@ -0,0 +1,214 @@
+package poll
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+const (
+	statusOpen = "open"
+	// statusBooking holds the poll while its booking is created
+	statusBooking = "booking"
+	statusBooked  = "booked"
+)
+
+type PollRepository interface {
+	InsertPoll(ctx context.Context, poll Poll) (Poll, error)
+	GetPoll(ctx context.Context, id string) (Poll, error)
+	SetVotes(ctx context.Context, pollID, username string, slotIDs []string) error
+	SetPollStatus(ctx context.Context, id, from, to, bookingID string) error
+}
+
+// BookingCreator books the winning slot, it is the booking service.
+type BookingCreator interface {
+	CreateBooking(ctx context.Context, booking bk.Booking) (bk.Booking, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo     PollRepository
+	bookings BookingCreator
+	audit    AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo PollRepository, bookings BookingCreator, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, bookings: bookings}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetPoll(ctx context.Context, id string) (Poll, error) {
+	return s.repo.GetPoll(ctx, id)
+}
+
+// CreatePoll opens a poll organized by the user, the invitees are Discord
+// usernames as the players of a booking.
+func (s *Service) CreatePoll(ctx context.Context, poll Poll, user discord.DiscordUser) (Poll, error) {
+	poll.Title = strings.TrimSpace(poll.Title)
+	poll.Game = strings.TrimSpace(poll.Game)
+
+	if len(poll.Title) == 0 || len(poll.Game) == 0 {
+		return Poll{}, fmt.Errorf("%w: title and game cannot be empty", ErrInvalidPoll)
+	}
+
+	if len(poll.Slots) == 0 {
+		return Poll{}, fmt.Errorf("%w: at least one slot is needed", ErrInvalidPoll)
+	}
+
+	now := time.Now()
+	slots := make([]Slot, 0, len(poll.Slots))
+
+	for _, slot := range poll.Slots {
+		if !slot.DateTime.After(now) {
+			return Poll{}, fmt.Errorf("%w: slot %v is in the past", ErrInvalidPoll, slot.DateTime.Format(time.RFC3339))
+		}
+
+		if !slices.ContainsFunc(slots, func(s Slot) bool { return s.DateTime.Equal(slot.DateTime) }) {
+			slots = append(slots, Slot{DateTime: slot.DateTime, Votes: []string{}})
+		}
+	}
+
+	invitees := make([]string, 0, len(poll.Invitees))
+
+	for _, invitee := range poll.Invitees {
+		invitee = strings.ToLower(strings.TrimSpace(invitee))
+
+		if len(invitee) != 0 && !slices.Contains(invitees, invitee) {
+			invitees = append(invitees, invitee)
+		}
+	}
+
+	poll.Slots = slots
+	poll.Invitees = invitees
+	poll.OrganizerID = user.ID
+	poll.Organizer = user.Username
+	poll.Status = statusOpen
+	poll.BookingID = ""
+
+	inserted, err := s.repo.InsertPoll(ctx, poll)
+
+	s.record(ctx, err, "poll.create", inserted.ID, map[string]any{"title": inserted.Title})
+
+	return inserted, err
+}
+
+// Vote replaces the slots the user is available for, only the organizer and
+// the invitees vote.
+func (s *Service) Vote(ctx context.Context, id string, slotIDs []string, user discord.DiscordUser) (Poll, error) {
+	poll, err := s.repo.GetPoll(ctx, id)
+
+	if err != nil {
+		return Poll{}, err
+	}
+
+	if poll.Status != statusOpen {
+		return Poll{}, ErrPollClosed
+	}
+
+	username := strings.ToLower(user.Username)
+
+	if poll.OrganizerID != user.ID && !slices.Contains(poll.Invitees, username) {
+		return Poll{}, ErrNotAllowed
+	}
+
+	for _, slotID := range slotIDs {
+		if _, ok := poll.Slot(slotID); !ok {
+			return Poll{}, fmt.Errorf("%w: unknown slot '%v'", ErrInvalidPoll, slotID)
+		}
+	}
+
+	if err := s.repo.SetVotes(ctx, id, username, slotIDs); err != nil {
+		return Poll{}, err
+	}
+
+	return s.repo.GetPoll(ctx, id)
+}
+
+// Book turns a slot into a booking of the organizer with the players who
+// voted for it, the winning slot when slotID is empty. The poll is closed
+// once booked.
+func (s *Service) Book(ctx context.Context, id, slotID string, user discord.DiscordUser) (bk.Booking, error) {
+	poll, err := s.repo.GetPoll(ctx, id)
+
+	if err != nil {
+		return bk.Booking{}, err
+	}
+
+	if poll.OrganizerID != user.ID && !user.Admin {
+		return bk.Booking{}, ErrNotAllowed
+	}
+
+	if poll.Status != statusOpen {
+		return bk.Booking{}, ErrPollClosed
+	}
+
+	slot, ok := poll.Winner()
+
+	if len(slotID) != 0 {
+		slot, ok = poll.Slot(slotID)
+
+		if !ok {
+			return bk.Booking{}, fmt.Errorf("%w: unknown slot '%v'", ErrInvalidPoll, slotID)
+		}
+	} else if !ok {
+		return bk.Booking{}, ErrNoVotes
+	}
+
+	if err := s.repo.SetPollStatus(ctx, id, statusOpen, statusBooking, ""); err != nil {
+		return bk.Booking{}, err
+	}
+
+	booking, err := s.bookings.CreateBooking(ctx, bk.Booking{
+		Game:            poll.Game,
+		UserID:          poll.OrganizerID,
+		Username:        poll.Organizer,
+		Points:          poll.Points,
+		Description:     poll.Description,
+		ReminderEnabled: true,
+		DateTime:        slot.DateTime,
+		Players:         slices.DeleteFunc(slices.Clone(slot.Votes), func(voter string) bool { return strings.EqualFold(voter, poll.Organizer) }),
+	})
+
+	if err != nil {
+		if reopenErr := s.repo.SetPollStatus(ctx, id, statusBooking, statusOpen, ""); reopenErr != nil {
+			slog.Error("failed to reopen poll after a failed booking", "pollId", id, "err", reopenErr)
+		}
+		return bk.Booking{}, err
+	}
+
+	if err := s.repo.SetPollStatus(ctx, id, statusBooking, statusBooked, booking.ID); err != nil {
+		return bk.Booking{}, err
+	}
+
+	s.record(ctx, nil, "poll.book", id, map[string]any{"bookingId": booking.ID})
+
+	return booking, nil
+}
+
+func (s *Service) record(ctx context.Context, err error, action, pollID string, payload any) {
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, action, "poll", pollID, payload)
+	}
+}
@@ -0,0 +1,195 @@
+package poll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/poll"
+	poll_mocks "github.com/hanksha/tbz-booking-system-backend/poll/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeBookings struct {
+	created []bk.Booking
+	err     error
+}
+
+func (f *fakeBookings) CreateBooking(ctx context.Context, booking bk.Booking) (bk.Booking, error) {
+	if f.err != nil {
+		return bk.Booking{}, f.err
+	}
+
+	booking.ID = "42"
+	f.created = append(f.created, booking)
+
+	return booking, nil
+}
+
+var (
+	organizer = discord.DiscordUser{ID: "1", Username: "alice"}
+	friday    = time.Now().Add(72 * time.Hour).Truncate(time.Minute)
+	saturday  = friday.Add(24 * time.Hour)
+)
+
+func openPoll() poll.Poll {
+	return poll.Poll{
+		ID: "5", Title: "Partie", Game: "Necromunda", Points: 1000,
+		OrganizerID: "1", Organizer: "alice", Invitees: []string{"bob", "carol"}, Status: "open",
+		Slots: []poll.Slot{
+			{ID: "10", DateTime: friday, Votes: []string{"alice", "bob"}},
+			{ID: "11", DateTime: saturday, Votes: []string{"alice", "bob", "carol"}},
+		},
+	}
+}
+
+func TestWinner(t *testing.T) {
+	p := openPoll()
+	winner, ok := p.Winner()
+
+	require.True(t, ok)
+	require.Equal(t, "11", winner.ID)
+
+	p.Slots[0].Votes = append(p.Slots[0].Votes, "carol")
+	winner, _ = p.Winner()
+
+	// ties go to the earliest slot
+	require.Equal(t, "10", winner.ID)
+
+	_, ok = poll.Poll{Slots: []poll.Slot{{ID: "10", DateTime: friday}}}.Winner()
+
+	require.False(t, ok)
+}
+
+func TestCreatePoll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{})
+
+		repo.EXPECT().InsertPoll(gomock.Any(), poll.Poll{
+			Title: "Partie", Game: "Necromunda",
+			OrganizerID: "1", Organizer: "alice", Invitees: []string{"bob"}, Status: "open",
+			Slots: []poll.Slot{{DateTime: friday, Votes: []string{}}},
+		}).DoAndReturn(func(ctx context.Context, p poll.Poll) (poll.Poll, error) {
+			p.ID = "5"
+			return p, nil
+		}).Times(1)
+
+		created, err := svc.CreatePoll(context.Background(), poll.Poll{
+			Title: " Partie ", Game: "Necromunda", Invitees: []string{"Bob", "bob "},
+			Slots: []poll.Slot{{DateTime: friday}, {DateTime: friday}},
+		}, organizer)
+
+		require.Nil(t, err)
+		require.Equal(t, "5", created.ID)
+	})
+
+	t.Run("slot in the past", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{})
+
+		_, err := svc.CreatePoll(context.Background(), poll.Poll{
+			Title: "Partie", Game: "Necromunda", Slots: []poll.Slot{{DateTime: time.Now().Add(-time.Hour)}},
+		}, organizer)
+
+		require.ErrorIs(t, err, poll.ErrInvalidPoll)
+	})
+}
+
+func TestVote(t *testing.T) {
+	t.Run("invitee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{})
+
+		repo.EXPECT().GetPoll(gomock.Any(), "5").Return(openPoll(), nil).Times(2)
+		repo.EXPECT().SetVotes(gomock.Any(), "5", "carol", []string{"10"}).Return(nil).Times(1)
+
+		_, err := svc.Vote(context.Background(), "5", []string{"10"}, discord.DiscordUser{ID: "3", Username: "Carol"})
+
+		require.Nil(t, err)
+	})
+
+	t.Run("not invited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{})
+
+		repo.EXPECT().GetPoll(gomock.Any(), "5").Return(openPoll(), nil).Times(1)
+
+		_, err := svc.Vote(context.Background(), "5", []string{"10"}, discord.DiscordUser{ID: "4", Username: "dave"})
+
+		require.ErrorIs(t, err, poll.ErrNotAllowed)
+	})
+}
+
+func TestBook(t *testing.T) {
+	t.Run("winning slot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		bookings := &fakeBookings{}
+		svc := poll.NewService(repo, bookings)
+
+		repo.EXPECT().GetPoll(gomock.Any(), "5").Return(openPoll(), nil).Times(1)
+		gomock.InOrder(
+			repo.EXPECT().SetPollStatus(gomock.Any(), "5", "open", "booking", "").Return(nil),
+			repo.EXPECT().SetPollStatus(gomock.Any(), "5", "booking", "booked", "42").Return(nil),
+		)
+
+		booking, err := svc.Book(context.Background(), "5", "", organizer)
+
+		require.Nil(t, err)
+		require.Equal(t, "42", booking.ID)
+		assert.Equal(t, saturday, bookings.created[0].DateTime)
+		assert.Equal(t, []string{"bob", "carol"}, bookings.created[0].Players)
+		assert.Equal(t, "1", bookings.created[0].UserID)
+	})
+
+	t.Run("booking failed reopens the poll", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{err: bk.ErrInvalidPoints})
+
+		repo.EXPECT().GetPoll(gomock.Any(), "5").Return(openPoll(), nil).Times(1)
+		gomock.InOrder(
+			repo.EXPECT().SetPollStatus(gomock.Any(), "5", "open", "booking", "").Return(nil),
+			repo.EXPECT().SetPollStatus(gomock.Any(), "5", "booking", "open", "").Return(nil),
+		)
+
+		_, err := svc.Book(context.Background(), "5", "10", organizer)
+
+		require.ErrorIs(t, err, bk.ErrInvalidPoints)
+	})
+
+	t.Run("not the organizer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := poll_mocks.NewMockPollRepository(ctrl)
+		svc := poll.NewService(repo, &fakeBookings{})
+
+		repo.EXPECT().GetPoll(gomock.Any(), "5").Return(openPoll(), nil).Times(1)
+
+		_, err := svc.Book(context.Background(), "5", "", discord.DiscordUser{ID: "2", Username: "bob"})
+
+		require.ErrorIs(t, err, poll.ErrNotAllowed)
+	})
+}
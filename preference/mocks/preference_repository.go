@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/preference (interfaces: PreferenceRepository)
+//
+// Generated by this command:
+//
+//	mockgen . PreferenceRepository
+//
+
+// Package mock_preference is a generated GoMock package.
+package mock_preference
+
+import (
+	context "context"
+	reflect "reflect"
+
+	preference "github.com/hanksha/tbz-booking-system-backend/preference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferenceRepository is a mock of PreferenceRepository interface.
+type MockPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPreferenceRepositoryMockRecorder is the mock recorder for MockPreferenceRepository.
+type MockPreferenceRepositoryMockRecorder struct {
+	mock *MockPreferenceRepository
+}
+
+// NewMockPreferenceRepository creates a new mock instance.
+func NewMockPreferenceRepository(ctrl *gomock.Controller) *MockPreferenceRepository {
+	mock := &MockPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepository) EXPECT() *MockPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceRepository) GetPreferences(ctx context.Context, userID string) (preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).GetPreferences), ctx, userID)
+}
+
+// GetSubscribers mocks base method.
+func (m *MockPreferenceRepository) GetSubscribers(ctx context.Context, usernames []string) ([]preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribers", ctx, usernames)
+	ret0, _ := ret[0].([]preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribers indicates an expected call of GetSubscribers.
+func (mr *MockPreferenceRepositoryMockRecorder) GetSubscribers(ctx, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribers", reflect.TypeOf((*MockPreferenceRepository)(nil).GetSubscribers), ctx, usernames)
+}
+
+// SavePreferences mocks base method.
+func (m *MockPreferenceRepository) SavePreferences(ctx context.Context, prefs preference.Preferences) (preference.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, prefs)
+	ret0, _ := ret[0].(preference.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) SavePreferences(ctx, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).SavePreferences), ctx, prefs)
+}
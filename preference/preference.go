@@ -0,0 +1,47 @@
+package preference
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+// Preferences are the notification settings of a member, the booking
+// notifications are only sent on a channel once its owner opted in.
+type Preferences struct {
+	UserID             string `json:"userId"`
+	Username           string `json:"username"`
+	Email              string `json:"email" binding:"omitempty,email,max=254"`
+	EmailNotifications bool   `json:"emailNotifications"`
+	// TelegramChatID is the chat with the bot, its messages are sent to
+	TelegramChatID        string    `json:"telegramChatId" binding:"max=32"`
+	TelegramNotifications bool      `json:"telegramNotifications"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// EmailSubscribed tells whether the booking emails are sent to the member.
+func (p Preferences) EmailSubscribed() bool {
+	return p.EmailNotifications && len(p.Email) != 0
+}
+
+// TelegramSubscribed tells whether the booking messages are sent to the
+// member on Telegram.
+func (p Preferences) TelegramSubscribed() bool {
+	return p.TelegramNotifications && len(p.TelegramChatID) != 0
+}
+
+// Participants lists the lowercase usernames of the organizer and the players
+// of the booking, each once.
+func Participants(booking bk.Booking) []string {
+	usernames := []string{strings.ToLower(booking.Username)}
+
+	for _, player := range booking.Players {
+		if player = strings.ToLower(strings.TrimSpace(player)); len(player) != 0 && !slices.Contains(usernames, player) {
+			usernames = append(usernames, player)
+		}
+	}
+
+	return usernames
+}
@@ -0,0 +1,5 @@
+package preference
+
+import "errors"
+
+var ErrInvalidPreferences = errors.New("invalid preferences")
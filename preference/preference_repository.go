@@ -0,0 +1,93 @@
+package preference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// GetPreferences returns the preferences of the user, opted out of every
+// channel when they never saved any.
+func (r *Repository) GetPreferences(ctx context.Context, userID string) (Preferences, error) {
+	sql := `
+		SELECT "userId", username, email, "emailNotifications", "telegramChatId", "telegramNotifications", "updatedAt"
+		FROM "game-table-booking".user_preference
+		WHERE "userId" = $1;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, userID)
+
+	if err != nil {
+		return Preferences{}, fmt.Errorf("failed to fetch preferences of %v: %w", userID, err)
+	}
+
+	prefs, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[Preferences])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Preferences{UserID: userID}, nil
+	}
+
+	if err != nil {
+		return Preferences{}, fmt.Errorf("error scanning preference row: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (r *Repository) SavePreferences(ctx context.Context, prefs Preferences) (Preferences, error) {
+	sql := `
+		INSERT INTO "game-table-booking".user_preference("userId", username, email, "emailNotifications", "telegramChatId", "telegramNotifications")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("userId") DO UPDATE
+		SET username = EXCLUDED.username,
+			email = EXCLUDED.email,
+			"emailNotifications" = EXCLUDED."emailNotifications",
+			"telegramChatId" = EXCLUDED."telegramChatId",
+			"telegramNotifications" = EXCLUDED."telegramNotifications",
+			"updatedAt" = now()
+		RETURNING "updatedAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, prefs.UserID, prefs.Username, prefs.Email, prefs.EmailNotifications, prefs.TelegramChatID, prefs.TelegramNotifications).Scan(&prefs.UpdatedAt)
+
+	if err != nil {
+		return Preferences{}, fmt.Errorf("failed to save preferences of %v: %w", prefs.UserID, err)
+	}
+
+	return prefs, nil
+}
+
+// GetSubscribers lists the preferences of the members with the usernames who
+// opted in the booking notifications of any channel.
+func (r *Repository) GetSubscribers(ctx context.Context, usernames []string) ([]Preferences, error) {
+	sql := `
+		SELECT "userId", username, email, "emailNotifications", "telegramChatId", "telegramNotifications", "updatedAt"
+		FROM "game-table-booking".user_preference
+		WHERE lower(username) = ANY($1)
+		AND (("emailNotifications" AND email <> '') OR ("telegramNotifications" AND "telegramChatId" <> ''))
+		ORDER BY username;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, usernames)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscribers: %w", err)
+	}
+
+	subscribers, err := pgx.CollectRows(rows, pgx.RowToStructByName[Preferences])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning preference rows: %w", err)
+	}
+
+	return subscribers, nil
+}
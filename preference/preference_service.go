@@ -0,0 +1,75 @@
+package preference
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type PreferenceRepository interface {
+	GetPreferences(ctx context.Context, userID string) (Preferences, error)
+	SavePreferences(ctx context.Context, prefs Preferences) (Preferences, error)
+	GetSubscribers(ctx context.Context, usernames []string) ([]Preferences, error)
+}
+
+type Service struct {
+	repo PreferenceRepository
+}
+
+func NewService(repo PreferenceRepository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) GetPreferences(ctx context.Context, user discord.DiscordUser) (Preferences, error) {
+	prefs, err := s.repo.GetPreferences(ctx, user.ID)
+
+	if err != nil {
+		return Preferences{}, err
+	}
+
+	prefs.Username = user.Username
+
+	return prefs, nil
+}
+
+// SavePreferences stores the email and the Telegram chat of the user and the
+// channels they opted in, which need them.
+func (s *Service) SavePreferences(ctx context.Context, user discord.DiscordUser, prefs Preferences) (Preferences, error) {
+	prefs.UserID = user.ID
+	prefs.Username = user.Username
+	prefs.Email = strings.TrimSpace(prefs.Email)
+	prefs.TelegramChatID = strings.TrimSpace(prefs.TelegramChatID)
+
+	if len(prefs.Email) != 0 {
+		address, err := mail.ParseAddress(prefs.Email)
+
+		if err != nil || address.Address != prefs.Email {
+			return Preferences{}, fmt.Errorf("%w: '%v' is not an email address", ErrInvalidPreferences, prefs.Email)
+		}
+	}
+
+	if prefs.EmailNotifications && len(prefs.Email) == 0 {
+		return Preferences{}, fmt.Errorf("%w: an email is required to receive notifications", ErrInvalidPreferences)
+	}
+
+	// chats with users have positive ids, groups negative ones
+	if _, err := strconv.ParseInt(prefs.TelegramChatID, 10, 64); len(prefs.TelegramChatID) != 0 && err != nil {
+		return Preferences{}, fmt.Errorf("%w: '%v' is not a Telegram chat id", ErrInvalidPreferences, prefs.TelegramChatID)
+	}
+
+	if prefs.TelegramNotifications && len(prefs.TelegramChatID) == 0 {
+		return Preferences{}, fmt.Errorf("%w: a Telegram chat id is required to receive notifications", ErrInvalidPreferences)
+	}
+
+	return s.repo.SavePreferences(ctx, prefs)
+}
+
+// GetSubscribers lists the preferences of the members with the usernames who
+// opted in the notifications of any channel.
+func (s *Service) GetSubscribers(ctx context.Context, usernames []string) ([]Preferences, error) {
+	return s.repo.GetSubscribers(ctx, usernames)
+}
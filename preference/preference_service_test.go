@@ -0,0 +1,74 @@
+package preference_test
+
+import (
+	"context"
+	"testing"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+	preference_mocks "github.com/hanksha/tbz-booking-system-backend/preference/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var alice = discord.DiscordUser{ID: "1", Username: "alice"}
+
+func TestSavePreferences(t *testing.T) {
+	t.Run("opt in", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := preference_mocks.NewMockPreferenceRepository(ctrl)
+		svc := preference.NewService(repo)
+
+		repo.EXPECT().SavePreferences(gomock.Any(), preference.Preferences{
+			UserID:                "1",
+			Username:              "alice",
+			Email:                 "alice@example.com",
+			EmailNotifications:    true,
+			TelegramChatID:        "-1001234",
+			TelegramNotifications: true,
+		}).DoAndReturn(func(ctx context.Context, prefs preference.Preferences) (preference.Preferences, error) {
+			return prefs, nil
+		}).Times(1)
+
+		prefs, err := svc.SavePreferences(context.Background(), alice, preference.Preferences{
+			UserID:                "2",
+			Email:                 " alice@example.com ",
+			EmailNotifications:    true,
+			TelegramChatID:        "-1001234",
+			TelegramNotifications: true,
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, "1", prefs.UserID)
+	})
+
+	invalid := map[string]preference.Preferences{
+		"opt in without an email":  {EmailNotifications: true},
+		"invalid email":            {Email: "Alice <alice@example.com>"},
+		"opt in without a chat":    {TelegramNotifications: true},
+		"invalid Telegram chat id": {TelegramChatID: "@alice"},
+	}
+
+	for name, prefs := range invalid {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := preference_mocks.NewMockPreferenceRepository(ctrl)
+			svc := preference.NewService(repo)
+
+			_, err := svc.SavePreferences(context.Background(), alice, prefs)
+
+			require.ErrorIs(t, err, preference.ErrInvalidPreferences)
+		})
+	}
+}
+
+func TestParticipants(t *testing.T) {
+	booking := bk.Booking{Username: "Alice", Players: []string{"bob", " alice", "", "Carol"}}
+
+	require.Equal(t, []string{"alice", "bob", "carol"}, preference.Participants(booking))
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/presence (interfaces: PresenceUpdater)
+//
+// Generated by this command:
+//
+//	mockgen . PresenceUpdater
+//
+
+// Package mock_presence is a generated GoMock package.
+package mock_presence
+
+import (
+	context "context"
+	reflect "reflect"
+
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresenceUpdater is a mock of PresenceUpdater interface.
+type MockPresenceUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceUpdaterMockRecorder is the mock recorder for MockPresenceUpdater.
+type MockPresenceUpdaterMockRecorder struct {
+	mock *MockPresenceUpdater
+}
+
+// NewMockPresenceUpdater creates a new mock instance.
+func NewMockPresenceUpdater(ctrl *gomock.Controller) *MockPresenceUpdater {
+	mock := &MockPresenceUpdater{ctrl: ctrl}
+	mock.recorder = &MockPresenceUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceUpdater) EXPECT() *MockPresenceUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdatePresence mocks base method.
+func (m *MockPresenceUpdater) UpdatePresence(ctx context.Context, presence discord.Presence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePresence", ctx, presence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePresence indicates an expected call of UpdatePresence.
+func (mr *MockPresenceUpdaterMockRecorder) UpdatePresence(ctx, presence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePresence", reflect.TypeOf((*MockPresenceUpdater)(nil).UpdatePresence), ctx, presence)
+}
@@ -0,0 +1,68 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type PresenceUpdater interface {
+	UpdatePresence(ctx context.Context, presence discord.Presence) error
+}
+
+type BookingSource interface {
+	GetActiveBookings(ctx context.Context) ([]bk.Booking, error)
+}
+
+// Service shows the next game on the status line of the bot.
+type Service struct {
+	updater  PresenceUpdater
+	bookings BookingSource
+	location *time.Location
+}
+
+func NewService(updater PresenceUpdater, bookings BookingSource, loc *time.Location) *Service {
+	return &Service{updater: updater, bookings: bookings, location: loc}
+}
+
+// Refresh shows the next accepted booking listed to the members, the
+// private ones staying out of the status line, or clears the status when
+// none is coming.
+func (s *Service) Refresh(ctx context.Context) error {
+	bookings, err := s.bookings.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	now := time.Now()
+	var next *bk.Booking
+
+	for i, booking := range bookings {
+		if booking.Status != "accepted" || booking.Visibility == bk.VisibilityPrivate || !booking.DateTime.After(now) {
+			continue
+		}
+
+		if next == nil || booking.DateTime.Before(next.DateTime) {
+			next = &bookings[i]
+		}
+	}
+
+	status := ""
+
+	if next != nil {
+		status = s.Status(*next)
+	}
+
+	return s.updater.UpdatePresence(ctx, discord.CustomStatus(status))
+}
+
+// Status is the status line announcing the booking, such as "Next game:
+// Blood Bowl, Thu 19:00".
+func (s *Service) Status(booking bk.Booking) string {
+	return fmt.Sprintf("Next game: %s, %s", strings.TrimSpace(booking.Game), booking.DateTime.In(s.location).Format("Mon 15:04"))
+}
@@ -0,0 +1,66 @@
+package presence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/presence"
+	mock_presence "github.com/hanksha/tbz-booking-system-backend/presence/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type bookingSource []bk.Booking
+
+func (s bookingSource) GetActiveBookings(ctx context.Context) ([]bk.Booking, error) {
+	return s, nil
+}
+
+func TestRefresh(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	setup := func(t *testing.T, bookings bookingSource) (*presence.Service, *mock_presence.MockPresenceUpdater) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		updater := mock_presence.NewMockPresenceUpdater(ctrl)
+
+		return presence.NewService(updater, bookings, paris), updater
+	}
+
+	t.Run("shows the next game", func(t *testing.T) {
+		next := time.Now().Add(2 * time.Hour)
+		svc, updater := setup(t, bookingSource{
+			{Game: "Kill Team", Status: "accepted", DateTime: next.Add(24 * time.Hour)},
+			{Game: "Blood Bowl", Status: "accepted", DateTime: next},
+			{Game: "Secret", Status: "accepted", Visibility: bk.VisibilityPrivate, DateTime: next.Add(-time.Hour)},
+			{Game: "Pending", Status: "pending", DateTime: next.Add(-time.Hour)},
+			{Game: "Started", Status: "accepted", DateTime: time.Now().Add(-time.Hour)},
+		})
+
+		status := "Next game: Blood Bowl, " + next.In(paris).Format("Mon 15:04")
+		updater.EXPECT().UpdatePresence(gomock.Any(), discord.CustomStatus(status)).Return(nil).Times(1)
+
+		require.Nil(t, svc.Refresh(context.Background()))
+	})
+
+	t.Run("clears the status without games", func(t *testing.T) {
+		svc, updater := setup(t, bookingSource{})
+
+		updater.EXPECT().UpdatePresence(gomock.Any(), discord.Presence{Activities: []discord.Activity{}, Status: "online"}).Return(nil).Times(1)
+
+		require.Nil(t, svc.Refresh(context.Background()))
+	})
+}
+
+func TestStatus(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	svc := presence.NewService(nil, nil, paris)
+
+	status := svc.Status(bk.Booking{Game: "Blood Bowl", DateTime: time.Date(2026, 3, 5, 18, 0, 0, 0, time.UTC)})
+
+	require.Equal(t, "Next game: Blood Bowl, Thu 19:00", status)
+}
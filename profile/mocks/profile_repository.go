@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/profile (interfaces: ProfileRepository)
+//
+// Generated by this command:
+//
+//	mockgen . ProfileRepository
+//
+
+// Package mock_profile is a generated GoMock package.
+package mock_profile
+
+import (
+	context "context"
+	reflect "reflect"
+
+	profile "github.com/hanksha/tbz-booking-system-backend/profile"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileRepository is a mock of ProfileRepository interface.
+type MockProfileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileRepositoryMockRecorder is the mock recorder for MockProfileRepository.
+type MockProfileRepositoryMockRecorder struct {
+	mock *MockProfileRepository
+}
+
+// NewMockProfileRepository creates a new mock instance.
+func NewMockProfileRepository(ctrl *gomock.Controller) *MockProfileRepository {
+	mock := &MockProfileRepository{ctrl: ctrl}
+	mock.recorder = &MockProfileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileRepository) EXPECT() *MockProfileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDirectory mocks base method.
+func (m *MockProfileRepository) GetDirectory(ctx context.Context, query string, after *profile.Cursor, limit int) ([]profile.DirectoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectory", ctx, query, after, limit)
+	ret0, _ := ret[0].([]profile.DirectoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectory indicates an expected call of GetDirectory.
+func (mr *MockProfileRepositoryMockRecorder) GetDirectory(ctx, query, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectory", reflect.TypeOf((*MockProfileRepository)(nil).GetDirectory), ctx, query, after, limit)
+}
+
+// SaveProfile mocks base method.
+func (m *MockProfileRepository) SaveProfile(ctx context.Context, arg1 profile.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfile", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProfile indicates an expected call of SaveProfile.
+func (mr *MockProfileRepositoryMockRecorder) SaveProfile(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfile", reflect.TypeOf((*MockProfileRepository)(nil).SaveProfile), ctx, arg1)
+}
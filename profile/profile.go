@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// Profile is a Discord user seen through the API. The bookings reference it
+// by id, their stats following the user across username changes.
+type Profile struct {
+	ID         string `json:"userId"`
+	Username   string `json:"username"`
+	GlobalName string `json:"globalName"`
+	// Avatar is the hash of the avatar, AvatarURL its URL, empty for the
+	// default one
+	Avatar      string    `json:"avatar"`
+	AvatarURL   string    `json:"avatarUrl" db:"-"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
+
+// DirectoryEntry is a user of the directory with their activity, for the
+// admins. There is no points balance: points are not tracked per user, those
+// of a booking being the size of its game.
+type DirectoryEntry struct {
+	Profile
+	// Bookings counts those organized by the user, deleted ones excepted
+	Bookings  int  `json:"bookings"`
+	Suspended bool `json:"suspended"`
+	// SuspendedUntil ends the suspension, it never does when nil
+	SuspendedUntil *time.Time `json:"suspendedUntil,omitempty"`
+	// LastActivityAt is the last time the user was seen or changed a
+	// booking
+	LastActivityAt time.Time `json:"lastActivityAt"`
+}
+
+// DirectoryPage is a page of the directory, NextCursor resuming after it.
+type DirectoryPage struct {
+	Users      []DirectoryEntry `json:"users"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
+
+func (p Profile) user() discord.User {
+	return discord.User{ID: p.ID, Username: p.Username, GlobalName: p.GlobalName, Avatar: p.Avatar}
+}
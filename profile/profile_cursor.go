@@ -0,0 +1,40 @@
+package profile
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor is a keyset position in the directory, ordered by lowercase
+// username then id.
+type Cursor struct {
+	Username string
+	ID       string
+}
+
+type encodedCursor struct {
+	Username string `json:"u"`
+	ID       string `json:"i"`
+}
+
+// Encode returns the opaque form of the cursor handed to API clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(encodedCursor{Username: c.Username, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var decoded encodedCursor
+
+	if err := json.Unmarshal(raw, &decoded); err != nil || len(decoded.ID) == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{Username: decoded.Username, ID: decoded.ID}, nil
+}
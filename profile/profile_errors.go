@@ -0,0 +1,5 @@
+package profile
+
+import "errors"
+
+var ErrInvalidCursor = errors.New("invalid cursor")
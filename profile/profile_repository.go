@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// SaveProfile records that the user was seen now, under their current
+// username, global name and avatar.
+func (r *Repository) SaveProfile(ctx context.Context, profile Profile) error {
+	sql := `
+		INSERT INTO "game-table-booking".users(id, username, "globalName", avatar)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username,
+			"globalName" = EXCLUDED."globalName",
+			avatar = EXCLUDED.avatar,
+			"lastSeenAt" = now();
+	`
+
+	if _, err := r.conn.Exec(ctx, sql, profile.ID, profile.Username, profile.GlobalName, profile.Avatar); err != nil {
+		return fmt.Errorf("failed to save profile of %v: %w", profile.ID, err)
+	}
+
+	return nil
+}
+
+// GetDirectory lists the users whose username or global name contains
+// query, all of them when it is empty, by username from after on.
+func (r *Repository) GetDirectory(ctx context.Context, query string, after *Cursor, limit int) ([]DirectoryEntry, error) {
+	sql := `
+		SELECT users.id, users.username, users."globalName", users.avatar, users."firstSeenAt", users."lastSeenAt",
+			stats.bookings, ban."userId" IS NOT NULL AS suspended, ban."expiresAt" AS "suspendedUntil",
+			GREATEST(users."lastSeenAt", stats."lastBookedAt") AS "lastActivityAt"
+		FROM "game-table-booking".users
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS bookings, MAX(booking."updatedAt") AS "lastBookedAt"
+			FROM "game-table-booking".booking
+			WHERE booking."userId" = users.id AND booking."deletedAt" IS NULL
+		) stats
+		LEFT JOIN "game-table-booking".user_ban ban
+			ON ban."userId" = users.id AND (ban."expiresAt" IS NULL OR ban."expiresAt" > now())
+		WHERE ($1 = '' OR strpos(lower(users.username), lower($1)) > 0 OR strpos(lower(users."globalName"), lower($1)) > 0)
+		AND (NOT $2 OR (lower(users.username), users.id) > ($3, $4))
+		ORDER BY lower(users.username), users.id
+		LIMIT $5;
+	`
+
+	var username, id string
+
+	if after != nil {
+		username, id = after.Username, after.ID
+	}
+
+	rows, err := r.conn.Query(ctx, sql, query, after != nil, username, id, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the directory: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[DirectoryEntry])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning directory rows: %w", err)
+	}
+
+	return entries, nil
+}
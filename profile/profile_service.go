@@ -0,0 +1,142 @@
+package profile
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// DefaultSeenInterval is how often the last visit of a user is recorded, a
+// change of their username or avatar being recorded right away.
+const DefaultSeenInterval = time.Hour
+
+// Bounds of the directory pages.
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type ProfileRepository interface {
+	SaveProfile(ctx context.Context, profile Profile) error
+	GetDirectory(ctx context.Context, query string, after *Cursor, limit int) ([]DirectoryEntry, error)
+}
+
+type Service struct {
+	repo     ProfileRepository
+	interval time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+
+	mu sync.Mutex
+	// seen are the users recorded by this replica, and when
+	seen map[string]seenUser
+}
+
+type seenUser struct {
+	user discord.User
+	at   time.Time
+}
+
+type ServiceOption func(*Service)
+
+// WithSeenInterval records the visits of a user at most once per interval
+// rather than DefaultSeenInterval, each of them when it is zero.
+func WithSeenInterval(interval time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.interval = interval
+	}
+}
+
+func NewService(repo ProfileRepository, opts ...ServiceOption) *Service {
+	s := &Service{
+		repo:     repo,
+		interval: DefaultSeenInterval,
+		now:      time.Now,
+		logger:   slog.Default().With("component", "profile"),
+		seen:     map[string]seenUser{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Record saves the profile of a user who authenticated, skipped when it was
+// saved less than the seen interval ago and did not change since. A failure
+// is only logged, the request going on without it.
+func (s *Service) Record(ctx context.Context, user discord.User) {
+	if len(user.ID) == 0 {
+		return
+	}
+
+	now := s.now()
+
+	s.mu.Lock()
+	last, ok := s.seen[user.ID]
+	s.mu.Unlock()
+
+	if ok && last.user == user && now.Sub(last.at) < s.interval {
+		return
+	}
+
+	err := s.repo.SaveProfile(ctx, Profile{ID: user.ID, Username: user.Username, GlobalName: user.GlobalName, Avatar: user.Avatar})
+
+	if err != nil {
+		s.logger.Warn("failed to record user profile", "userId", user.ID, "err", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.seen[user.ID] = seenUser{user: user, at: now}
+	s.mu.Unlock()
+}
+
+// GetDirectory lists the users who used the booking system with their
+// activity, those whose username or global name contains query when it is
+// set, a page at a time.
+func (s *Service) GetDirectory(ctx context.Context, query, cursor string, limit int) (DirectoryPage, error) {
+	var after *Cursor
+
+	if len(cursor) != 0 {
+		decoded, err := DecodeCursor(cursor)
+
+		if err != nil {
+			return DirectoryPage{}, err
+		}
+
+		after = &decoded
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	limit = min(limit, maxLimit)
+
+	// one more entry tells whether there is a next page
+	entries, err := s.repo.GetDirectory(ctx, strings.TrimSpace(query), after, limit+1)
+
+	if err != nil {
+		return DirectoryPage{}, err
+	}
+
+	page := DirectoryPage{Users: entries}
+
+	if len(entries) > limit {
+		page.Users = entries[:limit]
+		last := page.Users[limit-1]
+		page.NextCursor = Cursor{Username: strings.ToLower(last.Username), ID: last.ID}.Encode()
+	}
+
+	for i := range page.Users {
+		page.Users[i].AvatarURL = page.Users[i].user().AvatarURL()
+	}
+
+	return page, nil
+}
@@ -0,0 +1,124 @@
+package profile_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/profile"
+	profile_mocks "github.com/hanksha/tbz-booking-system-backend/profile/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var alice = discord.User{ID: "1", Username: "alice", GlobalName: "Alice", Avatar: "a1"}
+
+func TestRecord(t *testing.T) {
+	t.Run("once per interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		repo.EXPECT().SaveProfile(gomock.Any(), profile.Profile{ID: "1", Username: "alice", GlobalName: "Alice", Avatar: "a1"}).Return(nil).Times(1)
+
+		svc.Record(context.Background(), alice)
+		svc.Record(context.Background(), alice)
+	})
+
+	t.Run("username changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		renamed := alice
+		renamed.Username = "alice2"
+
+		gomock.InOrder(
+			repo.EXPECT().SaveProfile(gomock.Any(), gomock.Any()).Return(nil),
+			repo.EXPECT().SaveProfile(gomock.Any(), profile.Profile{ID: "1", Username: "alice2", GlobalName: "Alice", Avatar: "a1"}).Return(nil),
+		)
+
+		svc.Record(context.Background(), alice)
+		svc.Record(context.Background(), renamed)
+	})
+
+	t.Run("retried after a failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		gomock.InOrder(
+			repo.EXPECT().SaveProfile(gomock.Any(), gomock.Any()).Return(errors.New("connection refused")),
+			repo.EXPECT().SaveProfile(gomock.Any(), gomock.Any()).Return(nil),
+		)
+
+		svc.Record(context.Background(), alice)
+		svc.Record(context.Background(), alice)
+	})
+}
+
+func TestGetDirectory(t *testing.T) {
+	entries := []profile.DirectoryEntry{
+		{Profile: profile.Profile{ID: "1", Username: "Alice", Avatar: "a1"}, Bookings: 3},
+		{Profile: profile.Profile{ID: "2", Username: "alicia"}, Suspended: true},
+		{Profile: profile.Profile{ID: "3", Username: "alina"}},
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		repo.EXPECT().GetDirectory(gomock.Any(), "ali", nil, 3).Return(entries, nil).Times(1)
+
+		page, err := svc.GetDirectory(context.Background(), " ali ", "", 2)
+
+		require.Nil(t, err)
+		require.Len(t, page.Users, 2)
+		require.Equal(t, "https://cdn.discordapp.com/avatars/1/a1.png", page.Users[0].AvatarURL)
+		require.Empty(t, page.Users[1].AvatarURL)
+
+		cursor, err := profile.DecodeCursor(page.NextCursor)
+
+		require.Nil(t, err)
+		require.Equal(t, profile.Cursor{Username: "alicia", ID: "2"}, cursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		after := profile.Cursor{Username: "alicia", ID: "2"}
+		repo.EXPECT().GetDirectory(gomock.Any(), "", &after, 201).Return(entries[2:], nil).Times(1)
+
+		page, err := svc.GetDirectory(context.Background(), "", after.Encode(), 1000)
+
+		require.Nil(t, err)
+		require.Len(t, page.Users, 1)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := profile_mocks.NewMockProfileRepository(ctrl)
+		svc := profile.NewService(repo)
+
+		_, err := svc.GetDirectory(context.Background(), "", "not-a-cursor", 0)
+
+		require.ErrorIs(t, err, profile.ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/ranking (interfaces: RankingRepository)
+//
+// Generated by this command:
+//
+//	mockgen . RankingRepository
+//
+
+// Package mock_ranking is a generated GoMock package.
+package mock_ranking
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ranking "github.com/hanksha/tbz-booking-system-backend/ranking"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingRepository is a mock of RankingRepository interface.
+type MockRankingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRankingRepositoryMockRecorder is the mock recorder for MockRankingRepository.
+type MockRankingRepositoryMockRecorder struct {
+	mock *MockRankingRepository
+}
+
+// NewMockRankingRepository creates a new mock instance.
+func NewMockRankingRepository(ctrl *gomock.Controller) *MockRankingRepository {
+	mock := &MockRankingRepository{ctrl: ctrl}
+	mock.recorder = &MockRankingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingRepository) EXPECT() *MockRankingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMatches mocks base method.
+func (m *MockRankingRepository) GetMatches(ctx context.Context, game string) ([]ranking.Match, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatches", ctx, game)
+	ret0, _ := ret[0].([]ranking.Match)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatches indicates an expected call of GetMatches.
+func (mr *MockRankingRepositoryMockRecorder) GetMatches(ctx, game any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatches", reflect.TypeOf((*MockRankingRepository)(nil).GetMatches), ctx, game)
+}
+
+// GetRankedGames mocks base method.
+func (m *MockRankingRepository) GetRankedGames(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankedGames", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankedGames indicates an expected call of GetRankedGames.
+func (mr *MockRankingRepositoryMockRecorder) GetRankedGames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankedGames", reflect.TypeOf((*MockRankingRepository)(nil).GetRankedGames), ctx)
+}
@@ -0,0 +1,196 @@
+package ranking
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	initialRating = 1000
+	// kFactor is the most rating a player wins or loses against one opponent
+	kFactor = 32
+)
+
+// Match is the reported result of a completed booking.
+type Match struct {
+	Game     string
+	Players  []string
+	Winner   string
+	Scores   map[string]int
+	PlayedAt time.Time
+}
+
+// Ranking is the standing of a player in a game.
+type Ranking struct {
+	Rank   int    `json:"rank"`
+	Player string `json:"player"`
+	Rating int    `json:"rating"`
+	Games  int    `json:"games"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Draws  int    `json:"draws"`
+}
+
+type standing struct {
+	rating              float64
+	games, wins, losses int
+	draws               int
+}
+
+// Compute rates the players of the matches, played in order, with the ELO
+// system. Every pair of players of a match is a duel: the higher score wins
+// it, or the winner when the match has no scores.
+func Compute(matches []Match) []Ranking {
+	standings := map[string]*standing{}
+
+	get := func(player string) *standing {
+		if _, ok := standings[player]; !ok {
+			standings[player] = &standing{rating: initialRating}
+		}
+		return standings[player]
+	}
+
+	for _, match := range matches {
+		players := participants(match)
+		deltas := make(map[string]float64, len(players))
+
+		for i, a := range players {
+			for _, b := range players[i+1:] {
+				outcome, ok := duel(match, a, b)
+
+				if !ok {
+					continue
+				}
+
+				expected := 1 / (1 + math.Pow(10, (get(b).rating-get(a).rating)/400))
+				deltas[a] += kFactor * (outcome - expected)
+				deltas[b] -= kFactor * (outcome - expected)
+			}
+		}
+
+		winners := matchWinners(match, players)
+
+		for _, player := range players {
+			s := get(player)
+			s.rating += deltas[player]
+			s.games++
+
+			switch {
+			case len(winners) == 1 && winners[0] == player:
+				s.wins++
+			case len(winners) > 1 && slices.Contains(winners, player):
+				s.draws++
+			case len(winners) > 0:
+				s.losses++
+			}
+		}
+	}
+
+	rankings := make([]Ranking, 0, len(standings))
+
+	for player, s := range standings {
+		rankings = append(rankings, Ranking{
+			Player: player,
+			Rating: int(math.Round(s.rating)),
+			Games:  s.games,
+			Wins:   s.wins,
+			Losses: s.losses,
+			Draws:  s.draws,
+		})
+	}
+
+	slices.SortFunc(rankings, func(a, b Ranking) int {
+		if a.Rating != b.Rating {
+			return b.Rating - a.Rating
+		}
+		if a.Wins != b.Wins {
+			return b.Wins - a.Wins
+		}
+		return strings.Compare(a.Player, b.Player)
+	})
+
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+
+	return rankings
+}
+
+// participants are the players of a match, or the scored ones when the
+// booking had no players listed.
+func participants(match Match) []string {
+	players := slices.Clone(match.Players)
+
+	for player := range match.Scores {
+		if !slices.Contains(players, player) {
+			players = append(players, player)
+		}
+	}
+
+	if len(match.Winner) != 0 && !slices.Contains(players, match.Winner) {
+		players = append(players, match.Winner)
+	}
+
+	slices.Sort(players)
+
+	return players
+}
+
+// duel returns the outcome of the duel for a, 1 for a win, 0.5 for a draw and
+// 0 for a loss, and false when the match tells nothing about it.
+func duel(match Match, a, b string) (float64, bool) {
+	scoreA, okA := match.Scores[a]
+	scoreB, okB := match.Scores[b]
+
+	if okA && okB {
+		switch {
+		case scoreA > scoreB:
+			return 1, true
+		case scoreA < scoreB:
+			return 0, true
+		default:
+			return 0.5, true
+		}
+	}
+
+	switch match.Winner {
+	case a:
+		return 1, true
+	case b:
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// matchWinners is the winner of the match, or the players sharing the top
+// score, several for a draw.
+func matchWinners(match Match, players []string) []string {
+	if len(match.Winner) != 0 {
+		return []string{match.Winner}
+	}
+
+	var winners []string
+	top := math.MinInt
+
+	for _, player := range players {
+		score, ok := match.Scores[player]
+
+		if !ok {
+			continue
+		}
+
+		if score > top {
+			top = score
+			winners = winners[:0]
+		}
+
+		if score == top {
+			winners = append(winners, player)
+		}
+	}
+
+	return winners
+}
@@ -0,0 +1,66 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+// GetMatches lists the reported results of a game, ignoring case, in the
+// order they were played.
+func (r *Repository) GetMatches(ctx context.Context, game string) ([]Match, error) {
+	sql := `
+		SELECT b.game, COALESCE(b.players, '{}'), COALESCE(r.winner, ''), r.scores, b."dateTime"
+		FROM "game-table-booking".booking_result r
+		JOIN "game-table-booking".booking b ON b.id = r."bookingId"
+		WHERE lower(b.game)=lower($1) AND b."deletedAt" IS NULL
+		ORDER BY b."dateTime", b.id;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch matches of '%v': %w", game, err)
+	}
+
+	matches, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Match])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning match rows: %w", err)
+	}
+
+	return matches, nil
+}
+
+// GetRankedGames lists the games having at least one reported result.
+func (r *Repository) GetRankedGames(ctx context.Context) ([]string, error) {
+	sql := `
+		SELECT DISTINCT ON (lower(b.game)) b.game
+		FROM "game-table-booking".booking_result r
+		JOIN "game-table-booking".booking b ON b.id = r."bookingId"
+		WHERE b."deletedAt" IS NULL
+		ORDER BY lower(b.game), b.game;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ranked games: %w", err)
+	}
+
+	games, err := pgx.CollectRows(rows, pgx.RowTo[string])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning game rows: %w", err)
+	}
+
+	return games, nil
+}
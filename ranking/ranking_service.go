@@ -0,0 +1,123 @@
+package ranking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// leaderboardSize is the number of players listed per game in the
+// leaderboard post.
+const leaderboardSize = 5
+
+// Discord accepts at most 10 embeds per message
+const maxEmbeds = 10
+
+type RankingRepository interface {
+	GetMatches(ctx context.Context, game string) ([]Match, error)
+	GetRankedGames(ctx context.Context) ([]string, error)
+}
+
+type MessageSender interface {
+	SendMessage(ctx context.Context, channelID string, message discord.Message) error
+}
+
+type Service struct {
+	repo      RankingRepository
+	sender    MessageSender
+	channelID string
+	// channel overrides channelID when set
+	channel func(ctx context.Context) string
+}
+
+type ServiceOption func(*Service)
+
+// WithChannelLookup posts the leaderboard to the channel lookup returns,
+// rather than the one given to NewService, for the admins to change it
+// without a redeploy.
+func WithChannelLookup(lookup func(ctx context.Context) string) ServiceOption {
+	return func(s *Service) {
+		s.channel = lookup
+	}
+}
+
+func NewService(repo RankingRepository, sender MessageSender, channelID string, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, sender: sender, channelID: channelID}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) channelOf(ctx context.Context) string {
+	if s.channel != nil {
+		return s.channel(ctx)
+	}
+
+	return s.channelID
+}
+
+// GetRankings ranks the players of a game, from its reported results.
+func (s *Service) GetRankings(ctx context.Context, game string) ([]Ranking, error) {
+	matches, err := s.repo.GetMatches(ctx, game)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Compute(matches), nil
+}
+
+// PostLeaderboard posts the top players of every ranked game to the Discord
+// channel.
+func (s *Service) PostLeaderboard(ctx context.Context) error {
+	games, err := s.repo.GetRankedGames(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	var embeds []discord.Embed
+
+	for _, game := range games {
+		rankings, err := s.GetRankings(ctx, game)
+
+		if err != nil {
+			return err
+		}
+
+		if len(rankings) > 0 {
+			embeds = append(embeds, leaderboardEmbed(game, rankings))
+		}
+	}
+
+	for i := 0; i < len(embeds); i += maxEmbeds {
+		message := discord.Message{Embeds: embeds[i:min(i+maxEmbeds, len(embeds))]}
+
+		if i == 0 {
+			message.Content = "Classement mensuel des joueurs :trophy:"
+		}
+
+		if err := s.sender.SendMessage(ctx, s.channelOf(ctx), message); err != nil {
+			return fmt.Errorf("failed to post leaderboard: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func leaderboardEmbed(game string, rankings []Ranking) discord.Embed {
+	embed := discord.Embed{Type: "rich", Title: game}
+
+	for _, ranking := range rankings[:min(leaderboardSize, len(rankings))] {
+		embed.Fields = append(embed.Fields, discord.EmbedField{
+			Name:  fmt.Sprintf("%d. %v", ranking.Rank, ranking.Player),
+			Value: fmt.Sprintf("%d ELO, %dV / %dD / %dN", ranking.Rating, ranking.Wins, ranking.Losses, ranking.Draws),
+		})
+	}
+
+	return embed
+}
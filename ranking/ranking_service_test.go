@@ -0,0 +1,128 @@
+package ranking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/ranking"
+	ranking_mocks "github.com/hanksha/tbz-booking-system-backend/ranking/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingSender struct {
+	messages []discord.Message
+	err      error
+}
+
+func (s *recordingSender) SendMessage(ctx context.Context, channelID string, message discord.Message) error {
+	s.messages = append(s.messages, message)
+	return s.err
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("winner beats every player", func(t *testing.T) {
+		rankings := ranking.Compute([]ranking.Match{
+			{Players: []string{"alice", "bob", "carol"}, Winner: "alice"},
+		})
+
+		require.Equal(t, []ranking.Ranking{
+			{Rank: 1, Player: "alice", Rating: 1032, Games: 1, Wins: 1},
+			{Rank: 2, Player: "bob", Rating: 984, Games: 1, Losses: 1},
+			{Rank: 3, Player: "carol", Rating: 984, Games: 1, Losses: 1},
+		}, rankings)
+	})
+
+	t.Run("scores decide the duels", func(t *testing.T) {
+		rankings := ranking.Compute([]ranking.Match{
+			{Players: []string{"alice", "bob", "carol"}, Scores: map[string]int{"alice": 10, "bob": 7, "carol": 3}},
+		})
+
+		require.Equal(t, []ranking.Ranking{
+			{Rank: 1, Player: "alice", Rating: 1032, Games: 1, Wins: 1},
+			{Rank: 2, Player: "bob", Rating: 1000, Games: 1, Losses: 1},
+			{Rank: 3, Player: "carol", Rating: 968, Games: 1, Losses: 1},
+		}, rankings)
+	})
+
+	t.Run("tied top score is a draw", func(t *testing.T) {
+		rankings := ranking.Compute([]ranking.Match{
+			{Players: []string{"alice", "bob"}, Scores: map[string]int{"alice": 5, "bob": 5}},
+		})
+
+		require.Equal(t, []ranking.Ranking{
+			{Rank: 1, Player: "alice", Rating: 1000, Games: 1, Draws: 1},
+			{Rank: 2, Player: "bob", Rating: 1000, Games: 1, Draws: 1},
+		}, rankings)
+	})
+
+	t.Run("upsets move ratings more", func(t *testing.T) {
+		rankings := ranking.Compute([]ranking.Match{
+			{Players: []string{"alice", "bob"}, Winner: "alice"},
+			{Players: []string{"alice", "bob"}, Winner: "bob"},
+		})
+
+		// bob beat the favorite, he wins back more than he lost
+		require.Equal(t, "bob", rankings[0].Player)
+		require.Greater(t, rankings[0].Rating, 1000)
+	})
+}
+
+func TestPostLeaderboard(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ranking_mocks.NewMockRankingRepository(ctrl)
+		sender := &recordingSender{}
+		svc := ranking.NewService(repo, sender, "channel")
+
+		repo.EXPECT().GetRankedGames(gomock.Any()).Return([]string{"Star Wars"}, nil).Times(1)
+		repo.EXPECT().GetMatches(gomock.Any(), "Star Wars").Return([]ranking.Match{
+			{Players: []string{"alice", "bob"}, Winner: "alice"},
+		}, nil).Times(1)
+
+		err := svc.PostLeaderboard(context.Background())
+
+		require.Nil(t, err)
+		require.Len(t, sender.messages, 1)
+		require.Equal(t, "Star Wars", sender.messages[0].Embeds[0].Title)
+		require.Equal(t, discord.EmbedField{Name: "1. alice", Value: "1016 ELO, 1V / 0D / 0N"}, sender.messages[0].Embeds[0].Fields[0])
+	})
+
+	t.Run("nothing ranked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ranking_mocks.NewMockRankingRepository(ctrl)
+		sender := &recordingSender{}
+		svc := ranking.NewService(repo, sender, "channel")
+
+		repo.EXPECT().GetRankedGames(gomock.Any()).Return(nil, nil).Times(1)
+
+		err := svc.PostLeaderboard(context.Background())
+
+		require.Nil(t, err)
+		require.Empty(t, sender.messages)
+	})
+
+	t.Run("send failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := ranking_mocks.NewMockRankingRepository(ctrl)
+		sender := &recordingSender{err: errors.New("discord down")}
+		svc := ranking.NewService(repo, sender, "channel")
+
+		repo.EXPECT().GetRankedGames(gomock.Any()).Return([]string{"Chess"}, nil).Times(1)
+		repo.EXPECT().GetMatches(gomock.Any(), "Chess").Return([]ranking.Match{
+			{Players: []string{"alice", "bob"}, Winner: "bob"},
+		}, nil).Times(1)
+
+		err := svc.PostLeaderboard(context.Background())
+
+		require.ErrorContains(t, err, "discord down")
+	})
+}
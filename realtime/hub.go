@@ -0,0 +1,118 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+type Message struct {
+	ID int64 `json:"id"`
+	bk.Event
+}
+
+type Subscription struct {
+	C      <-chan Message
+	ch     chan Message
+	filter func(bk.Booking) bool
+}
+
+// Hub fans booking events out to live subscribers and keeps a bounded
+// history so reconnecting clients can resume from their last event ID.
+type Hub struct {
+	mu          sync.Mutex
+	lastID      int64
+	history     []Message
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		historySize: historySize,
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+func (h *Hub) Publish(ctx context.Context, event bk.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	msg := Message{ID: h.lastID, Event: event}
+
+	h.history = append(h.history, msg)
+	if len(h.history) > h.historySize {
+		h.history = h.history[len(h.history)-h.historySize:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter(event.Booking) {
+			continue
+		}
+
+		select {
+		case sub.ch <- msg:
+		default:
+			// slow consumer, drop it so it reconnects and resumes
+			delete(h.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe registers a subscriber receiving the events matching filter.
+// When lastEventID is positive, the missed events are returned as backlog;
+// resumed is false when they are no longer in history and the client has
+// to refetch its state.
+func (h *Hub) Subscribe(lastEventID int64, filter func(bk.Booking) bool) (sub *Subscription, backlog []Message, resumed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Message, 32)
+	sub = &Subscription{C: ch, ch: ch, filter: filter}
+	h.subscribers[sub] = struct{}{}
+
+	if lastEventID <= 0 {
+		return sub, nil, true
+	}
+
+	if lastEventID > h.lastID {
+		return sub, nil, false
+	}
+
+	if len(h.history) != 0 && h.history[0].ID > lastEventID+1 {
+		return sub, nil, false
+	}
+
+	for _, msg := range h.history {
+		if msg.ID > lastEventID && filter(msg.Booking) {
+			backlog = append(backlog, msg)
+		}
+	}
+
+	return sub, backlog, true
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Close ends every subscription, so their WebSocket connections are closed
+// on shutdown. Hijacked connections are not tracked by http.Server.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.ch)
+	}
+}
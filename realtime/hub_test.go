@@ -0,0 +1,91 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/realtime"
+	"github.com/stretchr/testify/require"
+)
+
+func all(bk.Booking) bool { return true }
+
+func TestPublish(t *testing.T) {
+	t.Run("delivers matching events", func(t *testing.T) {
+		hub := realtime.NewHub(10)
+		sub, _, _ := hub.Subscribe(0, func(b bk.Booking) bool { return b.UserID == "user1" })
+
+		hub.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated, Booking: bk.Booking{ID: "1", UserID: "user2"}})
+		hub.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated, Booking: bk.Booking{ID: "2", UserID: "user1"}})
+
+		msg := <-sub.C
+		require.Equal(t, int64(2), msg.ID)
+		require.Equal(t, "2", msg.Booking.ID)
+		require.Len(t, sub.C, 0)
+	})
+
+	t.Run("drops slow subscribers", func(t *testing.T) {
+		hub := realtime.NewHub(100)
+		sub, _, _ := hub.Subscribe(0, all)
+
+		for i := 0; i < 50; i++ {
+			hub.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated})
+		}
+
+		count := 0
+		for range sub.C {
+			count++
+		}
+
+		require.Less(t, count, 50)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	hub := realtime.NewHub(3)
+
+	for i := 0; i < 5; i++ {
+		hub.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated})
+	}
+
+	t.Run("resume from history", func(t *testing.T) {
+		sub, backlog, resumed := hub.Subscribe(3, all)
+		defer hub.Unsubscribe(sub)
+
+		require.True(t, resumed)
+		require.Len(t, backlog, 2)
+		require.Equal(t, int64(4), backlog[0].ID)
+		require.Equal(t, int64(5), backlog[1].ID)
+	})
+
+	t.Run("resume too old", func(t *testing.T) {
+		sub, backlog, resumed := hub.Subscribe(1, all)
+		defer hub.Unsubscribe(sub)
+
+		require.False(t, resumed)
+		require.Empty(t, backlog)
+	})
+
+	t.Run("unknown event id", func(t *testing.T) {
+		sub, backlog, resumed := hub.Subscribe(42, all)
+		defer hub.Unsubscribe(sub)
+
+		require.False(t, resumed)
+		require.Empty(t, backlog)
+	})
+}
+
+func TestHubClose(t *testing.T) {
+	hub := realtime.NewHub(10)
+
+	sub, _, _ := hub.Subscribe(0, all)
+
+	hub.Close()
+
+	_, ok := <-sub.C
+	require.False(t, ok)
+
+	// unsubscribing after close is a no-op
+	hub.Unsubscribe(sub)
+}
@@ -0,0 +1,133 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+// ErrProduction is returned when seeding is attempted with APP_ENV=production.
+var ErrProduction = errors.New("refusing to seed a production database")
+
+type game struct {
+	name   string
+	points []int
+	// players is the usual number of players at the table
+	players int
+}
+
+var games = []game{
+	{name: "Warhammer 40,000", points: []int{1000, 1500, 2000}, players: 2},
+	{name: "Age of Sigmar", points: []int{1000, 2000}, players: 2},
+	{name: "Kill Team", points: []int{0}, players: 2},
+	{name: "Necromunda", points: []int{1000, 1500}, players: 4},
+	{name: "Blood Bowl", points: []int{1000, 1100}, players: 2},
+	{name: "Star Wars Legion", points: []int{800, 1000}, players: 2},
+	{name: "Star Wars Shatterpoint", points: []int{0}, players: 2},
+	{name: "Infinity", points: []int{200, 300}, players: 2},
+	{name: "Bolt Action", points: []int{1000, 1250}, players: 2},
+	{name: "Marvel Crisis Protocol", points: []int{10, 16}, players: 2},
+}
+
+var usernames = []string{
+	"hanksha", "kaelis", "grimgor", "lady.vex", "tau.empire", "old.greg",
+	"skarbrand", "necronlord", "bb.coach", "kenobi", "zelda.m", "pirouette",
+	"commissar.k", "blightking", "moonclan", "drukhari.jo",
+}
+
+var descriptions = []string{
+	"",
+	"Partie d'entraînement avant le tournoi",
+	"Liste en test, soyez indulgents",
+	"Campagne en cours, scénario 3",
+	"Première partie, explications bienvenues",
+	"Table décor ruines si possible",
+	"On ramène nos figurines peintes !",
+}
+
+// Bookings generates count bookings spread over the 90 days around now, with
+// past bookings mostly accepted and upcoming ones mostly pending.
+func Bookings(r *rand.Rand, count int, now time.Time) []bk.Booking {
+	bookings := make([]bk.Booking, 0, count)
+
+	for range count {
+		g := games[r.IntN(len(games))]
+		owner := usernames[r.IntN(len(usernames))]
+
+		players := []string{owner}
+		for len(players) < g.players {
+			player := usernames[r.IntN(len(usernames))]
+			if !slices.Contains(players, player) {
+				players = append(players, player)
+			}
+		}
+
+		day := now.AddDate(0, 0, r.IntN(90)-60)
+		dateTime := time.Date(day.Year(), day.Month(), day.Day(), 14+r.IntN(7), []int{0, 30}[r.IntN(2)], 0, 0, time.UTC)
+
+		bookings = append(bookings, bk.Booking{
+			Game:            g.name,
+			UserID:          fmt.Sprintf("seed-%v", owner),
+			Username:        owner,
+			Points:          g.points[r.IntN(len(g.points))],
+			Description:     descriptions[r.IntN(len(descriptions))],
+			Status:          status(r, dateTime.Before(now)),
+			ReminderEnabled: r.IntN(2) == 0,
+			DateTime:        dateTime,
+			Players:         players,
+		})
+	}
+
+	return bookings
+}
+
+func status(r *rand.Rand, past bool) string {
+	roll := r.IntN(100)
+
+	if past {
+		switch {
+		case roll < 80:
+			return "accepted"
+		case roll < 90:
+			return "canceled"
+		default:
+			return "refused"
+		}
+	}
+
+	switch {
+	case roll < 50:
+		return "pending"
+	case roll < 90:
+		return "accepted"
+	case roll < 95:
+		return "canceled"
+	default:
+		return "refused"
+	}
+}
+
+// Run inserts count generated bookings through repo. appEnv is the APP_ENV of
+// the target deployment, seeding is refused in production.
+func Run(ctx context.Context, repo bk.BookingRepository, appEnv string, count int) error {
+	if appEnv == "production" {
+		return ErrProduction
+	}
+
+	if count <= 0 {
+		return fmt.Errorf("invalid seed count %d", count)
+	}
+
+	r := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0))
+
+	if err := repo.InsertManyBookings(ctx, Bookings(r, count, time.Now())); err != nil {
+		return fmt.Errorf("failed to seed bookings: %w", err)
+	}
+
+	return nil
+}
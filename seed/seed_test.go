@@ -0,0 +1,73 @@
+package seed_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/seed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookings(t *testing.T) {
+	now := time.Date(2026, 3, 12, 12, 0, 0, 0, time.UTC)
+
+	t.Run("realistic bookings", func(t *testing.T) {
+		bookings := seed.Bookings(rand.New(rand.NewPCG(1, 2)), 200, now)
+
+		require.Len(t, bookings, 200)
+
+		games := map[string]struct{}{}
+		statuses := map[string]struct{}{}
+
+		for _, booking := range bookings {
+			games[booking.Game] = struct{}{}
+			statuses[booking.Status] = struct{}{}
+
+			require.Contains(t, booking.Players, booking.Username)
+			require.Contains(t, []string{"pending", "accepted", "refused", "canceled"}, booking.Status)
+			require.WithinDuration(t, now, booking.DateTime, 61*24*time.Hour)
+
+			if booking.DateTime.Before(now) {
+				require.NotEqual(t, "pending", booking.Status)
+			}
+		}
+
+		require.Greater(t, len(games), 5)
+		require.Len(t, statuses, 4)
+	})
+
+	t.Run("deterministic for a seed", func(t *testing.T) {
+		first := seed.Bookings(rand.New(rand.NewPCG(1, 2)), 10, now)
+		second := seed.Bookings(rand.New(rand.NewPCG(1, 2)), 10, now)
+
+		require.Equal(t, first, second)
+	})
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("inserts bookings", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		require.Nil(t, seed.Run(ctx, repo, "staging", 25))
+
+		history, err := repo.GetBookingHistory(ctx, nil, bk.Filter{}, nil, 100)
+
+		require.Nil(t, err)
+		require.Len(t, history, 25)
+	})
+
+	t.Run("refuses production", func(t *testing.T) {
+		repo := bk.NewMemoryRepository()
+
+		require.ErrorIs(t, seed.Run(ctx, repo, "production", 25), seed.ErrProduction)
+
+		history, _ := repo.GetBookingHistory(ctx, nil, bk.Filter{}, nil, 100)
+
+		require.Empty(t, history)
+	})
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/seed"
+)
+
+const seedUsage = "usage: seed [count]"
+
+const defaultSeedCount = 50
+
+// runSeedCommand handles `server seed [count]`, filling a development or
+// staging database with generated bookings. It refuses to run when appEnv
+// is production.
+func runSeedCommand(ctx context.Context, repo bk.BookingRepository, appEnv string, args []string) error {
+	count := defaultSeedCount
+
+	if len(args) > 1 {
+		return errors.New(seedUsage)
+	}
+
+	if len(args) == 1 {
+		var err error
+		if count, err = strconv.Atoi(args[0]); err != nil || count < 1 {
+			return fmt.Errorf("invalid count '%v': %s", args[0], seedUsage)
+		}
+	}
+
+	if err := seed.Run(ctx, repo, appEnv, count); err != nil {
+		return err
+	}
+
+	slog.Info("seeded bookings", "count", count)
+	return nil
+}
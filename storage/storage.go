@@ -0,0 +1,9 @@
+// Package storage keeps files, the attachments of the bookings, on the local
+// disk or in an S3-compatible bucket.
+package storage
+
+import "errors"
+
+var ErrNotFound = errors.New("file not found")
+
+var ErrInvalidKey = errors.New("invalid file key")
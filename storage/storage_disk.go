@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DiskStorage keeps the files under a directory of the local disk, fine for
+// a single replica.
+type DiskStorage struct {
+	dir string
+}
+
+func NewDiskStorage(dir string) *DiskStorage {
+	return &DiskStorage{dir: dir}
+}
+
+func (s *DiskStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	path, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory of '%v': %w", key, err)
+	}
+
+	// written aside then renamed, not to serve a partial file
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, content, 0o640); err != nil {
+		return fmt.Errorf("failed to write '%v': %w", key, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write '%v': %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *DiskStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open '%v': %w", key, err)
+	}
+
+	return file, nil
+}
+
+func (s *DiskStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete '%v': %w", key, err)
+	}
+
+	return nil
+}
+
+// path resolves the key under the directory, refusing the keys escaping it.
+func (s *DiskStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(key) || strings.ContainsRune(key, '\\') {
+		return "", fmt.Errorf("%w: '%v'", ErrInvalidKey, key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config locates an S3-compatible bucket, such as AWS S3, MinIO or
+// Cloudflare R2, addressed in path style.
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Storage keeps the files in an S3-compatible bucket, the requests signed
+// with AWS Signature Version 4.
+type S3Storage struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Storage(cfg S3Config, client *http.Client) *S3Storage {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &S3Storage{cfg: cfg, client: client, now: time.Now}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, content)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("failed to upload '%v': %w", key, err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload '%v': %w", key, responseError(res))
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to download '%v': %w", key, err)
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrNotFound
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, fmt.Errorf("failed to download '%v': %w", key, responseError(res))
+	}
+
+	return res.Body, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete '%v': %w", key, err)
+	}
+
+	defer res.Body.Close()
+
+	// deleting a missing object succeeds
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to delete '%v': %w", key, responseError(res))
+	}
+
+	return nil
+}
+
+// request builds the signed request on the object at key.
+func (s *S3Storage) request(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if len(key) == 0 || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("%w: '%v'", ErrInvalidKey, key)
+	}
+
+	segments := strings.Split(key, "/")
+
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	path := "/" + url.PathEscape(s.cfg.Bucket) + "/" + strings.Join(segments, "/")
+
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+path, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request on '%v': %w", key, err)
+	}
+
+	s.sign(req, path, body)
+
+	return req, nil
+}
+
+// sign adds the Authorization header of AWS Signature Version 4, signing
+// the host, the payload hash and the date.
+func (s *S3Storage) sign(req *http.Request, path string, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+	return fmt.Errorf("status %d: %v", res.StatusCode, strings.TrimSpace(string(body)))
+}
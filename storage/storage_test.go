@@ -0,0 +1,86 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStorage(t *testing.T) {
+	ctx := context.Background()
+	disk := storage.NewDiskStorage(t.TempDir())
+
+	require.Nil(t, disk.Put(ctx, "bookings/1/abc", []byte("army list"), "text/plain"))
+
+	file, err := disk.Get(ctx, "bookings/1/abc")
+	require.Nil(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	require.Equal(t, "army list", string(content))
+
+	require.Nil(t, disk.Delete(ctx, "bookings/1/abc"))
+
+	_, err = disk.Get(ctx, "bookings/1/abc")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.ErrorIs(t, disk.Put(ctx, "../escape", []byte("x"), "text/plain"), storage.ErrInvalidKey)
+}
+
+func TestS3Storage(t *testing.T) {
+	objects := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=key/") || !strings.Contains(auth, "/eu-west-3/s3/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	s3 := storage.NewS3Storage(storage.S3Config{
+		Endpoint:        server.URL,
+		Bucket:          "attachments",
+		Region:          "eu-west-3",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	}, server.Client())
+
+	require.Nil(t, s3.Put(ctx, "bookings/1/abc", []byte("scenario"), "application/pdf"))
+	require.Equal(t, "scenario", objects["/attachments/bookings/1/abc"])
+
+	body, err := s3.Get(ctx, "bookings/1/abc")
+	require.Nil(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	require.Equal(t, "scenario", string(content))
+
+	require.Nil(t, s3.Delete(ctx, "bookings/1/abc"))
+
+	_, err = s3.Get(ctx, "bookings/1/abc")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,80 @@
+// Package telegram sends the booking notifications to the members who
+// opted in through a Telegram bot, for those not using Discord.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type BotConfig struct {
+	APIURL string
+	Token  string
+}
+
+// BotClient sends messages as the bot through the Telegram Bot API.
+type BotClient struct {
+	cfg    BotConfig
+	client *http.Client
+}
+
+func NewBotClient(cfg BotConfig, client *http.Client) *BotClient {
+	cfg.APIURL = strings.TrimSuffix(cfg.APIURL, "/")
+
+	return &BotClient{cfg: cfg, client: client}
+}
+
+// SendMessage sends the HTML formatted text to the chat. The members start
+// the chat with the bot, which cannot write first.
+func (c *BotClient) SendMessage(ctx context.Context, chatID, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to encode Telegram message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.APIURL+"/bot"+c.cfg.Token+"/sendMessage", bytes.NewReader(body))
+
+	if err != nil {
+		return fmt.Errorf("failed to create Telegram request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+
+	if err != nil {
+		// the URL holds the token, keep it out of the logs
+		return fmt.Errorf("failed to send Telegram message to %v: %w", chatID, redact(err, c.cfg.Token))
+	}
+
+	defer res.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil || !result.OK {
+		return fmt.Errorf("failed to send Telegram message to %v: %v %v", chatID, res.StatusCode, result.Description)
+	}
+
+	return nil
+}
+
+func redact(err error, token string) error {
+	if len(token) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), token, "***"))
+}
@@ -0,0 +1,185 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/i18n"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+)
+
+type SubscriberRepository interface {
+	GetSubscribers(ctx context.Context, usernames []string) ([]preference.Preferences, error)
+}
+
+type MessageSender interface {
+	SendMessage(ctx context.Context, chatID, text string) error
+}
+
+type BookingSource interface {
+	GetActiveBookings(ctx context.Context) ([]bk.Booking, error)
+}
+
+// titles are those of the Discord notifications of the events, the
+// modifications being left out as every check-in or payment is one
+var titles = map[string]string{
+	bk.EventBookingCreated:  "New Booking",
+	bk.EventBookingAccepted: "Booking Accepted",
+	bk.EventBookingRefused:  "Booking Refused",
+	bk.EventBookingCanceled: "Booking Canceled",
+	bk.EventBookingReopened: "Booking Reopened",
+}
+
+var emojis = map[string]string{
+	bk.EventBookingCreated:  "📅",
+	bk.EventBookingAccepted: "✅",
+	bk.EventBookingRefused:  "⛔",
+	bk.EventBookingCanceled: "❎",
+	bk.EventBookingReopened: "♻️",
+}
+
+// Service messages the organizer and the players of the bookings who opted
+// in on Telegram along the life of their booking, and on the day of the game.
+type Service struct {
+	subscribers SubscriberRepository
+	sender      MessageSender
+	bookings    BookingSource
+	// bookingURL is the frontend page of the bookings, their id appended
+	bookingURL string
+	location   *time.Location
+	logger     *slog.Logger
+	wg         sync.WaitGroup
+}
+
+func NewService(subscribers SubscriberRepository, sender MessageSender, bookings BookingSource, bookingURL string, loc *time.Location) *Service {
+	return &Service{
+		subscribers: subscribers,
+		sender:      sender,
+		bookings:    bookings,
+		bookingURL:  strings.TrimSuffix(bookingURL, "/"),
+		location:    loc,
+		logger:      slog.Default().With("component", "telegram"),
+	}
+}
+
+// Publish implements booking.EventPublisher. Messages are sent in the
+// background so booking operations never wait on Telegram.
+func (s *Service) Publish(ctx context.Context, event bk.Event) {
+	title, ok := titles[event.Type]
+
+	if !ok {
+		return
+	}
+
+	text := s.format(event.Booking, i18n.Translate(i18n.French, title)+" "+emojis[event.Type])
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.send(ctx, event.Booking, text); err != nil {
+			s.logger.Error("failed to send Telegram messages", "bookingId", event.Booking.ID, "event", event.Type, "err", err)
+		}
+	}()
+}
+
+// SendReminders messages the subscribers taking part in the bookings of the
+// day with reminders enabled.
+func (s *Service) SendReminders(ctx context.Context) error {
+	bookings, err := s.bookings.GetActiveBookings(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to get active bookings: %w", err)
+	}
+
+	today := time.Now().In(s.location).Format(time.DateOnly)
+	failed := 0
+
+	for _, booking := range bookings {
+		dateTime := booking.DateTime.In(s.location)
+
+		if !booking.ReminderEnabled || dateTime.Format(time.DateOnly) != today {
+			continue
+		}
+
+		text := fmt.Sprintf("Rappel pour la réservation de <b>%s</b> aujourd'hui à %s !", html.EscapeString(booking.Game), dateTime.Format("15:04"))
+
+		if err := s.send(ctx, booking, text); err != nil {
+			s.logger.Error("failed to send Telegram reminders", "bookingId", booking.ID, "err", err)
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("failed to send the Telegram reminders of %d bookings", failed)
+	}
+
+	return nil
+}
+
+// Wait blocks until all the messages being sent are.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown waits for the messages being sent until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for Telegram messages: %w", ctx.Err())
+	}
+}
+
+func (s *Service) format(booking bk.Booking, title string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<b>%s</b>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "%s, %s\n", html.EscapeString(booking.Game), booking.DateTime.In(s.location).Format("02/01/2006 à 15:04"))
+
+	if len(booking.Players) != 0 {
+		fmt.Fprintf(&b, "Joueurs : %s\n", html.EscapeString(strings.Join(booking.Players, ", ")))
+	}
+
+	fmt.Fprintf(&b, `<a href="%s">Voir la réservation</a>`, html.EscapeString(s.bookingURL+"/"+booking.ID))
+
+	return b.String()
+}
+
+// send messages the organizer and the players of the booking who opted in.
+func (s *Service) send(ctx context.Context, booking bk.Booking, text string) error {
+	subscribers, err := s.subscribers.GetSubscribers(ctx, preference.Participants(booking))
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, subscriber := range subscribers {
+		if !subscriber.TelegramSubscribed() {
+			continue
+		}
+
+		if err := s.sender.SendMessage(ctx, subscriber.TelegramChatID, text); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+package telegram_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/preference"
+	"github.com/hanksha/tbz-booking-system-backend/telegram"
+	telegram_mocks "github.com/hanksha/tbz-booking-system-backend/telegram/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type sentMessage struct {
+	chatID string
+	text   string
+}
+
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []sentMessage
+	err      error
+}
+
+func (s *recordingSender) SendMessage(ctx context.Context, chatID, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, sentMessage{chatID: chatID, text: text})
+	return s.err
+}
+
+type bookingSource []bk.Booking
+
+func (s bookingSource) GetActiveBookings(ctx context.Context) ([]bk.Booking, error) {
+	return s, nil
+}
+
+func TestPublish(t *testing.T) {
+	booking := bk.Booking{
+		ID:       "42",
+		Username: "alice",
+		Game:     "Kill Team",
+		Players:  []string{"bob"},
+		DateTime: time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("messages the subscribers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := telegram_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{}
+		svc := telegram.NewService(repo, sender, bookingSource{}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), []string{"alice", "bob"}).Return([]preference.Preferences{
+			{Username: "alice", TelegramChatID: "1001", TelegramNotifications: true},
+			// opted in the emails only
+			{Username: "bob", Email: "bob@example.com", EmailNotifications: true},
+		}, nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted, Booking: booking})
+		svc.Wait()
+
+		require.Equal(t, []sentMessage{{
+			chatID: "1001",
+			text:   "<b>Réservation Acceptée ✅</b>\nKill Team, 06/03/2026 à 19:00\nJoueurs : bob\n<a href=\"https://tbz.example/bookings/42\">Voir la réservation</a>",
+		}}, sender.messages)
+	})
+
+	t.Run("ignores the modifications", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := telegram_mocks.NewMockSubscriberRepository(ctrl)
+		svc := telegram.NewService(repo, &recordingSender{}, bookingSource{}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingModified, Booking: booking})
+		svc.Wait()
+	})
+}
+
+func TestSendReminders(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 20, 0, 0, 0, time.UTC)
+
+	t.Run("messages the subscribers of the bookings of the day", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := telegram_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{}
+		svc := telegram.NewService(repo, sender, bookingSource{
+			{ID: "1", Username: "alice", Game: "Kill Team", DateTime: today, ReminderEnabled: true},
+			{ID: "2", Username: "alice", Game: "Blood Bowl", DateTime: today.AddDate(0, 0, 1), ReminderEnabled: true},
+		}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), []string{"alice"}).Return([]preference.Preferences{
+			{Username: "alice", TelegramChatID: "1001", TelegramNotifications: true},
+		}, nil).Times(1)
+
+		require.Nil(t, svc.SendReminders(context.Background()))
+
+		require.Equal(t, []sentMessage{{chatID: "1001", text: "Rappel pour la réservation de <b>Kill Team</b> aujourd'hui à 20:00 !"}}, sender.messages)
+	})
+
+	t.Run("sending failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := telegram_mocks.NewMockSubscriberRepository(ctrl)
+		sender := &recordingSender{err: errors.New("bot was blocked by the user")}
+		svc := telegram.NewService(repo, sender, bookingSource{
+			{ID: "1", Username: "alice", Game: "Kill Team", DateTime: today, ReminderEnabled: true},
+		}, "https://tbz.example/bookings", time.UTC)
+
+		repo.EXPECT().GetSubscribers(gomock.Any(), gomock.Any()).Return([]preference.Preferences{
+			{Username: "alice", TelegramChatID: "1001", TelegramNotifications: true},
+		}, nil).Times(1)
+
+		require.Error(t, svc.SendReminders(context.Background()))
+	})
+}
@@ -0,0 +1,46 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/telegram"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/bot123:abc/sendMessage", r.URL.Path)
+
+		var body map[string]any
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "42", body["chat_id"])
+		require.Equal(t, "<b>hello</b>", body["text"])
+		require.Equal(t, "HTML", body["parse_mode"])
+
+		fmt.Fprint(w, `{"ok":true,"result":{}}`)
+	}))
+	defer server.Close()
+
+	client := telegram.NewBotClient(telegram.BotConfig{APIURL: server.URL + "/", Token: "123:abc"}, server.Client())
+
+	require.Nil(t, client.SendMessage(context.Background(), "42", "<b>hello</b>"))
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`)
+		}))
+		defer server.Close()
+
+		client := telegram.NewBotClient(telegram.BotConfig{APIURL: server.URL, Token: "123:abc"}, server.Client())
+
+		err := client.SendMessage(context.Background(), "42", "hello")
+
+		require.ErrorContains(t, err, "bot was blocked by the user")
+	})
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/trust (interfaces: TrustRepository)
+//
+// Generated by this command:
+//
+//	mockgen . TrustRepository
+//
+
+// Package mock_trust is a generated GoMock package.
+package mock_trust
+
+import (
+	context "context"
+	reflect "reflect"
+
+	trust "github.com/hanksha/tbz-booking-system-backend/trust"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrustRepository is a mock of TrustRepository interface.
+type MockTrustRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrustRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrustRepositoryMockRecorder is the mock recorder for MockTrustRepository.
+type MockTrustRepositoryMockRecorder struct {
+	mock *MockTrustRepository
+}
+
+// NewMockTrustRepository creates a new mock instance.
+func NewMockTrustRepository(ctrl *gomock.Controller) *MockTrustRepository {
+	mock := &MockTrustRepository{ctrl: ctrl}
+	mock.recorder = &MockTrustRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrustRepository) EXPECT() *MockTrustRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTrustedUser mocks base method.
+func (m *MockTrustRepository) DeleteTrustedUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrustedUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrustedUser indicates an expected call of DeleteTrustedUser.
+func (mr *MockTrustRepositoryMockRecorder) DeleteTrustedUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrustedUser", reflect.TypeOf((*MockTrustRepository)(nil).DeleteTrustedUser), ctx, userID)
+}
+
+// GetTrustedUser mocks base method.
+func (m *MockTrustRepository) GetTrustedUser(ctx context.Context, userID string) (trust.TrustedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustedUser", ctx, userID)
+	ret0, _ := ret[0].(trust.TrustedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustedUser indicates an expected call of GetTrustedUser.
+func (mr *MockTrustRepositoryMockRecorder) GetTrustedUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustedUser", reflect.TypeOf((*MockTrustRepository)(nil).GetTrustedUser), ctx, userID)
+}
+
+// GetTrustedUsers mocks base method.
+func (m *MockTrustRepository) GetTrustedUsers(ctx context.Context) ([]trust.TrustedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustedUsers", ctx)
+	ret0, _ := ret[0].([]trust.TrustedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustedUsers indicates an expected call of GetTrustedUsers.
+func (mr *MockTrustRepositoryMockRecorder) GetTrustedUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustedUsers", reflect.TypeOf((*MockTrustRepository)(nil).GetTrustedUsers), ctx)
+}
+
+// UpsertTrustedUser mocks base method.
+func (m *MockTrustRepository) UpsertTrustedUser(ctx context.Context, user trust.TrustedUser) (trust.TrustedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTrustedUser", ctx, user)
+	ret0, _ := ret[0].(trust.TrustedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTrustedUser indicates an expected call of UpsertTrustedUser.
+func (mr *MockTrustRepositoryMockRecorder) UpsertTrustedUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTrustedUser", reflect.TypeOf((*MockTrustRepository)(nil).UpsertTrustedUser), ctx, user)
+}
@@ -0,0 +1,7 @@
+package trust
+
+import "errors"
+
+var ErrTrustedUserNotFound = errors.New("trusted user not found")
+
+var ErrInvalidTrustedUser = errors.New("invalid trusted user")
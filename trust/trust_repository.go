@@ -0,0 +1,126 @@
+package trust
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+func (r *Repository) GetTrustedUsers(ctx context.Context) ([]TrustedUser, error) {
+	sql := `
+		SELECT "userId", COALESCE(username, ''), COALESCE(note, ''), COALESCE("createdBy", ''), "createdAt"
+		FROM "game-table-booking".trusted_user
+		ORDER BY "createdAt" DESC;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trusted users: %w", err)
+	}
+
+	defer rows.Close()
+
+	users := []TrustedUser{}
+
+	for rows.Next() {
+		var user TrustedUser
+		err := rows.Scan(
+			&user.UserID,
+			&user.Username,
+			&user.Note,
+			&user.CreatedBy,
+			&user.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning trusted user row: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trusted user rows: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) GetTrustedUser(ctx context.Context, userID string) (TrustedUser, error) {
+	sql := `
+		SELECT "userId", COALESCE(username, ''), COALESCE(note, ''), COALESCE("createdBy", ''), "createdAt"
+		FROM "game-table-booking".trusted_user
+		WHERE "userId"=$1;
+	`
+
+	var user TrustedUser
+	err := r.conn.QueryRow(ctx, sql, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.Note,
+		&user.CreatedBy,
+		&user.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return TrustedUser{}, ErrTrustedUserNotFound
+	}
+
+	if err != nil {
+		return TrustedUser{}, fmt.Errorf("failed to fetch trusted user %v: %w", userID, err)
+	}
+
+	return user, nil
+}
+
+func (r *Repository) UpsertTrustedUser(ctx context.Context, user TrustedUser) (TrustedUser, error) {
+	sql := `
+		INSERT INTO "game-table-booking".trusted_user("userId", username, note, "createdBy")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("userId") DO UPDATE SET
+			username=EXCLUDED.username,
+			note=EXCLUDED.note,
+			"createdBy"=EXCLUDED."createdBy",
+			"createdAt"=now()
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		user.UserID,
+		user.Username,
+		user.Note,
+		user.CreatedBy,
+	).Scan(&user.CreatedAt)
+
+	if err != nil {
+		return TrustedUser{}, fmt.Errorf("failed to save trusted user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *Repository) DeleteTrustedUser(ctx context.Context, userID string) error {
+	sql := `DELETE FROM "game-table-booking".trusted_user WHERE "userId"=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, userID)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete trusted user '%v': %w", userID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrTrustedUserNotFound
+	}
+
+	return nil
+}
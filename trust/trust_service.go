@@ -0,0 +1,93 @@
+package trust
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+type TrustRepository interface {
+	GetTrustedUsers(ctx context.Context) ([]TrustedUser, error)
+	GetTrustedUser(ctx context.Context, userID string) (TrustedUser, error)
+	UpsertTrustedUser(ctx context.Context, user TrustedUser) (TrustedUser, error)
+	DeleteTrustedUser(ctx context.Context, userID string) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  TrustRepository
+	audit AuditRecorder
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo TrustRepository, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetTrustedUsers(ctx context.Context) ([]TrustedUser, error) {
+	return s.repo.GetTrustedUsers(ctx)
+}
+
+func (s *Service) TrustUser(ctx context.Context, user TrustedUser) (TrustedUser, error) {
+	user.UserID = strings.TrimSpace(user.UserID)
+
+	if len(user.UserID) == 0 {
+		return TrustedUser{}, fmt.Errorf("%w: userId cannot be empty", ErrInvalidTrustedUser)
+	}
+
+	if admin, ok := discord.UserFromContext(ctx); ok {
+		user.CreatedBy = admin.Username
+	}
+
+	saved, err := s.repo.UpsertTrustedUser(ctx, user)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "trust.create", "user", saved.UserID, map[string]any{"note": saved.Note})
+	}
+
+	return saved, err
+}
+
+func (s *Service) RevokeTrust(ctx context.Context, userID string) error {
+	err := s.repo.DeleteTrustedUser(ctx, userID)
+
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, "trust.delete", "user", userID, nil)
+	}
+
+	return err
+}
+
+// IsTrusted implements booking.TrustChecker.
+func (s *Service) IsTrusted(ctx context.Context, userID string) (bool, error) {
+	_, err := s.repo.GetTrustedUser(ctx, userID)
+
+	if errors.Is(err, ErrTrustedUserNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
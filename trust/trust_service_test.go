@@ -0,0 +1,89 @@
+package trust_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/trust"
+	trust_mocks "github.com/hanksha/tbz-booking-system-backend/trust/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIsTrusted(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		trusted bool
+	}{
+		{name: "trusted", trusted: true},
+		{name: "not trusted", err: trust.ErrTrustedUserNotFound, trusted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := trust_mocks.NewMockTrustRepository(ctrl)
+			svc := trust.NewService(repo)
+
+			repo.EXPECT().GetTrustedUser(gomock.Any(), "1").Return(trust.TrustedUser{UserID: "1"}, tt.err).Times(1)
+
+			trusted, err := svc.IsTrusted(context.Background(), "1")
+
+			require.Nil(t, err)
+			require.Equal(t, tt.trusted, trusted)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := trust_mocks.NewMockTrustRepository(ctrl)
+		svc := trust.NewService(repo)
+		failure := errors.New("connection refused")
+
+		repo.EXPECT().GetTrustedUser(gomock.Any(), "1").Return(trust.TrustedUser{}, failure).Times(1)
+
+		_, err := svc.IsTrusted(context.Background(), "1")
+
+		require.ErrorIs(t, err, failure)
+	})
+}
+
+func TestTrustUser(t *testing.T) {
+	t.Run("records the admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := trust_mocks.NewMockTrustRepository(ctrl)
+		svc := trust.NewService(repo)
+		ctx := discord.ContextWithUser(context.Background(), discord.DiscordUser{ID: "9", Username: "admin", Admin: true})
+		expected := trust.TrustedUser{UserID: "1", Username: "john", CreatedBy: "admin"}
+
+		repo.EXPECT().UpsertTrustedUser(gomock.Any(), expected).Return(expected, nil).Times(1)
+
+		saved, err := svc.TrustUser(ctx, trust.TrustedUser{UserID: " 1 ", Username: "john"})
+
+		require.Nil(t, err)
+		require.Equal(t, expected, saved)
+	})
+
+	t.Run("empty user id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := trust_mocks.NewMockTrustRepository(ctrl)
+		svc := trust.NewService(repo)
+
+		repo.EXPECT().UpsertTrustedUser(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.TrustUser(context.Background(), trust.TrustedUser{UserID: " "})
+
+		require.ErrorIs(t, err, trust.ErrInvalidTrustedUser)
+	})
+}
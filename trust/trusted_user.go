@@ -0,0 +1,13 @@
+package trust
+
+import "time"
+
+// TrustedUser is a member the admins trusted, whose bookings are accepted
+// without review.
+type TrustedUser struct {
+	UserID    string    `json:"userId" binding:"required"`
+	Username  string    `json:"username"`
+	Note      string    `json:"note"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/venue (interfaces: VenueRepository)
+//
+// Generated by this command:
+//
+//	mockgen . VenueRepository
+//
+
+// Package mock_venue is a generated GoMock package.
+package mock_venue
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	venue "github.com/hanksha/tbz-booking-system-backend/venue"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVenueRepository is a mock of VenueRepository interface.
+type MockVenueRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueRepositoryMockRecorder is the mock recorder for MockVenueRepository.
+type MockVenueRepositoryMockRecorder struct {
+	mock *MockVenueRepository
+}
+
+// NewMockVenueRepository creates a new mock instance.
+func NewMockVenueRepository(ctrl *gomock.Controller) *MockVenueRepository {
+	mock := &MockVenueRepository{ctrl: ctrl}
+	mock.recorder = &MockVenueRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueRepository) EXPECT() *MockVenueRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountTablesTaken mocks base method.
+func (m *MockVenueRepository) CountTablesTaken(ctx context.Context, id string, at time.Time, bookingID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTablesTaken", ctx, id, at, bookingID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTablesTaken indicates an expected call of CountTablesTaken.
+func (mr *MockVenueRepositoryMockRecorder) CountTablesTaken(ctx, id, at, bookingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTablesTaken", reflect.TypeOf((*MockVenueRepository)(nil).CountTablesTaken), ctx, id, at, bookingID)
+}
+
+// DeleteVenue mocks base method.
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVenue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVenue indicates an expected call of DeleteVenue.
+func (mr *MockVenueRepositoryMockRecorder) DeleteVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVenue", reflect.TypeOf((*MockVenueRepository)(nil).DeleteVenue), ctx, id)
+}
+
+// GetVenue mocks base method.
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenue", ctx, id)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenue indicates an expected call of GetVenue.
+func (mr *MockVenueRepositoryMockRecorder) GetVenue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenue", reflect.TypeOf((*MockVenueRepository)(nil).GetVenue), ctx, id)
+}
+
+// GetVenues mocks base method.
+func (m *MockVenueRepository) GetVenues(ctx context.Context) ([]venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenues", ctx)
+	ret0, _ := ret[0].([]venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenues indicates an expected call of GetVenues.
+func (mr *MockVenueRepositoryMockRecorder) GetVenues(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenues", reflect.TypeOf((*MockVenueRepository)(nil).GetVenues), ctx)
+}
+
+// InsertVenue mocks base method.
+func (m *MockVenueRepository) InsertVenue(ctx context.Context, arg1 venue.Venue) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVenue", ctx, arg1)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertVenue indicates an expected call of InsertVenue.
+func (mr *MockVenueRepositoryMockRecorder) InsertVenue(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVenue", reflect.TypeOf((*MockVenueRepository)(nil).InsertVenue), ctx, arg1)
+}
+
+// UpdateVenue mocks base method.
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, arg1 venue.Venue) (venue.Venue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVenue", ctx, arg1)
+	ret0, _ := ret[0].(venue.Venue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVenue indicates an expected call of UpdateVenue.
+func (mr *MockVenueRepositoryMockRecorder) UpdateVenue(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVenue", reflect.TypeOf((*MockVenueRepository)(nil).UpdateVenue), ctx, arg1)
+}
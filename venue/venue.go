@@ -0,0 +1,75 @@
+package venue
+
+import "time"
+
+// Venue is a room of the club, its tables booked apart from the other rooms.
+type Venue struct {
+	ID     string `json:"id"`
+	Name   string `json:"name" binding:"required,max=100"`
+	Tables int    `json:"tables" binding:"gte=1"`
+	// OpeningHours are the weekly openings of the room, open at any time
+	// when empty.
+	OpeningHours []OpeningHours `json:"openingHours" binding:"omitempty,dive"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// OpeningHours opens the room on a day of the week, 0 being sunday, between
+// two times of day such as 14:00. A room closing before it opens closes the
+// next day.
+type OpeningHours struct {
+	Weekday int    `json:"weekday" binding:"gte=0,lte=6"`
+	Opens   string `json:"opens" binding:"required"`
+	Closes  string `json:"closes" binding:"required"`
+}
+
+// Open reports whether a game lasting d fits in the opening hours when
+// starting at the given time, evaluated in loc.
+func (v Venue) Open(at time.Time, d time.Duration, loc *time.Location) bool {
+	if len(v.OpeningHours) == 0 {
+		return true
+	}
+
+	at = at.In(loc)
+
+	// the day before, for the openings closing after midnight
+	for _, offset := range []int{-1, 0} {
+		day := time.Date(at.Year(), at.Month(), at.Day()+offset, 0, 0, 0, 0, loc)
+
+		for _, hours := range v.OpeningHours {
+			if hours.Weekday != int(day.Weekday()) {
+				continue
+			}
+
+			opens, closes, err := hours.window(day)
+
+			if err == nil && !at.Before(opens) && !at.Add(d).After(closes) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (h OpeningHours) window(day time.Time) (opens, closes time.Time, err error) {
+	from, err := time.Parse("15:04", h.Opens)
+
+	if err != nil {
+		return
+	}
+
+	to, err := time.Parse("15:04", h.Closes)
+
+	if err != nil {
+		return
+	}
+
+	opens = time.Date(day.Year(), day.Month(), day.Day(), from.Hour(), from.Minute(), 0, 0, day.Location())
+	closes = time.Date(day.Year(), day.Month(), day.Day(), to.Hour(), to.Minute(), 0, 0, day.Location())
+
+	if !closes.After(opens) {
+		closes = closes.AddDate(0, 0, 1)
+	}
+
+	return opens, closes, nil
+}
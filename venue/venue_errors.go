@@ -0,0 +1,9 @@
+package venue
+
+import "errors"
+
+var ErrVenueNotFound = errors.New("venue not found")
+
+var ErrInvalidVenue = errors.New("invalid venue")
+
+var ErrDuplicateVenue = errors.New("venue already exists")
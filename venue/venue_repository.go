@@ -0,0 +1,161 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+const venueColumns = `id::text, name, tables, "openingHours", "createdAt"`
+
+func (r *Repository) GetVenues(ctx context.Context) ([]Venue, error) {
+	sql := `
+		SELECT ` + venueColumns + `
+		FROM "game-table-booking".venue
+		ORDER BY name;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch venues: %w", err)
+	}
+
+	venues, err := pgx.CollectRows(rows, pgx.RowToStructByPos[Venue])
+
+	if err != nil {
+		return nil, fmt.Errorf("error scanning venue rows: %w", err)
+	}
+
+	return venues, nil
+}
+
+func (r *Repository) GetVenue(ctx context.Context, id string) (Venue, error) {
+	sql := `
+		SELECT ` + venueColumns + `
+		FROM "game-table-booking".venue
+		WHERE id::text=$1;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, id)
+
+	if err != nil {
+		return Venue{}, fmt.Errorf("failed to fetch venue '%v': %w", id, err)
+	}
+
+	venue, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[Venue])
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Venue{}, ErrVenueNotFound
+	}
+
+	if err != nil {
+		return Venue{}, fmt.Errorf("error scanning venue '%v': %w", id, err)
+	}
+
+	return venue, nil
+}
+
+func (r *Repository) InsertVenue(ctx context.Context, venue Venue) (Venue, error) {
+	sql := `
+		INSERT INTO "game-table-booking".venue(name, tables, "openingHours")
+		VALUES ($1, $2, $3)
+		RETURNING id::text, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, venue.Name, venue.Tables, venue.OpeningHours).Scan(&venue.ID, &venue.CreatedAt)
+
+	if isUniqueViolation(err) {
+		return Venue{}, fmt.Errorf("%w: '%v'", ErrDuplicateVenue, venue.Name)
+	}
+
+	if err != nil {
+		return Venue{}, fmt.Errorf("failed to insert venue: %w", err)
+	}
+
+	return venue, nil
+}
+
+func (r *Repository) UpdateVenue(ctx context.Context, venue Venue) (Venue, error) {
+	sql := `
+		UPDATE "game-table-booking".venue
+		SET name=$1, tables=$2, "openingHours"=$3
+		WHERE id::text=$4
+		RETURNING "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql, venue.Name, venue.Tables, venue.OpeningHours, venue.ID).Scan(&venue.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Venue{}, ErrVenueNotFound
+	}
+
+	if isUniqueViolation(err) {
+		return Venue{}, fmt.Errorf("%w: '%v'", ErrDuplicateVenue, venue.Name)
+	}
+
+	if err != nil {
+		return Venue{}, fmt.Errorf("failed to update venue '%v': %w", venue.ID, err)
+	}
+
+	return venue, nil
+}
+
+// DeleteVenue removes the venue, its bookings are left without one.
+func (r *Repository) DeleteVenue(ctx context.Context, id string) error {
+	sql := `DELETE FROM "game-table-booking".venue WHERE id::text=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete venue '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrVenueNotFound
+	}
+
+	return nil
+}
+
+// CountTablesTaken counts the pending and accepted bookings of the venue
+// holding a table at the given time, the booking with bookingID excepted.
+func (r *Repository) CountTablesTaken(ctx context.Context, id string, at time.Time, bookingID string) (int, error) {
+	sql := `
+		SELECT COUNT(*)
+		FROM "game-table-booking".booking
+		WHERE "venueId"::text = $1
+			AND status IN ('pending', 'accepted')
+			AND "deletedAt" IS NULL
+			AND "dateTime" > $2 AND "dateTime" < $3
+			AND id::text <> $4;
+	`
+
+	var count int
+
+	err := r.conn.QueryRow(ctx, sql, id, at.Add(-bk.TableDuration), at.Add(bk.TableDuration), bookingID).Scan(&count)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the tables taken in venue '%v': %w", id, err)
+	}
+
+	return count, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
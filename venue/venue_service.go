@@ -0,0 +1,157 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+type VenueRepository interface {
+	GetVenues(ctx context.Context) ([]Venue, error)
+	GetVenue(ctx context.Context, id string) (Venue, error)
+	InsertVenue(ctx context.Context, venue Venue) (Venue, error)
+	UpdateVenue(ctx context.Context, venue Venue) (Venue, error)
+	DeleteVenue(ctx context.Context, id string) error
+	CountTablesTaken(ctx context.Context, id string, at time.Time, bookingID string) (int, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo  VenueRepository
+	audit AuditRecorder
+	// location is the time zone of the opening hours
+	location *time.Location
+}
+
+type ServiceOption func(*Service)
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo VenueRepository, loc *time.Location, opts ...ServiceOption) *Service {
+	s := &Service{repo: repo, location: loc}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetVenues(ctx context.Context) ([]Venue, error) {
+	return s.repo.GetVenues(ctx)
+}
+
+func (s *Service) GetVenue(ctx context.Context, id string) (Venue, error) {
+	return s.repo.GetVenue(ctx, id)
+}
+
+func (s *Service) CreateVenue(ctx context.Context, venue Venue) (Venue, error) {
+	if err := normalize(&venue); err != nil {
+		return Venue{}, err
+	}
+
+	inserted, err := s.repo.InsertVenue(ctx, venue)
+
+	s.record(ctx, err, "venue.create", inserted.ID, inserted)
+
+	return inserted, err
+}
+
+// UpdateVenue changes the venue, the bookings already made outside of its
+// new opening hours or beyond its new table count are kept.
+func (s *Service) UpdateVenue(ctx context.Context, venue Venue) (Venue, error) {
+	if err := normalize(&venue); err != nil {
+		return Venue{}, err
+	}
+
+	updated, err := s.repo.UpdateVenue(ctx, venue)
+
+	s.record(ctx, err, "venue.update", venue.ID, updated)
+
+	return updated, err
+}
+
+func (s *Service) DeleteVenue(ctx context.Context, id string) error {
+	err := s.repo.DeleteVenue(ctx, id)
+
+	s.record(ctx, err, "venue.delete", id, nil)
+
+	return err
+}
+
+// CheckVenue tells whether a booking may hold a table of the venue at the
+// given time: the venue must be open for the whole game and have a table
+// left. It is the booking.VenueChecker of the booking service, an unknown
+// venue fails with booking.ErrInvalidVenue.
+func (s *Service) CheckVenue(ctx context.Context, id string, at time.Time, bookingID string) (bool, string, error) {
+	venue, err := s.repo.GetVenue(ctx, id)
+
+	if errors.Is(err, ErrVenueNotFound) {
+		return false, "", fmt.Errorf("%w: no venue with id '%v'", bk.ErrInvalidVenue, id)
+	}
+
+	if err != nil {
+		return false, "", err
+	}
+
+	if !venue.Open(at, bk.TableDuration, s.location) {
+		return false, fmt.Sprintf("%v is closed at that time", venue.Name), nil
+	}
+
+	taken, err := s.repo.CountTablesTaken(ctx, id, at, bookingID)
+
+	if err != nil {
+		return false, "", err
+	}
+
+	if taken >= venue.Tables {
+		return false, fmt.Sprintf("no table left in %v at that time", venue.Name), nil
+	}
+
+	return true, "", nil
+}
+
+func normalize(venue *Venue) error {
+	venue.Name = strings.TrimSpace(venue.Name)
+
+	if len(venue.Name) == 0 {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidVenue)
+	}
+
+	if venue.Tables < 1 {
+		return fmt.Errorf("%w: at least one table is required", ErrInvalidVenue)
+	}
+
+	if venue.OpeningHours == nil {
+		venue.OpeningHours = []OpeningHours{}
+	}
+
+	for _, hours := range venue.OpeningHours {
+		if hours.Weekday < 0 || hours.Weekday > 6 {
+			return fmt.Errorf("%w: weekday %d is not between 0 (sunday) and 6", ErrInvalidVenue, hours.Weekday)
+		}
+
+		if _, _, err := hours.window(time.Now()); err != nil {
+			return fmt.Errorf("%w: '%v' to '%v' are not times of day such as 20:00", ErrInvalidVenue, hours.Opens, hours.Closes)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, err error, action, venueID string, payload any) {
+	if err == nil && s.audit != nil {
+		s.audit.Record(ctx, action, "venue", venueID, payload)
+	}
+}
@@ -0,0 +1,101 @@
+package venue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/venue"
+	venue_mocks "github.com/hanksha/tbz-booking-system-backend/venue/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckVenue(t *testing.T) {
+	cave := venue.Venue{ID: "2", Name: "Cave", Tables: 2, OpeningHours: []venue.OpeningHours{
+		{Weekday: int(time.Friday), Opens: "18:00", Closes: "23:00"},
+	}}
+	friday := time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC)
+
+	t.Run("table left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := venue_mocks.NewMockVenueRepository(ctrl)
+		svc := venue.NewService(repo, time.UTC)
+
+		repo.EXPECT().GetVenue(gomock.Any(), "2").Return(cave, nil).Times(1)
+		repo.EXPECT().CountTablesTaken(gomock.Any(), "2", friday, "5").Return(1, nil).Times(1)
+
+		valid, _, err := svc.CheckVenue(context.Background(), "2", friday, "5")
+
+		require.Nil(t, err)
+		require.True(t, valid)
+	})
+
+	t.Run("no table left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := venue_mocks.NewMockVenueRepository(ctrl)
+		svc := venue.NewService(repo, time.UTC)
+
+		repo.EXPECT().GetVenue(gomock.Any(), "2").Return(cave, nil).Times(1)
+		repo.EXPECT().CountTablesTaken(gomock.Any(), "2", friday, "").Return(2, nil).Times(1)
+
+		valid, reason, err := svc.CheckVenue(context.Background(), "2", friday, "")
+
+		require.Nil(t, err)
+		require.False(t, valid)
+		require.Equal(t, "no table left in Cave at that time", reason)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := venue_mocks.NewMockVenueRepository(ctrl)
+		svc := venue.NewService(repo, time.UTC)
+
+		repo.EXPECT().GetVenue(gomock.Any(), "2").Return(cave, nil).Times(1)
+		repo.EXPECT().CountTablesTaken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		valid, reason, err := svc.CheckVenue(context.Background(), "2", friday.Add(2*time.Hour), "")
+
+		require.Nil(t, err)
+		require.False(t, valid)
+		require.Equal(t, "Cave is closed at that time", reason)
+	})
+
+	t.Run("unknown venue", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := venue_mocks.NewMockVenueRepository(ctrl)
+		svc := venue.NewService(repo, time.UTC)
+
+		repo.EXPECT().GetVenue(gomock.Any(), "9").Return(venue.Venue{}, venue.ErrVenueNotFound).Times(1)
+
+		valid, _, err := svc.CheckVenue(context.Background(), "9", friday, "")
+
+		require.ErrorIs(t, err, bk.ErrInvalidVenue)
+		require.False(t, valid)
+	})
+}
+
+func TestCreateVenueRejectsBadOpeningHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := venue_mocks.NewMockVenueRepository(ctrl)
+	svc := venue.NewService(repo, time.UTC)
+
+	repo.EXPECT().InsertVenue(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := svc.CreateVenue(context.Background(), venue.Venue{Name: "Cave", Tables: 2, OpeningHours: []venue.OpeningHours{
+		{Weekday: 5, Opens: "6pm", Closes: "23:00"},
+	}})
+
+	require.ErrorIs(t, err, venue.ErrInvalidVenue)
+}
@@ -0,0 +1,41 @@
+package venue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hanksha/tbz-booking-system-backend/venue"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.Nil(t, err)
+
+	v := venue.Venue{Name: "Cave", Tables: 2, OpeningHours: []venue.OpeningHours{
+		{Weekday: int(time.Friday), Opens: "18:00", Closes: "01:00"},
+		{Weekday: int(time.Saturday), Opens: "14:00", Closes: "20:00"},
+	}}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		open bool
+	}{
+		{"friday evening", time.Date(2026, 3, 6, 20, 0, 0, 0, loc), true},
+		{"friday ending after midnight", time.Date(2026, 3, 6, 22, 0, 0, 0, loc), true},
+		{"friday ending after closing", time.Date(2026, 3, 6, 23, 0, 0, 0, loc), false},
+		{"saturday afternoon", time.Date(2026, 3, 7, 14, 0, 0, 0, loc), true},
+		{"saturday before opening", time.Date(2026, 3, 7, 12, 0, 0, 0, loc), false},
+		{"sunday", time.Date(2026, 3, 8, 15, 0, 0, 0, loc), false},
+		{"in UTC", time.Date(2026, 3, 6, 19, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.open, v.Open(tt.at, 3*time.Hour, loc))
+		})
+	}
+
+	require.True(t, venue.Venue{}.Open(time.Now(), 3*time.Hour, loc))
+}
@@ -0,0 +1,57 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit and BuildTime are set at build time with
+//
+//	go build -ldflags "-X github.com/hanksha/tbz-booking-system-backend/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/hanksha/tbz-booking-system-backend/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When left empty, the VCS information embedded by the go command is used.
+var (
+	Commit    string
+	BuildTime string
+)
+
+type Info struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build information of the running binary, "unknown" for
+// what is known neither from ldflags nor from the embedded build info.
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if len(info.Commit) == 0 {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if len(info.BuildTime) == 0 {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if len(info.Commit) == 0 {
+		info.Commit = "unknown"
+	}
+
+	if len(info.BuildTime) == 0 {
+		info.BuildTime = "unknown"
+	}
+
+	return info
+}
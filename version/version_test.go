@@ -0,0 +1,29 @@
+package version_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/hanksha/tbz-booking-system-backend/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("ldflags take precedence", func(t *testing.T) {
+		version.Commit, version.BuildTime = "abc123", "2026-01-01T00:00:00Z"
+		defer func() { version.Commit, version.BuildTime = "", "" }()
+
+		info := version.Get()
+
+		require.Equal(t, "abc123", info.Commit)
+		require.Equal(t, "2026-01-01T00:00:00Z", info.BuildTime)
+		require.Equal(t, runtime.Version(), info.GoVersion)
+	})
+
+	t.Run("never empty", func(t *testing.T) {
+		info := version.Get()
+
+		require.NotEmpty(t, info.Commit)
+		require.NotEmpty(t, info.BuildTime)
+	})
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/voice (interfaces: ChannelClient)
+//
+// Generated by this command:
+//
+//	mockgen . ChannelClient
+//
+
+// Package mock_voice is a generated GoMock package.
+package mock_voice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	discord "github.com/hanksha/tbz-booking-system-backend/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChannelClient is a mock of ChannelClient interface.
+type MockChannelClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelClientMockRecorder
+	isgomock struct{}
+}
+
+// MockChannelClientMockRecorder is the mock recorder for MockChannelClient.
+type MockChannelClientMockRecorder struct {
+	mock *MockChannelClient
+}
+
+// NewMockChannelClient creates a new mock instance.
+func NewMockChannelClient(ctrl *gomock.Controller) *MockChannelClient {
+	mock := &MockChannelClient{ctrl: ctrl}
+	mock.recorder = &MockChannelClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelClient) EXPECT() *MockChannelClientMockRecorder {
+	return m.recorder
+}
+
+// CreateChannel mocks base method.
+func (m *MockChannelClient) CreateChannel(ctx context.Context, channel discord.Channel) (discord.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", ctx, channel)
+	ret0, _ := ret[0].(discord.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockChannelClientMockRecorder) CreateChannel(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChannelClient)(nil).CreateChannel), ctx, channel)
+}
+
+// DeleteChannel mocks base method.
+func (m *MockChannelClient) DeleteChannel(ctx context.Context, channelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannel", ctx, channelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannel indicates an expected call of DeleteChannel.
+func (mr *MockChannelClientMockRecorder) DeleteChannel(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockChannelClient)(nil).DeleteChannel), ctx, channelID)
+}
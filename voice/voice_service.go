@@ -0,0 +1,195 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+)
+
+// maxNameLength is the longest channel name Discord accepts.
+const maxNameLength = 100
+
+type ChannelClient interface {
+	CreateChannel(ctx context.Context, channel discord.Channel) (discord.Channel, error)
+	DeleteChannel(ctx context.Context, channelID string) error
+}
+
+type BookingSource interface {
+	GetBookingByID(ctx context.Context, id string) (bk.Booking, error)
+	GetVoiceChannelBookings(ctx context.Context) ([]bk.Booking, error)
+}
+
+// ChannelHandler records the voice channel of a booking, empty once deleted.
+type ChannelHandler func(ctx context.Context, bookingID, channelID string)
+
+// Service keeps a temporary Discord voice channel for each remote booking,
+// created once the booking is accepted and deleted when it is over or no
+// longer accepted.
+type Service struct {
+	client   ChannelClient
+	bookings BookingSource
+	// categoryID is the category of the server the channels are created in
+	categoryID string
+	onChange   ChannelHandler
+	logger     *slog.Logger
+	wg         sync.WaitGroup
+	// mu serializes the syncs, so a booking never gets two channels
+	mu sync.Mutex
+}
+
+type ServiceOption func(*Service)
+
+// WithChannelHandler sets what records the voice channels, the bookings
+// showing them.
+func WithChannelHandler(handler ChannelHandler) ServiceOption {
+	return func(s *Service) {
+		s.onChange = handler
+	}
+}
+
+func NewService(client ChannelClient, bookings BookingSource, categoryID string, opts ...ServiceOption) *Service {
+	s := &Service{
+		client:     client,
+		bookings:   bookings,
+		categoryID: categoryID,
+		onChange:   func(ctx context.Context, bookingID, channelID string) {},
+		logger:     slog.Default().With("component", "voice"),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Publish implements booking.EventPublisher. Syncs happen in the background
+// so booking operations never wait on Discord.
+func (s *Service) Publish(ctx context.Context, event bk.Event) {
+	// pending bookings have no channel yet
+	if event.Type == bk.EventBookingCreated && event.Booking.Status != "accepted" {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.Sync(ctx, event.Booking.ID)
+	}()
+}
+
+// Sync creates the voice channel of the booking when it wants one and has
+// none, and deletes it when it no longer wants one. The channels of deleted
+// bookings are left to Cleanup, as they are found no more.
+func (s *Service) Sync(ctx context.Context, bookingID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+
+	switch {
+	case errors.Is(err, bk.ErrBookingNotFound):
+	case err != nil:
+		s.logger.Error("failed to get booking to sync", "bookingId", bookingID, "err", err)
+	default:
+		s.sync(ctx, booking, time.Now())
+	}
+}
+
+// Cleanup deletes the voice channels of the bookings over, deleted or no
+// longer accepted, and returns how many it deleted.
+func (s *Service) Cleanup(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bookings, err := s.bookings.GetVoiceChannelBookings(ctx)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bookings with a voice channel: %w", err)
+	}
+
+	now := time.Now()
+	deleted := 0
+
+	for _, booking := range bookings {
+		if !booking.WantsVoiceChannel(now) && s.sync(ctx, booking, now) {
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// Wait blocks until all in-flight syncs are done.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown waits for the in-flight syncs until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for voice channel syncs: %w", ctx.Err())
+	}
+}
+
+// sync creates or deletes the voice channel of the booking, reporting
+// whether it deleted one.
+func (s *Service) sync(ctx context.Context, booking bk.Booking, now time.Time) bool {
+	switch {
+	case booking.WantsVoiceChannel(now) && len(booking.VoiceChannelID) == 0:
+		channel, err := s.client.CreateChannel(ctx, discord.Channel{
+			Type:     discord.ChannelGuildVoice,
+			ParentID: s.categoryID,
+			Name:     ChannelName(booking),
+		})
+
+		if err != nil {
+			s.logger.Error("failed to create voice channel", "bookingId", booking.ID, "err", err)
+			return false
+		}
+
+		s.onChange(ctx, booking.ID, channel.ID)
+	case !booking.WantsVoiceChannel(now) && len(booking.VoiceChannelID) != 0:
+		if err := s.client.DeleteChannel(ctx, booking.VoiceChannelID); err != nil {
+			s.logger.Error("failed to delete voice channel", "bookingId", booking.ID, "channelId", booking.VoiceChannelID, "err", err)
+			return false
+		}
+
+		s.onChange(ctx, booking.ID, "")
+
+		return true
+	}
+
+	return false
+}
+
+// ChannelName names the voice channel after the game and the owner of the
+// booking, cut to the length Discord accepts.
+func ChannelName(booking bk.Booking) string {
+	name := fmt.Sprintf("%s (%s)", booking.Game, booking.Username)
+
+	for utf8.RuneCountInString(name) > maxNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
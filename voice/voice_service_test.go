@@ -0,0 +1,161 @@
+package voice_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/discord"
+	"github.com/hanksha/tbz-booking-system-backend/voice"
+	mock_voice "github.com/hanksha/tbz-booking-system-backend/voice/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type bookingSource map[string]bk.Booking
+
+func (s bookingSource) GetBookingByID(ctx context.Context, id string) (bk.Booking, error) {
+	booking, ok := s[id]
+
+	if !ok || booking.DeletedAt != nil {
+		return bk.Booking{}, bk.ErrBookingNotFound
+	}
+
+	return booking, nil
+}
+
+func (s bookingSource) GetVoiceChannelBookings(ctx context.Context) ([]bk.Booking, error) {
+	bookings := []bk.Booking{}
+
+	for _, booking := range s {
+		if len(booking.VoiceChannelID) != 0 {
+			bookings = append(bookings, booking)
+		}
+	}
+
+	return bookings, nil
+}
+
+type channelRecorder map[string]string
+
+func (r channelRecorder) record(ctx context.Context, bookingID, channelID string) {
+	r[bookingID] = channelID
+}
+
+func TestSync(t *testing.T) {
+	dateTime := time.Now().Add(24 * time.Hour)
+
+	setup := func(t *testing.T, bookings bookingSource) (*voice.Service, *mock_voice.MockChannelClient, channelRecorder) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		client := mock_voice.NewMockChannelClient(ctrl)
+		recorder := channelRecorder{}
+		svc := voice.NewService(client, bookings, "category", voice.WithChannelHandler(recorder.record))
+
+		return svc, client, recorder
+	}
+
+	t.Run("creates the channel of an accepted remote booking", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Game: "Kill Team", Username: "alice", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().CreateChannel(gomock.Any(), discord.Channel{
+			Type:     discord.ChannelGuildVoice,
+			ParentID: "category",
+			Name:     "Kill Team (alice)",
+		}).Return(discord.Channel{ID: "voice-1"}, nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted, Booking: booking})
+		svc.Wait()
+
+		require.Equal(t, "voice-1", recorder["12"])
+	})
+
+	t.Run("keeps the existing channel", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: dateTime, VoiceChannelID: "voice-1"}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().CreateChannel(gomock.Any(), gomock.Any()).Times(0)
+		client.EXPECT().DeleteChannel(gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingModified, Booking: booking})
+		svc.Wait()
+
+		require.Empty(t, recorder)
+	})
+
+	t.Run("leaves the bookings played at the club alone", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Status: "accepted", DateTime: dateTime}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().CreateChannel(gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted, Booking: booking})
+		svc.Wait()
+
+		require.Empty(t, recorder)
+	})
+
+	t.Run("deletes the channel of a canceled booking", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Tags: []string{bk.RemoteTag}, Status: "canceled", DateTime: dateTime, VoiceChannelID: "voice-1"}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().DeleteChannel(gomock.Any(), "voice-1").Return(nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingCanceled, Booking: booking})
+		svc.Wait()
+
+		require.Contains(t, recorder, "12")
+		require.Empty(t, recorder["12"])
+	})
+
+	t.Run("keeps the channel when its deletion fails", func(t *testing.T) {
+		booking := bk.Booking{ID: "12", Status: "refused", DateTime: dateTime, VoiceChannelID: "voice-1"}
+		svc, client, recorder := setup(t, bookingSource{"12": booking})
+
+		client.EXPECT().DeleteChannel(gomock.Any(), "voice-1").Return(errors.New("403 Forbidden")).Times(1)
+
+		svc.Sync(context.Background(), "12")
+
+		require.Empty(t, recorder)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	deletedAt := now.Add(-time.Hour)
+	bookings := bookingSource{
+		"playing":  {ID: "playing", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: now.Add(-time.Hour), VoiceChannelID: "voice-1"},
+		"over":     {ID: "over", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: now.Add(-bk.TableDuration - time.Minute), VoiceChannelID: "voice-2"},
+		"deleted":  {ID: "deleted", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: now.Add(time.Hour), DeletedAt: &deletedAt, VoiceChannelID: "voice-3"},
+		"upcoming": {ID: "upcoming", Tags: []string{bk.RemoteTag}, Status: "accepted", DateTime: now.Add(time.Hour)},
+	}
+
+	client := mock_voice.NewMockChannelClient(ctrl)
+	recorder := channelRecorder{}
+	svc := voice.NewService(client, bookings, "category", voice.WithChannelHandler(recorder.record))
+
+	client.EXPECT().DeleteChannel(gomock.Any(), "voice-2").Return(nil).Times(1)
+	client.EXPECT().DeleteChannel(gomock.Any(), "voice-3").Return(nil).Times(1)
+
+	deleted, err := svc.Cleanup(context.Background())
+
+	require.Nil(t, err)
+	require.Equal(t, 2, deleted)
+	require.Equal(t, channelRecorder{"over": "", "deleted": ""}, recorder)
+}
+
+func TestChannelName(t *testing.T) {
+	require.Equal(t, "Kill Team (alice)", voice.ChannelName(bk.Booking{Game: "Kill Team", Username: "alice"}))
+
+	name := voice.ChannelName(bk.Booking{Game: strings.Repeat("é", 120), Username: "alice"})
+
+	require.Equal(t, strings.Repeat("é", 100), name)
+}
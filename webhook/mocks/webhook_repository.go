@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/hanksha/tbz-booking-system-backend/webhook (interfaces: WebhookRepository)
+//
+// Generated by this command:
+//
+//	mockgen . WebhookRepository
+//
+
+// Package mock_webhook is a generated GoMock package.
+package mock_webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	webhook "github.com/hanksha/tbz-booking-system-backend/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, limit)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveries(ctx, webhookID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveries), ctx, webhookID, limit)
+}
+
+// GetDeliveryByID mocks base method.
+func (m *MockWebhookRepository) GetDeliveryByID(ctx context.Context, id string) (webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryByID", ctx, id)
+	ret0, _ := ret[0].(webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryByID indicates an expected call of GetDeliveryByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveryByID), ctx, id)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockWebhookRepository) GetWebhookByID(ctx context.Context, id string) (webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", ctx, id)
+	ret0, _ := ret[0].(webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookByID), ctx, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhookRepository) GetWebhooks(ctx context.Context) ([]webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx)
+	ret0, _ := ret[0].([]webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhooks), ctx)
+}
+
+// InsertDelivery mocks base method.
+func (m *MockWebhookRepository) InsertDelivery(ctx context.Context, delivery webhook.Delivery) (webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDelivery", ctx, delivery)
+	ret0, _ := ret[0].(webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertDelivery indicates an expected call of InsertDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) InsertDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).InsertDelivery), ctx, delivery)
+}
+
+// InsertWebhook mocks base method.
+func (m *MockWebhookRepository) InsertWebhook(ctx context.Context, arg1 webhook.Webhook) (webhook.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", ctx, arg1)
+	ret0, _ := ret[0].(webhook.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) InsertWebhook(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).InsertWebhook), ctx, arg1)
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"slices"
+	"time"
+)
+
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url" binding:"required"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"` // empty means every event
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (w Webhook) Subscribes(eventType string) bool {
+	return w.Active && (len(w.Events) == 0 || slices.Contains(w.Events, eventType))
+}
+
+type Delivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhookId"`
+	Event      string    `json:"event"`
+	Payload    string    `json:"payload"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error"`
+	Success    bool      `json:"success"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
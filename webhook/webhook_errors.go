@@ -0,0 +1,9 @@
+package webhook
+
+import "errors"
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct{ conn *pgxpool.Pool }
+
+func NewRepository(conn *pgxpool.Pool) *Repository {
+	return &Repository{conn: conn}
+}
+
+func (r *Repository) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	sql := `
+		SELECT id, url, secret, COALESCE(events, '{}'), active, "createdAt"
+		FROM "game-table-booking".webhook
+		ORDER BY id;
+	`
+
+	rows, err := r.conn.Query(ctx, sql)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch webhooks: %w", err)
+	}
+
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+
+	for rows.Next() {
+		var webhook Webhook
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.Events,
+			&webhook.Active,
+			&webhook.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook row: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *Repository) GetWebhookByID(ctx context.Context, id string) (Webhook, error) {
+	sql := `
+		SELECT id, url, secret, COALESCE(events, '{}'), active, "createdAt"
+		FROM "game-table-booking".webhook
+		WHERE id=$1;
+	`
+
+	var webhook Webhook
+	err := r.conn.QueryRow(ctx, sql, id).Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.Active,
+		&webhook.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Webhook{}, ErrWebhookNotFound
+	}
+
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to fetch webhook with id %v: %w", id, err)
+	}
+
+	return webhook, nil
+}
+
+func (r *Repository) InsertWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	sql := `
+		INSERT INTO "game-table-booking".webhook(url, secret, events, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		webhook.URL,
+		webhook.Secret,
+		webhook.Events,
+		webhook.Active,
+	).Scan(&webhook.ID, &webhook.CreatedAt)
+
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, id string) error {
+	sql := `DELETE FROM "game-table-booking".webhook WHERE id=$1;`
+
+	tag, err := r.conn.Exec(ctx, sql, id)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook '%v': %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) InsertDelivery(ctx context.Context, delivery Delivery) (Delivery, error) {
+	sql := `
+		INSERT INTO "game-table-booking".webhook_delivery(
+		"webhookId", event, payload, attempt, "statusCode", error, success)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, "createdAt";
+	`
+
+	err := r.conn.QueryRow(ctx, sql,
+		delivery.WebhookID,
+		delivery.Event,
+		delivery.Payload,
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Success,
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to insert webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (r *Repository) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]Delivery, error) {
+	sql := `
+		SELECT id, "webhookId", event, payload, attempt, "statusCode", COALESCE(error, ''), success, "createdAt"
+		FROM "game-table-booking".webhook_delivery
+		WHERE "webhookId"=$1
+		ORDER BY id DESC
+		LIMIT $2;
+	`
+
+	rows, err := r.conn.Query(ctx, sql, webhookID, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch deliveries for webhook '%v': %w", webhookID, err)
+	}
+
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+
+	for rows.Next() {
+		var delivery Delivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery row: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) GetDeliveryByID(ctx context.Context, id string) (Delivery, error) {
+	sql := `
+		SELECT id, "webhookId", event, payload, attempt, "statusCode", COALESCE(error, ''), success, "createdAt"
+		FROM "game-table-booking".webhook_delivery
+		WHERE id=$1;
+	`
+
+	var delivery Delivery
+	err := r.conn.QueryRow(ctx, sql, id).Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.Event,
+		&delivery.Payload,
+		&delivery.Attempt,
+		&delivery.StatusCode,
+		&delivery.Error,
+		&delivery.Success,
+		&delivery.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to fetch webhook delivery with id %v: %w", id, err)
+	}
+
+	return delivery, nil
+}
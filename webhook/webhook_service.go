@@ -0,0 +1,332 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+)
+
+const (
+	SignatureHeader = "X-TBZ-Signature"
+	TimestampHeader = "X-TBZ-Timestamp"
+	EventHeader     = "X-TBZ-Event"
+)
+
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context) ([]Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (Webhook, error)
+	InsertWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	InsertDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+	GetDeliveries(ctx context.Context, webhookID string, limit int) ([]Delivery, error)
+	GetDeliveryByID(ctx context.Context, id string) (Delivery, error)
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, action, targetType, targetID string, payload any)
+}
+
+type Service struct {
+	repo        WebhookRepository
+	audit       AuditRecorder
+	client      *http.Client
+	maxAttempts int
+	retryDelay  time.Duration
+	logger      *slog.Logger
+	wg          sync.WaitGroup
+	// stopping is closed on shutdown to abandon pending retries
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+type ServiceOption func(*Service)
+
+// WithRetry sets how many times a delivery is attempted and the delay before
+// the first retry, doubled on each following attempt.
+func WithRetry(maxAttempts int, retryDelay time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxAttempts = maxAttempts
+		s.retryDelay = retryDelay
+	}
+}
+
+func WithAuditRecorder(recorder AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.audit = recorder
+	}
+}
+
+func NewService(repo WebhookRepository, client *http.Client, opts ...ServiceOption) *Service {
+	s := &Service{
+		repo:        repo,
+		client:      client,
+		maxAttempts: 5,
+		retryDelay:  5 * time.Second,
+		logger:      slog.Default().With("component", "webhook"),
+		stopping:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	webhooks, err := s.repo.GetWebhooks(ctx)
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, err
+}
+
+// CreateWebhook registers a new webhook, generating its secret when none is
+// provided. The secret is only ever returned here.
+func (s *Service) CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error) {
+	target, err := url.Parse(webhook.URL)
+
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || len(target.Host) == 0 {
+		return Webhook{}, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+
+	if len(webhook.Secret) == 0 {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return Webhook{}, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	webhook.Active = true
+
+	created, err := s.repo.InsertWebhook(ctx, webhook)
+
+	if err == nil {
+		s.record(ctx, "webhook.create", created.ID, map[string]any{"url": created.URL, "events": created.Events})
+	}
+
+	return created, err
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id string) error {
+	err := s.repo.DeleteWebhook(ctx, id)
+
+	if err == nil {
+		s.record(ctx, "webhook.delete", id, nil)
+	}
+
+	return err
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, webhookID string) ([]Delivery, error) {
+	if _, err := s.repo.GetWebhookByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDeliveries(ctx, webhookID, 100)
+}
+
+// Redeliver sends the payload of a past delivery once more and returns the
+// resulting delivery.
+func (s *Service) Redeliver(ctx context.Context, webhookID, deliveryID string) (Delivery, error) {
+	delivery, err := s.repo.GetDeliveryByID(ctx, deliveryID)
+
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	if delivery.WebhookID != webhookID {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+
+	webhook, err := s.repo.GetWebhookByID(ctx, webhookID)
+
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return s.attempt(ctx, webhook, delivery.Event, []byte(delivery.Payload), 1)
+}
+
+// Publish implements booking.EventPublisher. Deliveries happen in the
+// background so booking operations never wait on remote endpoints.
+func (s *Service) Publish(ctx context.Context, event bk.Event) {
+	ctx = context.WithoutCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		webhooks, err := s.repo.GetWebhooks(ctx)
+
+		if err != nil {
+			s.logger.Error("failed to get webhooks", "err", err)
+			return
+		}
+
+		payload, err := json.Marshal(event)
+
+		if err != nil {
+			s.logger.Error("failed to marshal webhook payload", "err", err)
+			return
+		}
+
+		for _, webhook := range webhooks {
+			if webhook.Subscribes(event.Type) {
+				s.wg.Add(1)
+				go func() {
+					defer s.wg.Done()
+					s.deliver(ctx, webhook, event.Type, payload)
+				}()
+			}
+		}
+	}()
+}
+
+// Wait blocks until all in-flight deliveries are done.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Shutdown abandons pending retries and waits for the in-flight deliveries
+// until ctx is done. Abandoned deliveries stay recorded as failed and can be
+// redelivered.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stopping) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for webhook deliveries: %w", ctx.Err())
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, webhook Webhook, event string, payload []byte) {
+	delay := s.retryDelay
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		delivery, err := s.attempt(ctx, webhook, event, payload, attempt)
+
+		if err != nil {
+			s.logger.Error("failed to record webhook delivery", "webhookId", webhook.ID, "err", err)
+		}
+
+		if delivery.Success {
+			return
+		}
+
+		if attempt == s.maxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		case <-s.stopping:
+			s.logger.Warn("webhook delivery abandoned on shutdown", "webhookId", webhook.ID, "event", event, "attempts", attempt)
+			return
+		}
+
+		delay *= 2
+	}
+
+	s.logger.Warn("webhook delivery failed", "webhookId", webhook.ID, "event", event, "attempts", s.maxAttempts)
+}
+
+func (s *Service) attempt(ctx context.Context, webhook Webhook, event string, payload []byte, attempt int) (Delivery, error) {
+	delivery := Delivery{
+		WebhookID: webhook.ID,
+		Event:     event,
+		Payload:   string(payload),
+		Attempt:   attempt,
+	}
+
+	statusCode, err := s.send(ctx, webhook, event, payload)
+
+	delivery.StatusCode = statusCode
+	delivery.Success = err == nil
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	saved, err := s.repo.InsertDelivery(ctx, delivery)
+
+	if err != nil {
+		return delivery, err
+	}
+
+	return saved, nil
+}
+
+func (s *Service) send(ctx context.Context, webhook Webhook, event string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", webhook.URL, bytes.NewReader(payload))
+
+	if err != nil {
+		return 0, fmt.Errorf("failed create new request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, timestamp, payload))
+
+	res, err := s.client.Do(req)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("request failed with status '%v'", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+func (s *Service) record(ctx context.Context, action, webhookID string, payload any) {
+	if s.audit != nil {
+		s.audit.Record(ctx, action, "webhook", webhookID, payload)
+	}
+}
+
+// Sign computes the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" that
+// receivers use to authenticate deliveries.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,169 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bk "github.com/hanksha/tbz-booking-system-backend/booking"
+	"github.com/hanksha/tbz-booking-system-backend/webhook"
+	wh_mocks "github.com/hanksha/tbz-booking-system-backend/webhook/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newService(t *testing.T) (*gomock.Controller, *wh_mocks.MockWebhookRepository, *webhook.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := wh_mocks.NewMockWebhookRepository(ctrl)
+	svc := webhook.NewService(repo, http.DefaultClient, webhook.WithRetry(3, time.Millisecond))
+
+	return ctrl, repo, svc
+}
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("generates secret", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		repo.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, wh webhook.Webhook) (webhook.Webhook, error) {
+			wh.ID = "1"
+			return wh, nil
+		}).Times(1)
+
+		created, err := svc.CreateWebhook(context.Background(), webhook.Webhook{URL: "https://example.com/hook"})
+
+		require.Nil(t, err)
+		require.Equal(t, "1", created.ID)
+		require.Len(t, created.Secret, 64)
+		require.True(t, created.Active)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		repo.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.CreateWebhook(context.Background(), webhook.Webhook{URL: "ftp://example.com"})
+
+		require.ErrorIs(t, err, webhook.ErrInvalidWebhook)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("signed delivery", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		var signature, timestamp, event string
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get(webhook.SignatureHeader)
+			timestamp = r.Header.Get(webhook.TimestampHeader)
+			event = r.Header.Get(webhook.EventHeader)
+			body, _ = io.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		hooks := []webhook.Webhook{
+			{ID: "1", URL: server.URL, Secret: "s3cret", Active: true},
+			{ID: "2", URL: server.URL, Secret: "other", Active: true, Events: []string{bk.EventBookingCanceled}},
+		}
+
+		repo.EXPECT().GetWebhooks(gomock.Any()).Return(hooks, nil).Times(1)
+		repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d webhook.Delivery) (webhook.Delivery, error) {
+			require.Equal(t, "1", d.WebhookID)
+			require.True(t, d.Success)
+			return d, nil
+		}).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingCreated, Booking: bk.Booking{ID: "42"}})
+		svc.Wait()
+
+		require.Equal(t, bk.EventBookingCreated, event)
+		require.Equal(t, "sha256="+webhook.Sign("s3cret", timestamp, body), signature)
+		require.Contains(t, string(body), `"id":"42"`)
+	})
+
+	t.Run("retries failures", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer server.Close()
+
+		repo.EXPECT().GetWebhooks(gomock.Any()).Return([]webhook.Webhook{{ID: "1", URL: server.URL, Active: true}}, nil).Times(1)
+		repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).Return(webhook.Delivery{}, nil).Times(2)
+		repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).Return(webhook.Delivery{Success: true}, nil).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted})
+		svc.Wait()
+
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		repo.EXPECT().GetWebhooks(gomock.Any()).Return(nil, errors.New("repo error")).Times(1)
+		repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted})
+		svc.Wait()
+	})
+
+	t.Run("shutdown abandons retries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := wh_mocks.NewMockWebhookRepository(ctrl)
+		svc := webhook.NewService(repo, http.DefaultClient, webhook.WithRetry(3, time.Hour))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		attempted := make(chan struct{})
+
+		repo.EXPECT().GetWebhooks(gomock.Any()).Return([]webhook.Webhook{{ID: "1", URL: server.URL, Active: true}}, nil).Times(1)
+		repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d webhook.Delivery) (webhook.Delivery, error) {
+			close(attempted)
+			return d, nil
+		}).Times(1)
+
+		svc.Publish(context.Background(), bk.Event{Type: bk.EventBookingAccepted})
+		<-attempted
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		require.Nil(t, svc.Shutdown(ctx))
+	})
+}
+
+func TestRedeliver(t *testing.T) {
+	t.Run("wrong webhook", func(t *testing.T) {
+		ctrl, repo, svc := newService(t)
+		defer ctrl.Finish()
+
+		repo.EXPECT().GetDeliveryByID(gomock.Any(), "7").Return(webhook.Delivery{ID: "7", WebhookID: "2"}, nil).Times(1)
+		repo.EXPECT().GetWebhookByID(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.Redeliver(context.Background(), "1", "7")
+
+		require.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+	})
+}